@@ -1,9 +1,12 @@
 package api
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/go-acme/lego/v4/acme"
 )
@@ -12,9 +15,14 @@ type AccountService service
 
 // New Creates a new account.
 func (a *AccountService) New(req acme.Account) (acme.ExtendedAccount, error) {
+	return a.NewContext(context.Background(), req)
+}
+
+// NewContext is like [AccountService.New] with a context.
+func (a *AccountService) NewContext(ctx context.Context, req acme.Account) (acme.ExtendedAccount, error) {
 	var account acme.Account
 
-	resp, err := a.core.post(a.core.GetDirectory().NewAccountURL, req, &account)
+	resp, err := a.core.post(ctx, a.core.GetDirectory().NewAccountURL, req, &account)
 	location := getLocation(resp)
 
 	if location != "" {
@@ -30,11 +38,31 @@ func (a *AccountService) New(req acme.Account) (acme.ExtendedAccount, error) {
 
 // NewEAB Creates a new account with an External Account Binding.
 func (a *AccountService) NewEAB(accMsg acme.Account, kid, hmacEncoded string) (acme.ExtendedAccount, error) {
+	return a.NewEABContext(context.Background(), accMsg, kid, hmacEncoded)
+}
+
+// NewEABContext is like [AccountService.NewEAB] with a context.
+func (a *AccountService) NewEABContext(ctx context.Context, accMsg acme.Account, kid, hmacEncoded string) (acme.ExtendedAccount, error) {
 	hmac, err := decodeEABHmac(hmacEncoded)
 	if err != nil {
 		return acme.ExtendedAccount{}, err
 	}
 
+	return a.newEAB(ctx, accMsg, kid, hmac)
+}
+
+// NewEABWithEncoding Creates a new account with an External Account Binding,
+// the HMAC key is decoded with the encoding (detected with EABEncodingAuto) and must be at least 32 bytes.
+func (a *AccountService) NewEABWithEncoding(ctx context.Context, accMsg acme.Account, kid, hmacEncoded string, encoding EABEncoding) (acme.ExtendedAccount, error) {
+	hmac, err := decodeEABHmacWithEncoding(hmacEncoded, encoding)
+	if err != nil {
+		return acme.ExtendedAccount{}, err
+	}
+
+	return a.newEAB(ctx, accMsg, kid, hmac)
+}
+
+func (a *AccountService) newEAB(ctx context.Context, accMsg acme.Account, kid string, hmac []byte) (acme.ExtendedAccount, error) {
 	eabJWS, err := a.core.signEABContent(a.core.GetDirectory().NewAccountURL, kid, hmac)
 	if err != nil {
 		return acme.ExtendedAccount{}, fmt.Errorf("acme: error signing eab content: %w", err)
@@ -42,18 +70,23 @@ func (a *AccountService) NewEAB(accMsg acme.Account, kid, hmacEncoded string) (a
 
 	accMsg.ExternalAccountBinding = eabJWS
 
-	return a.New(accMsg)
+	return a.NewContext(ctx, accMsg)
 }
 
 // Get Retrieves an account.
 func (a *AccountService) Get(accountURL string) (acme.Account, error) {
+	return a.GetContext(context.Background(), accountURL)
+}
+
+// GetContext is like [AccountService.Get] with a context.
+func (a *AccountService) GetContext(ctx context.Context, accountURL string) (acme.Account, error) {
 	if accountURL == "" {
 		return acme.Account{}, errors.New("account[get]: empty URL")
 	}
 
 	var account acme.Account
 
-	_, err := a.core.postAsGet(accountURL, &account)
+	_, err := a.core.postAsGet(ctx, accountURL, &account)
 	if err != nil {
 		return acme.Account{}, err
 	}
@@ -63,13 +96,18 @@ func (a *AccountService) Get(accountURL string) (acme.Account, error) {
 
 // Update Updates an account.
 func (a *AccountService) Update(accountURL string, req acme.Account) (acme.Account, error) {
+	return a.UpdateContext(context.Background(), accountURL, req)
+}
+
+// UpdateContext is like [AccountService.Update] with a context.
+func (a *AccountService) UpdateContext(ctx context.Context, accountURL string, req acme.Account) (acme.Account, error) {
 	if accountURL == "" {
 		return acme.Account{}, errors.New("account[update]: empty URL")
 	}
 
 	var account acme.Account
 
-	_, err := a.core.post(accountURL, req, &account)
+	_, err := a.core.post(ctx, accountURL, req, &account)
 	if err != nil {
 		return acme.Account{}, err
 	}
@@ -79,16 +117,37 @@ func (a *AccountService) Update(accountURL string, req acme.Account) (acme.Accou
 
 // Deactivate Deactivates an account.
 func (a *AccountService) Deactivate(accountURL string) error {
+	return a.DeactivateContext(context.Background(), accountURL)
+}
+
+// DeactivateContext is like [AccountService.Deactivate] with a context.
+func (a *AccountService) DeactivateContext(ctx context.Context, accountURL string) error {
 	if accountURL == "" {
 		return errors.New("account[deactivate]: empty URL")
 	}
 
 	req := acme.Account{Status: acme.StatusDeactivated}
-	_, err := a.core.post(accountURL, req, nil)
+	_, err := a.core.post(ctx, accountURL, req, nil)
 
 	return err
 }
 
+// EABEncoding the encoding of the EAB HMAC key.
+type EABEncoding string
+
+// The encodings of the EAB HMAC key.
+const (
+	// EABEncodingAuto detects the encoding: hexadecimal, base64url, or standard base64.
+	EABEncodingAuto      EABEncoding = "auto"
+	EABEncodingHex       EABEncoding = "hex"
+	EABEncodingBase64    EABEncoding = "base64"
+	EABEncodingBase64URL EABEncoding = "base64url"
+)
+
+// minEABHmacSize the minimal size of the HMAC key (HS256).
+// https://www.rfc-editor.org/rfc/rfc7518.html#section-3.2
+const minEABHmacSize = 32
+
 func decodeEABHmac(hmacEncoded string) ([]byte, error) {
 	hmac, errRaw := base64.RawURLEncoding.DecodeString(hmacEncoded)
 	if errRaw == nil {
@@ -102,3 +161,80 @@ func decodeEABHmac(hmacEncoded string) ([]byte, error) {
 
 	return nil, fmt.Errorf("acme: could not decode hmac key: %w", errors.Join(errRaw, err))
 }
+
+// decodeEABHmacWithEncoding decodes the HMAC key provided by the CA.
+// The CAs don't use the same encoding, so the encoding is detected when it is not defined (or EABEncodingAuto):
+// hexadecimal, base64url, or standard base64, with or without padding.
+// A key can be valid in several encodings (ex: only hexadecimal characters): the encoding must be defined for these keys.
+func decodeEABHmacWithEncoding(hmacEncoded string, encoding EABEncoding) ([]byte, error) {
+	value := strings.TrimSpace(hmacEncoded)
+	if value == "" {
+		return nil, errors.New("acme: empty hmac key")
+	}
+
+	var (
+		hmac     []byte
+		err      error
+		expected string
+	)
+
+	switch encoding {
+	case "", EABEncodingAuto:
+		expected = "hexadecimal, base64url, or base64"
+		hmac, err = detectHmacKey(value)
+
+	case EABEncodingHex:
+		expected = "hexadecimal"
+		hmac, err = hex.DecodeString(value)
+
+	case EABEncodingBase64:
+		expected = "base64"
+		hmac, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(value, "="))
+
+	case EABEncodingBase64URL:
+		expected = "base64url"
+		hmac, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+
+	default:
+		return nil, fmt.Errorf("acme: unsupported hmac key encoding: %q", encoding)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("acme: could not decode hmac key (expected %s encoding): %w", expected, err)
+	}
+
+	if len(hmac) < minEABHmacSize {
+		return nil, fmt.Errorf("acme: hmac key too short: %d bytes, at least %d bytes are required", len(hmac), minEABHmacSize)
+	}
+
+	return hmac, nil
+}
+
+func detectHmacKey(value string) ([]byte, error) {
+	if isHex(value) {
+		return hex.DecodeString(value)
+	}
+
+	encoding := base64.RawURLEncoding
+	if strings.ContainsAny(value, "+/") {
+		encoding = base64.RawStdEncoding
+	}
+
+	return encoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+// isHex reports whether the value looks like a hexadecimal encoded key.
+// A random base64 key containing only hexadecimal characters is very unlikely.
+func isHex(value string) bool {
+	if len(value)%2 != 0 {
+		return false
+	}
+
+	for _, c := range value {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -20,6 +20,10 @@ func Test_decodeEABHmac(t *testing.T) {
 			desc: "URLEncoding",
 			hmac: "nKTo9Hu8fpCqWPXx-25LVbZrJWxcHISsr4qHrRR0j5U=",
 		},
+		{
+			desc: "short key",
+			hmac: "c2hvcnQta2V5",
+		},
 	}
 
 	for _, test := range testCases {
@@ -33,3 +37,189 @@ func Test_decodeEABHmac(t *testing.T) {
 		})
 	}
 }
+
+func Test_decodeEABHmacWithEncoding_encodings(t *testing.T) {
+	expected := []byte{
+		0xc8, 0xc9, 0xca, 0xcb, 0xcc, 0xcd, 0xce, 0xcf, 0xd0, 0xd1, 0xd2, 0xd3, 0xd4, 0xd5, 0xd6, 0xd7,
+		0xd8, 0xd9, 0xda, 0xdb, 0xdc, 0xdd, 0xde, 0xdf, 0xe0, 0xe1, 0xe2, 0xe3, 0xe4, 0xe5, 0xe6, 0xe7,
+	}
+
+	testCases := []struct {
+		desc     string
+		hmac     string
+		encoding EABEncoding
+	}{
+		{
+			desc: "hexadecimal",
+			hmac: "c8c9cacbcccdcecfd0d1d2d3d4d5d6d7d8d9dadbdcdddedfe0e1e2e3e4e5e6e7",
+		},
+		{
+			desc: "hexadecimal (uppercase)",
+			hmac: "C8C9CACBCCCDCECFD0D1D2D3D4D5D6D7D8D9DADBDCDDDEDFE0E1E2E3E4E5E6E7",
+		},
+		{
+			desc: "StdEncoding",
+			hmac: "yMnKy8zNzs/Q0dLT1NXW19jZ2tvc3d7f4OHi4+Tl5uc=",
+		},
+		{
+			desc: "RawStdEncoding",
+			hmac: "yMnKy8zNzs/Q0dLT1NXW19jZ2tvc3d7f4OHi4+Tl5uc",
+		},
+		{
+			desc: "URLEncoding",
+			hmac: "yMnKy8zNzs_Q0dLT1NXW19jZ2tvc3d7f4OHi4-Tl5uc=",
+		},
+		{
+			desc: "RawURLEncoding",
+			hmac: "yMnKy8zNzs_Q0dLT1NXW19jZ2tvc3d7f4OHi4-Tl5uc",
+		},
+		{
+			desc: "surrounding whitespaces",
+			hmac: " yMnKy8zNzs_Q0dLT1NXW19jZ2tvc3d7f4OHi4-Tl5uc\n",
+		},
+		{
+			desc:     "explicit auto",
+			hmac:     "yMnKy8zNzs/Q0dLT1NXW19jZ2tvc3d7f4OHi4+Tl5uc=",
+			encoding: EABEncodingAuto,
+		},
+		{
+			desc:     "explicit hexadecimal",
+			hmac:     "c8c9cacbcccdcecfd0d1d2d3d4d5d6d7d8d9dadbdcdddedfe0e1e2e3e4e5e6e7",
+			encoding: EABEncodingHex,
+		},
+		{
+			desc:     "explicit base64",
+			hmac:     "yMnKy8zNzs/Q0dLT1NXW19jZ2tvc3d7f4OHi4+Tl5uc=",
+			encoding: EABEncodingBase64,
+		},
+		{
+			desc:     "explicit base64 without padding",
+			hmac:     "yMnKy8zNzs/Q0dLT1NXW19jZ2tvc3d7f4OHi4+Tl5uc",
+			encoding: EABEncodingBase64,
+		},
+		{
+			desc:     "explicit base64url",
+			hmac:     "yMnKy8zNzs_Q0dLT1NXW19jZ2tvc3d7f4OHi4-Tl5uc=",
+			encoding: EABEncodingBase64URL,
+		},
+		{
+			desc:     "explicit base64url without padding",
+			hmac:     "yMnKy8zNzs_Q0dLT1NXW19jZ2tvc3d7f4OHi4-Tl5uc",
+			encoding: EABEncodingBase64URL,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := decodeEABHmacWithEncoding(test.hmac, test.encoding)
+			require.NoError(t, err)
+
+			assert.Equal(t, expected, v)
+		})
+	}
+}
+
+func Test_decodeEABHmacWithEncoding_ambiguous(t *testing.T) {
+	// Only hexadecimal characters: the key is also a valid base64url key.
+	hmac := "c8c9cacbcccdcecfd0d1d2d3d4d5d6d7d8d9dadbdcdddedfe0e1e2e3e4e5e6e7"
+
+	testCases := []struct {
+		desc     string
+		encoding EABEncoding
+		expected []byte
+	}{
+		{
+			desc:     "detected as hexadecimal",
+			encoding: EABEncodingAuto,
+			expected: []byte{
+				0xc8, 0xc9, 0xca, 0xcb, 0xcc, 0xcd, 0xce, 0xcf, 0xd0, 0xd1, 0xd2, 0xd3, 0xd4, 0xd5, 0xd6, 0xd7,
+				0xd8, 0xd9, 0xda, 0xdb, 0xdc, 0xdd, 0xde, 0xdf, 0xe0, 0xe1, 0xe2, 0xe3, 0xe4, 0xe5, 0xe6, 0xe7,
+			},
+		},
+		{
+			desc:     "base64url override",
+			encoding: EABEncodingBase64URL,
+			expected: []byte{
+				0x73, 0xc7, 0x3d, 0x71, 0xa7, 0x1b, 0x71, 0xc7, 0x1d, 0x71, 0xe7, 0x1f, 0x77, 0x47, 0x75, 0x77,
+				0x67, 0x77, 0x77, 0x87, 0x79, 0x77, 0xa7, 0x7b, 0x77, 0xc7, 0x7d, 0x75, 0xa7, 0x5b, 0x75, 0xc7,
+				0x5d, 0x75, 0xe7, 0x5f, 0x7b, 0x47, 0xb5, 0x7b, 0x67, 0xb7, 0x7b, 0x87, 0xb9, 0x7b, 0xa7, 0xbb,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := decodeEABHmacWithEncoding(hmac, test.encoding)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, v)
+		})
+	}
+}
+
+func Test_decodeEABHmacWithEncoding_errors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		hmac     string
+		encoding EABEncoding
+		expected string
+	}{
+		{
+			desc:     "empty",
+			hmac:     "  ",
+			expected: "acme: empty hmac key",
+		},
+		{
+			desc:     "mixed encodings",
+			hmac:     "yMnKy8zNzs/Q0dLT1NXW19jZ2tvc3d7f4OHi4-Tl5uc",
+			expected: "acme: could not decode hmac key (expected hexadecimal, base64url, or base64 encoding): illegal base64 data at input byte 37",
+		},
+		{
+			desc:     "invalid characters",
+			hmac:     "not a key!",
+			expected: "acme: could not decode hmac key (expected hexadecimal, base64url, or base64 encoding): illegal base64 data at input byte 3",
+		},
+		{
+			desc:     "too short",
+			hmac:     "c8c9cacbcccdcecf",
+			expected: "acme: hmac key too short: 8 bytes, at least 32 bytes are required",
+		},
+		{
+			desc:     "not hexadecimal",
+			hmac:     "yMnKy8zNzs_Q0dLT1NXW19jZ2tvc3d7f4OHi4-Tl5uc",
+			encoding: EABEncodingHex,
+			expected: "acme: could not decode hmac key (expected hexadecimal encoding): encoding/hex: invalid byte: U+0079 'y'",
+		},
+		{
+			desc:     "not base64",
+			hmac:     "yMnKy8zNzs_Q0dLT1NXW19jZ2tvc3d7f4OHi4-Tl5uc",
+			encoding: EABEncodingBase64,
+			expected: "acme: could not decode hmac key (expected base64 encoding): illegal base64 data at input byte 10",
+		},
+		{
+			desc:     "not base64url",
+			hmac:     "yMnKy8zNzs/Q0dLT1NXW19jZ2tvc3d7f4OHi4+Tl5uc=",
+			encoding: EABEncodingBase64URL,
+			expected: "acme: could not decode hmac key (expected base64url encoding): illegal base64 data at input byte 10",
+		},
+		{
+			desc:     "unsupported encoding",
+			hmac:     "yMnKy8zNzs_Q0dLT1NXW19jZ2tvc3d7f4OHi4-Tl5uc",
+			encoding: "base32",
+			expected: `acme: unsupported hmac key encoding: "base32"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := decodeEABHmacWithEncoding(test.hmac, test.encoding)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
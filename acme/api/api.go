@@ -16,6 +16,9 @@ import (
 	"github.com/go-acme/lego/v4/acme/api/internal/secure"
 	"github.com/go-acme/lego/v4/acme/api/internal/sender"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/metrics"
+	"github.com/go-acme/lego/v4/tracing"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Core ACME/LE core API.
@@ -26,19 +29,39 @@ type Core struct {
 	directory    acme.Directory
 	HTTPClient   *http.Client
 
+	// Logger the logger of the client.
+	// If nil, the global logger (log.Logger) is used.
+	Logger log.StdLogger
+
+	// StructuredLogger the structured logger of the client.
+	// If not nil, it's used instead of Logger.
+	StructuredLogger log.StructuredLogger
+
+	// MetricsRecorder records the metrics of the ACME operations.
+	// If nil, the metrics are not recorded.
+	MetricsRecorder metrics.Recorder
+
+	// TracerProvider provides the tracer of the ACME operations.
+	// If nil, the operations are not traced.
+	TracerProvider trace.TracerProvider
+
 	common         service // Reuse a single struct instead of allocating one for each service on the heap.
 	Accounts       *AccountService
 	Authorizations *AuthorizationService
 	Certificates   *CertificateService
 	Challenges     *ChallengeService
+	Delegations    *DelegationService
 	Orders         *OrderService
 }
 
 // New Creates a new Core.
+// The HTTP client is copied: its transport is wrapped without modifying the HTTP client of the caller.
 func New(httpClient *http.Client, userAgent, caDirURL, kid string, privateKey crypto.PrivateKey) (*Core, error) {
-	doer := sender.NewDoer(httpClient, userAgent)
+	client := *httpClient
+
+	doer := sender.NewDoer(&client, userAgent)
 
-	dir, err := getDirectory(doer, caDirURL)
+	dir, err := getDirectory(context.Background(), doer, caDirURL)
 	if err != nil {
 		return nil, err
 	}
@@ -47,13 +70,14 @@ func New(httpClient *http.Client, userAgent, caDirURL, kid string, privateKey cr
 
 	jws := secure.NewJWS(privateKey, kid, nonceManager)
 
-	c := &Core{doer: doer, nonceManager: nonceManager, jws: jws, directory: dir, HTTPClient: httpClient}
+	c := &Core{doer: doer, nonceManager: nonceManager, jws: jws, directory: dir, HTTPClient: &client}
 
 	c.common.core = c
 	c.Accounts = (*AccountService)(&c.common)
 	c.Authorizations = (*AuthorizationService)(&c.common)
 	c.Certificates = (*CertificateService)(&c.common)
 	c.Challenges = (*ChallengeService)(&c.common)
+	c.Delegations = (*DelegationService)(&c.common)
 	c.Orders = (*OrderService)(&c.common)
 
 	return c, nil
@@ -61,31 +85,34 @@ func New(httpClient *http.Client, userAgent, caDirURL, kid string, privateKey cr
 
 // post performs an HTTP POST request and parses the response body as JSON,
 // into the provided respBody object.
-func (a *Core) post(uri string, reqBody, response any) (*http.Response, error) {
+func (a *Core) post(ctx context.Context, uri string, reqBody, response any) (*http.Response, error) {
 	content, err := json.Marshal(reqBody)
 	if err != nil {
 		return nil, errors.New("failed to marshal message")
 	}
 
-	return a.retrievablePost(uri, content, response)
+	return a.retrievablePost(ctx, uri, content, response)
 }
 
 // postAsGet performs an HTTP POST ("POST-as-GET") request.
 // https://www.rfc-editor.org/rfc/rfc8555.html#section-6.3
-func (a *Core) postAsGet(uri string, response any) (*http.Response, error) {
-	return a.retrievablePost(uri, []byte{}, response)
+func (a *Core) postAsGet(ctx context.Context, uri string, response any) (*http.Response, error) {
+	return a.retrievablePost(ctx, uri, []byte{}, response)
 }
 
-func (a *Core) retrievablePost(uri string, content []byte, response any) (*http.Response, error) {
-	ctx := context.Background()
+func (a *Core) retrievablePost(ctx context.Context, uri string, content []byte, response any) (*http.Response, error) {
+	return a.retrievablePostWithJWS(ctx, a.jws, uri, content, response)
+}
 
+// retrievablePostWithJWS is like retrievablePost but the content is signed with the given JWS.
+func (a *Core) retrievablePostWithJWS(ctx context.Context, jws *secure.JWS, uri string, content []byte, response any) (*http.Response, error) {
 	// during tests, allow to support ~90% of bad nonce with a minimum of attempts.
 	bo := backoff.NewExponentialBackOff()
 	bo.InitialInterval = 200 * time.Millisecond
 	bo.MaxInterval = 5 * time.Second
 
 	operation := func() (*http.Response, error) {
-		resp, err := a.signedPost(uri, content, response)
+		resp, err := a.signedPost(ctx, jws, uri, content, response)
 		if err != nil {
 			// Retry if the nonce was invalidated
 			var e *acme.NonceError
@@ -100,7 +127,7 @@ func (a *Core) retrievablePost(uri string, content []byte, response any) (*http.
 	}
 
 	notify := func(err error, duration time.Duration) {
-		log.Infof("retry due to: %v", err)
+		a.Log().Infof("retry due to: %v", err)
 	}
 
 	return backoff.Retry(ctx, operation,
@@ -109,15 +136,15 @@ func (a *Core) retrievablePost(uri string, content []byte, response any) (*http.
 		backoff.WithNotify(notify))
 }
 
-func (a *Core) signedPost(uri string, content []byte, response any) (*http.Response, error) {
-	signedContent, err := a.jws.SignContent(uri, content)
+func (a *Core) signedPost(ctx context.Context, jws *secure.JWS, uri string, content []byte, response any) (*http.Response, error) {
+	signedContent, err := jws.SignContent(ctx, uri, content)
 	if err != nil {
 		return nil, fmt.Errorf("failed to post JWS message: failed to sign content: %w", err)
 	}
 
 	signedBody := bytes.NewBufferString(signedContent.FullSerialize())
 
-	resp, err := a.doer.Post(uri, signedBody, "application/jose+json", response)
+	resp, err := a.doer.Post(ctx, uri, signedBody, "application/jose+json", response)
 
 	// nonceErr is ignored to keep the root error.
 	nonce, nonceErr := nonces.GetFromResponse(resp)
@@ -142,13 +169,49 @@ func (a *Core) GetKeyAuthorization(token string) (string, error) {
 	return a.jws.GetKeyAuthorization(token)
 }
 
+// Log returns the printer of the log entries of the client.
+func (a *Core) Log() log.Printer {
+	if a == nil {
+		return log.Printer{}
+	}
+
+	if a.StructuredLogger != nil {
+		return log.WithStructured(a.StructuredLogger)
+	}
+
+	return log.With(a.Logger)
+}
+
+// Metrics returns the recorder of the metrics of the client.
+func (a *Core) Metrics() metrics.Recorder {
+	if a == nil {
+		return metrics.OrNoop(nil)
+	}
+
+	return metrics.OrNoop(a.MetricsRecorder)
+}
+
+// Tracer returns the tracer of the client.
+func (a *Core) Tracer() trace.Tracer {
+	if a == nil {
+		return tracing.Tracer(nil)
+	}
+
+	return tracing.Tracer(a.TracerProvider)
+}
+
+// GetAccountURL Gets the URL of the account (the key identifier), empty if the account is not registered.
+func (a *Core) GetAccountURL() string {
+	return a.jws.GetKid()
+}
+
 func (a *Core) GetDirectory() acme.Directory {
 	return a.directory
 }
 
-func getDirectory(do *sender.Doer, caDirURL string) (acme.Directory, error) {
+func getDirectory(ctx context.Context, do *sender.Doer, caDirURL string) (acme.Directory, error) {
 	var dir acme.Directory
-	if _, err := do.Get(caDirURL, &dir); err != nil {
+	if _, err := do.Get(ctx, caDirURL, &dir); err != nil {
 		return dir, fmt.Errorf("get directory at '%s': %w", caDirURL, err)
 	}
 
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 
 	"github.com/go-acme/lego/v4/acme"
@@ -10,13 +11,18 @@ type AuthorizationService service
 
 // Get Gets an authorization.
 func (c *AuthorizationService) Get(authzURL string) (acme.Authorization, error) {
+	return c.GetContext(context.Background(), authzURL)
+}
+
+// GetContext is like [AuthorizationService.Get] with a context.
+func (c *AuthorizationService) GetContext(ctx context.Context, authzURL string) (acme.Authorization, error) {
 	if authzURL == "" {
 		return acme.Authorization{}, errors.New("authorization[get]: empty URL")
 	}
 
 	var authz acme.Authorization
 
-	_, err := c.core.postAsGet(authzURL, &authz)
+	_, err := c.core.postAsGet(ctx, authzURL, &authz)
 	if err != nil {
 		return acme.Authorization{}, err
 	}
@@ -26,13 +32,18 @@ func (c *AuthorizationService) Get(authzURL string) (acme.Authorization, error)
 
 // Deactivate Deactivates an authorization.
 func (c *AuthorizationService) Deactivate(authzURL string) error {
+	return c.DeactivateContext(context.Background(), authzURL)
+}
+
+// DeactivateContext is like [AuthorizationService.Deactivate] with a context.
+func (c *AuthorizationService) DeactivateContext(ctx context.Context, authzURL string) error {
 	if authzURL == "" {
 		return errors.New("authorization[deactivate]: empty URL")
 	}
 
 	var disabledAuth acme.Authorization
 
-	_, err := c.core.post(authzURL, acme.Authorization{Status: acme.StatusDeactivated}, &disabledAuth)
+	_, err := c.core.post(ctx, authzURL, acme.Authorization{Status: acme.StatusDeactivated}, &disabledAuth)
 
 	return err
 }
@@ -2,12 +2,16 @@ package api
 
 import (
 	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"io"
 	"net/http"
 
 	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api/internal/secure"
 )
 
 // maxBodySize is the maximum size of body that we will read.
@@ -18,7 +22,12 @@ type CertificateService service
 // Get Returns the certificate and the issuer certificate.
 // 'bundle' is only applied if the issuer is provided by the 'up' link.
 func (c *CertificateService) Get(certURL string, bundle bool) ([]byte, []byte, error) {
-	cert, _, err := c.get(certURL, bundle)
+	return c.GetContext(context.Background(), certURL, bundle)
+}
+
+// GetContext is like [CertificateService.Get] with a context.
+func (c *CertificateService) GetContext(ctx context.Context, certURL string, bundle bool) ([]byte, []byte, error) {
+	cert, _, err := c.get(ctx, certURL, bundle)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -29,7 +38,12 @@ func (c *CertificateService) Get(certURL string, bundle bool) ([]byte, []byte, e
 // GetAll the certificates and the alternate certificates.
 // bundle' is only applied if the issuer is provided by the 'up' link.
 func (c *CertificateService) GetAll(certURL string, bundle bool) (map[string]*acme.RawCertificate, error) {
-	cert, headers, err := c.get(certURL, bundle)
+	return c.GetAllContext(context.Background(), certURL, bundle)
+}
+
+// GetAllContext is like [CertificateService.GetAll] with a context.
+func (c *CertificateService) GetAllContext(ctx context.Context, certURL string, bundle bool) (map[string]*acme.RawCertificate, error) {
+	cert, headers, err := c.get(ctx, certURL, bundle)
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +55,7 @@ func (c *CertificateService) GetAll(certURL string, bundle bool) (map[string]*ac
 	alts := getLinks(headers, "alternate")
 
 	for _, alt := range alts {
-		altCert, _, err := c.get(alt, bundle)
+		altCert, _, err := c.get(ctx, alt, bundle)
 		if err != nil {
 			return nil, err
 		}
@@ -52,19 +66,76 @@ func (c *CertificateService) GetAll(certURL string, bundle bool) (map[string]*ac
 	return certs, nil
 }
 
+// Walk calls fn with the certificate and then with each alternate certificate, until fn returns true.
+// Contrary to GetAll, the certificates are fetched one by one and are not kept in memory.
+// 'bundle' is only applied if the issuer is provided by the 'up' link.
+func (c *CertificateService) Walk(ctx context.Context, certURL string, bundle bool, fn func(link string, cert *acme.RawCertificate) (bool, error)) error {
+	cert, headers, err := c.get(ctx, certURL, bundle)
+	if err != nil {
+		return err
+	}
+
+	stop, err := fn(certURL, cert)
+	if err != nil || stop {
+		return err
+	}
+
+	// URLs of "alternate" link relation
+	// - https://www.rfc-editor.org/rfc/rfc8555.html#section-7.4.2
+	for _, alt := range getLinks(headers, "alternate") {
+		altCert, _, err := c.get(ctx, alt, bundle)
+		if err != nil {
+			return err
+		}
+
+		stop, err = fn(alt, altCert)
+		if err != nil || stop {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Revoke Revokes a certificate.
 func (c *CertificateService) Revoke(req acme.RevokeCertMessage) error {
-	_, err := c.core.post(c.core.GetDirectory().RevokeCertURL, req, nil)
+	return c.RevokeContext(context.Background(), req)
+}
+
+// RevokeContext is like [CertificateService.Revoke] with a context.
+func (c *CertificateService) RevokeContext(ctx context.Context, req acme.RevokeCertMessage) error {
+	_, err := c.core.post(ctx, c.core.GetDirectory().RevokeCertURL, req, nil)
+	return err
+}
+
+// RevokeWithKey Revokes a certificate.
+// The request is signed with the private key of the certificate instead of the account key.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.6
+func (c *CertificateService) RevokeWithKey(req acme.RevokeCertMessage, privateKey crypto.PrivateKey) error {
+	return c.RevokeWithKeyContext(context.Background(), req, privateKey)
+}
+
+// RevokeWithKeyContext is like [CertificateService.RevokeWithKey] with a context.
+func (c *CertificateService) RevokeWithKeyContext(ctx context.Context, req acme.RevokeCertMessage, privateKey crypto.PrivateKey) error {
+	content, err := json.Marshal(req)
+	if err != nil {
+		return errors.New("failed to marshal message")
+	}
+
+	jws := secure.NewJWS(privateKey, "", c.core.nonceManager)
+
+	_, err = c.core.retrievablePostWithJWS(ctx, jws, c.core.GetDirectory().RevokeCertURL, content, nil)
+
 	return err
 }
 
 // get Returns the certificate and the "up" link.
-func (c *CertificateService) get(certURL string, bundle bool) (*acme.RawCertificate, http.Header, error) {
+func (c *CertificateService) get(ctx context.Context, certURL string, bundle bool) (*acme.RawCertificate, http.Header, error) {
 	if certURL == "" {
 		return nil, nil, errors.New("certificate[get]: empty URL")
 	}
 
-	resp, err := c.core.postAsGet(certURL, nil)
+	resp, err := c.core.postAsGet(ctx, certURL, nil)
 	if err != nil {
 		return nil, nil, err
 	}
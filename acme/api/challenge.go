@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 
 	"github.com/go-acme/lego/v4/acme"
@@ -10,15 +11,30 @@ type ChallengeService service
 
 // New Creates a challenge.
 func (c *ChallengeService) New(chlgURL string) (acme.ExtendedChallenge, error) {
+	return c.NewContext(context.Background(), chlgURL)
+}
+
+// NewContext is like [ChallengeService.New] with a context.
+func (c *ChallengeService) NewContext(ctx context.Context, chlgURL string) (acme.ExtendedChallenge, error) {
 	if chlgURL == "" {
 		return acme.ExtendedChallenge{}, errors.New("challenge[new]: empty URL")
 	}
 
 	// Challenge initiation is done by sending a JWS payload containing the trivial JSON object `{}`.
 	// We use an empty struct instance as the postJSON payload here to achieve this result.
+	return c.Respond(ctx, chlgURL, struct{}{})
+}
+
+// Respond Responds to a challenge with a specific payload.
+// Some challenges (ex: device-attest-01) require a payload instead of the trivial JSON object `{}`.
+func (c *ChallengeService) Respond(ctx context.Context, chlgURL string, payload any) (acme.ExtendedChallenge, error) {
+	if chlgURL == "" {
+		return acme.ExtendedChallenge{}, errors.New("challenge[respond]: empty URL")
+	}
+
 	var chlng acme.ExtendedChallenge
 
-	resp, err := c.core.post(chlgURL, struct{}{}, &chlng)
+	resp, err := c.core.post(ctx, chlgURL, payload, &chlng)
 	if err != nil {
 		return acme.ExtendedChallenge{}, err
 	}
@@ -31,13 +47,18 @@ func (c *ChallengeService) New(chlgURL string) (acme.ExtendedChallenge, error) {
 
 // Get Gets a challenge.
 func (c *ChallengeService) Get(chlgURL string) (acme.ExtendedChallenge, error) {
+	return c.GetContext(context.Background(), chlgURL)
+}
+
+// GetContext is like [ChallengeService.Get] with a context.
+func (c *ChallengeService) GetContext(ctx context.Context, chlgURL string) (acme.ExtendedChallenge, error) {
 	if chlgURL == "" {
 		return acme.ExtendedChallenge{}, errors.New("challenge[get]: empty URL")
 	}
 
 	var chlng acme.ExtendedChallenge
 
-	resp, err := c.core.postAsGet(chlgURL, &chlng)
+	resp, err := c.core.postAsGet(ctx, chlgURL, &chlng)
 	if err != nil {
 		return acme.ExtendedChallenge{}, err
 	}
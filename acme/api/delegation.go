@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-acme/lego/v4/acme"
+)
+
+type DelegationService service
+
+// List Gets the URLs of the delegations configured for an account.
+// The delegations URL is the field `delegations` of the account.
+// - https://www.rfc-editor.org/rfc/rfc9115.html#section-2.3.1.1
+func (d *DelegationService) List(ctx context.Context, delegationsURL string) ([]string, error) {
+	if delegationsURL == "" {
+		return nil, errors.New("delegation[list]: empty URL")
+	}
+
+	var list acme.DelegationsList
+
+	_, err := d.core.postAsGet(ctx, delegationsURL, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Delegations, nil
+}
+
+// Get Gets a delegation.
+func (d *DelegationService) Get(ctx context.Context, delegationURL string) (acme.Delegation, error) {
+	if delegationURL == "" {
+		return acme.Delegation{}, errors.New("delegation[get]: empty URL")
+	}
+
+	var delegation acme.Delegation
+
+	_, err := d.core.postAsGet(ctx, delegationURL, &delegation)
+	if err != nil {
+		return acme.Delegation{}, err
+	}
+
+	if delegation.CSRTemplate == nil {
+		return acme.Delegation{}, errors.New("delegation[get]: missing CSR template")
+	}
+
+	return delegation, nil
+}
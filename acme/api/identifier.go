@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"net"
 	"slices"
+	"strings"
 
 	"github.com/go-acme/lego/v4/acme"
 )
@@ -18,10 +19,14 @@ func createIdentifiers(domains []string) []acme.Identifier {
 			continue
 		}
 
-		ident := acme.Identifier{Value: domain, Type: "dns"}
+		ident := acme.Identifier{Value: domain, Type: acme.IdentifierDNS}
 
-		if net.ParseIP(domain) != nil {
-			ident.Type = "ip"
+		switch {
+		case net.ParseIP(domain) != nil:
+			ident.Type = acme.IdentifierIP
+
+		case strings.Contains(domain, "@"):
+			ident.Type = acme.IdentifierEmail
 		}
 
 		identifiers = append(identifiers, ident)
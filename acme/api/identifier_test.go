@@ -109,3 +109,17 @@ func Test_compareIdentifiers(t *testing.T) {
 		})
 	}
 }
+
+func Test_createIdentifiers(t *testing.T) {
+	identifiers := createIdentifiers([]string{"example.com", "*.example.com", "192.0.2.1", "2001:db8::1", "foo@example.com", "example.com"})
+
+	expected := []acme.Identifier{
+		{Type: acme.IdentifierDNS, Value: "example.com"},
+		{Type: acme.IdentifierDNS, Value: "*.example.com"},
+		{Type: acme.IdentifierIP, Value: "192.0.2.1"},
+		{Type: acme.IdentifierIP, Value: "2001:db8::1"},
+		{Type: acme.IdentifierEmail, Value: "foo@example.com"},
+	}
+
+	assert.Equal(t, expected, identifiers)
+}
@@ -1,12 +1,14 @@
 package nonces
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"sync"
 
 	"github.com/go-acme/lego/v4/acme/api/internal/sender"
+	"github.com/go-acme/lego/v4/internal/faults"
 )
 
 // Manager Manages nonces.
@@ -49,17 +51,22 @@ func (n *Manager) Push(nonce string) {
 	n.nonces = append(n.nonces, nonce)
 }
 
-// Nonce implement jose.NonceSource.
-func (n *Manager) Nonce() (string, error) {
+// Nonce Pops a nonce, or gets a new one from the server.
+func (n *Manager) Nonce(ctx context.Context) (string, error) {
 	if nonce, ok := n.Pop(); ok {
 		return nonce, nil
 	}
 
-	return n.getNonce()
+	return n.getNonce(ctx)
 }
 
-func (n *Manager) getNonce() (string, error) {
-	resp, err := n.do.Head(n.nonceURL)
+// Source returns a jose.NonceSource bound to the context.
+func (n *Manager) Source(ctx context.Context) *Source {
+	return &Source{ctx: ctx, manager: n}
+}
+
+func (n *Manager) getNonce(ctx context.Context) (string, error) {
+	resp, err := n.do.Head(ctx, n.nonceURL)
 	if err != nil {
 		return "", fmt.Errorf("failed to get nonce from HTTP HEAD: %w", err)
 	}
@@ -67,6 +74,22 @@ func (n *Manager) getNonce() (string, error) {
 	return GetFromResponse(resp)
 }
 
+// Source implements jose.NonceSource.
+type Source struct {
+	ctx     context.Context
+	manager *Manager
+}
+
+// Nonce implements jose.NonceSource.
+func (s *Source) Nonce() (string, error) {
+	nonce, err := s.manager.Nonce(s.ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return faults.Nonce(nonce), nil
+}
+
 // GetFromResponse Extracts a nonce from an HTTP response.
 func GetFromResponse(resp *http.Response) (string, error) {
 	if resp == nil {
@@ -32,7 +32,7 @@ func TestNotHoldingLockWhileMakingHTTPRequests(t *testing.T) {
 	resultCh := make(chan bool)
 
 	go func() {
-		_, errN := manager.Nonce()
+		_, errN := manager.Nonce(t.Context())
 		if errN != nil {
 			t.Log(errN)
 		}
@@ -40,7 +40,7 @@ func TestNotHoldingLockWhileMakingHTTPRequests(t *testing.T) {
 		ch <- true
 	}()
 	go func() {
-		_, errN := manager.Nonce()
+		_, errN := manager.Nonce(t.Context())
 		if errN != nil {
 			t.Log(errN)
 		}
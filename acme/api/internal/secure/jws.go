@@ -1,13 +1,15 @@
 package secure
 
 import (
+	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rsa"
-	"encoding/base64"
 	"fmt"
 
+	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api/internal/nonces"
 	jose "github.com/go-jose/go-jose/v4"
 )
@@ -33,8 +35,13 @@ func (j *JWS) SetKid(kid string) {
 	j.kid = kid
 }
 
+// GetKid Gets the key identifier.
+func (j *JWS) GetKid() string {
+	return j.kid
+}
+
 // SignContent Signs a content with the JWS.
-func (j *JWS) SignContent(url string, content []byte) (*jose.JSONWebSignature, error) {
+func (j *JWS) SignContent(ctx context.Context, url string, content []byte) (*jose.JSONWebSignature, error) {
 	var alg jose.SignatureAlgorithm
 
 	switch k := j.privKey.(type) {
@@ -46,6 +53,8 @@ func (j *JWS) SignContent(url string, content []byte) (*jose.JSONWebSignature, e
 		} else if k.Curve == elliptic.P384() {
 			alg = jose.ES384
 		}
+	case ed25519.PrivateKey:
+		alg = jose.EdDSA
 	}
 
 	signKey := jose.SigningKey{
@@ -54,7 +63,7 @@ func (j *JWS) SignContent(url string, content []byte) (*jose.JSONWebSignature, e
 	}
 
 	options := jose.SignerOptions{
-		NonceSource: j.nonces,
+		NonceSource: j.nonces.Source(ctx),
 		ExtraHeaders: map[jose.HeaderKey]any{
 			"url": url,
 		},
@@ -110,25 +119,5 @@ func (j *JWS) SignEABContent(url, kid string, hmac []byte) (*jose.JSONWebSignatu
 
 // GetKeyAuthorization Gets the key authorization for a token.
 func (j *JWS) GetKeyAuthorization(token string) (string, error) {
-	var publicKey crypto.PublicKey
-
-	switch k := j.privKey.(type) {
-	case *ecdsa.PrivateKey:
-		publicKey = k.Public()
-	case *rsa.PrivateKey:
-		publicKey = k.Public()
-	}
-
-	// Generate the Key Authorization for the challenge
-	jwk := &jose.JSONWebKey{Key: publicKey}
-
-	thumbBytes, err := jwk.Thumbprint(crypto.SHA256)
-	if err != nil {
-		return "", err
-	}
-
-	// unpad the base64URL
-	keyThumb := base64.RawURLEncoding.EncodeToString(thumbBytes)
-
-	return token + "." + keyThumb, nil
+	return acme.KeyAuthorization(token, j.privKey)
 }
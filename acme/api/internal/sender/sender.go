@@ -1,14 +1,18 @@
 package sender
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"runtime"
 	"strings"
 
 	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/recorder"
 )
 
 type RequestOption func(*http.Request) error
@@ -27,6 +31,10 @@ type Doer struct {
 
 // NewDoer Creates a new Doer.
 func NewDoer(client *http.Client, userAgent string) *Doer {
+	if filename := os.Getenv(recorder.EnvRecordSession); filename != "" {
+		client.Transport = newSessionRecorder(filename, client.Transport)
+	}
+
 	client.Transport = newHTTPSOnly(client)
 
 	return &Doer{
@@ -37,8 +45,8 @@ func NewDoer(client *http.Client, userAgent string) *Doer {
 
 // Get performs a GET request with a proper User-Agent string.
 // If "response" is not provided, callers should close resp.Body when done reading from it.
-func (d *Doer) Get(url string, response any) (*http.Response, error) {
-	req, err := d.newRequest(http.MethodGet, url, nil)
+func (d *Doer) Get(ctx context.Context, url string, response any) (*http.Response, error) {
+	req, err := d.newRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -48,8 +56,8 @@ func (d *Doer) Get(url string, response any) (*http.Response, error) {
 
 // Head performs a HEAD request with a proper User-Agent string.
 // The response body (resp.Body) is already closed when this function returns.
-func (d *Doer) Head(url string) (*http.Response, error) {
-	req, err := d.newRequest(http.MethodHead, url, nil)
+func (d *Doer) Head(ctx context.Context, url string) (*http.Response, error) {
+	req, err := d.newRequest(ctx, http.MethodHead, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -59,8 +67,8 @@ func (d *Doer) Head(url string) (*http.Response, error) {
 
 // Post performs a POST request with a proper User-Agent string.
 // If "response" is not provided, callers should close resp.Body when done reading from it.
-func (d *Doer) Post(url string, body io.Reader, bodyType string, response any) (*http.Response, error) {
-	req, err := d.newRequest(http.MethodPost, url, body, contentType(bodyType))
+func (d *Doer) Post(ctx context.Context, url string, body io.Reader, bodyType string, response any) (*http.Response, error) {
+	req, err := d.newRequest(ctx, http.MethodPost, url, body, contentType(bodyType))
 	if err != nil {
 		return nil, err
 	}
@@ -68,8 +76,8 @@ func (d *Doer) Post(url string, body io.Reader, bodyType string, response any) (
 	return d.do(req, response)
 }
 
-func (d *Doer) newRequest(method, uri string, body io.Reader, opts ...RequestOption) (*http.Request, error) {
-	req, err := http.NewRequest(method, uri, body)
+func (d *Doer) newRequest(ctx context.Context, method, uri string, body io.Reader, opts ...RequestOption) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, uri, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -162,6 +170,20 @@ func checkError(req *http.Request, resp *http.Response) error {
 	}
 }
 
+// newSessionRecorder records the ACME API exchanges for bug reports.
+// A recording failure must not prevent the usage of the client.
+func newSessionRecorder(filename string, rt http.RoundTripper) http.RoundTripper {
+	rec, err := recorder.NewRecorder(filename, rt)
+	if err != nil {
+		log.Warnf("Unable to record the ACME session: %v", err)
+		return rt
+	}
+
+	log.Infof("The ACME session is recorded into %s", filename)
+
+	return rec
+}
+
 type httpsOnly struct {
 	rt http.RoundTripper
 }
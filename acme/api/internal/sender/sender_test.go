@@ -5,10 +5,12 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/platform/recorder"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -31,17 +33,19 @@ func TestDo_UserAgentOnAllHTTPMethod(t *testing.T) {
 		{
 			method: http.MethodGet,
 			call: func(u string) (*http.Response, error) {
-				return doer.Get(u, nil)
+				return doer.Get(t.Context(), u, nil)
 			},
 		},
 		{
 			method: http.MethodHead,
-			call:   doer.Head,
+			call: func(u string) (*http.Response, error) {
+				return doer.Head(t.Context(), u)
+			},
 		},
 		{
 			method: http.MethodPost,
 			call: func(u string) (*http.Response, error) {
-				return doer.Post(u, strings.NewReader("falalalala"), "text/plain", nil)
+				return doer.Post(t.Context(), u, strings.NewReader("falalalala"), "text/plain", nil)
 			},
 		},
 	}
@@ -78,7 +82,7 @@ func TestDo_failWithHTTP(t *testing.T) {
 
 	sender := NewDoer(server.Client(), "test")
 
-	_, err := sender.Post(server.URL, strings.NewReader("data"), "text/plain", nil)
+	_, err := sender.Post(t.Context(), server.URL, strings.NewReader("data"), "text/plain", nil)
 	require.ErrorContains(t, err, "HTTPS is required: http://")
 }
 
@@ -148,3 +152,30 @@ func errorAs[T error](t *testing.T, err error) {
 	var zero T
 	assert.ErrorAs(t, err, &zero)
 }
+
+func TestNewDoer_recordSession(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(`{"status":"valid"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	filename := filepath.Join(t.TempDir(), "session.jsonl")
+
+	t.Setenv(recorder.EnvRecordSession, filename)
+
+	doer := NewDoer(server.Client(), "")
+
+	var result map[string]string
+
+	_, err := doer.Get(t.Context(), server.URL+"/order", &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"status": "valid"}, result)
+
+	interactions, err := recorder.Load(filename)
+	require.NoError(t, err)
+	require.Len(t, interactions, 1)
+
+	assert.Equal(t, server.URL+"/order", interactions[0].Request.URL)
+	assert.JSONEq(t, `{"status":"valid"}`, interactions[0].Response.Body)
+}
@@ -1,10 +1,13 @@
 package api
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"maps"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
@@ -24,18 +27,66 @@ type OrderOptions struct {
 	// order is intended to replace.
 	// - https://www.rfc-editor.org/rfc/rfc9773.html#section-5
 	ReplacesCertID string
+
+	// AutoRenewal requests a Short-Term, Automatically Renewed (STAR) certificate.
+	// NotBefore and NotAfter cannot be used with a STAR order.
+	// - https://www.rfc-editor.org/rfc/rfc8739.html
+	AutoRenewal *AutoRenewalOptions
+
+	// Delegation the URL of a delegation configured by the identifier owner (ACME delegation).
+	// The CSR of the order must match the CSR template of the delegation.
+	// - https://www.rfc-editor.org/rfc/rfc9115.html#section-2.3.1.2
+	Delegation string
+
+	// Extensions the fields, unknown to lego, added to the order request (ex: the fields of an experimental extension of a CA).
+	// The fields known to lego cannot be overridden.
+	Extensions acme.Extensions
+}
+
+// AutoRenewalOptions the options of a STAR order.
+// - https://www.rfc-editor.org/rfc/rfc8739.html#section-3.1.1
+type AutoRenewalOptions struct {
+	// StartDate the earliest date of validity of the first certificate (optional).
+	StartDate time.Time
+
+	// EndDate the latest date of validity of the last certificate (required).
+	EndDate time.Time
+
+	// Lifetime the validity period of each certificate (required).
+	Lifetime time.Duration
+
+	// LifetimeAdjust the "left pad" added to each certificate, to handle the clock skews (optional).
+	LifetimeAdjust time.Duration
+
+	// AllowCertificateGet enables the unauthenticated GET requests to the star-certificate URL.
+	AllowCertificateGet bool
 }
 
 type OrderService service
 
 // New Creates a new order.
 func (o *OrderService) New(domains []string) (acme.ExtendedOrder, error) {
-	return o.NewWithOptions(domains, nil)
+	return o.NewContext(context.Background(), domains)
+}
+
+// NewContext is like [OrderService.New] with a context.
+func (o *OrderService) NewContext(ctx context.Context, domains []string) (acme.ExtendedOrder, error) {
+	return o.NewWithOptionsContext(ctx, domains, nil)
 }
 
 // NewWithOptions Creates a new order.
 func (o *OrderService) NewWithOptions(domains []string, opts *OrderOptions) (acme.ExtendedOrder, error) {
-	orderReq := acme.Order{Identifiers: createIdentifiers(domains)}
+	return o.NewWithOptionsContext(context.Background(), domains, opts)
+}
+
+// NewWithOptionsContext is like [OrderService.NewWithOptions] with a context.
+func (o *OrderService) NewWithOptionsContext(ctx context.Context, domains []string, opts *OrderOptions) (acme.ExtendedOrder, error) {
+	return o.NewWithIdentifiers(ctx, createIdentifiers(domains), opts)
+}
+
+// NewWithIdentifiers Creates a new order with explicit identifiers (ex: `permanent-identifier` for a device).
+func (o *OrderService) NewWithIdentifiers(ctx context.Context, identifiers []acme.Identifier, opts *OrderOptions) (acme.ExtendedOrder, error) {
+	orderReq := acme.Order{Identifiers: slices.Clone(identifiers)}
 
 	if opts != nil {
 		if !opts.NotAfter.IsZero() {
@@ -51,17 +102,45 @@ func (o *OrderService) NewWithOptions(domains []string, opts *OrderOptions) (acm
 		}
 
 		if opts.Profile != "" {
+			err := checkProfile(o.core.GetDirectory(), opts.Profile)
+			if err != nil {
+				return acme.ExtendedOrder{}, err
+			}
+
 			orderReq.Profile = opts.Profile
 		}
+
+		if opts.AutoRenewal != nil {
+			if orderReq.NotBefore != "" || orderReq.NotAfter != "" {
+				return acme.ExtendedOrder{}, errors.New("notBefore and notAfter cannot be used with an auto-renewal (STAR) order")
+			}
+
+			autoRenewal, err := newAutoRenewal(o.core.GetDirectory(), opts.AutoRenewal)
+			if err != nil {
+				return acme.ExtendedOrder{}, err
+			}
+
+			orderReq.AutoRenewal = autoRenewal
+		}
+
+		orderReq.Extensions = opts.Extensions
+
+		if opts.Delegation != "" {
+			if !o.core.GetDirectory().Meta.DelegationEnabled {
+				return acme.ExtendedOrder{}, errors.New("the server does not support the delegated orders (RFC 9115)")
+			}
+
+			orderReq.Delegation = opts.Delegation
+		}
 	}
 
 	var order acme.Order
 
-	resp, err := o.core.post(o.core.GetDirectory().NewOrderURL, orderReq, &order)
+	resp, err := o.core.post(ctx, o.core.GetDirectory().NewOrderURL, orderReq, &order)
 	if err != nil {
 		are := &acme.AlreadyReplacedError{}
 		if !errors.As(err, &are) {
-			return acme.ExtendedOrder{}, err
+			return acme.ExtendedOrder{}, checkIdentifiersError(orderReq.Identifiers, err)
 		}
 
 		// If the Server rejects the request because the identified certificate has already been marked as replaced,
@@ -69,7 +148,7 @@ func (o *OrderService) NewWithOptions(domains []string, opts *OrderOptions) (acm
 		// https://www.rfc-editor.org/rfc/rfc9773.html#section-5
 		orderReq.Replaces = ""
 
-		resp, err = o.core.post(o.core.GetDirectory().NewOrderURL, orderReq, &order)
+		resp, err = o.core.post(ctx, o.core.GetDirectory().NewOrderURL, orderReq, &order)
 		if err != nil {
 			return acme.ExtendedOrder{}, err
 		}
@@ -98,34 +177,100 @@ func (o *OrderService) NewWithOptions(domains []string, opts *OrderOptions) (acm
 	return acme.ExtendedOrder{
 		Order:    order,
 		Location: resp.Header.Get("Location"),
+		Links:    getAllLinks(resp.Header),
 	}, nil
 }
 
 // Get Gets an order.
 func (o *OrderService) Get(orderURL string) (acme.ExtendedOrder, error) {
+	return o.GetContext(context.Background(), orderURL)
+}
+
+// GetContext is like [OrderService.Get] with a context.
+func (o *OrderService) GetContext(ctx context.Context, orderURL string) (acme.ExtendedOrder, error) {
 	if orderURL == "" {
 		return acme.ExtendedOrder{}, errors.New("order[get]: empty URL")
 	}
 
 	var order acme.Order
 
-	_, err := o.core.postAsGet(orderURL, &order)
+	resp, err := o.core.postAsGet(ctx, orderURL, &order)
 	if err != nil {
 		return acme.ExtendedOrder{}, err
 	}
 
-	return acme.ExtendedOrder{Order: order}, nil
+	return acme.ExtendedOrder{Order: order, Location: orderURL, Links: getAllLinks(resp.Header)}, nil
+}
+
+// List Gets the URLs of the orders of an account.
+// The pages of the list (Link header with a "next" relation) are followed.
+// - https://www.rfc-editor.org/rfc/rfc8555.html#section-7.1.2.1
+func (o *OrderService) List(ctx context.Context, ordersURL string) ([]string, error) {
+	if ordersURL == "" {
+		return nil, errors.New("order[list]: empty URL")
+	}
+
+	var orders []string
+
+	visited := map[string]struct{}{}
+
+	for ordersURL != "" {
+		if _, ok := visited[ordersURL]; ok {
+			return nil, fmt.Errorf("order[list]: loop in the pages of the list: %s", ordersURL)
+		}
+
+		visited[ordersURL] = struct{}{}
+
+		var list acme.OrdersList
+
+		resp, err := o.core.postAsGet(ctx, ordersURL, &list)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, list.Orders...)
+
+		ordersURL = ""
+
+		if links := getLinks(resp.Header, "next"); len(links) > 0 {
+			ordersURL = links[0]
+		}
+	}
+
+	return orders, nil
+}
+
+// Cancel Cancels an auto-renewal (STAR) order: no more certificates are issued.
+// - https://www.rfc-editor.org/rfc/rfc8739.html#section-2.3
+func (o *OrderService) Cancel(ctx context.Context, orderURL string) (acme.ExtendedOrder, error) {
+	if orderURL == "" {
+		return acme.ExtendedOrder{}, errors.New("order[cancel]: empty URL")
+	}
+
+	var order acme.Order
+
+	_, err := o.core.post(ctx, orderURL, acme.OrderStatusMessage{Status: acme.StatusCanceled}, &order)
+	if err != nil {
+		return acme.ExtendedOrder{}, err
+	}
+
+	return acme.ExtendedOrder{Order: order, Location: orderURL}, nil
 }
 
 // UpdateForCSR Updates an order for a CSR.
 func (o *OrderService) UpdateForCSR(orderURL string, csr []byte) (acme.ExtendedOrder, error) {
+	return o.UpdateForCSRContext(context.Background(), orderURL, csr)
+}
+
+// UpdateForCSRContext is like [OrderService.UpdateForCSR] with a context.
+func (o *OrderService) UpdateForCSRContext(ctx context.Context, orderURL string, csr []byte) (acme.ExtendedOrder, error) {
 	csrMsg := acme.CSRMessage{
 		Csr: base64.RawURLEncoding.EncodeToString(csr),
 	}
 
 	var order acme.Order
 
-	_, err := o.core.post(orderURL, csrMsg, &order)
+	_, err := o.core.post(ctx, orderURL, csrMsg, &order)
 	if err != nil {
 		return acme.ExtendedOrder{}, err
 	}
@@ -136,3 +281,89 @@ func (o *OrderService) UpdateForCSR(orderURL string, csr []byte) (acme.ExtendedO
 
 	return acme.ExtendedOrder{Order: order}, nil
 }
+
+// checkIdentifiersError explains the rejection of the IP identifiers by a server without the support of RFC 8738:
+// the ACME directory doesn't advertise the supported identifier types.
+func checkIdentifiersError(identifiers []acme.Identifier, err error) error {
+	var problem *acme.ProblemDetails
+	if !errors.As(err, &problem) || problem.Type != acme.UnsupportedIdentifierErr && problem.Type != acme.RejectedIdentifierErr {
+		return err
+	}
+
+	if !slices.ContainsFunc(identifiers, func(ident acme.Identifier) bool { return ident.Type == acme.IdentifierIP }) {
+		return err
+	}
+
+	return fmt.Errorf("the server rejected the IP identifiers, the IP address certificates (RFC 8738) may not be supported: %w", err)
+}
+
+// checkProfile checks that the profile is advertised by the server.
+// - https://www.ietf.org/id/draft-ietf-acme-profiles-00.html#section-3
+func checkProfile(dir acme.Directory, profile string) error {
+	if len(dir.Meta.Profiles) == 0 {
+		return fmt.Errorf("the server does not support the certificate profiles: the profile %q cannot be used", profile)
+	}
+
+	if _, ok := dir.Meta.Profiles[profile]; ok {
+		return nil
+	}
+
+	names := slices.Sorted(maps.Keys(dir.Meta.Profiles))
+
+	return fmt.Errorf("the profile %q is not supported by the server (available profiles: %s)", profile, strings.Join(names, ", "))
+}
+
+// newAutoRenewal creates the auto-renewal object of a STAR order,
+// and checks it against the capabilities advertised by the server.
+// - https://www.rfc-editor.org/rfc/rfc8739.html#section-3.1.1
+func newAutoRenewal(dir acme.Directory, opts *AutoRenewalOptions) (*acme.AutoRenewal, error) {
+	meta := dir.Meta.AutoRenewal
+	if meta == nil {
+		return nil, errors.New("the server does not support the auto-renewal (STAR) certificates")
+	}
+
+	if opts.EndDate.IsZero() {
+		return nil, errors.New("auto-renewal: the end date is required")
+	}
+
+	if opts.Lifetime <= 0 {
+		return nil, errors.New("auto-renewal: the lifetime is required")
+	}
+
+	if meta.MinLifetime > 0 && opts.Lifetime < time.Duration(meta.MinLifetime)*time.Second {
+		return nil, fmt.Errorf("auto-renewal: the lifetime (%s) is lower than the minimum lifetime allowed by the server (%s)",
+			opts.Lifetime, time.Duration(meta.MinLifetime)*time.Second)
+	}
+
+	start := opts.StartDate
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	if !opts.EndDate.After(start) {
+		return nil, fmt.Errorf("auto-renewal: the end date (%s) must be after the start date (%s)",
+			opts.EndDate.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+
+	if meta.MaxDuration > 0 && opts.EndDate.Sub(start) > time.Duration(meta.MaxDuration)*time.Second {
+		return nil, fmt.Errorf("auto-renewal: the duration (%s) is greater than the maximum duration allowed by the server (%s)",
+			opts.EndDate.Sub(start).Round(time.Second), time.Duration(meta.MaxDuration)*time.Second)
+	}
+
+	if opts.AllowCertificateGet && !meta.AllowCertificateGet {
+		return nil, errors.New("auto-renewal: the server does not support the unauthenticated GET requests to the STAR certificates")
+	}
+
+	autoRenewal := &acme.AutoRenewal{
+		EndDate:             opts.EndDate.Format(time.RFC3339),
+		Lifetime:            int(opts.Lifetime.Seconds()),
+		LifetimeAdjust:      int(opts.LifetimeAdjust.Seconds()),
+		AllowCertificateGet: opts.AllowCertificateGet,
+	}
+
+	if !opts.StartDate.IsZero() {
+		autoRenewal.StartDate = opts.StartDate.Format(time.RFC3339)
+	}
+
+	return autoRenewal, nil
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"testing"
@@ -87,6 +88,19 @@ func TestOrderService_NewWithOptions(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with profile",
+			opts: &OrderOptions{
+				Profile: "shortlived",
+			},
+			expected: acme.ExtendedOrder{
+				Order: acme.Order{
+					Status:      "valid",
+					Identifiers: []acme.Identifier{{Type: "dns", Value: "example.com"}},
+					Profile:     "shortlived",
+				},
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -101,6 +115,120 @@ func TestOrderService_NewWithOptions(t *testing.T) {
 	}
 }
 
+func TestOrderService_NewWithOptions_extensions(t *testing.T) {
+	privateKey, errK := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, errK, "Could not generate test key")
+
+	server := tester.MockACMEServer().
+		Route("POST /newOrder",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := readSignedBody(req, privateKey)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				order := acme.Order{}
+
+				err = json.Unmarshal(body, &order)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+				rw.Header().Set("Location", serverURL+"/order/1")
+				rw.Header().Add("Link", `<`+serverURL+`/order/1/policy>; rel="x-policy"`)
+
+				servermock.JSONEncode(acme.Order{
+					Status:      acme.StatusPending,
+					Identifiers: order.Identifiers,
+					Extensions: acme.Extensions{
+						"x-request": order.Extensions["x-request"],
+						"x-policy":  json.RawMessage(`{"maxLifetime":86400}`),
+					},
+				}).ServeHTTP(rw, req)
+			})).
+		BuildHTTPS(t)
+
+	core, err := New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	opts := &OrderOptions{
+		Extensions: acme.Extensions{
+			"x-request": json.RawMessage(`"foo"`),
+			// The known fields cannot be overridden.
+			"status": json.RawMessage(`"valid"`),
+		},
+	}
+
+	order, err := core.Orders.NewWithOptionsContext(t.Context(), []string{"example.com"}, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusPending, order.Status)
+	assert.Equal(t, map[string][]string{"x-policy": {server.URL + "/order/1/policy"}}, order.Links)
+
+	var request string
+
+	ok, err := order.Extensions.Get("x-request", &request)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	assert.Equal(t, "foo", request)
+
+	var policy struct {
+		MaxLifetime int `json:"maxLifetime"`
+	}
+
+	ok, err = order.Extensions.Get("x-policy", &policy)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	assert.Equal(t, 86400, policy.MaxLifetime)
+}
+
+func TestOrderService_NewWithOptions_unknownProfile(t *testing.T) {
+	privateKey, errK := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, errK, "Could not generate test key")
+
+	server := tester.MockACMEServer().BuildHTTPS(t)
+
+	core, err := New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	_, err = core.Orders.NewWithOptionsContext(t.Context(), []string{"example.com"}, &OrderOptions{Profile: "tlsserver"})
+	require.EqualError(t, err, `the profile "tlsserver" is not supported by the server (available profiles: classic, shortlived)`)
+}
+
+func TestOrderService_NewWithOptions_unsupportedIP(t *testing.T) {
+	privateKey, errK := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, errK, "Could not generate test key")
+
+	server := tester.MockACMEServer().
+		Route("POST /newOrder",
+			servermock.JSONEncode(acme.ProblemDetails{
+				Type:   acme.UnsupportedIdentifierErr,
+				Detail: "Invalid identifiers requested",
+			}).WithStatusCode(http.StatusBadRequest)).
+		BuildHTTPS(t)
+
+	core, err := New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	_, err = core.Orders.NewWithOptionsContext(t.Context(), []string{"example.com", "192.0.2.1"}, nil)
+	require.ErrorContains(t, err, "the server rejected the IP identifiers, the IP address certificates (RFC 8738) may not be supported: "+
+		"acme: error: 400 :: POST :: "+server.URL+"/newOrder :: urn:ietf:params:acme:error:unsupportedIdentifier :: Invalid identifiers requested")
+
+	_, err = core.Orders.NewWithOptionsContext(t.Context(), []string{"example.com"}, nil)
+	require.EqualError(t, err, "acme: error: 400 :: POST :: "+server.URL+"/newOrder :: urn:ietf:params:acme:error:unsupportedIdentifier :: Invalid identifiers requested")
+}
+
+func Test_checkProfile_noProfiles(t *testing.T) {
+	err := checkProfile(acme.Directory{}, "shortlived")
+	require.EqualError(t, err, `the server does not support the certificate profiles: the profile "shortlived" cannot be used`)
+}
+
 func readSignedBody(r *http.Request, privateKey *rsa.PrivateKey) ([]byte, error) {
 	reqBody, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -124,3 +252,166 @@ func readSignedBody(r *http.Request, privateKey *rsa.PrivateKey) ([]byte, error)
 
 	return body, nil
 }
+
+func TestOrderService_List(t *testing.T) {
+	privateKey, errK := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, errK, "Could not generate test key")
+
+	server := tester.MockACMEServer().
+		Route("POST /orders",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+				rw.Header().Add("Link", fmt.Sprintf(`<%s/orders/2>;rel="next"`, serverURL))
+
+				servermock.JSONEncode(acme.OrdersList{
+					Orders: []string{serverURL + "/order/1", serverURL + "/order/2"},
+				}).ServeHTTP(rw, req)
+			})).
+		Route("POST /orders/2",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+				servermock.JSONEncode(acme.OrdersList{
+					Orders: []string{serverURL + "/order/3"},
+				}).ServeHTTP(rw, req)
+			})).
+		Route("POST /loop",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+				rw.Header().Add("Link", fmt.Sprintf(`<%s/loop>;rel="next"`, serverURL))
+
+				servermock.JSONEncode(acme.OrdersList{}).ServeHTTP(rw, req)
+			})).
+		BuildHTTPS(t)
+
+	core, err := New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	orders, err := core.Orders.List(t.Context(), server.URL+"/orders")
+	require.NoError(t, err)
+
+	expected := []string{server.URL + "/order/1", server.URL + "/order/2", server.URL + "/order/3"}
+	assert.Equal(t, expected, orders)
+
+	_, err = core.Orders.List(t.Context(), server.URL+"/loop")
+	require.EqualError(t, err, "order[list]: loop in the pages of the list: "+server.URL+"/loop")
+}
+
+func TestOrderService_Cancel(t *testing.T) {
+	privateKey, errK := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, errK, "Could not generate test key")
+
+	server := tester.MockACMEServer().
+		Route("POST /order/1",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, err := readSignedBody(req, privateKey)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				msg := acme.OrderStatusMessage{}
+
+				err = json.Unmarshal(body, &msg)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				servermock.JSONEncode(acme.Order{Status: msg.Status}).ServeHTTP(rw, req)
+			})).
+		BuildHTTPS(t)
+
+	core, err := New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	order, err := core.Orders.Cancel(t.Context(), server.URL+"/order/1")
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusCanceled, order.Status)
+	assert.Equal(t, server.URL+"/order/1", order.Location)
+}
+
+func Test_newAutoRenewal(t *testing.T) {
+	dir := acme.Directory{
+		Meta: acme.Meta{
+			AutoRenewal: &acme.AutoRenewalMeta{MinLifetime: 86400, MaxDuration: 31536000},
+		},
+	}
+
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc       string
+		dir        acme.Directory
+		opts       *AutoRenewalOptions
+		expected   *acme.AutoRenewal
+		requireErr require.ErrorAssertionFunc
+	}{
+		{
+			desc: "valid",
+			dir:  dir,
+			opts: &AutoRenewalOptions{
+				StartDate:      start,
+				EndDate:        start.AddDate(0, 1, 0),
+				Lifetime:       4 * 24 * time.Hour,
+				LifetimeAdjust: time.Hour,
+			},
+			expected: &acme.AutoRenewal{
+				StartDate:      "2030-01-01T00:00:00Z",
+				EndDate:        "2030-02-01T00:00:00Z",
+				Lifetime:       345600,
+				LifetimeAdjust: 3600,
+			},
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "not supported",
+			opts:       &AutoRenewalOptions{EndDate: start, Lifetime: 24 * time.Hour},
+			requireErr: require.Error,
+		},
+		{
+			desc:       "missing end date",
+			dir:        dir,
+			opts:       &AutoRenewalOptions{Lifetime: 24 * time.Hour},
+			requireErr: require.Error,
+		},
+		{
+			desc:       "lifetime too short",
+			dir:        dir,
+			opts:       &AutoRenewalOptions{StartDate: start, EndDate: start.AddDate(0, 1, 0), Lifetime: time.Hour},
+			requireErr: require.Error,
+		},
+		{
+			desc:       "duration too long",
+			dir:        dir,
+			opts:       &AutoRenewalOptions{StartDate: start, EndDate: start.AddDate(2, 0, 0), Lifetime: 24 * time.Hour},
+			requireErr: require.Error,
+		},
+		{
+			desc:       "end date before start date",
+			dir:        dir,
+			opts:       &AutoRenewalOptions{StartDate: start, EndDate: start.Add(-time.Hour), Lifetime: 24 * time.Hour},
+			requireErr: require.Error,
+		},
+		{
+			desc:       "unauthenticated GET not supported",
+			dir:        dir,
+			opts:       &AutoRenewalOptions{StartDate: start, EndDate: start.AddDate(0, 1, 0), Lifetime: 24 * time.Hour, AllowCertificateGet: true},
+			requireErr: require.Error,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			autoRenewal, err := newAutoRenewal(test.dir, test.opts)
+			test.requireErr(t, err)
+
+			assert.Equal(t, test.expected, autoRenewal)
+		})
+	}
+}
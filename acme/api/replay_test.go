@@ -0,0 +1,136 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/platform/tester/replay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The sessions (fixtures/sessions) are synthetic: they are hand-written, not captured from a real ACME server.
+// They use the format of LEGO_RECORD_SESSION and mimic the responses of the Let's Encrypt staging,
+// they lock the protocol behavior of the client.
+const (
+	stagingBaseURL    = "https://acme-staging-v02.api.letsencrypt.org"
+	stagingAccountURL = stagingBaseURL + "/acme/acct/123456789"
+	stagingOrderURL   = stagingBaseURL + "/acme/order/123456789/987654321"
+	stagingAuthzURL   = stagingBaseURL + "/acme/authz/123456789/1122334455"
+	stagingChlgURL    = stagingBaseURL + "/acme/chall/123456789/1122334455/AbCdEf"
+	stagingCertURL    = stagingBaseURL + "/acme/cert/fa1b2c3d4e5f60718293a4b5c6d7e8f90011"
+)
+
+const (
+	leafPEM         = "-----BEGIN CERTIFICATE-----\nbGVhZg==\n-----END CERTIFICATE-----\n"
+	issuerPEM       = "-----BEGIN CERTIFICATE-----\naW50ZXJtZWRpYXRl\n-----END CERTIFICATE-----\n"
+	alternateIssuer = "-----BEGIN CERTIFICATE-----\nYWx0ZXJuYXRl\n-----END CERTIFICATE-----\n"
+)
+
+func TestReplay_order(t *testing.T) {
+	core := newReplayCore(t, "order")
+
+	order, err := core.Orders.NewContext(t.Context(), []string{"example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusPending, order.Status)
+	assert.Equal(t, stagingOrderURL, order.Location)
+	assert.Equal(t, []string{stagingAuthzURL}, order.Authorizations)
+
+	authz, err := core.Authorizations.GetContext(t.Context(), order.Authorizations[0])
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.Identifier{Type: "dns", Value: "example.com"}, authz.Identifier)
+	require.Len(t, authz.Challenges, 1)
+
+	chlg, err := core.Challenges.NewContext(t.Context(), authz.Challenges[0].URL)
+	require.NoError(t, err)
+
+	assert.Equal(t, stagingChlgURL, chlg.URL)
+	assert.Equal(t, stagingAuthzURL, chlg.AuthorizationURL)
+
+	// The requests are matched on their payload: the CSR of the session.
+	csr, err := base64.RawURLEncoding.DecodeString("MIHcMIGEAgEAMBYxFDASBgNVBAMTC2V4YW1wbGUuY29t")
+	require.NoError(t, err)
+
+	order, err = core.Orders.UpdateForCSRContext(t.Context(), order.Finalize, csr)
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusProcessing, order.Status)
+
+	order, err = core.Orders.GetContext(t.Context(), stagingOrderURL)
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusValid, order.Status)
+	assert.Equal(t, stagingCertURL, order.Certificate)
+
+	cert, issuer, err := core.Certificates.GetContext(t.Context(), order.Certificate, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, leafPEM+"\n"+issuerPEM, string(cert))
+	assert.Equal(t, "\n"+issuerPEM, string(issuer))
+}
+
+func TestReplay_badNonce(t *testing.T) {
+	core := newReplayCore(t, "bad_nonce")
+
+	// The 2 badNonce errors are retried with a new nonce.
+	order, err := core.Orders.NewContext(t.Context(), []string{"example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusPending, order.Status)
+	assert.Equal(t, stagingOrderURL, order.Location)
+}
+
+func TestReplay_rateLimited(t *testing.T) {
+	core := newReplayCore(t, "rate_limited")
+
+	// The rate limit is not retried.
+	_, err := core.Orders.NewContext(t.Context(), []string{"example.com"})
+	require.Error(t, err)
+
+	var rateLimitedErr *acme.RateLimitedError
+	require.ErrorAs(t, err, &rateLimitedErr)
+
+	assert.Equal(t, "3600", rateLimitedErr.RetryAfter)
+	assert.Equal(t, http.StatusTooManyRequests, rateLimitedErr.HTTPStatus)
+	assert.Equal(t, acme.RateLimitedErr, rateLimitedErr.Type)
+	assert.Equal(t, stagingBaseURL+"/acme/new-order", rateLimitedErr.URL)
+}
+
+func TestReplay_alternateChains(t *testing.T) {
+	core := newReplayCore(t, "alternate_chains")
+
+	certs, err := core.Certificates.GetAllContext(t.Context(), stagingCertURL, true)
+	require.NoError(t, err)
+
+	require.Len(t, certs, 2)
+
+	require.Contains(t, certs, stagingCertURL)
+	assert.Equal(t, leafPEM+"\n"+issuerPEM, string(certs[stagingCertURL].Cert))
+	assert.Equal(t, "\n"+issuerPEM, string(certs[stagingCertURL].Issuer))
+
+	require.Contains(t, certs, stagingCertURL+"/1")
+	assert.Equal(t, leafPEM+"\n"+alternateIssuer, string(certs[stagingCertURL+"/1"].Cert))
+	assert.Equal(t, "\n"+alternateIssuer, string(certs[stagingCertURL+"/1"].Issuer))
+}
+
+func newReplayCore(t *testing.T, session string) *Core {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	client := replay.NewClient(t, filepath.Join("fixtures", "sessions", session+".jsonl"))
+
+	core, err := New(client, "lego-test", stagingBaseURL+"/directory", stagingAccountURL, privateKey)
+	require.NoError(t, err)
+
+	return core
+}
@@ -23,9 +23,14 @@ func getLink(header http.Header, rel string) string {
 }
 
 func getLinks(header http.Header, rel string) []string {
+	return getAllLinks(header)[rel]
+}
+
+// getAllLinks get the URLs of the Link header by rel.
+func getAllLinks(header http.Header) map[string][]string {
 	linkExpr := regexp.MustCompile(`<(.+?)>(?:;[^;]+)*?;\s*rel="(.+?)"`)
 
-	var links []string
+	var links map[string][]string
 
 	for _, link := range header["Link"] {
 		for _, m := range linkExpr.FindAllStringSubmatch(link, -1) {
@@ -33,9 +38,11 @@ func getLinks(header http.Header, rel string) []string {
 				continue
 			}
 
-			if m[2] == rel {
-				links = append(links, m[1])
+			if links == nil {
+				links = make(map[string][]string)
 			}
+
+			links[m[2]] = append(links[m[2]], m[1])
 		}
 	}
 
@@ -56,6 +56,24 @@ func Test_getLink(t *testing.T) {
 	}
 }
 
+func Test_getAllLinks(t *testing.T) {
+	header := http.Header{
+		"Link": []string{
+			`<https://example.com/next>; rel="next", <https://example.com/alt/1>; rel="alternate"`,
+			`<https://example.com/alt/2>;rel="alternate"`,
+		},
+	}
+
+	expected := map[string][]string{
+		"next":      {"https://example.com/next"},
+		"alternate": {"https://example.com/alt/1", "https://example.com/alt/2"},
+	}
+
+	assert.Equal(t, expected, getAllLinks(header))
+
+	assert.Nil(t, getAllLinks(http.Header{}))
+}
+
 func TestParseRetryAfter(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -9,7 +9,9 @@ import (
 
 // ACME status values of Account, Order, Authorization and Challenge objects.
 // See https://www.rfc-editor.org/rfc/rfc8555.html#section-7.1.6 for details.
+// The status "canceled" is only used by the STAR orders (https://www.rfc-editor.org/rfc/rfc8739.html#section-2.3).
 const (
+	StatusCanceled    = "canceled"
 	StatusDeactivated = "deactivated"
 	StatusExpired     = "expired"
 	StatusInvalid     = "invalid"
@@ -21,6 +23,18 @@ const (
 	StatusValid       = "valid"
 )
 
+// ACME identifier types.
+// - https://www.rfc-editor.org/rfc/rfc8555.html#section-9.7.7
+// - https://www.rfc-editor.org/rfc/rfc8738.html#section-3
+// - https://datatracker.ietf.org/doc/draft-acme-device-attest/
+// - https://www.rfc-editor.org/rfc/rfc8823.html#section-3
+const (
+	IdentifierDNS                 = "dns"
+	IdentifierIP                  = "ip"
+	IdentifierPermanentIdentifier = "permanent-identifier"
+	IdentifierEmail               = "email"
+)
+
 // CRL reason codes as defined in RFC 5280.
 // https://datatracker.ietf.org/doc/html/rfc5280#section-5.3.1
 const (
@@ -79,6 +93,48 @@ type Meta struct {
 	// A map of profile names to human-readable descriptions of those profiles.
 	// https://www.ietf.org/id/draft-ietf-acme-profiles-00.html#section-3
 	Profiles map[string]string `json:"profiles"`
+
+	// auto-renewal (optional, object):
+	// The support of the Short-Term, Automatically Renewed (STAR) certificates.
+	// https://www.rfc-editor.org/rfc/rfc8739.html#section-3.1.1
+	AutoRenewal *AutoRenewalMeta `json:"auto-renewal,omitempty"`
+
+	// delegation-enabled (optional, boolean):
+	// Indicates that the server supports the delegation of certificates (ACME delegation).
+	// https://www.rfc-editor.org/rfc/rfc9115.html#section-2.3.1
+	DelegationEnabled bool `json:"delegation-enabled,omitempty"`
+
+	// Extensions the fields of the meta object unknown to lego.
+	Extensions Extensions `json:"-"`
+}
+
+func (m *Meta) UnmarshalJSON(data []byte) error {
+	type meta Meta
+
+	extensions, err := unmarshalWithExtensions(data, (*meta)(m))
+	if err != nil {
+		return err
+	}
+
+	m.Extensions = extensions
+
+	return nil
+}
+
+// AutoRenewalMeta the STAR capabilities of the ACME server (related to Meta).
+// - https://www.rfc-editor.org/rfc/rfc8739.html#section-3.1.1
+type AutoRenewalMeta struct {
+	// min-lifetime (required, integer):
+	// Minimum acceptable value for auto-renewal lifetime, in seconds.
+	MinLifetime int `json:"min-lifetime"`
+
+	// max-duration (required, integer):
+	// Maximum allowed delta between the end-date and start-date attributes of the order's auto-renewal object, in seconds.
+	MaxDuration int `json:"max-duration"`
+
+	// allow-certificate-get (optional, boolean):
+	// Indicates if the server supports the unauthenticated GET requests to the star-certificate URL.
+	AllowCertificateGet bool `json:"allow-certificate-get,omitempty"`
 }
 
 // ExtendedAccount an extended Account.
@@ -123,6 +179,11 @@ type Account struct {
 	// This allows a client to look up an account URL based on an account key (see Section 7.3.1).
 	OnlyReturnExisting bool `json:"onlyReturnExisting,omitempty"`
 
+	// delegations (optional, string):
+	// A URL from which a list of the delegations configured for this account can be fetched via a POST-as-GET request.
+	// - https://www.rfc-editor.org/rfc/rfc9115.html#section-2.3.1.1
+	Delegations string `json:"delegations,omitempty"`
+
 	// externalAccountBinding (optional, object):
 	// An optional field for binding the new account with an existing non-ACME account (see Section 7.3.4).
 	ExternalAccountBinding json.RawMessage `json:"externalAccountBinding,omitempty"`
@@ -134,6 +195,9 @@ type ExtendedOrder struct {
 
 	// The order URL, contains the value of the response header `Location`
 	Location string `json:"-"`
+
+	// Links the URLs of the response header `Link`, by relation (ex: a relation defined by a CA extension).
+	Links map[string][]string `json:"-"`
 }
 
 // Order the ACME order Object.
@@ -199,6 +263,172 @@ type Order struct {
 	// previously-issued certificate which this order is intended to replace.
 	// - https://www.rfc-editor.org/rfc/rfc9773.html#section-5
 	Replaces string `json:"replaces,omitempty"`
+
+	// auto-renewal (optional, object):
+	// The request of a Short-Term, Automatically Renewed (STAR) certificate.
+	// - https://www.rfc-editor.org/rfc/rfc8739.html#section-3.1.1
+	AutoRenewal *AutoRenewal `json:"auto-renewal,omitempty"`
+
+	// star-certificate (optional, string):
+	// A URL for the last certificate issued by the STAR order.
+	// - https://www.rfc-editor.org/rfc/rfc8739.html#section-3.1.2
+	StarCertificate string `json:"star-certificate,omitempty"`
+
+	// delegation (optional, string):
+	// The URL of the delegation object used by a delegated order.
+	// - https://www.rfc-editor.org/rfc/rfc9115.html#section-2.3.1.2
+	Delegation string `json:"delegation,omitempty"`
+
+	// Extensions the fields of the order unknown to lego.
+	// The extensions of an order request are sent with the other fields.
+	Extensions Extensions `json:"-"`
+}
+
+func (r *Order) UnmarshalJSON(data []byte) error {
+	type order Order
+
+	extensions, err := unmarshalWithExtensions(data, (*order)(r))
+	if err != nil {
+		return err
+	}
+
+	r.Extensions = extensions
+
+	return nil
+}
+
+func (r Order) MarshalJSON() ([]byte, error) {
+	type order Order
+
+	return marshalWithExtensions(order(r), r.Extensions)
+}
+
+// AutoRenewal the auto-renewal object of a STAR order (related to Order).
+// - https://www.rfc-editor.org/rfc/rfc8739.html#section-3.1.1
+type AutoRenewal struct {
+	// start-date (optional, string):
+	// The earliest date of validity of the first certificate issued, in RFC 3339 format.
+	// When omitted, the start date is as soon as authorization is complete.
+	StartDate string `json:"start-date,omitempty"`
+
+	// end-date (required, string):
+	// The latest date of validity of the last certificate issued, in RFC 3339 format.
+	EndDate string `json:"end-date"`
+
+	// lifetime (required, integer):
+	// The maximum validity period of each STAR certificate, an integer that denotes a number of seconds.
+	Lifetime int `json:"lifetime"`
+
+	// lifetime-adjust (optional, integer):
+	// Amount of "left pad" added to each STAR certificate, an integer that denotes a number of seconds.
+	LifetimeAdjust int `json:"lifetime-adjust,omitempty"`
+
+	// allow-certificate-get (optional, boolean):
+	// Enables the unauthenticated GET requests to the star-certificate URL.
+	AllowCertificateGet bool `json:"allow-certificate-get,omitempty"`
+}
+
+// OrderStatusMessage the request to update the status of an order (ex: cancellation of a STAR order).
+// - https://www.rfc-editor.org/rfc/rfc8739.html#section-2.3
+type OrderStatusMessage struct {
+	Status string `json:"status"`
+}
+
+// DelegationsList the ACME delegations list object.
+// - https://www.rfc-editor.org/rfc/rfc9115.html#section-2.3.1.1
+type DelegationsList struct {
+	// delegations (required, array of string):
+	// An array of URLs, each identifying a delegation configured for the account.
+	Delegations []string `json:"delegations"`
+}
+
+// Delegation the ACME delegation object.
+// - https://www.rfc-editor.org/rfc/rfc9115.html#section-2.3.1.1
+type Delegation struct {
+	// csr-template (required, object):
+	// The template that the CSR of the delegated orders must match.
+	CSRTemplate *CSRTemplate `json:"csr-template"`
+
+	// cname-map (optional, object):
+	// A map of the CNAME records the identifier owner needs to install (name to target),
+	// to redirect the traffic to the delegated entity.
+	CNAMEMap map[string]string `json:"cname-map,omitempty"`
+}
+
+// CSR template values.
+// - https://www.rfc-editor.org/rfc/rfc9115.html#section-4.1
+const (
+	// CSRTemplateAnyValue the field can have any value, or can be omitted.
+	CSRTemplateAnyValue = "*"
+
+	// CSRTemplateRequiredValue the field must be provided by the client.
+	CSRTemplateRequiredValue = "**"
+)
+
+// CSRTemplate the template of the CSR of a delegated order.
+// Each value of a field is either a fixed value, CSRTemplateAnyValue, or CSRTemplateRequiredValue.
+// - https://www.rfc-editor.org/rfc/rfc9115.html#section-4
+type CSRTemplate struct {
+	// keyTypes (required, array of object):
+	// The accepted key types.
+	KeyTypes []CSRTemplateKeyType `json:"keyTypes"`
+
+	// subject (optional, object):
+	// The subject fields (ex: "country", "organization", "commonName").
+	Subject map[string]string `json:"subject,omitempty"`
+
+	// extensions (required, object):
+	// The extensions of the CSR.
+	Extensions CSRTemplateExtensions `json:"extensions"`
+}
+
+// CSRTemplateKeyType a key type accepted by a CSR template.
+// - https://www.rfc-editor.org/rfc/rfc9115.html#appendix-A
+type CSRTemplateKeyType struct {
+	// PublicKeyType the algorithm of the public key ("rsaEncryption" or "id-ecPublicKey").
+	PublicKeyType string `json:"PublicKeyType"`
+
+	// PublicKeyLength the size of an RSA key.
+	PublicKeyLength int `json:"PublicKeyLength,omitempty"`
+
+	// NamedCurve the curve of an EC key (ex: "secp256r1").
+	NamedCurve string `json:"namedCurve,omitempty"`
+
+	// SignatureType the signature algorithm of the CSR (ex: "ecdsa-with-SHA256").
+	SignatureType string `json:"SignatureType"`
+}
+
+// CSRTemplateExtensions the extensions of a CSR template.
+// - https://www.rfc-editor.org/rfc/rfc9115.html#appendix-A
+type CSRTemplateExtensions struct {
+	// subjectAltName (required, object):
+	// The subject alternative names.
+	SubjectAltName CSRTemplateSAN `json:"subjectAltName"`
+
+	// keyUsage (optional, array of string):
+	// The key usages (ex: "digitalSignature").
+	KeyUsage []string `json:"keyUsage,omitempty"`
+
+	// extendedKeyUsage (optional, array of string):
+	// The extended key usages (ex: "serverAuth").
+	ExtendedKeyUsage []string `json:"extendedKeyUsage,omitempty"`
+}
+
+// CSRTemplateSAN the subject alternative names of a CSR template.
+type CSRTemplateSAN struct {
+	DNS   []string `json:"DNS,omitempty"`
+	Email []string `json:"Email,omitempty"`
+	URI   []string `json:"URI,omitempty"`
+}
+
+// OrdersList the ACME orders list object.
+// - https://www.rfc-editor.org/rfc/rfc8555.html#section-7.1.2.1
+type OrdersList struct {
+	// orders (required, array of string):
+	// An array of URLs, each identifying an order belonging to the account.
+	// The server SHOULD include pending orders and SHOULD NOT include orders that are invalid in the array of URLs.
+	// The server MAY return an incomplete list, along with a Link header field with a "next" link relation indicating where further entries can be acquired.
+	Orders []string `json:"orders"`
 }
 
 func (r *Order) Err() error {
@@ -240,6 +470,22 @@ type Authorization struct {
 	// For authorizations created as a result of a newOrder request containing a DNS identifier
 	// with a value that contained a wildcard prefix this field MUST be present, and true.
 	Wildcard bool `json:"wildcard,omitempty"`
+
+	// Extensions the fields of the authorization unknown to lego.
+	Extensions Extensions `json:"-"`
+}
+
+func (a *Authorization) UnmarshalJSON(data []byte) error {
+	type authorization Authorization
+
+	extensions, err := unmarshalWithExtensions(data, (*authorization)(a))
+	if err != nil {
+		return err
+	}
+
+	a.Extensions = extensions
+
+	return nil
 }
 
 // ExtendedChallenge a extended Challenge.
@@ -293,6 +539,11 @@ type Challenge struct {
 
 	// https://www.rfc-editor.org/rfc/rfc8555.html#section-8.1
 	KeyAuthorization string `json:"keyAuthorization"`
+
+	// from (required for email-reply-00, string):
+	// The email address that the ACME server uses to send the challenge email.
+	// https://www.rfc-editor.org/rfc/rfc8823.html#section-3
+	From string `json:"from,omitempty"`
 }
 
 func (c *Challenge) Err() error {
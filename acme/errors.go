@@ -7,10 +7,17 @@ import (
 
 // Errors types.
 const (
-	errNS              = "urn:ietf:params:acme:error:"
-	BadNonceErr        = errNS + "badNonce"
-	AlreadyReplacedErr = errNS + "alreadyReplaced"
-	RateLimitedErr     = errNS + "rateLimited"
+	errNS                    = "urn:ietf:params:acme:error:"
+	BadNonceErr              = errNS + "badNonce"
+	BadSignatureAlgorithmErr = errNS + "badSignatureAlgorithm"
+	AlreadyReplacedErr       = errNS + "alreadyReplaced"
+	RateLimitedErr           = errNS + "rateLimited"
+	RejectedIdentifierErr    = errNS + "rejectedIdentifier"
+	UnsupportedIdentifierErr = errNS + "unsupportedIdentifier"
+
+	// STAR errors: https://www.rfc-editor.org/rfc/rfc8739.html#section-3.5
+	AutoRenewalCanceledErr = errNS + "autoRenewalCanceled"
+	AutoRenewalExpiredErr  = errNS + "autoRenewalExpired"
 )
 
 // ProblemDetails the problem details object.
@@ -23,6 +30,10 @@ type ProblemDetails struct {
 	Instance    string       `json:"instance,omitempty"`
 	SubProblems []SubProblem `json:"subproblems,omitempty"`
 
+	// Algorithms the signature algorithms supported by the server (badSignatureAlgorithm).
+	// - https://www.rfc-editor.org/rfc/rfc8555.html#section-6.2
+	Algorithms []string `json:"algorithms,omitempty"`
+
 	// additional values to have a better error message (Not defined by the RFC)
 	Method string `json:"method,omitempty"`
 	URL    string `json:"url,omitempty"`
@@ -43,6 +54,10 @@ func (p *ProblemDetails) Error() string {
 		_, _ = fmt.Fprintf(msg, ", problem: %q :: %s", sub.Type, sub.Detail)
 	}
 
+	if len(p.Algorithms) > 0 {
+		msg.WriteString(", supported algorithms: " + strings.Join(p.Algorithms, ", "))
+	}
+
 	if p.Instance != "" {
 		msg.WriteString(", url: " + p.Instance)
 	}
@@ -0,0 +1,109 @@
+package acme
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Extensions the fields of an ACME object unknown to lego (ex: the fields of an experimental extension of a CA),
+// by name, with their raw JSON values.
+type Extensions map[string]json.RawMessage
+
+// Get decodes the value of the field name into v.
+// Returns false if the field is not defined.
+func (e Extensions) Get(name string, v any) (bool, error) {
+	raw, ok := e[name]
+	if !ok {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(raw, v)
+}
+
+// unmarshalWithExtensions unmarshals data into v (a pointer to a struct),
+// and returns the fields of data that are not fields of v.
+func unmarshalWithExtensions(data []byte, v any) (Extensions, error) {
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v).Elem())
+
+	var extensions Extensions
+
+	for name, value := range fields {
+		// Like encoding/json, the names of the fields are case-insensitive.
+		if _, ok := known[strings.ToLower(name)]; ok {
+			continue
+		}
+
+		if extensions == nil {
+			extensions = make(Extensions)
+		}
+
+		extensions[name] = value
+	}
+
+	return extensions, nil
+}
+
+// marshalWithExtensions marshals v (a struct), and adds the extensions that are not already fields of v.
+func marshalWithExtensions(v any, extensions Extensions) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extensions) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+
+	known := jsonFieldNames(reflect.TypeOf(v))
+
+	for name, value := range extensions {
+		if _, ok := known[strings.ToLower(name)]; ok {
+			continue
+		}
+
+		fields[name] = value
+	}
+
+	return json.Marshal(fields)
+}
+
+// jsonFieldNames returns the lowercase JSON names of the fields of a struct.
+func jsonFieldNames(typ reflect.Type) map[string]struct{} {
+	names := make(map[string]struct{})
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+
+		names[strings.ToLower(name)] = struct{}{}
+	}
+
+	return names
+}
@@ -0,0 +1,111 @@
+package acme
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeta_UnmarshalJSON(t *testing.T) {
+	data := `{
+		"termsOfService": "https://example.com/tos",
+		"profiles": {"classic": "The classic profile"},
+		"x-profile-negotiation": {"default": "classic"},
+		"x-policy": "https://example.com/policy"
+	}`
+
+	var meta Meta
+
+	err := json.Unmarshal([]byte(data), &meta)
+	require.NoError(t, err)
+
+	expected := Meta{
+		TermsOfService: "https://example.com/tos",
+		Profiles:       map[string]string{"classic": "The classic profile"},
+		Extensions: Extensions{
+			"x-profile-negotiation": json.RawMessage(`{"default": "classic"}`),
+			"x-policy":              json.RawMessage(`"https://example.com/policy"`),
+		},
+	}
+
+	assert.Equal(t, expected, meta)
+}
+
+func TestOrder_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		data     string
+		expected Order
+	}{
+		{
+			desc:     "no extensions",
+			data:     `{"status": "pending", "identifiers": [{"type": "dns", "value": "example.com"}]}`,
+			expected: Order{Status: StatusPending, Identifiers: []Identifier{{Type: "dns", Value: "example.com"}}},
+		},
+		{
+			desc: "extensions",
+			data: `{"status": "pending", "Finalize": "https://example.com/finalize", "x-foo": [1, 2]}`,
+			expected: Order{
+				Status:     StatusPending,
+				Finalize:   "https://example.com/finalize",
+				Extensions: Extensions{"x-foo": json.RawMessage(`[1, 2]`)},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var order Order
+
+			err := json.Unmarshal([]byte(test.data), &order)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, order)
+		})
+	}
+}
+
+func TestOrder_MarshalJSON(t *testing.T) {
+	order := Order{
+		Identifiers: []Identifier{{Type: "dns", Value: "example.com"}},
+		Extensions: Extensions{
+			"x-foo":  json.RawMessage(`{"bar":true}`),
+			"Status": json.RawMessage(`"valid"`),
+		},
+	}
+
+	data, err := json.Marshal(order)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"identifiers":[{"type":"dns","value":"example.com"}],"x-foo":{"bar":true}}`, string(data))
+
+	data, err = json.Marshal(Order{Status: StatusValid})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"status":"valid","identifiers":null}`, string(data))
+}
+
+func TestAuthorization_UnmarshalJSON(t *testing.T) {
+	var authz Authorization
+
+	err := json.Unmarshal([]byte(`{"status":"valid","identifier":{"type":"dns","value":"example.com"},"x-validated-by":"dns-01"}`), &authz)
+	require.NoError(t, err)
+
+	assert.Equal(t, StatusValid, authz.Status)
+
+	var validatedBy string
+
+	ok, err := authz.Extensions.Get("x-validated-by", &validatedBy)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	assert.Equal(t, "dns-01", validatedBy)
+
+	ok, err = authz.Extensions.Get("x-missing", &validatedBy)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,44 @@
+package acme
+
+import (
+	"crypto"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	jose "github.com/go-jose/go-jose/v4"
+)
+
+// Thumbprint computes the JWK thumbprint (RFC 7638) of an account key, encoded with base64url (without padding).
+// The key can be a private key (crypto.Signer) or a public key.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-8.1
+func Thumbprint(key any) (string, error) {
+	if signer, ok := key.(crypto.Signer); ok {
+		key = signer.Public()
+	}
+
+	if key == nil {
+		return "", errors.New("thumbprint: nil key")
+	}
+
+	jwk := &jose.JSONWebKey{Key: key}
+
+	thumbBytes, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("thumbprint: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(thumbBytes), nil
+}
+
+// KeyAuthorization computes the key authorization of a challenge token for an account key.
+// The key can be a private key (crypto.Signer) or a public key.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-8.1
+func KeyAuthorization(token string, key any) (string, error) {
+	thumbprint, err := Thumbprint(key)
+	if err != nil {
+		return "", err
+	}
+
+	return token + "." + thumbprint, nil
+}
@@ -0,0 +1,63 @@
+package acme
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// https://www.rfc-editor.org/rfc/rfc7638.html#section-3.1
+const rfc7638Key = `{
+  "kty": "RSA",
+  "n": "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+  "e": "AQAB",
+  "alg": "RS256",
+  "kid": "2011-04-29"
+}`
+
+func TestThumbprint(t *testing.T) {
+	var jwk jose.JSONWebKey
+
+	err := jwk.UnmarshalJSON([]byte(rfc7638Key))
+	require.NoError(t, err)
+
+	thumbprint, err := Thumbprint(jwk.Key)
+	require.NoError(t, err)
+
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", thumbprint)
+}
+
+func TestThumbprint_privateKey(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	fromPrivate, err := Thumbprint(privateKey)
+	require.NoError(t, err)
+
+	fromPublic, err := Thumbprint(privateKey.Public())
+	require.NoError(t, err)
+
+	assert.Equal(t, fromPublic, fromPrivate)
+}
+
+func TestThumbprint_nil(t *testing.T) {
+	_, err := Thumbprint(nil)
+	require.EqualError(t, err, "thumbprint: nil key")
+}
+
+func TestKeyAuthorization(t *testing.T) {
+	var jwk jose.JSONWebKey
+
+	err := jwk.UnmarshalJSON([]byte(rfc7638Key))
+	require.NoError(t, err)
+
+	keyAuth, err := KeyAuthorization("token123", jwk.Key)
+	require.NoError(t, err)
+
+	assert.Equal(t, "token123.NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", keyAuth)
+}
@@ -1,6 +1,8 @@
 package certcrypto
 
 import (
+	"bufio"
+	"bytes"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/ed25519"
@@ -13,6 +15,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"net"
 	"slices"
@@ -30,6 +33,9 @@ const (
 	RSA3072 = KeyType("3072")
 	RSA4096 = KeyType("4096")
 	RSA8192 = KeyType("8192")
+
+	// ED25519 EdDSA with the Ed25519 curve (RFC 8410): not supported by all the CAs (ex: Let's Encrypt).
+	ED25519 = KeyType("ed25519")
 )
 
 const (
@@ -85,6 +91,59 @@ func ParsePEMBundle(bundle []byte) ([]*x509.Certificate, error) {
 	return certificates, nil
 }
 
+// ReadPEMBundle reads a certificate bundle from top to bottom and returns
+// a slice of x509 certificates. This function will error if no certificates are found.
+// Contrary to ParsePEMBundle, the bundle is not loaded in memory: the PEM blocks are decoded one by one.
+func ReadPEMBundle(r io.Reader) ([]*x509.Certificate, error) {
+	var (
+		certificates []*x509.Certificate
+		block        []byte
+	)
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+
+		if block == nil && !bytes.HasPrefix(line, []byte("-----BEGIN ")) {
+			continue
+		}
+
+		block = append(block, line...)
+		block = append(block, '\n')
+
+		if !bytes.HasPrefix(line, []byte("-----END ")) {
+			continue
+		}
+
+		certDERBlock, _ := pem.Decode(block)
+
+		block = nil
+
+		if certDERBlock == nil || certDERBlock.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(certDERBlock.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		certificates = append(certificates, cert)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(certificates) == 0 {
+		return nil, errors.New("no certificates were found while parsing the bundle")
+	}
+
+	return certificates, nil
+}
+
 // ParsePEMPrivateKey parses a private key from key, which is a PEM block.
 // Borrowed from Go standard library, to handle various private key and PEM block types.
 // https://github.com/golang/go/blob/693748e9fa385f1e2c3b91ca9acbb6c0ad2d133d/src/crypto/tls/tls.go#L291-L308
@@ -120,19 +179,35 @@ func ParsePEMPrivateKey(key []byte) (crypto.PrivateKey, error) {
 }
 
 func GeneratePrivateKey(keyType KeyType) (crypto.PrivateKey, error) {
+	return GeneratePrivateKeyWithReader(keyType, rand.Reader)
+}
+
+// GeneratePrivateKeyWithReader generates a private key using random as the source of randomness.
+// If random is nil, crypto/rand.Reader is used.
+//
+// Since Go 1.26, the source of randomness is ignored by the standard library
+// unless the GODEBUG setting cryptocustomrand=1 is used (this is the default for modules declaring go < 1.26).
+func GeneratePrivateKeyWithReader(keyType KeyType, random io.Reader) (crypto.PrivateKey, error) {
+	if random == nil {
+		random = rand.Reader
+	}
+
 	switch keyType {
 	case EC256:
-		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		return ecdsa.GenerateKey(elliptic.P256(), random)
 	case EC384:
-		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		return ecdsa.GenerateKey(elliptic.P384(), random)
 	case RSA2048:
-		return rsa.GenerateKey(rand.Reader, 2048)
+		return rsa.GenerateKey(random, 2048)
 	case RSA3072:
-		return rsa.GenerateKey(rand.Reader, 3072)
+		return rsa.GenerateKey(random, 3072)
 	case RSA4096:
-		return rsa.GenerateKey(rand.Reader, 4096)
+		return rsa.GenerateKey(random, 4096)
 	case RSA8192:
-		return rsa.GenerateKey(rand.Reader, 8192)
+		return rsa.GenerateKey(random, 8192)
+	case ED25519:
+		_, privateKey, err := ed25519.GenerateKey(random)
+		return privateKey, err
 	}
 
 	return nil, fmt.Errorf("invalid KeyType: %s", keyType)
@@ -152,18 +227,34 @@ type CSROptions struct {
 	SAN            []string
 	MustStaple     bool
 	EmailAddresses []string
+
+	// Rand the source of randomness used to sign the CSR.
+	// If nil, crypto/rand.Reader is used.
+	Rand io.Reader
+
+	// MutateTemplate is called with the template before the CSR is signed (ex: to set subject fields or extensions).
+	MutateTemplate func(template *x509.CertificateRequest)
 }
 
 func CreateCSR(privateKey crypto.PrivateKey, opts CSROptions) ([]byte, error) {
 	var (
-		dnsNames    []string
-		ipAddresses []net.IP
+		dnsNames       []string
+		ipAddresses    []net.IP
+		emailAddresses = slices.Clone(opts.EmailAddresses)
 	)
 
 	for _, altname := range opts.SAN {
-		if ip := net.ParseIP(altname); ip != nil {
-			ipAddresses = append(ipAddresses, ip)
-		} else {
+		switch {
+		case net.ParseIP(altname) != nil:
+			ipAddresses = append(ipAddresses, net.ParseIP(altname))
+
+		case strings.Contains(altname, "@"):
+			// S/MIME certificates (RFC 8823).
+			if !slices.Contains(emailAddresses, altname) {
+				emailAddresses = append(emailAddresses, altname)
+			}
+
+		default:
 			dnsNames = append(dnsNames, altname)
 		}
 	}
@@ -171,7 +262,7 @@ func CreateCSR(privateKey crypto.PrivateKey, opts CSROptions) ([]byte, error) {
 	template := x509.CertificateRequest{
 		Subject:        pkix.Name{CommonName: opts.Domain},
 		DNSNames:       dnsNames,
-		EmailAddresses: opts.EmailAddresses,
+		EmailAddresses: emailAddresses,
 		IPAddresses:    ipAddresses,
 	}
 
@@ -182,7 +273,16 @@ func CreateCSR(privateKey crypto.PrivateKey, opts CSROptions) ([]byte, error) {
 		})
 	}
 
-	return x509.CreateCertificateRequest(rand.Reader, &template, privateKey)
+	if opts.MutateTemplate != nil {
+		opts.MutateTemplate(&template)
+	}
+
+	random := opts.Rand
+	if random == nil {
+		random = rand.Reader
+	}
+
+	return x509.CreateCertificateRequest(random, &template, privateKey)
 }
 
 func PEMEncode(data any) []byte {
@@ -198,6 +298,9 @@ func PEMBlock(data any) *pem.Block {
 		pemBlock = &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}
 	case *rsa.PrivateKey:
 		pemBlock = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	case ed25519.PrivateKey:
+		keyBytes, _ := x509.MarshalPKCS8PrivateKey(key)
+		pemBlock = &pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes}
 	case *x509.CertificateRequest:
 		pemBlock = &pem.Block{Type: "CERTIFICATE REQUEST", Bytes: key.Raw}
 	case DERCertificateBytes:
@@ -241,6 +344,28 @@ func ParsePEMCertificate(cert []byte) (*x509.Certificate, error) {
 	return x509.ParseCertificate(pemBlock.Bytes)
 }
 
+// MatchPrivateKey checks that the private key is the key of the certificate.
+func MatchPrivateKey(cert *x509.Certificate, privateKey crypto.PrivateKey) error {
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return errors.New("unsupported private key type")
+	}
+
+	pub, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !pub.Equal(signer.Public()) {
+		return errors.New("the private key doesn't match the certificate")
+	}
+
+	return nil
+}
+
+// HasMustStaple reports whether the certificate includes the OCSP must staple TLS feature extension (RFC 7633).
+func HasMustStaple(cert *x509.Certificate) bool {
+	return slices.ContainsFunc(cert.Extensions, func(ext pkix.Extension) bool {
+		return ext.Id.Equal(tlsFeatureExtensionOID) && bytes.Equal(ext.Value, ocspMustStapleFeature)
+	})
+}
+
 func GetCertificateMainDomain(cert *x509.Certificate) (string, error) {
 	return getMainDomain(cert.Subject, cert.DNSNames, cert.IPAddresses)
 }
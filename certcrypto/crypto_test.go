@@ -3,12 +3,17 @@ package certcrypto
 import (
 	"bytes"
 	"crypto"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/pem"
 	"testing"
 	"time"
 
+	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -27,6 +32,26 @@ func TestGeneratePrivateKey(t *testing.T) {
 	assert.NotNil(t, key)
 }
 
+func TestGeneratePrivateKeyWithReader(t *testing.T) {
+	for _, keyType := range []KeyType{EC256, RSA2048, ED25519} {
+		t.Run(string(keyType), func(t *testing.T) {
+			t.Parallel()
+
+			key1, err := GeneratePrivateKeyWithReader(keyType, tester.NewRand("lego"))
+			require.NoError(t, err)
+
+			key2, err := GeneratePrivateKeyWithReader(keyType, tester.NewRand("lego"))
+			require.NoError(t, err)
+
+			key3, err := GeneratePrivateKeyWithReader(keyType, tester.NewRand("other"))
+			require.NoError(t, err)
+
+			assert.Equal(t, key1, key2)
+			assert.NotEqual(t, key1, key3)
+		})
+	}
+}
+
 func TestGenerateCSR(t *testing.T) {
 	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
 	require.NoError(t, err, "Error generating private key")
@@ -129,6 +154,54 @@ func TestGenerateCSR(t *testing.T) {
 	}
 }
 
+func TestCreateCSR_mutateTemplate(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err, "Error generating private key")
+
+	extension := pkix.Extension{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}, Value: []byte{0x05, 0x00}}
+
+	raw, err := CreateCSR(privateKey, CSROptions{
+		Domain: testDomain1,
+		SAN:    []string{testDomain1},
+		MutateTemplate: func(template *x509.CertificateRequest) {
+			template.Subject.Organization = []string{"Lego"}
+			template.Subject.OrganizationalUnit = []string{"Tests"}
+			template.Subject.Country = []string{"FR"}
+			template.ExtraExtensions = append(template.ExtraExtensions, extension)
+		},
+	})
+	require.NoError(t, err)
+
+	csr, err := x509.ParseCertificateRequest(raw)
+	require.NoError(t, err)
+
+	assert.Equal(t, testDomain1, csr.Subject.CommonName)
+	assert.Equal(t, []string{"Lego"}, csr.Subject.Organization)
+	assert.Equal(t, []string{"Tests"}, csr.Subject.OrganizationalUnit)
+	assert.Equal(t, []string{"FR"}, csr.Subject.Country)
+	assert.Equal(t, []string{testDomain1}, csr.DNSNames)
+	assert.Contains(t, csr.Extensions, extension)
+}
+
+func TestCreateCSR_emailSAN(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err, "Error generating private key")
+
+	raw, err := CreateCSR(privateKey, CSROptions{
+		Domain:         "foo@example.com",
+		SAN:            []string{"foo@example.com", "bar@example.com", testDomain1},
+		EmailAddresses: []string{"bar@example.com"},
+	})
+	require.NoError(t, err)
+
+	csr, err := x509.ParseCertificateRequest(raw)
+	require.NoError(t, err)
+
+	assert.Equal(t, "foo@example.com", csr.Subject.CommonName)
+	assert.Equal(t, []string{"bar@example.com", "foo@example.com"}, csr.EmailAddresses)
+	assert.Equal(t, []string{testDomain1}, csr.DNSNames)
+}
+
 func TestPEMEncode(t *testing.T) {
 	key, err := rsa.GenerateKey(rand.Reader, 1024)
 	require.NoError(t, err, "Error generating private key")
@@ -169,6 +242,60 @@ func TestParsePEMCertificate(t *testing.T) {
 	assert.Equal(t, expiration.UTC(), cert.NotAfter)
 }
 
+func TestHasMustStaple(t *testing.T) {
+	privateKey, err := GeneratePrivateKey(RSA2048)
+	require.NoError(t, err, "Error generating private key")
+
+	certBytes, err := generateDerCert(privateKey.(*rsa.PrivateKey), time.Now().Add(time.Hour), "test.com", nil)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+
+	assert.False(t, HasMustStaple(cert))
+
+	extensions := []pkix.Extension{{Id: tlsFeatureExtensionOID, Value: ocspMustStapleFeature}}
+
+	certBytes, err = generateDerCert(privateKey.(*rsa.PrivateKey), time.Now().Add(time.Hour), "test.com", extensions)
+	require.NoError(t, err)
+
+	cert, err = x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+
+	assert.True(t, HasMustStaple(cert))
+}
+
+func TestReadPEMBundle(t *testing.T) {
+	privateKey, err := GeneratePrivateKey(RSA2048)
+	require.NoError(t, err, "Error generating private key")
+
+	cert1, err := generateDerCert(privateKey.(*rsa.PrivateKey), time.Time{}, "a.test.com", nil)
+	require.NoError(t, err, "Error generating cert")
+
+	cert2, err := generateDerCert(privateKey.(*rsa.PrivateKey), time.Time{}, "b.test.com", nil)
+	require.NoError(t, err, "Error generating cert")
+
+	bundle := bytes.Join([][]byte{
+		[]byte("comment\n"),
+		PEMEncode(DERCertificateBytes(cert1)),
+		PEMEncode(privateKey),
+		[]byte("another comment\n"),
+		PEMEncode(DERCertificateBytes(cert2)),
+	}, nil)
+
+	expected, err := ParsePEMBundle(bundle)
+	require.NoError(t, err)
+
+	certs, err := ReadPEMBundle(bytes.NewReader(bundle))
+	require.NoError(t, err)
+
+	require.Len(t, certs, 2)
+	assert.Equal(t, expected, certs)
+
+	_, err = ReadPEMBundle(bytes.NewReader(PEMEncode(privateKey)))
+	require.EqualError(t, err, "no certificates were found while parsing the bundle")
+}
+
 func TestParsePEMPrivateKey(t *testing.T) {
 	privateKey, err := GeneratePrivateKey(RSA2048)
 	require.NoError(t, err, "Error generating private key")
@@ -195,3 +322,20 @@ func TestParsePEMPrivateKey(t *testing.T) {
 	_, err = ParsePEMPrivateKey([]byte("This is not PEM"))
 	require.Errorf(t, err, "Expected to return an error for non-PEM input")
 }
+
+func TestParsePEMPrivateKey_ed25519(t *testing.T) {
+	privateKey, err := GeneratePrivateKey(ED25519)
+	require.NoError(t, err)
+
+	pemPrivateKey := PEMEncode(privateKey)
+
+	block, _ := pem.Decode(pemPrivateKey)
+	require.NotNil(t, block)
+	assert.Equal(t, "PRIVATE KEY", block.Type)
+
+	decoded, err := ParsePEMPrivateKey(pemPrivateKey)
+	require.NoError(t, err)
+
+	require.IsType(t, ed25519.PrivateKey{}, decoded)
+	assert.True(t, decoded.(ed25519.PrivateKey).Equal(privateKey))
+}
@@ -0,0 +1,85 @@
+package certcrypto
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// sctListExtensionOID the extension of the embedded Signed Certificate Timestamps (RFC 6962 section 3.3).
+var sctListExtensionOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+// SignedCertificateTimestamp an SCT embedded inside a certificate:
+// the promise of a Certificate Transparency log to include the certificate (RFC 6962).
+type SignedCertificateTimestamp struct {
+	// Version the version of the SCT (0 for v1).
+	Version uint8
+
+	// LogID the SHA-256 hash of the public key of the log.
+	LogID []byte
+
+	Timestamp time.Time
+}
+
+// ExtractSCTs returns the Signed Certificate Timestamps embedded inside the certificate.
+// The signatures of the SCTs are not verified.
+func ExtractSCTs(cert *x509.Certificate) ([]SignedCertificateTimestamp, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(sctListExtensionOID) {
+			continue
+		}
+
+		var raw []byte
+
+		rest, err := asn1.Unmarshal(ext.Value, &raw)
+		if err != nil {
+			return nil, fmt.Errorf("SCT list: %w", err)
+		}
+
+		if len(rest) > 0 {
+			return nil, errors.New("SCT list: trailing data")
+		}
+
+		return parseSCTList(raw)
+	}
+
+	return nil, nil
+}
+
+// parseSCTList parses a SignedCertificateTimestampList (RFC 6962 section 3.3).
+func parseSCTList(data []byte) ([]SignedCertificateTimestamp, error) {
+	input := cryptobyte.String(data)
+
+	var list cryptobyte.String
+	if !input.ReadUint16LengthPrefixed(&list) || !input.Empty() {
+		return nil, errors.New("SCT list: invalid length")
+	}
+
+	var scts []SignedCertificateTimestamp
+
+	for !list.Empty() {
+		var raw cryptobyte.String
+		if !list.ReadUint16LengthPrefixed(&raw) {
+			return nil, errors.New("SCT list: invalid SCT length")
+		}
+
+		var (
+			sct       SignedCertificateTimestamp
+			timestamp uint64
+		)
+
+		if !raw.ReadUint8(&sct.Version) || !raw.ReadBytes(&sct.LogID, 32) || !raw.ReadUint64(&timestamp) {
+			return nil, errors.New("SCT list: invalid SCT")
+		}
+
+		sct.Timestamp = time.UnixMilli(int64(timestamp)).UTC()
+
+		scts = append(scts, sct)
+	}
+
+	return scts, nil
+}
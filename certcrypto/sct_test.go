@@ -0,0 +1,91 @@
+package certcrypto
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/cryptobyte"
+)
+
+func TestExtractSCTs(t *testing.T) {
+	timestamp := time.Date(2025, time.March, 14, 10, 20, 30, 0, time.UTC)
+
+	logID1 := bytes.Repeat([]byte{0x01}, 32)
+	logID2 := bytes.Repeat([]byte{0x02}, 32)
+
+	var b cryptobyte.Builder
+
+	b.AddUint16LengthPrefixed(func(list *cryptobyte.Builder) {
+		for _, logID := range [][]byte{logID1, logID2} {
+			list.AddUint16LengthPrefixed(func(sct *cryptobyte.Builder) {
+				sct.AddUint8(0)
+				sct.AddBytes(logID)
+				sct.AddUint64(uint64(timestamp.UnixMilli()))
+				// extensions
+				sct.AddUint16(0)
+				// signature: hash and signature algorithms, then the signature.
+				sct.AddUint8(4)
+				sct.AddUint8(3)
+				sct.AddUint16LengthPrefixed(func(sig *cryptobyte.Builder) {
+					sig.AddBytes([]byte("signature"))
+				})
+			})
+		}
+	})
+
+	value, err := asn1.Marshal(b.BytesOrPanic())
+	require.NoError(t, err)
+
+	cert := createTestCertificate(t, []pkix.Extension{{Id: sctListExtensionOID, Value: value}})
+
+	scts, err := ExtractSCTs(cert)
+	require.NoError(t, err)
+
+	expected := []SignedCertificateTimestamp{
+		{Version: 0, LogID: logID1, Timestamp: timestamp},
+		{Version: 0, LogID: logID2, Timestamp: timestamp},
+	}
+
+	assert.Equal(t, expected, scts)
+}
+
+func TestExtractSCTs_none(t *testing.T) {
+	cert := createTestCertificate(t, nil)
+
+	scts, err := ExtractSCTs(cert)
+	require.NoError(t, err)
+
+	assert.Empty(t, scts)
+}
+
+func TestExtractSCTs_invalid(t *testing.T) {
+	value, err := asn1.Marshal([]byte{0x00, 0x05, 0x00})
+	require.NoError(t, err)
+
+	cert := createTestCertificate(t, []pkix.Extension{{Id: sctListExtensionOID, Value: value}})
+
+	_, err = ExtractSCTs(cert)
+	require.EqualError(t, err, "SCT list: invalid length")
+}
+
+func createTestCertificate(t *testing.T, extensions []pkix.Extension) *x509.Certificate {
+	t.Helper()
+
+	privateKey, err := GeneratePrivateKey(RSA2048)
+	require.NoError(t, err)
+
+	der, err := generateDerCert(privateKey.(*rsa.PrivateKey), time.Now().Add(time.Hour), "test.com", extensions)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
@@ -0,0 +1,57 @@
+package certcrypto
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+)
+
+// TLSA certificate usages (RFC 6698 and RFC 7218).
+const (
+	TLSAUsagePKIXTA = 0
+	TLSAUsagePKIXEE = 1
+	TLSAUsageDANETA = 2
+	TLSAUsageDANEEE = 3
+)
+
+// TLSA selectors (RFC 6698 and RFC 7218).
+const (
+	TLSASelectorCert = 0
+	TLSASelectorSPKI = 1
+)
+
+// TLSA matching types (RFC 6698 and RFC 7218).
+const (
+	TLSAMatchingFull   = 0
+	TLSAMatchingSHA256 = 1
+	TLSAMatchingSHA512 = 2
+)
+
+// TLSAData computes the "Certificate Association Data" of a TLSA record (hex encoded).
+func TLSAData(cert *x509.Certificate, selector, matchingType uint8) (string, error) {
+	var data []byte
+
+	switch selector {
+	case TLSASelectorCert:
+		data = cert.Raw
+	case TLSASelectorSPKI:
+		data = cert.RawSubjectPublicKeyInfo
+	default:
+		return "", fmt.Errorf("unsupported TLSA selector: %d", selector)
+	}
+
+	switch matchingType {
+	case TLSAMatchingFull:
+		return hex.EncodeToString(data), nil
+	case TLSAMatchingSHA256:
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:]), nil
+	case TLSAMatchingSHA512:
+		sum := sha512.Sum512(data)
+		return hex.EncodeToString(sum[:]), nil
+	default:
+		return "", fmt.Errorf("unsupported TLSA matching type: %d", matchingType)
+	}
+}
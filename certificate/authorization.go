@@ -1,13 +1,13 @@
 package certificate
 
 import (
+	"context"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
-	"github.com/go-acme/lego/v4/log"
 )
 
-func (c *Certifier) getAuthorizations(order acme.ExtendedOrder) ([]acme.Authorization, error) {
+func (c *Certifier) getAuthorizations(ctx context.Context, order acme.ExtendedOrder) ([]acme.Authorization, error) {
 	resc, errc := make(chan acme.Authorization), make(chan domainError)
 
 	delay := time.Second / time.Duration(c.overallRequestLimit)
@@ -16,7 +16,7 @@ func (c *Certifier) getAuthorizations(order acme.ExtendedOrder) ([]acme.Authoriz
 		time.Sleep(delay)
 
 		go func(authzURL string) {
-			authz, err := c.core.Authorizations.Get(authzURL)
+			authz, err := c.core.Authorizations.GetContext(ctx, authzURL)
 			if err != nil {
 				errc <- domainError{Domain: authz.Identifier.Value, Error: err}
 				return
@@ -40,7 +40,7 @@ func (c *Certifier) getAuthorizations(order acme.ExtendedOrder) ([]acme.Authoriz
 	}
 
 	for i, auth := range order.Authorizations {
-		log.Infof("[%s] AuthURL: %s", order.Identifiers[i].Value, auth)
+		c.core.Log().With("domain", order.Identifiers[i].Value, "order", order.Location).Infof("[%s] AuthURL: %s", order.Identifiers[i].Value, auth)
 	}
 
 	close(resc)
@@ -49,23 +49,46 @@ func (c *Certifier) getAuthorizations(order acme.ExtendedOrder) ([]acme.Authoriz
 	return responses, failures.Join()
 }
 
-func (c *Certifier) deactivateAuthorizations(order acme.ExtendedOrder, force bool) {
+// deactivateFailedAuthorizations relinquishes the authorizations of a failed order, unless keep is true.
+func (c *Certifier) deactivateFailedAuthorizations(ctx context.Context, order acme.ExtendedOrder, force, keep bool) {
+	if keep {
+		c.core.Log().With("order", order.Location).Infof("Keeping the authorizations of the failed order: %s", order.Location)
+		return
+	}
+
+	c.deactivateAuthorizations(ctx, order, force)
+}
+
+// deactivateAuthorizations relinquishes the pending authorizations of the order,
+// and the valid authorizations if force is true.
+// The other authorizations (invalid, expired, etc.) cannot be deactivated.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-7.5.2
+func (c *Certifier) deactivateAuthorizations(ctx context.Context, order acme.ExtendedOrder, force bool) {
+	// The authorizations are relinquished even if the context is canceled.
+	ctx = context.WithoutCancel(ctx)
+
+	logger := c.core.Log().With("order", order.Location)
+
 	for _, authzURL := range order.Authorizations {
-		auth, err := c.core.Authorizations.Get(authzURL)
+		auth, err := c.core.Authorizations.GetContext(ctx, authzURL)
 		if err != nil {
-			log.Infof("Unable to get the authorization for %s: %v", authzURL, err)
+			logger.Infof("Unable to get the authorization for %s: %v", authzURL, err)
 			continue
 		}
 
 		if auth.Status == acme.StatusValid && !force {
-			log.Infof("Skipping deactivating of valid auth: %s", authzURL)
+			logger.Infof("Skipping deactivating of valid auth: %s", authzURL)
+			continue
+		}
+
+		if auth.Status != acme.StatusValid && auth.Status != acme.StatusPending {
 			continue
 		}
 
-		log.Infof("Deactivating auth: %s", authzURL)
+		logger.Infof("Deactivating auth: %s", authzURL)
 
-		if c.core.Authorizations.Deactivate(authzURL) != nil {
-			log.Infof("Unable to deactivate the authorization: %s", authzURL)
+		if c.core.Authorizations.DeactivateContext(ctx, authzURL) != nil {
+			logger.Infof("Unable to deactivate the authorization: %s", authzURL)
 		}
 	}
 }
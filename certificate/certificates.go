@@ -2,13 +2,17 @@ package certificate
 
 import (
 	"bytes"
+	"cmp"
+	"context"
 	"crypto"
 	"crypto/x509"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
@@ -17,7 +21,10 @@ import (
 	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/metrics"
 	"github.com/go-acme/lego/v4/platform/wait"
+	"github.com/go-acme/lego/v4/tracing"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/crypto/ocsp"
 	"golang.org/x/net/idna"
 )
@@ -49,12 +56,42 @@ type Resource struct {
 	Certificate       []byte `json:"-"`
 	IssuerCertificate []byte `json:"-"`
 	CSR               []byte `json:"-"`
+
+	// DroppedDomains the domains removed from the order because their validation failed.
+	// Only used with ObtainRequest.AllowPartial.
+	DroppedDomains []string `json:"-"`
+
+	// StarCertificateURL the URL of the last certificate issued by an auto-renewal (STAR) order.
+	// Only used with ObtainRequest.AutoRenewal.
+	StarCertificateURL string `json:"starCertificateUrl,omitempty"`
+
+	// OrderURL the URL of the auto-renewal (STAR) order, used to cancel the order.
+	// Only used with ObtainRequest.AutoRenewal.
+	OrderURL string `json:"orderUrl,omitempty"`
+
+	// SANOrder the order of the SANs of the CSR (ObtainRequest.SANOrder).
+	SANOrder SANOrder `json:"sanOrder,omitempty"`
 }
 
+// SANOrder the order of the Subject Alternative Names of the CSR.
+//
+// The CommonName is always the first SAN.
+// Some legacy clients depend on the order of the subjectAltName extension of the certificate.
+type SANOrder string
+
+const (
+	// SANOrderCA keeps the order of the identifiers returned by the CA inside the order (default).
+	SANOrderCA SANOrder = "ca"
+	// SANOrderSorted sorts the identifiers by type, then by value:
+	// the SANs are the same for every order of the same domains.
+	SANOrderSorted SANOrder = "sorted"
+)
+
 // ObtainRequest The request to obtain certificate.
 //
 // The first domain in domains is used for the CommonName field of the certificate,
 // all other domains are added using the Subject Alternate Names extension.
+// The domains can be IP addresses (RFC 8738): they are validated with the HTTP-01 or TLS-ALPN-01 challenges only.
 //
 // A new private key is generated for every invocation of the function Obtain.
 // If you do not want that you can supply your own private key in the privateKey parameter.
@@ -62,8 +99,13 @@ type Resource struct {
 //
 // If `Bundle` is true, the `[]byte` contains both the issuer certificate and your issued certificate as a bundle.
 //
+// By default, the pending authorizations are relinquished if the obtain request fails,
+// unless `KeepFailedAuthorizations` is true.
 // If `AlwaysDeactivateAuthorizations` is true, the authorizations are also relinquished if the obtain request was successful.
 // See https://datatracker.ietf.org/doc/html/rfc8555#section-7.5.2.
+//
+// If `AllowPartial` is true, and the validation of some domains fails,
+// the order is retried without these domains (the dropped domains are reported inside Resource.DroppedDomains).
 type ObtainRequest struct {
 	Domains        []string
 	PrivateKey     crypto.PrivateKey
@@ -81,22 +123,44 @@ type ObtainRequest struct {
 	Profile string
 
 	AlwaysDeactivateAuthorizations bool
+	KeepFailedAuthorizations       bool
 
 	// A string uniquely identifying a previously-issued certificate which this
 	// order is intended to replace.
 	// - https://www.rfc-editor.org/rfc/rfc9773.html#section-5
 	ReplacesCertID string
+
+	AllowPartial bool
+
+	// AutoRenewal requests a Short-Term, Automatically Renewed (STAR) certificate (RFC 8739):
+	// the CA issues a new certificate at every lifetime until the end date, without new validations.
+	// The new certificates are fetched with Certifier.GetStarCertificate.
+	AutoRenewal *api.AutoRenewalOptions
+
+	// MutateCSRTemplate is called with the template of the CSR before it's signed,
+	// to set the subject fields (ex: O, OU, C) or the extra extensions expected by a private CA.
+	// The subject CommonName and the SANs are already set.
+	MutateCSRTemplate func(template *x509.CertificateRequest)
+
+	// SANOrder the order of the SANs of the CSR (default: SANOrderCA).
+	SANOrder SANOrder
 }
 
 // ObtainForCSRRequest The request to obtain a certificate matching the CSR passed into it.
 //
 // If `Bundle` is true, the `[]byte` contains both the issuer certificate and your issued certificate as a bundle.
 //
+// By default, the pending authorizations are relinquished if the obtain request fails,
+// unless `KeepFailedAuthorizations` is true.
 // If `AlwaysDeactivateAuthorizations` is true, the authorizations are also relinquished if the obtain request was successful.
 // See https://datatracker.ietf.org/doc/html/rfc8555#section-7.5.2.
 type ObtainForCSRRequest struct {
 	CSR *x509.CertificateRequest
 
+	// Identifiers the identifiers of the order (ex: `permanent-identifier` for a device certificate).
+	// If empty, the identifiers are the domains and the IP addresses of the CSR.
+	Identifiers []acme.Identifier
+
 	PrivateKey crypto.PrivateKey
 
 	NotBefore      time.Time
@@ -110,22 +174,63 @@ type ObtainForCSRRequest struct {
 	Profile string
 
 	AlwaysDeactivateAuthorizations bool
+	KeepFailedAuthorizations       bool
 
 	// A string uniquely identifying a previously-issued certificate which this
 	// order is intended to replace.
 	// - https://www.rfc-editor.org/rfc/rfc9773.html#section-5
 	ReplacesCertID string
+
+	// AutoRenewal requests a Short-Term, Automatically Renewed (STAR) certificate (RFC 8739).
+	AutoRenewal *api.AutoRenewalOptions
+}
+
+// domains returns the domains of the request: the values of the identifiers, or the domains of the CSR.
+func (r ObtainForCSRRequest) domains() []string {
+	if len(r.Identifiers) > 0 {
+		var domains []string
+
+		for _, identifier := range r.Identifiers {
+			domains = append(domains, identifier.Value)
+		}
+
+		return domains
+	}
+
+	if r.CSR == nil {
+		return nil
+	}
+
+	// figure out what domains it concerns
+	// start with the common name
+	return certcrypto.ExtractDomainsCSR(r.CSR)
 }
 
 type resolver interface {
 	Solve(authorizations []acme.Authorization) error
 }
 
+// contextResolver is a resolver able to cancel the resolution of the challenges.
+type contextResolver interface {
+	SolveContext(ctx context.Context, authorizations []acme.Authorization) error
+}
+
 type CertifierOptions struct {
 	KeyType             certcrypto.KeyType
 	Timeout             time.Duration
 	OverallRequestLimit int
 	DisableCommonName   bool
+
+	// Rand the source of randomness used to generate the private keys and the CSRs.
+	// If nil, crypto/rand.Reader is used.
+	Rand io.Reader
+
+	// LowMemory fetches the certificate chains one by one instead of keeping all the chains in memory.
+	LowMemory bool
+
+	// Events receives the events of the issuance lifecycle.
+	// If nil, the events are ignored.
+	Events Events
 }
 
 // Certifier A service to obtain/renew/revoke certificates.
@@ -152,21 +257,55 @@ func NewCertifier(core *api.Core, resolver resolver, options CertifierOptions) *
 	return c
 }
 
+// solve resolves the challenges of the authorizations, with the context when the resolver supports it.
+func (c *Certifier) solve(ctx context.Context, authorizations []acme.Authorization) error {
+	if r, ok := c.resolver.(contextResolver); ok {
+		return r.SolveContext(ctx, authorizations)
+	}
+
+	return c.resolver.Solve(authorizations)
+}
+
 // Obtain tries to obtain a single certificate using all domains passed into it.
 //
-// This function will never return a partial certificate.
+// This function will never return a partial certificate, unless `AllowPartial` is true.
 // If one domain in the list fails, the whole certificate will fail.
 func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
+	return c.ObtainContext(context.Background(), request)
+}
+
+// ObtainContext is like [Certifier.Obtain] with a context.
+func (c *Certifier) ObtainContext(ctx context.Context, request ObtainRequest) (*Resource, error) {
+	start := time.Now()
+
+	ctx, span := c.core.Tracer().Start(ctx, tracing.SpanObtain, trace.WithAttributes(tracing.AttrDomains.StringSlice(request.Domains)))
+
+	cert, err := c.obtain(ctx, request)
+
+	tracing.End(span, err)
+
+	c.core.Metrics().ObserveOrder(metrics.Result(err), time.Since(start))
+
+	c.notifyObtain(ctx, request.Domains, cert, err)
+
+	return cert, err
+}
+
+func (c *Certifier) obtain(ctx context.Context, request ObtainRequest) (*Resource, error) {
 	if len(request.Domains) == 0 {
 		return nil, errors.New("no domains to obtain a certificate for")
 	}
 
+	if request.SANOrder != "" && request.SANOrder != SANOrderCA && request.SANOrder != SANOrderSorted {
+		return nil, fmt.Errorf("unknown SAN order: %q", request.SANOrder)
+	}
+
 	domains := sanitizeDomain(request.Domains)
 
 	if request.Bundle {
-		log.Infof("[%s] acme: Obtaining bundled SAN certificate", strings.Join(domains, ", "))
+		c.core.Log().Infof("[%s] acme: Obtaining bundled SAN certificate", strings.Join(domains, ", "))
 	} else {
-		log.Infof("[%s] acme: Obtaining SAN certificate", strings.Join(domains, ", "))
+		c.core.Log().Infof("[%s] acme: Obtaining SAN certificate", strings.Join(domains, ", "))
 	}
 
 	orderOpts := &api.OrderOptions{
@@ -174,32 +313,40 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 		NotAfter:       request.NotAfter,
 		Profile:        request.Profile,
 		ReplacesCertID: request.ReplacesCertID,
+		AutoRenewal:    request.AutoRenewal,
 	}
 
-	order, err := c.core.Orders.NewWithOptions(domains, orderOpts)
+	order, err := c.core.Orders.NewWithOptionsContext(ctx, domains, orderOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	authz, err := c.getAuthorizations(order)
+	c.events().OrderCreated(ctx, OrderEvent{Domains: domains, OrderURL: order.Location})
+
+	authz, err := c.getAuthorizations(ctx, order)
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
-		c.deactivateAuthorizations(order, request.AlwaysDeactivateAuthorizations)
+		c.deactivateFailedAuthorizations(ctx, order, request.AlwaysDeactivateAuthorizations, request.KeepFailedAuthorizations)
 		return nil, err
 	}
 
-	err = c.resolver.Solve(authz)
+	err = c.solve(ctx, authz)
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
-		c.deactivateAuthorizations(order, request.AlwaysDeactivateAuthorizations)
+		c.deactivateFailedAuthorizations(ctx, order, request.AlwaysDeactivateAuthorizations, request.KeepFailedAuthorizations)
+
+		if request.AllowPartial {
+			return c.obtainPartial(ctx, request, domains, err)
+		}
+
 		return nil, err
 	}
 
-	log.Infof("[%s] acme: Validations succeeded; requesting certificates", strings.Join(domains, ", "))
+	c.core.Log().With("order", order.Location).Infof("[%s] acme: Validations succeeded; requesting certificates", strings.Join(domains, ", "))
 
 	failures := newObtainError()
 
-	cert, err := c.getForOrder(domains, order, request)
+	cert, err := c.getForOrder(ctx, domains, order, request)
 	if err != nil {
 		for _, auth := range authz {
 			failures.Add(challenge.GetTargetedDomain(auth), err)
@@ -207,12 +354,62 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 	}
 
 	if request.AlwaysDeactivateAuthorizations {
-		c.deactivateAuthorizations(order, true)
+		c.deactivateAuthorizations(ctx, order, true)
 	}
 
 	return cert, failures.Join()
 }
 
+// notifyObtain sends the result of a certificate request to the events.
+func (c *Certifier) notifyObtain(ctx context.Context, domains []string, cert *Resource, err error) {
+	if err != nil {
+		c.events().ObtainFailed(ctx, CertEvent{Domains: domains, Err: err})
+		return
+	}
+
+	c.events().CertObtained(ctx, CertEvent{Domains: domains, Resource: cert})
+}
+
+func (c *Certifier) events() Events {
+	return EventsOrNoop(c.options.Events)
+}
+
+// obtainPartial retries the order without the domains that failed the validation.
+func (c *Certifier) obtainPartial(ctx context.Context, request ObtainRequest, domains []string, solveErr error) (*Resource, error) {
+	var failures interface{ Domains() []string }
+	if !errors.As(solveErr, &failures) {
+		return nil, solveErr
+	}
+
+	dropped := failures.Domains()
+
+	var remaining []string
+
+	for _, domain := range domains {
+		if !slices.Contains(dropped, domain) {
+			remaining = append(remaining, domain)
+		}
+	}
+
+	if len(remaining) == 0 || len(remaining) == len(domains) {
+		return nil, solveErr
+	}
+
+	c.core.Log().Warnf("[%s] acme: Validations failed; retrying the order without: %s",
+		strings.Join(remaining, ", "), strings.Join(dropped, ", "))
+
+	request.Domains = remaining
+
+	cert, err := c.obtain(ctx, request)
+	if err != nil {
+		return cert, err
+	}
+
+	cert.DroppedDomains = append(dropped, cert.DroppedDomains...)
+
+	return cert, nil
+}
+
 // ObtainForCSR tries to obtain a certificate matching the CSR passed into it.
 //
 // The domains are inferred from the CommonName and SubjectAltNames, if any.
@@ -223,18 +420,37 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 // This function will never return a partial certificate.
 // If one domain in the list fails, the whole certificate will fail.
 func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error) {
+	return c.ObtainForCSRContext(context.Background(), request)
+}
+
+// ObtainForCSRContext is like [Certifier.ObtainForCSR] with a context.
+func (c *Certifier) ObtainForCSRContext(ctx context.Context, request ObtainForCSRRequest) (*Resource, error) {
+	start := time.Now()
+
+	ctx, span := c.core.Tracer().Start(ctx, tracing.SpanObtainForCSR)
+
+	cert, err := c.obtainForCSR(ctx, request)
+
+	tracing.End(span, err)
+
+	c.core.Metrics().ObserveOrder(metrics.Result(err), time.Since(start))
+
+	c.notifyObtain(ctx, request.domains(), cert, err)
+
+	return cert, err
+}
+
+func (c *Certifier) obtainForCSR(ctx context.Context, request ObtainForCSRRequest) (*Resource, error) {
 	if request.CSR == nil {
 		return nil, errors.New("cannot obtain resource for CSR: CSR is missing")
 	}
 
-	// figure out what domains it concerns
-	// start with the common name
-	domains := certcrypto.ExtractDomainsCSR(request.CSR)
+	domains := request.domains()
 
 	if request.Bundle {
-		log.Infof("[%s] acme: Obtaining bundled SAN certificate given a CSR", strings.Join(domains, ", "))
+		c.core.Log().Infof("[%s] acme: Obtaining bundled SAN certificate given a CSR", strings.Join(domains, ", "))
 	} else {
-		log.Infof("[%s] acme: Obtaining SAN certificate given a CSR", strings.Join(domains, ", "))
+		c.core.Log().Infof("[%s] acme: Obtaining SAN certificate given a CSR", strings.Join(domains, ", "))
 	}
 
 	orderOpts := &api.OrderOptions{
@@ -242,28 +458,41 @@ func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error)
 		NotAfter:       request.NotAfter,
 		Profile:        request.Profile,
 		ReplacesCertID: request.ReplacesCertID,
+		AutoRenewal:    request.AutoRenewal,
+	}
+
+	var (
+		order acme.ExtendedOrder
+		err   error
+	)
+
+	if len(request.Identifiers) > 0 {
+		order, err = c.core.Orders.NewWithIdentifiers(ctx, request.Identifiers, orderOpts)
+	} else {
+		order, err = c.core.Orders.NewWithOptionsContext(ctx, domains, orderOpts)
 	}
 
-	order, err := c.core.Orders.NewWithOptions(domains, orderOpts)
 	if err != nil {
 		return nil, err
 	}
 
-	authz, err := c.getAuthorizations(order)
+	c.events().OrderCreated(ctx, OrderEvent{Domains: domains, OrderURL: order.Location})
+
+	authz, err := c.getAuthorizations(ctx, order)
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
-		c.deactivateAuthorizations(order, request.AlwaysDeactivateAuthorizations)
+		c.deactivateFailedAuthorizations(ctx, order, request.AlwaysDeactivateAuthorizations, request.KeepFailedAuthorizations)
 		return nil, err
 	}
 
-	err = c.resolver.Solve(authz)
+	err = c.solve(ctx, authz)
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
-		c.deactivateAuthorizations(order, request.AlwaysDeactivateAuthorizations)
+		c.deactivateFailedAuthorizations(ctx, order, request.AlwaysDeactivateAuthorizations, request.KeepFailedAuthorizations)
 		return nil, err
 	}
 
-	log.Infof("[%s] acme: Validations succeeded; requesting certificates", strings.Join(domains, ", "))
+	c.core.Log().With("order", order.Location).Infof("[%s] acme: Validations succeeded; requesting certificates", strings.Join(domains, ", "))
 
 	failures := newObtainError()
 
@@ -272,7 +501,7 @@ func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error)
 		privateKey = certcrypto.PEMEncode(request.PrivateKey)
 	}
 
-	cert, err := c.getForCSR(domains, order, request.Bundle, request.CSR.Raw, privateKey, request.PreferredChain)
+	cert, err := c.getForCSR(ctx, domains, order, request.Bundle, request.CSR.Raw, privateKey, request.PreferredChain)
 	if err != nil {
 		for _, auth := range authz {
 			failures.Add(challenge.GetTargetedDomain(auth), err)
@@ -280,7 +509,7 @@ func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error)
 	}
 
 	if request.AlwaysDeactivateAuthorizations {
-		c.deactivateAuthorizations(order, true)
+		c.deactivateAuthorizations(ctx, order, true)
 	}
 
 	if cert != nil {
@@ -291,13 +520,13 @@ func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error)
 	return cert, failures.Join()
 }
 
-func (c *Certifier) getForOrder(domains []string, order acme.ExtendedOrder, request ObtainRequest) (*Resource, error) {
+func (c *Certifier) getForOrder(ctx context.Context, domains []string, order acme.ExtendedOrder, request ObtainRequest) (*Resource, error) {
 	privateKey := request.PrivateKey
 
 	if privateKey == nil {
 		var err error
 
-		privateKey, err = certcrypto.GeneratePrivateKey(c.options.KeyType)
+		privateKey, err = certcrypto.GeneratePrivateKeyWithReader(c.options.KeyType, c.options.Rand)
 		if err != nil {
 			return nil, err
 		}
@@ -314,13 +543,24 @@ func (c *Certifier) getForOrder(domains []string, order acme.ExtendedOrder, requ
 	//   Clients SHOULD NOT make any assumptions about the sort order of
 	//   "identifiers" or "authorizations" elements in the returned order
 	//   object.
+	// The identifiers are used as returned by the CA, unless they are explicitly sorted.
+
+	sanOrder := request.SANOrder
+	if sanOrder == "" {
+		sanOrder = SANOrderCA
+	}
+
+	identifiers := order.Identifiers
+	if sanOrder == SANOrderSorted {
+		identifiers = sortIdentifiers(identifiers)
+	}
 
 	var san []string
 	if commonName != "" {
 		san = append(san, commonName)
 	}
 
-	for _, auth := range order.Identifiers {
+	for _, auth := range identifiers {
 		if auth.Value != commonName {
 			san = append(san, auth.Value)
 		}
@@ -331,6 +571,8 @@ func (c *Certifier) getForOrder(domains []string, order acme.ExtendedOrder, requ
 		SAN:            san,
 		MustStaple:     request.MustStaple,
 		EmailAddresses: request.EmailAddresses,
+		Rand:           c.options.Rand,
+		MutateTemplate: request.MutateCSRTemplate,
 	}
 
 	csr, err := certcrypto.CreateCSR(privateKey, csrOptions)
@@ -338,11 +580,32 @@ func (c *Certifier) getForOrder(domains []string, order acme.ExtendedOrder, requ
 		return nil, err
 	}
 
-	return c.getForCSR(domains, order, request.Bundle, csr, certcrypto.PEMEncode(privateKey), request.PreferredChain)
+	cert, err := c.getForCSR(ctx, domains, order, request.Bundle, csr, certcrypto.PEMEncode(privateKey), request.PreferredChain)
+	if err != nil {
+		return nil, err
+	}
+
+	cert.SANOrder = sanOrder
+
+	return cert, nil
 }
 
-func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle bool, csr, privateKeyPem []byte, preferredChain string) (*Resource, error) {
-	respOrder, err := c.core.Orders.UpdateForCSR(order.Finalize, csr)
+// sortIdentifiers returns a sorted copy of the identifiers: by type, then by value.
+func sortIdentifiers(identifiers []acme.Identifier) []acme.Identifier {
+	sorted := slices.Clone(identifiers)
+
+	slices.SortFunc(sorted, func(a, b acme.Identifier) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Value, b.Value))
+	})
+
+	return sorted
+}
+
+func (c *Certifier) getForCSR(ctx context.Context, domains []string, order acme.ExtendedOrder, bundle bool, csr, privateKeyPem []byte, preferredChain string) (_ *Resource, err error) {
+	ctx, span := c.core.Tracer().Start(ctx, tracing.SpanFinalize, trace.WithAttributes(tracing.AttrOrder.String(order.Location)))
+	defer func() { tracing.End(span, err) }()
+
+	respOrder, err := c.core.Orders.UpdateForCSRContext(ctx, order.Finalize, csr)
 	if err != nil {
 		return nil, err
 	}
@@ -353,9 +616,13 @@ func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle
 		PrivateKey: privateKeyPem,
 	}
 
+	if order.AutoRenewal != nil {
+		certRes.OrderURL = order.Location
+	}
+
 	if respOrder.Status == acme.StatusValid {
 		// if the certificate is available right away, shortcut!
-		ok, errR := c.checkResponse(respOrder, certRes, bundle, preferredChain)
+		ok, errR := c.checkResponse(ctx, respOrder, certRes, bundle, preferredChain)
 		if errR != nil {
 			return nil, errR
 		}
@@ -370,13 +637,13 @@ func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle
 		timeout = 30 * time.Second
 	}
 
-	err = wait.For("certificate", timeout, timeout/60, func() (bool, error) {
-		ord, errW := c.core.Orders.Get(order.Location)
+	err = wait.ForContext(ctx, "certificate", timeout, timeout/60, func() (bool, error) {
+		ord, errW := c.core.Orders.GetContext(ctx, order.Location)
 		if errW != nil {
 			return false, errW
 		}
 
-		done, errW := c.checkResponse(ord, certRes, bundle, preferredChain)
+		done, errW := c.checkResponse(ctx, ord, certRes, bundle, preferredChain)
 		if errW != nil {
 			return false, errW
 		}
@@ -395,13 +662,27 @@ func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle
 // The certRes input should already have the Domain (common name) field populated.
 //
 // If bundle is true, the certificate will be bundled with the issuer's cert.
-func (c *Certifier) checkResponse(order acme.ExtendedOrder, certRes *Resource, bundle bool, preferredChain string) (bool, error) {
+func (c *Certifier) checkResponse(ctx context.Context, order acme.ExtendedOrder, certRes *Resource, bundle bool, preferredChain string) (bool, error) {
 	valid, err := checkOrderStatus(order)
 	if err != nil || !valid {
 		return valid, err
 	}
 
-	certs, err := c.core.Certificates.GetAll(order.Certificate, bundle)
+	// The STAR orders provide the URL of the last issued certificate instead of the certificate URL.
+	// https://www.rfc-editor.org/rfc/rfc8739.html#section-3.1.2
+	if order.StarCertificate != "" {
+		certRes.StarCertificateURL = order.StarCertificate
+
+		if order.Certificate == "" {
+			order.Certificate = order.StarCertificate
+		}
+	}
+
+	if c.options.LowMemory {
+		return true, c.walkChains(ctx, order, certRes, bundle, preferredChain)
+	}
+
+	certs, err := c.core.Certificates.GetAllContext(ctx, order.Certificate, bundle)
 	if err != nil {
 		return false, err
 	}
@@ -413,7 +694,7 @@ func (c *Certifier) checkResponse(order acme.ExtendedOrder, certRes *Resource, b
 	certRes.CertStableURL = order.Certificate
 
 	if preferredChain == "" {
-		log.Infof("[%s] Server responded with a certificate.", certRes.Domain)
+		c.core.Log().Infof("[%s] Server responded with a certificate.", certRes.Domain)
 
 		return true, nil
 	}
@@ -425,7 +706,7 @@ func (c *Certifier) checkResponse(order acme.ExtendedOrder, certRes *Resource, b
 		}
 
 		if ok {
-			log.Infof("[%s] Server responded with a certificate for the preferred certificate chains %q.", certRes.Domain, preferredChain)
+			c.core.Log().Infof("[%s] Server responded with a certificate for the preferred certificate chains %q.", certRes.Domain, preferredChain)
 
 			certRes.IssuerCertificate = cert.Issuer
 			certRes.Certificate = cert.Cert
@@ -436,26 +717,116 @@ func (c *Certifier) checkResponse(order acme.ExtendedOrder, certRes *Resource, b
 		}
 	}
 
-	log.Infof("lego has been configured to prefer certificate chains with issuer %q, but no chain from the CA matched this issuer. Using the default certificate chain instead.", preferredChain)
+	c.core.Log().Infof("lego has been configured to prefer certificate chains with issuer %q, but no chain from the CA matched this issuer. Using the default certificate chain instead.", preferredChain)
 
 	return true, nil
 }
 
+// walkChains is the low-memory version of the chain selection:
+// only the default chain and the current alternate chain are kept in memory,
+// and the alternate chains are not fetched if there is no preferred chain.
+func (c *Certifier) walkChains(ctx context.Context, order acme.ExtendedOrder, certRes *Resource, bundle bool, preferredChain string) error {
+	var matched bool
+
+	err := c.core.Certificates.Walk(ctx, order.Certificate, bundle, func(link string, cert *acme.RawCertificate) (bool, error) {
+		if link == order.Certificate {
+			// Set the default certificate
+			certRes.IssuerCertificate = cert.Issuer
+			certRes.Certificate = cert.Cert
+			certRes.CertURL = link
+			certRes.CertStableURL = link
+		}
+
+		if preferredChain == "" {
+			return true, nil
+		}
+
+		ok, err := hasPreferredChain(cert.Issuer, preferredChain)
+		if err != nil || !ok {
+			return false, err
+		}
+
+		matched = true
+
+		certRes.IssuerCertificate = cert.Issuer
+		certRes.Certificate = cert.Cert
+		certRes.CertURL = link
+		certRes.CertStableURL = link
+
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case preferredChain == "":
+		c.core.Log().Infof("[%s] Server responded with a certificate.", certRes.Domain)
+	case matched:
+		c.core.Log().Infof("[%s] Server responded with a certificate for the preferred certificate chains %q.", certRes.Domain, preferredChain)
+	default:
+		c.core.Log().Infof("lego has been configured to prefer certificate chains with issuer %q, but no chain from the CA matched this issuer. Using the default certificate chain instead.", preferredChain)
+	}
+
+	return nil
+}
+
 // Revoke takes a PEM encoded certificate or bundle and tries to revoke it at the CA.
 func (c *Certifier) Revoke(cert []byte) error {
-	return c.RevokeWithReason(cert, nil)
+	return c.RevokeContext(context.Background(), cert)
+}
+
+// RevokeContext is like [Certifier.Revoke] with a context.
+func (c *Certifier) RevokeContext(ctx context.Context, cert []byte) error {
+	return c.RevokeWithReasonContext(ctx, cert, nil)
 }
 
 // RevokeWithReason takes a PEM encoded certificate or bundle and tries to revoke it at the CA.
 func (c *Certifier) RevokeWithReason(cert []byte, reason *uint) error {
-	certificates, err := certcrypto.ParsePEMBundle(cert)
+	return c.RevokeWithReasonContext(context.Background(), cert, reason)
+}
+
+// RevokeWithReasonContext is like [Certifier.RevokeWithReason] with a context.
+func (c *Certifier) RevokeWithReasonContext(ctx context.Context, cert []byte, reason *uint) error {
+	revokeMsg, _, err := newRevokeCertMessage(cert, reason)
+	if err != nil {
+		return err
+	}
+
+	return c.core.Certificates.RevokeContext(ctx, revokeMsg)
+}
+
+// RevokeWithKey takes a PEM encoded certificate or bundle and tries to revoke it at the CA.
+// The request is signed with the private key of the certificate instead of the account key,
+// so a certificate issued to another account can be revoked.
+func (c *Certifier) RevokeWithKey(cert []byte, reason *uint, privateKey crypto.PrivateKey) error {
+	return c.RevokeWithKeyContext(context.Background(), cert, reason, privateKey)
+}
+
+// RevokeWithKeyContext is like [Certifier.RevokeWithKey] with a context.
+func (c *Certifier) RevokeWithKeyContext(ctx context.Context, cert []byte, reason *uint, privateKey crypto.PrivateKey) error {
+	revokeMsg, x509Cert, err := newRevokeCertMessage(cert, reason)
 	if err != nil {
 		return err
 	}
 
+	err = certcrypto.MatchPrivateKey(x509Cert, privateKey)
+	if err != nil {
+		return err
+	}
+
+	return c.core.Certificates.RevokeWithKeyContext(ctx, revokeMsg, privateKey)
+}
+
+func newRevokeCertMessage(cert []byte, reason *uint) (acme.RevokeCertMessage, *x509.Certificate, error) {
+	certificates, err := certcrypto.ParsePEMBundle(cert)
+	if err != nil {
+		return acme.RevokeCertMessage{}, nil, err
+	}
+
 	x509Cert := certificates[0]
 	if x509Cert.IsCA {
-		return errors.New("certificate bundle starts with a CA certificate")
+		return acme.RevokeCertMessage{}, nil, errors.New("certificate bundle starts with a CA certificate")
 	}
 
 	revokeMsg := acme.RevokeCertMessage{
@@ -463,7 +834,7 @@ func (c *Certifier) RevokeWithReason(cert []byte, reason *uint) error {
 		Reason:      reason,
 	}
 
-	return c.core.Certificates.Revoke(revokeMsg)
+	return revokeMsg, x509Cert, nil
 }
 
 // RenewOptions options used by Certifier.RenewWithOptions.
@@ -477,9 +848,19 @@ type RenewOptions struct {
 	Profile string
 
 	AlwaysDeactivateAuthorizations bool
+	KeepFailedAuthorizations       bool
+	// If true, the order identifies the renewed certificate (RFC 9773 "replaces" field),
+	// so the CA can correlate the renewal. Ignored if the CA doesn't support ARI.
+	// - https://www.rfc-editor.org/rfc/rfc9773.html#section-5
+	ReplacesCertificate bool
+
 	// Not supported for CSR request.
 	MustStaple     bool
 	EmailAddresses []string
+	// Not supported for CSR request.
+	MutateCSRTemplate func(template *x509.CertificateRequest)
+	// Not supported for CSR request.
+	SANOrder SANOrder
 }
 
 // Renew takes a Resource and tries to renew the certificate.
@@ -513,6 +894,14 @@ func (c *Certifier) Renew(certRes Resource, bundle, mustStaple bool, preferredCh
 //
 // For private key reuse the PrivateKey property of the passed in Resource should be non-nil.
 func (c *Certifier) RenewWithOptions(certRes Resource, options *RenewOptions) (*Resource, error) {
+	return c.RenewWithOptionsContext(context.Background(), certRes, options)
+}
+
+// RenewWithOptionsContext is like [Certifier.RenewWithOptions] with a context.
+func (c *Certifier) RenewWithOptionsContext(ctx context.Context, certRes Resource, options *RenewOptions) (_ *Resource, err error) {
+	ctx, span := c.core.Tracer().Start(ctx, tracing.SpanRenew, trace.WithAttributes(tracing.AttrDomain.String(certRes.Domain)))
+	defer func() { tracing.End(span, err) }()
+
 	// Input certificate is PEM encoded.
 	// Decode it here as we may need the decoded cert later on in the renewal process.
 	// The input may be a bundle or a single certificate.
@@ -528,7 +917,16 @@ func (c *Certifier) RenewWithOptions(certRes Resource, options *RenewOptions) (*
 
 	// This is just meant to be informal for the user.
 	timeLeft := x509Cert.NotAfter.Sub(time.Now().UTC())
-	log.Infof("[%s] acme: Trying renewal with %d hours remaining", certRes.Domain, int(timeLeft.Hours()))
+	c.core.Log().Infof("[%s] acme: Trying renewal with %d hours remaining", certRes.Domain, int(timeLeft.Hours()))
+
+	var replacesCertID string
+
+	if options != nil && options.ReplacesCertificate {
+		replacesCertID, err = MakeARICertID(x509Cert)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] error making certID: %w", certRes.Domain, err)
+		}
+	}
 
 	// We always need to request a new certificate to renew.
 	// Start by checking to see if the certificate was based off a CSR,
@@ -539,7 +937,7 @@ func (c *Certifier) RenewWithOptions(certRes Resource, options *RenewOptions) (*
 			return nil, errP
 		}
 
-		request := ObtainForCSRRequest{CSR: csr}
+		request := ObtainForCSRRequest{CSR: csr, ReplacesCertID: replacesCertID}
 
 		if options != nil {
 			request.NotBefore = options.NotBefore
@@ -548,9 +946,10 @@ func (c *Certifier) RenewWithOptions(certRes Resource, options *RenewOptions) (*
 			request.PreferredChain = options.PreferredChain
 			request.Profile = options.Profile
 			request.AlwaysDeactivateAuthorizations = options.AlwaysDeactivateAuthorizations
+			request.KeepFailedAuthorizations = options.KeepFailedAuthorizations
 		}
 
-		return c.ObtainForCSR(request)
+		return c.ObtainForCSRContext(ctx, request)
 	}
 
 	var privateKey crypto.PrivateKey
@@ -562,8 +961,9 @@ func (c *Certifier) RenewWithOptions(certRes Resource, options *RenewOptions) (*
 	}
 
 	request := ObtainRequest{
-		Domains:    certcrypto.ExtractDomains(x509Cert),
-		PrivateKey: privateKey,
+		Domains:        certcrypto.ExtractDomains(x509Cert),
+		PrivateKey:     privateKey,
+		ReplacesCertID: replacesCertID,
 	}
 
 	if options != nil {
@@ -575,9 +975,12 @@ func (c *Certifier) RenewWithOptions(certRes Resource, options *RenewOptions) (*
 		request.EmailAddresses = options.EmailAddresses
 		request.Profile = options.Profile
 		request.AlwaysDeactivateAuthorizations = options.AlwaysDeactivateAuthorizations
+		request.KeepFailedAuthorizations = options.KeepFailedAuthorizations
+		request.MutateCSRTemplate = options.MutateCSRTemplate
+		request.SANOrder = options.SANOrder
 	}
 
-	return c.Obtain(request)
+	return c.ObtainContext(ctx, request)
 }
 
 // GetOCSP takes a PEM encoded cert or cert bundle returning the raw OCSP response,
@@ -589,6 +992,17 @@ func (c *Certifier) RenewWithOptions(certRes Resource, options *RenewOptions) (*
 //
 // If the []byte and/or ocsp.Response return values are nil, the OCSP status may be assumed OCSPUnknown.
 func (c *Certifier) GetOCSP(bundle []byte) ([]byte, *ocsp.Response, error) {
+	return c.GetOCSPContext(context.Background(), bundle)
+}
+
+// GetOCSPContext is like [Certifier.GetOCSP] with a context.
+func (c *Certifier) GetOCSPContext(ctx context.Context, bundle []byte) ([]byte, *ocsp.Response, error) {
+	return FetchOCSP(ctx, c.core.HTTPClient, bundle)
+}
+
+// FetchOCSP is like Certifier.GetOCSP, with an HTTP client instead of an ACME client
+// (ex: to check the OCSP status of a stored certificate).
+func FetchOCSP(ctx context.Context, client *http.Client, bundle []byte) ([]byte, *ocsp.Response, error) {
 	certificates, err := certcrypto.ParsePEMBundle(bundle)
 	if err != nil {
 		return nil, nil, err
@@ -612,7 +1026,12 @@ func (c *Certifier) GetOCSP(bundle []byte) ([]byte, *ocsp.Response, error) {
 			return nil, nil, errors.New("no issuing certificate URL")
 		}
 
-		resp, errC := c.core.HTTPClient.Get(issuedCert.IssuingCertificateURL[0])
+		req, errC := http.NewRequestWithContext(ctx, http.MethodGet, issuedCert.IssuingCertificateURL[0], http.NoBody)
+		if errC != nil {
+			return nil, nil, errC
+		}
+
+		resp, errC := client.Do(req)
 		if errC != nil {
 			return nil, nil, errC
 		}
@@ -641,7 +1060,14 @@ func (c *Certifier) GetOCSP(bundle []byte) ([]byte, *ocsp.Response, error) {
 		return nil, nil, err
 	}
 
-	resp, err := c.core.HTTPClient.Post(issuedCert.OCSPServer[0], "application/ocsp-request", bytes.NewReader(ocspReq))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, issuedCert.OCSPServer[0], bytes.NewReader(ocspReq))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/ocsp-request")
+
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -667,7 +1093,12 @@ func (c *Certifier) GetOCSP(bundle []byte) ([]byte, *ocsp.Response, error) {
 //
 // If bundle is true, the Certificate field in the returned Resource includes the issuer certificate.
 func (c *Certifier) Get(url string, bundle bool) (*Resource, error) {
-	cert, issuer, err := c.core.Certificates.Get(url, bundle)
+	return c.GetContext(context.Background(), url, bundle)
+}
+
+// GetContext is like [Certifier.Get] with a context.
+func (c *Certifier) GetContext(ctx context.Context, url string, bundle bool) (*Resource, error) {
+	cert, issuer, err := c.core.Certificates.GetContext(ctx, url, bundle)
 	if err != nil {
 		return nil, err
 	}
@@ -723,10 +1154,29 @@ func checkOrderStatus(order acme.ExtendedOrder) (bool, error) {
 // That is, it MUST be encoded according to the rules in Section 7 of [RFC5280].
 //
 // https://www.rfc-editor.org/rfc/rfc5280.html#section-7
+//
+// For the email addresses (S/MIME certificates), only the domain part is converted.
+// The IP addresses are converted to their canonical form (RFC 8738 §3, RFC 5952).
 func sanitizeDomain(domains []string) []string {
 	var sanitizedDomains []string
 
 	for _, domain := range domains {
+		if ip := net.ParseIP(strings.Trim(domain, "[]")); ip != nil {
+			sanitizedDomains = append(sanitizedDomains, ip.String())
+			continue
+		}
+
+		if local, host, ok := strings.Cut(domain, "@"); ok {
+			sanitizedHost, err := idna.ToASCII(host)
+			if err != nil {
+				log.Infof("skip email address %q: unable to sanitize (punnycode): %v", domain, err)
+			} else {
+				sanitizedDomains = append(sanitizedDomains, local+"@"+sanitizedHost)
+			}
+
+			continue
+		}
+
 		sanitizedDomain, err := idna.ToASCII(domain)
 		if err != nil {
 			log.Infof("skip domain %q: unable to sanitize (punnycode): %v", domain, err)
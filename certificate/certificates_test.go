@@ -1,10 +1,20 @@
 package certificate
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/go-acme/lego/v4/acme"
@@ -195,7 +205,7 @@ func Test_checkResponse(t *testing.T) {
 	}
 	certRes := &Resource{}
 
-	valid, err := certifier.checkResponse(order, certRes, true, "")
+	valid, err := certifier.checkResponse(t.Context(), order, certRes, true, "")
 	require.NoError(t, err)
 	assert.True(t, valid)
 	assert.NotNil(t, certRes)
@@ -229,7 +239,7 @@ func Test_checkResponse_issuerRelUp(t *testing.T) {
 	}
 	certRes := &Resource{}
 
-	valid, err := certifier.checkResponse(order, certRes, true, "")
+	valid, err := certifier.checkResponse(t.Context(), order, certRes, true, "")
 	require.NoError(t, err)
 	assert.True(t, valid)
 	assert.NotNil(t, certRes)
@@ -263,7 +273,7 @@ func Test_checkResponse_no_bundle(t *testing.T) {
 	}
 	certRes := &Resource{}
 
-	valid, err := certifier.checkResponse(order, certRes, false, "")
+	valid, err := certifier.checkResponse(t.Context(), order, certRes, false, "")
 	require.NoError(t, err)
 	assert.True(t, valid)
 	assert.NotNil(t, certRes)
@@ -306,7 +316,7 @@ func Test_checkResponse_alternate(t *testing.T) {
 		Domain: "example.com",
 	}
 
-	valid, err := certifier.checkResponse(order, certRes, true, "DST Root CA X3")
+	valid, err := certifier.checkResponse(t.Context(), order, certRes, true, "DST Root CA X3")
 	require.NoError(t, err)
 
 	assert.True(t, valid)
@@ -320,6 +330,82 @@ func Test_checkResponse_alternate(t *testing.T) {
 	assert.Equal(t, issuerMock2, string(certRes.IssuerCertificate), "IssuerCertificate")
 }
 
+func Test_checkResponse_alternate_lowMemory(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		preferredChain   string
+		expectedURL      string
+		expectedCert     string
+		expectedIssuer   string
+		expectedAltCalls int
+	}{
+		{
+			desc:             "preferred chain",
+			preferredChain:   "DST Root CA X3",
+			expectedURL:      "/certificate/1",
+			expectedCert:     certResponseMock2,
+			expectedIssuer:   issuerMock2,
+			expectedAltCalls: 1,
+		},
+		{
+			desc:           "no preferred chain",
+			expectedURL:    "/certificate",
+			expectedCert:   certResponseMock,
+			expectedIssuer: issuerMock,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			var altCalls atomic.Int32
+
+			server := tester.MockACMEServer().
+				Route("POST /certificate",
+					http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+						rw.Header().Add("Link",
+							fmt.Sprintf(`<https://%s/certificate/1>;title="foo";rel="alternate"`, req.Context().Value(http.LocalAddrContextKey)))
+
+						servermock.RawStringResponse(certResponseMock).ServeHTTP(rw, req)
+					})).
+				Route("/certificate/1",
+					http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+						altCalls.Add(1)
+
+						servermock.RawStringResponse(certResponseMock2).ServeHTTP(rw, req)
+					})).
+				BuildHTTPS(t)
+
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			require.NoError(t, err, "Could not generate test key")
+
+			core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+			require.NoError(t, err)
+
+			certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048, LowMemory: true})
+
+			order := acme.ExtendedOrder{
+				Order: acme.Order{
+					Status:      acme.StatusValid,
+					Certificate: server.URL + "/certificate",
+				},
+			}
+			certRes := &Resource{
+				Domain: "example.com",
+			}
+
+			valid, err := certifier.checkResponse(t.Context(), order, certRes, true, test.preferredChain)
+			require.NoError(t, err)
+
+			assert.True(t, valid)
+			assert.Equal(t, server.URL+test.expectedURL, certRes.CertURL)
+			assert.Equal(t, server.URL+test.expectedURL, certRes.CertStableURL)
+			assert.Equal(t, test.expectedCert, string(certRes.Certificate), "Certificate")
+			assert.Equal(t, test.expectedIssuer, string(certRes.IssuerCertificate), "IssuerCertificate")
+			assert.EqualValues(t, test.expectedAltCalls, altCalls.Load())
+		})
+	}
+}
+
 func Test_Get(t *testing.T) {
 	server := tester.MockACMEServer().
 		Route("POST /acme/cert/test-cert", servermock.RawStringResponse(certResponseMock)).
@@ -346,6 +432,32 @@ func Test_Get(t *testing.T) {
 	assert.Equal(t, issuerMock, string(certRes.IssuerCertificate), "IssuerCertificate")
 }
 
+func Test_GetContext(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("POST /acme/cert/test-cert", servermock.RawStringResponse(certResponseMock)).
+		BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	certRes, err := certifier.GetContext(t.Context(), server.URL+"/acme/cert/test-cert", true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "acme.wtf", certRes.Domain)
+	assert.Equal(t, certResponseMock, string(certRes.Certificate), "Certificate")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err = certifier.GetContext(ctx, server.URL+"/acme/cert/test-cert", true)
+	require.ErrorContains(t, err, context.Canceled.Error())
+}
+
 func Test_checkOrderStatus(t *testing.T) {
 	testCases := []struct {
 		desc       string
@@ -398,3 +510,547 @@ type resolverMock struct {
 func (r *resolverMock) Solve(_ []acme.Authorization) error {
 	return r.error
 }
+
+type contextResolverMock struct {
+	resolverMock
+
+	ctx context.Context
+}
+
+func (r *contextResolverMock) SolveContext(ctx context.Context, _ []acme.Authorization) error {
+	r.ctx = ctx
+
+	return r.error
+}
+
+func TestCertifier_solve(t *testing.T) {
+	certifier := NewCertifier(nil, &resolverMock{error: errors.New("oops")}, CertifierOptions{})
+
+	err := certifier.solve(t.Context(), nil)
+	require.EqualError(t, err, "oops")
+}
+
+func TestCertifier_solve_context(t *testing.T) {
+	type ctxKey struct{}
+
+	ctx := context.WithValue(t.Context(), ctxKey{}, "value")
+
+	solver := &contextResolverMock{}
+
+	certifier := NewCertifier(nil, solver, CertifierOptions{})
+
+	err := certifier.solve(ctx, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "value", solver.ctx.Value(ctxKey{}))
+}
+
+func TestCertifier_RevokeWithKey(t *testing.T) {
+	certKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	certPEM, err := certcrypto.GeneratePemCert(certKey, "example.com", nil)
+	require.NoError(t, err)
+
+	server := tester.MockACMEServer().
+		Route("POST /revokeCert", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			var jws struct {
+				Protected string `json:"protected"`
+			}
+
+			err := json.NewDecoder(req.Body).Decode(&jws)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			raw, err := base64.RawURLEncoding.DecodeString(jws.Protected)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			var header map[string]any
+
+			err = json.Unmarshal(raw, &header)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			// The request must be signed with the embedded key of the certificate, not with the account.
+			if _, ok := header["kid"]; ok {
+				http.Error(rw, "unexpected kid", http.StatusBadRequest)
+				return
+			}
+
+			if _, ok := header["jwk"]; !ok {
+				http.Error(rw, "missing jwk", http.StatusBadRequest)
+				return
+			}
+		})).
+		BuildHTTPS(t)
+
+	accountKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", server.URL+"/account/1", accountKey)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	reason := acme.CRLReasonKeyCompromise
+
+	err = certifier.RevokeWithKey(certPEM, &reason, certKey)
+	require.NoError(t, err)
+
+	err = certifier.RevokeWithKeyContext(t.Context(), certPEM, &reason, certKey)
+	require.NoError(t, err)
+
+	err = certifier.RevokeWithKey(certPEM, &reason, accountKey)
+	require.EqualError(t, err, "the private key doesn't match the certificate")
+}
+
+func TestCertifier_Obtain_allowPartial(t *testing.T) {
+	var ordered [][]string
+
+	server := tester.MockACMEServer().
+		Route("POST /newOrder", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			var order acme.Order
+
+			err := readJWSPayload(req, &order)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			var domains, authorizations []string
+			for _, identifier := range order.Identifiers {
+				domains = append(domains, identifier.Value)
+				authorizations = append(authorizations, serverURL+"/authz/"+identifier.Value)
+			}
+
+			ordered = append(ordered, domains)
+
+			rw.Header().Set("Location", serverURL+"/order")
+
+			servermock.JSONEncode(acme.Order{
+				Status:         acme.StatusPending,
+				Identifiers:    order.Identifiers,
+				Authorizations: authorizations,
+				Finalize:       serverURL + "/finalize",
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /authz/{domain}", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			servermock.JSONEncode(acme.Authorization{
+				Status:     acme.StatusPending,
+				Identifier: acme.Identifier{Type: "dns", Value: req.PathValue("domain")},
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /finalize", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			servermock.JSONEncode(acme.Order{
+				Status:      acme.StatusValid,
+				Certificate: serverURL + "/certificate",
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /certificate", servermock.RawStringResponse(certResponseMock)).
+		BuildHTTPS(t)
+
+	accountKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", server.URL+"/account/1", accountKey)
+	require.NoError(t, err)
+
+	solver := &failingDomainsResolver{failures: map[string]error{"b.example.com": errors.New("oops")}}
+
+	events := &eventsRecorder{}
+
+	certifier := NewCertifier(core, solver, CertifierOptions{KeyType: certcrypto.EC256, Events: events})
+
+	request := ObtainRequest{
+		Domains: []string{"a.example.com", "b.example.com", "c.example.com"},
+		Bundle:  true,
+	}
+
+	_, err = certifier.Obtain(request)
+	require.EqualError(t, err, "error: one or more domains had a problem:\n[b.example.com] oops\n")
+
+	request.AllowPartial = true
+
+	cert, err := certifier.Obtain(request)
+	require.NoError(t, err)
+
+	assert.Equal(t, "a.example.com", cert.Domain)
+	assert.Equal(t, []string{"b.example.com"}, cert.DroppedDomains)
+
+	expected := [][]string{
+		{"a.example.com", "b.example.com", "c.example.com"},
+		{"a.example.com", "b.example.com", "c.example.com"},
+		{"a.example.com", "c.example.com"},
+	}
+
+	assert.Equal(t, expected, ordered)
+
+	expectedEvents := []string{
+		"order created: a.example.com, b.example.com, c.example.com",
+		"obtain failed: a.example.com, b.example.com, c.example.com",
+		"order created: a.example.com, b.example.com, c.example.com",
+		"order created: a.example.com, c.example.com",
+		"cert obtained: a.example.com, b.example.com, c.example.com",
+	}
+
+	assert.Equal(t, expectedEvents, events.events)
+}
+
+// eventsRecorder records the events of the certificate requests.
+type eventsRecorder struct {
+	NoopEvents
+
+	events []string
+}
+
+func (e *eventsRecorder) OrderCreated(_ context.Context, event OrderEvent) {
+	e.events = append(e.events, "order created: "+strings.Join(event.Domains, ", "))
+}
+
+func (e *eventsRecorder) CertObtained(_ context.Context, event CertEvent) {
+	e.events = append(e.events, "cert obtained: "+strings.Join(event.Domains, ", "))
+}
+
+func (e *eventsRecorder) ObtainFailed(_ context.Context, event CertEvent) {
+	e.events = append(e.events, "obtain failed: "+strings.Join(event.Domains, ", "))
+}
+
+func TestCertifier_RenewWithOptions_replacesCertificate(t *testing.T) {
+	var replaces []string
+
+	server := tester.MockACMEServer().
+		Route("POST /newOrder", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			var order acme.Order
+
+			err := readJWSPayload(req, &order)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			replaces = append(replaces, order.Replaces)
+
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			rw.Header().Set("Location", serverURL+"/order")
+
+			servermock.JSONEncode(acme.Order{
+				Status:      acme.StatusReady,
+				Identifiers: order.Identifiers,
+				Finalize:    serverURL + "/finalize",
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /finalize", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			servermock.JSONEncode(acme.Order{
+				Status:      acme.StatusValid,
+				Certificate: serverURL + "/certificate",
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /certificate", servermock.RawStringResponse(certResponseMock)).
+		BuildHTTPS(t)
+
+	accountKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", server.URL+"/account/1", accountKey)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &failingDomainsResolver{}, CertifierOptions{KeyType: certcrypto.EC256})
+
+	certRes := Resource{Domain: "acme.wtf", Certificate: []byte(certResponseMock)}
+
+	_, err = certifier.RenewWithOptions(certRes, &RenewOptions{Bundle: true})
+	require.NoError(t, err)
+
+	_, err = certifier.RenewWithOptions(certRes, &RenewOptions{Bundle: true, ReplacesCertificate: true})
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate([]byte(certResponseMock))
+	require.NoError(t, err)
+
+	certID, err := MakeARICertID(cert)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"", certID}, replaces)
+}
+
+func TestCertifier_Obtain_sanOrder(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		sanOrder SANOrder
+		expected []string
+		stored   SANOrder
+	}{
+		{
+			desc:     "default",
+			expected: []string{"b.example.com", "c.example.com", "d.example.com", "a.example.com"},
+			stored:   SANOrderCA,
+		},
+		{
+			desc:     "ca",
+			sanOrder: SANOrderCA,
+			expected: []string{"b.example.com", "c.example.com", "d.example.com", "a.example.com"},
+			stored:   SANOrderCA,
+		},
+		{
+			desc:     "sorted",
+			sanOrder: SANOrderSorted,
+			expected: []string{"b.example.com", "a.example.com", "c.example.com", "d.example.com"},
+			stored:   SANOrderSorted,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var sans []string
+
+			server := tester.MockACMEServer().
+				Route("POST /newOrder", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+					rw.Header().Set("Location", serverURL+"/order")
+
+					// The CA doesn't keep the order of the identifiers of the request.
+					servermock.JSONEncode(acme.Order{
+						Status: acme.StatusReady,
+						Identifiers: []acme.Identifier{
+							{Type: "dns", Value: "c.example.com"},
+							{Type: "dns", Value: "d.example.com"},
+							{Type: "dns", Value: "a.example.com"},
+							{Type: "dns", Value: "b.example.com"},
+						},
+						Finalize: serverURL + "/finalize",
+					}).ServeHTTP(rw, req)
+				})).
+				Route("POST /finalize", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					var msg acme.CSRMessage
+
+					err := readJWSPayload(req, &msg)
+					if err != nil {
+						http.Error(rw, err.Error(), http.StatusBadRequest)
+						return
+					}
+
+					raw, err := base64.RawURLEncoding.DecodeString(msg.Csr)
+					if err != nil {
+						http.Error(rw, err.Error(), http.StatusBadRequest)
+						return
+					}
+
+					csr, err := x509.ParseCertificateRequest(raw)
+					if err != nil {
+						http.Error(rw, err.Error(), http.StatusBadRequest)
+						return
+					}
+
+					sans = csr.DNSNames
+
+					serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+					servermock.JSONEncode(acme.Order{
+						Status:      acme.StatusValid,
+						Certificate: serverURL + "/certificate",
+					}).ServeHTTP(rw, req)
+				})).
+				Route("POST /certificate", servermock.RawStringResponse(certResponseMock)).
+				BuildHTTPS(t)
+
+			accountKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			require.NoError(t, err)
+
+			core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", server.URL+"/account/1", accountKey)
+			require.NoError(t, err)
+
+			certifier := NewCertifier(core, &failingDomainsResolver{}, CertifierOptions{KeyType: certcrypto.EC256})
+
+			cert, err := certifier.ObtainContext(t.Context(), ObtainRequest{
+				Domains:  []string{"b.example.com", "a.example.com", "c.example.com", "d.example.com"},
+				Bundle:   true,
+				SANOrder: test.sanOrder,
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, sans)
+			assert.Equal(t, test.stored, cert.SANOrder)
+		})
+	}
+}
+
+func TestCertifier_Obtain_sanOrder_unknown(t *testing.T) {
+	certifier := &Certifier{}
+
+	_, err := certifier.obtain(t.Context(), ObtainRequest{Domains: []string{"example.com"}, SANOrder: "random"})
+	require.EqualError(t, err, `unknown SAN order: "random"`)
+}
+
+func TestCertifier_Obtain_allowPartial_allFailed(t *testing.T) {
+	certifier := &Certifier{}
+
+	solveErr := failingDomainsError{"a.example.com": errors.New("oops")}
+
+	// Nothing to retry: the original error is returned.
+	_, err := certifier.obtainPartial(t.Context(), ObtainRequest{AllowPartial: true}, []string{"a.example.com"}, solveErr)
+	require.Equal(t, error(solveErr), err)
+}
+
+// failingDomainsResolver fails the validation of some domains, like the resolver.Prober.
+func TestCertifier_Obtain_deactivateFailedAuthorizations(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		keep     bool
+		expected []string
+	}{
+		{
+			desc:     "default",
+			expected: []string{"a.example.com"},
+		},
+		{
+			desc: "keep failed authorizations",
+			keep: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			var (
+				mu          sync.Mutex
+				deactivated []string
+			)
+
+			// a.example.com is pending, b.example.com is invalid (failed challenge).
+			statuses := map[string]string{"a.example.com": acme.StatusPending, "b.example.com": acme.StatusInvalid}
+
+			server := tester.MockACMEServer().
+				Route("POST /newOrder", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+					rw.Header().Set("Location", serverURL+"/order")
+
+					servermock.JSONEncode(acme.Order{
+						Status: acme.StatusPending,
+						Identifiers: []acme.Identifier{
+							{Type: "dns", Value: "a.example.com"},
+							{Type: "dns", Value: "b.example.com"},
+						},
+						Authorizations: []string{serverURL + "/authz/a.example.com", serverURL + "/authz/b.example.com"},
+						Finalize:       serverURL + "/finalize",
+					}).ServeHTTP(rw, req)
+				})).
+				Route("POST /authz/{domain}", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+					domain := req.PathValue("domain")
+
+					var authz acme.Authorization
+
+					// The error is ignored: the payload of a POST-as-GET is empty.
+					_ = readJWSPayload(req, &authz)
+
+					if authz.Status == acme.StatusDeactivated {
+						mu.Lock()
+						deactivated = append(deactivated, domain)
+						mu.Unlock()
+					}
+
+					servermock.JSONEncode(acme.Authorization{
+						Status:     statuses[domain],
+						Identifier: acme.Identifier{Type: "dns", Value: domain},
+					}).ServeHTTP(rw, req)
+				})).
+				BuildHTTPS(t)
+
+			accountKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			require.NoError(t, err)
+
+			core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", server.URL+"/account/1", accountKey)
+			require.NoError(t, err)
+
+			solver := &failingDomainsResolver{failures: map[string]error{"b.example.com": errors.New("oops")}}
+
+			certifier := NewCertifier(core, solver, CertifierOptions{KeyType: certcrypto.EC256})
+
+			_, err = certifier.Obtain(ObtainRequest{
+				Domains:                  []string{"a.example.com", "b.example.com"},
+				KeepFailedAuthorizations: test.keep,
+			})
+			require.Error(t, err)
+
+			assert.Equal(t, test.expected, deactivated)
+		})
+	}
+}
+
+type failingDomainsResolver struct {
+	failures map[string]error
+}
+
+func (r *failingDomainsResolver) Solve(authorizations []acme.Authorization) error {
+	failures := failingDomainsError{}
+
+	for _, authz := range authorizations {
+		if err, ok := r.failures[authz.Identifier.Value]; ok {
+			failures[authz.Identifier.Value] = err
+		}
+	}
+
+	if len(failures) > 0 {
+		return failures
+	}
+
+	return nil
+}
+
+type failingDomainsError map[string]error
+
+func (e failingDomainsError) Error() string {
+	msg := "error: one or more domains had a problem:\n"
+
+	for _, domain := range e.Domains() {
+		msg += fmt.Sprintf("[%s] %s\n", domain, e[domain])
+	}
+
+	return msg
+}
+
+func (e failingDomainsError) Domains() []string {
+	return slices.Sorted(maps.Keys(e))
+}
+
+func readJWSPayload(req *http.Request, v any) error {
+	var jws struct {
+		Payload string `json:"payload"`
+	}
+
+	err := json.NewDecoder(req.Body).Decode(&jws)
+	if err != nil {
+		return err
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
+
+func Test_sanitizeDomain(t *testing.T) {
+	domains := []string{"example.com", "bücher.example", "192.0.2.1", "2001:DB8:0:0::1", "[2001:db8::2]", "foo@bücher.example"}
+
+	expected := []string{"example.com", "xn--bcher-kva.example", "192.0.2.1", "2001:db8::1", "2001:db8::2", "foo@xn--bcher-kva.example"}
+
+	assert.Equal(t, expected, sanitizeDomain(domains))
+}
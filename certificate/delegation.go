@@ -0,0 +1,438 @@
+package certificate
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"maps"
+	"math/bits"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
+)
+
+var (
+	oidExtensionKeyUsage         = asn1.ObjectIdentifier{2, 5, 29, 15}
+	oidExtensionExtendedKeyUsage = asn1.ObjectIdentifier{2, 5, 29, 37}
+)
+
+// https://www.rfc-editor.org/rfc/rfc5280.html#section-4.2.1.3
+var delegationKeyUsages = map[string]x509.KeyUsage{
+	"digitalSignature": x509.KeyUsageDigitalSignature,
+	"nonRepudiation":   x509.KeyUsageContentCommitment,
+	"keyEncipherment":  x509.KeyUsageKeyEncipherment,
+	"dataEncipherment": x509.KeyUsageDataEncipherment,
+	"keyAgreement":     x509.KeyUsageKeyAgreement,
+	"keyCertSign":      x509.KeyUsageCertSign,
+	"cRLSign":          x509.KeyUsageCRLSign,
+	"encipherOnly":     x509.KeyUsageEncipherOnly,
+	"decipherOnly":     x509.KeyUsageDecipherOnly,
+}
+
+// https://www.rfc-editor.org/rfc/rfc5280.html#section-4.2.1.12
+var delegationExtKeyUsages = map[string]asn1.ObjectIdentifier{
+	"serverAuth":      {1, 3, 6, 1, 5, 5, 7, 3, 1},
+	"clientAuth":      {1, 3, 6, 1, 5, 5, 7, 3, 2},
+	"codeSigning":     {1, 3, 6, 1, 5, 5, 7, 3, 3},
+	"emailProtection": {1, 3, 6, 1, 5, 5, 7, 3, 4},
+	"timeStamping":    {1, 3, 6, 1, 5, 5, 7, 3, 8},
+	"OCSPSigning":     {1, 3, 6, 1, 5, 5, 7, 3, 9},
+}
+
+var delegationCurves = map[string]elliptic.Curve{
+	"secp256r1": elliptic.P256(),
+	"secp384r1": elliptic.P384(),
+	"secp521r1": elliptic.P521(),
+}
+
+var delegationSignatureAlgorithms = map[string]x509.SignatureAlgorithm{
+	"ecdsa-with-SHA256":       x509.ECDSAWithSHA256,
+	"ecdsa-with-SHA384":       x509.ECDSAWithSHA384,
+	"ecdsa-with-SHA512":       x509.ECDSAWithSHA512,
+	"sha256WithRSAEncryption": x509.SHA256WithRSA,
+	"sha384WithRSAEncryption": x509.SHA384WithRSA,
+	"sha512WithRSAEncryption": x509.SHA512WithRSA,
+}
+
+// Delegation a delegation configured by the identifier owner (ACME delegation, RFC 9115).
+// A delegated entity (ex: a CDN) obtains certificates for the identifiers of the owner,
+// with a CSR matching the CSR template of the delegation, without validating the identifiers.
+type Delegation struct {
+	acme.Delegation
+
+	// URL the URL of the delegation.
+	URL string
+}
+
+// DelegationRequest the request to obtain a certificate for a delegation.
+//
+// The CSR is created by NewDelegationCSR, then signed by the delegated entity (the private key is never shared).
+type DelegationRequest struct {
+	Delegation Delegation
+	CSR        *x509.CertificateRequest
+
+	Bundle         bool
+	PreferredChain string
+
+	// AutoRenewal requests a Short-Term, Automatically Renewed (STAR) certificate (RFC 8739).
+	// - https://www.rfc-editor.org/rfc/rfc9115.html#section-2.3.2
+	AutoRenewal *api.AutoRenewalOptions
+}
+
+// ListDelegations returns the delegations configured for an account.
+// The delegations URL is the field `delegations` of the account (registration.Resource.Body.Delegations).
+func (c *Certifier) ListDelegations(ctx context.Context, delegationsURL string) ([]Delegation, error) {
+	delegationURLs, err := c.core.Delegations.List(ctx, delegationsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var delegations []Delegation
+
+	for _, delegationURL := range delegationURLs {
+		delegation, err := c.core.Delegations.Get(ctx, delegationURL)
+		if err != nil {
+			return nil, fmt.Errorf("delegation %s: %w", delegationURL, err)
+		}
+
+		delegations = append(delegations, Delegation{Delegation: delegation, URL: delegationURL})
+	}
+
+	return delegations, nil
+}
+
+// ObtainForDelegation obtains a certificate for a delegated order.
+// The CSR must match the CSR template of the delegation.
+func (c *Certifier) ObtainForDelegation(ctx context.Context, request DelegationRequest) (*Resource, error) {
+	if request.CSR == nil {
+		return nil, errors.New("cannot obtain a delegated certificate: CSR is missing")
+	}
+
+	if request.Delegation.URL == "" {
+		return nil, errors.New("cannot obtain a delegated certificate: the delegation URL is missing")
+	}
+
+	err := ValidateDelegationCSR(request.Delegation.CSRTemplate, request.CSR)
+	if err != nil {
+		return nil, err
+	}
+
+	domains := certcrypto.ExtractDomainsCSR(request.CSR)
+
+	c.core.Log().Infof("[%s] acme: Obtaining delegated certificate", strings.Join(domains, ", "))
+
+	orderOpts := &api.OrderOptions{
+		Delegation:  request.Delegation.URL,
+		AutoRenewal: request.AutoRenewal,
+	}
+
+	order, err := c.core.Orders.NewWithOptionsContext(ctx, domains, orderOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The identifiers are usually authorized by the identifier owner: the order is ready.
+	if len(order.Authorizations) > 0 {
+		authz, errA := c.getAuthorizations(ctx, order)
+		if errA != nil {
+			c.deactivateFailedAuthorizations(ctx, order, false, false)
+			return nil, errA
+		}
+
+		errA = c.solve(ctx, authz)
+		if errA != nil {
+			c.deactivateFailedAuthorizations(ctx, order, false, false)
+			return nil, errA
+		}
+	}
+
+	cert, err := c.getForCSR(ctx, domains, order, request.Bundle, request.CSR.Raw, nil, request.PreferredChain)
+	if err != nil {
+		return nil, err
+	}
+
+	cert.CSR = certcrypto.PEMEncode(request.CSR)
+
+	return cert, nil
+}
+
+// NewDelegationCSR creates the unsigned CSR matching a CSR template: it must be signed by the delegated entity.
+// The subject values are used for the subject fields with the value "**" (required) or "*" (optional) in the template.
+//
+//	template, _ := certificate.NewDelegationCSR(delegation.CSRTemplate, map[string]string{"organization": "CDN Inc."})
+//	csrDER, _ := x509.CreateCertificateRequest(rand.Reader, template, privateKey)
+//	csr, _ := x509.ParseCertificateRequest(csrDER)
+//
+// - https://www.rfc-editor.org/rfc/rfc9115.html#section-4
+func NewDelegationCSR(template *acme.CSRTemplate, subject map[string]string) (*x509.CertificateRequest, error) {
+	if template == nil {
+		return nil, errors.New("missing CSR template")
+	}
+
+	csr := &x509.CertificateRequest{}
+
+	for _, key := range slices.Sorted(maps.Keys(subject)) {
+		if _, ok := template.Subject[key]; !ok {
+			return nil, fmt.Errorf("CSR template: the subject field %q is not allowed", key)
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(template.Subject)) {
+		value := template.Subject[key]
+
+		switch value {
+		case acme.CSRTemplateRequiredValue:
+			value = subject[key]
+			if value == "" {
+				return nil, fmt.Errorf("CSR template: the subject field %q is required", key)
+			}
+
+		case acme.CSRTemplateAnyValue:
+			value = subject[key]
+			if value == "" {
+				continue
+			}
+
+		default:
+			if v, ok := subject[key]; ok && v != value {
+				return nil, fmt.Errorf("CSR template: the subject field %q must be %q", key, value)
+			}
+		}
+
+		err := setSubjectField(&csr.Subject, key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	san := template.Extensions.SubjectAltName
+
+	if len(san.DNS) == 0 && len(san.Email) == 0 && len(san.URI) == 0 {
+		return nil, errors.New("CSR template: the subject alternative names are required")
+	}
+
+	csr.DNSNames = slices.Clone(san.DNS)
+	csr.EmailAddresses = slices.Clone(san.Email)
+
+	for _, raw := range san.URI {
+		uri, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("CSR template: URI: %w", err)
+		}
+
+		csr.URIs = append(csr.URIs, uri)
+	}
+
+	if len(template.Extensions.KeyUsage) > 0 {
+		ext, err := marshalDelegationKeyUsage(template.Extensions.KeyUsage)
+		if err != nil {
+			return nil, err
+		}
+
+		csr.ExtraExtensions = append(csr.ExtraExtensions, ext)
+	}
+
+	if len(template.Extensions.ExtendedKeyUsage) > 0 {
+		ext, err := marshalDelegationExtKeyUsage(template.Extensions.ExtendedKeyUsage)
+		if err != nil {
+			return nil, err
+		}
+
+		csr.ExtraExtensions = append(csr.ExtraExtensions, ext)
+	}
+
+	return csr, nil
+}
+
+// ValidateDelegationCSR checks that a signed CSR matches a CSR template.
+// - https://www.rfc-editor.org/rfc/rfc9115.html#section-4
+func ValidateDelegationCSR(template *acme.CSRTemplate, csr *x509.CertificateRequest) error {
+	if template == nil {
+		return errors.New("missing CSR template")
+	}
+
+	err := csr.CheckSignature()
+	if err != nil {
+		return fmt.Errorf("CSR signature: %w", err)
+	}
+
+	if !slices.ContainsFunc(template.KeyTypes, func(keyType acme.CSRTemplateKeyType) bool {
+		return matchDelegationKeyType(keyType, csr)
+	}) {
+		return fmt.Errorf("the key type of the CSR (%s, %s) is not allowed by the CSR template", csr.PublicKeyAlgorithm, csr.SignatureAlgorithm)
+	}
+
+	for key, value := range template.Subject {
+		actual, err := getSubjectField(csr.Subject, key)
+		if err != nil {
+			return err
+		}
+
+		switch value {
+		case acme.CSRTemplateAnyValue:
+		case acme.CSRTemplateRequiredValue:
+			if actual == "" {
+				return fmt.Errorf("the subject field %q of the CSR is required by the CSR template", key)
+			}
+		default:
+			if actual != value {
+				return fmt.Errorf("the subject field %q of the CSR must be %q: %q", key, value, actual)
+			}
+		}
+	}
+
+	san := template.Extensions.SubjectAltName
+
+	if !equalSet(san.DNS, csr.DNSNames) {
+		return fmt.Errorf("the DNS names of the CSR %v don't match the CSR template %v", csr.DNSNames, san.DNS)
+	}
+
+	if !equalSet(san.Email, csr.EmailAddresses) {
+		return fmt.Errorf("the email addresses of the CSR %v don't match the CSR template %v", csr.EmailAddresses, san.Email)
+	}
+
+	var uris []string
+	for _, uri := range csr.URIs {
+		uris = append(uris, uri.String())
+	}
+
+	if !equalSet(san.URI, uris) {
+		return fmt.Errorf("the URIs of the CSR %v don't match the CSR template %v", uris, san.URI)
+	}
+
+	return nil
+}
+
+func matchDelegationKeyType(keyType acme.CSRTemplateKeyType, csr *x509.CertificateRequest) bool {
+	if keyType.SignatureType != "" && delegationSignatureAlgorithms[keyType.SignatureType] != csr.SignatureAlgorithm {
+		return false
+	}
+
+	switch keyType.PublicKeyType {
+	case "id-ecPublicKey":
+		pub, ok := csr.PublicKey.(*ecdsa.PublicKey)
+		if !ok {
+			return false
+		}
+
+		return keyType.NamedCurve == "" || delegationCurves[keyType.NamedCurve] == pub.Curve
+
+	case "rsaEncryption":
+		pub, ok := csr.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			return false
+		}
+
+		return keyType.PublicKeyLength == 0 || pub.N.BitLen() == keyType.PublicKeyLength
+
+	default:
+		return false
+	}
+}
+
+func subjectField(name *pkix.Name, key string) (*string, *[]string, error) {
+	switch key {
+	case "commonName":
+		return &name.CommonName, nil, nil
+	case "country":
+		return nil, &name.Country, nil
+	case "stateOrProvince":
+		return nil, &name.Province, nil
+	case "locality":
+		return nil, &name.Locality, nil
+	case "organization":
+		return nil, &name.Organization, nil
+	case "organizationalUnit":
+		return nil, &name.OrganizationalUnit, nil
+	default:
+		return nil, nil, fmt.Errorf("CSR template: unsupported subject field %q", key)
+	}
+}
+
+func setSubjectField(name *pkix.Name, key, value string) error {
+	single, multi, err := subjectField(name, key)
+	if err != nil {
+		return err
+	}
+
+	if single != nil {
+		*single = value
+	} else {
+		*multi = []string{value}
+	}
+
+	return nil
+}
+
+func getSubjectField(name pkix.Name, key string) (string, error) {
+	single, multi, err := subjectField(&name, key)
+	if err != nil {
+		return "", err
+	}
+
+	if single != nil {
+		return *single, nil
+	}
+
+	return strings.Join(*multi, ", "), nil
+}
+
+func marshalDelegationKeyUsage(usages []string) (pkix.Extension, error) {
+	var ku x509.KeyUsage
+
+	for _, usage := range usages {
+		v, ok := delegationKeyUsages[usage]
+		if !ok {
+			return pkix.Extension{}, fmt.Errorf("CSR template: unsupported key usage %q", usage)
+		}
+
+		ku |= v
+	}
+
+	// The bits of the BIT STRING are in the reverse order of the bits of x509.KeyUsage.
+	b := []byte{bits.Reverse8(byte(ku)), bits.Reverse8(byte(ku >> 8))}
+	if b[1] == 0 {
+		b = b[:1]
+	}
+
+	value, err := asn1.Marshal(asn1.BitString{Bytes: b, BitLength: len(b)*8 - bits.TrailingZeros8(b[len(b)-1])})
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{Id: oidExtensionKeyUsage, Critical: true, Value: value}, nil
+}
+
+func marshalDelegationExtKeyUsage(usages []string) (pkix.Extension, error) {
+	var oids []asn1.ObjectIdentifier
+
+	for _, usage := range usages {
+		oid, ok := delegationExtKeyUsages[usage]
+		if !ok {
+			return pkix.Extension{}, fmt.Errorf("CSR template: unsupported extended key usage %q", usage)
+		}
+
+		oids = append(oids, oid)
+	}
+
+	value, err := asn1.Marshal(oids)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{Id: oidExtensionExtendedKeyUsage, Value: value}, nil
+}
+
+func equalSet(a, b []string) bool {
+	return slices.Equal(slices.Sorted(slices.Values(a)), slices.Sorted(slices.Values(b)))
+}
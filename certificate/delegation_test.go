@@ -0,0 +1,212 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCSRTemplate() *acme.CSRTemplate {
+	return &acme.CSRTemplate{
+		KeyTypes: []acme.CSRTemplateKeyType{{
+			PublicKeyType: "id-ecPublicKey",
+			NamedCurve:    "secp256r1",
+			SignatureType: "ecdsa-with-SHA256",
+		}},
+		Subject: map[string]string{
+			"country":      "CA",
+			"organization": acme.CSRTemplateRequiredValue,
+			"locality":     acme.CSRTemplateAnyValue,
+		},
+		Extensions: acme.CSRTemplateExtensions{
+			SubjectAltName:   acme.CSRTemplateSAN{DNS: []string{"abc.ido.example", "www.ido.example"}},
+			KeyUsage:         []string{"digitalSignature"},
+			ExtendedKeyUsage: []string{"serverAuth"},
+		},
+	}
+}
+
+func signTestCSR(t *testing.T, template *x509.CertificateRequest, key any) *x509.CertificateRequest {
+	t.Helper()
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, key)
+	require.NoError(t, err)
+
+	csr, err := x509.ParseCertificateRequest(der)
+	require.NoError(t, err)
+
+	return csr
+}
+
+func TestNewDelegationCSR(t *testing.T) {
+	template, err := NewDelegationCSR(newTestCSRTemplate(), map[string]string{"organization": "CDN Inc."})
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	csr := signTestCSR(t, template, key)
+
+	assert.Equal(t, []string{"CA"}, csr.Subject.Country)
+	assert.Equal(t, []string{"CDN Inc."}, csr.Subject.Organization)
+	assert.Empty(t, csr.Subject.Locality)
+	assert.Equal(t, []string{"abc.ido.example", "www.ido.example"}, csr.DNSNames)
+
+	require.NoError(t, ValidateDelegationCSR(newTestCSRTemplate(), csr))
+
+	// The key usage extension is encoded like the extension of a certificate.
+	certTemplate := &x509.Certificate{SerialNumber: big.NewInt(1), KeyUsage: x509.KeyUsageDigitalSignature}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, certTemplate, certTemplate, key.Public(), key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certDER)
+	require.NoError(t, err)
+
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidExtensionKeyUsage) {
+			assert.Equal(t, ext, template.ExtraExtensions[0])
+		}
+	}
+}
+
+func TestNewDelegationCSR_errors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		subject  map[string]string
+		expected string
+	}{
+		{
+			desc:     "missing required field",
+			expected: `CSR template: the subject field "organization" is required`,
+		},
+		{
+			desc:     "field not allowed",
+			subject:  map[string]string{"organization": "CDN Inc.", "commonName": "example"},
+			expected: `CSR template: the subject field "commonName" is not allowed`,
+		},
+		{
+			desc:     "fixed value",
+			subject:  map[string]string{"organization": "CDN Inc.", "country": "FR"},
+			expected: `CSR template: the subject field "country" must be "CA"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewDelegationCSR(newTestCSRTemplate(), test.subject)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestValidateDelegationCSR_errors(t *testing.T) {
+	template, err := NewDelegationCSR(newTestCSRTemplate(), map[string]string{"organization": "CDN Inc."})
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	err = ValidateDelegationCSR(newTestCSRTemplate(), signTestCSR(t, template, rsaKey))
+	require.EqualError(t, err, "the key type of the CSR (RSA, SHA256-RSA) is not allowed by the CSR template")
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template.DNSNames = []string{"abc.ido.example"}
+
+	err = ValidateDelegationCSR(newTestCSRTemplate(), signTestCSR(t, template, ecKey))
+	require.EqualError(t, err, "the DNS names of the CSR [abc.ido.example] don't match the CSR template [abc.ido.example www.ido.example]")
+
+	template.DNSNames = []string{"abc.ido.example", "www.ido.example"}
+	template.Subject.Organization = nil
+
+	err = ValidateDelegationCSR(newTestCSRTemplate(), signTestCSR(t, template, ecKey))
+	require.EqualError(t, err, `the subject field "organization" of the CSR is required by the CSR template`)
+}
+
+func TestCertifier_ObtainForDelegation(t *testing.T) {
+	var delegation string
+
+	server := tester.MockACMEServer().
+		Route("POST /delegations", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			servermock.JSONEncode(acme.DelegationsList{Delegations: []string{serverURL + "/delegation/1"}}).ServeHTTP(rw, req)
+		})).
+		Route("POST /delegation/1", servermock.JSONEncode(acme.Delegation{CSRTemplate: newTestCSRTemplate()})).
+		Route("POST /newOrder", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			var order acme.Order
+
+			err := readJWSPayload(req, &order)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			delegation = order.Delegation
+
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			rw.Header().Set("Location", serverURL+"/order")
+
+			servermock.JSONEncode(acme.Order{
+				Status:      acme.StatusReady,
+				Identifiers: order.Identifiers,
+				Delegation:  order.Delegation,
+				Finalize:    serverURL + "/finalize",
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /finalize", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			servermock.JSONEncode(acme.Order{
+				Status:      acme.StatusValid,
+				Certificate: serverURL + "/certificate",
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /certificate", servermock.RawStringResponse(certResponseMock)).
+		BuildHTTPS(t)
+
+	certifier := newOrdersCertifier(t, server.Client(), server.URL)
+
+	delegations, err := certifier.ListDelegations(t.Context(), server.URL+"/delegations")
+	require.NoError(t, err)
+
+	require.Len(t, delegations, 1)
+
+	assert.Equal(t, server.URL+"/delegation/1", delegations[0].URL)
+
+	template, err := NewDelegationCSR(delegations[0].CSRTemplate, map[string]string{"organization": "CDN Inc."})
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	cert, err := certifier.ObtainForDelegation(t.Context(), DelegationRequest{
+		Delegation: delegations[0],
+		CSR:        signTestCSR(t, template, key),
+		Bundle:     true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, server.URL+"/delegation/1", delegation)
+	assert.Equal(t, "abc.ido.example", cert.Domain)
+	assert.Equal(t, certResponseMock, string(cert.Certificate))
+	assert.Nil(t, cert.PrivateKey)
+	assert.NotNil(t, cert.CSR)
+}
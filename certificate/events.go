@@ -0,0 +1,88 @@
+package certificate
+
+import (
+	"context"
+
+	"github.com/go-acme/lego/v4/challenge"
+)
+
+// Events receives the events of the issuance lifecycle (ex: to send notifications, write audit logs, display a progress).
+//
+// The methods are called synchronously during the issuance:
+// they must return quickly and be safe for concurrent use (the challenges are solved concurrently).
+// NoopEvents can be embedded to implement only some of the methods.
+type Events interface {
+	// OrderCreated is called when an order has been created.
+	OrderCreated(ctx context.Context, event OrderEvent)
+
+	// ChallengePresented is called when a challenge has been presented:
+	// after the creation of the record for the challenges with a preparation step (DNS-01),
+	// before the validation for the other challenges (HTTP-01, TLS-ALPN-01), which present the challenge during the validation.
+	ChallengePresented(ctx context.Context, event ChallengeEvent)
+
+	// ChallengeValidated is called when a challenge has been validated by the ACME server.
+	ChallengeValidated(ctx context.Context, event ChallengeEvent)
+
+	// ChallengeFailed is called when a challenge cannot be presented or validated.
+	ChallengeFailed(ctx context.Context, event ChallengeEvent)
+
+	// CleanupFailed is called when the cleanup of a challenge has failed.
+	CleanupFailed(ctx context.Context, event ChallengeEvent)
+
+	// CertObtained is called when a certificate has been obtained.
+	CertObtained(ctx context.Context, event CertEvent)
+
+	// ObtainFailed is called when a certificate cannot be obtained.
+	ObtainFailed(ctx context.Context, event CertEvent)
+}
+
+// OrderEvent an event related to an order.
+type OrderEvent struct {
+	Domains []string
+	// OrderURL the URL of the order.
+	OrderURL string
+}
+
+// ChallengeEvent an event related to a challenge.
+type ChallengeEvent struct {
+	// Domain the targeted domain (ex: "*.example.com").
+	Domain string
+	Type   challenge.Type
+	// Err the error, only for the failures.
+	Err error
+}
+
+// CertEvent an event related to a certificate request.
+type CertEvent struct {
+	Domains []string
+	// Resource the certificate, only if the certificate has been obtained.
+	Resource *Resource
+	// Err the error, only for the failures.
+	Err error
+}
+
+// NoopEvents an implementation of Events that does nothing.
+type NoopEvents struct{}
+
+func (NoopEvents) OrderCreated(context.Context, OrderEvent) {}
+
+func (NoopEvents) ChallengePresented(context.Context, ChallengeEvent) {}
+
+func (NoopEvents) ChallengeValidated(context.Context, ChallengeEvent) {}
+
+func (NoopEvents) ChallengeFailed(context.Context, ChallengeEvent) {}
+
+func (NoopEvents) CleanupFailed(context.Context, ChallengeEvent) {}
+
+func (NoopEvents) CertObtained(context.Context, CertEvent) {}
+
+func (NoopEvents) ObtainFailed(context.Context, CertEvent) {}
+
+// EventsOrNoop returns the events, or an implementation that does nothing if the events are nil.
+func EventsOrNoop(events Events) Events {
+	if events == nil {
+		return NoopEvents{}
+	}
+
+	return events
+}
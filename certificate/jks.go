@@ -0,0 +1,189 @@
+package certificate
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+)
+
+const (
+	jksMagic   = 0xfeedfeed
+	jksVersion = 2
+
+	jksPrivateKeyEntry = 1
+
+	// jksWhitener the string appended to the password to compute the integrity digest of the keystore.
+	jksWhitener = "Mighty Aphrodite"
+)
+
+// oidJKSKeyProtector the OID of the proprietary algorithm of Sun protecting the private keys of a JKS keystore.
+var oidJKSKeyProtector = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 42, 2, 17, 1, 1}
+
+// EncodeJKS encodes the private key, the certificate, and the issuer certificates of the resource
+// as a Java KeyStore (JKS) with a single private key entry, protected by the password.
+// The alias is stored in lower case, like keytool.
+//
+// The keystore and the private key use the same password (expected by Tomcat and Kafka).
+func EncodeJKS(res *Resource, alias, password string) ([]byte, error) {
+	if alias == "" {
+		return nil, errors.New("JKS: the alias is required")
+	}
+
+	if len(res.PrivateKey) == 0 {
+		return nil, errors.New("JKS: the private key is required")
+	}
+
+	chain, err := certcrypto.ParsePEMBundle(res.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("JKS: certificate: %w", err)
+	}
+
+	// The certificate is not bundled with the issuers (--no-bundle).
+	if len(chain) == 1 && len(res.IssuerCertificate) > 0 {
+		issuers, errP := certcrypto.ParsePEMBundle(res.IssuerCertificate)
+		if errP != nil {
+			return nil, fmt.Errorf("JKS: issuer certificate: %w", errP)
+		}
+
+		chain = append(chain, issuers...)
+	}
+
+	privateKey, err := certcrypto.ParsePEMPrivateKey(res.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("JKS: private key: %w", err)
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("JKS: private key: %w", err)
+	}
+
+	passwordBytes := jksPassword(password)
+
+	protectedKey, err := protectJKSKey(pkcs8, passwordBytes)
+	if err != nil {
+		return nil, fmt.Errorf("JKS: private key: %w", err)
+	}
+
+	buf := &bytes.Buffer{}
+
+	writeJKSUint32(buf, jksMagic)
+	writeJKSUint32(buf, jksVersion)
+	writeJKSUint32(buf, 1)
+
+	writeJKSUint32(buf, jksPrivateKeyEntry)
+
+	err = writeJKSString(buf, strings.ToLower(alias))
+	if err != nil {
+		return nil, fmt.Errorf("JKS: alias: %w", err)
+	}
+
+	_ = binary.Write(buf, binary.BigEndian, time.Now().UnixMilli())
+
+	writeJKSUint32(buf, uint32(len(protectedKey)))
+	buf.Write(protectedKey)
+
+	writeJKSUint32(buf, uint32(len(chain)))
+
+	for _, cert := range chain {
+		_ = writeJKSString(buf, "X.509")
+
+		writeJKSUint32(buf, uint32(len(cert.Raw)))
+		buf.Write(cert.Raw)
+	}
+
+	digest := sha1.New()
+	digest.Write(passwordBytes)
+	digest.Write([]byte(jksWhitener))
+	digest.Write(buf.Bytes())
+
+	buf.Write(digest.Sum(nil))
+
+	return buf.Bytes(), nil
+}
+
+// protectJKSKey encrypts the private key (PKCS#8) with the key protector of the JKS format:
+// the key is XORed with a stream of SHA-1 digests of the password, seeded with a random salt,
+// and followed by the SHA-1 digest of the password and of the key.
+func protectJKSKey(pkcs8, passwordBytes []byte) ([]byte, error) {
+	salt := make([]byte, sha1.Size)
+
+	_, err := rand.Read(salt)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := make([]byte, len(pkcs8))
+
+	block := salt
+
+	for i := 0; i < len(pkcs8); i += sha1.Size {
+		digest := sha1.New()
+		digest.Write(passwordBytes)
+		digest.Write(block)
+		block = digest.Sum(nil)
+
+		for j := 0; j < sha1.Size && i+j < len(pkcs8); j++ {
+			encrypted[i+j] = pkcs8[i+j] ^ block[j]
+		}
+	}
+
+	check := sha1.New()
+	check.Write(passwordBytes)
+	check.Write(pkcs8)
+
+	info := struct {
+		Algorithm     pkix.AlgorithmIdentifier
+		EncryptedData []byte
+	}{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidJKSKeyProtector, Parameters: asn1.NullRawValue},
+		EncryptedData: bytes.Join([][]byte{salt, encrypted, check.Sum(nil)}, nil),
+	}
+
+	return asn1.Marshal(info)
+}
+
+// jksPassword returns the bytes of the password used by the JKS format: the UTF-16 code units (big-endian) of the password.
+func jksPassword(password string) []byte {
+	var data []byte
+	for _, unit := range utf16.Encode([]rune(password)) {
+		data = binary.BigEndian.AppendUint16(data, unit)
+	}
+
+	return data
+}
+
+func writeJKSUint32(buf *bytes.Buffer, value uint32) {
+	_ = binary.Write(buf, binary.BigEndian, value)
+}
+
+// writeJKSString writes a string with the format of Java DataOutput.writeUTF (the length on 2 bytes, then the bytes).
+// Only the strings without NUL characters and without characters outside the BMP have the same encoding in UTF-8.
+func writeJKSString(buf *bytes.Buffer, value string) error {
+	if len(value) > math.MaxUint16 {
+		return errors.New("too long")
+	}
+
+	for _, r := range value {
+		if r == 0 || r > 0xffff {
+			return fmt.Errorf("unsupported character: %q", r)
+		}
+	}
+
+	_ = binary.Write(buf, binary.BigEndian, uint16(len(value)))
+	buf.WriteString(value)
+
+	return nil
+}
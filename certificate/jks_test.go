@@ -0,0 +1,169 @@
+package certificate
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The values of the JKS format, from the implementation of the JDK (sun.security.provider.JavaKeyStore and KeyProtector):
+// the test reader doesn't use the constants and the helpers of the encoder.
+const (
+	testJKSMagic    = 0xfeedfeed
+	testJKSWhitener = "Mighty Aphrodite"
+)
+
+func TestEncodeJKS(t *testing.T) {
+	res, leafKey := createKeyStoreTestResource(t)
+
+	// The UTF-16 (big-endian) encoding of "changeit".
+	testJKSPassword := []byte{0, 'c', 0, 'h', 0, 'a', 0, 'n', 0, 'g', 0, 'e', 0, 'i', 0, 't'}
+
+	data, err := EncodeJKS(res, "Tomcat", "changeit")
+	require.NoError(t, err)
+
+	// Integrity digest: SHA-1(password, whitener, content).
+	content, sum := data[:len(data)-sha1.Size], data[len(data)-sha1.Size:]
+
+	digest := sha1.New()
+	digest.Write(testJKSPassword)
+	digest.Write([]byte(testJKSWhitener))
+	digest.Write(content)
+
+	assert.Equal(t, digest.Sum(nil), sum)
+
+	r := bytes.NewReader(content)
+
+	assert.Equal(t, uint32(testJKSMagic), readTestUint32(t, r))
+	assert.Equal(t, uint32(2), readTestUint32(t, r), "version")
+	assert.Equal(t, uint32(1), readTestUint32(t, r), "number of entries")
+	assert.Equal(t, uint32(1), readTestUint32(t, r), "private key entry")
+	assert.Equal(t, "tomcat", readTestString(t, r))
+
+	var timestamp int64
+	require.NoError(t, binary.Read(r, binary.BigEndian, &timestamp))
+	assert.Positive(t, timestamp)
+
+	// Private key: EncryptedPrivateKeyInfo with the key protector of Sun (NULL parameters).
+	var info struct {
+		Algorithm     pkix.AlgorithmIdentifier
+		EncryptedData []byte
+	}
+
+	rest, err := asn1.Unmarshal(readTestBytes(t, r, readTestUint32(t, r)), &info)
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+
+	assert.Equal(t, asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 42, 2, 17, 1, 1}, info.Algorithm.Algorithm)
+	assert.Equal(t, []byte{0x05, 0x00}, info.Algorithm.Parameters.FullBytes)
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(unprotectTestJKSKey(t, info.EncryptedData, testJKSPassword))
+	require.NoError(t, err)
+
+	assert.Equal(t, leafKey, privateKey)
+
+	// Chain.
+	require.Equal(t, uint32(2), readTestUint32(t, r))
+
+	var subjects []string
+
+	for range 2 {
+		assert.Equal(t, "X.509", readTestString(t, r))
+
+		cert, err := x509.ParseCertificate(readTestBytes(t, r, readTestUint32(t, r)))
+		require.NoError(t, err)
+
+		subjects = append(subjects, cert.Subject.CommonName)
+	}
+
+	assert.Equal(t, []string{"", "lego test root"}, subjects)
+
+	assert.Zero(t, r.Len())
+}
+
+func TestEncodeJKS_errors(t *testing.T) {
+	res, _ := createKeyStoreTestResource(t)
+
+	_, err := EncodeJKS(res, "", "changeit")
+	require.EqualError(t, err, "JKS: the alias is required")
+
+	_, err = EncodeJKS(res, "lego\x00", "changeit")
+	require.EqualError(t, err, `JKS: alias: unsupported character: '\x00'`)
+
+	_, err = EncodeJKS(&Resource{Certificate: res.Certificate}, "lego", "changeit")
+	require.EqualError(t, err, "JKS: the private key is required")
+}
+
+func Test_jksPassword(t *testing.T) {
+	assert.Equal(t, []byte{0x00, 'a', 0x00, 'b', 0x00, 0xe9}, jksPassword("abé"))
+}
+
+// unprotectTestJKSKey decrypts a private key protected with the key protector of Sun (KeyProtector.recover of the JDK):
+// salt (20 bytes), the key XORed with the digests SHA-1(password, previous digest), SHA-1(password, key).
+func unprotectTestJKSKey(t *testing.T, data, passwordBytes []byte) []byte {
+	t.Helper()
+
+	require.Greater(t, len(data), 2*sha1.Size)
+
+	salt, encrypted, check := data[:sha1.Size], data[sha1.Size:len(data)-sha1.Size], data[len(data)-sha1.Size:]
+
+	plain := make([]byte, len(encrypted))
+
+	block := salt
+
+	for i := 0; i < len(encrypted); i += sha1.Size {
+		digest := sha1.New()
+		digest.Write(passwordBytes)
+		digest.Write(block)
+		block = digest.Sum(nil)
+
+		for j := 0; j < sha1.Size && i+j < len(encrypted); j++ {
+			plain[i+j] = encrypted[i+j] ^ block[j]
+		}
+	}
+
+	digest := sha1.New()
+	digest.Write(passwordBytes)
+	digest.Write(plain)
+
+	require.Equal(t, digest.Sum(nil), check)
+
+	return plain
+}
+
+func readTestUint32(t *testing.T, r io.Reader) uint32 {
+	t.Helper()
+
+	var value uint32
+	require.NoError(t, binary.Read(r, binary.BigEndian, &value))
+
+	return value
+}
+
+func readTestString(t *testing.T, r io.Reader) string {
+	t.Helper()
+
+	var size uint16
+	require.NoError(t, binary.Read(r, binary.BigEndian, &size))
+
+	return string(readTestBytes(t, r, uint32(size)))
+}
+
+func readTestBytes(t *testing.T, r io.Reader, size uint32) []byte {
+	t.Helper()
+
+	data := make([]byte, size)
+
+	_, err := io.ReadFull(r, data)
+	require.NoError(t, err)
+
+	return data
+}
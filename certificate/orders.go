@@ -0,0 +1,74 @@
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-acme/lego/v4/acme"
+)
+
+// ListOrders returns the orders of an account.
+// The orders URL is the field `orders` of the account (registration.Resource.Body.Orders):
+// some CAs don't provide it.
+func (c *Certifier) ListOrders(ctx context.Context, ordersURL string) ([]acme.ExtendedOrder, error) {
+	orderURLs, err := c.core.Orders.List(ctx, ordersURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders []acme.ExtendedOrder
+
+	for _, orderURL := range orderURLs {
+		order, err := c.core.Orders.GetContext(ctx, orderURL)
+		if err != nil {
+			return nil, fmt.Errorf("order %s: %w", orderURL, err)
+		}
+
+		orders = append(orders, order)
+	}
+
+	return orders, nil
+}
+
+// IsStuckOrder reports whether the order waits for the validation of its authorizations.
+// The pending orders (and their pending authorizations) count against the rate limits of the CAs until they expire.
+func IsStuckOrder(order acme.ExtendedOrder) bool {
+	return order.Status == acme.StatusPending
+}
+
+// AbandonOrder deactivates the pending authorizations of a pending order.
+// The order becomes invalid and stops counting against the rate limits.
+func (c *Certifier) AbandonOrder(ctx context.Context, orderURL string) error {
+	order, err := c.core.Orders.GetContext(ctx, orderURL)
+	if err != nil {
+		return err
+	}
+
+	if !IsStuckOrder(order) {
+		return fmt.Errorf("order %s: only the pending orders can be abandoned (status: %s)", orderURL, order.Status)
+	}
+
+	var errs []error
+
+	for _, authzURL := range order.Authorizations {
+		authz, err := c.core.Authorizations.GetContext(ctx, authzURL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("authorization %s: %w", authzURL, err))
+			continue
+		}
+
+		if authz.Status != acme.StatusPending {
+			continue
+		}
+
+		c.core.Log().Infof("[%s] acme: Deactivating auth: %s", authz.Identifier.Value, authzURL)
+
+		err = c.core.Authorizations.DeactivateContext(ctx, authzURL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("authorization %s: %w", authzURL, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
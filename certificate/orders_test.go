@@ -0,0 +1,104 @@
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertifier_ListOrders(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("POST /orders", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			servermock.JSONEncode(acme.OrdersList{
+				Orders: []string{serverURL + "/order/1", serverURL + "/order/2"},
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /order/1", servermock.JSONEncode(acme.Order{Status: acme.StatusValid})).
+		Route("POST /order/2", servermock.JSONEncode(acme.Order{Status: acme.StatusPending})).
+		BuildHTTPS(t)
+
+	certifier := newOrdersCertifier(t, server.Client(), server.URL)
+
+	orders, err := certifier.ListOrders(t.Context(), server.URL+"/orders")
+	require.NoError(t, err)
+
+	require.Len(t, orders, 2)
+
+	assert.Equal(t, server.URL+"/order/1", orders[0].Location)
+	assert.False(t, IsStuckOrder(orders[0]))
+
+	assert.Equal(t, server.URL+"/order/2", orders[1].Location)
+	assert.True(t, IsStuckOrder(orders[1]))
+}
+
+func TestCertifier_AbandonOrder(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		deactivated []string
+	)
+
+	server := tester.MockACMEServer().
+		Route("POST /order/pending", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			servermock.JSONEncode(acme.Order{
+				Status:         acme.StatusPending,
+				Authorizations: []string{serverURL + "/authz/valid", serverURL + "/authz/pending"},
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /order/valid", servermock.JSONEncode(acme.Order{Status: acme.StatusValid})).
+		Route("POST /authz/{status}", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			var authz acme.Authorization
+
+			// The error is ignored: the payload of a POST-as-GET is empty.
+			_ = readJWSPayload(req, &authz)
+
+			if authz.Status == acme.StatusDeactivated {
+				mu.Lock()
+				deactivated = append(deactivated, req.PathValue("status"))
+				mu.Unlock()
+			}
+
+			servermock.JSONEncode(acme.Authorization{
+				Status:     req.PathValue("status"),
+				Identifier: acme.Identifier{Type: "dns", Value: req.PathValue("status") + ".example.com"},
+			}).ServeHTTP(rw, req)
+		})).
+		BuildHTTPS(t)
+
+	certifier := newOrdersCertifier(t, server.Client(), server.URL)
+
+	err := certifier.AbandonOrder(t.Context(), server.URL+"/order/valid")
+	require.EqualError(t, err, "order "+server.URL+"/order/valid: only the pending orders can be abandoned (status: valid)")
+
+	err = certifier.AbandonOrder(t.Context(), server.URL+"/order/pending")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"pending"}, slices.Clone(deactivated))
+}
+
+func newOrdersCertifier(t *testing.T, client *http.Client, serverURL string) *Certifier {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	core, err := api.New(client, "lego-test", serverURL+"/dir", serverURL+"/account/1", key)
+	require.NoError(t, err)
+
+	return NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+}
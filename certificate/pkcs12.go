@@ -0,0 +1,74 @@
+package certificate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// PKCS12Format the encryption of a PKCS#12 bundle.
+type PKCS12Format string
+
+const (
+	// PKCS12Modern AES-256-CBC with PBKDF2, and SHA-256 MAC (pkcs12.Modern2023).
+	// Supported by OpenSSL 1.1.1+, Java 12+, and Windows Server 2019+.
+	PKCS12Modern PKCS12Format = "SHA256"
+
+	// PKCS12LegacyDES 3DES encryption, and SHA-1 MAC (pkcs12.LegacyDES).
+	// Supported by the older systems (ex: Windows Server 2016 and earlier).
+	PKCS12LegacyDES PKCS12Format = "DES"
+
+	// PKCS12LegacyRC2 RC2 encryption for the certificates, 3DES for the private key, and SHA-1 MAC (pkcs12.LegacyRC2).
+	// Supported by most of the appliances, but RC2 is not supported by OpenSSL 3 without the legacy provider.
+	PKCS12LegacyRC2 PKCS12Format = "RC2"
+)
+
+// EncodePKCS12 encodes the certificate, the issuer certificates, and the private key of the resource
+// as a PKCS#12 bundle (.pfx), encrypted with the password.
+func EncodePKCS12(res *Resource, password string, format PKCS12Format) ([]byte, error) {
+	encoder, err := getPKCS12Encoder(format)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res.PrivateKey) == 0 {
+		return nil, errors.New("PKCS#12: the private key is required")
+	}
+
+	cert, err := certcrypto.ParsePEMCertificate(res.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#12: certificate: %w", err)
+	}
+
+	chain, err := certcrypto.ParsePEMBundle(res.IssuerCertificate)
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#12: issuer certificate: %w", err)
+	}
+
+	privateKey, err := certcrypto.ParsePEMPrivateKey(res.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#12: private key: %w", err)
+	}
+
+	data, err := encoder.Encode(privateKey, cert, chain, password)
+	if err != nil {
+		return nil, fmt.Errorf("PKCS#12: %w", err)
+	}
+
+	return data, nil
+}
+
+func getPKCS12Encoder(format PKCS12Format) (*pkcs12.Encoder, error) {
+	switch format {
+	case PKCS12Modern:
+		return pkcs12.Modern2023, nil
+	case PKCS12LegacyDES:
+		return pkcs12.LegacyDES, nil
+	case PKCS12LegacyRC2:
+		return pkcs12.LegacyRC2, nil
+	default:
+		return nil, fmt.Errorf("PKCS#12: unknown format: %q", format)
+	}
+}
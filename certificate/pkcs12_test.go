@@ -0,0 +1,94 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+func TestEncodePKCS12(t *testing.T) {
+	res, leafKey := createKeyStoreTestResource(t)
+
+	formats := []PKCS12Format{PKCS12Modern, PKCS12LegacyDES, PKCS12LegacyRC2}
+
+	for _, format := range formats {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			data, err := EncodePKCS12(res, "secret", format)
+			require.NoError(t, err)
+
+			privateKey, cert, chain, err := pkcs12.DecodeChain(data, "secret")
+			require.NoError(t, err)
+
+			assert.Equal(t, leafKey, privateKey)
+			assert.Equal(t, []string{"example.com"}, cert.DNSNames)
+			require.Len(t, chain, 1)
+			assert.Equal(t, "lego test root", chain[0].Subject.CommonName)
+		})
+	}
+}
+
+func TestEncodePKCS12_errors(t *testing.T) {
+	res, _ := createKeyStoreTestResource(t)
+
+	_, err := EncodePKCS12(res, "secret", "AES")
+	require.EqualError(t, err, `PKCS#12: unknown format: "AES"`)
+
+	_, err = EncodePKCS12(&Resource{Certificate: res.Certificate, IssuerCertificate: res.IssuerCertificate}, "secret", PKCS12Modern)
+	require.EqualError(t, err, "PKCS#12: the private key is required")
+}
+
+func createKeyStoreTestResource(t *testing.T) (*Resource, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "lego test root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+	require.NoError(t, err)
+
+	root, err := x509.ParseCertificate(rootDER)
+	require.NoError(t, err)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	leafTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"example.com"},
+	}
+
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, root, leafKey.Public(), rootKey)
+	require.NoError(t, err)
+
+	res := &Resource{
+		Certificate:       certcrypto.PEMEncode(certcrypto.DERCertificateBytes(leafDER)),
+		IssuerCertificate: certcrypto.PEMEncode(certcrypto.DERCertificateBytes(rootDER)),
+		PrivateKey:        certcrypto.PEMEncode(leafKey),
+	}
+
+	return res, leafKey
+}
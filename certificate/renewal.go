@@ -1,17 +1,22 @@
 package certificate
 
 import (
+	"context"
 	"crypto/x509"
 	"encoding/asn1"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"golang.org/x/crypto/ocsp"
 )
 
 // RenewalInfoRequest contains the necessary renewal information.
@@ -74,12 +79,17 @@ func (r *RenewalInfoResponse) ShouldRenewAt(now time.Time, willingToSleep time.D
 //
 // https://www.rfc-editor.org/rfc/rfc9773.html
 func (c *Certifier) GetRenewalInfo(req RenewalInfoRequest) (*RenewalInfoResponse, error) {
+	return c.GetRenewalInfoContext(context.Background(), req)
+}
+
+// GetRenewalInfoContext is like [Certifier.GetRenewalInfo] with a context.
+func (c *Certifier) GetRenewalInfoContext(ctx context.Context, req RenewalInfoRequest) (*RenewalInfoResponse, error) {
 	certID, err := MakeARICertID(req.Cert)
 	if err != nil {
 		return nil, fmt.Errorf("error making certID: %w", err)
 	}
 
-	resp, err := c.core.Certificates.GetRenewalInfo(certID)
+	resp, err := c.core.Certificates.GetRenewalInfoContext(ctx, certID)
 	if err != nil {
 		return nil, err
 	}
@@ -131,3 +141,165 @@ func MakeARICertID(leaf *x509.Certificate) (string, error) {
 	// Construct the final identifier by concatenating AKI and Serial Number.
 	return fmt.Sprintf("%s.%s", aki, serial), nil
 }
+
+// RenewalOptions the options of NeedsRenewal.
+type RenewalOptions struct {
+	// Days the number of days left on the certificate to renew it.
+	// The certificate is renewed when the number of full days left is lower than or equal to Days.
+	// A negative value always renews the certificate.
+	Days int
+
+	// Dynamic computes the renewal date from the lifetime of the certificate, instead of Days:
+	// the certificate is renewed when 1/3 of its lifetime is left (1/2 for the certificates valid for 10 days or less).
+	Dynamic bool
+
+	// Certifier checks the renewal information (ARI) and the revocation status (OCSP) with the CA.
+	// If nil, only the dates of the certificate are used.
+	Certifier *Certifier
+
+	// DisableARI doesn't check the renewal information (ARI).
+	DisableARI bool
+
+	// ARIWillingToSleep the duration the caller is willing to wait for the renewal time suggested by the CA (ARI).
+	ARIWillingToSleep time.Duration
+
+	// CheckRevocation checks the revocation status of the certificate (OCSP):
+	// a revoked certificate is renewed immediately.
+	// The certificates without OCSP server are not checked.
+	CheckRevocation bool
+}
+
+// RenewalDecision the renewal decision of a certificate.
+type RenewalDecision struct {
+	// Renew true if the certificate must be renewed.
+	Renew bool
+
+	// RenewAt the time of the renewal (only if Renew is true):
+	// now, or the time suggested by the CA (ARI) if it's within RenewalOptions.ARIWillingToSleep.
+	RenewAt time.Time
+
+	// Reason the reason of the decision.
+	Reason string
+
+	// NotAfter the expiration date of the certificate.
+	NotAfter time.Time
+
+	// Remaining the remaining validity of the certificate.
+	Remaining time.Duration
+
+	// DueDate the renewal date computed from the dates of the certificate (RenewalOptions.Days or RenewalOptions.Dynamic).
+	DueDate time.Time
+}
+
+// NeedsRenewal decides if a certificate (PEM encoded, or a PEM bundle starting with the certificate) must be renewed.
+// It uses the same checks as the CLI, in this order:
+// the revocation status (RenewalOptions.CheckRevocation), the renewal information of the CA (ARI),
+// and the dates of the certificate (RenewalOptions.Days or RenewalOptions.Dynamic).
+//
+// The errors of the CA (ex: the server doesn't support ARI) are logged, and the next check is used.
+func NeedsRenewal(ctx context.Context, certPEM []byte, opts RenewalOptions) (*RenewalDecision, error) {
+	certificates, err := certcrypto.ParsePEMBundle(certPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return needsRenewal(ctx, certPEM, certificates[0], opts, time.Now().UTC())
+}
+
+// NeedsRenewalCertificates is like [NeedsRenewal] with the parsed certificates of the bundle (the certificate first, then the issuers).
+func NeedsRenewalCertificates(ctx context.Context, certificates []*x509.Certificate, opts RenewalOptions) (*RenewalDecision, error) {
+	if len(certificates) == 0 {
+		return nil, errors.New("no certificate")
+	}
+
+	// The issuers are used to check the revocation status.
+	var certPEM []byte
+	for _, cert := range certificates {
+		certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+
+	return needsRenewal(ctx, certPEM, certificates[0], opts, time.Now().UTC())
+}
+
+func needsRenewal(ctx context.Context, certPEM []byte, cert *x509.Certificate, opts RenewalOptions, now time.Time) (*RenewalDecision, error) {
+	if cert.IsCA {
+		return nil, errors.New("the certificate bundle starts with a CA certificate")
+	}
+
+	domain := strings.Join(certcrypto.ExtractDomains(cert), ", ")
+
+	decision := &RenewalDecision{
+		NotAfter:  cert.NotAfter,
+		Remaining: cert.NotAfter.Sub(now),
+		DueDate:   RenewalDueDate(cert, opts.Days, opts.Dynamic),
+	}
+
+	if opts.Certifier != nil && opts.CheckRevocation && len(cert.OCSPServer) > 0 {
+		_, ocspResp, errO := opts.Certifier.GetOCSPContext(ctx, certPEM)
+
+		switch {
+		case errO != nil:
+			opts.Certifier.core.Log().Warnf("[%s] acme: checking the revocation status: %v", domain, errO)
+
+		case ocspResp != nil && ocspResp.Status == ocsp.Revoked:
+			decision.Renew = true
+			decision.RenewAt = now
+			decision.Reason = "the certificate has been revoked"
+
+			return decision, nil
+		}
+	}
+
+	if opts.Certifier != nil && !opts.DisableARI {
+		renewalInfo, errR := opts.Certifier.GetRenewalInfoContext(ctx, RenewalInfoRequest{Cert: cert})
+		if errR != nil {
+			opts.Certifier.core.Log().Warnf("[%s] acme: calling renewal info endpoint: %v", domain, errR)
+		} else if renewAt := renewalInfo.ShouldRenewAt(now, opts.ARIWillingToSleep); renewAt != nil {
+			decision.Renew = true
+			decision.RenewAt = *renewAt
+			decision.Reason = "the renewal information (ARI) indicates that the renewal is needed"
+
+			return decision, nil
+		}
+	}
+
+	if now.After(decision.DueDate) {
+		decision.Renew = true
+		decision.RenewAt = now
+		decision.Reason = fmt.Sprintf("the certificate expires at %s, the renewal date is %s",
+			cert.NotAfter.Format(time.RFC3339), decision.DueDate.Format(time.RFC3339))
+
+		return decision, nil
+	}
+
+	decision.Reason = fmt.Sprintf("the certificate expires at %s, the renewal can be performed in %s",
+		cert.NotAfter.Format(time.RFC3339), decision.DueDate.Sub(now))
+
+	return decision, nil
+}
+
+// RenewalDueDate returns the date from which a certificate must be renewed, based on the dates of the certificate:
+// the certificate must be renewed after this date.
+//
+// If dynamic is true, the date is computed from the lifetime of the certificate:
+// 1/3 of the lifetime left, or 1/2 of the lifetime for the certificates valid for 10 days or less.
+// Otherwise, the certificate must be renewed when the number of full days left is lower than or equal to days
+// (the zero time if days is negative: always renew).
+func RenewalDueDate(cert *x509.Certificate, days int, dynamic bool) time.Time {
+	if dynamic {
+		lifetime := cert.NotAfter.Sub(cert.NotBefore)
+
+		var divisor int64 = 3
+		if lifetime.Round(24*time.Hour).Hours()/24.0 <= 10 {
+			divisor = 2
+		}
+
+		return cert.NotAfter.Add(-1 * time.Duration(lifetime.Nanoseconds()/divisor))
+	}
+
+	if days < 0 {
+		return time.Time{}
+	}
+
+	return cert.NotAfter.Add(-time.Duration(days+1) * 24 * time.Hour)
+}
@@ -3,6 +3,8 @@ package certificate
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"net/http"
 	"testing"
 	"time"
@@ -237,3 +239,188 @@ func TestRenewalInfoResponse_ShouldRenew(t *testing.T) {
 		assert.Nil(t, rt)
 	})
 }
+
+func TestRenewalDueDate(t *testing.T) {
+	notBefore := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		notAfter time.Time
+		days     int
+		dynamic  bool
+		expected time.Time
+	}{
+		{
+			desc:     "30 days",
+			notAfter: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			days:     30,
+			expected: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "0 days: only the day of the expiration",
+			notAfter: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "negative days: always renew",
+			notAfter: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			days:     -1,
+			expected: time.Time{},
+		},
+		{
+			desc:     "dynamic: 1/3 of the lifetime",
+			notAfter: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+			dynamic:  true,
+			expected: time.Date(2025, 1, 21, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "dynamic: 1/2 of the lifetime of the short-lived certificates",
+			notAfter: time.Date(2025, 1, 7, 0, 0, 0, 0, time.UTC),
+			dynamic:  true,
+			expected: time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			cert := &x509.Certificate{NotBefore: notBefore, NotAfter: test.notAfter}
+
+			assert.Equal(t, test.expected, RenewalDueDate(cert, test.days, test.dynamic))
+		})
+	}
+}
+
+func Test_needsRenewal(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "example.com"},
+		NotBefore: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	decision, err := needsRenewal(t.Context(), nil, cert, RenewalOptions{Days: 30}, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	expected := &RenewalDecision{
+		Reason:    "the certificate expires at 2025-04-01T00:00:00Z, the renewal can be performed in 672h0m0s",
+		NotAfter:  cert.NotAfter,
+		Remaining: 59 * 24 * time.Hour,
+		DueDate:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, expected, decision)
+
+	now := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	decision, err = needsRenewal(t.Context(), nil, cert, RenewalOptions{Days: 30}, now)
+	require.NoError(t, err)
+
+	assert.True(t, decision.Renew)
+	assert.Equal(t, now, decision.RenewAt)
+	assert.Equal(t, "the certificate expires at 2025-04-01T00:00:00Z, the renewal date is 2025-03-01T00:00:00Z", decision.Reason)
+}
+
+func Test_needsRenewal_dynamic(t *testing.T) {
+	testCases := []struct {
+		desc                string
+		now                 time.Time
+		notBefore, notAfter time.Time
+		expected            assert.BoolAssertionFunc
+	}{
+		{
+			desc:      "higher than 1/3 of the certificate lifetime left (lifetime > 10 days)",
+			now:       time.Date(2025, 1, 19, 1, 1, 1, 1, time.UTC),
+			notBefore: time.Date(2025, 1, 1, 1, 1, 1, 1, time.UTC),
+			notAfter:  time.Date(2025, 1, 30, 1, 1, 1, 1, time.UTC),
+			expected:  assert.False,
+		},
+		{
+			desc:      "lower than 1/3 of the certificate lifetime left(lifetime > 10 days)",
+			now:       time.Date(2025, 1, 21, 1, 1, 1, 1, time.UTC),
+			notBefore: time.Date(2025, 1, 1, 1, 1, 1, 1, time.UTC),
+			notAfter:  time.Date(2025, 1, 30, 1, 1, 1, 1, time.UTC),
+			expected:  assert.True,
+		},
+		{
+			desc:      "higher than 1/2 of the certificate lifetime left (lifetime < 10 days)",
+			now:       time.Date(2025, 1, 4, 1, 1, 1, 1, time.UTC),
+			notBefore: time.Date(2025, 1, 1, 1, 1, 1, 1, time.UTC),
+			notAfter:  time.Date(2025, 1, 10, 1, 1, 1, 1, time.UTC),
+			expected:  assert.False,
+		},
+		{
+			desc:      "lower than 1/2 of the certificate lifetime left (lifetime < 10 days)",
+			now:       time.Date(2025, 1, 6, 1, 1, 1, 1, time.UTC),
+			notBefore: time.Date(2025, 1, 1, 1, 1, 1, 1, time.UTC),
+			notAfter:  time.Date(2025, 1, 10, 1, 1, 1, 1, time.UTC),
+			expected:  assert.True,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			x509Cert := &x509.Certificate{
+				NotBefore: test.notBefore,
+				NotAfter:  test.notAfter,
+			}
+
+			decision, err := needsRenewal(t.Context(), nil, x509Cert, RenewalOptions{Dynamic: true}, test.now)
+			require.NoError(t, err)
+
+			test.expected(t, decision.Renew)
+		})
+	}
+}
+
+func Test_needsRenewal_CA(t *testing.T) {
+	_, err := needsRenewal(t.Context(), nil, &x509.Certificate{IsCA: true}, RenewalOptions{}, time.Now())
+	require.EqualError(t, err, "the certificate bundle starts with a CA certificate")
+}
+
+func TestNeedsRenewal_ARI(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("GET /renewalInfo/"+ariLeafCertID,
+			servermock.RawStringResponse(`{
+				"suggestedWindow": {
+					"start": "2020-03-17T17:51:09Z",
+					"end": "2020-03-17T18:21:09Z"
+				}
+			}`).
+				WithHeader("Content-Type", "application/json")).
+		BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	decision, err := NeedsRenewal(t.Context(), []byte(ariLeafPEM), RenewalOptions{Days: 30, Certifier: certifier})
+	require.NoError(t, err)
+
+	assert.True(t, decision.Renew)
+	assert.Equal(t, "the renewal information (ARI) indicates that the renewal is needed", decision.Reason)
+	assert.WithinDuration(t, time.Now(), decision.RenewAt, time.Minute)
+}
+
+func TestNeedsRenewalCertificates(t *testing.T) {
+	_, err := NeedsRenewalCertificates(t.Context(), nil, RenewalOptions{})
+	require.EqualError(t, err, "no certificate")
+
+	certificates, err := certcrypto.ParsePEMBundle([]byte(ariLeafPEM))
+	require.NoError(t, err)
+
+	decision, err := NeedsRenewalCertificates(t.Context(), certificates, RenewalOptions{Days: 30})
+	require.NoError(t, err)
+
+	expected, err := NeedsRenewal(t.Context(), []byte(ariLeafPEM), RenewalOptions{Days: 30})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected.Renew, decision.Renew)
+	assert.Equal(t, expected.DueDate, decision.DueDate)
+}
@@ -0,0 +1,68 @@
+package certificate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-acme/lego/v4/acme"
+)
+
+// GetStarCertificate fetches the last certificate issued by an auto-renewal (STAR) order (RFC 8739).
+// The validations are not performed again: the certificates share the private key of the order.
+//
+// The returned Resource is a copy of certRes with the new certificate.
+// If the order has been canceled or is expired, the error is recognized by IsAutoRenewalEnded.
+// - https://www.rfc-editor.org/rfc/rfc8739.html#section-3.3
+func (c *Certifier) GetStarCertificate(ctx context.Context, certRes Resource, bundle bool) (*Resource, error) {
+	if certRes.StarCertificateURL == "" {
+		return nil, fmt.Errorf("[%s] the certificate has not been issued by an auto-renewal (STAR) order", certRes.Domain)
+	}
+
+	c.core.Log().Infof("[%s] acme: Fetching the last STAR certificate", certRes.Domain)
+
+	cert, issuer, err := c.core.Certificates.GetContext(ctx, certRes.StarCertificateURL, bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	certRes.Certificate = cert
+	certRes.IssuerCertificate = issuer
+	certRes.CertURL = certRes.StarCertificateURL
+	certRes.CertStableURL = certRes.StarCertificateURL
+
+	return &certRes, nil
+}
+
+// CancelAutoRenewal cancels an auto-renewal (STAR) order: the CA stops issuing new certificates.
+// The certificates already issued are not revoked.
+// - https://www.rfc-editor.org/rfc/rfc8739.html#section-2.3
+func (c *Certifier) CancelAutoRenewal(ctx context.Context, certRes Resource) error {
+	if certRes.OrderURL == "" {
+		return fmt.Errorf("[%s] the certificate has not been issued by an auto-renewal (STAR) order", certRes.Domain)
+	}
+
+	c.core.Log().Infof("[%s] acme: Canceling the auto-renewal order: %s", certRes.Domain, certRes.OrderURL)
+
+	order, err := c.core.Orders.Cancel(ctx, certRes.OrderURL)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != acme.StatusCanceled {
+		return fmt.Errorf("[%s] the auto-renewal order has not been canceled (status: %s)", certRes.Domain, order.Status)
+	}
+
+	return nil
+}
+
+// IsAutoRenewalEnded reports whether the error indicates that an auto-renewal (STAR) order has been canceled or is expired:
+// a new order is required to obtain a new certificate.
+func IsAutoRenewalEnded(err error) bool {
+	var problem *acme.ProblemDetails
+	if !errors.As(err, &problem) {
+		return false
+	}
+
+	return problem.Type == acme.AutoRenewalCanceledErr || problem.Type == acme.AutoRenewalExpiredErr
+}
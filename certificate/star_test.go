@@ -0,0 +1,116 @@
+package certificate
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkResponse_star(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("POST /star/1", servermock.RawStringResponse(certResponseMock)).
+		BuildHTTPS(t)
+
+	certifier := newOrdersCertifier(t, server.Client(), server.URL)
+
+	order := acme.ExtendedOrder{
+		Order: acme.Order{
+			Status:          acme.StatusValid,
+			AutoRenewal:     &acme.AutoRenewal{EndDate: "2030-01-01T00:00:00Z", Lifetime: 86400},
+			StarCertificate: server.URL + "/star/1",
+		},
+	}
+
+	certRes := &Resource{}
+
+	valid, err := certifier.checkResponse(t.Context(), order, certRes, true, "")
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	assert.Equal(t, server.URL+"/star/1", certRes.StarCertificateURL)
+	assert.Equal(t, server.URL+"/star/1", certRes.CertURL)
+	assert.Equal(t, certResponseMock, string(certRes.Certificate))
+}
+
+func TestCertifier_GetStarCertificate(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("POST /star/1", servermock.RawStringResponse(certResponseMock)).
+		Route("POST /star/2",
+			servermock.JSONEncode(acme.ProblemDetails{
+				Type:   acme.AutoRenewalExpiredErr,
+				Detail: "the auto-renewal order is expired",
+			}).WithStatusCode(http.StatusForbidden)).
+		BuildHTTPS(t)
+
+	certifier := newOrdersCertifier(t, server.Client(), server.URL)
+
+	certRes := Resource{
+		Domain:             "example.com",
+		PrivateKey:         []byte("key"),
+		StarCertificateURL: server.URL + "/star/1",
+		OrderURL:           server.URL + "/order/1",
+	}
+
+	newRes, err := certifier.GetStarCertificate(t.Context(), certRes, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, certResponseMock, string(newRes.Certificate))
+	assert.Equal(t, issuerMock, string(newRes.IssuerCertificate))
+	assert.Equal(t, []byte("key"), newRes.PrivateKey)
+	assert.Equal(t, certRes.OrderURL, newRes.OrderURL)
+	assert.Nil(t, certRes.Certificate)
+
+	certRes.StarCertificateURL = server.URL + "/star/2"
+
+	_, err = certifier.GetStarCertificate(t.Context(), certRes, true)
+	require.Error(t, err)
+
+	assert.True(t, IsAutoRenewalEnded(err))
+
+	_, err = certifier.GetStarCertificate(t.Context(), Resource{Domain: "example.com"}, true)
+	require.EqualError(t, err, "[example.com] the certificate has not been issued by an auto-renewal (STAR) order")
+}
+
+func TestCertifier_CancelAutoRenewal(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("POST /order/{status}", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			var msg acme.OrderStatusMessage
+
+			err := readJWSPayload(req, &msg)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if msg.Status != acme.StatusCanceled {
+				http.Error(rw, fmt.Sprintf("unexpected status: %s", msg.Status), http.StatusBadRequest)
+				return
+			}
+
+			servermock.JSONEncode(acme.Order{Status: req.PathValue("status")}).ServeHTTP(rw, req)
+		})).
+		BuildHTTPS(t)
+
+	certifier := newOrdersCertifier(t, server.Client(), server.URL)
+
+	err := certifier.CancelAutoRenewal(t.Context(), Resource{Domain: "example.com", OrderURL: server.URL + "/order/canceled"})
+	require.NoError(t, err)
+
+	err = certifier.CancelAutoRenewal(t.Context(), Resource{Domain: "example.com", OrderURL: server.URL + "/order/valid"})
+	require.EqualError(t, err, "[example.com] the auto-renewal order has not been canceled (status: valid)")
+
+	err = certifier.CancelAutoRenewal(t.Context(), Resource{Domain: "example.com"})
+	require.EqualError(t, err, "[example.com] the certificate has not been issued by an auto-renewal (STAR) order")
+}
+
+func TestIsAutoRenewalEnded(t *testing.T) {
+	assert.True(t, IsAutoRenewalEnded(fmt.Errorf("wrapped: %w", &acme.ProblemDetails{Type: acme.AutoRenewalCanceledErr})))
+	assert.False(t, IsAutoRenewalEnded(&acme.ProblemDetails{Type: acme.RateLimitedErr}))
+	assert.False(t, IsAutoRenewalEnded(nil))
+}
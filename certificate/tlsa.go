@@ -0,0 +1,130 @@
+package certificate
+
+import (
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+)
+
+// TLSAOptions the parameters of the TLSA records (DANE).
+type TLSAOptions struct {
+	Usage        uint8
+	Selector     uint8
+	MatchingType uint8
+
+	// Port the port of the service (ex: 443, 25).
+	Port int
+	// Protocol the transport protocol of the service (ex: "tcp").
+	Protocol string
+}
+
+// TLSARecord a TLSA record.
+type TLSARecord struct {
+	// Name the owner name of the record (ex: "_443._tcp.example.com.").
+	Name string
+
+	Usage        uint8
+	Selector     uint8
+	MatchingType uint8
+
+	// Data the certificate association data (hex encoded).
+	Data string
+}
+
+// RData returns the RDATA of the record in the presentation format (ex: "3 1 1 <hex>").
+func (r TLSARecord) RData() string {
+	return fmt.Sprintf("%d %d %d %s", r.Usage, r.Selector, r.MatchingType, r.Data)
+}
+
+// String returns the record in the zone-file format.
+func (r TLSARecord) String() string {
+	return fmt.Sprintf("%s IN TLSA %s", r.Name, r.RData())
+}
+
+// TLSARecords computes the TLSA records of a certificate chain, for each domain of the leaf certificate.
+// The certificates must be ordered from the leaf to the issuer.
+// The wildcard domains are ignored.
+// The usages PKIX-TA (0) and DANE-TA (2) use the issuer certificate (the second certificate of the chain).
+func TLSARecords(certs []*x509.Certificate, opts TLSAOptions) ([]TLSARecord, error) {
+	if len(certs) == 0 {
+		return nil, errors.New("tlsa: missing certificate")
+	}
+
+	if opts.Protocol == "" {
+		opts.Protocol = "tcp"
+	}
+
+	var cert *x509.Certificate
+
+	switch opts.Usage {
+	case certcrypto.TLSAUsagePKIXEE, certcrypto.TLSAUsageDANEEE:
+		cert = certs[0]
+	case certcrypto.TLSAUsagePKIXTA, certcrypto.TLSAUsageDANETA:
+		if len(certs) < 2 {
+			return nil, fmt.Errorf("tlsa: the usage %d requires the issuer certificate", opts.Usage)
+		}
+
+		cert = certs[1]
+	default:
+		return nil, fmt.Errorf("tlsa: unsupported usage: %d", opts.Usage)
+	}
+
+	data, err := certcrypto.TLSAData(cert, opts.Selector, opts.MatchingType)
+	if err != nil {
+		return nil, fmt.Errorf("tlsa: %w", err)
+	}
+
+	var records []TLSARecord
+
+	for _, domain := range certs[0].DNSNames {
+		if strings.HasPrefix(domain, "*.") {
+			continue
+		}
+
+		records = append(records, TLSARecord{
+			Name:         fmt.Sprintf("_%d._%s.%s.", opts.Port, opts.Protocol, strings.TrimSuffix(domain, ".")),
+			Usage:        opts.Usage,
+			Selector:     opts.Selector,
+			MatchingType: opts.MatchingType,
+			Data:         data,
+		})
+	}
+
+	return records, nil
+}
+
+// NextKeyTLSARecords computes the TLSA records of a future certificate using the public key next,
+// with the domains of the leaf certificate.
+// The records can be published before the key is used, so the clients with the records in cache
+// can connect when the key goes live.
+// Only the usages PKIX-EE (1) and DANE-EE (3) with the selector SPKI (1) are supported:
+// the other records depend on the future certificate itself.
+func NextKeyTLSARecords(leaf *x509.Certificate, next crypto.PublicKey, opts TLSAOptions) ([]TLSARecord, error) {
+	if !SupportsNextKeyTLSA(opts) {
+		return nil, fmt.Errorf("tlsa: the records of the next key are not supported with the parameters %d %d", opts.Usage, opts.Selector)
+	}
+
+	spki, err := x509.MarshalPKIXPublicKey(next)
+	if err != nil {
+		return nil, fmt.Errorf("tlsa: %w", err)
+	}
+
+	cert := *leaf
+	cert.RawSubjectPublicKeyInfo = spki
+
+	return TLSARecords([]*x509.Certificate{&cert}, opts)
+}
+
+// SupportsNextKeyTLSA returns true if the TLSA records of the next key can be computed before the certificate is issued.
+func SupportsNextKeyTLSA(opts TLSAOptions) bool {
+	switch opts.Usage {
+	case certcrypto.TLSAUsagePKIXEE, certcrypto.TLSAUsageDANEEE:
+		return opts.Selector == certcrypto.TLSASelectorSPKI
+	default:
+		return false
+	}
+}
@@ -0,0 +1,149 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSARecords(t *testing.T) {
+	leaf := &x509.Certificate{
+		Raw:                     []byte("raw-leaf"),
+		RawSubjectPublicKeyInfo: []byte("spki-leaf"),
+		DNSNames:                []string{"example.com", "*.example.com", "mail.example.com"},
+	}
+
+	issuer := &x509.Certificate{
+		Raw:                     []byte("raw-issuer"),
+		RawSubjectPublicKeyInfo: []byte("spki-issuer"),
+	}
+
+	testCases := []struct {
+		desc          string
+		certs         []*x509.Certificate
+		opts          TLSAOptions
+		expected      []string
+		expectedError string
+	}{
+		{
+			desc:  "DANE-EE SPKI SHA-256",
+			certs: []*x509.Certificate{leaf, issuer},
+			opts:  TLSAOptions{Usage: 3, Selector: 1, MatchingType: 1, Port: 443},
+			expected: []string{
+				"_443._tcp.example.com. IN TLSA 3 1 1 20b5378d8b4c9e98d11f564a106257c598327a5e11a50c4fa28da6412eaf4c66",
+				"_443._tcp.mail.example.com. IN TLSA 3 1 1 20b5378d8b4c9e98d11f564a106257c598327a5e11a50c4fa28da6412eaf4c66",
+			},
+		},
+		{
+			desc:  "DANE-TA full certificate SHA-512",
+			certs: []*x509.Certificate{leaf, issuer},
+			opts:  TLSAOptions{Usage: 2, Selector: 0, MatchingType: 2, Port: 25, Protocol: "tcp"},
+			expected: []string{
+				"_25._tcp.example.com. IN TLSA 2 0 2 9ba4579f6a78d90382a583291b95485201af880da27df8d22a7b34956fbc13ce818e295637cf25106869ab43fb853a7c20ee7072012ffe283ee55d6d826c9c56",
+				"_25._tcp.mail.example.com. IN TLSA 2 0 2 9ba4579f6a78d90382a583291b95485201af880da27df8d22a7b34956fbc13ce818e295637cf25106869ab43fb853a7c20ee7072012ffe283ee55d6d826c9c56",
+			},
+		},
+		{
+			desc:  "full data",
+			certs: []*x509.Certificate{leaf},
+			opts:  TLSAOptions{Usage: 1, Selector: 1, MatchingType: 0, Port: 443},
+			expected: []string{
+				"_443._tcp.example.com. IN TLSA 1 1 0 73706b692d6c656166",
+				"_443._tcp.mail.example.com. IN TLSA 1 1 0 73706b692d6c656166",
+			},
+		},
+		{
+			desc:          "missing issuer",
+			certs:         []*x509.Certificate{leaf},
+			opts:          TLSAOptions{Usage: 2, Selector: 1, MatchingType: 1, Port: 443},
+			expectedError: "tlsa: the usage 2 requires the issuer certificate",
+		},
+		{
+			desc:          "unsupported usage",
+			certs:         []*x509.Certificate{leaf},
+			opts:          TLSAOptions{Usage: 4, Port: 443},
+			expectedError: "tlsa: unsupported usage: 4",
+		},
+		{
+			desc:          "unsupported selector",
+			certs:         []*x509.Certificate{leaf},
+			opts:          TLSAOptions{Usage: 3, Selector: 2, Port: 443},
+			expectedError: "tlsa: unsupported TLSA selector: 2",
+		},
+		{
+			desc:          "unsupported matching type",
+			certs:         []*x509.Certificate{leaf},
+			opts:          TLSAOptions{Usage: 3, MatchingType: 3, Port: 443},
+			expectedError: "tlsa: unsupported TLSA matching type: 3",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			records, err := TLSARecords(test.certs, test.opts)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			var lines []string
+			for _, record := range records {
+				lines = append(lines, record.String())
+			}
+
+			assert.Equal(t, test.expected, lines)
+		})
+	}
+}
+
+func TestNextKeyTLSARecords(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	spki, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	leaf := &x509.Certificate{
+		Raw:                     []byte("raw-leaf"),
+		RawSubjectPublicKeyInfo: []byte("spki-leaf"),
+		DNSNames:                []string{"example.com", "mail.example.com"},
+	}
+
+	opts := TLSAOptions{Usage: 3, Selector: 1, MatchingType: 1, Port: 443}
+
+	records, err := NextKeyTLSARecords(leaf, key.Public(), opts)
+	require.NoError(t, err)
+
+	// The records of the future certificate with the same domains and the next key.
+	expected, err := TLSARecords([]*x509.Certificate{{RawSubjectPublicKeyInfo: spki, DNSNames: leaf.DNSNames}}, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, records)
+
+	// The leaf certificate is not modified.
+	assert.Equal(t, []byte("spki-leaf"), leaf.RawSubjectPublicKeyInfo)
+}
+
+func TestNextKeyTLSARecords_unsupported(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	leaf := &x509.Certificate{DNSNames: []string{"example.com"}}
+
+	for _, opts := range []TLSAOptions{
+		{Usage: 3, Selector: 0, MatchingType: 1, Port: 443},
+		{Usage: 2, Selector: 1, MatchingType: 1, Port: 443},
+	} {
+		_, err = NextKeyTLSARecords(leaf, key.Public(), opts)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,98 @@
+package certificate
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+)
+
+// VerifyOptions the options of the verification of an issued certificate.
+type VerifyOptions struct {
+	// Domains the requested domains: each domain must be covered by the certificate.
+	Domains []string
+
+	// Roots the trust store used to verify the chain.
+	// If nil, the system trust store is used.
+	Roots *x509.CertPool
+}
+
+// Verify checks an issued certificate before its deployment:
+//   - the private key matches the certificate (if the private key is available),
+//   - the chain verifies to the trust store,
+//   - every requested domain is covered by the certificate.
+func Verify(res *Resource, opts VerifyOptions) error {
+	if res == nil {
+		return errors.New("verify: missing certificate")
+	}
+
+	certs, err := certcrypto.ParsePEMBundle(res.Certificate)
+	if err != nil {
+		return fmt.Errorf("verify: %w", err)
+	}
+
+	leaf := certs[0]
+
+	if len(res.PrivateKey) > 0 {
+		privateKey, errP := certcrypto.ParsePEMPrivateKey(res.PrivateKey)
+		if errP != nil {
+			return fmt.Errorf("verify: %w", errP)
+		}
+
+		errP = certcrypto.MatchPrivateKey(leaf, privateKey)
+		if errP != nil {
+			return fmt.Errorf("verify: %w", errP)
+		}
+	}
+
+	intermediates := x509.NewCertPool()
+
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if len(res.IssuerCertificate) > 0 {
+		issuers, errP := certcrypto.ParsePEMBundle(res.IssuerCertificate)
+		if errP != nil {
+			return fmt.Errorf("verify: issuer: %w", errP)
+		}
+
+		for _, cert := range issuers {
+			intermediates.AddCert(cert)
+		}
+	}
+
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         opts.Roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("verify: chain: %w", err)
+	}
+
+	var missing []string
+
+	for _, domain := range sanitizeDomain(opts.Domains) {
+		if strings.Contains(domain, "@") {
+			if !slices.ContainsFunc(leaf.EmailAddresses, func(email string) bool { return strings.EqualFold(email, domain) }) {
+				missing = append(missing, domain)
+			}
+
+			continue
+		}
+
+		if leaf.VerifyHostname(domain) != nil {
+			missing = append(missing, domain)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("verify: the certificate doesn't cover the domains: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
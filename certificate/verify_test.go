@@ -0,0 +1,128 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "lego test root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+	require.NoError(t, err)
+
+	root, err := x509.ParseCertificate(rootDER)
+	require.NoError(t, err)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	leafTemplate := &x509.Certificate{
+		SerialNumber:   big.NewInt(2),
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+		DNSNames:       []string{"example.com", "*.example.com"},
+		EmailAddresses: []string{"foo@example.com"},
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTemplate, root, leafKey.Public(), rootKey)
+	require.NoError(t, err)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+
+	res := &Resource{
+		Certificate:       certcrypto.PEMEncode(certcrypto.DERCertificateBytes(leafDER)),
+		IssuerCertificate: certcrypto.PEMEncode(certcrypto.DERCertificateBytes(rootDER)),
+		PrivateKey:        certcrypto.PEMEncode(leafKey),
+	}
+
+	testCases := []struct {
+		desc          string
+		res           *Resource
+		opts          VerifyOptions
+		expectedError string
+	}{
+		{
+			desc: "success",
+			res:  res,
+			opts: VerifyOptions{Domains: []string{"example.com", "*.example.com", "www.example.com"}, Roots: roots},
+		},
+		{
+			desc: "email address",
+			res:  res,
+			opts: VerifyOptions{Domains: []string{"foo@example.com", "Foo@Example.com"}, Roots: roots},
+		},
+		{
+			desc:          "email address not covered",
+			res:           res,
+			opts:          VerifyOptions{Domains: []string{"foo@example.com", "bar@example.com"}, Roots: roots},
+			expectedError: "verify: the certificate doesn't cover the domains: bar@example.com",
+		},
+		{
+			desc: "without private key",
+			res:  &Resource{Certificate: res.Certificate, IssuerCertificate: res.IssuerCertificate},
+			opts: VerifyOptions{Domains: []string{"example.com"}, Roots: roots},
+		},
+		{
+			desc:          "key mismatch",
+			res:           &Resource{Certificate: res.Certificate, IssuerCertificate: res.IssuerCertificate, PrivateKey: certcrypto.PEMEncode(otherKey)},
+			opts:          VerifyOptions{Roots: roots},
+			expectedError: "verify: the private key doesn't match the certificate",
+		},
+		{
+			desc:          "untrusted chain",
+			res:           res,
+			opts:          VerifyOptions{Roots: x509.NewCertPool()},
+			expectedError: "verify: chain: x509: certificate signed by unknown authority",
+		},
+		{
+			desc:          "domain not covered",
+			res:           res,
+			opts:          VerifyOptions{Domains: []string{"example.com", "example.org", "a.b.example.com"}, Roots: roots},
+			expectedError: "verify: the certificate doesn't cover the domains: example.org, a.b.example.com",
+		},
+		{
+			desc:          "missing certificate",
+			res:           &Resource{},
+			expectedError: "verify: no certificates were found while parsing the bundle",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := Verify(test.res, test.opts)
+			if test.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, test.expectedError)
+			}
+		})
+	}
+}
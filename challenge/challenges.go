@@ -18,6 +18,20 @@ const (
 	// Note: GetRecord returns a DNS record which will fulfill this challenge.
 	DNS01 = Type("dns-01")
 
+	// DNSAccount01 is the "dns-account-01" ACME challenge https://datatracker.ietf.org/doc/draft-ietf-acme-dns-account-label/
+	// The TXT record is created on an account-scoped label (`_[account-label]._acme-challenge.[domain].`),
+	// each ACME account can delegate its own challenge domain with a CNAME.
+	// Note: dns01.GetAccountChallengeInfo returns the information of the DNS record which will fulfill this challenge.
+	DNSAccount01 = Type("dns-account-01")
+
+	// DeviceAttest01 is the "device-attest-01" ACME challenge https://datatracker.ietf.org/doc/draft-acme-device-attest/
+	// The device proves the possession of its permanent identifier with an attestation (WebAuthn format).
+	DeviceAttest01 = Type("device-attest-01")
+
+	// EmailReply00 is the "email-reply-00" ACME challenge https://www.rfc-editor.org/rfc/rfc8823.html
+	// The email address is validated by replying to the challenge email sent by the ACME server (S/MIME certificates).
+	EmailReply00 = Type("email-reply-00")
+
 	// TLSALPN01 is the "tls-alpn-01" ACME challenge https://www.rfc-editor.org/rfc/rfc8737.html
 	TLSALPN01 = Type("tls-alpn-01")
 )
@@ -0,0 +1,200 @@
+package deviceattest01
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// Attestation statement formats.
+// https://www.iana.org/assignments/webauthn/webauthn.xhtml#webauthn-attestation-statement-format-ids
+const (
+	// FormatApple the Apple Managed Device Attestation:
+	// the nonce of the leaf certificate is the digest of the key authorization (see KeyAuthorizationDigest).
+	FormatApple = "apple"
+
+	// FormatStep the attestation of the PIV keys (ex: YubiKey) used by smallstep:
+	// the key authorization is signed by the attested key.
+	FormatStep = "step"
+
+	// FormatTPM the attestation of a TPM 2.0 key:
+	// the qualifying data (extraData) of the certInfo is the digest of the key authorization (see KeyAuthorizationDigest).
+	FormatTPM = "tpm"
+
+	// FormatPacked the attestation format optimized for the authenticators with limited resources:
+	// the key authorization is signed by the attestation key.
+	FormatPacked = "packed"
+)
+
+// COSE algorithms identifiers used in the attestation statements.
+// https://www.iana.org/assignments/cose/cose.xhtml#algorithms
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgES384 int64 = -35
+	AlgES512 int64 = -36
+	AlgRS256 int64 = -257
+)
+
+// AttestationObject an attestation object (WebAuthn format).
+// https://www.w3.org/TR/webauthn-2/#sctn-attestation
+type AttestationObject struct {
+	// Format the attestation statement format identifier (ex: FormatApple).
+	Format string
+
+	// Statement the attestation statement (attStmt), its fields depend on the format.
+	Statement map[string]any
+
+	// AuthData the authenticator data, empty if the device doesn't provide them.
+	AuthData []byte
+}
+
+// NewAppleAttestation creates an attestation object of an Apple device.
+// The chain starts with the leaf certificate.
+func NewAppleAttestation(chain []*x509.Certificate) *AttestationObject {
+	return &AttestationObject{
+		Format:    FormatApple,
+		Statement: map[string]any{"x5c": rawCertificates(chain)},
+	}
+}
+
+// NewStepAttestation creates an attestation object of a PIV key (ex: YubiKey).
+// The chain starts with the attestation certificate of the key,
+// the signature is the signature of the key authorization by the attested key (see SignKeyAuthorization).
+func NewStepAttestation(chain []*x509.Certificate, alg int64, sig []byte) *AttestationObject {
+	return &AttestationObject{
+		Format: FormatStep,
+		Statement: map[string]any{
+			"alg": alg,
+			"sig": sig,
+			"x5c": rawCertificates(chain),
+		},
+	}
+}
+
+// TPMStatement the attestation statement of a TPM 2.0 key.
+// https://www.w3.org/TR/webauthn-2/#sctn-tpm-attestation
+type TPMStatement struct {
+	// Alg the COSE algorithm of the signature.
+	Alg int64
+
+	// Chain the chain of the attestation key (AK) certificate, starting with the AK certificate.
+	Chain []*x509.Certificate
+
+	// Sig the signature of the certInfo by the attestation key.
+	Sig []byte
+
+	// CertInfo the TPMS_ATTEST structure (the result of TPM2_Certify).
+	CertInfo []byte
+
+	// PubArea the TPMT_PUBLIC structure of the attested key.
+	PubArea []byte
+}
+
+// NewTPMAttestation creates an attestation object of a TPM 2.0 key.
+func NewTPMAttestation(stmt TPMStatement) *AttestationObject {
+	return &AttestationObject{
+		Format: FormatTPM,
+		Statement: map[string]any{
+			"ver":      "2.0",
+			"alg":      stmt.Alg,
+			"x5c":      rawCertificates(stmt.Chain),
+			"sig":      stmt.Sig,
+			"certInfo": stmt.CertInfo,
+			"pubArea":  stmt.PubArea,
+		},
+	}
+}
+
+// Marshal encodes the attestation object with CBOR.
+func (o *AttestationObject) Marshal() ([]byte, error) {
+	if o.Format == "" {
+		return nil, errors.New("missing attestation format")
+	}
+
+	statement := o.Statement
+	if statement == nil {
+		statement = map[string]any{}
+	}
+
+	authData := o.AuthData
+	if authData == nil {
+		authData = []byte{}
+	}
+
+	return marshalCBOR(map[string]any{
+		"fmt":      o.Format,
+		"attStmt":  statement,
+		"authData": authData,
+	})
+}
+
+// KeyAuthorizationDigest returns the SHA-256 digest of the key authorization:
+// the value bound to the attestation (the nonce of the Apple attestation, the qualifying data of the TPM attestation).
+func KeyAuthorizationDigest(keyAuth string) []byte {
+	sum := sha256.Sum256([]byte(keyAuth))
+
+	return sum[:]
+}
+
+// SignKeyAuthorization signs the key authorization with the attested key (ex: the key of a YubiKey),
+// and returns the COSE algorithm and the signature.
+func SignKeyAuthorization(signer crypto.Signer, keyAuth string) (int64, []byte, error) {
+	switch pub := signer.Public().(type) {
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			sum := sha256.Sum256([]byte(keyAuth))
+			sig, err := signer.Sign(rand.Reader, sum[:], crypto.SHA256)
+
+			return AlgES256, sig, err
+
+		case elliptic.P384():
+			sum := sha512.Sum384([]byte(keyAuth))
+			sig, err := signer.Sign(rand.Reader, sum[:], crypto.SHA384)
+
+			return AlgES384, sig, err
+
+		case elliptic.P521():
+			sum := sha512.Sum512([]byte(keyAuth))
+			sig, err := signer.Sign(rand.Reader, sum[:], crypto.SHA512)
+
+			return AlgES512, sig, err
+
+		default:
+			return 0, nil, fmt.Errorf("unsupported curve: %s", pub.Curve.Params().Name)
+		}
+
+	case *rsa.PublicKey:
+		sum := sha256.Sum256([]byte(keyAuth))
+		sig, err := signer.Sign(rand.Reader, sum[:], crypto.SHA256)
+
+		return AlgRS256, sig, err
+
+	case ed25519.PublicKey:
+		sig, err := signer.Sign(rand.Reader, []byte(keyAuth), crypto.Hash(0))
+
+		return AlgEdDSA, sig, err
+
+	default:
+		return 0, nil, fmt.Errorf("unsupported key type: %T", pub)
+	}
+}
+
+func rawCertificates(chain []*x509.Certificate) [][]byte {
+	raw := make([][]byte, 0, len(chain))
+
+	for _, cert := range chain {
+		raw = append(raw, cert.Raw)
+	}
+
+	return raw
+}
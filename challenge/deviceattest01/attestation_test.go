@@ -0,0 +1,166 @@
+package deviceattest01
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttestationObject_Marshal(t *testing.T) {
+	chain := []*x509.Certificate{{Raw: []byte{0x01, 0x02}}, {Raw: []byte{0x03}}}
+
+	testCases := []struct {
+		desc     string
+		attObj   *AttestationObject
+		expected string
+	}{
+		{
+			desc:   "apple",
+			attObj: NewAppleAttestation(chain),
+			// {"fmt": "apple", "attStmt": {"x5c": [h'0102', h'03']}, "authData": h''}
+			expected: "a363666d74656170706c656761747453746d74a16378356382420102410368617574684461746140",
+		},
+		{
+			desc:   "step",
+			attObj: NewStepAttestation(chain[:1], AlgES256, []byte{0xaa}),
+			// {"fmt": "step", "attStmt": {"alg": -7, "sig": h'aa', "x5c": [h'0102']}, "authData": h''}
+			expected: "a363666d7464737465706761747453746d74a363616c67266373696741aa637835638142010268617574684461746140",
+		},
+		{
+			desc: "with authenticator data",
+			attObj: &AttestationObject{
+				Format:   FormatPacked,
+				AuthData: []byte{0xff},
+			},
+			// {"fmt": "packed", "attStmt": {}, "authData": h'ff'}
+			expected: "a363666d74667061636b65646761747453746d74a068617574684461746141ff",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			data, err := test.attObj.Marshal()
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, hex.EncodeToString(data))
+		})
+	}
+}
+
+func TestAttestationObject_Marshal_error(t *testing.T) {
+	_, err := (&AttestationObject{}).Marshal()
+	require.EqualError(t, err, "missing attestation format")
+}
+
+func TestNewTPMAttestation(t *testing.T) {
+	attObj := NewTPMAttestation(TPMStatement{
+		Alg:      AlgRS256,
+		Chain:    []*x509.Certificate{{Raw: []byte{0x01}}},
+		Sig:      []byte{0x02},
+		CertInfo: []byte{0x03},
+		PubArea:  []byte{0x04},
+	})
+
+	expected := map[string]any{
+		"ver":      "2.0",
+		"alg":      AlgRS256,
+		"x5c":      [][]byte{{0x01}},
+		"sig":      []byte{0x02},
+		"certInfo": []byte{0x03},
+		"pubArea":  []byte{0x04},
+	}
+
+	assert.Equal(t, FormatTPM, attObj.Format)
+	assert.Equal(t, expected, attObj.Statement)
+}
+
+func TestKeyAuthorizationDigest(t *testing.T) {
+	sum := sha256.Sum256([]byte("token.thumbprint"))
+
+	assert.Equal(t, sum[:], KeyAuthorizationDigest("token.thumbprint"))
+}
+
+func TestSignKeyAuthorization(t *testing.T) {
+	const keyAuth = "token.thumbprint"
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	ec384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	sum256 := sha256.Sum256([]byte(keyAuth))
+	sum384 := sha512.Sum384([]byte(keyAuth))
+
+	testCases := []struct {
+		desc   string
+		signer crypto.Signer
+		alg    int64
+		verify func(t *testing.T, sig []byte)
+	}{
+		{
+			desc:   "ES256",
+			signer: ecKey,
+			alg:    AlgES256,
+			verify: func(t *testing.T, sig []byte) {
+				t.Helper()
+				assert.True(t, ecdsa.VerifyASN1(&ecKey.PublicKey, sum256[:], sig))
+			},
+		},
+		{
+			desc:   "ES384",
+			signer: ec384Key,
+			alg:    AlgES384,
+			verify: func(t *testing.T, sig []byte) {
+				t.Helper()
+				assert.True(t, ecdsa.VerifyASN1(&ec384Key.PublicKey, sum384[:], sig))
+			},
+		},
+		{
+			desc:   "RS256",
+			signer: rsaKey,
+			alg:    AlgRS256,
+			verify: func(t *testing.T, sig []byte) {
+				t.Helper()
+				assert.NoError(t, rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, sum256[:], sig))
+			},
+		},
+		{
+			desc:   "EdDSA",
+			signer: edKey,
+			alg:    AlgEdDSA,
+			verify: func(t *testing.T, sig []byte) {
+				t.Helper()
+				assert.True(t, ed25519.Verify(edKey.Public().(ed25519.PublicKey), []byte(keyAuth), sig))
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			alg, sig, err := SignKeyAuthorization(test.signer, keyAuth)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.alg, alg)
+
+			test.verify(t, sig)
+		})
+	}
+}
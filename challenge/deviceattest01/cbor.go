@@ -0,0 +1,160 @@
+package deviceattest01
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"slices"
+)
+
+// CBOR major types.
+// https://www.rfc-editor.org/rfc/rfc8949.html#section-3.1
+const (
+	majorUnsigned byte = iota
+	majorNegative
+	majorBytes
+	majorText
+	majorArray
+	majorMap
+)
+
+// marshalCBOR encodes a value with the core deterministic encoding of CBOR (only the types used by the attestation objects).
+// https://www.rfc-editor.org/rfc/rfc8949.html#section-4.2.1
+func marshalCBOR(value any) ([]byte, error) {
+	buf := &bytes.Buffer{}
+
+	err := encodeCBOR(buf, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodeCBOR(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteByte(0xf6)
+
+	case bool:
+		if v {
+			buf.WriteByte(0xf5)
+		} else {
+			buf.WriteByte(0xf4)
+		}
+
+	case int:
+		encodeCBORInt(buf, int64(v))
+
+	case int64:
+		encodeCBORInt(buf, v)
+
+	case uint64:
+		encodeCBORHead(buf, majorUnsigned, v)
+
+	case string:
+		encodeCBORHead(buf, majorText, uint64(len(v)))
+		buf.WriteString(v)
+
+	case []byte:
+		encodeCBORHead(buf, majorBytes, uint64(len(v)))
+		buf.Write(v)
+
+	case [][]byte:
+		encodeCBORHead(buf, majorArray, uint64(len(v)))
+
+		for _, item := range v {
+			encodeCBORHead(buf, majorBytes, uint64(len(item)))
+			buf.Write(item)
+		}
+
+	case []any:
+		encodeCBORHead(buf, majorArray, uint64(len(v)))
+
+		for _, item := range v {
+			err := encodeCBOR(buf, item)
+			if err != nil {
+				return err
+			}
+		}
+
+	case map[string]any:
+		return encodeCBORMap(buf, v)
+
+	default:
+		return fmt.Errorf("cbor: unsupported type: %T", value)
+	}
+
+	return nil
+}
+
+// encodeCBORMap encodes a map, the keys are sorted in the bytewise lexicographic order of their encodings.
+func encodeCBORMap(buf *bytes.Buffer, m map[string]any) error {
+	type entry struct {
+		key, value []byte
+	}
+
+	var entries []entry
+
+	for key, value := range m {
+		k, err := marshalCBOR(key)
+		if err != nil {
+			return err
+		}
+
+		v, err := marshalCBOR(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+
+		entries = append(entries, entry{key: k, value: v})
+	}
+
+	slices.SortFunc(entries, func(a, b entry) int {
+		return bytes.Compare(a.key, b.key)
+	})
+
+	encodeCBORHead(buf, majorMap, uint64(len(entries)))
+
+	for _, e := range entries {
+		buf.Write(e.key)
+		buf.Write(e.value)
+	}
+
+	return nil
+}
+
+func encodeCBORInt(buf *bytes.Buffer, v int64) {
+	if v < 0 {
+		encodeCBORHead(buf, majorNegative, uint64(-(v + 1)))
+		return
+	}
+
+	encodeCBORHead(buf, majorUnsigned, uint64(v))
+}
+
+// encodeCBORHead encodes the initial byte and the argument with the shortest form.
+func encodeCBORHead(buf *bytes.Buffer, major byte, arg uint64) {
+	head := major << 5
+
+	switch {
+	case arg < 24:
+		buf.WriteByte(head | byte(arg))
+
+	case arg <= 0xff:
+		buf.WriteByte(head | 24)
+		buf.WriteByte(byte(arg))
+
+	case arg <= 0xffff:
+		buf.WriteByte(head | 25)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(arg)))
+
+	case arg <= 0xffffffff:
+		buf.WriteByte(head | 26)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(arg)))
+
+	default:
+		buf.WriteByte(head | 27)
+		buf.Write(binary.BigEndian.AppendUint64(nil, arg))
+	}
+}
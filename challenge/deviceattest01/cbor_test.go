@@ -0,0 +1,54 @@
+package deviceattest01
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_marshalCBOR(t *testing.T) {
+	// https://www.rfc-editor.org/rfc/rfc8949.html#appendix-A
+	testCases := []struct {
+		desc     string
+		value    any
+		expected string
+	}{
+		{desc: "0", value: 0, expected: "00"},
+		{desc: "23", value: 23, expected: "17"},
+		{desc: "24", value: 24, expected: "1818"},
+		{desc: "1000", value: int64(1000), expected: "1903e8"},
+		{desc: "1000000", value: 1000000, expected: "1a000f4240"},
+		{desc: "1000000000000", value: uint64(1000000000000), expected: "1b000000e8d4a51000"},
+		{desc: "-1", value: -1, expected: "20"},
+		{desc: "-7", value: int64(-7), expected: "26"},
+		{desc: "-257", value: int64(-257), expected: "390100"},
+		{desc: "false", value: false, expected: "f4"},
+		{desc: "true", value: true, expected: "f5"},
+		{desc: "null", value: nil, expected: "f6"},
+		{desc: "empty bytes", value: []byte{}, expected: "40"},
+		{desc: "bytes", value: []byte{1, 2, 3, 4}, expected: "4401020304"},
+		{desc: "empty text", value: "", expected: "60"},
+		{desc: "text", value: "IETF", expected: "6449455446"},
+		{desc: "unicode text", value: "ü", expected: "62c3bc"},
+		{desc: "array", value: []any{1, []any{2, 3}, []any{4, 5}}, expected: "8301820203820405"},
+		{desc: "array of bytes", value: [][]byte{{1}, {2}}, expected: "8241014102"},
+		{desc: "map", value: map[string]any{"a": 1, "b": []any{2, 3}}, expected: "a26161016162820203"},
+		{desc: "sorted keys", value: map[string]any{"fmt": "x", "attStmt": map[string]any{}, "authData": []byte{}}, expected: "a363666d7461786761747453746d74a068617574684461746140"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			data, err := marshalCBOR(test.value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, hex.EncodeToString(data))
+		})
+	}
+}
+
+func Test_marshalCBOR_unsupported(t *testing.T) {
+	_, err := marshalCBOR(map[string]any{"foo": 1.5})
+	require.EqualError(t, err, "foo: cbor: unsupported type: float64")
+}
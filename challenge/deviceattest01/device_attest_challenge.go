@@ -0,0 +1,105 @@
+// Package deviceattest01 implements the device-attest-01 challenge.
+// https://datatracker.ietf.org/doc/draft-acme-device-attest/
+package deviceattest01
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+)
+
+// ValidateFunc responds to the challenge with the payload and waits for the validation.
+type ValidateFunc func(ctx context.Context, core *api.Core, domain string, chlng acme.Challenge, payload any) error
+
+type ChallengeOption func(*Challenge) error
+
+// Attester creates the attestations of a device.
+type Attester interface {
+	// Attest returns the attestation of the identifier (ex: the serial number of the device),
+	// bound to the key authorization of the challenge.
+	Attest(ctx context.Context, identifier acme.Identifier, keyAuth string) (*AttestationObject, error)
+}
+
+// AttesterFunc an Attester function.
+type AttesterFunc func(ctx context.Context, identifier acme.Identifier, keyAuth string) (*AttestationObject, error)
+
+// Attest implements Attester.
+func (f AttesterFunc) Attest(ctx context.Context, identifier acme.Identifier, keyAuth string) (*AttestationObject, error) {
+	return f(ctx, identifier, keyAuth)
+}
+
+// payload the response to the challenge.
+// https://datatracker.ietf.org/doc/html/draft-acme-device-attest-03#section-5
+type payload struct {
+	AttObj string `json:"attObj"`
+}
+
+// Challenge implements the device-attest-01 challenge.
+type Challenge struct {
+	core     *api.Core
+	validate ValidateFunc
+	attester Attester
+}
+
+func NewChallenge(core *api.Core, validate ValidateFunc, attester Attester, opts ...ChallengeOption) *Challenge {
+	chlg := &Challenge{
+		core:     core,
+		validate: validate,
+		attester: attester,
+	}
+
+	for _, opt := range opts {
+		err := opt(chlg)
+		if err != nil {
+			chlg.core.Log().Infof("challenge option error: %v", err)
+		}
+	}
+
+	return chlg
+}
+
+// Solve sends the attestation of the device to the ACME server.
+func (c *Challenge) Solve(authz acme.Authorization) error {
+	return c.SolveContext(context.Background(), authz)
+}
+
+// SolveContext is like [Challenge.Solve] with a context.
+func (c *Challenge) SolveContext(ctx context.Context, authz acme.Authorization) error {
+	domain := challenge.GetTargetedDomain(authz)
+	c.core.Log().Infof("[%s] acme: Trying to solve DEVICE-ATTEST-01", domain)
+
+	chlng, err := challenge.FindChallenge(challenge.DeviceAttest01, authz)
+	if err != nil {
+		return err
+	}
+
+	if c.attester == nil {
+		return fmt.Errorf("[%s] acme: no attester configured", domain)
+	}
+
+	// Generate the Key Authorization for the challenge
+	keyAuth, err := c.core.GetKeyAuthorization(chlng.Token)
+	if err != nil {
+		return err
+	}
+
+	attObj, err := c.attester.Attest(ctx, authz.Identifier, keyAuth)
+	if err != nil {
+		return fmt.Errorf("[%s] acme: error creating the attestation: %w", domain, err)
+	}
+
+	if attObj == nil {
+		return fmt.Errorf("[%s] acme: empty attestation", domain)
+	}
+
+	raw, err := attObj.Marshal()
+	if err != nil {
+		return fmt.Errorf("[%s] acme: error encoding the attestation: %w", domain, err)
+	}
+
+	return c.validate(ctx, c.core, domain, chlng, payload{AttObj: base64.RawURLEncoding.EncodeToString(raw)})
+}
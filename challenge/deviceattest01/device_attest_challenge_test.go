@@ -0,0 +1,131 @@
+package deviceattest01
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallenge_Solve(t *testing.T) {
+	server := tester.MockACMEServer().BuildHTTPS(t)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	expectedKeyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	attester := AttesterFunc(func(_ context.Context, identifier acme.Identifier, keyAuth string) (*AttestationObject, error) {
+		assert.Equal(t, acme.Identifier{Type: acme.IdentifierPermanentIdentifier, Value: "SN-1234"}, identifier)
+		assert.Equal(t, expectedKeyAuth, keyAuth)
+
+		return NewAppleAttestation([]*x509.Certificate{{Raw: []byte{0x01, 0x02}}, {Raw: []byte{0x03}}}), nil
+	})
+
+	var response any
+
+	validate := func(_ context.Context, _ *api.Core, domain string, chlng acme.Challenge, payload any) error {
+		assert.Equal(t, "SN-1234", domain)
+		assert.Equal(t, "https://example.com/chlg/1", chlng.URL)
+
+		response = payload
+
+		return nil
+	}
+
+	chlg := NewChallenge(core, validate, attester)
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{Type: acme.IdentifierPermanentIdentifier, Value: "SN-1234"},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DeviceAttest01.String(), URL: "https://example.com/chlg/1", Token: "token"},
+		},
+	}
+
+	err = chlg.SolveContext(t.Context(), authz)
+	require.NoError(t, err)
+
+	require.IsType(t, payload{}, response)
+
+	raw, err := base64.RawURLEncoding.DecodeString(response.(payload).AttObj)
+	require.NoError(t, err)
+
+	assert.Equal(t, "a363666d74656170706c656761747453746d74a16378356382420102410368617574684461746140", hex.EncodeToString(raw))
+}
+
+func TestChallenge_Solve_error(t *testing.T) {
+	server := tester.MockACMEServer().BuildHTTPS(t)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	validate := func(_ context.Context, _ *api.Core, _ string, _ acme.Challenge, _ any) error {
+		return nil
+	}
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{Type: acme.IdentifierPermanentIdentifier, Value: "SN-1234"},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DeviceAttest01.String(), Token: "token"},
+		},
+	}
+
+	testCases := []struct {
+		desc     string
+		attester Attester
+		expected string
+	}{
+		{
+			desc:     "no attester",
+			expected: "[SN-1234] acme: no attester configured",
+		},
+		{
+			desc: "attestation error",
+			attester: AttesterFunc(func(_ context.Context, _ acme.Identifier, _ string) (*AttestationObject, error) {
+				return nil, errors.New("device locked")
+			}),
+			expected: "[SN-1234] acme: error creating the attestation: device locked",
+		},
+		{
+			desc: "empty attestation",
+			attester: AttesterFunc(func(_ context.Context, _ acme.Identifier, _ string) (*AttestationObject, error) {
+				return nil, nil
+			}),
+			expected: "[SN-1234] acme: empty attestation",
+		},
+		{
+			desc: "invalid attestation",
+			attester: AttesterFunc(func(_ context.Context, _ acme.Identifier, _ string) (*AttestationObject, error) {
+				return &AttestationObject{}, nil
+			}),
+			expected: "[SN-1234] acme: error encoding the attestation: missing attestation format",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			chlg := NewChallenge(core, validate, test.attester)
+
+			err := chlg.SolveContext(t.Context(), authz)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package dns01
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/wait"
+	"github.com/miekg/dns"
+)
+
+// TXTRecordFinder is implemented by the DNS providers able to read the TXT records through their API.
+type TXTRecordFinder interface {
+	// HasTXTRecord reports whether a TXT record with the value exists for the FQDN.
+	HasTXTRecord(fqdn, value string) (bool, error)
+}
+
+// hasTXTRecord calls HasTXTRecord when the provider implements TXTRecordFinder (used by the wrappers to forward it).
+// It returns false when the provider cannot read the records.
+func hasTXTRecord(provider challenge.Provider, fqdn, value string) (bool, error) {
+	finder, ok := provider.(TXTRecordFinder)
+	if !ok {
+		return false, nil
+	}
+
+	return finder.HasTXTRecord(fqdn, value)
+}
+
+// cleanUpCheckDNS checks that the record is no longer served by the authoritative nameservers (replaced inside the tests).
+var cleanUpCheckDNS = (*resolver).checkAuthoritativeNssRemoval
+
+// WithCleanUpCheck wraps a provider to check, after each successful CleanUp,
+// that the TXT record has been removed from the provider (when it implements TXTRecordFinder)
+// and from the authoritative nameservers.
+// Some DNS APIs report a success without deleting the record:
+// an error is returned when the record is still present after the timeout.
+// The DNS queries use the settings of the challenge (ex: AddRecursiveNameservers, AuthoritativeNameserverPort).
+// If interval is zero, the polling interval of the wrapped provider (or DefaultPollingInterval) is used.
+// The optional Timeout and Sequential methods of the wrapped provider are preserved.
+func WithCleanUpCheck(provider challenge.Provider, timeout, interval time.Duration) challenge.Provider {
+	if timeout <= 0 {
+		return provider
+	}
+
+	if interval <= 0 {
+		interval = DefaultPollingInterval
+
+		if p, ok := provider.(challenge.ProviderTimeout); ok {
+			_, interval = p.Timeout()
+		}
+	}
+
+	finder, _ := provider.(TXTRecordFinder)
+
+	cleanUp := func(ctx context.Context, domain, token, keyAuth string) error {
+		err := cleanUpFunc(provider)(ctx, domain, token, keyAuth)
+		if err != nil {
+			return err
+		}
+
+		r := resolverFor(keyAuth)
+
+		info := GetChallengeInfo(domain, keyAuth)
+
+		return wait.ForContext(ctx, "cleanup check", timeout, interval, func() (bool, error) {
+			return checkRecordRemoval(r, finder, info.EffectiveFQDN, info.Value)
+		})
+	}
+
+	return wrapProvider(provider, presentFunc(provider), cleanUp)
+}
+
+func checkRecordRemoval(r *resolver, finder TXTRecordFinder, fqdn, value string) (bool, error) {
+	if finder != nil {
+		found, err := finder.HasTXTRecord(fqdn, value)
+		if err != nil {
+			return false, fmt.Errorf("provider: %w", err)
+		}
+
+		if found {
+			return false, fmt.Errorf("provider: the TXT record %s %q still exists", fqdn, value)
+		}
+	}
+
+	err := cleanUpCheckDNS(r, fqdn, value)
+	if err != nil {
+		return false, fmt.Errorf("authoritative nameservers: %w", err)
+	}
+
+	return true, nil
+}
+
+// checkAuthoritativeNssRemoval checks that none of the authoritative nameservers serves the TXT record.
+func (r *resolver) checkAuthoritativeNssRemoval(fqdn, value string) error {
+	authoritativeNss, err := r.lookupNameservers(fqdn)
+	if err != nil {
+		return err
+	}
+
+	var errAll error
+
+	for _, ns := range authoritativeNss {
+		ns = net.JoinHostPort(ns, r.authoritativePort)
+
+		msg, errQ := r.query(fqdn, dns.TypeTXT, []string{ns}, false)
+		if errQ != nil {
+			errAll = errors.Join(errAll, fmt.Errorf("NS %s: %w", ns, errQ))
+			continue
+		}
+
+		for _, rr := range msg.Answer {
+			if txt, ok := rr.(*dns.TXT); ok && strings.Join(txt.Txt, "") == value {
+				errAll = errors.Join(errAll, fmt.Errorf("NS %s still returns the TXT record %s %q", ns, fqdn, value))
+				break
+			}
+		}
+	}
+
+	return errAll
+}
@@ -0,0 +1,177 @@
+package dns01
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeFinderProvider struct {
+	fakeProvider
+
+	found []bool
+	calls int
+}
+
+func (f *fakeFinderProvider) HasTXTRecord(_, _ string) (bool, error) {
+	f.calls++
+
+	if f.calls > len(f.found) {
+		return false, nil
+	}
+
+	return f.found[f.calls-1], nil
+}
+
+func mockCleanUpCheckDNS(t *testing.T, err error) {
+	t.Helper()
+
+	original := cleanUpCheckDNS
+	t.Cleanup(func() { cleanUpCheckDNS = original })
+
+	cleanUpCheckDNS = func(_ *resolver, _, _ string) error { return err }
+}
+
+func TestWithCleanUpCheck(t *testing.T) {
+	mockCleanUpCheckDNS(t, nil)
+
+	fake := &fakeFinderProvider{found: []bool{true, true}}
+
+	provider := WithCleanUpCheck(fake, time.Second, time.Millisecond)
+
+	require.NoError(t, provider.CleanUp("example.com", "", "123d=="))
+
+	assert.Equal(t, 1, fake.cleanUpCalls)
+	assert.Equal(t, 3, fake.calls)
+}
+
+func TestWithCleanUpCheck_stillPresent(t *testing.T) {
+	mockCleanUpCheckDNS(t, errors.New("NS ns1.example.com.:53 still returns the TXT record"))
+
+	provider := WithCleanUpCheck(&fakeProvider{}, 50*time.Millisecond, 10*time.Millisecond)
+
+	err := provider.CleanUp("example.com", "", "123d==")
+	require.ErrorContains(t, err, "cleanup check: time limit exceeded: last error: authoritative nameservers: NS ns1.example.com.:53 still returns the TXT record")
+}
+
+func TestWithCleanUpCheck_cleanUpError(t *testing.T) {
+	mockCleanUpCheckDNS(t, errors.New("unexpected call"))
+
+	provider := WithCleanUpCheck(&fakeProvider{failures: 1}, time.Second, time.Millisecond)
+
+	err := provider.CleanUp("example.com", "", "123d==")
+	require.EqualError(t, err, "cleanup error")
+}
+
+func TestWithCleanUpCheck_disabled(t *testing.T) {
+	fake := &fakeProvider{}
+
+	assert.Same(t, fake, WithCleanUpCheck(fake, 0, 0))
+}
+
+func TestWithCleanUpCheck_wrappedFinder(t *testing.T) {
+	mockCleanUpCheckDNS(t, nil)
+
+	fake := &fakeFinderProvider{found: []bool{true}}
+
+	provider := WithCleanUpCheck(WithLogging(NewMultiProvider(&fakeProvider{}, fake), nil), time.Second, time.Millisecond)
+
+	require.NoError(t, provider.CleanUp("example.com", "", "123d=="))
+
+	assert.Equal(t, 2, fake.calls)
+}
+
+func TestWrappers_forwardTXTRecordFinder(t *testing.T) {
+	export := func(provider challenge.Provider) challenge.Provider {
+		p, err := WithExport(provider, ExportOptions{Path: filepath.Join(t.TempDir(), "records.zone")})
+		require.NoError(t, err)
+
+		return p
+	}
+
+	testCases := []struct {
+		desc string
+		wrap func(provider challenge.Provider) challenge.Provider
+	}{
+		{
+			desc: "logging",
+			wrap: func(provider challenge.Provider) challenge.Provider { return WithLogging(provider, nil) },
+		},
+		{
+			desc: "retries and timeout",
+			wrap: func(provider challenge.Provider) challenge.Provider {
+				return WithTimeout(WithRetries(provider, 2), time.Second)
+			},
+		},
+		{
+			desc: "multi provider (primary)",
+			wrap: func(provider challenge.Provider) challenge.Provider {
+				return NewMultiProvider(provider, &fakeProvider{})
+			},
+		},
+		{
+			desc: "multi provider (fallback)",
+			wrap: func(provider challenge.Provider) challenge.Provider {
+				return NewMultiProvider(&fakeProvider{}, provider)
+			},
+		},
+		{
+			desc: "export",
+			wrap: export,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			finder, ok := test.wrap(&fakeFinderProvider{found: []bool{true}}).(TXTRecordFinder)
+			require.True(t, ok)
+
+			found, err := finder.HasTXTRecord("_acme-challenge.example.com.", "value")
+			require.NoError(t, err)
+
+			assert.True(t, found)
+
+			// The wrapped provider cannot read the records.
+			finder, ok = test.wrap(&fakeProvider{}).(TXTRecordFinder)
+			require.True(t, ok)
+
+			found, err = finder.HasTXTRecord("_acme-challenge.example.com.", "value")
+			require.NoError(t, err)
+
+			assert.False(t, found)
+		})
+	}
+}
+
+func Test_checkAuthoritativeNssRemoval(t *testing.T) {
+	mockResolver(t,
+		dnsmock.NewServer().
+			Query("ns0.lego.localhost. A",
+				dnsmock.Answer(fakeA("ns0.lego.localhost.", "127.0.0.1"))).
+			Query("_acme-challenge.example.com. TXT",
+				dnsmock.Answer(fakeTXT("_acme-challenge.example.com.", "one"))).
+			Build(t),
+	)
+
+	useAsNameserver(t,
+		dnsmock.NewServer().
+			Query("_acme-challenge.example.com. SOA", dnsmock.Error(dns.RcodeNameError)).
+			Query("example.com. SOA", dnsmock.SOA("")).
+			Query("example.com. NS",
+				dnsmock.Answer(fakeNS("example.com.", "ns0.lego.localhost."))).
+			Build(t),
+	)
+
+	err := getDefaultResolver().checkAuthoritativeNssRemoval("_acme-challenge.example.com.", "two")
+	require.NoError(t, err)
+
+	err = getDefaultResolver().checkAuthoritativeNssRemoval("_acme-challenge.example.com.", "one")
+	require.ErrorContains(t, err, `still returns the TXT record _acme-challenge.example.com. "one"`)
+}
@@ -0,0 +1,73 @@
+package dns01
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"strings"
+	"sync"
+
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+)
+
+// accountLabels the account labels of the key authorizations of the ongoing dns-account-01 challenges.
+// Used by GetChallengeInfo to return the record of the dns-account-01 challenge to the DNS providers.
+var accountLabels sync.Map
+
+// challengeResolvers the resolvers of the key authorizations of the ongoing challenges.
+// Used by GetChallengeInfo and the cleanup check to resolve the records with the settings of the challenge.
+var challengeResolvers sync.Map
+
+// NewAccountChallenge creates a solver for the dns-account-01 challenge.
+// The DNS providers of the dns-01 challenge can be used.
+func NewAccountChallenge(core *api.Core, validate ValidateContextFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
+	return newChallenge(challenge.DNSAccount01, core, validate, provider, opts...)
+}
+
+// GetAccountChallengeInfo returns information used to create a DNS record which will fulfill the `dns-account-01` challenge.
+func GetAccountChallengeInfo(domain, accountURL, keyAuth string) ChallengeInfo {
+	return getChallengeInfo(resolverFor(keyAuth), GetAccountLabel(accountURL)+"._acme-challenge", domain, keyAuth)
+}
+
+// GetAccountLabel returns the account label used by the `dns-account-01` challenge (i.e. `_[label]`):
+// the lowercase base32 encoding of the first 10 bytes of the SHA-256 of the account URL.
+// https://datatracker.ietf.org/doc/draft-ietf-acme-dns-account-label/
+func GetAccountLabel(accountURL string) string {
+	sum := sha256.Sum256([]byte(accountURL))
+
+	return "_" + strings.ToLower(base32.StdEncoding.EncodeToString(sum[:10]))
+}
+
+func (c *Challenge) getChallengeInfo(domain, keyAuth string) ChallengeInfo {
+	if c.chlgType == challenge.DNSAccount01 {
+		return getChallengeInfo(c.resolver, GetAccountLabel(c.core.GetAccountURL())+"._acme-challenge", domain, keyAuth)
+	}
+
+	return getChallengeInfo(c.resolver, "_acme-challenge", domain, keyAuth)
+}
+
+// register binds the key authorization to the resolver of the challenge, and to the account label, for GetChallengeInfo.
+func (c *Challenge) register(keyAuth string) {
+	challengeResolvers.Store(keyAuth, c.resolver)
+
+	if c.chlgType == challenge.DNSAccount01 {
+		accountLabels.Store(keyAuth, GetAccountLabel(c.core.GetAccountURL()))
+	}
+}
+
+func (c *Challenge) unregister(keyAuth string) {
+	challengeResolvers.Delete(keyAuth)
+
+	if c.chlgType == challenge.DNSAccount01 {
+		accountLabels.Delete(keyAuth)
+	}
+}
+
+// resolverFor returns the resolver of the challenge of the key authorization, or the default resolver.
+func resolverFor(keyAuth string) *resolver {
+	if r, ok := challengeResolvers.Load(keyAuth); ok {
+		return r.(*resolver)
+	}
+
+	return getDefaultResolver()
+}
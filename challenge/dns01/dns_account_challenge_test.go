@@ -0,0 +1,115 @@
+package dns01
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAccountURL = "https://example.com/acme/acct/ExampleAccount"
+
+// providerRecorderMock records the FQDN returned by GetChallengeInfo, as the DNS providers.
+type providerRecorderMock struct {
+	presented, cleaned []string
+}
+
+func (p *providerRecorderMock) Present(domain, _, keyAuth string) error {
+	p.presented = append(p.presented, GetChallengeInfo(domain, keyAuth).EffectiveFQDN)
+	return nil
+}
+
+func (p *providerRecorderMock) CleanUp(domain, _, keyAuth string) error {
+	p.cleaned = append(p.cleaned, GetChallengeInfo(domain, keyAuth).EffectiveFQDN)
+	return nil
+}
+
+func TestGetAccountLabel(t *testing.T) {
+	assert.Equal(t, "_ujmmovf2vn55tgye", GetAccountLabel(testAccountURL))
+}
+
+func TestGetAccountChallengeInfo(t *testing.T) {
+	useAsNameserver(t, dnsmock.NewServer().
+		Query("_ujmmovf2vn55tgye._acme-challenge.example.com. CNAME", dnsmock.CNAME("tenant.example.net.")).
+		Query("tenant.example.net. CNAME", dnsmock.Noop).
+		Build(t))
+
+	info := GetAccountChallengeInfo("example.com", testAccountURL, "123")
+
+	expected := ChallengeInfo{
+		FQDN:          "_ujmmovf2vn55tgye._acme-challenge.example.com.",
+		EffectiveFQDN: "tenant.example.net.",
+		Value:         "pmWkWSBCL51Bfkhn79xPuKBKHz__H6B-mY6G9_eieuM",
+	}
+
+	assert.Equal(t, expected, info)
+}
+
+func TestAccountChallenge(t *testing.T) {
+	useAsNameserver(t, dnsmock.NewServer().
+		Query("_ujmmovf2vn55tgye._acme-challenge.example.com. CNAME", dnsmock.Noop).
+		Build(t))
+
+	server := tester.MockACMEServer().BuildHTTPS(t)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", testAccountURL, privateKey)
+	require.NoError(t, err)
+
+	var checked, validated string
+
+	validate := func(_ context.Context, _ *api.Core, _ string, chlng acme.Challenge) error {
+		validated = chlng.Type
+		return nil
+	}
+
+	preCheck := func(_, fqdn, _ string, _ PreCheckFunc) (bool, error) {
+		checked = fqdn
+		return true, nil
+	}
+
+	provider := &providerRecorderMock{}
+
+	chlg := NewAccountChallenge(core, validate, provider, WrapPreCheck(preCheck))
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{Value: "example.com"},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String(), Token: "dns"},
+			{Type: challenge.DNSAccount01.String(), Token: "dns-account"},
+		},
+	}
+
+	err = chlg.PreSolveContext(t.Context(), authz)
+	require.NoError(t, err)
+
+	err = chlg.SolveContext(t.Context(), authz)
+	require.NoError(t, err)
+
+	err = chlg.CleanUpContext(t.Context(), authz)
+	require.NoError(t, err)
+
+	fqdn := "_ujmmovf2vn55tgye._acme-challenge.example.com."
+
+	assert.Equal(t, []string{fqdn}, provider.presented)
+	assert.Equal(t, fqdn, checked)
+	assert.Equal(t, challenge.DNSAccount01.String(), validated)
+	assert.Equal(t, []string{fqdn}, provider.cleaned)
+
+	// The key authorization is not bound to the account after the cleanup.
+	keyAuth, err := core.GetKeyAuthorization("dns-account")
+	require.NoError(t, err)
+
+	_, found := accountLabels.Load(keyAuth)
+	assert.False(t, found)
+}
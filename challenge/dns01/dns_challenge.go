@@ -1,6 +1,7 @@
 package dns01
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
@@ -12,9 +13,12 @@ import (
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/internal/faults"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/wait"
+	"github.com/go-acme/lego/v4/tracing"
 	"github.com/miekg/dns"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -30,6 +34,20 @@ const (
 
 type ValidateFunc func(core *api.Core, domain string, chlng acme.Challenge) error
 
+// ValidateContextFunc is like [ValidateFunc] with a context.
+type ValidateContextFunc func(ctx context.Context, core *api.Core, domain string, chlng acme.Challenge) error
+
+// validateContext adapts a ValidateFunc to a ValidateContextFunc.
+func validateContext(validate ValidateFunc) ValidateContextFunc {
+	if validate == nil {
+		return nil
+	}
+
+	return func(_ context.Context, core *api.Core, domain string, chlng acme.Challenge) error {
+		return validate(core, domain, chlng)
+	}
+}
+
 type ChallengeOption func(*Challenge) error
 
 // CondOption Conditional challenge option.
@@ -44,28 +62,46 @@ func CondOption(condition bool, opt ChallengeOption) ChallengeOption {
 	return opt
 }
 
-// Challenge implements the dns-01 challenge.
+// Challenge implements the dns-01 and dns-account-01 challenges.
 type Challenge struct {
-	core       *api.Core
-	validate   ValidateFunc
-	provider   challenge.Provider
-	preCheck   preCheck
-	dnsTimeout time.Duration
+	chlgType challenge.Type
+	core     *api.Core
+	validate ValidateContextFunc
+	provider challenge.Provider
+	preCheck preCheck
+	resolver *resolver
 }
 
+// NewChallenge creates a solver for the dns-01 challenge.
 func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
+	return newChallenge(challenge.DNS01, core, validateContext(validate), provider, opts...)
+}
+
+// NewChallengeContext is like [NewChallenge] with a validation function using a context.
+func NewChallengeContext(core *api.Core, validate ValidateContextFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
+	return newChallenge(challenge.DNS01, core, validate, provider, opts...)
+}
+
+func newChallenge(chlgType challenge.Type, core *api.Core, validate ValidateContextFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
+	// The options modify the copy of the default resolver: the challenges don't share their settings.
+	r := getDefaultResolver().clone()
+
 	chlg := &Challenge{
-		core:       core,
-		validate:   validate,
-		provider:   provider,
-		preCheck:   newPreCheck(),
-		dnsTimeout: 10 * time.Second,
+		chlgType: chlgType,
+		core:     core,
+		validate: validate,
+		provider: provider,
+		preCheck: newPreCheck(r),
+		resolver: r,
 	}
 
+	// The log entries of the DNS queries are written with the logger of the client.
+	r.log = core.Log()
+
 	for _, opt := range opts {
 		err := opt(chlg)
 		if err != nil {
-			log.Infof("challenge option error: %v", err)
+			chlg.core.Log().Errorf("challenge option error: %v", err)
 		}
 	}
 
@@ -75,10 +111,15 @@ func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Prov
 // PreSolve just submits the txt record to the dns provider.
 // It does not validate record propagation, or do anything at all with the acme server.
 func (c *Challenge) PreSolve(authz acme.Authorization) error {
+	return c.PreSolveContext(context.Background(), authz)
+}
+
+// PreSolveContext is like [Challenge.PreSolve] with a context.
+func (c *Challenge) PreSolveContext(ctx context.Context, authz acme.Authorization) error {
 	domain := challenge.GetTargetedDomain(authz)
-	log.Infof("[%s] acme: Preparing to solve DNS-01", domain)
+	c.log(domain).Infof("[%s] acme: Preparing to solve %s", domain, c.name())
 
-	chlng, err := challenge.FindChallenge(challenge.DNS01, authz)
+	chlng, err := challenge.FindChallenge(c.chlgType, authz)
 	if err != nil {
 		return err
 	}
@@ -93,7 +134,17 @@ func (c *Challenge) PreSolve(authz acme.Authorization) error {
 		return err
 	}
 
-	err = c.provider.Present(authz.Identifier.Value, chlng.Token, keyAuth)
+	c.register(keyAuth)
+
+	ctx, span := c.core.Tracer().Start(ctx, tracing.SpanPresent, tracing.WithChallenge(domain, c.chlgType.String()))
+
+	err = faults.Present(domain)
+	if err == nil {
+		err = presentFunc(c.provider)(ctx, authz.Identifier.Value, chlng.Token, keyAuth)
+	}
+
+	tracing.End(span, err)
+
 	if err != nil {
 		return fmt.Errorf("[%s] acme: error presenting token: %w", domain, err)
 	}
@@ -102,10 +153,16 @@ func (c *Challenge) PreSolve(authz acme.Authorization) error {
 }
 
 func (c *Challenge) Solve(authz acme.Authorization) error {
+	return c.SolveContext(context.Background(), authz)
+}
+
+// SolveContext is like [Challenge.Solve] with a context.
+func (c *Challenge) SolveContext(ctx context.Context, authz acme.Authorization) error {
 	domain := challenge.GetTargetedDomain(authz)
-	log.Infof("[%s] acme: Trying to solve DNS-01", domain)
+	logger := c.log(domain)
+	logger.Infof("[%s] acme: Trying to solve %s", domain, c.name())
 
-	chlng, err := challenge.FindChallenge(challenge.DNS01, authz)
+	chlng, err := challenge.FindChallenge(c.chlgType, authz)
 	if err != nil {
 		return err
 	}
@@ -116,7 +173,7 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 		return err
 	}
 
-	info := GetChallengeInfo(authz.Identifier.Value, keyAuth)
+	info := c.getChallengeInfo(authz.Identifier.Value, keyAuth)
 
 	var timeout, interval time.Duration
 
@@ -127,32 +184,63 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 		timeout, interval = DefaultPropagationTimeout, DefaultPollingInterval
 	}
 
-	log.Infof("[%s] acme: Checking DNS record propagation. [nameservers=%s]", domain, strings.Join(recursiveNameservers, ","))
+	logger.Infof("[%s] acme: Checking DNS record propagation. [nameservers=%s]", domain, strings.Join(c.resolver.nameservers, ","))
 
-	time.Sleep(interval)
+	start := time.Now()
 
-	err = wait.For("propagation", timeout, interval, func() (bool, error) {
-		stop, errP := c.preCheck.call(domain, info.EffectiveFQDN, info.Value)
-		if !stop || errP != nil {
-			log.Infof("[%s] acme: Waiting for DNS record propagation.", domain)
-		}
-
-		return stop, errP
-	})
+	err = c.waitPropagation(ctx, domain, info, timeout, interval)
 	if err != nil {
 		return err
 	}
 
+	c.core.Metrics().ObservePropagation(providerName(c.provider), time.Since(start))
+
 	chlng.KeyAuthorization = keyAuth
 
-	return c.validate(c.core, domain, chlng)
+	return c.validate(ctx, c.core, domain, chlng)
+}
+
+// waitPropagation waits for the propagation of the TXT record.
+func (c *Challenge) waitPropagation(ctx context.Context, domain string, info ChallengeInfo, timeout, interval time.Duration) (err error) {
+	ctx, span := c.core.Tracer().Start(ctx, tracing.SpanPropagation, tracing.WithChallenge(domain, c.chlgType.String()),
+		trace.WithAttributes(tracing.AttrDNSProvider.String(providerName(c.provider))))
+	defer func() { tracing.End(span, err) }()
+
+	logger := c.log(domain)
+
+	err = wait.Sleep(ctx, interval)
+	if err != nil {
+		return err
+	}
+
+	check := c.preCheck.stable(func() (bool, error) {
+		return c.preCheck.call(domain, info.EffectiveFQDN, info.Value)
+	})
+
+	return wait.ForContext(log.NewContext(ctx, logger), "propagation", timeout, interval, func() (bool, error) {
+		stop, errP := check()
+		if !stop || errP != nil {
+			logger.Infof("[%s] acme: Waiting for DNS record propagation.", domain)
+		}
+
+		if !stop && errP != nil {
+			logger.Debugf("[%s] acme: propagation check: %v", domain, errP)
+		}
+
+		return stop, errP
+	})
 }
 
 // CleanUp cleans the challenge.
 func (c *Challenge) CleanUp(authz acme.Authorization) error {
-	log.Infof("[%s] acme: Cleaning DNS-01 challenge", challenge.GetTargetedDomain(authz))
+	return c.CleanUpContext(context.Background(), authz)
+}
 
-	chlng, err := challenge.FindChallenge(challenge.DNS01, authz)
+// CleanUpContext is like [Challenge.CleanUp] with a context.
+func (c *Challenge) CleanUpContext(ctx context.Context, authz acme.Authorization) error {
+	c.log(challenge.GetTargetedDomain(authz)).Infof("[%s] acme: Cleaning %s challenge", challenge.GetTargetedDomain(authz), c.name())
+
+	chlng, err := challenge.FindChallenge(c.chlgType, authz)
 	if err != nil {
 		return err
 	}
@@ -162,7 +250,40 @@ func (c *Challenge) CleanUp(authz acme.Authorization) error {
 		return err
 	}
 
-	return c.provider.CleanUp(authz.Identifier.Value, chlng.Token, keyAuth)
+	c.register(keyAuth)
+	defer c.unregister(keyAuth)
+
+	ctx, span := c.core.Tracer().Start(ctx, tracing.SpanCleanUp, tracing.WithChallenge(challenge.GetTargetedDomain(authz), c.chlgType.String()))
+
+	err = cleanUpFunc(c.provider)(ctx, authz.Identifier.Value, chlng.Token, keyAuth)
+
+	tracing.End(span, err)
+
+	return err
+}
+
+// providerName returns the name of a provider used in the metrics and the traces:
+// the name of the wrapped provider (see named), or the name of the package of the provider (ex: "cloudflare" for *cloudflare.DNSProvider).
+func providerName(provider challenge.Provider) string {
+	if p, ok := provider.(named); ok {
+		return p.Name()
+	}
+
+	name := strings.TrimPrefix(fmt.Sprintf("%T", provider), "*")
+
+	pkg, _, _ := strings.Cut(name, ".")
+
+	return pkg
+}
+
+// log returns the printer of the log entries related to a domain.
+func (c *Challenge) log(domain string) log.Printer {
+	return c.core.Log().With("domain", domain, "challenge", c.chlgType)
+}
+
+// name returns the name of the challenge type used in the logs (ex: DNS-01).
+func (c *Challenge) name() string {
+	return strings.ToUpper(c.chlgType.String())
 }
 
 func (c *Challenge) Sequential() (bool, time.Duration) {
@@ -177,6 +298,12 @@ type sequential interface {
 	Sequential() time.Duration
 }
 
+// named is implemented by the providers wrapping another provider (ex: the provider reloaded by the CLI),
+// to name the wrapped provider instead of the wrapper.
+type named interface {
+	Name() string
+}
+
 // GetRecord returns a DNS record which will fulfill the `dns-01` challenge.
 //
 // Deprecated: use GetChallengeInfo instead.
@@ -188,7 +315,8 @@ func GetRecord(domain, keyAuth string) (fqdn, value string) {
 
 // ChallengeInfo contains the information use to create the TXT record.
 type ChallengeInfo struct {
-	// FQDN is the full-qualified challenge domain (i.e. `_acme-challenge.[domain].`)
+	// FQDN is the full-qualified challenge domain
+	// (i.e. `_acme-challenge.[domain].`, or `_[account-label]._acme-challenge.[domain].` for dns-account-01).
 	FQDN string
 
 	// EffectiveFQDN contains the resulting FQDN after the CNAMEs resolutions.
@@ -199,7 +327,21 @@ type ChallengeInfo struct {
 }
 
 // GetChallengeInfo returns information used to create a DNS record which will fulfill the `dns-01` challenge.
+//
+// During a `dns-account-01` challenge, the key authorization is bound to the account,
+// and the information of the `dns-account-01` challenge is returned:
+// the DNS providers don't need to know the challenge type.
 func GetChallengeInfo(domain, keyAuth string) ChallengeInfo {
+	r := resolverFor(keyAuth)
+
+	if label, ok := accountLabels.Load(keyAuth); ok {
+		return getChallengeInfo(r, label.(string)+"._acme-challenge", domain, keyAuth)
+	}
+
+	return getChallengeInfo(r, "_acme-challenge", domain, keyAuth)
+}
+
+func getChallengeInfo(r *resolver, prefix, domain, keyAuth string) ChallengeInfo {
 	keyAuthShaBytes := sha256.Sum256([]byte(keyAuth))
 	// base64URL encoding without padding
 	value := base64.RawURLEncoding.EncodeToString(keyAuthShaBytes[:sha256.Size])
@@ -208,13 +350,13 @@ func GetChallengeInfo(domain, keyAuth string) ChallengeInfo {
 
 	return ChallengeInfo{
 		Value:         value,
-		FQDN:          getChallengeFQDN(domain, false),
-		EffectiveFQDN: getChallengeFQDN(domain, !ok),
+		FQDN:          getChallengeFQDN(r, prefix, domain, false),
+		EffectiveFQDN: getChallengeFQDN(r, prefix, domain, !ok),
 	}
 }
 
-func getChallengeFQDN(domain string, followCNAME bool) string {
-	fqdn := fmt.Sprintf("_acme-challenge.%s.", domain)
+func getChallengeFQDN(r *resolver, prefix, domain string, followCNAME bool) string {
+	fqdn := fmt.Sprintf("%s.%s.", prefix, domain)
 
 	if !followCNAME {
 		return fqdn
@@ -223,20 +365,20 @@ func getChallengeFQDN(domain string, followCNAME bool) string {
 	// recursion counter so it doesn't spin out of control
 	for range 50 {
 		// Keep following CNAMEs
-		r, err := dnsQuery(fqdn, dns.TypeCNAME, recursiveNameservers, true)
+		msg, err := r.query(fqdn, dns.TypeCNAME, r.nameservers, true)
 
-		if err != nil || r.Rcode != dns.RcodeSuccess {
+		if err != nil || msg.Rcode != dns.RcodeSuccess {
 			// No more CNAME records to follow, exit
 			break
 		}
 
 		// Check if the domain has CNAME then use that
-		cname := updateDomainWithCName(r, fqdn)
+		cname := updateDomainWithCName(msg, fqdn)
 		if cname == fqdn {
 			break
 		}
 
-		log.Infof("Found CNAME entry for %q: %q", fqdn, cname)
+		r.log.Infof("Found CNAME entry for %q: %q", fqdn, cname)
 
 		fqdn = cname
 	}
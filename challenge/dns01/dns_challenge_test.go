@@ -1,9 +1,12 @@
 package dns01
 
 import (
+	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"errors"
+	"log/slog"
 	"testing"
 	"time"
 
@@ -12,6 +15,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
+	"github.com/miekg/dns"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -339,3 +343,201 @@ func TestGetChallengeInfo_CNAME_disabled(t *testing.T) {
 
 	assert.Equal(t, expected, info)
 }
+
+func TestNewChallenge_resolvers(t *testing.T) {
+	addrA := dnsmock.NewServer().
+		Query("_acme-challenge.example.com. CNAME", dnsmock.CNAME("a.example.org.")).
+		Query("a.example.org. CNAME", dnsmock.Noop).
+		Build(t)
+
+	addrB := dnsmock.NewServer().
+		Query("_acme-challenge.example.com. CNAME", dnsmock.CNAME("b.example.org.")).
+		Query("b.example.org. CNAME", dnsmock.Noop).
+		Build(t)
+
+	// The options of the challenges also set the default resolver.
+	mockDefaultResolver(t, func(_ *resolver) {})
+
+	testCases := []struct {
+		desc     string
+		addr     string
+		keyAuth  string
+		expected string
+	}{
+		{
+			desc:     "resolver A",
+			addr:     addrA.String(),
+			keyAuth:  "keyAuthA",
+			expected: "a.example.org.",
+		},
+		{
+			desc:     "resolver B",
+			addr:     addrB.String(),
+			keyAuth:  "keyAuthB",
+			expected: "b.example.org.",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			chlg := NewChallenge(nil, nil, &providerMock{},
+				AddRecursiveNameservers([]string{test.addr}),
+				AddDNSTimeout(2*time.Second),
+			)
+
+			chlg.register(test.keyAuth)
+			t.Cleanup(func() { chlg.unregister(test.keyAuth) })
+
+			for range 10 {
+				// The DNS providers resolve the challenge record through the registered resolver of the challenge.
+				info := GetChallengeInfo("example.com", test.keyAuth)
+
+				assert.Equal(t, test.expected, info.EffectiveFQDN)
+			}
+		})
+	}
+}
+
+func TestNewChallenge_logger(t *testing.T) {
+	addr := dnsmock.NewServer().
+		Query("_acme-challenge.example.com. CNAME", dnsmock.CNAME("example.org.")).
+		Query("example.org. CNAME", dnsmock.Noop).
+		Build(t)
+
+	// The options of the challenges also set the default resolver.
+	mockDefaultResolver(t, func(_ *resolver) {})
+
+	buf := &bytes.Buffer{}
+
+	core := &api.Core{StructuredLogger: slog.New(slog.NewTextHandler(buf, nil))}
+
+	chlg := NewChallenge(core, nil, &providerMock{}, AddRecursiveNameservers([]string{addr.String()}))
+
+	info := chlg.getChallengeInfo("example.com", "123")
+
+	assert.Equal(t, "example.org.", info.EffectiveFQDN)
+
+	// The DNS queries of the challenge use the logger of the client.
+	assert.Contains(t, buf.String(), `msg="Found CNAME entry for \"_acme-challenge.example.com.\": \"example.org.\""`)
+}
+
+func Test_providerName(t *testing.T) {
+	assert.Equal(t, "dns01", providerName(&providerMock{}))
+
+	// The name of the wrapped provider, not the name of the package of the wrapper.
+	assert.Equal(t, "cloudflare", providerName(&namedProviderMock{Provider: &providerMock{}, name: "cloudflare"}))
+}
+
+type namedProviderMock struct {
+	challenge.Provider
+
+	name string
+}
+
+func (p *namedProviderMock) Name() string {
+	return p.name
+}
+
+// zoneProviderMock finds the zone of the challenge record like the DNS providers.
+type zoneProviderMock struct {
+	zone string
+}
+
+func (p *zoneProviderMock) Present(domain, _, keyAuth string) error {
+	zone, err := FindZoneByFqdn(GetChallengeInfo(domain, keyAuth).EffectiveFQDN)
+	if err != nil {
+		return err
+	}
+
+	p.zone = zone
+
+	return nil
+}
+
+func (p *zoneProviderMock) CleanUp(_, _, _ string) error {
+	return nil
+}
+
+func TestNewChallenge_providerFindZone(t *testing.T) {
+	// The default resolver can't find the zone.
+	useAsNameserver(t, dnsmock.NewServer().
+		Query("_acme-challenge.example.com. CNAME", dnsmock.Noop).
+		Query("_acme-challenge.example.com. SOA", dnsmock.Error(dns.RcodeServerFailure)).
+		Query("example.com. SOA", dnsmock.Error(dns.RcodeServerFailure)).
+		Build(t))
+
+	addr := dnsmock.NewServer().
+		Query("_acme-challenge.example.com. CNAME", dnsmock.Noop).
+		Query("_acme-challenge.example.com. SOA", dnsmock.Error(dns.RcodeNameError)).
+		Query("example.com. SOA", dnsmock.SOA("")).
+		Build(t)
+
+	server := tester.MockACMEServer().BuildHTTPS(t)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	provider := &zoneProviderMock{}
+
+	// The nameservers and the timeout of the options are used by the package-level functions called by the providers.
+	chlg := NewChallenge(core, nil, provider,
+		AddRecursiveNameservers([]string{addr.String()}),
+		AddDNSTimeout(2*time.Second),
+	)
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{Value: "example.com"},
+		Challenges: []acme.Challenge{{Type: challenge.DNS01.String()}},
+	}
+
+	err = chlg.PreSolveContext(t.Context(), authz)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com.", provider.zone)
+	assert.Equal(t, 2*time.Second, getDefaultResolver().timeout)
+}
+
+type contextKey struct{}
+
+func TestNewChallengeContext(t *testing.T) {
+	useAsNameserver(t, dnsmock.NewServer().
+		Query("_acme-challenge.example.com. CNAME", dnsmock.Noop).
+		Build(t))
+
+	server := tester.MockACMEServer().BuildHTTPS(t)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	var validated bool
+
+	validate := func(ctx context.Context, _ *api.Core, _ string, _ acme.Challenge) error {
+		// The context of SolveContext is given to the validation.
+		assert.Equal(t, "lego", ctx.Value(contextKey{}))
+
+		validated = true
+
+		return nil
+	}
+
+	chlg := NewChallengeContext(core, validate, &providerMock{},
+		WrapPreCheck(func(_, _, _ string, _ PreCheckFunc) (bool, error) { return true, nil }))
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{Value: "example.com"},
+		Challenges: []acme.Challenge{{Type: challenge.DNS01.String()}},
+	}
+
+	err = chlg.SolveContext(context.WithValue(t.Context(), contextKey{}, "lego"), authz)
+	require.NoError(t, err)
+
+	assert.True(t, validated)
+}
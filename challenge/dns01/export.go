@@ -0,0 +1,248 @@
+package dns01
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/log"
+)
+
+// Export formats.
+const (
+	// ExportZoneFile an RFC 1035 zone-file fragment.
+	ExportZoneFile = "zonefile"
+	// ExportNSUpdate an nsupdate script.
+	ExportNSUpdate = "nsupdate"
+)
+
+// ExportOptions the options of the export of the challenge records.
+type ExportOptions struct {
+	// Path the file where the records are written.
+	Path string
+
+	// Format the format of the file: ExportZoneFile (default) or ExportNSUpdate.
+	Format string
+
+	// Timeout the maximum time to wait for the records to be applied.
+	// If zero, the timeout of the wrapped provider (or DefaultPropagationTimeout) is used.
+	Timeout time.Duration
+}
+
+// WithExport wraps a provider to write the pending challenge records to a file,
+// so the DNS changes can be applied through an external pipeline.
+// The file is rewritten each time a record is added or removed.
+// If provider is nil, the records are only exported:
+// lego resumes when the propagation check finds the records.
+// The optional Sequential method of the wrapped provider is preserved.
+func WithExport(provider challenge.Provider, opts ExportOptions) (challenge.Provider, error) {
+	if opts.Path == "" {
+		return nil, errors.New("export: missing path")
+	}
+
+	switch opts.Format {
+	case "":
+		opts.Format = ExportZoneFile
+	case ExportZoneFile, ExportNSUpdate:
+	default:
+		return nil, fmt.Errorf("export: unsupported format %q", opts.Format)
+	}
+
+	e := &exportProvider{provider: provider, opts: opts}
+
+	if _, ok := provider.(sequential); ok {
+		return &exportSequentialProvider{exportProvider: e}, nil
+	}
+
+	return e, nil
+}
+
+type exportRecord struct {
+	fqdn  string
+	value string
+}
+
+type exportProvider struct {
+	provider challenge.Provider
+	opts     ExportOptions
+
+	mu      sync.Mutex
+	pending []exportRecord
+	removed []exportRecord
+}
+
+func (e *exportProvider) Present(domain, token, keyAuth string) error {
+	info := GetChallengeInfo(domain, keyAuth)
+
+	record := exportRecord{fqdn: info.EffectiveFQDN, value: info.Value}
+
+	e.mu.Lock()
+
+	e.removed = slices.DeleteFunc(e.removed, func(r exportRecord) bool { return r == record })
+
+	if !slices.Contains(e.pending, record) {
+		e.pending = append(e.pending, record)
+	}
+
+	err := e.write()
+
+	e.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("[%s] dns01: the TXT record %s has been exported to %s", domain, info.EffectiveFQDN, e.opts.Path)
+
+	if e.provider == nil {
+		return nil
+	}
+
+	return e.provider.Present(domain, token, keyAuth)
+}
+
+func (e *exportProvider) CleanUp(domain, token, keyAuth string) error {
+	info := GetChallengeInfo(domain, keyAuth)
+
+	record := exportRecord{fqdn: info.EffectiveFQDN, value: info.Value}
+
+	e.mu.Lock()
+
+	e.pending = slices.DeleteFunc(e.pending, func(r exportRecord) bool { return r == record })
+
+	if !slices.Contains(e.removed, record) {
+		e.removed = append(e.removed, record)
+	}
+
+	err := e.write()
+
+	e.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if e.provider == nil {
+		return nil
+	}
+
+	return e.provider.CleanUp(domain, token, keyAuth)
+}
+
+func (e *exportProvider) Timeout() (timeout, interval time.Duration) {
+	timeout, interval = DefaultPropagationTimeout, DefaultPollingInterval
+
+	if p, ok := e.provider.(challenge.ProviderTimeout); ok {
+		timeout, interval = p.Timeout()
+	}
+
+	if e.opts.Timeout > 0 {
+		timeout = e.opts.Timeout
+	}
+
+	return timeout, interval
+}
+
+// Name returns the name of the wrapped provider (used in the metrics and the traces),
+// or "export" when the records are only exported.
+func (e *exportProvider) Name() string {
+	if e.provider == nil {
+		return "export"
+	}
+
+	return providerName(e.provider)
+}
+
+// HasTXTRecord forwards TXTRecordFinder to the wrapped provider.
+func (e *exportProvider) HasTXTRecord(fqdn, value string) (bool, error) {
+	return hasTXTRecord(e.provider, fqdn, value)
+}
+
+// write writes the file atomically. The caller must hold the lock.
+func (e *exportProvider) write() error {
+	var content string
+
+	switch e.opts.Format {
+	case ExportNSUpdate:
+		content = formatNSUpdate(e.pending, e.removed)
+	default:
+		content = formatZoneFile(e.pending, e.removed)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(e.opts.Path), filepath.Base(e.opts.Path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.WriteString(content)
+	if err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("export: %w", err)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), e.opts.Path)
+	if err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+
+	return nil
+}
+
+// exportSequentialProvider is an exportProvider for the providers that require sequential solving.
+type exportSequentialProvider struct {
+	*exportProvider
+}
+
+func (e *exportSequentialProvider) Sequential() time.Duration {
+	return e.provider.(sequential).Sequential()
+}
+
+// formatZoneFile creates an RFC 1035 zone-file fragment with the pending records.
+// The removed records are listed as comments.
+func formatZoneFile(pending, removed []exportRecord) string {
+	b := new(strings.Builder)
+
+	b.WriteString("; lego: ACME challenge records\n")
+
+	for _, r := range pending {
+		_, _ = fmt.Fprintf(b, "%s %d IN TXT %q\n", r.fqdn, DefaultTTL, r.value)
+	}
+
+	for _, r := range removed {
+		_, _ = fmt.Fprintf(b, "; remove: %s %d IN TXT %q\n", r.fqdn, DefaultTTL, r.value)
+	}
+
+	return b.String()
+}
+
+// formatNSUpdate creates an nsupdate script that adds the pending records and deletes the removed records.
+func formatNSUpdate(pending, removed []exportRecord) string {
+	b := new(strings.Builder)
+
+	b.WriteString("; lego: ACME challenge records\n")
+
+	for _, r := range removed {
+		_, _ = fmt.Fprintf(b, "update delete %s TXT %q\n", r.fqdn, r.value)
+	}
+
+	for _, r := range pending {
+		_, _ = fmt.Fprintf(b, "update add %s %d TXT %q\n", r.fqdn, DefaultTTL, r.value)
+	}
+
+	b.WriteString("send\n")
+
+	return b.String()
+}
@@ -0,0 +1,127 @@
+package dns01
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithExport_zoneFile(t *testing.T) {
+	t.Setenv("LEGO_DISABLE_CNAME_SUPPORT", "true")
+
+	path := filepath.Join(t.TempDir(), "records.zone")
+
+	inner := &fakeProvider{}
+
+	provider, err := WithExport(inner, ExportOptions{Path: path})
+	require.NoError(t, err)
+
+	err = provider.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	err = provider.Present("example.org", "token", "keyAuth2")
+	require.NoError(t, err)
+
+	assertExport(t, path, `; lego: ACME challenge records
+_acme-challenge.example.com. 120 IN TXT "pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM"
+_acme-challenge.example.org. 120 IN TXT "gpH7LxdIY1oTt1znlqNN_Zt9Cx2dDJRm59GdZYY75G4"
+`)
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assertExport(t, path, `; lego: ACME challenge records
+_acme-challenge.example.org. 120 IN TXT "gpH7LxdIY1oTt1znlqNN_Zt9Cx2dDJRm59GdZYY75G4"
+; remove: _acme-challenge.example.com. 120 IN TXT "pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM"
+`)
+
+	assert.Equal(t, 2, inner.presentCalls)
+	assert.Equal(t, 1, inner.cleanUpCalls)
+}
+
+func TestWithExport_nsupdate(t *testing.T) {
+	t.Setenv("LEGO_DISABLE_CNAME_SUPPORT", "true")
+
+	path := filepath.Join(t.TempDir(), "records.nsupdate")
+
+	provider, err := WithExport(nil, ExportOptions{Path: path, Format: ExportNSUpdate})
+	require.NoError(t, err)
+
+	err = provider.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	err = provider.Present("example.org", "token", "keyAuth2")
+	require.NoError(t, err)
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assertExport(t, path, `; lego: ACME challenge records
+update delete _acme-challenge.example.com. TXT "pW9ZKG0xz_PCriK-nCMOjADy9eJcgGWIzkkj2fN4uZM"
+update add _acme-challenge.example.org. 120 TXT "gpH7LxdIY1oTt1znlqNN_Zt9Cx2dDJRm59GdZYY75G4"
+send
+`)
+}
+
+func TestWithExport_timeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.zone")
+
+	provider, err := WithExport(&fakeProviderTimeout{}, ExportOptions{Path: path})
+	require.NoError(t, err)
+
+	timeout, interval := provider.(*exportProvider).Timeout()
+	assert.Equal(t, 3*time.Minute, timeout)
+	assert.Equal(t, 7*time.Second, interval)
+
+	provider, err = WithExport(nil, ExportOptions{Path: path, Timeout: time.Hour})
+	require.NoError(t, err)
+
+	timeout, interval = provider.(*exportProvider).Timeout()
+	assert.Equal(t, time.Hour, timeout)
+	assert.Equal(t, DefaultPollingInterval, interval)
+}
+
+func TestWithExport_sequential(t *testing.T) {
+	provider, err := WithExport(&fakeSequentialProvider{}, ExportOptions{Path: filepath.Join(t.TempDir(), "records.zone")})
+	require.NoError(t, err)
+
+	p, ok := provider.(sequential)
+	require.True(t, ok)
+
+	assert.Equal(t, 42*time.Second, p.Sequential())
+}
+
+func TestWithExport_name(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.zone")
+
+	provider, err := WithExport(&namedProviderMock{Provider: &fakeProvider{}, name: "cloudflare"}, ExportOptions{Path: path})
+	require.NoError(t, err)
+
+	assert.Equal(t, "cloudflare", providerName(provider))
+
+	provider, err = WithExport(nil, ExportOptions{Path: path})
+	require.NoError(t, err)
+
+	assert.Equal(t, "export", providerName(provider))
+}
+
+func TestWithExport_errors(t *testing.T) {
+	_, err := WithExport(nil, ExportOptions{})
+	require.EqualError(t, err, "export: missing path")
+
+	_, err = WithExport(nil, ExportOptions{Path: "records", Format: "bind"})
+	require.EqualError(t, err, `export: unsupported format "bind"`)
+}
+
+func assertExport(t *testing.T, path, expected string) {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, string(content))
+}
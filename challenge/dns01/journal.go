@@ -0,0 +1,252 @@
+package dns01
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/log"
+)
+
+// Journal actions.
+const (
+	JournalPresent = "present"
+	JournalCleanUp = "cleanup"
+)
+
+// Journal outcomes.
+const (
+	JournalSuccess = "success"
+	JournalError   = "error"
+)
+
+// JournalEntry an entry of the journal of the DNS record changes (one JSON object per line).
+type JournalEntry struct {
+	StartedAt time.Time `json:"startedAt"`
+	EndedAt   time.Time `json:"endedAt"`
+
+	Action   string `json:"action"`
+	Provider string `json:"provider"`
+	Zone     string `json:"zone,omitempty"`
+	Domain   string `json:"domain"`
+	FQDN     string `json:"fqdn"`
+	Value    string `json:"value"`
+
+	Outcome string `json:"outcome"`
+	Error   string `json:"error,omitempty"`
+}
+
+// journalFindZone finds the zone of a record (replaced inside the tests).
+var journalFindZone = FindZoneByFqdn
+
+// WithJournal wraps a provider to append an entry to the journal file (JSON lines)
+// for each record created or deleted through Present and CleanUp.
+// The name is the name of the provider recorded inside the entries.
+// A failure to write the journal is logged: it doesn't fail the challenge.
+// The optional Timeout and Sequential methods of the wrapped provider are preserved.
+func WithJournal(provider challenge.Provider, name, path string) (challenge.Provider, error) {
+	if path == "" {
+		return nil, errors.New("journal: missing path")
+	}
+
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("journal: %w", err)
+	}
+
+	j := &journal{path: path, provider: name, findZone: journalFindZone, zones: make(map[string]string)}
+
+	return wrapProvider(provider, j.record(JournalPresent, presentFunc(provider)), j.record(JournalCleanUp, cleanUpFunc(provider))), nil
+}
+
+type journal struct {
+	path     string
+	provider string
+
+	findZone func(fqdn string) (string, error)
+
+	mu    sync.Mutex
+	zones map[string]string
+}
+
+func (j *journal) record(action string, fn providerFunc) providerFunc {
+	return func(ctx context.Context, domain, token, keyAuth string) error {
+		info := GetChallengeInfo(domain, keyAuth)
+
+		entry := JournalEntry{
+			StartedAt: time.Now().UTC(),
+			Action:    action,
+			Provider:  j.provider,
+			Domain:    domain,
+			FQDN:      info.EffectiveFQDN,
+			Value:     info.Value,
+			Outcome:   JournalSuccess,
+		}
+
+		err := fn(ctx, domain, token, keyAuth)
+
+		entry.EndedAt = time.Now().UTC()
+		entry.Zone = j.zone(info.EffectiveFQDN)
+
+		if err != nil {
+			entry.Outcome = JournalError
+			entry.Error = err.Error()
+		}
+
+		if errW := j.write(entry); errW != nil {
+			log.Warnf("[%s] dns01: %v", domain, errW)
+		}
+
+		return err
+	}
+}
+
+// zone returns the zone of the FQDN, or an empty string if the zone cannot be found.
+func (j *journal) zone(fqdn string) string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if zone, ok := j.zones[fqdn]; ok {
+		return zone
+	}
+
+	zone, err := j.findZone(fqdn)
+	if err != nil {
+		return ""
+	}
+
+	j.zones[fqdn] = zone
+
+	return zone
+}
+
+func (j *journal) write(entry JournalEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	file, err := os.OpenFile(j.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	return nil
+}
+
+// ReadJournal reads the entries of a journal file.
+// A missing file is an empty journal.
+func ReadJournal(path string) ([]JournalEntry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("journal: %w", err)
+	}
+
+	defer func() { _ = file.Close() }()
+
+	return parseJournal(file, path)
+}
+
+func parseJournal(r io.Reader, name string) ([]JournalEntry, error) {
+	var entries []JournalEntry
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var n int
+
+	for scanner.Scan() {
+		n++
+
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry JournalEntry
+
+		err := json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("journal: %s:%d: %w", name, n, err)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("journal: %s: %w", name, err)
+	}
+
+	return entries, nil
+}
+
+// PendingJournalEntries returns the entries of the records created successfully
+// and never deleted successfully afterward (the cleanup leaks), in the order of creation.
+func PendingJournalEntries(entries []JournalEntry) []JournalEntry {
+	type key struct {
+		provider string
+		fqdn     string
+		value    string
+	}
+
+	var order []key
+
+	pending := make(map[key]JournalEntry)
+
+	for _, entry := range entries {
+		if entry.Outcome != JournalSuccess {
+			continue
+		}
+
+		k := key{provider: entry.Provider, fqdn: entry.FQDN, value: entry.Value}
+
+		switch entry.Action {
+		case JournalPresent:
+			if _, ok := pending[k]; !ok {
+				order = append(order, k)
+			}
+
+			pending[k] = entry
+
+		case JournalCleanUp:
+			delete(pending, k)
+		}
+	}
+
+	var result []JournalEntry
+
+	for _, k := range order {
+		if entry, ok := pending[k]; ok {
+			result = append(result, entry)
+			delete(pending, k)
+		}
+	}
+
+	return result
+}
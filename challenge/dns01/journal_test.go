@@ -0,0 +1,105 @@
+package dns01
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithJournal(t *testing.T) {
+	original := journalFindZone
+	t.Cleanup(func() { journalFindZone = original })
+
+	journalFindZone = func(fqdn string) (string, error) { return "example.com.", nil }
+
+	path := filepath.Join(t.TempDir(), "dns", "journal.jsonl")
+
+	fake := &fakeProvider{failures: 1}
+
+	provider, err := WithJournal(fake, "fake", path)
+	require.NoError(t, err)
+
+	require.Error(t, provider.Present("example.com", "", "123d=="))
+	require.NoError(t, provider.Present("example.com", "", "123d=="))
+	require.Error(t, provider.CleanUp("example.com", "", "123d=="))
+
+	entries, err := ReadJournal(path)
+	require.NoError(t, err)
+
+	require.Len(t, entries, 3)
+
+	info := GetChallengeInfo("example.com", "123d==")
+
+	for _, entry := range entries {
+		assert.Equal(t, "fake", entry.Provider)
+		assert.Equal(t, "example.com.", entry.Zone)
+		assert.Equal(t, "example.com", entry.Domain)
+		assert.Equal(t, info.EffectiveFQDN, entry.FQDN)
+		assert.Equal(t, info.Value, entry.Value)
+		assert.False(t, entry.StartedAt.IsZero())
+		assert.False(t, entry.EndedAt.Before(entry.StartedAt))
+	}
+
+	assert.Equal(t, JournalPresent, entries[0].Action)
+	assert.Equal(t, JournalError, entries[0].Outcome)
+	assert.Equal(t, "present error", entries[0].Error)
+
+	assert.Equal(t, JournalPresent, entries[1].Action)
+	assert.Equal(t, JournalSuccess, entries[1].Outcome)
+	assert.Empty(t, entries[1].Error)
+
+	assert.Equal(t, JournalCleanUp, entries[2].Action)
+	assert.Equal(t, JournalError, entries[2].Outcome)
+
+	assert.Equal(t, []JournalEntry{entries[1]}, PendingJournalEntries(entries))
+}
+
+func TestWithJournal_optionalMethods(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+
+	provider, err := WithJournal(&fakeSequentialProvider{}, "fake", path)
+	require.NoError(t, err)
+
+	seq, ok := provider.(sequential)
+	require.True(t, ok)
+	assert.Equal(t, 42*time.Second, seq.Sequential())
+
+	provider, err = WithJournal(&fakeProviderTimeout{}, "fake", path)
+	require.NoError(t, err)
+
+	timeout, interval := provider.(challenge.ProviderTimeout).Timeout()
+	assert.Equal(t, 3*time.Minute, timeout)
+	assert.Equal(t, 7*time.Second, interval)
+}
+
+func TestReadJournal_missing(t *testing.T) {
+	entries, err := ReadJournal(filepath.Join(t.TempDir(), "journal.jsonl"))
+	require.NoError(t, err)
+
+	assert.Empty(t, entries)
+}
+
+func Test_parseJournal_invalid(t *testing.T) {
+	_, err := parseJournal(strings.NewReader("{}\n\nnot json\n"), "journal.jsonl")
+	require.ErrorContains(t, err, "journal: journal.jsonl:3: ")
+}
+
+func TestPendingJournalEntries(t *testing.T) {
+	entries := []JournalEntry{
+		{Action: JournalPresent, Provider: "a", FQDN: "_acme-challenge.a.com.", Value: "1", Outcome: JournalSuccess},
+		{Action: JournalPresent, Provider: "a", FQDN: "_acme-challenge.b.com.", Value: "2", Outcome: JournalSuccess},
+		{Action: JournalPresent, Provider: "a", FQDN: "_acme-challenge.c.com.", Value: "3", Outcome: JournalError},
+		{Action: JournalCleanUp, Provider: "a", FQDN: "_acme-challenge.a.com.", Value: "1", Outcome: JournalSuccess},
+		{Action: JournalCleanUp, Provider: "a", FQDN: "_acme-challenge.b.com.", Value: "2", Outcome: JournalError},
+		{Action: JournalPresent, Provider: "b", FQDN: "_acme-challenge.a.com.", Value: "1", Outcome: JournalSuccess},
+	}
+
+	expected := []JournalEntry{entries[1], entries[5]}
+
+	assert.Equal(t, expected, PendingJournalEntries(entries))
+}
@@ -39,14 +39,10 @@ func mockResolver(t *testing.T, addr net.Addr) {
 	_, port, err := net.SplitHostPort(addr.String())
 	require.NoError(t, err)
 
-	originalDefaultNameserverPort := defaultNameserverPort
-
-	t.Cleanup(func() {
-		defaultNameserverPort = originalDefaultNameserverPort
+	mockDefaultResolver(t, func(r *resolver) {
+		r.authoritativePort = port
 	})
 
-	defaultNameserverPort = port
-
 	originalResolver := net.DefaultResolver
 
 	t.Cleanup(func() {
@@ -71,11 +67,23 @@ func useAsNameserver(t *testing.T, addr net.Addr) {
 		ClearFqdnCache()
 	})
 
-	originalRecursiveNameservers := recursiveNameservers
+	mockDefaultResolver(t, func(r *resolver) {
+		r.nameservers = ParseNameservers([]string{addr.String()})
+	})
+}
+
+// mockDefaultResolver replaces the default resolver by a modified copy during the test execution.
+func mockDefaultResolver(t *testing.T, update func(r *resolver)) {
+	t.Helper()
+
+	original := getDefaultResolver()
 
 	t.Cleanup(func() {
-		recursiveNameservers = originalRecursiveNameservers
+		defaultResolver.Store(original)
 	})
 
-	recursiveNameservers = ParseNameservers([]string{addr.String()})
+	r := original.clone()
+	update(r)
+
+	defaultResolver.Store(r)
 }
@@ -0,0 +1,170 @@
+package dns01
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/log"
+)
+
+// NewMultiProvider creates a provider that uses the primary provider,
+// and the fallback providers (in order) when Present fails (API outage, rate limit, etc.).
+// CleanUp is called on the provider that has created the record,
+// and a provider is cleaned up when its Present fails (the record may have been partially created).
+// The context of PresentContext and CleanUpContext is passed to the providers that implement challenge.ProviderContext.
+//
+// The timeout and the polling interval are the largest values of the providers.
+// The challenges are solved sequentially if one of the providers requires it.
+func NewMultiProvider(primary challenge.Provider, fallbacks ...challenge.Provider) challenge.Provider {
+	m := &multiProvider{
+		providers: append([]challenge.Provider{primary}, fallbacks...),
+		used:      make(map[string]challenge.Provider),
+	}
+
+	if _, ok := m.sequential(); ok {
+		return &multiSequentialProvider{multiProvider: m}
+	}
+
+	return m
+}
+
+type multiProvider struct {
+	providers []challenge.Provider
+
+	mu   sync.Mutex
+	used map[string]challenge.Provider
+}
+
+func (m *multiProvider) Present(domain, token, keyAuth string) error {
+	return m.PresentContext(context.Background(), domain, token, keyAuth)
+}
+
+func (m *multiProvider) PresentContext(ctx context.Context, domain, token, keyAuth string) error {
+	var errs []error
+
+	for i, provider := range m.providers {
+		name := providerName(provider)
+
+		err := presentFunc(provider)(ctx, domain, token, keyAuth)
+		if err == nil {
+			m.mu.Lock()
+			m.used[multiProviderKey(domain, token, keyAuth)] = provider
+			m.mu.Unlock()
+
+			return nil
+		}
+
+		errs = append(errs, fmt.Errorf("provider %s: %w", name, err))
+
+		// The record may have been partially created.
+		errC := cleanUpFunc(provider)(context.WithoutCancel(ctx), domain, token, keyAuth)
+		if errC != nil {
+			log.Warnf("[%s] dns01: present: provider %s: cleanup after failure: %v", domain, name, errC)
+		}
+
+		if ctx.Err() != nil {
+			return fmt.Errorf("present interrupted: %w", errors.Join(errs...))
+		}
+
+		if i < len(m.providers)-1 {
+			log.Warnf("[%s] dns01: present: provider %s failed, trying the next provider (%s): %v",
+				domain, name, providerName(m.providers[i+1]), err)
+		}
+	}
+
+	return fmt.Errorf("all the providers failed: %w", errors.Join(errs...))
+}
+
+func (m *multiProvider) CleanUp(domain, token, keyAuth string) error {
+	return m.CleanUpContext(context.Background(), domain, token, keyAuth)
+}
+
+func (m *multiProvider) CleanUpContext(ctx context.Context, domain, token, keyAuth string) error {
+	key := multiProviderKey(domain, token, keyAuth)
+
+	m.mu.Lock()
+	provider, ok := m.used[key]
+	delete(m.used, key)
+	m.mu.Unlock()
+
+	if !ok {
+		// The record has not been created by this provider instance (ex: Present has failed).
+		provider = m.providers[0]
+	}
+
+	return cleanUpFunc(provider)(ctx, domain, token, keyAuth)
+}
+
+func (m *multiProvider) Timeout() (timeout, interval time.Duration) {
+	for _, provider := range m.providers {
+		t, i := DefaultPropagationTimeout, DefaultPollingInterval
+
+		if p, ok := provider.(challenge.ProviderTimeout); ok {
+			t, i = p.Timeout()
+		}
+
+		timeout = max(timeout, t)
+		interval = max(interval, i)
+	}
+
+	return timeout, interval
+}
+
+// Name returns the name of the primary provider (used in the metrics and the traces).
+func (m *multiProvider) Name() string {
+	return providerName(m.providers[0])
+}
+
+// HasTXTRecord forwards TXTRecordFinder to the providers: the record is found if one of the providers has it.
+func (m *multiProvider) HasTXTRecord(fqdn, value string) (bool, error) {
+	var errs []error
+
+	for _, provider := range m.providers {
+		found, err := hasTXTRecord(provider, fqdn, value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", providerName(provider), err))
+			continue
+		}
+
+		if found {
+			return true, nil
+		}
+	}
+
+	return false, errors.Join(errs...)
+}
+
+func (m *multiProvider) sequential() (time.Duration, bool) {
+	var (
+		interval time.Duration
+		found    bool
+	)
+
+	for _, provider := range m.providers {
+		if p, ok := provider.(sequential); ok {
+			interval = max(interval, p.Sequential())
+			found = true
+		}
+	}
+
+	return interval, found
+}
+
+// multiSequentialProvider is a multiProvider for the providers that require sequential solving.
+type multiSequentialProvider struct {
+	*multiProvider
+}
+
+func (m *multiSequentialProvider) Sequential() time.Duration {
+	interval, _ := m.sequential()
+
+	return interval
+}
+
+func multiProviderKey(domain, token, keyAuth string) string {
+	return domain + "\x00" + token + "\x00" + keyAuth
+}
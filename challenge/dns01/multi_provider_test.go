@@ -0,0 +1,129 @@
+package dns01
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMultiProvider(t *testing.T) {
+	primary := &fakeProvider{}
+	fallback := &fakeProvider{}
+
+	provider := NewMultiProvider(primary, fallback)
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, primary.presentCalls)
+	assert.Equal(t, 1, primary.cleanUpCalls)
+	assert.Equal(t, 0, fallback.presentCalls)
+	assert.Equal(t, 0, fallback.cleanUpCalls)
+}
+
+func TestNewMultiProvider_fallback(t *testing.T) {
+	primary := &fakeProvider{failures: 1}
+	fallback := &fakeProvider{}
+
+	provider := NewMultiProvider(primary, fallback)
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, primary.presentCalls)
+	// The primary is cleaned up after the failure of Present.
+	assert.Equal(t, 1, primary.cleanUpCalls)
+	assert.Equal(t, 1, fallback.presentCalls)
+	assert.Equal(t, 1, fallback.cleanUpCalls)
+}
+
+func TestNewMultiProvider_allFailed(t *testing.T) {
+	primary := &fakeProvider{failures: 1}
+	fallback := &fakeProvider{failures: 1}
+
+	provider := NewMultiProvider(
+		&namedProviderMock{Provider: primary, name: "cloudflare"},
+		&namedProviderMock{Provider: fallback, name: "route53"},
+	)
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "all the providers failed: provider cloudflare: present error\nprovider route53: present error")
+
+	assert.Equal(t, 1, primary.presentCalls)
+	assert.Equal(t, 1, primary.cleanUpCalls)
+	assert.Equal(t, 1, fallback.presentCalls)
+	assert.Equal(t, 1, fallback.cleanUpCalls)
+}
+
+func TestNewMultiProvider_context(t *testing.T) {
+	primary := &fakeContextProvider{interrupted: make(chan struct{})}
+	fallback := &fakeProvider{}
+
+	provider := NewMultiProvider(primary, fallback)
+
+	p, ok := provider.(challenge.ProviderContext)
+	require.True(t, ok)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	err := p.PresentContext(ctx, "example.com", "token", "keyAuth")
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The cancellation reaches the primary, and the fallback is not used.
+	<-primary.interrupted
+
+	assert.Equal(t, 0, fallback.presentCalls)
+
+	// The cleanup of the primary after the failure is not canceled.
+	assert.Equal(t, 1, primary.cleanUpCalls)
+
+	err = p.CleanUpContext(ctx, "example.com", "token", "keyAuth")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestNewMultiProvider_Timeout(t *testing.T) {
+	provider := NewMultiProvider(&fakeProvider{}, &fakeProviderTimeout{})
+
+	p, ok := provider.(challenge.ProviderTimeout)
+	require.True(t, ok)
+
+	timeout, interval := p.Timeout()
+
+	assert.Equal(t, 3*time.Minute, timeout)
+	assert.Equal(t, 7*time.Second, interval)
+}
+
+func TestNewMultiProvider_Sequential(t *testing.T) {
+	provider := NewMultiProvider(&fakeProvider{})
+
+	_, ok := provider.(sequential)
+	assert.False(t, ok)
+
+	provider = NewMultiProvider(&fakeProvider{}, &fakeSequentialProvider{})
+
+	p, ok := provider.(sequential)
+	require.True(t, ok)
+
+	assert.Equal(t, 42*time.Second, p.Sequential())
+}
+
+func TestNewMultiProvider_name(t *testing.T) {
+	provider := NewMultiProvider(&namedProviderMock{Provider: &fakeProvider{}, name: "cloudflare"}, &fakeProvider{})
+
+	assert.Equal(t, "cloudflare", providerName(provider))
+
+	provider = NewMultiProvider(&namedProviderMock{Provider: &fakeProvider{}, name: "cloudflare"}, &fakeSequentialProvider{})
+
+	assert.Equal(t, "cloudflare", providerName(provider))
+}
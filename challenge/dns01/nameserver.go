@@ -9,8 +9,11 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/dialer"
 	"github.com/miekg/dns"
 )
 
@@ -18,13 +21,96 @@ const defaultResolvConf = "/etc/resolv.conf"
 
 var fqdnSoaCache = &sync.Map{}
 
+// defaultAuthoritativePort the port of the authoritative nameservers.
+const defaultAuthoritativePort = "53"
+
+// defaultNameservers are used when the system nameservers are not available (Google Public DNS).
+// The IP addresses don't require a working resolver, and are grouped by address family (IPv4, then IPv6):
+// they are sorted by the address family preference of the queries (see dialer.SortAddresses).
 var defaultNameservers = []string{
-	"google-public-dns-a.google.com:53",
-	"google-public-dns-b.google.com:53",
+	"8.8.8.8:53",
+	"8.8.4.4:53",
+	"[2001:4860:4860::8888]:53",
+	"[2001:4860:4860::8844]:53",
+}
+
+// defaultResolver the resolver of the package-level functions (ex: FindZoneByFqdn, used by the DNS providers).
+// The resolvers of the challenges are copies of the default resolver, modified by the options of each challenge.
+var defaultResolver atomic.Pointer[resolver]
+
+// getDefaultResolver returns the default resolver: the system nameservers, or the nameservers of SetDefaultNameservers.
+func getDefaultResolver() *resolver {
+	if r := defaultResolver.Load(); r != nil {
+		return r
+	}
+
+	defaultResolver.CompareAndSwap(nil, &resolver{
+		nameservers:       getNameservers(defaultResolvConf, defaultNameservers),
+		timeout:           defaultDNSTimeout,
+		family:            dialer.Auto,
+		authoritativePort: defaultAuthoritativePort,
+	})
+
+	return defaultResolver.Load()
+}
+
+// resolver the settings of the DNS queries.
+// A resolver is never modified after its first use: the clients don't share their settings.
+type resolver struct {
+	// nameservers the recursive nameservers, used to find the zones and to pre-check DNS propagation.
+	nameservers []string
+
+	// timeout the timeout of a DNS query.
+	timeout time.Duration
+
+	// retries the number of additional attempts of a DNS query on the same nameserver when the query fails.
+	retries int
+
+	// tcpFallback enables the fallback to TCP when a UDP query fails (e.g. timeout).
+	// The fallback to TCP on truncated responses is always enabled.
+	tcpFallback bool
+
+	// family the address family preference of the DNS queries.
+	family dialer.Family
+
+	// authoritativePort the port of the authoritative nameservers.
+	authoritativePort string
+
+	// log the printer of the log entries (the logger of the client of the challenge).
+	log log.Printer
+}
+
+func (r *resolver) clone() *resolver {
+	c := *r
+	c.nameservers = slices.Clone(r.nameservers)
+
+	return &c
+}
+
+// SetDefaultNameservers sets the recursive nameservers of the package-level functions (ex: FindZoneByFqdn),
+// used by the DNS providers to find the zones.
+// It's a process-wide setting: the challenges use their own nameservers (AddRecursiveNameservers),
+// the option AddRecursiveNameservers also sets the default nameservers.
+// An empty list restores the system nameservers.
+func SetDefaultNameservers(nameservers []string) {
+	if len(nameservers) == 0 {
+		defaultResolver.Store(nil)
+		return
+	}
+
+	updateDefaultResolver(func(r *resolver) {
+		r.nameservers = ParseNameservers(nameservers)
+	})
 }
 
-// recursiveNameservers are used to pre-check DNS propagation.
-var recursiveNameservers = getNameservers(defaultResolvConf, defaultNameservers)
+// updateDefaultResolver replaces the default resolver by a modified copy.
+// The resolvers of the existing challenges are not modified.
+func updateDefaultResolver(update func(r *resolver)) {
+	r := getDefaultResolver().clone()
+	update(r)
+
+	defaultResolver.Store(r)
+}
 
 // soaCacheEntry holds a cached SOA record (only selected fields).
 type soaCacheEntry struct {
@@ -55,16 +141,82 @@ func ClearFqdnCache() {
 	})
 }
 
+// AddDNSTimeout sets the timeout of the DNS queries of the challenge,
+// and of the package-level functions (ex: FindZoneByFqdn) used by the DNS providers to find the zones.
 func AddDNSTimeout(timeout time.Duration) ChallengeOption {
-	return func(_ *Challenge) error {
-		dnsTimeout = timeout
+	return func(chlg *Challenge) error {
+		chlg.resolver.timeout = timeout
+
+		updateDefaultResolver(func(r *resolver) {
+			r.timeout = timeout
+		})
+
 		return nil
 	}
 }
 
+// AddDNSQueryRetries sets the number of additional attempts of a DNS query on the same nameserver when the query fails.
+func AddDNSQueryRetries(retries int) ChallengeOption {
+	return func(chlg *Challenge) error {
+		if retries < 0 {
+			return fmt.Errorf("invalid DNS query retries: %d", retries)
+		}
+
+		chlg.resolver.retries = retries
+
+		return nil
+	}
+}
+
+// DNSTCPFallback enables the fallback to TCP when a UDP query fails (e.g. timeout).
+// The fallback to TCP on truncated responses is always enabled.
+func DNSTCPFallback(enabled bool) ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.resolver.tcpFallback = enabled
+		return nil
+	}
+}
+
+// DNSAddressFamily sets the address family preference of the DNS queries.
+// With a preference, the nameservers of the preferred address family are queried first.
+func DNSAddressFamily(family dialer.Family) ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.resolver.family = family
+		return nil
+	}
+}
+
+// AddRecursiveNameservers sets the recursive nameservers of the challenge:
+// the CNAME resolution, the zone lookups, and the propagation checks.
+// The nameservers are also the default nameservers (see SetDefaultNameservers):
+// the DNS providers find the zones with the package-level functions (ex: FindZoneByFqdn).
 func AddRecursiveNameservers(nameservers []string) ChallengeOption {
-	return func(_ *Challenge) error {
-		recursiveNameservers = ParseNameservers(nameservers)
+	return func(chlg *Challenge) error {
+		err := CheckNameservers(nameservers)
+		if err != nil {
+			return err
+		}
+
+		chlg.resolver.nameservers = ParseNameservers(nameservers)
+
+		updateDefaultResolver(func(r *resolver) {
+			r.nameservers = ParseNameservers(nameservers)
+		})
+
+		return nil
+	}
+}
+
+// AuthoritativeNameserverPort sets the port used to query the authoritative nameservers (propagation and cleanup checks).
+// The default port is 53.
+func AuthoritativeNameserverPort(port int) ChallengeOption {
+	return func(chlg *Challenge) error {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("invalid authoritative nameserver port: %d", port)
+		}
+
+		chlg.resolver.authoritativePort = strconv.Itoa(port)
+
 		return nil
 	}
 }
@@ -79,36 +231,73 @@ func getNameservers(path string, defaults []string) []string {
 	return ParseNameservers(config.Servers)
 }
 
+// ParseNameservers normalizes the nameservers to the host:port syntax:
+// the port 53 is added if missing, and the IPv6 addresses are enclosed in brackets (ex: "[2001:db8::1]:53").
+// Supported syntaxes: host, host:port, IPv4, IPv4:port, IPv6, [IPv6], [IPv6]:port.
+// The entries without host (ex: ":8053") are ignored (see CheckNameservers).
 func ParseNameservers(servers []string) []string {
 	var resolvers []string
 
 	for _, resolver := range servers {
-		// ensure all servers have a port number
-		if _, _, err := net.SplitHostPort(resolver); err != nil {
-			resolvers = append(resolvers, net.JoinHostPort(resolver, "53"))
-		} else {
-			resolvers = append(resolvers, resolver)
+		host, address := splitNameserver(resolver)
+		if host == "" {
+			continue
 		}
+
+		resolvers = append(resolvers, address)
 	}
 
 	return resolvers
 }
 
+// CheckNameservers checks that each nameserver has a host: ":8053" is not the local nameserver.
+// The empty entries are ignored.
+func CheckNameservers(servers []string) error {
+	for _, resolver := range servers {
+		if strings.TrimSpace(resolver) == "" {
+			continue
+		}
+
+		host, _ := splitNameserver(resolver)
+		if host == "" {
+			return fmt.Errorf("invalid nameserver %q: missing host", resolver)
+		}
+	}
+
+	return nil
+}
+
+// splitNameserver returns the host of the nameserver, and its address with a port (53 if missing).
+func splitNameserver(resolver string) (string, string) {
+	resolver = strings.TrimSpace(resolver)
+
+	// ensure all servers have a port number
+	host, _, err := net.SplitHostPort(resolver)
+	if err != nil {
+		// The brackets of an IPv6 address without port (ex: "[2001:db8::1]") are added by JoinHostPort.
+		host = strings.Trim(resolver, "[]")
+
+		return host, net.JoinHostPort(host, "53")
+	}
+
+	return host, resolver
+}
+
 // lookupNameservers returns the authoritative nameservers for the given fqdn.
-func lookupNameservers(fqdn string) ([]string, error) {
+func (r *resolver) lookupNameservers(fqdn string) ([]string, error) {
 	var authoritativeNss []string
 
-	zone, err := FindZoneByFqdn(fqdn)
+	zone, err := r.findZoneByFqdn(fqdn, r.nameservers)
 	if err != nil {
 		return nil, fmt.Errorf("could not find zone: %w", err)
 	}
 
-	r, err := dnsQuery(zone, dns.TypeNS, recursiveNameservers, true)
+	msg, err := r.query(zone, dns.TypeNS, r.nameservers, true)
 	if err != nil {
 		return nil, fmt.Errorf("NS call failed: %w", err)
 	}
 
-	for _, rr := range r.Answer {
+	for _, rr := range msg.Answer {
 		if ns, ok := rr.(*dns.NS); ok {
 			authoritativeNss = append(authoritativeNss, strings.ToLower(ns.Ns))
 		}
@@ -124,13 +313,13 @@ func lookupNameservers(fqdn string) ([]string, error) {
 // FindPrimaryNsByFqdn determines the primary nameserver of the zone apex for the given fqdn
 // by recursing up the domain labels until the nameserver returns a SOA record in the answer section.
 func FindPrimaryNsByFqdn(fqdn string) (string, error) {
-	return FindPrimaryNsByFqdnCustom(fqdn, recursiveNameservers)
+	return FindPrimaryNsByFqdnCustom(fqdn, getDefaultResolver().nameservers)
 }
 
 // FindPrimaryNsByFqdnCustom determines the primary nameserver of the zone apex for the given fqdn
 // by recursing up the domain labels until the nameserver returns a SOA record in the answer section.
 func FindPrimaryNsByFqdnCustom(fqdn string, nameservers []string) (string, error) {
-	soa, err := lookupSoaByFqdn(fqdn, nameservers)
+	soa, err := getDefaultResolver().lookupSoaByFqdn(fqdn, nameservers)
 	if err != nil {
 		return "", fmt.Errorf("[fqdn=%s] %w", fqdn, err)
 	}
@@ -141,13 +330,17 @@ func FindPrimaryNsByFqdnCustom(fqdn string, nameservers []string) (string, error
 // FindZoneByFqdn determines the zone apex for the given fqdn
 // by recursing up the domain labels until the nameserver returns a SOA record in the answer section.
 func FindZoneByFqdn(fqdn string) (string, error) {
-	return FindZoneByFqdnCustom(fqdn, recursiveNameservers)
+	return FindZoneByFqdnCustom(fqdn, getDefaultResolver().nameservers)
 }
 
 // FindZoneByFqdnCustom determines the zone apex for the given fqdn
 // by recursing up the domain labels until the nameserver returns a SOA record in the answer section.
 func FindZoneByFqdnCustom(fqdn string, nameservers []string) (string, error) {
-	soa, err := lookupSoaByFqdn(fqdn, nameservers)
+	return getDefaultResolver().findZoneByFqdn(fqdn, nameservers)
+}
+
+func (r *resolver) findZoneByFqdn(fqdn string, nameservers []string) (string, error) {
+	soa, err := r.lookupSoaByFqdn(fqdn, nameservers)
 	if err != nil {
 		return "", fmt.Errorf("[fqdn=%s] %w", fqdn, err)
 	}
@@ -155,9 +348,12 @@ func FindZoneByFqdnCustom(fqdn string, nameservers []string) (string, error) {
 	return soa.zone, nil
 }
 
-func lookupSoaByFqdn(fqdn string, nameservers []string) (*soaCacheEntry, error) {
+func (r *resolver) lookupSoaByFqdn(fqdn string, nameservers []string) (*soaCacheEntry, error) {
+	// The zones are cached by nameservers: the clients can use different nameservers.
+	key := strings.Join(nameservers, ",") + "|" + fqdn
+
 	// Do we have it cached and is it still fresh?
-	entAny, ok := fqdnSoaCache.Load(fqdn)
+	entAny, ok := fqdnSoaCache.Load(key)
 	if ok && entAny != nil {
 		ent, ok1 := entAny.(*soaCacheEntry)
 		if ok1 && !ent.isExpired() {
@@ -165,46 +361,46 @@ func lookupSoaByFqdn(fqdn string, nameservers []string) (*soaCacheEntry, error)
 		}
 	}
 
-	ent, err := fetchSoaByFqdn(fqdn, nameservers)
+	ent, err := r.fetchSoaByFqdn(fqdn, nameservers)
 	if err != nil {
 		return nil, err
 	}
 
-	fqdnSoaCache.Store(fqdn, ent)
+	fqdnSoaCache.Store(key, ent)
 
 	return ent, nil
 }
 
-func fetchSoaByFqdn(fqdn string, nameservers []string) (*soaCacheEntry, error) {
+func (r *resolver) fetchSoaByFqdn(fqdn string, nameservers []string) (*soaCacheEntry, error) {
 	var (
 		err error
-		r   *dns.Msg
+		msg *dns.Msg
 	)
 
 	for domain := range DomainsSeq(fqdn) {
-		r, err = dnsQuery(domain, dns.TypeSOA, nameservers, true)
+		msg, err = r.query(domain, dns.TypeSOA, nameservers, true)
 		if err != nil {
 			continue
 		}
 
-		if r == nil {
+		if msg == nil {
 			continue
 		}
 
-		switch r.Rcode {
+		switch msg.Rcode {
 		case dns.RcodeSuccess:
 			// Check if we got a SOA RR in the answer section
-			if len(r.Answer) == 0 {
+			if len(msg.Answer) == 0 {
 				continue
 			}
 
 			// CNAME records cannot/should not exist at the root of a zone.
 			// So we skip a domain when a CNAME is found.
-			if dnsMsgContainsCNAME(r) {
+			if dnsMsgContainsCNAME(msg) {
 				continue
 			}
 
-			for _, ans := range r.Answer {
+			for _, ans := range msg.Answer {
 				if soa, ok := ans.(*dns.SOA); ok {
 					return newSoaCacheEntry(soa), nil
 				}
@@ -213,11 +409,11 @@ func fetchSoaByFqdn(fqdn string, nameservers []string) (*soaCacheEntry, error) {
 			// NXDOMAIN
 		default:
 			// Any response code other than NOERROR and NXDOMAIN is treated as error
-			return nil, &DNSError{Message: fmt.Sprintf("unexpected response for '%s'", domain), MsgOut: r}
+			return nil, &DNSError{Message: fmt.Sprintf("unexpected response for '%s'", domain), MsgOut: msg}
 		}
 	}
 
-	return nil, &DNSError{Message: fmt.Sprintf("could not find the start of authority for '%s'", fqdn), MsgOut: r, Err: err}
+	return nil, &DNSError{Message: fmt.Sprintf("could not find the start of authority for '%s'", fqdn), MsgOut: msg, Err: err}
 }
 
 // dnsMsgContainsCNAME checks for a CNAME answer in msg.
@@ -228,7 +424,7 @@ func dnsMsgContainsCNAME(msg *dns.Msg) bool {
 	})
 }
 
-func dnsQuery(fqdn string, rtype uint16, nameservers []string, recursive bool) (*dns.Msg, error) {
+func (r *resolver) query(fqdn string, rtype uint16, nameservers []string, recursive bool) (*dns.Msg, error) {
 	m := createDNSMsg(fqdn, rtype, recursive)
 
 	if len(nameservers) == 0 {
@@ -236,14 +432,14 @@ func dnsQuery(fqdn string, rtype uint16, nameservers []string, recursive bool) (
 	}
 
 	var (
-		r      *dns.Msg
+		msg    *dns.Msg
 		err    error
 		errAll error
 	)
 
-	for _, ns := range nameservers {
-		r, err = sendDNSQuery(m, ns)
-		if err == nil && len(r.Answer) > 0 {
+	for _, ns := range dialer.SortAddresses(r.family, nameservers) {
+		msg, err = r.send(m, ns)
+		if err == nil && len(msg.Answer) > 0 {
 			break
 		}
 
@@ -251,10 +447,10 @@ func dnsQuery(fqdn string, rtype uint16, nameservers []string, recursive bool) (
 	}
 
 	if err != nil {
-		return r, errAll
+		return msg, errAll
 	}
 
-	return r, nil
+	return msg, nil
 }
 
 func createDNSMsg(fqdn string, rtype uint16, recursive bool) *dns.Msg {
@@ -269,32 +465,41 @@ func createDNSMsg(fqdn string, rtype uint16, recursive bool) *dns.Msg {
 	return m
 }
 
-func sendDNSQuery(m *dns.Msg, ns string) (*dns.Msg, error) {
-	if ok, _ := strconv.ParseBool(os.Getenv("LEGO_EXPERIMENTAL_DNS_TCP_ONLY")); ok {
-		tcp := &dns.Client{Net: "tcp", Timeout: dnsTimeout}
+func (r *resolver) send(m *dns.Msg, ns string) (*dns.Msg, error) {
+	var (
+		msg *dns.Msg
+		err error
+	)
 
-		r, _, err := tcp.Exchange(m, ns)
-		if err != nil {
-			return r, &DNSError{Message: "DNS call error", MsgIn: m, NS: ns, Err: err}
+	for range r.retries + 1 {
+		msg, err = r.exchange(m, ns)
+		if err == nil {
+			return msg, nil
 		}
-
-		return r, nil
 	}
 
-	udp := &dns.Client{Net: "udp", Timeout: dnsTimeout}
-	r, _, err := udp.Exchange(m, ns)
+	return msg, &DNSError{Message: "DNS call error", MsgIn: m, NS: ns, Err: err}
+}
 
-	if r != nil && r.Truncated {
-		tcp := &dns.Client{Net: "tcp", Timeout: dnsTimeout}
-		// If the TCP request succeeds, the "err" will reset to nil
-		r, _, err = tcp.Exchange(m, ns)
+func (r *resolver) exchange(m *dns.Msg, ns string) (*dns.Msg, error) {
+	if ok, _ := strconv.ParseBool(os.Getenv("LEGO_EXPERIMENTAL_DNS_TCP_ONLY")); ok {
+		tcp := &dns.Client{Net: r.family.Network("tcp"), Timeout: r.timeout}
+
+		msg, _, err := tcp.Exchange(m, ns)
+
+		return msg, err
 	}
 
-	if err != nil {
-		return r, &DNSError{Message: "DNS call error", MsgIn: m, NS: ns, Err: err}
+	udp := &dns.Client{Net: r.family.Network("udp"), Timeout: r.timeout}
+	msg, _, err := udp.Exchange(m, ns)
+
+	if (msg != nil && msg.Truncated) || (err != nil && r.tcpFallback) {
+		tcp := &dns.Client{Net: r.family.Network("tcp"), Timeout: r.timeout}
+		// If the TCP request succeeds, the "err" will reset to nil
+		msg, _, err = tcp.Exchange(m, ns)
 	}
 
-	return r, nil
+	return msg, err
 }
 
 // DNSError error related to DNS calls.
@@ -3,8 +3,11 @@ package dns01
 import (
 	"errors"
 	"sort"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/go-acme/lego/v4/platform/dialer"
 	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
 	"github.com/miekg/dns"
 	"github.com/stretchr/testify/assert"
@@ -67,7 +70,7 @@ func Test_lookupNameserversOK(t *testing.T) {
 		t.Run(test.fqdn, func(t *testing.T) {
 			useAsNameserver(t, test.fakeDNSServer.Build(t))
 
-			nss, err := lookupNameservers(test.fqdn)
+			nss, err := getDefaultResolver().lookupNameservers(test.fqdn)
 			require.NoError(t, err)
 
 			sort.Strings(nss)
@@ -114,7 +117,7 @@ func Test_lookupNameserversErr(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			useAsNameserver(t, test.fakeDNSServer.Build(t))
 
-			_, err := lookupNameservers(test.fqdn)
+			_, err := getDefaultResolver().lookupNameservers(test.fqdn)
 			require.Error(t, err)
 			assert.EqualError(t, err, test.error)
 		})
@@ -298,6 +301,111 @@ func Test_getNameservers_ResolveConfServers(t *testing.T) {
 	}
 }
 
+func TestParseNameservers(t *testing.T) {
+	servers := []string{"192.0.2.1", "192.0.2.2:5353", "2001:db8::1", "[2001:db8::2]", "[2001:db8::3]:5353", "ns.example.com", " ns.example.org:5353 ", "", ":8053", "[]:53"}
+
+	expected := []string{"192.0.2.1:53", "192.0.2.2:5353", "[2001:db8::1]:53", "[2001:db8::2]:53", "[2001:db8::3]:5353", "ns.example.com:53", "ns.example.org:5353"}
+
+	assert.Equal(t, expected, ParseNameservers(servers))
+}
+
+func TestSetDefaultNameservers(t *testing.T) {
+	t.Cleanup(func() { defaultResolver.Store(nil) })
+
+	SetDefaultNameservers([]string{"192.0.2.1"})
+
+	assert.Equal(t, []string{"192.0.2.1:53"}, getDefaultResolver().nameservers)
+
+	// An empty list restores the system nameservers.
+	SetDefaultNameservers(nil)
+
+	assert.Equal(t, getNameservers(defaultResolvConf, defaultNameservers), getDefaultResolver().nameservers)
+}
+
+func TestCheckNameservers(t *testing.T) {
+	err := CheckNameservers([]string{"192.0.2.1", "[2001:db8::3]:5353", "ns.example.com:5353", ""})
+	require.NoError(t, err)
+
+	err = CheckNameservers([]string{"192.0.2.1", ":8053"})
+	require.EqualError(t, err, `invalid nameserver ":8053": missing host`)
+
+	err = CheckNameservers([]string{"[]"})
+	require.EqualError(t, err, `invalid nameserver "[]": missing host`)
+}
+
+func TestAddRecursiveNameservers(t *testing.T) {
+	mockDefaultResolver(t, func(_ *resolver) {})
+
+	chlg := &Challenge{resolver: getDefaultResolver().clone()}
+
+	err := AddRecursiveNameservers([]string{"127.0.0.1:8053"})(chlg)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"127.0.0.1:8053"}, chlg.resolver.nameservers)
+	assert.Equal(t, []string{"127.0.0.1:8053"}, getDefaultResolver().nameservers)
+
+	err = AddRecursiveNameservers([]string{":8053"})(chlg)
+	require.EqualError(t, err, `invalid nameserver ":8053": missing host`)
+
+	assert.Equal(t, []string{"127.0.0.1:8053"}, chlg.resolver.nameservers)
+	assert.Equal(t, []string{"127.0.0.1:8053"}, getDefaultResolver().nameservers)
+}
+
+func TestAddDNSTimeout(t *testing.T) {
+	mockDefaultResolver(t, func(_ *resolver) {})
+
+	chlg := &Challenge{resolver: getDefaultResolver().clone()}
+
+	err := AddDNSTimeout(3 * time.Second)(chlg)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3*time.Second, chlg.resolver.timeout)
+	assert.Equal(t, 3*time.Second, getDefaultResolver().timeout)
+}
+
+func TestAuthoritativeNameserverPort(t *testing.T) {
+	chlg := &Challenge{resolver: &resolver{authoritativePort: defaultAuthoritativePort}}
+
+	err := AuthoritativeNameserverPort(5353)(chlg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "5353", chlg.resolver.authoritativePort)
+
+	err = AuthoritativeNameserverPort(0)(chlg)
+	require.EqualError(t, err, "invalid authoritative nameserver port: 0")
+
+	assert.Equal(t, "5353", chlg.resolver.authoritativePort)
+	assert.Equal(t, defaultAuthoritativePort, getDefaultResolver().authoritativePort)
+}
+
+func Test_defaultNameservers_addressFamily(t *testing.T) {
+	assert.Equal(t, []string{"8.8.8.8:53", "8.8.4.4:53", "[2001:4860:4860::8888]:53", "[2001:4860:4860::8844]:53"},
+		dialer.SortAddresses(dialer.IPv4, defaultNameservers))
+
+	assert.Equal(t, []string{"[2001:4860:4860::8888]:53", "[2001:4860:4860::8844]:53", "8.8.8.8:53", "8.8.4.4:53"},
+		dialer.SortAddresses(dialer.IPv6, defaultNameservers))
+}
+
+func Test_dnsQuery_addressFamily(t *testing.T) {
+	r := &resolver{timeout: 200 * time.Millisecond}
+
+	addr := dnsmock.NewServer().
+		Query("example.com. TXT", dnsmock.Answer(fakeTXT("example.com.", "value"))).
+		Build(t)
+
+	r.family = dialer.IPv4
+
+	msg, err := r.query("example.com.", dns.TypeTXT, []string{addr.String()}, true)
+	require.NoError(t, err)
+	require.Len(t, msg.Answer, 1)
+
+	// The IPv4 address of the mock is not usable with IPv6 only.
+	r.family = dialer.IPv6
+
+	_, err = r.query("example.com.", dns.TypeTXT, []string{addr.String()}, true)
+	require.Error(t, err)
+}
+
 func TestDNSError_Error(t *testing.T) {
 	msgIn := createDNSMsg("example.com.", dns.TypeTXT, true)
 
@@ -363,3 +471,37 @@ func TestDNSError_Error(t *testing.T) {
 		})
 	}
 }
+
+func Test_sendDNSQuery_retries(t *testing.T) {
+	r := &resolver{timeout: 200 * time.Millisecond, retries: 1}
+
+	var calls atomic.Int64
+
+	addr := dnsmock.NewServer().
+		Query("example.com. TXT", func(w dns.ResponseWriter, req *dns.Msg) {
+			// The first query is dropped to simulate a timeout.
+			if calls.Add(1) == 1 {
+				return
+			}
+
+			dnsmock.Answer(fakeTXT("example.com.", "value"))(w, req)
+		}).
+		Build(t)
+
+	msg, err := r.send(createDNSMsg("example.com.", dns.TypeTXT, true), addr.String())
+	require.NoError(t, err)
+
+	require.Len(t, msg.Answer, 1)
+	assert.Equal(t, int64(2), calls.Load())
+}
+
+func Test_sendDNSQuery_noRetry(t *testing.T) {
+	r := &resolver{timeout: 200 * time.Millisecond}
+
+	addr := dnsmock.NewServer().
+		Query("example.com. TXT", func(_ dns.ResponseWriter, _ *dns.Msg) {}).
+		Build(t)
+
+	_, err := r.send(createDNSMsg("example.com.", dns.TypeTXT, true), addr.String())
+	require.ErrorContains(t, err, "DNS call error")
+}
@@ -4,5 +4,5 @@ package dns01
 
 import "time"
 
-// dnsTimeout is used to override the default DNS timeout of 20 seconds.
-var dnsTimeout = 20 * time.Second
+// defaultDNSTimeout the default timeout of the DNS queries.
+const defaultDNSTimeout = 20 * time.Second
@@ -1,6 +1,7 @@
 package dns01
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"strings"
@@ -9,10 +10,6 @@ import (
 	"github.com/miekg/dns"
 )
 
-// defaultNameserverPort used by authoritative NS.
-// This is for tests only.
-var defaultNameserverPort = "53"
-
 // PreCheckFunc checks DNS propagation before notifying ACME that the DNS challenge is ready.
 type PreCheckFunc func(fqdn, value string) (bool, error)
 
@@ -49,6 +46,32 @@ func RecursiveNSsPropagationRequirement() ChallengeOption {
 	}
 }
 
+// FailOnWrongValue stops the propagation check immediately when an authoritative nameserver returns TXT records
+// without the expected value, instead of waiting for the propagation timeout:
+// the record has probably been created in the wrong place (e.g. wrong zone).
+func FailOnWrongValue() ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.preCheck.failOnWrongValue = true
+		return nil
+	}
+}
+
+// PropagationStability requires the expected TXT value to be observed in consecutive propagation checks (rounds)
+// before the validation of the challenge.
+// Mitigates the DNS providers with anycast nameservers flapping between updated and stale POPs.
+// A failed check resets the count.
+func PropagationStability(rounds int) ChallengeOption {
+	return func(chlg *Challenge) error {
+		if rounds < 1 {
+			return fmt.Errorf("invalid number of stability rounds: %d", rounds)
+		}
+
+		chlg.preCheck.stabilityRounds = rounds
+
+		return nil
+	}
+}
+
 func PropagationWait(wait time.Duration, skipCheck bool) ChallengeOption {
 	return WrapPreCheck(func(domain, fqdn, value string, check PreCheckFunc) (bool, error) {
 		time.Sleep(wait)
@@ -62,6 +85,9 @@ func PropagationWait(wait time.Duration, skipCheck bool) ChallengeOption {
 }
 
 type preCheck struct {
+	// resolver the DNS queries of the challenge.
+	resolver *resolver
+
 	// checks DNS propagation before notifying ACME that the DNS challenge is ready.
 	checkFunc WrapPreCheckFunc
 
@@ -70,10 +96,17 @@ type preCheck struct {
 
 	// require the TXT record to be propagated to all recursive name servers
 	requireRecursiveNssPropagation bool
+
+	// stop the check when an authoritative name server returns TXT records without the expected value
+	failOnWrongValue bool
+
+	// number of consecutive successful checks required before the validation
+	stabilityRounds int
 }
 
-func newPreCheck() preCheck {
+func newPreCheck(r *resolver) preCheck {
 	return preCheck{
+		resolver:                           r,
 		requireAuthoritativeNssPropagation: true,
 	}
 }
@@ -86,10 +119,36 @@ func (p preCheck) call(domain, fqdn, value string) (bool, error) {
 	return p.checkFunc(domain, fqdn, value, p.checkDNSPropagation)
 }
 
+// stable wraps a propagation check (one per challenge):
+// the check succeeds only when the expected TXT value has been observed in the required number of consecutive rounds.
+func (p preCheck) stable(check func() (bool, error)) func() (bool, error) {
+	if p.stabilityRounds <= 1 {
+		return check
+	}
+
+	var observed int
+
+	return func() (bool, error) {
+		stop, err := check()
+		if !stop || err != nil {
+			observed = 0
+			return stop, err
+		}
+
+		observed++
+
+		if observed < p.stabilityRounds {
+			return false, fmt.Errorf("record observed in %d/%d consecutive checks", observed, p.stabilityRounds)
+		}
+
+		return true, nil
+	}
+}
+
 // checkDNSPropagation checks if the expected TXT record has been propagated to all authoritative nameservers.
 func (p preCheck) checkDNSPropagation(fqdn, value string) (bool, error) {
 	// Initial attempt to resolve at the recursive NS (require to get CNAME)
-	r, err := dnsQuery(fqdn, dns.TypeTXT, recursiveNameservers, true)
+	r, err := p.resolver.query(fqdn, dns.TypeTXT, p.resolver.nameservers, true)
 	if err != nil {
 		return false, fmt.Errorf("initial recursive nameserver: %w", err)
 	}
@@ -99,7 +158,7 @@ func (p preCheck) checkDNSPropagation(fqdn, value string) (bool, error) {
 	}
 
 	if p.requireRecursiveNssPropagation {
-		_, err = checkNameserversPropagation(fqdn, value, recursiveNameservers, false)
+		_, err = p.resolver.checkNameserversPropagation(fqdn, value, p.resolver.nameservers, false)
 		if err != nil {
 			return false, fmt.Errorf("recursive nameservers: %w", err)
 		}
@@ -109,13 +168,18 @@ func (p preCheck) checkDNSPropagation(fqdn, value string) (bool, error) {
 		return true, nil
 	}
 
-	authoritativeNss, err := lookupNameservers(fqdn)
+	authoritativeNss, err := p.resolver.lookupNameservers(fqdn)
 	if err != nil {
 		return false, err
 	}
 
-	found, err := checkNameserversPropagation(fqdn, value, authoritativeNss, true)
+	found, err := p.resolver.checkNameserversPropagation(fqdn, value, authoritativeNss, true)
 	if err != nil {
+		if p.failOnWrongValue && hasWrongValue(err) {
+			// Stops the check: the record will not appear by waiting.
+			return true, fmt.Errorf("authoritative nameservers: wrong TXT record value: %w", err)
+		}
+
 		return found, fmt.Errorf("authoritative nameservers: %w", err)
 	}
 
@@ -123,41 +187,77 @@ func (p preCheck) checkDNSPropagation(fqdn, value string) (bool, error) {
 }
 
 // checkNameserversPropagation queries each of the given nameservers for the expected TXT record.
-func checkNameserversPropagation(fqdn, value string, nameservers []string, addPort bool) (bool, error) {
+// All the nameservers are queried, and the failures are returned as joined PropagationError.
+func (r *resolver) checkNameserversPropagation(fqdn, value string, nameservers []string, addPort bool) (bool, error) {
+	var errAll error
+
 	for _, ns := range nameservers {
 		if addPort {
-			ns = net.JoinHostPort(ns, defaultNameserverPort)
+			ns = net.JoinHostPort(ns, r.authoritativePort)
 		}
 
-		r, err := dnsQuery(fqdn, dns.TypeTXT, []string{ns}, false)
+		err := r.checkNameserverPropagation(fqdn, value, ns)
 		if err != nil {
-			return false, err
+			errAll = errors.Join(errAll, err)
 		}
+	}
 
-		if r.Rcode != dns.RcodeSuccess {
-			return false, fmt.Errorf("NS %s returned %s for %s", ns, dns.RcodeToString[r.Rcode], fqdn)
-		}
+	if errAll != nil {
+		return false, errAll
+	}
+
+	return true, nil
+}
+
+// checkNameserverPropagation queries a nameserver for the expected TXT record.
+func (r *resolver) checkNameserverPropagation(fqdn, value, ns string) error {
+	msg, err := r.query(fqdn, dns.TypeTXT, []string{ns}, false)
+	if err != nil {
+		return newQueryPropagationError(ns, fqdn, value, err)
+	}
 
-		var records []string
+	if msg.Rcode != dns.RcodeSuccess {
+		return newRcodePropagationError(ns, fqdn, value, msg.Rcode)
+	}
 
-		var found bool
+	var records []string
 
-		for _, rr := range r.Answer {
-			if txt, ok := rr.(*dns.TXT); ok {
-				record := strings.Join(txt.Txt, "")
+	for _, rr := range msg.Answer {
+		if txt, ok := rr.(*dns.TXT); ok {
+			record := strings.Join(txt.Txt, "")
 
-				records = append(records, record)
-				if record == value {
-					found = true
-					break
-				}
+			if record == value {
+				return nil
 			}
+
+			records = append(records, record)
 		}
+	}
+
+	kind := PropagationWrongValue
+	if len(records) == 0 {
+		kind = PropagationEmptyAnswer
+	}
 
-		if !found {
-			return false, fmt.Errorf("NS %s did not return the expected TXT record [fqdn: %s, value: %s]: %s", ns, fqdn, value, strings.Join(records, " ,"))
+	return &PropagationError{Kind: kind, Nameserver: ns, FQDN: fqdn, Expected: value, Values: records}
+}
+
+// hasWrongValue reports whether one of the nameservers has returned TXT records without the expected value.
+func hasWrongValue(err error) bool {
+	var errs []error
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	} else {
+		errs = []error{err}
+	}
+
+	for _, e := range errs {
+		var propagationErr *PropagationError
+		if errors.As(e, &propagationErr) && propagationErr.Kind == PropagationWrongValue {
+			return true
 		}
 	}
 
-	return true, nil
+	return false
 }
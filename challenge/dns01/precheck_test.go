@@ -1,7 +1,10 @@
 package dns01
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
 	"github.com/miekg/dns"
@@ -43,10 +46,11 @@ func Test_preCheck_checkDNSPropagation(t *testing.T) {
 	)
 
 	testCases := []struct {
-		desc          string
-		fqdn          string
-		value         string
-		expectedError string
+		desc             string
+		fqdn             string
+		value            string
+		failOnWrongValue bool
+		expectedError    string
 	}{
 		{
 			desc:  "success",
@@ -59,18 +63,27 @@ func Test_preCheck_checkDNSPropagation(t *testing.T) {
 			value:         "fe01=",
 			expectedError: "did not return the expected TXT record [fqdn: acme-staging.api.example.com., value: fe01=]: one ,two ,three ,four ,five",
 		},
+		{
+			desc:             "no matching TXT record: fail on wrong value",
+			fqdn:             "acme-staging.api.example.com.",
+			value:            "fe01=",
+			failOnWrongValue: true,
+			expectedError:    "authoritative nameservers: wrong TXT record value: NS ns0.lego.localhost.:",
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			ClearFqdnCache()
 
-			check := newPreCheck()
+			check := newPreCheck(getDefaultResolver())
+			check.failOnWrongValue = test.failOnWrongValue
 
 			ok, err := check.checkDNSPropagation(test.fqdn, test.value)
 			if test.expectedError != "" {
 				assert.ErrorContainsf(t, err, test.expectedError, "PreCheckDNS must fail for %s", test.fqdn)
-				assert.False(t, ok, "PreCheckDNS must fail for %s", test.fqdn)
+				// When failing on the wrong value, the check must stop immediately.
+				assert.Equal(t, test.failOnWrongValue, ok, "PreCheckDNS must fail for %s", test.fqdn)
 			} else {
 				assert.NoErrorf(t, err, "PreCheckDNS failed for %s", test.fqdn)
 				assert.True(t, ok, "PreCheckDNS failed for %s", test.fqdn)
@@ -130,7 +143,7 @@ func Test_checkNameserversPropagation_authoritativeNss(t *testing.T) {
 
 			addr := test.fakeDNSServer.Build(t)
 
-			ok, err := checkNameserversPropagation(test.fqdn, test.value, []string{addr.String()}, false)
+			ok, err := getDefaultResolver().checkNameserversPropagation(test.fqdn, test.value, []string{addr.String()}, false)
 
 			if test.expectedError == "" {
 				require.NoError(t, err)
@@ -143,3 +156,150 @@ func Test_checkNameserversPropagation_authoritativeNss(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkNameserversPropagation_errorKinds(t *testing.T) {
+	r := &resolver{timeout: 200 * time.Millisecond}
+
+	testCases := []struct {
+		desc          string
+		fakeDNSServer *dnsmock.Builder
+		expectedKind  PropagationErrorKind
+		expectedValue []string
+	}{
+		{
+			desc: "NXDOMAIN",
+			fakeDNSServer: dnsmock.NewServer().
+				Query("_acme-challenge.example.com. TXT", dnsmock.Error(dns.RcodeNameError)),
+			expectedKind: PropagationNXDomain,
+		},
+		{
+			desc: "SERVFAIL",
+			fakeDNSServer: dnsmock.NewServer().
+				Query("_acme-challenge.example.com. TXT", dnsmock.Error(dns.RcodeServerFailure)),
+			expectedKind: PropagationServFail,
+		},
+		{
+			desc: "REFUSED",
+			fakeDNSServer: dnsmock.NewServer().
+				Query("_acme-challenge.example.com. TXT", dnsmock.Error(dns.RcodeRefused)),
+			expectedKind: PropagationRcode,
+		},
+		{
+			desc: "timeout",
+			fakeDNSServer: dnsmock.NewServer().
+				Query("_acme-challenge.example.com. TXT", func(_ dns.ResponseWriter, _ *dns.Msg) {}),
+			expectedKind: PropagationTimeout,
+		},
+		{
+			desc: "empty answer",
+			fakeDNSServer: dnsmock.NewServer().
+				Query("_acme-challenge.example.com. TXT", dnsmock.Noop),
+			expectedKind: PropagationEmptyAnswer,
+		},
+		{
+			desc: "wrong value",
+			fakeDNSServer: dnsmock.NewServer().
+				Query("_acme-challenge.example.com. TXT",
+					dnsmock.Answer(fakeTXT("_acme-challenge.example.com.", "other"))),
+			expectedKind:  PropagationWrongValue,
+			expectedValue: []string{"other"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			addr := test.fakeDNSServer.Build(t)
+
+			ok, err := r.checkNameserversPropagation("_acme-challenge.example.com.", "expected", []string{addr.String()}, false)
+			require.Error(t, err)
+			assert.False(t, ok)
+
+			var propagationErr *PropagationError
+			require.ErrorAs(t, err, &propagationErr)
+
+			assert.Equal(t, test.expectedKind, propagationErr.Kind)
+			assert.Equal(t, addr.String(), propagationErr.Nameserver)
+			assert.Equal(t, "_acme-challenge.example.com.", propagationErr.FQDN)
+			assert.Equal(t, test.expectedValue, propagationErr.Values)
+		})
+	}
+}
+
+func Test_checkNameserversPropagation_allNameservers(t *testing.T) {
+	addr1 := dnsmock.NewServer().
+		Query("_acme-challenge.example.com. TXT", dnsmock.Error(dns.RcodeServerFailure)).
+		Build(t)
+
+	addr2 := dnsmock.NewServer().
+		Query("_acme-challenge.example.com. TXT",
+			dnsmock.Answer(fakeTXT("_acme-challenge.example.com.", "other"))).
+		Build(t)
+
+	_, err := getDefaultResolver().checkNameserversPropagation("_acme-challenge.example.com.", "expected", []string{addr1.String(), addr2.String()}, false)
+	require.Error(t, err)
+
+	expected := fmt.Sprintf("NS %s returned SERVFAIL for _acme-challenge.example.com.\n"+
+		"NS %s did not return the expected TXT record [fqdn: _acme-challenge.example.com., value: expected]: other", addr1, addr2)
+
+	require.EqualError(t, err, expected)
+}
+
+func Test_preCheck_stable(t *testing.T) {
+	// true: the record is observed, false: a stale POP answered.
+	observations := []bool{true, false, true, true, false, true, true, true, true}
+
+	var calls int
+
+	check := preCheck{stabilityRounds: 3}.stable(func() (bool, error) {
+		observed := observations[calls]
+		calls++
+
+		if !observed {
+			return false, errors.New("stale")
+		}
+
+		return true, nil
+	})
+
+	var results []string
+
+	for {
+		stop, err := check()
+
+		if err != nil {
+			results = append(results, err.Error())
+		}
+
+		if stop {
+			break
+		}
+	}
+
+	expected := []string{
+		"record observed in 1/3 consecutive checks",
+		"stale",
+		"record observed in 1/3 consecutive checks",
+		"record observed in 2/3 consecutive checks",
+		"stale",
+		"record observed in 1/3 consecutive checks",
+		"record observed in 2/3 consecutive checks",
+	}
+
+	assert.Equal(t, expected, results)
+	assert.Equal(t, 8, calls)
+}
+
+func Test_preCheck_stable_disabled(t *testing.T) {
+	var calls int
+
+	check := newPreCheck(getDefaultResolver()).stable(func() (bool, error) {
+		calls++
+		return true, nil
+	})
+
+	stop, err := check()
+	require.NoError(t, err)
+
+	assert.True(t, stop)
+	assert.Equal(t, 1, calls)
+}
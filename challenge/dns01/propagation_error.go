@@ -0,0 +1,97 @@
+package dns01
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// PropagationErrorKind the kind of failure of a propagation check.
+type PropagationErrorKind string
+
+// Kinds of propagation check failures.
+const (
+	// PropagationNXDomain the nameserver doesn't know the domain (NXDOMAIN).
+	PropagationNXDomain PropagationErrorKind = "NXDOMAIN"
+	// PropagationServFail the nameserver failed to answer (SERVFAIL).
+	PropagationServFail PropagationErrorKind = "SERVFAIL"
+	// PropagationRcode the nameserver returned another unexpected response code.
+	PropagationRcode PropagationErrorKind = "unexpected response code"
+	// PropagationTimeout the query to the nameserver timed out.
+	PropagationTimeout PropagationErrorKind = "timeout"
+	// PropagationQueryError the query to the nameserver failed.
+	PropagationQueryError PropagationErrorKind = "query error"
+	// PropagationEmptyAnswer the nameserver returned no TXT record.
+	PropagationEmptyAnswer PropagationErrorKind = "empty answer"
+	// PropagationWrongValue the nameserver returned TXT records, but not the expected one.
+	PropagationWrongValue PropagationErrorKind = "wrong value"
+)
+
+// PropagationError describes the failure of a propagation check on a nameserver.
+type PropagationError struct {
+	Kind       PropagationErrorKind
+	Nameserver string
+	FQDN       string
+	Expected   string
+
+	// Values the TXT values returned by the nameserver.
+	Values []string
+	// Rcode the response code returned by the nameserver.
+	Rcode int
+	// Err the underlying error of the query.
+	Err error
+}
+
+func (e *PropagationError) Error() string {
+	switch e.Kind {
+	case PropagationNXDomain, PropagationServFail, PropagationRcode:
+		return fmt.Sprintf("NS %s returned %s for %s", e.Nameserver, dns.RcodeToString[e.Rcode], e.FQDN)
+
+	case PropagationTimeout, PropagationQueryError:
+		return fmt.Sprintf("NS %s: %s for %s: %v", e.Nameserver, e.Kind, e.FQDN, e.Err)
+
+	default:
+		return fmt.Sprintf("NS %s did not return the expected TXT record [fqdn: %s, value: %s]: %s", e.Nameserver, e.FQDN, e.Expected, strings.Join(e.Values, " ,"))
+	}
+}
+
+func (e *PropagationError) Unwrap() error {
+	return e.Err
+}
+
+// IsPending reports whether the failure can be caused by a record not yet propagated.
+func (e *PropagationError) IsPending() bool {
+	switch e.Kind {
+	case PropagationNXDomain, PropagationEmptyAnswer, PropagationWrongValue:
+		return true
+	default:
+		return false
+	}
+}
+
+func newQueryPropagationError(ns, fqdn, value string, err error) *PropagationError {
+	kind := PropagationQueryError
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		kind = PropagationTimeout
+	}
+
+	return &PropagationError{Kind: kind, Nameserver: ns, FQDN: fqdn, Expected: value, Err: err}
+}
+
+func newRcodePropagationError(ns, fqdn, value string, rcode int) *PropagationError {
+	kind := PropagationRcode
+
+	switch rcode {
+	case dns.RcodeNameError:
+		kind = PropagationNXDomain
+	case dns.RcodeServerFailure:
+		kind = PropagationServFail
+	}
+
+	return &PropagationError{Kind: kind, Nameserver: ns, FQDN: fqdn, Expected: value, Rcode: rcode}
+}
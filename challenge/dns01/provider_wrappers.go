@@ -0,0 +1,215 @@
+package dns01
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/wait"
+)
+
+// WithRetries wraps a provider to retry Present and CleanUp up to attempts times (including the first one),
+// with an exponential backoff between the attempts.
+// The optional Timeout and Sequential methods of the wrapped provider are preserved.
+func WithRetries(provider challenge.Provider, attempts int) challenge.Provider {
+	if attempts <= 1 {
+		return provider
+	}
+
+	retried := func(fn providerFunc) providerFunc {
+		return func(ctx context.Context, domain, token, keyAuth string) error {
+			return retry(ctx, attempts, func() error { return fn(ctx, domain, token, keyAuth) })
+		}
+	}
+
+	return wrapProvider(provider, retried(presentFunc(provider)), retried(cleanUpFunc(provider)))
+}
+
+// WithLogging wraps a provider to log the calls to Present and CleanUp, their durations, and their errors.
+// The failures are logged at the warning level.
+// If logger is nil, the default lego logger is used.
+// The optional Timeout and Sequential methods of the wrapped provider are preserved.
+func WithLogging(provider challenge.Provider, logger log.StdLogger) challenge.Provider {
+	infof := func(format string, args ...any) {
+		if logger == nil {
+			log.Infof(format, args...)
+			return
+		}
+
+		logger.Printf("[INFO] "+format, args...)
+	}
+
+	warnf := func(format string, args ...any) {
+		if logger == nil {
+			log.Warnf(format, args...)
+			return
+		}
+
+		logger.Printf("[WARN] "+format, args...)
+	}
+
+	logged := func(action string, fn providerFunc) providerFunc {
+		return func(ctx context.Context, domain, token, keyAuth string) error {
+			infof("[%s] dns01: %s: start", domain, action)
+
+			start := time.Now()
+
+			err := fn(ctx, domain, token, keyAuth)
+			if err != nil {
+				warnf("[%s] dns01: %s: failed after %s: %v", domain, action, time.Since(start), err)
+				return err
+			}
+
+			infof("[%s] dns01: %s: done in %s", domain, action, time.Since(start))
+
+			return nil
+		}
+	}
+
+	return wrapProvider(provider, logged("present", presentFunc(provider)), logged("cleanup", cleanUpFunc(provider)))
+}
+
+// WithTimeout wraps a provider to bound the duration of each call to Present and CleanUp.
+// When the limit is reached, an error is returned and the context of the call is canceled:
+// the call is interrupted if the wrapped provider implements challenge.ProviderContext,
+// otherwise it ends in the background.
+// The optional Timeout and Sequential methods of the wrapped provider are preserved.
+func WithTimeout(provider challenge.Provider, timeout time.Duration) challenge.Provider {
+	if timeout <= 0 {
+		return provider
+	}
+
+	bounded := func(action string, fn providerFunc) providerFunc {
+		return func(ctx context.Context, domain, token, keyAuth string) error {
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			// Buffered: the goroutine never blocks, even when the result is no longer expected.
+			errCh := make(chan error, 1)
+
+			go func() {
+				errCh <- fn(ctx, domain, token, keyAuth)
+			}()
+
+			select {
+			case err := <-errCh:
+				return err
+			case <-ctx.Done():
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return fmt.Errorf("%s: time limit exceeded (%s)", action, timeout)
+				}
+
+				return context.Cause(ctx)
+			}
+		}
+	}
+
+	return wrapProvider(provider, bounded("present", presentFunc(provider)), bounded("cleanup", cleanUpFunc(provider)))
+}
+
+// newRetryBackOff creates the backoff used between the attempts of WithRetries.
+var newRetryBackOff = func() backoff.BackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = time.Second
+	bo.MaxInterval = 30 * time.Second
+
+	return bo
+}
+
+func retry(ctx context.Context, attempts int, operation func() error) error {
+	return wait.Retry(ctx, operation,
+		backoff.WithBackOff(newRetryBackOff()),
+		backoff.WithMaxTries(uint(attempts)),
+		backoff.WithMaxElapsedTime(0),
+	)
+}
+
+type providerFunc func(ctx context.Context, domain, token, keyAuth string) error
+
+// presentFunc returns the Present method of the provider, with the context when the provider supports it.
+func presentFunc(provider challenge.Provider) providerFunc {
+	if p, ok := provider.(challenge.ProviderContext); ok {
+		return p.PresentContext
+	}
+
+	return func(_ context.Context, domain, token, keyAuth string) error {
+		return provider.Present(domain, token, keyAuth)
+	}
+}
+
+// cleanUpFunc returns the CleanUp method of the provider, with the context when the provider supports it.
+func cleanUpFunc(provider challenge.Provider) providerFunc {
+	if p, ok := provider.(challenge.ProviderContext); ok {
+		return p.CleanUpContext
+	}
+
+	return func(_ context.Context, domain, token, keyAuth string) error {
+		return provider.CleanUp(domain, token, keyAuth)
+	}
+}
+
+// wrappedProvider is a challenge.ProviderTimeout and a challenge.ProviderContext that delegates to a wrapped provider.
+// When the wrapped provider doesn't implement challenge.ProviderTimeout, the default values are used.
+type wrappedProvider struct {
+	provider challenge.Provider
+
+	present providerFunc
+	cleanUp providerFunc
+}
+
+func (w *wrappedProvider) Present(domain, token, keyAuth string) error {
+	return w.present(context.Background(), domain, token, keyAuth)
+}
+
+func (w *wrappedProvider) PresentContext(ctx context.Context, domain, token, keyAuth string) error {
+	return w.present(ctx, domain, token, keyAuth)
+}
+
+func (w *wrappedProvider) CleanUp(domain, token, keyAuth string) error {
+	return w.cleanUp(context.Background(), domain, token, keyAuth)
+}
+
+func (w *wrappedProvider) CleanUpContext(ctx context.Context, domain, token, keyAuth string) error {
+	return w.cleanUp(ctx, domain, token, keyAuth)
+}
+
+func (w *wrappedProvider) Timeout() (timeout, interval time.Duration) {
+	if p, ok := w.provider.(challenge.ProviderTimeout); ok {
+		return p.Timeout()
+	}
+
+	return DefaultPropagationTimeout, DefaultPollingInterval
+}
+
+// Name returns the name of the wrapped provider (used in the metrics and the traces).
+func (w *wrappedProvider) Name() string {
+	return providerName(w.provider)
+}
+
+// HasTXTRecord forwards TXTRecordFinder to the wrapped provider.
+func (w *wrappedProvider) HasTXTRecord(fqdn, value string) (bool, error) {
+	return hasTXTRecord(w.provider, fqdn, value)
+}
+
+// wrappedSequentialProvider is a wrappedProvider for the providers that require sequential solving.
+type wrappedSequentialProvider struct {
+	*wrappedProvider
+}
+
+func (w *wrappedSequentialProvider) Sequential() time.Duration {
+	return w.provider.(sequential).Sequential()
+}
+
+func wrapProvider(provider challenge.Provider, present, cleanUp providerFunc) challenge.Provider {
+	w := &wrappedProvider{provider: provider, present: present, cleanUp: cleanUp}
+
+	if _, ok := provider.(sequential); ok {
+		return &wrappedSequentialProvider{wrappedProvider: w}
+	}
+
+	return w
+}
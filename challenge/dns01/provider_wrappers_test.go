@@ -0,0 +1,281 @@
+package dns01
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	stdlog "log"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvider struct {
+	presentCalls int
+	cleanUpCalls int
+
+	failures int
+
+	// block, when not nil, blocks Present until it's closed.
+	block chan struct{}
+}
+
+func (f *fakeProvider) Present(_, _, _ string) error {
+	f.presentCalls++
+
+	if f.block != nil {
+		<-f.block
+	}
+
+	if f.presentCalls <= f.failures {
+		return errors.New("present error")
+	}
+
+	return nil
+}
+
+func (f *fakeProvider) CleanUp(_, _, _ string) error {
+	f.cleanUpCalls++
+
+	if f.cleanUpCalls <= f.failures {
+		return errors.New("cleanup error")
+	}
+
+	return nil
+}
+
+// fakeContextProvider is a provider that supports the cancellation.
+type fakeContextProvider struct {
+	fakeProvider
+
+	// interrupted is closed when PresentContext returns.
+	interrupted chan struct{}
+}
+
+func (f *fakeContextProvider) PresentContext(ctx context.Context, _, _, _ string) error {
+	defer close(f.interrupted)
+
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func (f *fakeContextProvider) CleanUpContext(ctx context.Context, domain, token, keyAuth string) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	return f.CleanUp(domain, token, keyAuth)
+}
+
+type fakeProviderTimeout struct {
+	fakeProvider
+}
+
+func (f *fakeProviderTimeout) Timeout() (timeout, interval time.Duration) {
+	return 3 * time.Minute, 7 * time.Second
+}
+
+type fakeSequentialProvider struct {
+	fakeProvider
+}
+
+func (f *fakeSequentialProvider) Sequential() time.Duration {
+	return 42 * time.Second
+}
+
+func setupFastRetries(t *testing.T) {
+	t.Helper()
+
+	original := newRetryBackOff
+
+	t.Cleanup(func() { newRetryBackOff = original })
+
+	newRetryBackOff = func() backoff.BackOff {
+		return backoff.NewConstantBackOff(time.Millisecond)
+	}
+}
+
+func TestWithRetries(t *testing.T) {
+	setupFastRetries(t)
+
+	inner := &fakeProvider{failures: 2}
+
+	provider := WithRetries(inner, 3)
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, inner.presentCalls)
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, inner.cleanUpCalls)
+}
+
+func TestWithRetries_exhausted(t *testing.T) {
+	setupFastRetries(t)
+
+	inner := &fakeProvider{failures: 5}
+
+	provider := WithRetries(inner, 3)
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "present error")
+
+	assert.Equal(t, 3, inner.presentCalls)
+}
+
+func TestWithRetries_canceled(t *testing.T) {
+	setupFastRetries(t)
+
+	inner := &fakeProvider{failures: 5}
+
+	provider := WithRetries(inner, 3)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	p, ok := provider.(challenge.ProviderContext)
+	require.True(t, ok)
+
+	err := p.CleanUpContext(ctx, "example.com", "token", "keyAuth")
+	require.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, 1, inner.cleanUpCalls)
+}
+
+func TestWithRetries_noRetry(t *testing.T) {
+	inner := &fakeProvider{}
+
+	provider := WithRetries(inner, 1)
+
+	assert.Same(t, inner, provider)
+}
+
+func TestWithLogging(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	inner := &fakeProvider{failures: 1}
+
+	provider := WithLogging(inner, stdlog.New(buf, "", 0))
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "present error")
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "cleanup error")
+
+	err = provider.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	output := buf.String()
+
+	assert.Contains(t, output, "[INFO] [example.com] dns01: present: start")
+	assert.Contains(t, output, "[WARN] [example.com] dns01: present: failed after")
+	assert.Contains(t, output, ": present error")
+	assert.Contains(t, output, "[WARN] [example.com] dns01: cleanup: failed after")
+	assert.Contains(t, output, "[INFO] [example.com] dns01: present: done in")
+}
+
+func TestWithTimeout(t *testing.T) {
+	inner := &fakeProvider{block: make(chan struct{})}
+
+	// Releases the call that ends in the background.
+	t.Cleanup(func() { close(inner.block) })
+
+	provider := WithTimeout(inner, 10*time.Millisecond)
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "present: time limit exceeded (10ms)")
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+}
+
+func TestWithTimeout_context(t *testing.T) {
+	inner := &fakeContextProvider{interrupted: make(chan struct{})}
+
+	provider := WithTimeout(inner, 10*time.Millisecond)
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "present: time limit exceeded (10ms)")
+
+	select {
+	case <-inner.interrupted:
+	case <-time.After(time.Second):
+		t.Fatal("the call has not been interrupted")
+	}
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+}
+
+func TestWithTimeout_canceled(t *testing.T) {
+	inner := &fakeContextProvider{interrupted: make(chan struct{})}
+
+	provider := WithTimeout(inner, time.Minute)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	p, ok := provider.(challenge.ProviderContext)
+	require.True(t, ok)
+
+	err := p.PresentContext(ctx, "example.com", "token", "keyAuth")
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestWrappers_preserveTimeout(t *testing.T) {
+	setupFastRetries(t)
+
+	inner := &fakeProviderTimeout{}
+
+	provider := WithLogging(WithTimeout(WithRetries(inner, 2), time.Second), nil)
+
+	p, ok := provider.(challenge.ProviderTimeout)
+	require.True(t, ok)
+
+	timeout, interval := p.Timeout()
+	assert.Equal(t, 3*time.Minute, timeout)
+	assert.Equal(t, 7*time.Second, interval)
+
+	_, ok = provider.(sequential)
+	assert.False(t, ok)
+}
+
+func TestWrappers_defaultTimeout(t *testing.T) {
+	provider := WithLogging(&fakeProvider{}, nil)
+
+	p, ok := provider.(challenge.ProviderTimeout)
+	require.True(t, ok)
+
+	timeout, interval := p.Timeout()
+	assert.Equal(t, DefaultPropagationTimeout, timeout)
+	assert.Equal(t, DefaultPollingInterval, interval)
+}
+
+func TestWrappers_preserveSequential(t *testing.T) {
+	provider := WithTimeout(WithLogging(&fakeSequentialProvider{}, nil), time.Second)
+
+	p, ok := provider.(sequential)
+	require.True(t, ok)
+
+	assert.Equal(t, 42*time.Second, p.Sequential())
+}
+
+func TestWrappers_name(t *testing.T) {
+	provider := WithLogging(WithTimeout(WithRetries(&namedProviderMock{Provider: &fakeProvider{}, name: "cloudflare"}, 2), time.Second), nil)
+
+	assert.Equal(t, "cloudflare", providerName(provider))
+
+	provider = WithLogging(&fakeProvider{}, nil)
+
+	assert.Equal(t, "dns01", providerName(provider))
+}
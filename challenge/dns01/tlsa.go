@@ -0,0 +1,8 @@
+package dns01
+
+// TLSAProvider is implemented by the DNS providers able to manage TLSA records (DANE).
+type TLSAProvider interface {
+	// SetTLSA replaces the TLSA records of the FQDN.
+	// The records are the RDATA in the presentation format (ex: "3 1 1 <hex>").
+	SetTLSA(fqdn string, records []string) error
+}
@@ -0,0 +1,131 @@
+// Package emailreply00 implements the email-reply-00 challenge (S/MIME certificates).
+// https://www.rfc-editor.org/rfc/rfc8823.html
+package emailreply00
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+)
+
+const subjectPrefix = "ACME:"
+
+type ValidateFunc func(ctx context.Context, core *api.Core, domain string, chlng acme.Challenge) error
+
+type ChallengeOption func(*Challenge) error
+
+// Provider exchanges the emails of the challenge with the ACME server.
+type Provider interface {
+	// Receive returns the challenge email sent by the ACME server (from) to the email address.
+	// The subject contains the first part of the token, the Message-ID is used to reply to the email.
+	Receive(ctx context.Context, email, from string) (*Message, error)
+
+	// Reply sends the response email to the ACME server.
+	Reply(ctx context.Context, response *Message) error
+}
+
+// Challenge implements the email-reply-00 challenge.
+type Challenge struct {
+	core     *api.Core
+	validate ValidateFunc
+	provider Provider
+}
+
+func NewChallenge(core *api.Core, validate ValidateFunc, provider Provider, opts ...ChallengeOption) *Challenge {
+	chlg := &Challenge{
+		core:     core,
+		validate: validate,
+		provider: provider,
+	}
+
+	for _, opt := range opts {
+		err := opt(chlg)
+		if err != nil {
+			chlg.core.Log().Infof("challenge option error: %v", err)
+		}
+	}
+
+	return chlg
+}
+
+// Solve replies to the challenge email and waits for the validation.
+func (c *Challenge) Solve(authz acme.Authorization) error {
+	return c.SolveContext(context.Background(), authz)
+}
+
+// SolveContext is like [Challenge.Solve] with a context.
+func (c *Challenge) SolveContext(ctx context.Context, authz acme.Authorization) error {
+	email := authz.Identifier.Value
+	c.core.Log().Infof("[%s] acme: Trying to solve EMAIL-REPLY-00", email)
+
+	chlng, err := challenge.FindChallenge(challenge.EmailReply00, authz)
+	if err != nil {
+		return err
+	}
+
+	if c.provider == nil {
+		return fmt.Errorf("[%s] acme: no email provider configured", email)
+	}
+
+	msg, err := c.provider.Receive(ctx, email, chlng.From)
+	if err != nil {
+		return fmt.Errorf("[%s] acme: error receiving the challenge email: %w", email, err)
+	}
+
+	tokenPart1, err := GetTokenPart1(msg.Subject)
+	if err != nil {
+		return fmt.Errorf("[%s] acme: %w", email, err)
+	}
+
+	// The token is the concatenation of the token from the email and the token from the challenge object.
+	keyAuth, err := c.core.GetKeyAuthorization(tokenPart1 + chlng.Token)
+	if err != nil {
+		return err
+	}
+
+	err = c.provider.Reply(ctx, NewResponse(msg, email, chlng.From, keyAuth))
+	if err != nil {
+		return fmt.Errorf("[%s] acme: error sending the response email: %w", email, err)
+	}
+
+	return c.validate(ctx, c.core, email, chlng)
+}
+
+// GetTokenPart1 extracts the first part of the token from the subject of the challenge email ("ACME: <token-part1>").
+// https://www.rfc-editor.org/rfc/rfc8823.html#section-3.1
+func GetTokenPart1(subject string) (string, error) {
+	token, ok := strings.CutPrefix(strings.TrimSpace(subject), subjectPrefix)
+	if !ok {
+		return "", fmt.Errorf("invalid challenge email subject: %q", subject)
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errors.New("missing token in the challenge email subject")
+	}
+
+	return token, nil
+}
+
+// NewResponse creates the response email to a challenge email.
+// https://www.rfc-editor.org/rfc/rfc8823.html#section-3.2
+func NewResponse(msg *Message, email, from, keyAuth string) *Message {
+	sum := sha256.Sum256([]byte(keyAuth))
+
+	return &Message{
+		From:      email,
+		To:        from,
+		Subject:   "Re: " + strings.TrimSpace(msg.Subject),
+		InReplyTo: msg.MessageID,
+		Body: "-----BEGIN ACME RESPONSE-----\r\n" +
+			base64.RawURLEncoding.EncodeToString(sum[:]) + "\r\n" +
+			"-----END ACME RESPONSE-----\r\n",
+	}
+}
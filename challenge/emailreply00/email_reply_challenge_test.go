@@ -0,0 +1,196 @@
+package emailreply00
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerMock struct {
+	received *Message
+	err      error
+	replies  []*Message
+}
+
+func (p *providerMock) Receive(_ context.Context, _, _ string) (*Message, error) {
+	return p.received, p.err
+}
+
+func (p *providerMock) Reply(_ context.Context, response *Message) error {
+	p.replies = append(p.replies, response)
+	return nil
+}
+
+func TestChallenge_Solve(t *testing.T) {
+	server := tester.MockACMEServer().BuildHTTPS(t)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	keyAuth, err := core.GetKeyAuthorization("part1" + "part2")
+	require.NoError(t, err)
+
+	provider := &providerMock{
+		received: &Message{
+			From:      "acme-challenge@ca.example.net",
+			To:        "foo@example.com",
+			Subject:   "ACME: part1",
+			MessageID: "<A2299BB.FF7788@ca.example.net>",
+		},
+	}
+
+	var validated bool
+
+	validate := func(_ context.Context, _ *api.Core, domain string, chlng acme.Challenge) error {
+		assert.Equal(t, "foo@example.com", domain)
+		assert.Equal(t, "https://example.com/chlg/1", chlng.URL)
+
+		validated = true
+
+		return nil
+	}
+
+	chlg := NewChallenge(core, validate, provider)
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{Type: acme.IdentifierEmail, Value: "foo@example.com"},
+		Challenges: []acme.Challenge{
+			{
+				Type:  challenge.EmailReply00.String(),
+				URL:   "https://example.com/chlg/1",
+				Token: "part2",
+				From:  "acme-challenge@ca.example.net",
+			},
+		},
+	}
+
+	err = chlg.SolveContext(t.Context(), authz)
+	require.NoError(t, err)
+
+	assert.True(t, validated)
+
+	sum := sha256.Sum256([]byte(keyAuth))
+
+	expected := []*Message{{
+		From:      "foo@example.com",
+		To:        "acme-challenge@ca.example.net",
+		Subject:   "Re: ACME: part1",
+		InReplyTo: "<A2299BB.FF7788@ca.example.net>",
+		Body: "-----BEGIN ACME RESPONSE-----\r\n" +
+			base64.RawURLEncoding.EncodeToString(sum[:]) + "\r\n" +
+			"-----END ACME RESPONSE-----\r\n",
+	}}
+
+	assert.Equal(t, expected, provider.replies)
+}
+
+func TestChallenge_Solve_error(t *testing.T) {
+	server := tester.MockACMEServer().BuildHTTPS(t)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	validate := func(_ context.Context, _ *api.Core, _ string, _ acme.Challenge) error {
+		return nil
+	}
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{Type: acme.IdentifierEmail, Value: "foo@example.com"},
+		Challenges: []acme.Challenge{
+			{Type: challenge.EmailReply00.String(), Token: "part2", From: "acme-challenge@ca.example.net"},
+		},
+	}
+
+	testCases := []struct {
+		desc     string
+		provider Provider
+		expected string
+	}{
+		{
+			desc:     "no provider",
+			expected: "[foo@example.com] acme: no email provider configured",
+		},
+		{
+			desc:     "receive error",
+			provider: &providerMock{err: errors.New("mailbox unavailable")},
+			expected: "[foo@example.com] acme: error receiving the challenge email: mailbox unavailable",
+		},
+		{
+			desc:     "invalid subject",
+			provider: &providerMock{received: &Message{Subject: "Hello"}},
+			expected: `[foo@example.com] acme: invalid challenge email subject: "Hello"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			chlg := NewChallenge(core, validate, test.provider)
+
+			err := chlg.SolveContext(t.Context(), authz)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestGetTokenPart1(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		subject       string
+		expected      string
+		expectedError string
+	}{
+		{
+			desc:     "simple",
+			subject:  "ACME: LgYemJLy3F1LDkiJrdIGbEzyFJyOyf6vBdyZ1TG3sME=",
+			expected: "LgYemJLy3F1LDkiJrdIGbEzyFJyOyf6vBdyZ1TG3sME=",
+		},
+		{
+			desc:     "spaces",
+			subject:  "  ACME:   abc  ",
+			expected: "abc",
+		},
+		{
+			desc:          "missing prefix",
+			subject:       "Re: ACME: abc",
+			expectedError: `invalid challenge email subject: "Re: ACME: abc"`,
+		},
+		{
+			desc:          "missing token",
+			subject:       "ACME: ",
+			expectedError: "missing token in the challenge email subject",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			token, err := GetTokenPart1(test.subject)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, token)
+		})
+	}
+}
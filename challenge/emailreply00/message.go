@@ -0,0 +1,86 @@
+package emailreply00
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/mail"
+	"strings"
+)
+
+// Message an email of the challenge.
+type Message struct {
+	From      string
+	To        string
+	Subject   string
+	MessageID string
+	InReplyTo string
+	Body      string
+}
+
+// ParseMessage parses a raw email (RFC 5322), ex: the challenge email fetched from a mailbox.
+func ParseMessage(r io.Reader) (*Message, error) {
+	raw, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("parse email: %w", err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(raw.Header.Get("Subject"))
+	if err != nil {
+		return nil, fmt.Errorf("parse email: subject: %w", err)
+	}
+
+	body, err := io.ReadAll(raw.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parse email: body: %w", err)
+	}
+
+	return &Message{
+		From:      addressOf(raw.Header.Get("From")),
+		To:        addressOf(raw.Header.Get("To")),
+		Subject:   subject,
+		MessageID: raw.Header.Get("Message-ID"),
+		InReplyTo: raw.Header.Get("In-Reply-To"),
+		Body:      string(body),
+	}, nil
+}
+
+// Bytes returns the raw email (RFC 5322), ex: to send it with SMTP.
+func (m *Message) Bytes() []byte {
+	buf := new(bytes.Buffer)
+
+	writeHeader(buf, "From", m.From)
+	writeHeader(buf, "To", m.To)
+	writeHeader(buf, "Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+
+	if m.MessageID != "" {
+		writeHeader(buf, "Message-ID", m.MessageID)
+	}
+
+	if m.InReplyTo != "" {
+		writeHeader(buf, "In-Reply-To", m.InReplyTo)
+		writeHeader(buf, "References", m.InReplyTo)
+	}
+
+	writeHeader(buf, "MIME-Version", "1.0")
+	writeHeader(buf, "Content-Type", `text/plain; charset="utf-8"`)
+
+	buf.WriteString("\r\n")
+	buf.WriteString(m.Body)
+
+	return buf.Bytes()
+}
+
+func writeHeader(w io.StringWriter, key, value string) {
+	_, _ = w.WriteString(key + ": " + strings.NewReplacer("\r", "", "\n", "").Replace(value) + "\r\n")
+}
+
+func addressOf(value string) string {
+	addr, err := mail.ParseAddress(value)
+	if err != nil {
+		return value
+	}
+
+	return addr.Address
+}
@@ -0,0 +1,61 @@
+package emailreply00
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessage(t *testing.T) {
+	raw := "Auto-Submitted: auto-generated; type=acme\r\n" +
+		"Date: Sat, 5 Dec 2020 10:08:55 +0100\r\n" +
+		"Message-ID: <A2299BB.FF7788@ca.example.net>\r\n" +
+		"From: ACME CA <acme-generator@ca.example.net>\r\n" +
+		"To: <foo@example.com>\r\n" +
+		"Subject: =?utf-8?q?ACME:_LgYemJLy3F1LDkiJrdIGbEzyFJyOyf6vBdyZ1TG3sME=3D?=\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"This is an automatically generated ACME challenge.\r\n"
+
+	msg, err := ParseMessage(strings.NewReader(raw))
+	require.NoError(t, err)
+
+	expected := &Message{
+		From:      "acme-generator@ca.example.net",
+		To:        "foo@example.com",
+		Subject:   "ACME: LgYemJLy3F1LDkiJrdIGbEzyFJyOyf6vBdyZ1TG3sME=",
+		MessageID: "<A2299BB.FF7788@ca.example.net>",
+		Body:      "This is an automatically generated ACME challenge.\r\n",
+	}
+
+	assert.Equal(t, expected, msg)
+}
+
+func TestMessage_Bytes(t *testing.T) {
+	msg := &Message{
+		From:      "foo@example.com",
+		To:        "acme-generator@ca.example.net",
+		Subject:   "Re: ACME: abc",
+		InReplyTo: "<A2299BB.FF7788@ca.example.net>",
+		Body:      "-----BEGIN ACME RESPONSE-----\r\nxyz\r\n-----END ACME RESPONSE-----\r\n",
+	}
+
+	expected := "From: foo@example.com\r\n" +
+		"To: acme-generator@ca.example.net\r\n" +
+		"Subject: Re: ACME: abc\r\n" +
+		"In-Reply-To: <A2299BB.FF7788@ca.example.net>\r\n" +
+		"References: <A2299BB.FF7788@ca.example.net>\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=\"utf-8\"\r\n" +
+		"\r\n" +
+		"-----BEGIN ACME RESPONSE-----\r\nxyz\r\n-----END ACME RESPONSE-----\r\n"
+
+	assert.Equal(t, expected, string(msg.Bytes()))
+
+	parsed, err := ParseMessage(strings.NewReader(expected))
+	require.NoError(t, err)
+
+	assert.Equal(t, msg, parsed)
+}
@@ -0,0 +1,57 @@
+package emailreply00
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ProviderManual is an implementation of the Provider interface:
+// the challenge email is read, and the response email is sent, by the user.
+type ProviderManual struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewProviderManual returns a ProviderManual instance.
+func NewProviderManual() *ProviderManual {
+	return &ProviderManual{
+		in:  bufio.NewReader(os.Stdin),
+		out: os.Stdout,
+	}
+}
+
+// Receive asks for the subject of the challenge email.
+// The subject can be pasted with or without the "ACME:" prefix.
+func (p *ProviderManual) Receive(_ context.Context, email, from string) (*Message, error) {
+	_, _ = fmt.Fprintf(p.out, "lego: Please paste the subject of the email sent by %s to %s (ACME: <token>):\n", from, email)
+
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return nil, fmt.Errorf("manual: %w", err)
+	}
+
+	subject := strings.TrimSpace(line)
+	if !strings.HasPrefix(subject, subjectPrefix) {
+		subject = subjectPrefix + " " + subject
+	}
+
+	return &Message{From: from, To: email, Subject: subject}, nil
+}
+
+// Reply prints the response email and waits for the user to send it.
+func (p *ProviderManual) Reply(_ context.Context, response *Message) error {
+	_, _ = fmt.Fprintf(p.out, "lego: Please reply from %s to %s with the subject %q and the following body:\n", response.From, response.To, response.Subject)
+	_, _ = fmt.Fprint(p.out, strings.ReplaceAll(response.Body, "\r\n", "\n"))
+	_, _ = fmt.Fprintln(p.out, "lego: Press 'Enter' when you are done")
+
+	_, err := p.in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("manual: %w", err)
+	}
+
+	return nil
+}
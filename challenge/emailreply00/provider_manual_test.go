@@ -0,0 +1,45 @@
+package emailreply00
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderManual(t *testing.T) {
+	out := new(bytes.Buffer)
+
+	provider := &ProviderManual{
+		in:  bufio.NewReader(strings.NewReader("ACME: part1\n\nabc\n")),
+		out: out,
+	}
+
+	msg, err := provider.Receive(t.Context(), "foo@example.com", "acme@ca.example.net")
+	require.NoError(t, err)
+
+	assert.Equal(t, &Message{From: "acme@ca.example.net", To: "foo@example.com", Subject: "ACME: part1"}, msg)
+
+	err = provider.Reply(t.Context(), NewResponse(msg, "foo@example.com", "acme@ca.example.net", "part1part2.thumbprint"))
+	require.NoError(t, err)
+
+	// without the prefix.
+	msg, err = provider.Receive(t.Context(), "foo@example.com", "acme@ca.example.net")
+	require.NoError(t, err)
+
+	assert.Equal(t, "ACME: abc", msg.Subject)
+
+	expected := `lego: Please paste the subject of the email sent by acme@ca.example.net to foo@example.com (ACME: <token>):
+lego: Please reply from foo@example.com to acme@ca.example.net with the subject "Re: ACME: part1" and the following body:
+-----BEGIN ACME RESPONSE-----
+19gaND1fEJSfxWnkQqiJp4-tQIMWBJ0Ku-b0o3uP5DY
+-----END ACME RESPONSE-----
+lego: Press 'Enter' when you are done
+lego: Please paste the subject of the email sent by acme@ca.example.net to foo@example.com (ACME: <token>):
+`
+
+	assert.Equal(t, expected, out.String())
+}
@@ -1,17 +1,35 @@
 package http01
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/challenge"
-	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/challenge/selfcheck"
+	"github.com/go-acme/lego/v4/internal/faults"
+	"github.com/go-acme/lego/v4/platform/wait"
+	"github.com/go-acme/lego/v4/tracing"
 )
 
 type ValidateFunc func(core *api.Core, domain string, chlng acme.Challenge) error
 
+// ValidateContextFunc is like [ValidateFunc] with a context.
+type ValidateContextFunc func(ctx context.Context, core *api.Core, domain string, chlng acme.Challenge) error
+
+// validateContext adapts a ValidateFunc to a ValidateContextFunc.
+func validateContext(validate ValidateFunc) ValidateContextFunc {
+	if validate == nil {
+		return nil
+	}
+
+	return func(_ context.Context, core *api.Core, domain string, chlng acme.Challenge) error {
+		return validate(core, domain, chlng)
+	}
+}
+
 type ChallengeOption func(*Challenge) error
 
 // SetDelay sets a delay between the start of the HTTP server and the challenge validation.
@@ -29,12 +47,19 @@ func ChallengePath(token string) string {
 
 type Challenge struct {
 	core     *api.Core
-	validate ValidateFunc
+	validate ValidateContextFunc
 	provider challenge.Provider
 	delay    time.Duration
+
+	selfCheck *selfcheck.Config
 }
 
 func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
+	return NewChallengeContext(core, validateContext(validate), provider, opts...)
+}
+
+// NewChallengeContext is like [NewChallenge] with a validation function using a context.
+func NewChallengeContext(core *api.Core, validate ValidateContextFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
 	chlg := &Challenge{
 		core:     core,
 		validate: validate,
@@ -44,7 +69,7 @@ func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Prov
 	for _, opt := range opts {
 		err := opt(chlg)
 		if err != nil {
-			log.Infof("challenge option error: %v", err)
+			chlg.core.Log().Infof("challenge option error: %v", err)
 		}
 	}
 
@@ -55,9 +80,24 @@ func (c *Challenge) SetProvider(provider challenge.Provider) {
 	c.provider = provider
 }
 
+// Sequential returns true if the challenges must be solved one by one (ex: ProviderServer listens on a single port).
+func (c *Challenge) Sequential() (bool, time.Duration) {
+	if p, ok := c.provider.(interface{ Sequential() time.Duration }); ok {
+		return true, p.Sequential()
+	}
+
+	return false, 0
+}
+
 func (c *Challenge) Solve(authz acme.Authorization) error {
+	return c.SolveContext(context.Background(), authz)
+}
+
+// SolveContext is like [Challenge.Solve] with a context.
+func (c *Challenge) SolveContext(ctx context.Context, authz acme.Authorization) error {
 	domain := challenge.GetTargetedDomain(authz)
-	log.Infof("[%s] acme: Trying to solve HTTP-01", domain)
+	logger := c.core.Log().With("domain", domain, "challenge", challenge.HTTP01)
+	logger.Infof("[%s] acme: Trying to solve HTTP-01", domain)
 
 	chlng, err := challenge.FindChallenge(challenge.HTTP01, authz)
 	if err != nil {
@@ -70,23 +110,46 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 		return err
 	}
 
-	err = c.provider.Present(authz.Identifier.Value, chlng.Token, keyAuth)
+	_, span := c.core.Tracer().Start(ctx, tracing.SpanPresent, tracing.WithChallenge(domain, challenge.HTTP01.String()))
+
+	err = faults.Present(domain)
+	if err == nil {
+		err = c.provider.Present(authz.Identifier.Value, chlng.Token, keyAuth)
+	}
+
+	tracing.End(span, err)
+
 	if err != nil {
 		return fmt.Errorf("[%s] acme: error presenting token: %w", domain, err)
 	}
 
 	defer func() {
+		_, span := c.core.Tracer().Start(ctx, tracing.SpanCleanUp, tracing.WithChallenge(domain, challenge.HTTP01.String()))
+
 		err := c.provider.CleanUp(authz.Identifier.Value, chlng.Token, keyAuth)
+
+		tracing.End(span, err)
+
 		if err != nil {
-			log.Warnf("[%s] acme: cleaning up failed: %v", domain, err)
+			logger.Warnf("[%s] acme: cleaning up failed: %v", domain, err)
 		}
 	}()
 
 	if c.delay > 0 {
-		time.Sleep(c.delay)
+		err = wait.Sleep(ctx, c.delay)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.selfCheck != nil {
+		err = selfCheck(ctx, *c.selfCheck, authz.Identifier.Value, chlng.Token, keyAuth)
+		if err != nil {
+			return fmt.Errorf("[%s] acme: self-check: %w", domain, err)
+		}
 	}
 
 	chlng.KeyAuthorization = keyAuth
 
-	return c.validate(c.core, domain, chlng)
+	return c.validate(ctx, c.core, domain, chlng)
 }
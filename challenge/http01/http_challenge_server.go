@@ -8,6 +8,7 @@ import (
 	"net/textproto"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/go-acme/lego/v4/log"
 )
@@ -67,6 +68,11 @@ func (s *ProviderServer) GetAddress() string {
 	return s.address
 }
 
+// Sequential the server listens on a single address: the challenges are solved one by one.
+func (s *ProviderServer) Sequential() time.Duration {
+	return 0
+}
+
 // CleanUp closes the HTTP server and removes the token from `ChallengePath(token)`.
 func (s *ProviderServer) CleanUp(domain, token, keyAuth string) error {
 	if s.listener == nil {
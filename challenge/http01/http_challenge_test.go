@@ -440,3 +440,47 @@ func testServeWithProxy(t *testing.T, header, extra *testProxyHeader, expectErro
 		require.NoError(t, err)
 	}
 }
+
+type contextKey struct{}
+
+type providerNoop struct{}
+
+func (providerNoop) Present(_, _, _ string) error { return nil }
+func (providerNoop) CleanUp(_, _, _ string) error { return nil }
+
+func TestNewChallengeContext(t *testing.T) {
+	server := tester.MockACMEServer().BuildHTTPS(t)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	var validated bool
+
+	validate := func(ctx context.Context, _ *api.Core, _ string, _ acme.Challenge) error {
+		// The context of SolveContext is given to the validation.
+		assert.Equal(t, "lego", ctx.Value(contextKey{}))
+
+		validated = true
+
+		return nil
+	}
+
+	solver := NewChallengeContext(core, validate, providerNoop{})
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "localhost",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.HTTP01.String(), Token: "context1"},
+		},
+	}
+
+	err = solver.SolveContext(context.WithValue(t.Context(), contextKey{}, "lego"), authz)
+	require.NoError(t, err)
+
+	assert.True(t, validated)
+}
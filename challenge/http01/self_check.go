@@ -0,0 +1,99 @@
+package http01
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/go-acme/lego/v4/challenge/selfcheck"
+)
+
+// SetSelfCheck enables the self-check: before the validation by the CA,
+// the challenge is fetched from the domain (through the proxy of the configuration, if any).
+func SetSelfCheck(config selfcheck.Config) ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.selfCheck = &config
+		return nil
+	}
+}
+
+func selfCheck(ctx context.Context, config selfcheck.Config, domain, token, keyAuth string) error {
+	host := domain
+	if ip := net.ParseIP(domain); ip != nil && ip.To4() == nil {
+		host = "[" + domain + "]"
+	}
+
+	endpoint := "http://" + host + ChallengePath(token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := config.HTTPClient().Do(req)
+	if err != nil {
+		return selfcheck.Diagnose(endpoint, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	target := endpoint
+	if resp.Request.URL.String() != endpoint {
+		target += " -> " + resp.Request.URL.String()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return &selfcheck.Error{
+			Kind:    selfcheck.KindStatus,
+			Target:  target,
+			Message: fmt.Sprintf("unexpected status code: %d", resp.StatusCode),
+			Hint:    statusHint(resp.StatusCode),
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return selfcheck.Diagnose(target, err)
+	}
+
+	content := strings.TrimSpace(string(body))
+	if content == keyAuth {
+		return nil
+	}
+
+	scErr := &selfcheck.Error{
+		Kind:    selfcheck.KindContent,
+		Target:  target,
+		Message: fmt.Sprintf("the content is not the key authorization: got %q", excerpt(content)),
+	}
+
+	if token, _, ok := strings.Cut(keyAuth, "."); ok && strings.HasPrefix(content, token+".") {
+		scErr.Hint = "the key authorization of another account: check the account thumbprint of the server configuration"
+	}
+
+	return scErr
+}
+
+func statusHint(code int) string {
+	switch {
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return "the access to " + ChallengePath("") + " is denied: check the authentication and the access rules of the server"
+	case code >= http.StatusInternalServerError:
+		return "the server fails: check its logs"
+	default:
+		return ""
+	}
+}
+
+func excerpt(content string) string {
+	const maxLen = 64
+
+	if len(content) <= maxLen {
+		return content
+	}
+
+	return content[:maxLen] + "..."
+}
@@ -0,0 +1,104 @@
+package http01
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-acme/lego/v4/challenge/selfcheck"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_selfCheck(t *testing.T) {
+	// The proxy receives the requests to the domains.
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Host != "example.com" {
+			http.Error(rw, "unexpected host", http.StatusBadGateway)
+			return
+		}
+
+		switch req.URL.Path {
+		case ChallengePath("good"):
+			_, _ = rw.Write([]byte("good.keyauth\n"))
+		case ChallengePath("wrong"):
+			_, _ = rw.Write([]byte("<html>default vhost</html>"))
+		case ChallengePath("account"):
+			_, _ = rw.Write([]byte("account.other"))
+		case ChallengePath("denied"):
+			http.Error(rw, "denied", http.StatusForbidden)
+		case ChallengePath("loop"):
+			http.Redirect(rw, req, "http://example.com/loop", http.StatusFound)
+		case "/loop":
+			http.Redirect(rw, req, ChallengePath("loop"), http.StatusFound)
+		case ChallengePath("port"):
+			http.Redirect(rw, req, "http://example.com:8080/", http.StatusFound)
+		default:
+			http.NotFound(rw, req)
+		}
+	}))
+	t.Cleanup(proxyServer.Close)
+
+	proxyURL, err := url.Parse(proxyServer.URL)
+	require.NoError(t, err)
+
+	config := selfcheck.Config{Proxy: proxyURL}
+
+	testCases := []struct {
+		desc     string
+		token    string
+		expected string
+	}{
+		{
+			desc:  "success",
+			token: "good",
+		},
+		{
+			desc:     "wrong content",
+			token:    "wrong",
+			expected: `http://example.com/.well-known/acme-challenge/wrong: the content is not the key authorization: got "<html>default vhost</html>" (another server or virtual host answers for the domain)`,
+		},
+		{
+			desc:     "another account",
+			token:    "account",
+			expected: `http://example.com/.well-known/acme-challenge/account: the content is not the key authorization: got "account.other" (the key authorization of another account: check the account thumbprint of the server configuration)`,
+		},
+		{
+			desc:     "not found",
+			token:    "missing",
+			expected: "http://example.com/.well-known/acme-challenge/missing: unexpected status code: 404 (the challenge is not served: check the virtual host, the webroot, or the rewrite rules)",
+		},
+		{
+			desc:     "denied",
+			token:    "denied",
+			expected: "http://example.com/.well-known/acme-challenge/denied: unexpected status code: 403 (the access to /.well-known/acme-challenge/ is denied: check the authentication and the access rules of the server)",
+		},
+		{
+			desc:  "redirect loop",
+			token: "loop",
+			expected: "http://example.com/.well-known/acme-challenge/loop: redirect loop: " +
+				"http://example.com/.well-known/acme-challenge/loop -> http://example.com/loop -> http://example.com/.well-known/acme-challenge/loop" +
+				" (check the redirection rules of the server)",
+		},
+		{
+			desc:  "redirect to another port",
+			token: "port",
+			expected: "http://example.com/.well-known/acme-challenge/port: redirect to the port 8080: " +
+				"http://example.com/.well-known/acme-challenge/port -> http://example.com:8080/" +
+				" (the CA only follows the redirects to the ports 80 and 443)",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := selfCheck(t.Context(), config, "example.com", test.token, test.token+".keyauth")
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
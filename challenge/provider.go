@@ -1,6 +1,9 @@
 package challenge
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // Provider enables implementing a custom challenge
 // provider. Present presents the solution to a challenge available to
@@ -26,3 +29,12 @@ type ProviderTimeout interface {
 	Provider
 	Timeout() (timeout, interval time.Duration)
 }
+
+// ProviderContext allows for implementing a Provider whose calls can be canceled.
+// If an implementor of a Provider provides the PresentContext and CleanUpContext methods,
+// they are used instead of Present and CleanUp by the DNS-01 challenge and the provider wrappers (ex: dns01.WithTimeout).
+type ProviderContext interface {
+	Provider
+	PresentContext(ctx context.Context, domain, token, keyAuth string) error
+	CleanUpContext(ctx context.Context, domain, token, keyAuth string) error
+}
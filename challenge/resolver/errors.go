@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"maps"
 	"slices"
-	"sort"
 )
 
 // obtainError is returned when there are specific errors available per domain.
@@ -14,20 +13,18 @@ type obtainError map[string]error
 func (e obtainError) Error() string {
 	buffer := bytes.NewBufferString("error: one or more domains had a problem:\n")
 
-	var domains []string
-	for domain := range e {
-		domains = append(domains, domain)
-	}
-
-	sort.Strings(domains)
-
-	for _, domain := range domains {
+	for _, domain := range e.Domains() {
 		_, _ = fmt.Fprintf(buffer, "[%s] %s\n", domain, e[domain])
 	}
 
 	return buffer.String()
 }
 
+// Domains returns the sorted list of the domains with an error.
+func (e obtainError) Domains() []string {
+	return slices.Sorted(maps.Keys(e))
+}
+
 func (e obtainError) Unwrap() []error {
 	return slices.AppendSeq(make([]error, 0, len(e)), maps.Values(e))
 }
@@ -23,6 +23,16 @@ func Test_obtainError_Error(t *testing.T) {
 `)
 }
 
+func Test_obtainError_Domains(t *testing.T) {
+	err := obtainError{
+		"c.example.com": errors.New("oops"),
+		"*.example.com": errors.New("oops"),
+		"a.example.com": errors.New("oops"),
+	}
+
+	assert.Equal(t, []string{"*.example.com", "a.example.com", "c.example.com"}, err.Domains())
+}
+
 func Test_obtainError_Unwrap(t *testing.T) {
 	testCases := []struct {
 		desc   string
@@ -1,28 +1,35 @@
 package resolver
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/metrics"
+	"github.com/go-acme/lego/v4/platform/wait"
+	"github.com/go-acme/lego/v4/tracing"
 )
 
 // Interface for all challenge solvers to implement.
 type solver interface {
-	Solve(authorization acme.Authorization) error
+	SolveContext(ctx context.Context, authorization acme.Authorization) error
 }
 
 // Interface for challenges like dns, where we can set a record in advance for ALL challenges.
 // This saves quite a bit of time vs creating the records and solving them serially.
 type preSolver interface {
-	PreSolve(authorization acme.Authorization) error
+	PreSolveContext(ctx context.Context, authorization acme.Authorization) error
 }
 
 // Interface for challenges like dns, where we can solve all the challenges before to delete them.
 type cleanup interface {
-	CleanUp(authorization acme.Authorization) error
+	CleanUpContext(ctx context.Context, authorization acme.Authorization) error
 }
 
 type sequential interface {
@@ -31,12 +38,19 @@ type sequential interface {
 
 // an authz with the solver we have chosen and the index of the challenge associated with it.
 type selectedAuthSolver struct {
-	authz  acme.Authorization
-	solver solver
+	authz    acme.Authorization
+	solver   solver
+	chlgType challenge.Type
+}
+
+// event returns the event of the challenge.
+func (a *selectedAuthSolver) event(err error) certificate.ChallengeEvent {
+	return certificate.ChallengeEvent{Domain: challenge.GetTargetedDomain(a.authz), Type: a.chlgType, Err: err}
 }
 
 type Prober struct {
 	solverManager *SolverManager
+	events        certificate.Events
 }
 
 func NewProber(solverManager *SolverManager) *Prober {
@@ -46,8 +60,17 @@ func NewProber(solverManager *SolverManager) *Prober {
 }
 
 // Solve Looks through the challenge combinations to find a solvable match.
-// Then solves the challenges in series and returns.
-func (p *Prober) Solve(authorizations []acme.Authorization) error {
+// Then solves the challenges (at most SolverManager.SetConcurrency at the same time,
+// the challenges of the sequential providers are solved in series) and returns.
+func (p *Prober) Solve(authorizations []acme.Authorization) (err error) {
+	return p.SolveContext(context.Background(), authorizations)
+}
+
+// SolveContext is like [Prober.Solve] with a context.
+func (p *Prober) SolveContext(ctx context.Context, authorizations []acme.Authorization) (err error) {
+	ctx, span := p.solverManager.core.Tracer().Start(ctx, tracing.SpanSolve)
+	defer func() { tracing.End(span, err) }()
+
 	failures := make(obtainError)
 
 	var (
@@ -62,12 +85,12 @@ func (p *Prober) Solve(authorizations []acme.Authorization) error {
 		domain := challenge.GetTargetedDomain(authz)
 		if authz.Status == acme.StatusValid {
 			// Boulder might recycle recent validated authz (see issue #267)
-			log.Infof("[%s] acme: authorization already valid; skipping challenge", domain)
+			p.log().Infof("[%s] acme: authorization already valid; skipping challenge", domain)
 			continue
 		}
 
-		if solvr := p.solverManager.chooseSolver(authz); solvr != nil {
-			authSolver := &selectedAuthSolver{authz: authz, solver: solvr}
+		if solvr, chlgType := p.solverManager.chooseSolver(authz); solvr != nil {
+			authSolver := &selectedAuthSolver{authz: authz, solver: solvr, chlgType: chlgType}
 
 			switch s := solvr.(type) {
 			case sequential:
@@ -84,9 +107,15 @@ func (p *Prober) Solve(authorizations []acme.Authorization) error {
 		}
 	}
 
-	parallelSolve(authSolvers, failures)
+	p.parallelSolve(ctx, authSolvers, failures)
+
+	p.sequentialSolve(ctx, authSolversSequential, failures)
 
-	sequentialSolve(authSolversSequential, failures)
+	for _, authSolver := range slices.Concat(authSolvers, authSolversSequential) {
+		err := failures[challenge.GetTargetedDomain(authSolver.authz)]
+
+		p.solverManager.core.Metrics().ObserveChallenge(authSolver.chlgType.String(), metrics.Result(err))
+	}
 
 	// Be careful not to return an empty failures map,
 	// for even an empty obtainError is a non-nil error value
@@ -97,7 +126,7 @@ func (p *Prober) Solve(authorizations []acme.Authorization) error {
 	return nil
 }
 
-func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
+func (p *Prober) sequentialSolve(ctx context.Context, authSolvers []*selectedAuthSolver, failures obtainError) {
 	// Some CA are using the same token,
 	// this can be a problem with the DNS01 challenge when the DNS provider doesn't support duplicate TXT records.
 	// In the sequential mode, this is not a problem because we can solve the challenges in order.
@@ -112,15 +141,15 @@ func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 
 		if solvr, ok := authSolver.solver.(preSolver); ok {
 			if _, ok := uniq[authSolver.authz.Identifier.Value+chlg.Token]; ok && chlg.Token != "" {
-				log.Infof("acme: duplicate token for %q (DNS-01); skipping pre-solve.", authSolver.authz.Identifier.Value)
+				p.log().Infof("acme: duplicate token for %q (DNS-01); skipping pre-solve.", authSolver.authz.Identifier.Value)
 				continue
 			}
 
-			err := solvr.PreSolve(authSolver.authz)
+			err := p.preSolve(ctx, authSolver, solvr)
 			if err != nil {
 				failures[domain] = err
 
-				cleanUp(authSolver.solver, authSolver.authz)
+				p.cleanUp(ctx, authSolver)
 
 				continue
 			}
@@ -129,59 +158,73 @@ func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 		}
 
 		// Solve challenge
-		err := authSolver.solver.Solve(authSolver.authz)
+		err := p.solve(ctx, authSolver)
 		if err != nil {
 			failures[domain] = err
 
-			cleanUp(authSolver.solver, authSolver.authz)
+			p.cleanUp(ctx, authSolver)
 
 			continue
 		}
 
 		if _, ok := uniq[authSolver.authz.Identifier.Value+chlg.Token]; ok || chlg.Token == "" {
 			// Clean challenge
-			cleanUp(authSolver.solver, authSolver.authz)
+			p.cleanUp(ctx, authSolver)
 
-			if len(authSolvers)-1 > i {
-				solvr := authSolver.solver.(sequential)
-				_, interval := solvr.Sequential()
-				log.Infof("sequence: wait for %s", interval)
-				time.Sleep(interval)
+			solvr := authSolver.solver.(sequential)
+			if _, interval := solvr.Sequential(); len(authSolvers)-1 > i && interval > 0 {
+				p.log().Infof("sequence: wait for %s", interval)
+
+				err = wait.Sleep(ctx, interval)
+				if err != nil {
+					failures[domain] = err
+					return
+				}
 			}
 
 			delete(uniq, authSolver.authz.Identifier.Value+chlg.Token)
 		} else {
-			log.Infof("acme: duplicate token for %q (DNS-01); skipping cleanup.", authSolver.authz.Identifier.Value)
+			p.log().Infof("acme: duplicate token for %q (DNS-01); skipping cleanup.", authSolver.authz.Identifier.Value)
 		}
 	}
 }
 
-func parallelSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
+func (p *Prober) parallelSolve(ctx context.Context, authSolvers []*selectedAuthSolver, failures obtainError) {
 	// Some CA are using the same token,
 	// this can be a problem with the DNS01 challenge when the DNS provider doesn't support duplicate TXT records.
 	uniq := make(map[string]struct{})
 
-	// For all valid preSolvers, first submit the challenges, so they have max time to propagate
+	var preSolvers []*selectedAuthSolver
+
 	for _, authSolver := range authSolvers {
 		authz := authSolver.authz
 
 		chlg, err := challenge.FindChallenge(challenge.DNS01, authz)
 		if err == nil {
 			if _, ok := uniq[authz.Identifier.Value+chlg.Token]; ok {
-				log.Infof("acme: duplicate token for %q (DNS-01); skipping pre-solve.", authSolver.authz.Identifier.Value)
+				p.log().Infof("acme: duplicate token for %q (DNS-01); skipping pre-solve.", authSolver.authz.Identifier.Value)
 				continue
 			}
 
 			uniq[authz.Identifier.Value+chlg.Token] = struct{}{}
 		}
 
+		preSolvers = append(preSolvers, authSolver)
+	}
+
+	var mu sync.Mutex
+
+	// For all valid preSolvers, first submit the challenges, so they have max time to propagate
+	p.forEach(preSolvers, func(authSolver *selectedAuthSolver) {
 		if solvr, ok := authSolver.solver.(preSolver); ok {
-			err := solvr.PreSolve(authz)
+			err := p.preSolve(ctx, authSolver, solvr)
 			if err != nil {
-				failures[challenge.GetTargetedDomain(authz)] = err
+				mu.Lock()
+				failures[challenge.GetTargetedDomain(authSolver.authz)] = err
+				mu.Unlock()
 			}
 		}
-	}
+	})
 
 	defer func() {
 		// Clean all created TXT records
@@ -191,39 +234,123 @@ func parallelSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 				if _, ok := uniq[authSolver.authz.Identifier.Value+chlg.Token]; ok {
 					delete(uniq, authSolver.authz.Identifier.Value+chlg.Token)
 				} else {
-					log.Infof("acme: duplicate token for %q (DNS-01); skipping cleanup.", authSolver.authz.Identifier.Value)
+					p.log().Infof("acme: duplicate token for %q (DNS-01); skipping cleanup.", authSolver.authz.Identifier.Value)
 					continue
 				}
 			}
 
-			cleanUp(authSolver.solver, authSolver.authz)
+			p.cleanUp(ctx, authSolver)
 		}
 	}()
 
-	// Finally solve all challenges for real
-	for _, authSolver := range authSolvers {
-		authz := authSolver.authz
+	var solvers []*selectedAuthSolver
 
-		domain := challenge.GetTargetedDomain(authz)
-		if failures[domain] != nil {
+	for _, authSolver := range authSolvers {
+		if failures[challenge.GetTargetedDomain(authSolver.authz)] != nil {
 			// already failed in previous loop
 			continue
 		}
 
-		err := authSolver.solver.Solve(authz)
+		solvers = append(solvers, authSolver)
+	}
+
+	// Finally solve all challenges for real
+	p.forEach(solvers, func(authSolver *selectedAuthSolver) {
+		err := p.solve(ctx, authSolver)
 		if err != nil {
-			failures[domain] = err
+			mu.Lock()
+			failures[challenge.GetTargetedDomain(authSolver.authz)] = err
+			mu.Unlock()
+		}
+	})
+}
+
+// forEach calls fn for each authSolver, with at most SolverManager.concurrency calls at the same time.
+func (p *Prober) forEach(authSolvers []*selectedAuthSolver, fn func(authSolver *selectedAuthSolver)) {
+	concurrency := p.solverManager.concurrency
+
+	if concurrency <= 1 {
+		for _, authSolver := range authSolvers {
+			fn(authSolver)
 		}
+
+		return
 	}
+
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+
+	for _, authSolver := range authSolvers {
+		sem <- struct{}{}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			fn(authSolver)
+		}()
+	}
+
+	wg.Wait()
 }
 
-func cleanUp(solvr solver, authz acme.Authorization) {
-	if solvr, ok := solvr.(cleanup); ok {
-		domain := challenge.GetTargetedDomain(authz)
+// SetEvents sets the receiver of the events of the challenges.
+func (p *Prober) SetEvents(events certificate.Events) {
+	p.events = events
+}
+
+func (p *Prober) log() log.Printer {
+	return p.solverManager.core.Log()
+}
+
+// preSolve presents the challenge (ex: creates the DNS record).
+func (p *Prober) preSolve(ctx context.Context, authSolver *selectedAuthSolver, solvr preSolver) error {
+	err := solvr.PreSolveContext(ctx, authSolver.authz)
+	if err != nil {
+		certificate.EventsOrNoop(p.events).ChallengeFailed(ctx, authSolver.event(err))
+		return err
+	}
+
+	certificate.EventsOrNoop(p.events).ChallengePresented(ctx, authSolver.event(nil))
 
-		err := solvr.CleanUp(authz)
+	return nil
+}
+
+// solve validates the challenge.
+// The challenges without a preparation step (ex: HTTP-01) are presented during the validation.
+func (p *Prober) solve(ctx context.Context, authSolver *selectedAuthSolver) error {
+	events := certificate.EventsOrNoop(p.events)
+
+	if _, ok := authSolver.solver.(preSolver); !ok {
+		events.ChallengePresented(ctx, authSolver.event(nil))
+	}
+
+	err := authSolver.solver.SolveContext(ctx, authSolver.authz)
+	if err != nil {
+		events.ChallengeFailed(ctx, authSolver.event(err))
+		return err
+	}
+
+	events.ChallengeValidated(ctx, authSolver.event(nil))
+
+	return nil
+}
+
+// cleanUp cleans the challenge, even if the context is canceled.
+func (p *Prober) cleanUp(ctx context.Context, authSolver *selectedAuthSolver) {
+	if solvr, ok := authSolver.solver.(cleanup); ok {
+		domain := challenge.GetTargetedDomain(authSolver.authz)
+
+		err := solvr.CleanUpContext(context.WithoutCancel(ctx), authSolver.authz)
 		if err != nil {
-			log.Warnf("[%s] acme: cleaning up failed: %v ", domain, err)
+			p.log().Warnf("[%s] acme: cleaning up failed: %v ", domain, err)
+
+			certificate.EventsOrNoop(p.events).CleanupFailed(ctx, authSolver.event(err))
 		}
 	}
 }
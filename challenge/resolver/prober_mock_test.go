@@ -1,10 +1,13 @@
 package resolver
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/challenge"
 )
 
@@ -18,19 +21,19 @@ type preSolverMock struct {
 	cleanUpCounter  int
 }
 
-func (s *preSolverMock) PreSolve(authorization acme.Authorization) error {
+func (s *preSolverMock) PreSolveContext(_ context.Context, authorization acme.Authorization) error {
 	s.preSolveCounter++
 
 	return s.preSolve[authorization.Identifier.Value]
 }
 
-func (s *preSolverMock) Solve(authorization acme.Authorization) error {
+func (s *preSolverMock) SolveContext(_ context.Context, authorization acme.Authorization) error {
 	s.solveCounter++
 
 	return s.solve[authorization.Identifier.Value]
 }
 
-func (s *preSolverMock) CleanUp(authorization acme.Authorization) error {
+func (s *preSolverMock) CleanUpContext(_ context.Context, authorization acme.Authorization) error {
 	s.cleanUpCounter++
 
 	return s.cleanUp[authorization.Identifier.Value]
@@ -40,6 +43,50 @@ func (s *preSolverMock) String() string {
 	return fmt.Sprintf("PreSolve: %d, Solve: %d, CleanUp: %d", s.preSolveCounter, s.solveCounter, s.cleanUpCounter)
 }
 
+// concurrencyMock records the maximum number of concurrent calls to PreSolve and Solve.
+type concurrencyMock struct {
+	delay time.Duration
+
+	mu          sync.Mutex
+	current     int
+	maxPreSolve int
+	maxSolve    int
+}
+
+func (s *concurrencyMock) PreSolveContext(_ context.Context, _ acme.Authorization) error {
+	s.track(&s.maxPreSolve)
+
+	return nil
+}
+
+func (s *concurrencyMock) SolveContext(_ context.Context, _ acme.Authorization) error {
+	s.track(&s.maxSolve)
+
+	return nil
+}
+
+func (s *concurrencyMock) track(maximum *int) {
+	s.mu.Lock()
+	s.current++
+	*maximum = max(*maximum, s.current)
+	s.mu.Unlock()
+
+	time.Sleep(s.delay)
+
+	s.mu.Lock()
+	s.current--
+	s.mu.Unlock()
+}
+
+// sequentialConcurrencyMock is a concurrencyMock for the providers that require sequential solving.
+type sequentialConcurrencyMock struct {
+	*concurrencyMock
+}
+
+func (s *sequentialConcurrencyMock) Sequential() (bool, time.Duration) {
+	return true, 0
+}
+
 func createStubAuthorizationHTTP01(domain, status string) acme.Authorization {
 	return createStubAuthorization(domain, status, false, acme.Challenge{
 		Type:      challenge.HTTP01.String(),
@@ -77,3 +124,39 @@ func createStubAuthorization(domain, status string, wildcard bool, chlgs ...acme
 		Challenges: chlgs,
 	}
 }
+
+// eventsMock records the events of the challenges.
+type eventsMock struct {
+	certificate.NoopEvents
+
+	mu     sync.Mutex
+	events []string
+}
+
+func (e *eventsMock) ChallengePresented(_ context.Context, event certificate.ChallengeEvent) {
+	e.record("presented", event)
+}
+
+func (e *eventsMock) ChallengeValidated(_ context.Context, event certificate.ChallengeEvent) {
+	e.record("validated", event)
+}
+
+func (e *eventsMock) ChallengeFailed(_ context.Context, event certificate.ChallengeEvent) {
+	e.record("failed", event)
+}
+
+func (e *eventsMock) CleanupFailed(_ context.Context, event certificate.ChallengeEvent) {
+	e.record("cleanup failed", event)
+}
+
+func (e *eventsMock) record(name string, event certificate.ChallengeEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	msg := fmt.Sprintf("%s: %s (%s)", name, event.Domain, event.Type)
+	if event.Err != nil {
+		msg += ": " + event.Err.Error()
+	}
+
+	e.events = append(e.events, msg)
+}
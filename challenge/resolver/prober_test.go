@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/challenge"
@@ -155,3 +156,102 @@ func TestProber_Solve(t *testing.T) {
 		})
 	}
 }
+
+func TestProber_Solve_concurrency(t *testing.T) {
+	authz := []acme.Authorization{
+		createStubAuthorizationDNS01("a.example", false),
+		createStubAuthorizationDNS01("b.example", false),
+		createStubAuthorizationDNS01("c.example", false),
+		createStubAuthorizationDNS01("d.example", false),
+		createStubAuthorizationDNS01("e.example", false),
+		createStubAuthorizationDNS01("f.example", false),
+	}
+
+	testCases := []struct {
+		desc        string
+		concurrency int
+		sequential  bool
+		expected    int
+	}{
+		{
+			desc:     "default",
+			expected: 1,
+		},
+		{
+			desc:        "concurrency",
+			concurrency: 3,
+			expected:    3,
+		},
+		{
+			desc:        "sequential provider",
+			concurrency: 3,
+			sequential:  true,
+			expected:    1,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			mock := &concurrencyMock{delay: 50 * time.Millisecond}
+
+			var solvr solver = mock
+			if test.sequential {
+				solvr = &sequentialConcurrencyMock{concurrencyMock: mock}
+			}
+
+			prober := &Prober{
+				solverManager: &SolverManager{
+					solvers:     map[challenge.Type]solver{challenge.DNS01: solvr},
+					concurrency: test.concurrency,
+				},
+			}
+
+			err := prober.SolveContext(t.Context(), authz)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, mock.maxPreSolve)
+			assert.Equal(t, test.expected, mock.maxSolve)
+		})
+	}
+}
+
+func TestProber_Solve_events(t *testing.T) {
+	events := &eventsMock{}
+
+	prober := &Prober{
+		solverManager: &SolverManager{solvers: map[challenge.Type]solver{
+			challenge.HTTP01: &preSolverMock{
+				preSolve: map[string]error{
+					"example.com": errors.New("preSolve error example.com"),
+				},
+				solve: map[string]error{
+					"example.org": errors.New("solve error example.org"),
+				},
+				cleanUp: map[string]error{
+					"example.net": errors.New("clean error example.net"),
+				},
+			},
+		}},
+		events: events,
+	}
+
+	err := prober.SolveContext(t.Context(), []acme.Authorization{
+		createStubAuthorizationHTTP01("example.com", acme.StatusProcessing),
+		createStubAuthorizationHTTP01("example.org", acme.StatusProcessing),
+		createStubAuthorizationHTTP01("example.net", acme.StatusProcessing),
+	})
+	require.Error(t, err)
+
+	expected := []string{
+		"failed: example.com (http-01): preSolve error example.com",
+		"presented: example.org (http-01)",
+		"presented: example.net (http-01)",
+		"failed: example.org (http-01): solve error example.org",
+		"validated: example.net (http-01)",
+		"cleanup failed: example.net (http-01): clean error example.net",
+	}
+
+	assert.Equal(t, expected, events.events)
+}
@@ -4,20 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v5"
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/deviceattest01"
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/challenge/emailreply00"
 	"github.com/go-acme/lego/v4/challenge/http01"
 	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
-	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/internal/faults"
 	"github.com/go-acme/lego/v4/platform/wait"
 )
 
+// ipChallenges the challenge types allowed for the IP identifiers.
+// https://www.rfc-editor.org/rfc/rfc8738.html#section-7
+var ipChallenges = []challenge.Type{challenge.HTTP01, challenge.TLSALPN01}
+
 type byType []acme.Challenge
 
 func (a byType) Len() int           { return len(a) }
@@ -27,6 +35,24 @@ func (a byType) Less(i, j int) bool { return a[i].Type > a[j].Type }
 type SolverManager struct {
 	core    *api.Core
 	solvers map[challenge.Type]solver
+
+	// solvers to use for the domains matching a pattern, by challenge type.
+	routes map[challenge.Type][]route
+
+	// challenge type to use for specific domains.
+	challengeMap map[string]challenge.Type
+
+	// disabled challenge types.
+	disabled map[challenge.Type]struct{}
+
+	// maximum number of authorizations solved at the same time.
+	concurrency int
+}
+
+// route a solver used for the domains matching a pattern.
+type route struct {
+	pattern string
+	solver  solver
 }
 
 func NewSolversManager(core *api.Core) *SolverManager {
@@ -38,47 +64,281 @@ func NewSolversManager(core *api.Core) *SolverManager {
 
 // SetHTTP01Provider specifies a custom provider p that can solve the given HTTP-01 challenge.
 func (c *SolverManager) SetHTTP01Provider(p challenge.Provider, opts ...http01.ChallengeOption) error {
-	c.solvers[challenge.HTTP01] = http01.NewChallenge(c.core, validate, p, opts...)
+	c.solvers[challenge.HTTP01] = http01.NewChallengeContext(c.core, validate, p, opts...)
 	return nil
 }
 
 // SetTLSALPN01Provider specifies a custom provider p that can solve the given TLS-ALPN-01 challenge.
 func (c *SolverManager) SetTLSALPN01Provider(p challenge.Provider, opts ...tlsalpn01.ChallengeOption) error {
-	c.solvers[challenge.TLSALPN01] = tlsalpn01.NewChallenge(c.core, validate, p, opts...)
+	c.solvers[challenge.TLSALPN01] = tlsalpn01.NewChallengeContext(c.core, validate, p, opts...)
 	return nil
 }
 
 // SetDNS01Provider specifies a custom provider p that can solve the given DNS-01 challenge.
 func (c *SolverManager) SetDNS01Provider(p challenge.Provider, opts ...dns01.ChallengeOption) error {
-	c.solvers[challenge.DNS01] = dns01.NewChallenge(c.core, validate, p, opts...)
+	c.solvers[challenge.DNS01] = dns01.NewChallengeContext(c.core, validate, p, opts...)
+	return nil
+}
+
+// SetDNSAccount01Provider specifies a custom provider p that can solve the given DNS-ACCOUNT-01 challenge.
+// The DNS-ACCOUNT-01 challenge is preferred over the DNS-01 challenge when the server offers both.
+func (c *SolverManager) SetDNSAccount01Provider(p challenge.Provider, opts ...dns01.ChallengeOption) error {
+	c.solvers[challenge.DNSAccount01] = dns01.NewAccountChallenge(c.core, validate, p, opts...)
+	return nil
+}
+
+// SetDeviceAttest01Provider specifies a custom attester that can solve the given DEVICE-ATTEST-01 challenge.
+func (c *SolverManager) SetDeviceAttest01Provider(attester deviceattest01.Attester, opts ...deviceattest01.ChallengeOption) error {
+	c.solvers[challenge.DeviceAttest01] = deviceattest01.NewChallenge(c.core, validateWithPayload, attester, opts...)
+	return nil
+}
+
+// SetEmailReply00Provider specifies a custom provider p that can solve the given EMAIL-REPLY-00 challenge (S/MIME certificates).
+func (c *SolverManager) SetEmailReply00Provider(p emailreply00.Provider, opts ...emailreply00.ChallengeOption) error {
+	c.solvers[challenge.EmailReply00] = emailreply00.NewChallenge(c.core, validate, p, opts...)
+	return nil
+}
+
+// SetHTTP01ProviderFor specifies a custom provider p that can solve the HTTP-01 challenge for the domains matching the pattern,
+// instead of the provider defined by SetHTTP01Provider.
+// See SetDNS01ProviderFor for the patterns.
+func (c *SolverManager) SetHTTP01ProviderFor(pattern string, p challenge.Provider, opts ...http01.ChallengeOption) error {
+	return c.setRoute(challenge.HTTP01, pattern, http01.NewChallengeContext(c.core, validate, p, opts...))
+}
+
+// SetTLSALPN01ProviderFor specifies a custom provider p that can solve the TLS-ALPN-01 challenge for the domains matching the pattern,
+// instead of the provider defined by SetTLSALPN01Provider.
+// See SetDNS01ProviderFor for the patterns.
+func (c *SolverManager) SetTLSALPN01ProviderFor(pattern string, p challenge.Provider, opts ...tlsalpn01.ChallengeOption) error {
+	return c.setRoute(challenge.TLSALPN01, pattern, tlsalpn01.NewChallengeContext(c.core, validate, p, opts...))
+}
+
+// SetDNS01ProviderFor specifies a custom provider p that can solve the DNS-01 challenge for the domains matching the pattern,
+// instead of the provider defined by SetDNS01Provider (ex: RFC2136 for the internal zone, and Route53 for the other domains).
+//
+// The pattern is a domain (ex: `example.com`), or a wildcard matching all the subdomains (ex: `*.internal.example.com`).
+// The identifier of a wildcard domain is matched with its wildcard (ex: `*.example.com`).
+// The most specific pattern is used: the domain, then the longest wildcard.
+func (c *SolverManager) SetDNS01ProviderFor(pattern string, p challenge.Provider, opts ...dns01.ChallengeOption) error {
+	return c.setRoute(challenge.DNS01, pattern, dns01.NewChallengeContext(c.core, validate, p, opts...))
+}
+
+// SetDNSAccount01ProviderFor specifies a custom provider p that can solve the DNS-ACCOUNT-01 challenge for the domains matching the pattern,
+// instead of the provider defined by SetDNSAccount01Provider.
+// See SetDNS01ProviderFor for the patterns.
+func (c *SolverManager) SetDNSAccount01ProviderFor(pattern string, p challenge.Provider, opts ...dns01.ChallengeOption) error {
+	return c.setRoute(challenge.DNSAccount01, pattern, dns01.NewAccountChallenge(c.core, validate, p, opts...))
+}
+
+// SetConcurrency sets the maximum number of authorizations solved at the same time (default: 1).
+// The challenges of the providers implementing `Sequential() time.Duration` are always solved one by one
+// (ex: the HTTP-01 and TLS-ALPN-01 servers, or the DNS providers with rate limits).
+// The other providers must be safe for concurrent use.
+func (c *SolverManager) SetConcurrency(concurrency int) {
+	c.concurrency = concurrency
+}
+
+func (c *SolverManager) setRoute(chlgType challenge.Type, pattern string, solvr solver) error {
+	normalized, err := normalizePattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	if c.routes == nil {
+		c.routes = make(map[challenge.Type][]route)
+	}
+
+	routes := slices.DeleteFunc(c.routes[chlgType], func(r route) bool {
+		return r.pattern == normalized
+	})
+
+	c.routes[chlgType] = append(routes, route{pattern: normalized, solver: solvr})
+
 	return nil
 }
 
 // Remove removes a challenge type from the available solvers.
 func (c *SolverManager) Remove(chlgType challenge.Type) {
 	delete(c.solvers, chlgType)
+	delete(c.routes, chlgType)
 }
 
-// Checks all challenges from the server in order and returns the first matching solver.
-func (c *SolverManager) chooseSolver(authz acme.Authorization) solver {
-	// Allow to have a deterministic challenge order
+// Disable disables a challenge type:
+// the solver is kept but the challenge type is never chosen, even if it's forced for a domain.
+func (c *SolverManager) Disable(chlgType challenge.Type) {
+	if c.disabled == nil {
+		c.disabled = make(map[challenge.Type]struct{})
+	}
+
+	c.disabled[chlgType] = struct{}{}
+}
+
+// SetChallengeType forces the challenge type to use for a domain,
+// instead of the first challenge type with a solver.
+// The domain must match the identifier of the order (ex: `*.example.com` for a wildcard).
+func (c *SolverManager) SetChallengeType(domain string, chlgType challenge.Type) {
+	if c.challengeMap == nil {
+		c.challengeMap = make(map[string]challenge.Type)
+	}
+
+	c.challengeMap[domain] = chlgType
+}
+
+// Checks all challenges from the server in order and returns the first matching solver, and its challenge type.
+func (c *SolverManager) chooseSolver(authz acme.Authorization) (solver, challenge.Type) {
+	domain := challenge.GetTargetedDomain(authz)
+
+	solvr, chosenType, reasons := c.selectSolver(authz)
+
+	c.core.Log().Infof("[%s] acme: challenge selection: %s", domain, strings.Join(reasons, ", "))
+
+	if solvr == nil {
+		c.core.Log().Infof("[%s] acme: Could not find solver", domain)
+	}
+
+	return solvr, chosenType
+}
+
+// selectSolver returns the solver to use for the authorization, its challenge type,
+// and the reason why each challenge type was or wasn't chosen.
+func (c *SolverManager) selectSolver(authz acme.Authorization) (solver, challenge.Type, []string) {
+	// Allow to have a deterministic challenge order: tls-alpn-01, http-01, email-reply-00, dns-account-01, dns-01, device-attest-01.
 	sort.Sort(byType(authz.Challenges))
 
 	domain := challenge.GetTargetedDomain(authz)
+
+	forced, isForced := c.challengeMap[domain]
+
+	var (
+		chosen     solver
+		chosenType challenge.Type
+		offered    []challenge.Type
+		reasons    []string
+	)
+
 	for _, chlg := range authz.Challenges {
-		if solvr, ok := c.solvers[challenge.Type(chlg.Type)]; ok {
-			log.Infof("[%s] acme: use %s solver", domain, chlg.Type)
-			return solvr
+		chlgType := challenge.Type(chlg.Type)
+		offered = append(offered, chlgType)
+
+		solvr, pattern, hasSolver := c.getSolver(chlgType, domain)
+
+		var reason string
+
+		switch {
+		case chosen != nil:
+			reason = "skipped (a solver has already been chosen)"
+		case isForced && chlgType != forced:
+			reason = fmt.Sprintf("skipped (%s is forced for this domain)", forced)
+		case c.isDisabled(chlgType):
+			reason = "disabled"
+		case authz.Identifier.Type == acme.IdentifierIP && !slices.Contains(ipChallenges, chlgType):
+			reason = "not allowed for IP identifiers"
+		case !hasSolver:
+			reason = "no solver"
+		case pattern != "":
+			chosen, chosenType = solvr, chlgType
+			reason = fmt.Sprintf("chosen (%s)", pattern)
+
+			c.core.Log().Infof("[%s] acme: use %s solver for %s", domain, chlgType, pattern)
+		default:
+			chosen, chosenType = solvr, chlgType
+			reason = "chosen"
+
+			c.core.Log().Infof("[%s] acme: use %s solver", domain, chlgType)
 		}
 
-		log.Infof("[%s] acme: Could not find solver for: %s", domain, chlg.Type)
+		reasons = append(reasons, fmt.Sprintf("%s: %s", chlgType, reason))
 	}
 
-	return nil
+	if isForced && !slices.Contains(offered, forced) {
+		reasons = append(reasons, fmt.Sprintf("%s: forced for this domain but not offered by the server", forced))
+	}
+
+	for _, chlgType := range []challenge.Type{challenge.TLSALPN01, challenge.HTTP01, challenge.EmailReply00, challenge.DNSAccount01, challenge.DNS01, challenge.DeviceAttest01} {
+		if _, _, ok := c.getSolver(chlgType, domain); !ok || slices.Contains(offered, chlgType) || chlgType == forced && isForced {
+			continue
+		}
+
+		reasons = append(reasons, fmt.Sprintf("%s: not offered by the server", chlgType))
+	}
+
+	return chosen, chosenType, reasons
+}
+
+// getSolver returns the solver of the challenge type for the domain,
+// and the pattern of the route used (empty for the default solver).
+func (c *SolverManager) getSolver(chlgType challenge.Type, domain string) (solver, string, bool) {
+	if r, ok := matchRoute(c.routes[chlgType], domain); ok {
+		return r.solver, r.pattern, true
+	}
+
+	solvr, ok := c.solvers[chlgType]
+
+	return solvr, "", ok
 }
 
-func validate(core *api.Core, domain string, chlg acme.Challenge) error {
-	chlng, err := core.Challenges.New(chlg.URL)
+// matchRoute returns the most specific route matching the domain:
+// the route of the domain, then the route with the longest wildcard.
+func matchRoute(routes []route, domain string) (route, bool) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	var (
+		best  route
+		found bool
+	)
+
+	for _, r := range routes {
+		if r.pattern == domain {
+			return r, true
+		}
+
+		suffix, ok := strings.CutPrefix(r.pattern, "*")
+		if !ok || !strings.HasSuffix(domain, suffix) {
+			continue
+		}
+
+		if !found || len(r.pattern) > len(best.pattern) {
+			best = r
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// normalizePattern validates and normalizes a domain pattern (ex: `example.com`, `*.example.com`).
+func normalizePattern(pattern string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(pattern), "."))
+
+	name := strings.TrimPrefix(normalized, "*.")
+	if name == "" || strings.Contains(name, "*") || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid domain pattern %q: expected a domain (ex: example.com) or a wildcard (ex: *.example.com)", pattern)
+	}
+
+	return normalized, nil
+}
+
+func (c *SolverManager) isDisabled(chlgType challenge.Type) bool {
+	_, ok := c.disabled[chlgType]
+	return ok
+}
+
+func validate(ctx context.Context, core *api.Core, domain string, chlg acme.Challenge) error {
+	return validateWithPayload(ctx, core, domain, chlg, struct{}{})
+}
+
+// validateWithPayload responds to the challenge with the payload, and waits for the validation of the authorization.
+func validateWithPayload(ctx context.Context, core *api.Core, domain string, chlg acme.Challenge, payload any) error {
+	if delay := faults.ValidationDelay(); delay > 0 {
+		core.Log().Warnf("[%s] faults: delay the validation for %s", domain, delay)
+
+		err := wait.Sleep(ctx, delay)
+		if err != nil {
+			return err
+		}
+	}
+
+	chlng, err := core.Challenges.Respond(ctx, chlg.URL, payload)
 	if err != nil {
 		return fmt.Errorf("failed to initiate challenge: %w", err)
 	}
@@ -89,7 +349,7 @@ func validate(core *api.Core, domain string, chlg acme.Challenge) error {
 	}
 
 	if valid {
-		log.Infof("[%s] The server validated our request", domain)
+		core.Log().Infof("[%s] The server validated our request", domain)
 		return nil
 	}
 
@@ -102,8 +362,6 @@ func validate(core *api.Core, domain string, chlg acme.Challenge) error {
 		retryAfter = 5 * time.Second
 	}
 
-	ctx := context.Background()
-
 	bo := backoff.NewExponentialBackOff()
 	bo.InitialInterval = retryAfter
 	bo.MaxInterval = 10 * retryAfter
@@ -111,7 +369,7 @@ func validate(core *api.Core, domain string, chlg acme.Challenge) error {
 	// After the path is sent, the ACME server will access our server.
 	// Repeatedly check the server for an updated status on our request.
 	operation := func() error {
-		authz, err := core.Authorizations.Get(chlng.AuthorizationURL)
+		authz, err := core.Authorizations.GetContext(ctx, chlng.AuthorizationURL)
 		if err != nil {
 			return backoff.Permanent(err)
 		}
@@ -122,7 +380,7 @@ func validate(core *api.Core, domain string, chlg acme.Challenge) error {
 		}
 
 		if valid {
-			log.Infof("[%s] The server validated our request", domain)
+			core.Log().Infof("[%s] The server validated our request", domain)
 			return nil
 		}
 
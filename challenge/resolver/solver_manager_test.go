@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
 	"github.com/go-jose/go-jose/v4"
@@ -20,18 +21,347 @@ import (
 
 func TestByType(t *testing.T) {
 	challenges := []acme.Challenge{
-		{Type: "dns-01"}, {Type: "tlsalpn-01"}, {Type: "http-01"},
+		{Type: "dns-01"}, {Type: "tlsalpn-01"}, {Type: "dns-account-01"}, {Type: "email-reply-00"}, {Type: "http-01"},
 	}
 
 	sort.Sort(byType(challenges))
 
 	expected := []acme.Challenge{
-		{Type: "tlsalpn-01"}, {Type: "http-01"}, {Type: "dns-01"},
+		{Type: "tlsalpn-01"}, {Type: "http-01"}, {Type: "email-reply-00"}, {Type: "dns-account-01"}, {Type: "dns-01"},
 	}
 
 	assert.Equal(t, expected, challenges)
 }
 
+func TestSolverManager_chooseSolver_challengeType(t *testing.T) {
+	httpSolver := &preSolverMock{}
+	dnsSolver := &preSolverMock{}
+
+	manager := &SolverManager{
+		solvers: map[challenge.Type]solver{
+			challenge.HTTP01: httpSolver,
+			challenge.DNS01:  dnsSolver,
+		},
+	}
+
+	manager.SetChallengeType("api.example.com", challenge.DNS01)
+	manager.SetChallengeType("*.example.com", challenge.DNS01)
+	manager.SetChallengeType("tls.example.com", challenge.TLSALPN01)
+	manager.SetChallengeType("http.example.com", challenge.HTTP01)
+
+	testCases := []struct {
+		desc     string
+		authz    acme.Authorization
+		expected solver
+	}{
+		{
+			desc:     "default",
+			authz:    newAuthorization("example.com", false, challenge.HTTP01, challenge.DNS01),
+			expected: httpSolver,
+		},
+		{
+			desc:     "override",
+			authz:    newAuthorization("api.example.com", false, challenge.HTTP01, challenge.DNS01),
+			expected: dnsSolver,
+		},
+		{
+			desc:     "wildcard",
+			authz:    newAuthorization("example.com", true, challenge.DNS01),
+			expected: dnsSolver,
+		},
+		{
+			desc:  "no solver for the challenge type",
+			authz: newAuthorization("tls.example.com", false, challenge.HTTP01, challenge.TLSALPN01),
+		},
+		{
+			desc:  "challenge type not offered by the server",
+			authz: newAuthorization("http.example.com", false, challenge.DNS01),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			solvr, _ := manager.chooseSolver(test.authz)
+
+			if test.expected == nil {
+				assert.Nil(t, solvr)
+			} else {
+				assert.Same(t, test.expected, solvr)
+			}
+		})
+	}
+}
+
+func TestSolverManager_chooseSolver_dnsAccount(t *testing.T) {
+	dnsSolver := &preSolverMock{}
+	dnsAccountSolver := &preSolverMock{}
+
+	manager := &SolverManager{
+		solvers: map[challenge.Type]solver{
+			challenge.DNS01:        dnsSolver,
+			challenge.DNSAccount01: dnsAccountSolver,
+		},
+	}
+
+	manager.SetChallengeType("api.example.com", challenge.DNS01)
+
+	testCases := []struct {
+		desc     string
+		authz    acme.Authorization
+		expected solver
+	}{
+		{
+			desc:     "dns-account-01 preferred",
+			authz:    newAuthorization("example.com", false, challenge.DNS01, challenge.DNSAccount01),
+			expected: dnsAccountSolver,
+		},
+		{
+			desc:     "dns-account-01 not offered",
+			authz:    newAuthorization("example.com", false, challenge.DNS01),
+			expected: dnsSolver,
+		},
+		{
+			desc:     "forced dns-01",
+			authz:    newAuthorization("api.example.com", false, challenge.DNSAccount01, challenge.DNS01),
+			expected: dnsSolver,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			solvr, _ := manager.chooseSolver(test.authz)
+
+			assert.Same(t, test.expected, solvr)
+		})
+	}
+}
+
+func TestSolverManager_chooseSolver_routes(t *testing.T) {
+	httpSolver := &preSolverMock{}
+	dnsSolver := &preSolverMock{}
+	internalSolver := &preSolverMock{}
+	labSolver := &preSolverMock{}
+	apiSolver := &preSolverMock{}
+
+	manager := &SolverManager{
+		solvers: map[challenge.Type]solver{
+			challenge.HTTP01: httpSolver,
+			challenge.DNS01:  dnsSolver,
+		},
+	}
+
+	require.NoError(t, manager.setRoute(challenge.DNS01, "*.internal.example.com", internalSolver))
+	require.NoError(t, manager.setRoute(challenge.DNS01, "*.lab.internal.example.com.", labSolver))
+	require.NoError(t, manager.setRoute(challenge.DNS01, "API.example.com", apiSolver))
+
+	manager.SetChallengeType("api.example.com", challenge.DNS01)
+
+	testCases := []struct {
+		desc     string
+		authz    acme.Authorization
+		expected solver
+	}{
+		{
+			desc:     "default solver",
+			authz:    newAuthorization("www.example.com", false, challenge.DNS01),
+			expected: dnsSolver,
+		},
+		{
+			desc:     "wildcard pattern",
+			authz:    newAuthorization("a.b.internal.example.com", false, challenge.DNS01),
+			expected: internalSolver,
+		},
+		{
+			desc:     "wildcard identifier",
+			authz:    newAuthorization("internal.example.com", true, challenge.DNS01),
+			expected: internalSolver,
+		},
+		{
+			desc:     "domain of the wildcard pattern",
+			authz:    newAuthorization("internal.example.com", false, challenge.DNS01),
+			expected: dnsSolver,
+		},
+		{
+			desc:     "longest wildcard pattern",
+			authz:    newAuthorization("host.lab.internal.example.com", false, challenge.DNS01),
+			expected: labSolver,
+		},
+		{
+			desc:     "domain pattern",
+			authz:    newAuthorization("api.example.com", false, challenge.HTTP01, challenge.DNS01),
+			expected: apiSolver,
+		},
+		{
+			desc:     "other challenge type",
+			authz:    newAuthorization("a.internal.example.com", false, challenge.HTTP01, challenge.DNS01),
+			expected: httpSolver,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			solvr, _ := manager.chooseSolver(test.authz)
+
+			assert.Same(t, test.expected, solvr)
+		})
+	}
+}
+
+func TestSolverManager_setRoute(t *testing.T) {
+	first := &preSolverMock{}
+	second := &preSolverMock{}
+
+	manager := &SolverManager{}
+
+	require.NoError(t, manager.setRoute(challenge.DNS01, "*.example.com", first))
+	require.NoError(t, manager.setRoute(challenge.DNS01, "*.Example.com", second))
+
+	assert.Equal(t, []route{{pattern: "*.example.com", solver: second}}, manager.routes[challenge.DNS01])
+
+	for _, pattern := range []string{"", "*", "*.", "a.*.example.com", "**.example.com", "*..example.com"} {
+		err := manager.setRoute(challenge.DNS01, pattern, first)
+		require.Errorf(t, err, "pattern: %q", pattern)
+	}
+
+	manager.Remove(challenge.DNS01)
+
+	assert.Empty(t, manager.routes)
+}
+
+func TestSolverManager_selectSolver_routes_reasons(t *testing.T) {
+	internalSolver := &preSolverMock{}
+
+	manager := &SolverManager{
+		solvers: map[challenge.Type]solver{},
+	}
+
+	require.NoError(t, manager.setRoute(challenge.DNS01, "*.internal.example.com", internalSolver))
+
+	solvr, _, reasons := manager.selectSolver(newAuthorization("a.internal.example.com", false, challenge.HTTP01, challenge.DNS01))
+	assert.Same(t, internalSolver, solvr)
+	assert.Equal(t, []string{"http-01: no solver", "dns-01: chosen (*.internal.example.com)"}, reasons)
+
+	solvr, _, reasons = manager.selectSolver(newAuthorization("example.com", false, challenge.DNS01))
+	assert.Nil(t, solvr)
+	assert.Equal(t, []string{"dns-01: no solver"}, reasons)
+}
+
+func TestSolverManager_selectSolver_reasons(t *testing.T) {
+	httpSolver := &preSolverMock{}
+	tlsSolver := &preSolverMock{}
+	dnsSolver := &preSolverMock{}
+
+	manager := &SolverManager{
+		solvers: map[challenge.Type]solver{
+			challenge.HTTP01:    httpSolver,
+			challenge.TLSALPN01: tlsSolver,
+			challenge.DNS01:     dnsSolver,
+		},
+	}
+
+	manager.Disable(challenge.TLSALPN01)
+	manager.SetChallengeType("api.example.com", challenge.DNS01)
+	manager.SetChallengeType("tls.example.com", challenge.TLSALPN01)
+
+	testCases := []struct {
+		desc     string
+		authz    acme.Authorization
+		expected solver
+		reasons  []string
+	}{
+		{
+			desc:     "disabled challenge type",
+			authz:    newAuthorization("example.com", false, challenge.HTTP01, challenge.TLSALPN01, challenge.DNS01),
+			expected: httpSolver,
+			reasons: []string{
+				"tls-alpn-01: disabled",
+				"http-01: chosen",
+				"dns-01: skipped (a solver has already been chosen)",
+			},
+		},
+		{
+			desc:     "forced challenge type",
+			authz:    newAuthorization("api.example.com", false, challenge.HTTP01, challenge.DNS01),
+			expected: dnsSolver,
+			reasons: []string{
+				"http-01: skipped (dns-01 is forced for this domain)",
+				"dns-01: chosen",
+				"tls-alpn-01: not offered by the server",
+			},
+		},
+		{
+			desc:  "forced challenge type disabled",
+			authz: newAuthorization("tls.example.com", false, challenge.HTTP01, challenge.TLSALPN01),
+			reasons: []string{
+				"tls-alpn-01: disabled",
+				"http-01: skipped (tls-alpn-01 is forced for this domain)",
+				"dns-01: not offered by the server",
+			},
+		},
+		{
+			desc:  "forced challenge type not offered",
+			authz: newAuthorization("api.example.com", false, challenge.HTTP01),
+			reasons: []string{
+				"http-01: skipped (dns-01 is forced for this domain)",
+				"dns-01: forced for this domain but not offered by the server",
+				"tls-alpn-01: not offered by the server",
+			},
+		},
+		{
+			desc: "IP identifier",
+			authz: acme.Authorization{
+				Identifier: acme.Identifier{Type: acme.IdentifierIP, Value: "192.0.2.1"},
+				Challenges: []acme.Challenge{{Type: string(challenge.DNS01)}},
+			},
+			reasons: []string{
+				"dns-01: not allowed for IP identifiers",
+				"tls-alpn-01: not offered by the server",
+				"http-01: not offered by the server",
+			},
+		},
+		{
+			desc: "IP identifier with HTTP-01",
+			authz: acme.Authorization{
+				Identifier: acme.Identifier{Type: acme.IdentifierIP, Value: "192.0.2.1"},
+				Challenges: []acme.Challenge{{Type: string(challenge.DNS01)}, {Type: string(challenge.HTTP01)}},
+			},
+			expected: httpSolver,
+			reasons: []string{
+				"http-01: chosen",
+				"dns-01: skipped (a solver has already been chosen)",
+				"tls-alpn-01: not offered by the server",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			solvr, _, reasons := manager.selectSolver(test.authz)
+
+			if test.expected == nil {
+				assert.Nil(t, solvr)
+			} else {
+				assert.Same(t, test.expected, solvr)
+			}
+
+			assert.Equal(t, test.reasons, reasons)
+		})
+	}
+}
+
+func newAuthorization(domain string, wildcard bool, chlgTypes ...challenge.Type) acme.Authorization {
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{Type: "dns", Value: domain},
+		Wildcard:   wildcard,
+	}
+
+	for _, chlgType := range chlgTypes {
+		authz.Challenges = append(authz.Challenges, acme.Challenge{Type: string(chlgType)})
+	}
+
+	return authz
+}
+
 func TestValidate(t *testing.T) {
 	var statuses []string
 
@@ -118,7 +448,7 @@ func TestValidate(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			statuses = test.statuses
 
-			err := validate(core, "example.com", acme.Challenge{Type: "http-01", Token: "token", URL: server.URL + "/chlg"})
+			err := validate(t.Context(), core, "example.com", acme.Challenge{Type: "http-01", Token: "token", URL: server.URL + "/chlg"})
 			if test.want == "" {
 				require.NoError(t, err)
 			} else {
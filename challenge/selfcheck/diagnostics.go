@@ -0,0 +1,114 @@
+package selfcheck
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"syscall"
+)
+
+// Kind the kind of failure of a self-check.
+type Kind string
+
+// Kinds of failure.
+const (
+	KindDNS          Kind = "dns"
+	KindRefused      Kind = "connection refused"
+	KindTimeout      Kind = "timeout"
+	KindUnreachable  Kind = "unreachable"
+	KindTLS          Kind = "tls"
+	KindRedirect     Kind = "redirect"
+	KindRedirectLoop Kind = "redirect loop"
+	KindStatus       Kind = "status"
+	KindContent      Kind = "content"
+	KindUnknown      Kind = "unknown"
+)
+
+// hints the default actionable hints of the kinds of failure.
+var hints = map[Kind]string{
+	KindDNS:          "check the A/AAAA records of the domain in the public DNS",
+	KindRefused:      "nothing listens on the port, or a firewall rejects the connections",
+	KindTimeout:      "a firewall probably drops the connections, or the address is not the address of the server",
+	KindUnreachable:  "the address is not routable from the host (check the network, or use a proxy)",
+	KindTLS:          "a server or a reverse proxy answers without the challenge",
+	KindRedirect:     "the CA only follows the redirects to the ports 80 and 443",
+	KindRedirectLoop: "check the redirection rules of the server",
+	KindStatus:       "the challenge is not served: check the virtual host, the webroot, or the rewrite rules",
+	KindContent:      "another server or virtual host answers for the domain",
+}
+
+// Error a failure of a self-check, with an actionable hint.
+type Error struct {
+	Kind Kind
+
+	// Target the URL or the address checked.
+	Target string
+
+	Message string
+
+	// Hint the actionable hint (the hint of the kind if empty).
+	Hint string
+
+	Err error
+}
+
+func (e *Error) Error() string {
+	msg := e.Target + ": " + e.Message
+
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	hint := e.Hint
+	if hint == "" {
+		hint = hints[e.Kind]
+	}
+
+	if hint != "" {
+		msg += " (" + hint + ")"
+	}
+
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Diagnose converts the error of a connection to the target into an Error.
+func Diagnose(target string, err error) *Error {
+	var scErr *Error
+	if errors.As(err, &scErr) {
+		return scErr
+	}
+
+	var (
+		dnsErr   *net.DNSError
+		certErr  *tls.CertificateVerificationError
+		hostErr  x509.HostnameError
+		alertErr tls.AlertError
+		netErr   net.Error
+	)
+
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return &Error{Kind: KindRefused, Target: target, Message: "connection refused", Err: err}
+
+	case errors.Is(err, syscall.ENETUNREACH), errors.Is(err, syscall.EHOSTUNREACH):
+		return &Error{Kind: KindUnreachable, Target: target, Message: "unreachable", Err: err}
+
+	case errors.As(err, &dnsErr):
+		return &Error{Kind: KindDNS, Target: target, Message: "DNS resolution", Err: err}
+
+	case errors.As(err, &certErr), errors.As(err, &hostErr), errors.As(err, &alertErr):
+		return &Error{Kind: KindTLS, Target: target, Message: "TLS", Err: err}
+
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return &Error{Kind: KindTimeout, Target: target, Message: "timeout", Err: err}
+
+	default:
+		return &Error{Kind: KindUnknown, Target: target, Message: "failure", Err: err}
+	}
+}
@@ -0,0 +1,77 @@
+package selfcheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnose_refused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	addr := listener.Addr().String()
+
+	// Nothing listens on the port.
+	require.NoError(t, listener.Close())
+
+	_, err = Config{}.DialContext(t.Context(), "tcp", addr)
+	require.Error(t, err)
+
+	scErr := Diagnose(addr, err)
+
+	assert.Equal(t, KindRefused, scErr.Kind)
+	assert.Contains(t, scErr.Error(), "(nothing listens on the port, or a firewall rejects the connections)")
+}
+
+func TestDiagnose(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		err      error
+		expected Kind
+	}{
+		{
+			desc:     "timeout",
+			err:      context.DeadlineExceeded,
+			expected: KindTimeout,
+		},
+		{
+			desc:     "DNS",
+			err:      &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true},
+			expected: KindDNS,
+		},
+		{
+			desc:     "self-check error",
+			err:      &Error{Kind: KindRedirectLoop, Target: "http://example.com", Message: "redirect loop"},
+			expected: KindRedirectLoop,
+		},
+		{
+			desc:     "unknown",
+			err:      errors.New("oops"),
+			expected: KindUnknown,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			scErr := Diagnose("example.com:443", test.err)
+
+			assert.Equal(t, test.expected, scErr.Kind)
+			assert.ErrorIs(t, scErr, test.err)
+		})
+	}
+}
+
+func TestError_Error(t *testing.T) {
+	err := &Error{Kind: KindStatus, Target: "http://example.com/", Message: "unexpected status code: 404"}
+	assert.EqualError(t, err, "http://example.com/: unexpected status code: 404 (the challenge is not served: check the virtual host, the webroot, or the rewrite rules)")
+
+	err = &Error{Kind: KindTLS, Target: "example.com:443", Message: "TLS handshake", Hint: "custom hint", Err: errors.New("EOF")}
+	assert.EqualError(t, err, "example.com:443: TLS handshake: EOF (custom hint)")
+}
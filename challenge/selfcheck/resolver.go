@@ -0,0 +1,115 @@
+package selfcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// DefaultResolvers the public DNS servers used to resolve the domains.
+var DefaultResolvers = []string{
+	"1.1.1.1:53",
+	"8.8.8.8:53",
+}
+
+// LookupIP resolves the domain with the public DNS servers (Config.Resolvers), as the CA:
+// the hosts file and the local DNS views are ignored.
+func (c Config) LookupIP(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IP{ip}, nil
+	}
+
+	var ips []net.IP
+
+	for _, qType := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		msg, err := c.query(ctx, host, qType)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rr := range msg.Answer {
+			switch record := rr.(type) {
+			case *dns.A:
+				ips = append(ips, record.A)
+			case *dns.AAAA:
+				ips = append(ips, record.AAAA)
+			}
+		}
+	}
+
+	if len(ips) == 0 {
+		return nil, &Error{
+			Kind:    KindDNS,
+			Target:  host,
+			Message: "no A or AAAA records in the public DNS",
+			Hint:    "the CA will not find the server: create the records, or wait for their propagation",
+		}
+	}
+
+	return ips, nil
+}
+
+func (c Config) query(ctx context.Context, host string, qType uint16) (*dns.Msg, error) {
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(host), qType)
+	m.RecursionDesired = true
+
+	client := &dns.Client{Timeout: c.GetTimeout()}
+
+	var errAll error
+
+	for _, server := range c.GetResolvers() {
+		msg, _, err := client.ExchangeContext(ctx, m, server)
+		if err != nil {
+			errAll = errors.Join(errAll, err)
+			continue
+		}
+
+		switch msg.Rcode {
+		case dns.RcodeSuccess:
+			return msg, nil
+
+		case dns.RcodeNameError:
+			return nil, &Error{
+				Kind:    KindDNS,
+				Target:  host,
+				Message: fmt.Sprintf("the domain doesn't exist in the public DNS (NXDOMAIN from %s)", server),
+			}
+
+		default:
+			errAll = errors.Join(errAll, fmt.Errorf("%s: %s", server, dns.RcodeToString[msg.Rcode]))
+		}
+	}
+
+	return nil, &Error{Kind: KindDNS, Target: host, Message: "DNS resolution", Err: errAll}
+}
+
+// dialPublic connects to the address, the host is resolved with the public DNS servers.
+func (c Config) dialPublic(ctx context.Context, dialer *net.Dialer, network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := c.LookupIP(ctx, strings.Trim(host, "[]"))
+	if err != nil {
+		return nil, err
+	}
+
+	var errAll error
+
+	for _, ip := range ips {
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+
+		errAll = errors.Join(errAll, err)
+	}
+
+	return nil, errAll
+}
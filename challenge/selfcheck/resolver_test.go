@@ -0,0 +1,88 @@
+package selfcheck
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_LookupIP(t *testing.T) {
+	addr := dnsmock.NewServer().
+		Query("example.com. A", dnsmock.Answer(&dns.A{
+			Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+			A:   net.ParseIP("192.0.2.1"),
+		})).
+		Query("example.com. AAAA", dnsmock.Noop).
+		Query("empty.example.com. A", dnsmock.Noop).
+		Query("empty.example.com. AAAA", dnsmock.Noop).
+		Query("missing.example.com.", dnsmock.Error(dns.RcodeNameError)).
+		Build(t)
+
+	config := Config{Resolvers: []string{addr.String()}}
+
+	ips, err := config.LookupIP(t.Context(), "example.com")
+	require.NoError(t, err)
+
+	require.Len(t, ips, 1)
+	assert.Equal(t, "192.0.2.1", ips[0].String())
+
+	_, err = config.LookupIP(t.Context(), "empty.example.com")
+	require.EqualError(t, err, "empty.example.com: no A or AAAA records in the public DNS"+
+		" (the CA will not find the server: create the records, or wait for their propagation)")
+
+	_, err = config.LookupIP(t.Context(), "missing.example.com")
+	require.EqualError(t, err, "missing.example.com: the domain doesn't exist in the public DNS (NXDOMAIN from "+addr.String()+")"+
+		" (check the A/AAAA records of the domain in the public DNS)")
+
+	// The IP addresses are not resolved.
+	ips, err = config.LookupIP(t.Context(), "2001:db8::1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []net.IP{net.ParseIP("2001:db8::1")}, ips)
+}
+
+func TestConfig_DialContext_publicDNS(t *testing.T) {
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = target.Close() })
+
+	go func() {
+		conn, errA := target.Accept()
+		if errA != nil {
+			return
+		}
+
+		_, _ = conn.Write([]byte("hello"))
+		_ = conn.Close()
+	}()
+
+	// The hosts file resolves localhost, the public DNS resolves it to the target.
+	addr := dnsmock.NewServer().
+		Query("localhost. A", dnsmock.Answer(&dns.A{
+			Hdr: dns.RR_Header{Name: "localhost.", Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+			A:   net.ParseIP("127.0.0.1"),
+		})).
+		Query("localhost. AAAA", dnsmock.Noop).
+		Build(t)
+
+	config := Config{Resolvers: []string{addr.String()}}
+
+	_, port, err := net.SplitHostPort(target.Addr().String())
+	require.NoError(t, err)
+
+	conn, err := config.DialContext(t.Context(), "tcp", net.JoinHostPort("localhost", port))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	data, err := io.ReadAll(conn)
+	require.NoError(t, err)
+
+	assert.Equal(t, "hello", string(data))
+}
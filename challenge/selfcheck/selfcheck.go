@@ -0,0 +1,219 @@
+package selfcheck
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// DefaultTimeout the default timeout of a self-check.
+const DefaultTimeout = 10 * time.Second
+
+// maxRedirects the maximum number of redirects followed by the CA.
+const maxRedirects = 10
+
+// Config the configuration of the self-checks:
+// before the validation by the CA, the challenge is fetched from the domain to detect the misconfigurations.
+type Config struct {
+	// Proxy the proxy used to reach the domains (http, https, socks5, socks5h).
+	// The self-checks from the host itself can fail because of the NAT hairpinning,
+	// a proxy outside the network avoids that.
+	Proxy *url.URL
+
+	// Resolvers the DNS servers used to resolve the domains (DefaultResolvers if empty).
+	// Not used with a proxy: the proxy resolves the domains (socks5h, http, https) or the system resolver (socks5).
+	Resolvers []string
+
+	// Timeout the timeout of a self-check (DefaultTimeout if zero).
+	Timeout time.Duration
+}
+
+// GetTimeout returns the timeout of a self-check.
+func (c Config) GetTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return DefaultTimeout
+	}
+
+	return c.Timeout
+}
+
+// GetResolvers returns the DNS servers used to resolve the domains.
+func (c Config) GetResolvers() []string {
+	if len(c.Resolvers) == 0 {
+		return DefaultResolvers
+	}
+
+	return c.Resolvers
+}
+
+// HTTPClient returns the HTTP client of the self-checks.
+// The client follows the redirects as the CA: the redirect loops and the redirects to other ports are errors,
+// and the certificates of the HTTPS redirects are not verified.
+// The proxies of the environment are ignored.
+func (c Config) HTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: true, //nolint:gosec // the CA doesn't verify the certificates of the redirects.
+	}
+
+	if c.Proxy != nil {
+		// The transport supports the same proxy schemes as DialContext.
+		transport.Proxy = http.ProxyURL(c.Proxy)
+	} else {
+		transport.Proxy = nil
+		transport.DialContext = c.DialContext
+	}
+
+	return &http.Client{
+		Timeout:       c.GetTimeout(),
+		Transport:     transport,
+		CheckRedirect: checkRedirect,
+	}
+}
+
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	chain := make([]string, 0, len(via)+1)
+	for _, r := range via {
+		chain = append(chain, r.URL.String())
+	}
+
+	chain = append(chain, req.URL.String())
+
+	for _, r := range via {
+		if r.URL.String() == req.URL.String() {
+			return &Error{Kind: KindRedirectLoop, Target: via[0].URL.String(), Message: "redirect loop: " + strings.Join(chain, " -> ")}
+		}
+	}
+
+	if len(via) >= maxRedirects {
+		return &Error{Kind: KindRedirectLoop, Target: via[0].URL.String(), Message: fmt.Sprintf("more than %d redirects", maxRedirects)}
+	}
+
+	if port := req.URL.Port(); port != "" && port != "80" && port != "443" {
+		return &Error{Kind: KindRedirect, Target: via[0].URL.String(), Message: "redirect to the port " + port + ": " + strings.Join(chain, " -> ")}
+	}
+
+	return nil
+}
+
+// DialContext connects to the address, through the proxy if any.
+// Without proxy, the domain is resolved with the public DNS servers (Config.Resolvers).
+func (c Config) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: c.GetTimeout()}
+
+	if c.Proxy == nil {
+		return c.dialPublic(ctx, dialer, network, addr)
+	}
+
+	switch c.Proxy.Scheme {
+	case "socks5", "socks5h":
+		d, err := proxy.FromURL(c.Proxy, dialer)
+		if err != nil {
+			return nil, err
+		}
+
+		cd, ok := d.(proxy.ContextDialer)
+		if !ok {
+			return nil, errors.New("the SOCKS5 dialer doesn't support the context")
+		}
+
+		return cd.DialContext(ctx, network, addr)
+
+	case "http", "https":
+		return c.dialConnect(ctx, dialer, addr)
+
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme: %s", c.Proxy.Scheme)
+	}
+}
+
+// dialConnect opens a tunnel through an HTTP proxy (CONNECT method).
+func (c Config) dialConnect(ctx context.Context, dialer *net.Dialer, addr string) (net.Conn, error) {
+	proxyAddr := c.Proxy.Host
+	if c.Proxy.Port() == "" {
+		port := "80"
+		if c.Proxy.Scheme == "https" {
+			port = "443"
+		}
+
+		proxyAddr = net.JoinHostPort(c.Proxy.Hostname(), port)
+	}
+
+	conn, err := dialer.DialContext(ctx, "tcp", proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("proxy: %w", err)
+	}
+
+	if c.Proxy.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: c.Proxy.Hostname()})
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	} else {
+		_ = conn.SetDeadline(time.Now().Add(c.GetTimeout()))
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+
+	if c.Proxy.User != nil {
+		password, _ := c.Proxy.User.Password()
+		req.SetBasicAuth(c.Proxy.User.Username(), password)
+		req.Header.Set("Proxy-Authorization", req.Header.Get("Authorization"))
+		req.Header.Del("Authorization")
+	}
+
+	err = req.Write(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy: %w", err)
+	}
+
+	br := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy: %w", err)
+	}
+
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy: CONNECT %s: %s", addr, resp.Status)
+	}
+
+	_ = conn.SetDeadline(time.Time{})
+
+	if br.Buffered() > 0 {
+		// The data sent by the server right after the response of the proxy.
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+
+	return conn, nil
+}
+
+type bufferedConn struct {
+	net.Conn
+
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
@@ -0,0 +1,99 @@
+package selfcheck
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_DialContext_httpProxy(t *testing.T) {
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = target.Close() })
+
+	go func() {
+		conn, errA := target.Accept()
+		if errA != nil {
+			return
+		}
+
+		_, _ = conn.Write([]byte("hello"))
+		_ = conn.Close()
+	}()
+
+	var requested string
+
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodConnect {
+			http.Error(rw, "unexpected method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		requested = req.Host
+
+		upstream, errD := net.Dial("tcp", target.Addr().String())
+		if errD != nil {
+			http.Error(rw, errD.Error(), http.StatusBadGateway)
+			return
+		}
+
+		conn, _, errH := rw.(http.Hijacker).Hijack()
+		if errH != nil {
+			return
+		}
+
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+
+		go func() { _, _ = io.Copy(upstream, conn) }()
+
+		_, _ = io.Copy(conn, upstream)
+		_ = conn.Close()
+	}))
+	t.Cleanup(proxyServer.Close)
+
+	proxyURL, err := url.Parse(proxyServer.URL)
+	require.NoError(t, err)
+
+	config := Config{Proxy: proxyURL}
+
+	conn, err := config.DialContext(context.Background(), "tcp", "example.com:443")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	data, err := io.ReadAll(conn)
+	require.NoError(t, err)
+
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, "example.com:443", requested)
+}
+
+func TestConfig_DialContext_httpProxy_refused(t *testing.T) {
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		http.Error(rw, "denied", http.StatusForbidden)
+	}))
+	t.Cleanup(proxyServer.Close)
+
+	proxyURL, err := url.Parse(proxyServer.URL)
+	require.NoError(t, err)
+
+	config := Config{Proxy: proxyURL}
+
+	_, err = config.DialContext(context.Background(), "tcp", "example.com:443")
+	require.EqualError(t, err, "proxy: CONNECT example.com:443: 403 Forbidden")
+}
+
+func TestConfig_DialContext_unsupportedScheme(t *testing.T) {
+	config := Config{Proxy: &url.URL{Scheme: "ftp", Host: "proxy.example.com"}}
+
+	_, err := config.DialContext(context.Background(), "tcp", "example.com:443")
+	require.EqualError(t, err, "unsupported proxy scheme: ftp")
+}
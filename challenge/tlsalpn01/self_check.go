@@ -0,0 +1,99 @@
+package tlsalpn01
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/asn1"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/go-acme/lego/v4/challenge/selfcheck"
+)
+
+// SetSelfCheck enables the self-check: before the validation by the CA,
+// the challenge certificate is fetched from the domain (through the proxy of the configuration, if any).
+func SetSelfCheck(config selfcheck.Config) ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.selfCheck = &config
+		return nil
+	}
+}
+
+func selfCheck(ctx context.Context, config selfcheck.Config, domain, keyAuth string) error {
+	addr := net.JoinHostPort(domain, defaultTLSPort)
+
+	ctx, cancel := context.WithTimeout(ctx, config.GetTimeout())
+	defer cancel()
+
+	conn, err := config.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return selfcheck.Diagnose(addr, err)
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{
+		ServerName: domain,
+		NextProtos: []string{ACMETLS1Protocol},
+		// The challenge certificate is self-signed.
+		InsecureSkipVerify: true, //nolint:gosec // only the acmeValidation-v1 extension is checked.
+	})
+
+	defer func() { _ = tlsConn.Close() }()
+
+	err = tlsConn.HandshakeContext(ctx)
+	if err != nil {
+		return &selfcheck.Error{
+			Kind:    selfcheck.KindTLS,
+			Target:  addr,
+			Message: "TLS handshake",
+			Hint:    "the port " + defaultTLSPort + " doesn't serve TLS, or the server doesn't support the " + ACMETLS1Protocol + " protocol",
+			Err:     err,
+		}
+	}
+
+	state := tlsConn.ConnectionState()
+
+	if state.NegotiatedProtocol != ACMETLS1Protocol {
+		return &selfcheck.Error{
+			Kind:    selfcheck.KindTLS,
+			Target:  addr,
+			Message: fmt.Sprintf("the %s protocol is not negotiated", ACMETLS1Protocol),
+			Hint:    "a server or a reverse proxy terminates the TLS connections without forwarding the " + ACMETLS1Protocol + " protocol",
+		}
+	}
+
+	if len(state.PeerCertificates) == 0 {
+		return &selfcheck.Error{Kind: selfcheck.KindTLS, Target: addr, Message: "no certificate"}
+	}
+
+	zBytes := sha256.Sum256([]byte(keyAuth))
+
+	expected, err := asn1.Marshal(zBytes[:sha256.Size])
+	if err != nil {
+		return err
+	}
+
+	for _, ext := range state.PeerCertificates[0].Extensions {
+		if !ext.Id.Equal(idPeAcmeIdentifierV1) {
+			continue
+		}
+
+		if !slices.Equal(ext.Value, expected) {
+			return &selfcheck.Error{
+				Kind:    selfcheck.KindContent,
+				Target:  addr,
+				Message: "the acmeValidation-v1 extension doesn't match the key authorization",
+				Hint:    "the certificate of another challenge is served: another lego instance, or a previous attempt",
+			}
+		}
+
+		return nil
+	}
+
+	return &selfcheck.Error{
+		Kind:    selfcheck.KindContent,
+		Target:  addr,
+		Message: fmt.Sprintf("the certificate (%s) has no acmeValidation-v1 extension", state.PeerCertificates[0].Subject),
+	}
+}
@@ -0,0 +1,80 @@
+package tlsalpn01
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-acme/lego/v4/challenge/selfcheck"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_selfCheck(t *testing.T) {
+	cert, err := ChallengeCert("example.com", "good.keyauth")
+	require.NoError(t, err)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{*cert},
+		NextProtos:   []string{ACMETLS1Protocol},
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, errA := listener.Accept()
+			if errA != nil {
+				return
+			}
+
+			go func() {
+				_ = conn.(*tls.Conn).Handshake()
+				_ = conn.Close()
+			}()
+		}
+	}()
+
+	// The proxy forwards all the connections to the challenge server.
+	proxyServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		upstream, errD := net.Dial("tcp", listener.Addr().String())
+		if errD != nil {
+			http.Error(rw, errD.Error(), http.StatusBadGateway)
+			return
+		}
+
+		conn, _, errH := rw.(http.Hijacker).Hijack()
+		if errH != nil {
+			return
+		}
+
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+
+		go func() { _, _ = io.Copy(upstream, conn) }()
+
+		_, _ = io.Copy(conn, upstream)
+		_ = conn.Close()
+	}))
+	t.Cleanup(proxyServer.Close)
+
+	proxyURL, err := url.Parse(proxyServer.URL)
+	require.NoError(t, err)
+
+	config := selfcheck.Config{Proxy: proxyURL}
+
+	err = selfCheck(t.Context(), config, "example.com", "good.keyauth")
+	require.NoError(t, err)
+
+	err = selfCheck(t.Context(), config, "example.com", "other.keyauth")
+	require.EqualError(t, err, "example.com:443: the acmeValidation-v1 extension doesn't match the key authorization"+
+		" (the certificate of another challenge is served: another lego instance, or a previous attempt)")
+
+	var scErr *selfcheck.Error
+	require.ErrorAs(t, err, &scErr)
+
+	require.Equal(t, selfcheck.KindContent, scErr.Kind)
+}
@@ -1,6 +1,7 @@
 package tlsalpn01
 
 import (
+	"context"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/tls"
@@ -13,7 +14,10 @@ import (
 	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/challenge"
-	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/challenge/selfcheck"
+	"github.com/go-acme/lego/v4/internal/faults"
+	"github.com/go-acme/lego/v4/platform/wait"
+	"github.com/go-acme/lego/v4/tracing"
 )
 
 // idPeAcmeIdentifierV1 is the SMI Security for PKIX Certification Extension OID referencing the ACME extension.
@@ -22,6 +26,20 @@ var idPeAcmeIdentifierV1 = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
 
 type ValidateFunc func(core *api.Core, domain string, chlng acme.Challenge) error
 
+// ValidateContextFunc is like [ValidateFunc] with a context.
+type ValidateContextFunc func(ctx context.Context, core *api.Core, domain string, chlng acme.Challenge) error
+
+// validateContext adapts a ValidateFunc to a ValidateContextFunc.
+func validateContext(validate ValidateFunc) ValidateContextFunc {
+	if validate == nil {
+		return nil
+	}
+
+	return func(_ context.Context, core *api.Core, domain string, chlng acme.Challenge) error {
+		return validate(core, domain, chlng)
+	}
+}
+
 type ChallengeOption func(*Challenge) error
 
 // SetDelay sets a delay between the start of the TLS listener and the challenge validation.
@@ -34,12 +52,19 @@ func SetDelay(delay time.Duration) ChallengeOption {
 
 type Challenge struct {
 	core     *api.Core
-	validate ValidateFunc
+	validate ValidateContextFunc
 	provider challenge.Provider
 	delay    time.Duration
+
+	selfCheck *selfcheck.Config
 }
 
 func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
+	return NewChallengeContext(core, validateContext(validate), provider, opts...)
+}
+
+// NewChallengeContext is like [NewChallenge] with a validation function using a context.
+func NewChallengeContext(core *api.Core, validate ValidateContextFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
 	chlg := &Challenge{
 		core:     core,
 		validate: validate,
@@ -49,7 +74,7 @@ func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Prov
 	for _, opt := range opts {
 		err := opt(chlg)
 		if err != nil {
-			log.Infof("challenge option error: %v", err)
+			chlg.core.Log().Infof("challenge option error: %v", err)
 		}
 	}
 
@@ -60,10 +85,25 @@ func (c *Challenge) SetProvider(provider challenge.Provider) {
 	c.provider = provider
 }
 
+// Sequential returns true if the challenges must be solved one by one (ex: ProviderServer listens on a single port).
+func (c *Challenge) Sequential() (bool, time.Duration) {
+	if p, ok := c.provider.(interface{ Sequential() time.Duration }); ok {
+		return true, p.Sequential()
+	}
+
+	return false, 0
+}
+
 // Solve manages the provider to validate and solve the challenge.
 func (c *Challenge) Solve(authz acme.Authorization) error {
+	return c.SolveContext(context.Background(), authz)
+}
+
+// SolveContext is like [Challenge.Solve] with a context.
+func (c *Challenge) SolveContext(ctx context.Context, authz acme.Authorization) error {
 	domain := authz.Identifier.Value
-	log.Infof("[%s] acme: Trying to solve TLS-ALPN-01", challenge.GetTargetedDomain(authz))
+	logger := c.core.Log().With("domain", challenge.GetTargetedDomain(authz), "challenge", challenge.TLSALPN01)
+	logger.Infof("[%s] acme: Trying to solve TLS-ALPN-01", challenge.GetTargetedDomain(authz))
 
 	chlng, err := challenge.FindChallenge(challenge.TLSALPN01, authz)
 	if err != nil {
@@ -76,25 +116,48 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 		return err
 	}
 
-	err = c.provider.Present(domain, chlng.Token, keyAuth)
+	_, span := c.core.Tracer().Start(ctx, tracing.SpanPresent, tracing.WithChallenge(challenge.GetTargetedDomain(authz), challenge.TLSALPN01.String()))
+
+	err = faults.Present(domain)
+	if err == nil {
+		err = c.provider.Present(domain, chlng.Token, keyAuth)
+	}
+
+	tracing.End(span, err)
+
 	if err != nil {
 		return fmt.Errorf("[%s] acme: error presenting token: %w", challenge.GetTargetedDomain(authz), err)
 	}
 
 	defer func() {
+		_, span := c.core.Tracer().Start(ctx, tracing.SpanCleanUp, tracing.WithChallenge(challenge.GetTargetedDomain(authz), challenge.TLSALPN01.String()))
+
 		err := c.provider.CleanUp(domain, chlng.Token, keyAuth)
+
+		tracing.End(span, err)
+
 		if err != nil {
-			log.Warnf("[%s] acme: cleaning up failed: %v", challenge.GetTargetedDomain(authz), err)
+			logger.Warnf("[%s] acme: cleaning up failed: %v", challenge.GetTargetedDomain(authz), err)
 		}
 	}()
 
 	if c.delay > 0 {
-		time.Sleep(c.delay)
+		err = wait.Sleep(ctx, c.delay)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.selfCheck != nil {
+		err = selfCheck(ctx, *c.selfCheck, domain, keyAuth)
+		if err != nil {
+			return fmt.Errorf("[%s] acme: self-check: %w", challenge.GetTargetedDomain(authz), err)
+		}
 	}
 
 	chlng.KeyAuthorization = keyAuth
 
-	return c.validate(c.core, domain, chlng)
+	return c.validate(ctx, c.core, domain, chlng)
 }
 
 // ChallengeBlocks returns PEM blocks (certPEMBlock, keyPEMBlock) with the acmeValidation-v1 extension
@@ -1,6 +1,7 @@
 package tlsalpn01
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -197,3 +198,47 @@ func TestChallengeIPaddress(t *testing.T) {
 
 	require.NoError(t, solver.Solve(authz))
 }
+
+type contextKey struct{}
+
+type providerNoop struct{}
+
+func (providerNoop) Present(_, _, _ string) error { return nil }
+func (providerNoop) CleanUp(_, _, _ string) error { return nil }
+
+func TestNewChallengeContext(t *testing.T) {
+	server := tester.MockACMEServer().BuildHTTPS(t)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	var validated bool
+
+	validate := func(ctx context.Context, _ *api.Core, _ string, _ acme.Challenge) error {
+		// The context of SolveContext is given to the validation.
+		assert.Equal(t, "lego", ctx.Value(contextKey{}))
+
+		validated = true
+
+		return nil
+	}
+
+	solver := NewChallengeContext(core, validate, providerNoop{})
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "localhost",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.TLSALPN01.String(), Token: "context1"},
+		},
+	}
+
+	err = solver.SolveContext(context.WithValue(t.Context(), contextKey{}, "lego"), authz)
+	require.NoError(t, err)
+
+	assert.True(t, validated)
+}
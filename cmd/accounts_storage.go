@@ -3,68 +3,29 @@ package cmd
 import (
 	"crypto"
 	"encoding/json"
-	"encoding/pem"
+	"errors"
 	"net/url"
 	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/lego"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/registration"
+	"github.com/go-acme/lego/v4/storage"
 	"github.com/urfave/cli/v2"
 )
 
 const userIDPlaceholder = "noemail@example.com"
 
-const (
-	baseAccountsRootFolderName = "accounts"
-	baseKeysFolderName         = "keys"
-	accountFileName            = "account.json"
-)
-
 // AccountsStorage A storage for account data.
-//
-// rootPath:
-//
-//	./.lego/accounts/
-//	     │      └── root accounts directory
-//	     └── "path" option
-//
-// rootUserPath:
-//
-//	./.lego/accounts/localhost_14000/foo@example.com/
-//	     │      │             │             └── userID ("email" option)
-//	     │      │             └── CA server ("server" option)
-//	     │      └── root accounts directory
-//	     └── "path" option
-//
-// keysPath:
-//
-//	./.lego/accounts/localhost_14000/foo@example.com/keys/
-//	     │      │             │             │           └── root keys directory
-//	     │      │             │             └── userID ("email" option)
-//	     │      │             └── CA server ("server" option)
-//	     │      └── root accounts directory
-//	     └── "path" option
-//
-// accountFilePath:
-//
-//	./.lego/accounts/localhost_14000/foo@example.com/account.json
-//	     │      │             │             │             └── account file
-//	     │      │             │             └── userID ("email" option)
-//	     │      │             └── CA server ("server" option)
-//	     │      └── root accounts directory
-//	     └── "path" option
+// The data are stored by a storage.Backend (the filesystem by default, see storage.FileSystem for the layout).
 type AccountsStorage struct {
-	userID          string
-	email           string
-	rootPath        string
-	rootUserPath    string
-	keysPath        string
-	accountFilePath string
-	ctx             *cli.Context
+	userID   string
+	email    string
+	rootPath string
+	id       storage.AccountID
+	backend  storage.Backend
+	ctx      *cli.Context
 }
 
 // NewAccountsStorage Creates a new AccountsStorage.
@@ -77,30 +38,26 @@ func NewAccountsStorage(ctx *cli.Context) *AccountsStorage {
 		userID = userIDPlaceholder
 	}
 
-	serverURL, err := url.Parse(ctx.String(flgServer))
+	_, err := url.Parse(ctx.String(flgServer))
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	rootPath := filepath.Join(ctx.String(flgPath), baseAccountsRootFolderName)
-	serverPath := strings.NewReplacer(":", "_", "/", string(os.PathSeparator)).Replace(serverURL.Host)
-	accountsPath := filepath.Join(rootPath, serverPath)
-	rootUserPath := filepath.Join(accountsPath, userID)
+	fs := newFileSystemStorage(ctx)
 
 	return &AccountsStorage{
-		userID:          userID,
-		email:           email,
-		rootPath:        rootPath,
-		rootUserPath:    rootUserPath,
-		keysPath:        filepath.Join(rootUserPath, baseKeysFolderName),
-		accountFilePath: filepath.Join(rootUserPath, accountFileName),
-		ctx:             ctx,
+		userID:   userID,
+		email:    email,
+		rootPath: fs.AccountsPath(),
+		id:       storage.AccountID{Server: ctx.String(flgServer), UserID: userID},
+		backend:  newStorageBackend(ctx, fs),
+		ctx:      ctx,
 	}
 }
 
 func (s *AccountsStorage) ExistsAccountFilePath() bool {
-	accountFile := filepath.Join(s.rootUserPath, accountFileName)
-	if _, err := os.Stat(accountFile); os.IsNotExist(err) {
+	_, err := s.backend.ReadAccount(s.ctx.Context, s.id)
+	if errors.Is(err, storage.ErrNotFound) {
 		return false
 	} else if err != nil {
 		log.Fatal(err)
@@ -113,10 +70,6 @@ func (s *AccountsStorage) GetRootPath() string {
 	return s.rootPath
 }
 
-func (s *AccountsStorage) GetRootUserPath() string {
-	return s.rootUserPath
-}
-
 func (s *AccountsStorage) GetUserID() string {
 	return s.userID
 }
@@ -131,11 +84,11 @@ func (s *AccountsStorage) Save(account *Account) error {
 		return err
 	}
 
-	return os.WriteFile(s.accountFilePath, jsonBytes, filePerm)
+	return s.backend.WriteAccount(s.ctx.Context, s.id, jsonBytes)
 }
 
 func (s *AccountsStorage) LoadAccount(privateKey crypto.PrivateKey) *Account {
-	fileBytes, err := os.ReadFile(s.accountFilePath)
+	fileBytes, err := s.backend.ReadAccount(s.ctx.Context, s.id)
 	if err != nil {
 		log.Fatalf("Could not load file for account %s: %v", s.GetUserID(), err)
 	}
@@ -167,58 +120,32 @@ func (s *AccountsStorage) LoadAccount(privateKey crypto.PrivateKey) *Account {
 }
 
 func (s *AccountsStorage) GetPrivateKey(keyType certcrypto.KeyType) crypto.PrivateKey {
-	accKeyPath := filepath.Join(s.keysPath, s.GetUserID()+".key")
-
-	if _, err := os.Stat(accKeyPath); os.IsNotExist(err) {
+	privateKey, err := storage.LoadAccountKey(s.ctx.Context, s.backend, s.id)
+	if errors.Is(err, storage.ErrNotFound) {
 		log.Printf("No key found for account %s. Generating a %s key.", s.GetUserID(), keyType)
-		s.createKeysFolder()
 
-		privateKey, err := generatePrivateKey(accKeyPath, keyType)
+		privateKey, err = certcrypto.GeneratePrivateKey(keyType)
 		if err != nil {
 			log.Fatalf("Could not generate RSA private account key for account %s: %v", s.GetUserID(), err)
 		}
 
-		log.Printf("Saved key to %s", accKeyPath)
+		err = storage.SaveAccountKey(s.ctx.Context, s.backend, s.id, privateKey)
+		if err != nil {
+			log.Fatalf("Could not save the private key of the account %s: %v", s.GetUserID(), err)
+		}
+
+		log.Printf("Saved the key of the account %s", s.GetUserID())
 
 		return privateKey
 	}
 
-	privateKey, err := loadPrivateKey(accKeyPath)
 	if err != nil {
-		log.Fatalf("Could not load RSA private key from file %s: %v", accKeyPath, err)
+		log.Fatalf("Could not load the private key of the account %s: %v", s.GetUserID(), err)
 	}
 
 	return privateKey
 }
 
-func (s *AccountsStorage) createKeysFolder() {
-	if err := createNonExistingFolder(s.keysPath); err != nil {
-		log.Fatalf("Could not check/create directory for account %s: %v", s.GetUserID(), err)
-	}
-}
-
-func generatePrivateKey(file string, keyType certcrypto.KeyType) (crypto.PrivateKey, error) {
-	privateKey, err := certcrypto.GeneratePrivateKey(keyType)
-	if err != nil {
-		return nil, err
-	}
-
-	certOut, err := os.Create(file)
-	if err != nil {
-		return nil, err
-	}
-	defer certOut.Close()
-
-	pemKey := certcrypto.PEMBlock(privateKey)
-
-	err = pem.Encode(certOut, pemKey)
-	if err != nil {
-		return nil, err
-	}
-
-	return privateKey, nil
-}
-
 func loadPrivateKey(file string) (crypto.PrivateKey, error) {
 	keyBytes, err := os.ReadFile(file)
 	if err != nil {
@@ -244,7 +171,7 @@ func tryRecoverRegistration(ctx *cli.Context, privateKey crypto.PrivateKey) (*re
 		return nil, err
 	}
 
-	reg, err := client.Registration.ResolveAccountByKey()
+	reg, err := client.Registration.ResolveAccountByKeyContext(ctx.Context)
 	if err != nil {
 		return nil, err
 	}
@@ -2,40 +2,48 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"crypto/x509"
 	"encoding/json"
-	"encoding/pem"
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
-	"strconv"
+	"io"
 	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/storage"
 	"github.com/urfave/cli/v2"
-	"golang.org/x/net/idna"
-	"software.sslmate.com/src/go-pkcs12"
 )
 
 const (
-	baseCertificatesFolderName = "certificates"
-	baseArchivesFolderName     = "archives"
+	issuerExt   = storage.ExtIssuer
+	certExt     = storage.ExtCertificate
+	keyExt      = storage.ExtKey
+	pemExt      = ".pem"
+	pfxExt      = ".pfx"
+	jksExt      = ".jks"
+	resourceExt = storage.ExtResource
 )
 
+// MTA bundles.
 const (
-	issuerExt   = ".issuer.crt"
-	certExt     = ".crt"
-	keyExt      = ".key"
-	pemExt      = ".pem"
-	pfxExt      = ".pfx"
-	resourceExt = ".json"
+	mtaPostfix = "postfix"
+	mtaExim    = "exim"
+	mtaDovecot = "dovecot"
 )
 
+// mtaExts the extensions of the MTA bundles.
+var mtaExts = map[string]string{
+	mtaPostfix: ".postfix.pem",
+	mtaExim:    ".exim.crt",
+	mtaDovecot: ".dovecot.crt",
+}
+
 // CertificatesStorage a certificates' storage.
+// The files are stored by a storage.Backend (the filesystem by default).
 //
 // rootPath:
 //
@@ -49,45 +57,97 @@ const (
 //	     │      └── archived certificates directory
 //	     └── "path" option
 type CertificatesStorage struct {
+	ctx     context.Context
+	backend storage.Backend
+
+	// fs the filesystem storage: the paths of the files (hooks) and the archives.
+	fs *storage.FileSystem
+
 	rootPath    string
 	archivePath string
 	pem         bool
 	pfx         bool
 	pfxPassword string
 	pfxFormat   string
+	jks         bool
+	jksPassword string
+	jksAlias    string
+	mta         []string
 	filename    string // Deprecated
+
+	// name the name of the files of the certificate (--cert-name), instead of the sanitized domain.
+	name string
+}
+
+// storedResource the content of the metadata file (.json) of a certificate.
+type storedResource struct {
+	*certificate.Resource
+
+	// DomainChanges the history of the changes of the domains (renew --add/--remove).
+	DomainChanges []domainChange `json:"domainChanges,omitempty"`
+}
+
+// domainChange a change of the domains of a certificate.
+type domainChange struct {
+	Date    time.Time `json:"date"`
+	Added   []string  `json:"added,omitempty"`
+	Removed []string  `json:"removed,omitempty"`
 }
 
 // NewCertificatesStorage create a new certificates storage.
 func NewCertificatesStorage(ctx *cli.Context) *CertificatesStorage {
 	pfxFormat := ctx.String(flgPFXFormat)
 
-	switch pfxFormat {
-	case "DES", "RC2", "SHA256":
+	switch certificate.PKCS12Format(pfxFormat) {
+	case certificate.PKCS12Modern, certificate.PKCS12LegacyDES, certificate.PKCS12LegacyRC2:
 	default:
 		log.Fatalf("Invalid PFX format: %s", pfxFormat)
 	}
 
+	for _, mta := range ctx.StringSlice(flgMTA) {
+		if _, ok := mtaExts[mta]; !ok {
+			log.Fatalf("Invalid MTA: %s", mta)
+		}
+	}
+
+	name := ctx.String(flgCertName)
+	if name != "" {
+		err := validateCertName(name)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	fs := newFileSystemStorage(ctx)
+
 	return &CertificatesStorage{
-		rootPath:    filepath.Join(ctx.String(flgPath), baseCertificatesFolderName),
-		archivePath: filepath.Join(ctx.String(flgPath), baseArchivesFolderName),
+		ctx:         ctx.Context,
+		backend:     newStorageBackend(ctx, fs),
+		fs:          fs,
+		rootPath:    fs.CertificatesPath(),
+		archivePath: fs.ArchivesPath(),
 		pem:         ctx.Bool(flgPEM),
 		pfx:         ctx.Bool(flgPFX),
 		pfxPassword: ctx.String(flgPFXPass),
 		pfxFormat:   pfxFormat,
+		jks:         ctx.Bool(flgJKS),
+		jksPassword: ctx.String(flgJKSPass),
+		jksAlias:    ctx.String(flgJKSAlias),
+		mta:         ctx.StringSlice(flgMTA),
 		filename:    ctx.String(flgFilename),
+		name:        name,
 	}
 }
 
 func (s *CertificatesStorage) CreateRootFolder() {
-	err := createNonExistingFolder(s.rootPath)
+	err := createNonExistingFolder(s.fs, s.rootPath)
 	if err != nil {
 		log.Fatalf("Could not check/create path: %v", err)
 	}
 }
 
 func (s *CertificatesStorage) CreateArchiveFolder() {
-	err := createNonExistingFolder(s.archivePath)
+	err := createNonExistingFolder(s.fs, s.archivePath)
 	if err != nil {
 		log.Fatalf("Could not check/create path: %v", err)
 	}
@@ -97,20 +157,25 @@ func (s *CertificatesStorage) GetRootPath() string {
 	return s.rootPath
 }
 
-func (s *CertificatesStorage) SaveResource(certRes *certificate.Resource) {
+// listCertificateNames returns the (sanitized) names of the certificates of the storage.
+func (s *CertificatesStorage) listCertificateNames() ([]string, error) {
+	return s.backend.ListCertificates(s.ctx)
+}
+
+func (s *CertificatesStorage) SaveResource(certRes *certificate.Resource) error {
 	domain := certRes.Domain
 
 	// We store the certificate, private key and metadata in different files
 	// as web servers would not be able to work with a combined file.
 	err := s.WriteFile(domain, certExt, certRes.Certificate)
 	if err != nil {
-		log.Fatalf("Unable to save Certificate for domain %s\n\t%v", domain, err)
+		return fmt.Errorf("unable to save Certificate for domain %s: %w", domain, err)
 	}
 
 	if certRes.IssuerCertificate != nil {
 		err = s.WriteFile(domain, issuerExt, certRes.IssuerCertificate)
 		if err != nil {
-			log.Fatalf("Unable to save IssuerCertificate for domain %s\n\t%v", domain, err)
+			return fmt.Errorf("unable to save IssuerCertificate for domain %s: %w", domain, err)
 		}
 	}
 
@@ -118,80 +183,156 @@ func (s *CertificatesStorage) SaveResource(certRes *certificate.Resource) {
 	if certRes.PrivateKey != nil {
 		err = s.WriteCertificateFiles(domain, certRes)
 		if err != nil {
-			log.Fatalf("Unable to save PrivateKey for domain %s\n\t%v", domain, err)
+			return fmt.Errorf("unable to save PrivateKey for domain %s: %w", domain, err)
 		}
-	} else if s.pem || s.pfx {
-		// we don't have the private key; can't write the .pem or .pfx file
-		log.Fatalf("Unable to save PEM or PFX without private key for domain %s. Are you using a CSR?", domain)
+	} else if s.pem || s.pfx || s.jks || len(s.mta) > 0 {
+		// we don't have the private key; can't write the .pem, .pfx, .jks, or MTA files
+		return fmt.Errorf("unable to save PEM, PFX, JKS, or MTA files without private key for domain %s. Are you using a CSR?", domain)
 	}
 
-	jsonBytes, err := json.MarshalIndent(certRes, "", "\t")
+	// The history of the domains is kept.
+	stored := storedResource{Resource: certRes, DomainChanges: s.readDomainChanges(domain)}
+
+	jsonBytes, err := json.MarshalIndent(stored, "", "\t")
 	if err != nil {
-		log.Fatalf("Unable to marshal CertResource for domain %s\n\t%v", domain, err)
+		return fmt.Errorf("unable to marshal CertResource for domain %s: %w", domain, err)
 	}
 
 	err = s.WriteFile(domain, resourceExt, jsonBytes)
 	if err != nil {
-		log.Fatalf("Unable to save CertResource for domain %s\n\t%v", domain, err)
+		return fmt.Errorf("unable to save CertResource for domain %s: %w", domain, err)
 	}
+
+	return nil
 }
 
-func (s *CertificatesStorage) ReadResource(domain string) certificate.Resource {
+// AddDomainChange records a change of the domains of a certificate inside its metadata file (.json).
+func (s *CertificatesStorage) AddDomainChange(domain string, change domainChange) error {
 	raw, err := s.ReadFile(domain, resourceExt)
 	if err != nil {
-		log.Fatalf("Error while loading the meta data for domain %s\n\t%v", domain, err)
+		return err
+	}
+
+	stored := storedResource{Resource: &certificate.Resource{}}
+
+	err = json.Unmarshal(raw, &stored)
+	if err != nil {
+		return err
+	}
+
+	stored.DomainChanges = append(stored.DomainChanges, change)
+
+	jsonBytes, err := json.MarshalIndent(stored, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return s.WriteFile(domain, resourceExt, jsonBytes)
+}
+
+// readDomainChanges reads the history of the domains of a certificate, if any.
+func (s *CertificatesStorage) readDomainChanges(domain string) []domainChange {
+	raw, err := s.ReadFile(domain, resourceExt)
+	if err != nil {
+		return nil
+	}
+
+	var stored storedResource
+
+	err = json.Unmarshal(raw, &stored)
+	if err != nil {
+		return nil
+	}
+
+	return stored.DomainChanges
+}
+
+func (s *CertificatesStorage) ReadResource(domain string) (certificate.Resource, error) {
+	raw, err := s.ReadFile(domain, resourceExt)
+	if err != nil {
+		return certificate.Resource{}, fmt.Errorf("error while loading the meta data for domain %s: %w", domain, err)
 	}
 
 	var resource certificate.Resource
 	if err = json.Unmarshal(raw, &resource); err != nil {
-		log.Fatalf("Error while marshaling the meta data for domain %s\n\t%v", domain, err)
+		return certificate.Resource{}, fmt.Errorf("error while marshaling the meta data for domain %s: %w", domain, err)
 	}
 
-	return resource
+	return resource, nil
 }
 
-func (s *CertificatesStorage) ExistsFile(domain, extension string) bool {
-	filePath := s.GetFileName(domain, extension)
+func (s *CertificatesStorage) ExistsFile(domain, extension string) (bool, error) {
+	_, err := s.ReadFile(domain, extension)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
 
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return false
-	} else if err != nil {
-		log.Fatal(err)
+	if err != nil {
+		return false, err
 	}
 
-	return true
+	return true, nil
 }
 
 func (s *CertificatesStorage) ReadFile(domain, extension string) ([]byte, error) {
-	return os.ReadFile(s.GetFileName(domain, extension))
+	return s.backend.ReadCertificateFile(s.ctx, s.certName(domain), extension)
 }
 
+// GetFileName returns the path of a file of a certificate in the filesystem storage.
 func (s *CertificatesStorage) GetFileName(domain, extension string) string {
-	filename := sanitizedDomain(domain) + extension
-	return filepath.Join(s.rootPath, filename)
+	return s.fs.CertificateFilePath(s.certName(domain), extension)
 }
 
 func (s *CertificatesStorage) ReadCertificate(domain, extension string) ([]*x509.Certificate, error) {
-	content, err := s.ReadFile(domain, extension)
+	if fo, ok := s.backend.(fileOpener); ok {
+		file, err := fo.OpenCertificateFile(s.ctx, s.certName(domain), extension)
+		if err != nil {
+			return nil, err
+		}
+
+		defer func() { _ = file.Close() }()
+
+		// The input may be a bundle or a single certificate.
+		return certcrypto.ReadPEMBundle(file)
+	}
+
+	data, err := s.ReadFile(domain, extension)
 	if err != nil {
 		return nil, err
 	}
 
 	// The input may be a bundle or a single certificate.
-	return certcrypto.ParsePEMBundle(content)
+	return certcrypto.ReadPEMBundle(bytes.NewReader(data))
 }
 
 func (s *CertificatesStorage) WriteFile(domain, extension string, data []byte) error {
+	return s.writeFileParts(domain, extension, data)
+}
+
+// writeFileParts writes the parts one after the other without concatenating them in memory.
+func (s *CertificatesStorage) writeFileParts(domain, extension string, parts ...[]byte) error {
 	var baseFileName string
 	if s.filename != "" {
 		baseFileName = s.filename
 	} else {
-		baseFileName = sanitizedDomain(domain)
+		baseFileName = s.certName(domain)
 	}
 
-	filePath := filepath.Join(s.rootPath, baseFileName+extension)
+	if pw, ok := s.backend.(partsWriter); ok {
+		return pw.WriteCertificateFileParts(s.ctx, baseFileName, extension, parts...)
+	}
 
-	return os.WriteFile(filePath, data, filePerm)
+	return s.backend.WriteCertificateFile(s.ctx, baseFileName, extension, bytes.Join(parts, nil))
+}
+
+// fileOpener a storage.Backend able to read a file without loading it in memory (storage.FileSystem).
+type fileOpener interface {
+	OpenCertificateFile(ctx context.Context, name, ext string) (io.ReadCloser, error)
+}
+
+// partsWriter a storage.Backend able to write the parts of a file without concatenating them (storage.FileSystem).
+type partsWriter interface {
+	WriteCertificateFileParts(ctx context.Context, name, ext string, parts ...[]byte) error
 }
 
 func (s *CertificatesStorage) WriteCertificateFiles(domain string, certRes *certificate.Resource) error {
@@ -201,7 +342,7 @@ func (s *CertificatesStorage) WriteCertificateFiles(domain string, certRes *cert
 	}
 
 	if s.pem {
-		err = s.WriteFile(domain, pemExt, bytes.Join([][]byte{certRes.Certificate, certRes.PrivateKey}, nil))
+		err = s.writeFileParts(domain, pemExt, certRes.Certificate, certRes.PrivateKey)
 		if err != nil {
 			return fmt.Errorf("unable to save PEM file: %w", err)
 		}
@@ -214,36 +355,47 @@ func (s *CertificatesStorage) WriteCertificateFiles(domain string, certRes *cert
 		}
 	}
 
-	return nil
-}
-
-func (s *CertificatesStorage) WritePFXFile(domain string, certRes *certificate.Resource) error {
-	certPemBlock, _ := pem.Decode(certRes.Certificate)
-	if certPemBlock == nil {
-		return fmt.Errorf("unable to parse Certificate for domain %s", domain)
+	if s.jks {
+		err = s.WriteJKSFile(domain, certRes)
+		if err != nil {
+			return fmt.Errorf("unable to save JKS file: %w", err)
+		}
 	}
 
-	cert, err := x509.ParseCertificate(certPemBlock.Bytes)
-	if err != nil {
-		return fmt.Errorf("unable to load Certificate for domain %s: %w", domain, err)
+	for _, mta := range s.mta {
+		err = s.WriteMTAFile(domain, mta, certRes)
+		if err != nil {
+			return fmt.Errorf("unable to save %s file: %w", mta, err)
+		}
 	}
 
-	certChain, err := getCertificateChain(certRes)
-	if err != nil {
-		return fmt.Errorf("unable to get certificate chain for domain %s: %w", domain, err)
-	}
+	return nil
+}
 
-	privateKey, err := certcrypto.ParsePEMPrivateKey(certRes.PrivateKey)
-	if err != nil {
-		return fmt.Errorf("unable to parse PrivateKey for domain %s: %w", domain, err)
+// WriteMTAFile writes the bundle expected by an MTA:
+//   - postfix (smtpd_tls_chain_files): the private key, then the certificate, then the intermediates.
+//   - exim (tls_certificate) and dovecot (ssl_cert): the certificate, then the intermediates.
+//     The private key is the .key file.
+func (s *CertificatesStorage) WriteMTAFile(domain, mta string, certRes *certificate.Resource) error {
+	chain := [][]byte{certRes.Certificate}
+
+	// The certificate is not bundled with the issuer.
+	if bytes.Count(certRes.Certificate, []byte("-----BEGIN CERTIFICATE-----")) == 1 {
+		chain = append(chain, certRes.IssuerCertificate)
 	}
 
-	encoder, err := getPFXEncoder(s.pfxFormat)
-	if err != nil {
-		return fmt.Errorf("PFX encoder: %w", err)
+	switch mta {
+	case mtaPostfix:
+		return s.writeFileParts(domain, mtaExts[mta], append([][]byte{certRes.PrivateKey}, chain...)...)
+	case mtaExim, mtaDovecot:
+		return s.writeFileParts(domain, mtaExts[mta], chain...)
+	default:
+		return fmt.Errorf("unsupported MTA: %s", mta)
 	}
+}
 
-	pfxBytes, err := encoder.Encode(privateKey, cert, certChain, s.pfxPassword)
+func (s *CertificatesStorage) WritePFXFile(domain string, certRes *certificate.Resource) error {
+	pfxBytes, err := certificate.EncodePKCS12(certRes, s.pfxPassword, certificate.PKCS12Format(s.pfxFormat))
 	if err != nil {
 		return fmt.Errorf("unable to encode PFX data for domain %s: %w", domain, err)
 	}
@@ -251,73 +403,56 @@ func (s *CertificatesStorage) WritePFXFile(domain string, certRes *certificate.R
 	return s.WriteFile(domain, pfxExt, pfxBytes)
 }
 
-func (s *CertificatesStorage) MoveToArchive(domain string) error {
-	baseFilename := filepath.Join(s.rootPath, sanitizedDomain(domain))
+// WriteJKSFile writes the Java KeyStore (.jks), the alias is the certificate name by default.
+func (s *CertificatesStorage) WriteJKSFile(domain string, certRes *certificate.Resource) error {
+	alias := s.jksAlias
+	if alias == "" {
+		alias = s.certName(domain)
+	}
 
-	matches, err := filepath.Glob(baseFilename + ".*")
+	jksBytes, err := certificate.EncodeJKS(certRes, alias, s.jksPassword)
 	if err != nil {
-		return err
+		return fmt.Errorf("unable to encode JKS data for domain %s: %w", domain, err)
 	}
 
-	for _, oldFile := range matches {
-		if strings.TrimSuffix(oldFile, filepath.Ext(oldFile)) != baseFilename && oldFile != baseFilename+issuerExt {
-			continue
-		}
-
-		date := strconv.FormatInt(time.Now().Unix(), 10)
-		filename := date + "." + filepath.Base(oldFile)
-		newFile := filepath.Join(s.archivePath, filename)
-
-		err = os.Rename(oldFile, newFile)
-		if err != nil {
-			return err
-		}
-	}
+	return s.WriteFile(domain, jksExt, jksBytes)
+}
 
-	return nil
+func (s *CertificatesStorage) MoveToArchive(domain string) error {
+	return s.backend.ArchiveCertificate(s.ctx, s.certName(domain))
 }
 
-func getCertificateChain(certRes *certificate.Resource) ([]*x509.Certificate, error) {
-	chainCertPemBlock, rest := pem.Decode(certRes.IssuerCertificate)
-	if chainCertPemBlock == nil {
-		return nil, errors.New("unable to parse Issuer Certificate")
+// certName returns the name of the files of a certificate:
+// the name of the certificate (--cert-name), or the sanitized domain.
+func (s *CertificatesStorage) certName(domain string) string {
+	if s.name != "" {
+		return s.name
 	}
 
-	var certChain []*x509.Certificate
-
-	for chainCertPemBlock != nil {
-		chainCert, err := x509.ParseCertificate(chainCertPemBlock.Bytes)
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse Chain Certificate: %w", err)
-		}
+	return sanitizedDomain(domain)
+}
 
-		certChain = append(certChain, chainCert)
-		chainCertPemBlock, rest = pem.Decode(rest) // Try decoding the next pem block
-	}
+// withName returns a copy of the storage using name for the files of the certificate (like --cert-name).
+func (s *CertificatesStorage) withName(name string) *CertificatesStorage {
+	named := *s
+	named.name = name
 
-	return certChain, nil
+	return &named
 }
 
-func getPFXEncoder(pfxFormat string) (*pkcs12.Encoder, error) {
-	var encoder *pkcs12.Encoder
-
-	switch pfxFormat {
-	case "SHA256":
-		encoder = pkcs12.Modern2023
-	case "DES":
-		encoder = pkcs12.LegacyDES
-	case "RC2":
-		encoder = pkcs12.LegacyRC2
-	default:
-		return nil, fmt.Errorf("invalid PFX format: %s", pfxFormat)
+// validateCertName checks that the name of a certificate (--cert-name) can be used as a file name.
+func validateCertName(name string) error {
+	safe, err := storage.SanitizedName(name)
+	if err != nil || safe != name || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid certificate name %q: only letters, digits, dots, dashes, and underscores are allowed", name)
 	}
 
-	return encoder, nil
+	return nil
 }
 
 // sanitizedDomain Make sure no funny chars are in the cert names (like wildcards ;)).
 func sanitizedDomain(domain string) string {
-	safe, err := idna.ToASCII(strings.NewReplacer(":", "-", "*", "_").Replace(domain))
+	safe, err := storage.SanitizedName(domain)
 	if err != nil {
 		log.Fatal(err)
 	}
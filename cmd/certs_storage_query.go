@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/storage"
+)
+
+// StoredCertificate a certificate of the storage (current or archived).
+type StoredCertificate struct {
+	// Name the name of the files of the certificate (sanitized main domain).
+	Name string
+
+	Domains  []string
+	NotAfter time.Time
+
+	// Path the path of the certificate file.
+	Path string
+
+	// ArchiveName the name of the archive in the storage (empty for a current certificate).
+	ArchiveName string
+
+	// ArchivedAt the date of the archiving (zero for a current certificate).
+	ArchivedAt time.Time
+
+	x509Cert *x509.Certificate
+}
+
+// IsArchived reports whether the certificate is archived.
+func (c StoredCertificate) IsArchived() bool {
+	return !c.ArchivedAt.IsZero()
+}
+
+// CertificateFilter the filters of CertificatesStorage.ListCertificates.
+type CertificateFilter struct {
+	// Archived lists the archived certificates instead of the current certificates.
+	Archived bool
+
+	// Domain only the certificates containing this domain.
+	Domain string
+
+	// ExpiredBefore only the certificates expired before this date.
+	ExpiredBefore time.Time
+
+	// ArchivedBefore only the certificates archived before this date.
+	ArchivedBefore time.Time
+}
+
+func (f CertificateFilter) match(cert StoredCertificate) bool {
+	if f.Domain != "" && !slices.Contains(cert.Domains, f.Domain) {
+		return false
+	}
+
+	if !f.ExpiredBefore.IsZero() && !cert.NotAfter.Before(f.ExpiredBefore) {
+		return false
+	}
+
+	if !f.ArchivedBefore.IsZero() && (!cert.IsArchived() || !cert.ArchivedAt.Before(f.ArchivedBefore)) {
+		return false
+	}
+
+	return true
+}
+
+// ListCertificates lists the certificates of the storage matching the filter.
+func (s *CertificatesStorage) ListCertificates(filter CertificateFilter) ([]StoredCertificate, error) {
+	if filter.Archived {
+		return s.listArchivedCertificates(filter)
+	}
+
+	names, err := s.backend.ListCertificates(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []StoredCertificate
+
+	for _, name := range names {
+		cert := StoredCertificate{
+			Name: name,
+			Path: s.fs.CertificateFilePath(name, certExt),
+		}
+
+		data, err := s.backend.ReadCertificateFile(s.ctx, name, certExt)
+		if err != nil {
+			// An unreadable certificate must not prevent the listing (and the renewal) of the others.
+			log.Warnf("[%s] Could not read the certificate: %v", name, err)
+			continue
+		}
+
+		err = cert.parse(data)
+		if err != nil {
+			log.Warnf("[%s] Could not parse the certificate: %v", name, err)
+			continue
+		}
+
+		if filter.match(cert) {
+			certs = append(certs, cert)
+		}
+	}
+
+	return certs, nil
+}
+
+func (s *CertificatesStorage) listArchivedCertificates(filter CertificateFilter) ([]StoredCertificate, error) {
+	archiveNames, err := s.backend.ListArchivedCertificates(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []StoredCertificate
+
+	for _, archiveName := range archiveNames {
+		name, archivedAt, ok := storage.ParseArchiveName(archiveName)
+		if !ok {
+			// Not an archive created by MoveToArchive.
+			continue
+		}
+
+		cert := StoredCertificate{
+			Name:        name,
+			Path:        s.fs.ArchivedCertificateFilePath(archiveName, certExt),
+			ArchiveName: archiveName,
+			ArchivedAt:  archivedAt,
+		}
+
+		data, err := s.backend.ReadArchivedCertificateFile(s.ctx, archiveName, certExt)
+		if err != nil {
+			log.Warnf("[%s] Could not read the archived certificate: %v", archiveName, err)
+			continue
+		}
+
+		err = cert.parse(data)
+		if err != nil {
+			log.Warnf("[%s] Could not parse the archived certificate: %v", archiveName, err)
+			continue
+		}
+
+		if filter.match(cert) {
+			certs = append(certs, cert)
+		}
+	}
+
+	return certs, nil
+}
+
+// parse fills the domains and the expiration date from the certificate (PEM).
+func (c *StoredCertificate) parse(data []byte) error {
+	x509Cert, err := certcrypto.ParsePEMCertificate(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", c.Path, err)
+	}
+
+	c.Domains = certcrypto.ExtractDomains(x509Cert)
+	c.NotAfter = x509Cert.NotAfter
+	c.x509Cert = x509Cert
+
+	return nil
+}
+
+// Archive moves the files of a certificate to the archive directory.
+func (s *CertificatesStorage) Archive(domain string) error {
+	err := createNonExistingFolder(s.fs, s.archivePath)
+	if err != nil {
+		return err
+	}
+
+	return s.MoveToArchive(domain)
+}
+
+// Delete deletes the files of a certificate (current or archived).
+func (s *CertificatesStorage) Delete(cert StoredCertificate) error {
+	if cert.IsArchived() {
+		return s.backend.DeleteArchivedCertificate(s.ctx, cert.ArchiveName)
+	}
+
+	return s.backend.DeleteCertificate(s.ctx, cert.Name)
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificatesStorage_ListCertificates(t *testing.T) {
+	now := time.Now()
+
+	storage := newTestCertificatesStorage(t)
+
+	writeTestCertificate(t, filepath.Join(storage.rootPath, "example.com.crt"), now.Add(24*time.Hour), "example.com", "www.example.com")
+	writeTestCertificate(t, filepath.Join(storage.rootPath, "example.org.crt"), now.Add(-24*time.Hour), "example.org")
+	writeTestCertificate(t, filepath.Join(storage.rootPath, "example.org.issuer.crt"), now, "issuer")
+
+	// An unreadable certificate is skipped.
+	err := os.WriteFile(filepath.Join(storage.rootPath, "example.io.crt"), []byte("invalid"), 0o600)
+	require.NoError(t, err)
+
+	archivedAt := now.Add(-100 * 24 * time.Hour).Truncate(time.Second)
+
+	writeTestCertificate(t, filepath.Join(storage.archivePath, "1700000000.example.com.crt"), now.Add(-120*24*time.Hour), "example.com")
+	writeTestCertificate(t, filepath.Join(storage.archivePath, formatUnix(archivedAt)+".example.net.crt"), now.Add(24*time.Hour), "example.net")
+	writeTestCertificate(t, filepath.Join(storage.archivePath, "unknown.crt"), now, "unknown")
+
+	err = os.WriteFile(filepath.Join(storage.archivePath, formatUnix(archivedAt)+".example.io.crt"), []byte("invalid"), 0o600)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		filter   CertificateFilter
+		expected []string
+	}{
+		{
+			desc:     "current",
+			expected: []string{"example.com", "example.org"},
+		},
+		{
+			desc:     "current: domain",
+			filter:   CertificateFilter{Domain: "www.example.com"},
+			expected: []string{"example.com"},
+		},
+		{
+			desc:     "current: expired",
+			filter:   CertificateFilter{ExpiredBefore: now},
+			expected: []string{"example.org"},
+		},
+		{
+			desc:     "archived",
+			filter:   CertificateFilter{Archived: true},
+			expected: []string{"example.com", "example.net"},
+		},
+		{
+			desc:     "archived: expired",
+			filter:   CertificateFilter{Archived: true, ExpiredBefore: now},
+			expected: []string{"example.com"},
+		},
+		{
+			desc:     "archived: older than",
+			filter:   CertificateFilter{Archived: true, ArchivedBefore: now.Add(-90 * 24 * time.Hour)},
+			expected: []string{"example.com", "example.net"},
+		},
+		{
+			desc:     "archived: expired and older than",
+			filter:   CertificateFilter{Archived: true, ExpiredBefore: now.Add(-90 * 24 * time.Hour), ArchivedBefore: now.Add(-90 * 24 * time.Hour)},
+			expected: []string{"example.com"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			certs, err := storage.ListCertificates(test.filter)
+			require.NoError(t, err)
+
+			var names []string
+			for _, cert := range certs {
+				names = append(names, cert.Name)
+				assert.Equal(t, test.filter.Archived, cert.IsArchived())
+			}
+
+			assert.ElementsMatch(t, test.expected, names)
+		})
+	}
+
+	certs, err := storage.ListCertificates(CertificateFilter{Archived: true, Domain: "example.net"})
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+
+	assert.Equal(t, archivedAt, certs[0].ArchivedAt)
+}
+
+func TestCertificatesStorage_Delete(t *testing.T) {
+	storage := newTestCertificatesStorage(t)
+
+	domainFiles := generateTestFiles(t, storage.archivePath, "1700000000.example.com")
+	otherFiles := generateTestFiles(t, storage.archivePath, "1700000000.example.com.example.org")
+
+	err := storage.Delete(StoredCertificate{
+		Name:        "example.com",
+		Path:        filepath.Join(storage.archivePath, "1700000000.example.com.crt"),
+		ArchiveName: "1700000000.example.com",
+		ArchivedAt:  time.Unix(1700000000, 0),
+	})
+	require.NoError(t, err)
+
+	for _, file := range domainFiles {
+		assert.NoFileExists(t, file)
+	}
+
+	for _, file := range otherFiles {
+		assert.FileExists(t, file)
+	}
+}
+
+func Test_parseAge(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "90d", expected: 90 * 24 * time.Hour},
+		{value: "0d", expected: 0},
+		{value: "720h", expected: 720 * time.Hour},
+		{value: "1h30m", expected: 90 * time.Minute},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+
+			age, err := parseAge(test.value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, age)
+		})
+	}
+
+	for _, value := range []string{"", "d", "90days", "-1d", "-2h"} {
+		_, err := parseAge(value)
+		require.Error(t, err, value)
+	}
+}
+
+func writeTestCertificate(t *testing.T, filename string, notAfter time.Time, domains ...string) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+		DNSNames:     domains,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, privateKey.Public(), privateKey)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filename, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	require.NoError(t, err)
+}
+
+func formatUnix(date time.Time) string {
+	return strconv.FormatInt(date.Unix(), 10)
+}
@@ -8,7 +8,15 @@ func CreateCommands() []*cli.Command {
 		createRun(),
 		createRevoke(),
 		createRenew(),
+		createDaemon(),
 		createDNSHelp(),
 		createList(),
+		createInspect(),
+		createPrune(),
+		createOrder(),
+		createDNS(),
+		createEmail(),
+		createDevServer(),
+		createInternal(),
 	}
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/go-acme/lego/v4/lego"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/urfave/cli/v2"
 )
@@ -10,14 +11,50 @@ func Before(ctx *cli.Context) error {
 		log.Fatalf("Could not determine current working directory. Please pass --%s.", flgPath)
 	}
 
-	err := createNonExistingFolder(ctx.String(flgPath))
+	err := createNonExistingFolder(newFileSystemStorage(ctx), ctx.String(flgPath))
 	if err != nil {
 		log.Fatalf("Could not check/create path: %v", err)
 	}
 
+	err = checkConfigFlags(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = validateReachabilityCheck(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	setupLowMemory(ctx)
+
+	setupCredentials(ctx)
+
+	setupNetwork(ctx)
+
+	setupTLSPolicy()
+
+	setupMetrics(ctx)
+
+	err = validateTLSAFlags(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	if ctx.String(flgServer) == "" {
 		log.Fatalf("Could not determine current working server. Please pass --%s.", flgServer)
 	}
 
+	if ctx.Bool(flgAcceptStagingRoots) {
+		if !lego.IsStagingDirectory(ctx.String(flgServer)) {
+			log.Fatalf("--%s requires the Let's Encrypt staging server (--%s %s).", flgAcceptStagingRoots, flgServer, lego.LEDirectoryStaging)
+		}
+
+		_, err = lego.StagingRoots()
+		if err != nil {
+			log.Fatalf("Could not load the staging roots: %v", err)
+		}
+	}
+
 	return nil
 }
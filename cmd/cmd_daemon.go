@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgDaemonSchedule = "schedule"
+)
+
+func createDaemon() *cli.Command {
+	return &cli.Command{
+		Name:   "daemon",
+		Usage:  "Keep running and renew the stored certificates on a schedule",
+		Action: daemon,
+		Before: func(ctx *cli.Context) error {
+			if len(ctx.StringSlice(flgDomains)) > 0 || ctx.IsSet(flgCSR) || ctx.IsSet(flgCertName) {
+				log.Fatalf("--%s/-d, --%s/-c, and --%s cannot be used with the daemon: all the stored certificates are renewed", flgDomains, flgCSR, flgCertName)
+			}
+
+			if ctx.IsSet(flgDaemonSchedule) {
+				_, err := parseDaemonSchedule(ctx.String(flgDaemonSchedule))
+				if err != nil {
+					log.Fatalf("--%s: %v", flgDaemonSchedule, err)
+				}
+			}
+
+			return nil
+		},
+		Flags: append([]cli.Flag{
+			&cli.StringFlag{
+				Name: flgDaemonSchedule,
+				Usage: "When to check the certificates: cron-like expressions ('minute hour day-of-month month day-of-week') separated by ';'" +
+					" (e.g. '35 3,15 * * *'). The time zone can be set with a 'CRON_TZ=' prefix. Default: twice a day, at a randomly chosen time.",
+				EnvVars: []string{"LEGO_DAEMON_SCHEDULE"},
+			},
+		}, renewFlags()...),
+	}
+}
+
+func daemon(ctx *cli.Context) error {
+	expr := ctx.String(flgDaemonSchedule)
+	if expr == "" {
+		expr = defaultDaemonSchedule(rand.New(rand.NewSource(time.Now().UnixNano())))
+	}
+
+	schedule, err := parseDaemonSchedule(expr)
+	if err != nil {
+		return fmt.Errorf("--%s: %w", flgDaemonSchedule, err)
+	}
+
+	account, keyType := setupAccount(ctx, NewAccountsStorage(ctx))
+
+	if account.Registration == nil {
+		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
+	}
+
+	certsStorage := NewCertificatesStorage(ctx)
+
+	log.Infof("daemon: the certificates are checked at startup, then on the schedule %q", expr)
+
+	for {
+		renewStoredCertificates(ctx, account, keyType, certsStorage)
+
+		next := nextScheduledRun(schedule, time.Now())
+
+		log.Infof("daemon: next check at %s", next.Format(time.RFC3339))
+
+		select {
+		case <-ctx.Context.Done():
+			log.Println("daemon: stopped")
+			return nil
+
+		case <-time.After(time.Until(next)):
+		}
+	}
+}
+
+// renewStoredCertificates renews the stored certificates that need it, like the renew command.
+// The failures are logged: they don't stop the renewal of the other certificates.
+func renewStoredCertificates(ctx *cli.Context, account *Account, keyType certcrypto.KeyType, certsStorage *CertificatesStorage) {
+	certs, err := certsStorage.ListCertificates(CertificateFilter{})
+	if err != nil {
+		log.Warnf("daemon: could not list the certificates: %v", err)
+		return
+	}
+
+	for _, cert := range certs {
+		if ctx.Context.Err() != nil {
+			return
+		}
+
+		meta := map[string]string{
+			hookEnvAccountEmail: account.Email,
+		}
+
+		err = renewForDomains(ctx, account, keyType, certsStorage.withName(cert.Name), cert.Domains, !ctx.Bool(flgNoBundle), meta)
+		if err != nil {
+			log.Warnf("[%s] daemon: the renewal has failed: %v", cert.Name, err)
+		}
+	}
+}
+
+// parseDaemonSchedule parses the schedule of the daemon: the same expressions as the maintenance windows (--window).
+func parseDaemonSchedule(expr string) ([]*renewalWindow, error) {
+	schedule, err := parseRenewalWindows(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schedule) == 0 {
+		return nil, errors.New("empty schedule")
+	}
+
+	if nextScheduledRun(schedule, time.Now()).IsZero() {
+		return nil, fmt.Errorf("the schedule %q never matches", expr)
+	}
+
+	return schedule, nil
+}
+
+// defaultDaemonSchedule returns a schedule twice a day, at a randomly chosen time:
+// the renewals of all the lego users must not happen at the same time (see the random sleep of the renewal).
+func defaultDaemonSchedule(rnd *rand.Rand) string {
+	hour := rnd.Intn(12)
+
+	return fmt.Sprintf("%d %d,%d * * *", rnd.Intn(60), hour, hour+12)
+}
+
+// nextScheduledRun returns the start of the next minute, after now, matching the schedule.
+// Returns the zero time if the schedule doesn't match within 5 years (ex: "0 0 30 2 *").
+func nextScheduledRun(schedule []*renewalWindow, now time.Time) time.Time {
+	next := now.Truncate(time.Minute).Add(time.Minute)
+
+	for limit := next.AddDate(5, 0, 0); next.Before(limit); next = next.Add(time.Minute) {
+		if inRenewalWindows(schedule, next) {
+			return next
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_nextScheduledRun(t *testing.T) {
+	now := time.Date(2025, time.March, 14, 10, 20, 30, 0, time.UTC) // Friday
+
+	testCases := []struct {
+		desc     string
+		expr     string
+		expected time.Time
+	}{
+		{
+			desc:     "every minute",
+			expr:     "CRON_TZ=UTC * * * * *",
+			expected: time.Date(2025, time.March, 14, 10, 21, 0, 0, time.UTC),
+		},
+		{
+			desc:     "later the same day",
+			expr:     "CRON_TZ=UTC 35 3,15 * * *",
+			expected: time.Date(2025, time.March, 14, 15, 35, 0, 0, time.UTC),
+		},
+		{
+			desc:     "next day",
+			expr:     "CRON_TZ=UTC 0 9 * * *",
+			expected: time.Date(2025, time.March, 15, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "next monday",
+			expr:     "CRON_TZ=UTC 0 2 * * 1",
+			expected: time.Date(2025, time.March, 17, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "several expressions",
+			expr:     "CRON_TZ=UTC 0 2 * * 1; CRON_TZ=UTC 30 12 * * *",
+			expected: time.Date(2025, time.March, 14, 12, 30, 0, 0, time.UTC),
+		},
+		{
+			desc:     "time zone",
+			expr:     "CRON_TZ=Europe/Paris 0 12 * * *",
+			expected: time.Date(2025, time.March, 14, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "never",
+			expr: "CRON_TZ=UTC 0 0 30 2 *",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			schedule, err := parseRenewalWindows(test.expr)
+			require.NoError(t, err)
+
+			next := nextScheduledRun(schedule, now)
+
+			assert.True(t, test.expected.Equal(next), "expected %s, got %s", test.expected, next)
+		})
+	}
+}
+
+func Test_parseDaemonSchedule_errors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		expr     string
+		expected string
+	}{
+		{
+			desc:     "empty",
+			expr:     " ; ",
+			expected: "empty schedule",
+		},
+		{
+			desc:     "invalid expression",
+			expr:     "* 24 * * *",
+			expected: `window "* 24 * * *": hour: invalid value "24": expected a number between 0 and 23`,
+		},
+		{
+			desc:     "never",
+			expr:     "0 0 31 4 *",
+			expected: `the schedule "0 0 31 4 *" never matches`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseDaemonSchedule(test.expr)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func Test_defaultDaemonSchedule(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	for range 100 {
+		expr := defaultDaemonSchedule(rnd)
+
+		schedule, err := parseDaemonSchedule(expr)
+		require.NoError(t, err, expr)
+
+		// Twice a day.
+		var count int
+
+		start := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.Local)
+		for next := start; next.Before(start.AddDate(0, 0, 1)); next = next.Add(time.Minute) {
+			if inRenewalWindows(schedule, next) {
+				count++
+			}
+		}
+
+		assert.Equal(t, 2, count, expr)
+	}
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"text/tabwriter"
+
+	"github.com/go-acme/lego/v4/internal/devserver"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgDevServerDNSListen        = "dns.listen"
+	flgDevServerHTTPListen       = "http.listen"
+	flgDevServerManagementListen = "management.listen"
+	flgDevServerDefaultIPv4      = "default-ipv4"
+	flgDevServerDefaultIPv6      = "default-ipv6"
+	flgDevServerPebbleConfig     = "pebble-config"
+)
+
+func createDevServer() *cli.Command {
+	return &cli.Command{
+		Name:  "devserver",
+		Usage: "Starts a challenge test server (DNS-01 and HTTP-01) for the local development with Pebble.",
+		Description: "The DNS server answers the '_acme-challenge' TXT queries, and all the A/AAAA queries with the default IP addresses." +
+			" The challenges are set through the management API, compatible with pebble-challtestsrv and the 'httpreq' DNS provider.",
+		Action: devServer,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  flgDevServerDNSListen,
+				Usage: "The address of the DNS server (UDP and TCP).",
+				Value: ":8053",
+			},
+			&cli.StringFlag{
+				Name:  flgDevServerHTTPListen,
+				Usage: "The address of the HTTP-01 challenge server. Empty to disable it.",
+				Value: ":5002",
+			},
+			&cli.StringFlag{
+				Name:  flgDevServerManagementListen,
+				Usage: "The address of the management API.",
+				Value: ":8055",
+			},
+			&cli.StringFlag{
+				Name:  flgDevServerDefaultIPv4,
+				Usage: "The IPv4 address returned for all the A queries. Empty to disable the A records.",
+				Value: "127.0.0.1",
+			},
+			&cli.StringFlag{
+				Name:  flgDevServerDefaultIPv6,
+				Usage: "The IPv6 address returned for all the AAAA queries. Empty to disable the AAAA records.",
+			},
+			&cli.StringFlag{
+				Name:  flgDevServerPebbleConfig,
+				Usage: "Writes a Pebble configuration file (and its certificate) to this path.",
+			},
+		},
+	}
+}
+
+func devServer(ctx *cli.Context) error {
+	config := devserver.Config{
+		DNSAddress:        ctx.String(flgDevServerDNSListen),
+		HTTPAddress:       ctx.String(flgDevServerHTTPListen),
+		ManagementAddress: ctx.String(flgDevServerManagementListen),
+		DefaultIPv4:       ctx.String(flgDevServerDefaultIPv4),
+		DefaultIPv6:       ctx.String(flgDevServerDefaultIPv6),
+	}
+
+	server, err := devserver.New(config)
+	if err != nil {
+		return err
+	}
+
+	var certPath string
+
+	if ctx.IsSet(flgDevServerPebbleConfig) {
+		certPath, err = devserver.WritePebbleConfig(ctx.String(flgDevServerPebbleConfig), config.HTTPAddress)
+		if err != nil {
+			return fmt.Errorf("pebble configuration: %w", err)
+		}
+	}
+
+	err = displayDevServerUsage(ctx, config, certPath)
+	if err != nil {
+		return err
+	}
+
+	return server.Start(ctx.Context)
+}
+
+func displayDevServerUsage(ctx *cli.Context, config devserver.Config, certPath string) error {
+	dnsAddress := localAddress(config.DNSAddress)
+	_, dnsPort, _ := net.SplitHostPort(dnsAddress)
+
+	w := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 2, ' ', 0)
+	ew := &errWriter{w: w}
+
+	ew.writeln("Start Pebble with:")
+	ew.writeln()
+
+	if ctx.IsSet(flgDevServerPebbleConfig) {
+		ew.writef("\t$ pebble -config %s -dnsserver %s\n", ctx.String(flgDevServerPebbleConfig), dnsAddress)
+	} else {
+		ew.writef("\t$ pebble -dnsserver %s\n", dnsAddress)
+	}
+
+	ew.writeln()
+	ew.writeln("Then, use lego with:")
+	ew.writeln()
+
+	if certPath != "" {
+		ew.writef("\t$ export %s=%s\n", "LEGO_CA_CERTIFICATES", certPath)
+	}
+
+	ew.writef("\t$ %s=http://%s lego --server https://localhost:14000/dir --dns httpreq --dns.resolvers %s --dns.authoritative-port %s ...\n",
+		"HTTPREQ_ENDPOINT", localAddress(config.ManagementAddress), dnsAddress, dnsPort)
+	ew.writeln()
+
+	if ew.err != nil {
+		return ew.err
+	}
+
+	return w.Flush()
+}
+
+// localAddress replaces the unspecified host of an address by the loopback address.
+func localAddress(address string) string {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+
+	if host == "" || net.ParseIP(host).IsUnspecified() {
+		host = "127.0.0.1"
+	}
+
+	return net.JoinHostPort(host, port)
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flgJournalDomain  = "domain"
+	flgJournalSince   = "since"
+	flgJournalPending = "pending"
+	flgJournalJSON    = "json"
+)
+
+func createDNS() *cli.Command {
+	return &cli.Command{
+		Name:  "dns",
+		Usage: "Manage the DNS-01 challenge records.",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "journal",
+				Usage:  "Display the journal of the DNS-01 challenge records created and deleted by lego.",
+				Action: dnsJournal,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  flgJournalDomain,
+						Usage: "Only display the records of this domain (and its subdomains).",
+					},
+					&cli.DurationFlag{
+						Name:  flgJournalSince,
+						Usage: "Only display the records changed during this period (e.g. 24h).",
+					},
+					&cli.BoolFlag{
+						Name:  flgJournalPending,
+						Usage: "Only display the records created and not deleted (cleanup leaks).",
+					},
+					&cli.BoolFlag{
+						Name:  flgJournalJSON,
+						Usage: "Display the entries as JSON lines.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func dnsJournal(ctx *cli.Context) error {
+	entries, err := dns01.ReadJournal(getDNSJournalPath(ctx))
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool(flgJournalPending) {
+		entries = dns01.PendingJournalEntries(entries)
+	}
+
+	entries = filterJournal(entries, ctx.String(flgJournalDomain), ctx.Duration(flgJournalSince), time.Now())
+
+	if ctx.Bool(flgJournalJSON) {
+		encoder := json.NewEncoder(ctx.App.Writer)
+
+		for _, entry := range entries {
+			err = encoder.Encode(entry)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if len(entries) == 0 {
+		_, err = fmt.Fprintln(ctx.App.Writer, "No entries found.")
+		return err
+	}
+
+	w := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 2, ' ', 0)
+	ew := &errWriter{w: w}
+
+	ew.writeln("TIME\tACTION\tOUTCOME\tPROVIDER\tZONE\tFQDN\tVALUE\tERROR")
+
+	for _, entry := range entries {
+		ew.writef("%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.StartedAt.Local().Format(time.RFC3339), entry.Action, entry.Outcome, entry.Provider,
+			entry.Zone, entry.FQDN, entry.Value, entry.Error)
+	}
+
+	if ew.err != nil {
+		return ew.err
+	}
+
+	return w.Flush()
+}
+
+// filterJournal keeps the entries of the domain (and its subdomains) changed during the period before now.
+func filterJournal(entries []dns01.JournalEntry, domain string, since time.Duration, now time.Time) []dns01.JournalEntry {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+
+	var result []dns01.JournalEntry
+
+	for _, entry := range entries {
+		if since > 0 && entry.StartedAt.Before(now.Add(-since)) {
+			continue
+		}
+
+		if domain != "" {
+			name := strings.ToLower(strings.TrimPrefix(entry.Domain, "*."))
+			if name != domain && !strings.HasSuffix(name, "."+domain) {
+				continue
+			}
+		}
+
+		result = append(result, entry)
+	}
+
+	return result
+}
+
+// setupDNSJournal wraps the DNS provider to record the changes inside the journal (--dns.journal).
+func setupDNSJournal(ctx *cli.Context, provider challenge.Provider, name string) (challenge.Provider, error) {
+	if !ctx.Bool(flgDNSJournal) {
+		return provider, nil
+	}
+
+	return dns01.WithJournal(provider, name, getDNSJournalPath(ctx))
+}
+
+func getDNSJournalPath(ctx *cli.Context) string {
+	return filepath.Join(ctx.String(flgPath), "dns", "journal.jsonl")
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterJournal(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	entries := []dns01.JournalEntry{
+		{Domain: "example.com", StartedAt: now.Add(-48 * time.Hour)},
+		{Domain: "www.example.com", StartedAt: now.Add(-time.Hour)},
+		{Domain: "example.org", StartedAt: now.Add(-time.Hour)},
+		{Domain: "notexample.com", StartedAt: now.Add(-time.Hour)},
+	}
+
+	testCases := []struct {
+		desc     string
+		domain   string
+		since    time.Duration
+		expected []dns01.JournalEntry
+	}{
+		{
+			desc:     "no filter",
+			expected: entries,
+		},
+		{
+			desc:     "domain",
+			domain:   "Example.com.",
+			expected: []dns01.JournalEntry{entries[0], entries[1]},
+		},
+		{
+			desc:     "since",
+			since:    24 * time.Hour,
+			expected: []dns01.JournalEntry{entries[1], entries[2], entries[3]},
+		},
+		{
+			desc:     "domain and since",
+			domain:   "example.com",
+			since:    24 * time.Hour,
+			expected: []dns01.JournalEntry{entries[1]},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, filterJournal(entries, test.domain, test.since, now))
+		})
+	}
+}
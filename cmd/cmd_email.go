@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge/emailreply00"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgEmailAddress = "address"
+)
+
+func createEmail() *cli.Command {
+	return &cli.Command{
+		Name:  "email",
+		Usage: "Register an account, then create an S/MIME certificate for email addresses (RFC 8823).",
+		Description: "The email-reply-00 challenges are solved manually:" +
+			" paste the subject of the challenge email sent by the CA, then reply to this email with the displayed response.",
+		Before: func(ctx *cli.Context) error {
+			addresses := ctx.StringSlice(flgEmailAddress)
+			if len(addresses) == 0 {
+				log.Fatalf("Please specify --%s", flgEmailAddress)
+			}
+
+			for _, address := range addresses {
+				addr, err := mail.ParseAddress(address)
+				if err != nil || addr.Address != address {
+					log.Fatalf("Invalid email address: %q", address)
+				}
+			}
+
+			return nil
+		},
+		Action: email,
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  flgEmailAddress,
+				Usage: "Add an email address to the certificate. Can be specified multiple times.",
+			},
+			&cli.BoolFlag{
+				Name:  flgNoBundle,
+				Usage: "Do not create a certificate bundle by adding the issuers certificate to the new certificate.",
+			},
+			&cli.StringFlag{
+				Name:  flgPrivateKey,
+				Usage: "Path to private key (in PEM encoding) for the certificate. By default, the private key is generated.",
+			},
+			&cli.StringFlag{
+				Name: flgPreferredChain,
+				Usage: "If the CA offers multiple certificate chains, prefer the chain with an issuer matching this Subject Common Name." +
+					" If no match, the default offered chain will be used.",
+			},
+			&cli.StringFlag{
+				Name:  flgProfile,
+				Usage: "If the CA offers multiple certificate profiles (draft-ietf-acme-profiles), choose this one.",
+			},
+			&cli.StringFlag{
+				Name:  flgRunHook,
+				Usage: "Define a hook. The hook is executed when the certificates are effectively created.",
+			},
+			&cli.DurationFlag{
+				Name:  flgRunHookTimeout,
+				Usage: "Define the timeout for the hook execution.",
+				Value: 2 * time.Minute,
+			},
+		},
+	}
+}
+
+func email(ctx *cli.Context) error {
+	addresses := ctx.StringSlice(flgEmailAddress)
+
+	accountsStorage := NewAccountsStorage(ctx)
+
+	account, keyType := setupAccount(ctx, accountsStorage)
+
+	client, err := newClient(ctx, account, keyType)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = client.Challenge.SetEmailReply00Provider(emailreply00.NewProviderManual())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ensureRegistration(ctx, client, account, accountsStorage)
+
+	certsStorage := NewCertificatesStorage(ctx)
+	certsStorage.CreateRootFolder()
+
+	request := certificate.ObtainRequest{
+		Domains:        addresses,
+		Bundle:         !ctx.Bool(flgNoBundle),
+		PreferredChain: ctx.String(flgPreferredChain),
+		Profile:        ctx.String(flgProfile),
+	}
+
+	if ctx.IsSet(flgPrivateKey) {
+		request.PrivateKey, err = loadPrivateKey(ctx.String(flgPrivateKey))
+		if err != nil {
+			return fmt.Errorf("load private key: %w", err)
+		}
+	}
+
+	cert, err := client.Certificate.ObtainContext(ctx.Context, request)
+	if err != nil {
+		log.Fatalf("Could not obtain certificates:\n\t%v", err)
+	}
+
+	err = verifyCertificate(ctx, cert, addresses)
+	if err != nil {
+		log.Fatalf("[%s] The certificate has not been saved: %v", cert.Domain, err)
+	}
+
+	err = certsStorage.SaveResource(cert)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	meta := map[string]string{
+		hookEnvAccountEmail: account.Email,
+	}
+
+	addPathToMetadata(meta, cert.Domain, cert, certsStorage)
+
+	return launchHook(ctx.String(flgRunHook), ctx.Duration(flgRunHookTimeout), meta)
+}
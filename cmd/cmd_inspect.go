@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/storage"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/crypto/ocsp"
+)
+
+// Flag names.
+const (
+	flgInspectJSON = "json"
+	flgInspectOCSP = "ocsp"
+)
+
+func createInspect() *cli.Command {
+	return &cli.Command{
+		Name:   "inspect",
+		Usage:  "Display a summary of stored certificates: validity, chain verification, SCTs, OCSP status.",
+		Action: inspect,
+		Before: func(ctx *cli.Context) error {
+			if len(ctx.StringSlice(flgDomains)) == 0 && ctx.String(flgCertName) == "" {
+				log.Fatalf("Please specify --%s/-d or --%s", flgDomains, flgCertName)
+			}
+
+			return nil
+		},
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  flgInspectJSON,
+				Usage: "Display the summaries as JSON.",
+			},
+			&cli.BoolFlag{
+				Name: flgInspectOCSP,
+				Usage: "Request the OCSP status of the certificates from the OCSP responder of the CA." +
+					" Use --" + flgInspectOCSP + "=false to stay offline.",
+				Value: true,
+			},
+		},
+	}
+}
+
+// inspectReport the summary of a stored certificate.
+type inspectReport struct {
+	Certificate listedCertificate `json:"certificate"`
+
+	// Chain the certificates of the chain, from the leaf, including the issuer certificate file.
+	Chain []inspectedChainCertificate `json:"chain"`
+
+	// Verified reports whether the chain verifies against the trust store (--verify.roots),
+	// and whether the private key matches the certificate.
+	Verified          bool   `json:"verified"`
+	VerificationError string `json:"verificationError,omitempty"`
+
+	SCTs []inspectedSCT `json:"scts"`
+
+	// OCSP the OCSP status, only with --ocsp.
+	OCSP *inspectedOCSP `json:"ocsp,omitempty"`
+}
+
+type inspectedChainCertificate struct {
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	Serial    string    `json:"serial"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+}
+
+type inspectedSCT struct {
+	// LogID the ID of the Certificate Transparency log (base64).
+	LogID     string    `json:"logId"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type inspectedOCSP struct {
+	Status     string    `json:"status,omitempty"`
+	ProducedAt time.Time `json:"producedAt,omitzero"`
+	NextUpdate time.Time `json:"nextUpdate,omitzero"`
+	RevokedAt  time.Time `json:"revokedAt,omitzero"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func inspect(ctx *cli.Context) error {
+	certsStorage := NewCertificatesStorage(ctx)
+
+	names := ctx.StringSlice(flgDomains)
+
+	// All the domains of the certificate share the same files.
+	if name := ctx.String(flgCertName); name != "" {
+		names = []string{name}
+	}
+
+	roots, err := getVerifyRoots(ctx)
+	if err != nil {
+		return err
+	}
+
+	var ocspClient *http.Client
+	if ctx.Bool(flgInspectOCSP) {
+		ocspClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	var reports []inspectReport
+
+	for _, name := range names {
+		report, err := inspectCertificate(ctx.Context, certsStorage, name, roots, ocspClient)
+		if err != nil {
+			return fmt.Errorf("[%s] %w", name, err)
+		}
+
+		reports = append(reports, report)
+	}
+
+	if ctx.Bool(flgInspectJSON) {
+		encoder := json.NewEncoder(ctx.App.Writer)
+		encoder.SetIndent("", "\t")
+
+		return encoder.Encode(reports)
+	}
+
+	for _, report := range reports {
+		err = printInspectReport(ctx.App.Writer, report, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// inspectCertificate builds the summary of a stored certificate.
+// The OCSP status is only requested if ocspClient is not nil.
+func inspectCertificate(ctx context.Context, certsStorage *CertificatesStorage, name string, roots *x509.CertPool, ocspClient *http.Client) (inspectReport, error) {
+	bundle, err := certsStorage.ReadFile(name, certExt)
+	if err != nil {
+		return inspectReport{}, err
+	}
+
+	chain, err := certcrypto.ParsePEMBundle(bundle)
+	if err != nil {
+		return inspectReport{}, err
+	}
+
+	leaf := chain[0]
+
+	res := &certificate.Resource{Domain: name, Certificate: bundle}
+
+	// The issuer certificate file completes the chain of the certificates saved without bundle (--no-bundle).
+	res.IssuerCertificate, err = certsStorage.ReadFile(name, issuerExt)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return inspectReport{}, err
+	}
+
+	if res.IssuerCertificate != nil {
+		issuers, errP := certcrypto.ParsePEMBundle(res.IssuerCertificate)
+		if errP != nil {
+			return inspectReport{}, fmt.Errorf("issuer: %w", errP)
+		}
+
+		for _, issuer := range issuers {
+			if !slices.ContainsFunc(chain, issuer.Equal) {
+				chain = append(chain, issuer)
+			}
+		}
+	}
+
+	res.PrivateKey, err = certsStorage.ReadFile(name, keyExt)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return inspectReport{}, err
+	}
+
+	listed := newListedCertificate(certsStorage.certName(name), certsStorage.GetFileName(name, certExt), leaf)
+
+	report := inspectReport{Certificate: listed}
+
+	for _, cert := range chain {
+		report.Chain = append(report.Chain, inspectedChainCertificate{
+			Subject:   cert.Subject.String(),
+			Issuer:    cert.Issuer.String(),
+			Serial:    cert.SerialNumber.Text(16),
+			NotBefore: cert.NotBefore,
+			NotAfter:  cert.NotAfter,
+		})
+	}
+
+	err = certificate.Verify(res, certificate.VerifyOptions{Roots: roots})
+	if err != nil {
+		report.VerificationError = err.Error()
+	} else {
+		report.Verified = true
+	}
+
+	scts, err := certcrypto.ExtractSCTs(leaf)
+	if err != nil {
+		return inspectReport{}, err
+	}
+
+	for _, sct := range scts {
+		report.SCTs = append(report.SCTs, inspectedSCT{
+			LogID:     base64.StdEncoding.EncodeToString(sct.LogID),
+			Timestamp: sct.Timestamp,
+		})
+	}
+
+	if ocspClient != nil {
+		report.OCSP = inspectOCSP(ctx, ocspClient, chain)
+	}
+
+	return report, nil
+}
+
+// inspectOCSP requests the OCSP status of the leaf certificate of the chain.
+func inspectOCSP(ctx context.Context, client *http.Client, chain []*x509.Certificate) *inspectedOCSP {
+	var bundle []byte
+	for _, cert := range chain {
+		bundle = append(bundle, certcrypto.PEMEncode(certcrypto.DERCertificateBytes(cert.Raw))...)
+	}
+
+	_, resp, err := certificate.FetchOCSP(ctx, client, bundle)
+	if err != nil {
+		return &inspectedOCSP{Error: err.Error()}
+	}
+
+	result := &inspectedOCSP{
+		ProducedAt: resp.ProducedAt,
+		NextUpdate: resp.NextUpdate,
+	}
+
+	switch resp.Status {
+	case ocsp.Good:
+		result.Status = "good"
+	case ocsp.Revoked:
+		result.Status = "revoked"
+		result.RevokedAt = resp.RevokedAt
+	case ocsp.Unknown:
+		result.Status = "unknown"
+	default:
+		result.Status = "server failed"
+	}
+
+	return result
+}
+
+func printInspectReport(w io.Writer, report inspectReport, now time.Time) error {
+	ew := &errWriter{w: w}
+
+	cert := report.Certificate
+
+	ew.writeln(cert.Name)
+	ew.writef("  Path: %s\n", cert.Path)
+	ew.writef("  SANs: %s\n", strings.Join(cert.SANs, ", "))
+	ew.writef("  Serial: %s\n", cert.Serial)
+	ew.writef("  Key Type: %s\n", cert.KeyType)
+	ew.writef("  Not Before: %s\n", cert.NotBefore.Format(time.RFC3339))
+	ew.writef("  Not After: %s (%s)\n", cert.NotAfter.Format(time.RFC3339), formatExpiry(cert.NotAfter, now))
+	ew.writef("  Must Staple: %t\n", cert.MustStaple)
+
+	ew.writeln("  Chain:")
+
+	for i, c := range report.Chain {
+		ew.writef("    %d: %s\n", i, c.Subject)
+		ew.writef("       Issuer: %s\n", c.Issuer)
+		ew.writef("       Not After: %s\n", c.NotAfter.Format(time.RFC3339))
+	}
+
+	if report.Verified {
+		ew.writeln("  Verification: OK")
+	} else {
+		ew.writef("  Verification: FAILED (%s)\n", report.VerificationError)
+	}
+
+	ew.writef("  SCTs: %d\n", len(report.SCTs))
+
+	for _, sct := range report.SCTs {
+		ew.writef("    %s %s\n", sct.Timestamp.Format(time.RFC3339), sct.LogID)
+	}
+
+	switch {
+	case report.OCSP == nil:
+		// --ocsp=false
+
+	case report.OCSP.Error != "":
+		ew.writef("  OCSP: %s\n", report.OCSP.Error)
+
+	case !report.OCSP.RevokedAt.IsZero():
+		ew.writef("  OCSP: %s (at %s)\n", report.OCSP.Status, report.OCSP.RevokedAt.Format(time.RFC3339))
+
+	default:
+		ew.writef("  OCSP: %s (produced at %s)\n", report.OCSP.Status, report.OCSP.ProducedAt.Format(time.RFC3339))
+	}
+
+	ew.writeln()
+
+	return ew.err
+}
+
+// formatExpiry describes the time left before the expiry.
+func formatExpiry(notAfter, now time.Time) string {
+	if !notAfter.After(now) {
+		return fmt.Sprintf("expired %d days ago", int(now.Sub(notAfter).Hours()/24))
+	}
+
+	return fmt.Sprintf("expires in %d days", int(notAfter.Sub(now).Hours()/24))
+}
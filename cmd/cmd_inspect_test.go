@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_inspectCertificate(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	storage := newTestCertificatesStorage(t)
+
+	writeTestCertificate(t, filepath.Join(storage.rootPath, "example.com.crt"), notAfter, "example.com", "www.example.com")
+
+	report, err := inspectCertificate(t.Context(), storage, "example.com", nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", report.Certificate.Name)
+	assert.Equal(t, []string{"example.com", "www.example.com"}, report.Certificate.SANs)
+	assert.Equal(t, notAfter.UTC(), report.Certificate.NotAfter)
+
+	require.Len(t, report.Chain, 1)
+	assert.Equal(t, "1", report.Chain[0].Serial)
+
+	// The test certificate is self-signed: it doesn't verify against the system roots.
+	assert.False(t, report.Verified)
+	assert.NotEmpty(t, report.VerificationError)
+
+	assert.Empty(t, report.SCTs)
+	assert.Nil(t, report.OCSP)
+}
+
+func Test_inspectCertificate_notFound(t *testing.T) {
+	storage := newTestCertificatesStorage(t)
+
+	_, err := inspectCertificate(t.Context(), storage, "example.com", nil, nil)
+	require.Error(t, err)
+}
+
+func Test_printInspectReport(t *testing.T) {
+	now := time.Date(2025, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	report := inspectReport{
+		Certificate: listedCertificate{
+			Name:      "example.com",
+			SANs:      []string{"example.com", "www.example.com"},
+			Serial:    "fa3c",
+			NotBefore: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+			NotAfter:  time.Date(2025, time.May, 30, 0, 0, 0, 0, time.UTC),
+			KeyType:   "P256",
+			Path:      "/data/certificates/example.com.crt",
+		},
+		Chain: []inspectedChainCertificate{
+			{Subject: "CN=example.com", Issuer: "CN=R1", NotAfter: time.Date(2025, time.May, 30, 0, 0, 0, 0, time.UTC)},
+		},
+		Verified: true,
+		SCTs: []inspectedSCT{
+			{LogID: "AAEC", Timestamp: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		OCSP: &inspectedOCSP{Status: "good", ProducedAt: time.Date(2025, time.April, 30, 0, 0, 0, 0, time.UTC)},
+	}
+
+	buf := &bytes.Buffer{}
+
+	err := printInspectReport(buf, report, now)
+	require.NoError(t, err)
+
+	expected := `example.com
+  Path: /data/certificates/example.com.crt
+  SANs: example.com, www.example.com
+  Serial: fa3c
+  Key Type: P256
+  Not Before: 2025-03-01T00:00:00Z
+  Not After: 2025-05-30T00:00:00Z (expires in 28 days)
+  Must Staple: false
+  Chain:
+    0: CN=example.com
+       Issuer: CN=R1
+       Not After: 2025-05-30T00:00:00Z
+  Verification: OK
+  SCTs: 1
+    2025-03-01T00:00:00Z AAEC
+  OCSP: good (produced at 2025-04-30T00:00:00Z)
+
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func Test_formatExpiry(t *testing.T) {
+	now := time.Date(2025, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		notAfter time.Time
+		expected string
+	}{
+		{
+			desc:     "valid",
+			notAfter: now.Add(30*24*time.Hour + time.Hour),
+			expected: "expires in 30 days",
+		},
+		{
+			desc:     "less than a day",
+			notAfter: now.Add(time.Hour),
+			expected: "expires in 0 days",
+		},
+		{
+			desc:     "expired",
+			notAfter: now.Add(-2*24*time.Hour - time.Hour),
+			expected: "expired 2 days ago",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, formatExpiry(test.notAfter, now))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/go-acme/lego/v4/internal/dns/scaffold"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgNewProviderName         = "name"
+	flgNewProviderURL          = "url"
+	flgNewProviderSince        = "since"
+	flgNewProviderRoot         = "root"
+	flgNewProviderSkipGenerate = "skip-generate"
+)
+
+func createInternal() *cli.Command {
+	return &cli.Command{
+		Name:   "internal",
+		Usage:  "Tools for the development of lego.",
+		Hidden: true,
+		Subcommands: []*cli.Command{
+			createNewProvider(),
+		},
+	}
+}
+
+func createNewProvider() *cli.Command {
+	return &cli.Command{
+		Name:      "new-provider",
+		Usage:     "Generates the skeleton of a new DNS provider inside the lego repository.",
+		ArgsUsage: "<code>",
+		Description: "Creates the provider, its TOML descriptor, its API client, and the tests, " +
+			"then generates the configuration, the registry of the providers, and the documentation ('make generate-dns').",
+		Action: newProvider,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  flgNewProviderName,
+				Usage: "The name of the DNS provider (ex: 'Example DNS'). Default: the code.",
+			},
+			&cli.StringFlag{
+				Name:  flgNewProviderURL,
+				Usage: "The URL of the website of the DNS provider.",
+			},
+			&cli.StringFlag{
+				Name:  flgNewProviderSince,
+				Usage: "The first version of lego containing the DNS provider (ex: 'v4.30.0').",
+			},
+			&cli.StringFlag{
+				Name:  flgNewProviderRoot,
+				Usage: "The root directory of the lego repository.",
+				Value: ".",
+			},
+			&cli.BoolFlag{
+				Name:  flgNewProviderSkipGenerate,
+				Usage: "Skips the generation of the configuration, the registry, and the documentation.",
+			},
+		},
+	}
+}
+
+func newProvider(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("the code of the DNS provider is required (ex: 'lego internal new-provider example')")
+	}
+
+	provider := scaffold.Provider{
+		Code:  ctx.Args().First(),
+		Name:  ctx.String(flgNewProviderName),
+		URL:   ctx.String(flgNewProviderURL),
+		Since: ctx.String(flgNewProviderSince),
+	}
+
+	root := ctx.String(flgNewProviderRoot)
+
+	files, err := scaffold.Generate(root, provider)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		fmt.Println("Created:", file)
+	}
+
+	if !ctx.Bool(flgNewProviderSkipGenerate) {
+		cmd := exec.CommandContext(ctx.Context, "go", "generate", "./internal/dns/...")
+		cmd.Dir = root
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		err = cmd.Run()
+		if err != nil {
+			return fmt.Errorf("generate the configuration, the registry, and the documentation: %w", err)
+		}
+	}
+
+	fmt.Print(`
+Next steps:
+	- implement the API client (internal/client.go) and its tests,
+	- complete the TOML descriptor (description, links, configuration),
+	- run 'make generate-dns' after each change of the TOML descriptor.
+`)
+
+	return nil
+}
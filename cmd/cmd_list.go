@@ -1,21 +1,32 @@
 package cmd
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
 	"github.com/urfave/cli/v2"
 )
 
 const (
 	flgAccounts = "accounts"
 	flgNames    = "names"
+	flgListJSON = "json"
+	flgListARI  = "ari"
 )
 
 func createList() *cli.Command {
@@ -23,6 +34,17 @@ func createList() *cli.Command {
 		Name:   "list",
 		Usage:  "Display certificates and accounts information.",
 		Action: list,
+		Before: func(ctx *cli.Context) error {
+			if ctx.Bool(flgListJSON) && ctx.Bool(flgNames) {
+				log.Fatalf("--%s cannot be used with --%s", flgListJSON, flgNames)
+			}
+
+			if ctx.Bool(flgListARI) && !ctx.Bool(flgListJSON) {
+				log.Fatalf("--%s requires --%s", flgListARI, flgListJSON)
+			}
+
+			return nil
+		},
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:    flgAccounts,
@@ -34,6 +56,16 @@ func createList() *cli.Command {
 				Aliases: []string{"n"},
 				Usage:   "Display certificate common names only.",
 			},
+			&cli.BoolFlag{
+				Name: flgListJSON,
+				Usage: "Display the certificates (and the accounts with --" + flgAccounts + ") as JSON:" +
+					" SANs, serial number, validity, issuer, key type, OCSP must staple, renewal failures.",
+			},
+			&cli.BoolFlag{
+				Name: flgListARI,
+				Usage: "Include the suggested renewal window (RFC 9773) of the certificates, requested from the CA (--server)." +
+					" Only works with --" + flgListJSON + ".",
+			},
 			// fake email, needed by NewAccountsStorage
 			&cli.StringFlag{
 				Name:   flgEmail,
@@ -44,7 +76,50 @@ func createList() *cli.Command {
 	}
 }
 
+// listedCertificate a certificate displayed by the list command.
+type listedCertificate struct {
+	Name string   `json:"name"`
+	SANs []string `json:"sans"`
+
+	// Serial the serial number (hexadecimal).
+	Serial    string    `json:"serial"`
+	NotBefore time.Time `json:"notBefore"`
+	NotAfter  time.Time `json:"notAfter"`
+	Issuer    string    `json:"issuer"`
+
+	// KeyType the type of the public key, with the values of --key-type (ex: "2048", "P256").
+	KeyType    string `json:"keyType"`
+	MustStaple bool   `json:"mustStaple"`
+
+	Path string `json:"path"`
+
+	RenewalFailures *renewalFailures `json:"renewalFailures,omitempty"`
+
+	// RenewalInfo the renewal information (ARI) of the certificate, only with --ari.
+	RenewalInfo *listedRenewalInfo `json:"renewalInfo,omitempty"`
+
+	cert *x509.Certificate
+}
+
+// listedRenewalInfo the renewal information (RFC 9773) of a certificate, or the error of the request.
+type listedRenewalInfo struct {
+	SuggestedWindow *acme.Window `json:"suggestedWindow,omitempty"`
+	ExplanationURL  string       `json:"explanationURL,omitempty"`
+	Error           string       `json:"error,omitempty"`
+}
+
+// listedAccount an account displayed by the list command.
+type listedAccount struct {
+	Email string `json:"email"`
+	URI   string `json:"uri"`
+	Path  string `json:"path"`
+}
+
 func list(ctx *cli.Context) error {
+	if ctx.Bool(flgListJSON) {
+		return listJSON(ctx)
+	}
+
 	if ctx.Bool(flgAccounts) && !ctx.Bool(flgNames) {
 		if err := listAccount(ctx); err != nil {
 			return err
@@ -54,17 +129,51 @@ func list(ctx *cli.Context) error {
 	return listCertificates(ctx)
 }
 
-func listCertificates(ctx *cli.Context) error {
-	certsStorage := NewCertificatesStorage(ctx)
+// listJSON displays the inventory of the certificates (and of the accounts) as a JSON document,
+// for the monitoring systems.
+func listJSON(ctx *cli.Context) error {
+	certs, err := readListedCertificates(NewCertificatesStorage(ctx))
+	if err != nil {
+		return err
+	}
+
+	if ctx.Bool(flgListARI) && len(certs) > 0 {
+		addRenewalInfo(ctx, certs)
+	}
 
-	matches, err := filepath.Glob(filepath.Join(certsStorage.GetRootPath(), "*.crt"))
+	inventory := struct {
+		Certificates []listedCertificate `json:"certificates"`
+		Accounts     []listedAccount     `json:"accounts,omitempty"`
+	}{
+		Certificates: certs,
+	}
+
+	if inventory.Certificates == nil {
+		inventory.Certificates = []listedCertificate{}
+	}
+
+	if ctx.Bool(flgAccounts) {
+		inventory.Accounts, err = readListedAccounts(NewAccountsStorage(ctx))
+		if err != nil {
+			return err
+		}
+	}
+
+	encoder := json.NewEncoder(ctx.App.Writer)
+	encoder.SetIndent("", "\t")
+
+	return encoder.Encode(inventory)
+}
+
+func listCertificates(ctx *cli.Context) error {
+	certs, err := readListedCertificates(NewCertificatesStorage(ctx))
 	if err != nil {
 		return err
 	}
 
 	names := ctx.Bool(flgNames)
 
-	if len(matches) == 0 {
+	if len(certs) == 0 {
 		if !names {
 			fmt.Println("No certificates found.")
 		}
@@ -76,85 +185,179 @@ func listCertificates(ctx *cli.Context) error {
 		fmt.Println("Found the following certs:")
 	}
 
-	for _, filename := range matches {
-		if strings.HasSuffix(filename, issuerExt) {
+	for _, cert := range certs {
+		if names {
+			fmt.Println(cert.Name)
 			continue
 		}
 
-		data, err := os.ReadFile(filename)
-		if err != nil {
-			return err
+		fmt.Println("  Certificate Name:", cert.Name)
+		fmt.Println("    Domains:", strings.Join(cert.cert.DNSNames, ", "))
+
+		if len(cert.cert.IPAddresses) > 0 {
+			fmt.Println("    IPs:", formatIPAddresses(cert.cert.IPAddresses))
 		}
 
-		pCert, err := certcrypto.ParsePEMCertificate(data)
-		if err != nil {
-			return err
+		fmt.Println("    Expiry Date:", cert.cert.NotAfter)
+
+		if failures := cert.RenewalFailures; failures != nil {
+			fmt.Printf("    Failing Since: %s (%d attempts, last error: %s)\n", failures.FailingSince, failures.Attempts, failures.LastError)
 		}
 
-		name, err := certcrypto.GetCertificateMainDomain(pCert)
+		fmt.Println("    Certificate Path:", cert.Path)
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// readListedCertificates reads the certificates of the storage, with their renewal failures.
+func readListedCertificates(certsStorage *CertificatesStorage) ([]listedCertificate, error) {
+	stored, err := certsStorage.ListCertificates(CertificateFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	var certs []listedCertificate
+
+	for _, sc := range stored {
+		cert := newListedCertificate(sc.Name, sc.Path, sc.x509Cert)
+
+		cert.RenewalFailures, err = readRenewalFailures(certsStorage.withName(sc.Name), sc.Name)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		if names {
-			fmt.Println(name)
-		} else {
-			fmt.Println("  Certificate Name:", name)
-			fmt.Println("    Domains:", strings.Join(pCert.DNSNames, ", "))
+		certs = append(certs, cert)
+	}
 
-			if len(pCert.IPAddresses) > 0 {
-				fmt.Println("    IPs:", formatIPAddresses(pCert.IPAddresses))
-			}
+	return certs, nil
+}
 
-			fmt.Println("    Expiry Date:", pCert.NotAfter)
-			fmt.Println("    Certificate Path:", filename)
-			fmt.Println()
-		}
+func newListedCertificate(name, filename string, cert *x509.Certificate) listedCertificate {
+	sans := append([]string{}, cert.DNSNames...)
+
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
 	}
 
-	return nil
+	sans = append(sans, cert.EmailAddresses...)
+
+	return listedCertificate{
+		Name:       name,
+		SANs:       sans,
+		Serial:     cert.SerialNumber.Text(16),
+		NotBefore:  cert.NotBefore,
+		NotAfter:   cert.NotAfter,
+		Issuer:     cert.Issuer.String(),
+		KeyType:    publicKeyType(cert.PublicKey),
+		MustStaple: certcrypto.HasMustStaple(cert),
+		Path:       filename,
+		cert:       cert,
+	}
 }
 
-func listAccount(ctx *cli.Context) error {
-	accountsStorage := NewAccountsStorage(ctx)
+// publicKeyType returns the type of the public key, with the values of --key-type when possible.
+func publicKeyType(publicKey any) string {
+	switch pub := publicKey.(type) {
+	case *rsa.PublicKey:
+		return strconv.Itoa(pub.N.BitLen())
+	case *ecdsa.PublicKey:
+		return strings.ReplaceAll(pub.Curve.Params().Name, "-", "")
+	case ed25519.PublicKey:
+		return "ed25519"
+	default:
+		return "unknown"
+	}
+}
 
-	matches, err := filepath.Glob(filepath.Join(accountsStorage.GetRootPath(), "*", "*", "*.json"))
+// addRenewalInfo requests the renewal information (ARI) of the certificates.
+// The renewalInfo endpoint doesn't require an account: an ephemeral key is used, nothing is stored.
+// The errors are reported inside the renewal information of each certificate.
+func addRenewalInfo(ctx *cli.Context, certs []listedCertificate) {
+	privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	if err != nil {
+		log.Fatalf("Could not generate the ephemeral key: %v", err)
+	}
+
+	client, err := newClient(ctx, &Account{key: privateKey}, certcrypto.EC256)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for i, cert := range certs {
+		info, err := client.Certificate.GetRenewalInfoContext(ctx.Context, certificate.RenewalInfoRequest{Cert: cert.cert})
+		if err != nil {
+			certs[i].RenewalInfo = &listedRenewalInfo{Error: err.Error()}
+			continue
+		}
+
+		certs[i].RenewalInfo = &listedRenewalInfo{
+			SuggestedWindow: &info.SuggestedWindow,
+			ExplanationURL:  info.ExplanationURL,
+		}
+	}
+}
+
+func listAccount(ctx *cli.Context) error {
+	accounts, err := readListedAccounts(NewAccountsStorage(ctx))
 	if err != nil {
 		return err
 	}
 
-	if len(matches) == 0 {
+	if len(accounts) == 0 {
 		fmt.Println("No accounts found.")
 		return nil
 	}
 
 	fmt.Println("Found the following accounts:")
 
+	for _, account := range accounts {
+		uri, err := url.Parse(account.URI)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("  Email:", account.Email)
+		fmt.Println("  Server:", uri.Host)
+		fmt.Println("  Path:", account.Path)
+		fmt.Println()
+	}
+
+	return nil
+}
+
+// readListedAccounts reads the accounts of all the servers.
+func readListedAccounts(accountsStorage *AccountsStorage) ([]listedAccount, error) {
+	matches, err := filepath.Glob(filepath.Join(accountsStorage.GetRootPath(), "*", "*", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []listedAccount
+
 	for _, filename := range matches {
 		data, err := os.ReadFile(filename)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		var account Account
 
 		err = json.Unmarshal(data, &account)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		uri, err := url.Parse(account.Registration.URI)
-		if err != nil {
-			return err
+		listed := listedAccount{Email: account.Email, Path: filepath.Dir(filename)}
+		if account.Registration != nil {
+			listed.URI = account.Registration.URI
 		}
 
-		fmt.Println("  Email:", account.Email)
-		fmt.Println("  Server:", uri.Host)
-		fmt.Println("  Path:", filepath.Dir(filename))
-		fmt.Println()
+		accounts = append(accounts, listed)
 	}
 
-	return nil
+	return accounts, nil
 }
 
 func formatIPAddresses(ipAddresses []net.IP) string {
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readListedCertificates(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	storage := newTestCertificatesStorage(t)
+
+	writeTestCertificate(t, filepath.Join(storage.rootPath, "example.com.crt"), notAfter, "example.com", "www.example.com")
+	writeTestCertificate(t, filepath.Join(storage.rootPath, "example.com.issuer.crt"), notAfter, "issuer")
+
+	certs, err := readListedCertificates(storage)
+	require.NoError(t, err)
+
+	require.Len(t, certs, 1)
+
+	cert := certs[0]
+
+	assert.Equal(t, "example.com", cert.Name)
+	assert.Equal(t, []string{"example.com", "www.example.com"}, cert.SANs)
+	assert.Equal(t, "1", cert.Serial)
+	assert.Equal(t, notAfter.UTC(), cert.NotAfter)
+	assert.Equal(t, "P256", cert.KeyType)
+	assert.False(t, cert.MustStaple)
+	assert.Equal(t, filepath.Join(storage.rootPath, "example.com.crt"), cert.Path)
+	assert.Nil(t, cert.RenewalFailures)
+	assert.Nil(t, cert.RenewalInfo)
+}
+
+func Test_readListedCertificates_name(t *testing.T) {
+	notAfter := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	storage := newTestCertificatesStorage(t)
+
+	// A named certificate (--cert.name): the name is the name of the files, not the common name.
+	writeTestCertificate(t, filepath.Join(storage.rootPath, "web-frontend.crt"), notAfter, "example.com", "www.example.com")
+
+	err := storage.withName("web-frontend").WriteFile("example.com", failuresExt, []byte(`{"attempts": 2, "lastError": "oops"}`))
+	require.NoError(t, err)
+
+	certs, err := readListedCertificates(storage)
+	require.NoError(t, err)
+
+	require.Len(t, certs, 1)
+
+	assert.Equal(t, "web-frontend", certs[0].Name)
+	assert.Equal(t, filepath.Join(storage.rootPath, "web-frontend.crt"), certs[0].Path)
+
+	require.NotNil(t, certs[0].RenewalFailures)
+	assert.Equal(t, 2, certs[0].RenewalFailures.Attempts)
+}
+
+func Test_newListedCertificate(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(0xfa3c),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2025, time.May, 30, 0, 0, 0, 0, time.UTC),
+		DNSNames:     []string{"example.com"},
+		IPAddresses:  []net.IP{net.ParseIP("192.0.2.1")},
+		ExtraExtensions: []pkix.Extension{
+			{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}, Value: []byte{0x30, 0x03, 0x02, 0x01, 0x05}},
+		},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, privateKey.Public(), privateKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	listed := newListedCertificate("example.com", "example.com.crt", cert)
+
+	assert.Equal(t, "example.com", listed.Name)
+	assert.Equal(t, []string{"example.com", "192.0.2.1"}, listed.SANs)
+	assert.Equal(t, "fa3c", listed.Serial)
+	assert.Equal(t, template.NotBefore, listed.NotBefore)
+	assert.Equal(t, template.NotAfter, listed.NotAfter)
+	assert.Equal(t, "CN=example.com", listed.Issuer)
+	assert.Equal(t, "2048", listed.KeyType)
+	assert.True(t, listed.MustStaple)
+}
+
+func Test_publicKeyType(t *testing.T) {
+	key384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+
+	assert.Equal(t, "P384", publicKeyType(key384.Public()))
+	assert.Equal(t, "unknown", publicKeyType(nil))
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// Flag names.
+const (
+	flgPending    = "pending"
+	flgAllPending = "all-pending"
+)
+
+func createOrder() *cli.Command {
+	return &cli.Command{
+		Name:  "order",
+		Usage: "Manage the orders of the account (requires a CA exposing the orders of the accounts).",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "Display the orders of the account.",
+				Action: orderList,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  flgPending,
+						Usage: "Display only the pending orders.",
+					},
+				},
+			},
+			{
+				Name:      "abandon",
+				Usage:     "Abandon pending orders: their pending authorizations are deactivated.",
+				ArgsUsage: "[order URL...]",
+				Action:    orderAbandon,
+				Before: func(ctx *cli.Context) error {
+					if ctx.Bool(flgAllPending) == (ctx.NArg() > 0) {
+						log.Fatalf("Please specify either order URLs or --%s", flgAllPending)
+					}
+
+					return nil
+				},
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  flgAllPending,
+						Usage: "Abandon all the pending orders of the account.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func orderList(ctx *cli.Context) error {
+	client, ordersURL := setupOrderClient(ctx)
+
+	orders, err := client.Certificate.ListOrders(ctx.Context, ordersURL)
+	if err != nil {
+		return err
+	}
+
+	var stuck int
+
+	for _, order := range orders {
+		if certificate.IsStuckOrder(order) {
+			stuck++
+		} else if ctx.Bool(flgPending) {
+			continue
+		}
+
+		printOrder(os.Stdout, order)
+	}
+
+	if stuck > 0 {
+		log.Warnf("%d pending orders count against the rate limits of the CA: use 'lego order abandon --%s' to abandon them.", stuck, flgAllPending)
+	}
+
+	return nil
+}
+
+func orderAbandon(ctx *cli.Context) error {
+	client, ordersURL := setupOrderClient(ctx)
+
+	orderURLs := ctx.Args().Slice()
+
+	if ctx.Bool(flgAllPending) {
+		orders, err := client.Certificate.ListOrders(ctx.Context, ordersURL)
+		if err != nil {
+			return err
+		}
+
+		for _, order := range orders {
+			if certificate.IsStuckOrder(order) {
+				orderURLs = append(orderURLs, order.Location)
+			}
+		}
+	}
+
+	var errs []error
+
+	for _, orderURL := range orderURLs {
+		log.Printf("Abandoning the order %s", orderURL)
+
+		err := client.Certificate.AbandonOrder(ctx.Context, orderURL)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	log.Printf("%d orders abandoned, %d failed", len(orderURLs)-len(errs), len(errs))
+
+	return errors.Join(errs...)
+}
+
+// setupOrderClient creates a client and returns the orders URL of the account.
+func setupOrderClient(ctx *cli.Context) (*lego.Client, string) {
+	account, keyType := setupAccount(ctx, NewAccountsStorage(ctx))
+
+	if account.Registration == nil {
+		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
+	}
+
+	client, err := newClient(ctx, account, keyType)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ordersURL := account.Registration.Body.Orders
+	if ordersURL == "" {
+		reg, err := client.Registration.QueryRegistrationContext(ctx.Context)
+		if err != nil {
+			log.Fatalf("Could not query the account %s: %v", account.Email, err)
+		}
+
+		ordersURL = reg.Body.Orders
+	}
+
+	if ordersURL == "" {
+		log.Fatal("The CA doesn't expose the orders of the account.")
+	}
+
+	return client, ordersURL
+}
+
+func printOrder(w io.Writer, order acme.ExtendedOrder) {
+	_, _ = fmt.Fprintf(w, "%s\n", order.Location)
+	_, _ = fmt.Fprintf(w, "  Status: %s\n", order.Status)
+
+	if order.Expires != "" {
+		_, _ = fmt.Fprintf(w, "  Expires: %s\n", order.Expires)
+	}
+
+	for _, identifier := range order.Identifiers {
+		_, _ = fmt.Fprintf(w, "  Identifier: %s (%s)\n", identifier.Value, identifier.Type)
+	}
+
+	if order.Certificate != "" {
+		_, _ = fmt.Fprintf(w, "  Certificate: %s\n", order.Certificate)
+	}
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_printOrder(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	printOrder(buf, acme.ExtendedOrder{
+		Location: "https://example.com/order/1",
+		Order: acme.Order{
+			Status:  acme.StatusPending,
+			Expires: "2026-10-22T00:00:00Z",
+			Identifiers: []acme.Identifier{
+				{Type: "dns", Value: "example.com"},
+				{Type: "dns", Value: "*.example.com"},
+			},
+		},
+	})
+
+	expected := `https://example.com/order/1
+  Status: pending
+  Expires: 2026-10-22T00:00:00Z
+  Identifier: example.com (dns)
+  Identifier: *.example.com (dns)
+`
+
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flgPruneExpired   = "expired"
+	flgPruneOlderThan = "older-than"
+	flgPruneDryRun    = "dry-run"
+)
+
+func createPrune() *cli.Command {
+	return &cli.Command{
+		Name:   "prune",
+		Usage:  "Delete the archived certificates.",
+		Action: prune,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  flgPruneExpired,
+				Usage: "Only delete the archived certificates that are expired.",
+			},
+			&cli.StringFlag{
+				Name:  flgPruneOlderThan,
+				Usage: "Only delete the certificates archived for more than this duration (e.g. 90d, 720h).",
+			},
+			&cli.BoolFlag{
+				Name:  flgPruneDryRun,
+				Usage: "Only display the archived certificates that would be deleted.",
+			},
+		},
+	}
+}
+
+func prune(ctx *cli.Context) error {
+	if !ctx.Bool(flgPruneExpired) && !ctx.IsSet(flgPruneOlderThan) {
+		return fmt.Errorf("at least one of '%s' or '%s' is required", flgPruneExpired, flgPruneOlderThan)
+	}
+
+	now := time.Now()
+
+	filter := CertificateFilter{Archived: true}
+
+	if ctx.Bool(flgPruneExpired) {
+		filter.ExpiredBefore = now
+	}
+
+	if ctx.IsSet(flgPruneOlderThan) {
+		age, err := parseAge(ctx.String(flgPruneOlderThan))
+		if err != nil {
+			return fmt.Errorf("'%s': %w", flgPruneOlderThan, err)
+		}
+
+		filter.ArchivedBefore = now.Add(-age)
+	}
+
+	certsStorage := NewCertificatesStorage(ctx)
+
+	certs, err := certsStorage.ListCertificates(filter)
+	if err != nil {
+		return err
+	}
+
+	if len(certs) == 0 {
+		log.Println("No archived certificates to delete.")
+		return nil
+	}
+
+	for _, cert := range certs {
+		if ctx.Bool(flgPruneDryRun) {
+			log.Printf("Would delete the archived certificate %s (archived: %s, expiry: %s)", cert.Name, cert.ArchivedAt, cert.NotAfter)
+			continue
+		}
+
+		err = certsStorage.Delete(cert)
+		if err != nil {
+			return fmt.Errorf("delete the archived certificate %s: %w", cert.Path, err)
+		}
+
+		log.Printf("The archived certificate %s has been deleted (archived: %s, expiry: %s)", cert.Name, cert.ArchivedAt, cert.NotAfter)
+	}
+
+	return nil
+}
+
+// parseAge parses a duration with the days unit ("90d"), in addition to the units of time.ParseDuration.
+func parseAge(value string) (time.Duration, error) {
+	var (
+		age time.Duration
+		err error
+	)
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		var n int
+
+		n, err = strconv.Atoi(days)
+		age = time.Duration(n) * 24 * time.Hour
+	} else {
+		age, err = time.ParseDuration(value)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	if age < 0 {
+		return 0, errors.New("the duration cannot be negative")
+	}
+
+	return age, nil
+}
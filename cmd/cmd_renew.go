@@ -4,31 +4,44 @@ import (
 	"crypto"
 	"crypto/x509"
 	"errors"
+	"fmt"
 	"math/rand"
 	"os"
 	"slices"
+	"strings"
 	"time"
 
-	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/lego"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/storage"
 	"github.com/mattn/go-isatty"
 	"github.com/urfave/cli/v2"
 )
 
 // Flag names.
 const (
-	flgRenewDays              = "days"
-	flgRenewDynamic           = "dynamic"
-	flgARIDisable             = "ari-disable"
-	flgARIWaitToRenewDuration = "ari-wait-to-renew-duration"
-	flgReuseKey               = "reuse-key"
-	flgRenewHook              = "renew-hook"
-	flgRenewHookTimeout       = "renew-hook-timeout"
-	flgNoRandomSleep          = "no-random-sleep"
-	flgForceCertDomains       = "force-cert-domains"
+	flgRenewDays               = "days"
+	flgRenewDynamic            = "dynamic"
+	flgARIDisable              = "ari-disable"
+	flgARIWaitToRenewDuration  = "ari-wait-to-renew-duration"
+	flgReuseKey                = "reuse-key"
+	flgRenewHook               = "renew-hook"
+	flgRenewHookTimeout        = "renew-hook-timeout"
+	flgNoRandomSleep           = "no-random-sleep"
+	flgForceCertDomains        = "force-cert-domains"
+	flgRenewFailureHook        = "failure-hook"
+	flgRenewRetryInterval      = "retry-interval"
+	flgRenewRetryMaxInterval   = "retry-max-interval"
+	flgRenewMaxAttemptsPerDay  = "max-attempts-per-day"
+	flgRenewEscalationDays     = "escalation-days"
+	flgRenewWindow             = "window"
+	flgRenewWindowOverrideDays = "window-override-days"
+	flgRenewAdd                = "add"
+	flgRenewRemove             = "remove"
+	flgRenewDryRun             = "dry-run"
+	flgRenewDryRunServer       = "dry-run-server"
 )
 
 func createRenew() *cli.Command {
@@ -37,6 +50,11 @@ func createRenew() *cli.Command {
 		Usage:  "Renew a certificate",
 		Action: renew,
 		Before: func(ctx *cli.Context) error {
+			// The certificates are defined by the configuration file.
+			if ctx.IsSet(flgConfig) {
+				return nil
+			}
+
 			// we require either domains or csr, but not both
 			hasDomains := len(ctx.StringSlice(flgDomains)) > 0
 
@@ -53,95 +71,224 @@ func createRenew() *cli.Command {
 				log.Fatalf("--%s only works with --%s/-d, --%s/-c doesn't support this option.", flgForceCertDomains, flgDomains, flgCSR)
 			}
 
+			if ctx.Bool(flgRenewAdd) || ctx.Bool(flgRenewRemove) {
+				if ctx.Bool(flgRenewAdd) && ctx.Bool(flgRenewRemove) {
+					log.Fatalf("Please specify either --%s or --%s, but not both", flgRenewAdd, flgRenewRemove)
+				}
+
+				if hasCsr || ctx.Bool(flgForceCertDomains) {
+					log.Fatalf("--%s and --%s cannot be used with --%s/-c or --%s", flgRenewAdd, flgRenewRemove, flgCSR, flgForceCertDomains)
+				}
+
+				// The first domain is used as the name of the certificate by default: it can change.
+				if ctx.String(flgCertName) == "" {
+					log.Fatalf("--%s and --%s require --%s", flgRenewAdd, flgRenewRemove, flgCertName)
+				}
+			}
+
+			if ctx.IsSet(flgRenewDryRunServer) && !ctx.Bool(flgRenewDryRun) {
+				log.Fatalf("--%s requires --%s", flgRenewDryRunServer, flgRenewDryRun)
+			}
+
 			return nil
 		},
-		Flags: []cli.Flag{
-			&cli.IntFlag{
-				Name:  flgRenewDays,
-				Value: 30,
-				Usage: "The number of days left on a certificate to renew it.",
-			},
-			// TODO(ldez): in v5, remove this flag, use this behavior as default.
-			&cli.BoolFlag{
-				Name:  flgRenewDynamic,
-				Value: false,
-				Usage: "Compute dynamically, based on the lifetime of the certificate(s), when to renew: use 1/3rd of the lifetime left, or 1/2 of the lifetime for short-lived certificates). This supersedes --days and will be the default behavior in Lego v5.",
-			},
-			&cli.BoolFlag{
-				Name:  flgARIDisable,
-				Usage: "Do not use the renewalInfo endpoint (RFC9773) to check if a certificate should be renewed.",
-			},
-			&cli.DurationFlag{
-				Name:  flgARIWaitToRenewDuration,
-				Usage: "The maximum duration you're willing to sleep for a renewal time returned by the renewalInfo endpoint.",
-			},
+		Flags: append(renewFlags(),
 			&cli.BoolFlag{
-				Name:  flgReuseKey,
-				Usage: "Used to indicate you want to reuse your current private key for the new certificate.",
+				Name: flgRenewAdd,
+				Usage: "Add the domains (--" + flgDomains + "/-d) to the domains of the certificate, and renew it immediately." +
+					" Requires --" + flgCertName + ".",
 			},
 			&cli.BoolFlag{
-				Name:  flgNoBundle,
-				Usage: "Do not create a certificate bundle by adding the issuers certificate to the new certificate.",
+				Name: flgRenewRemove,
+				Usage: "Remove the domains (--" + flgDomains + "/-d) from the domains of the certificate, and renew it immediately." +
+					" Requires --" + flgCertName + ".",
 			},
 			&cli.BoolFlag{
-				Name: flgMustStaple,
-				Usage: "Include the OCSP must staple TLS extension in the CSR and generated certificate." +
-					" Only works if the CSR is generated by lego.",
-			},
-			&cli.TimestampFlag{
-				Name:   flgNotBefore,
-				Usage:  "Set the notBefore field in the certificate (RFC3339 format)",
-				Layout: time.RFC3339,
-			},
-			&cli.TimestampFlag{
-				Name:   flgNotAfter,
-				Usage:  "Set the notAfter field in the certificate (RFC3339 format)",
-				Layout: time.RFC3339,
+				Name: flgRenewDryRun,
+				Usage: "Renew the certificate with the test CA (--" + flgRenewDryRunServer + "), even if it's not due, with the real challenges:" +
+					" the certificate is not saved, and the hooks are not executed.",
 			},
 			&cli.StringFlag{
-				Name: flgPreferredChain,
-				Usage: "If the CA offers multiple certificate chains, prefer the chain with an issuer matching this Subject Common Name." +
-					" If no match, the default offered chain will be used.",
-			},
-			&cli.StringFlag{
-				Name:  flgProfile,
-				Usage: "If the CA offers multiple certificate profiles (draft-ietf-acme-profiles), choose this one.",
-			},
-			&cli.StringFlag{
-				Name:  flgAlwaysDeactivateAuthorizations,
-				Usage: "Force the authorizations to be relinquished even if the certificate request was successful.",
-			},
-			&cli.StringFlag{
-				Name:  flgRenewHook,
-				Usage: "Define a hook. The hook is executed only when the certificates are effectively renewed.",
-			},
-			&cli.DurationFlag{
-				Name:  flgRenewHookTimeout,
-				Usage: "Define the timeout for the hook execution.",
-				Value: 2 * time.Minute,
-			},
-			&cli.BoolFlag{
-				Name: flgNoRandomSleep,
-				Usage: "Do not add a random sleep before the renewal." +
-					" We do not recommend using this flag if you are doing your renewals in an automated way.",
-			},
-			&cli.BoolFlag{
-				Name:  flgForceCertDomains,
-				Usage: "Check and ensure that the cert's domain list matches those passed in the domains argument.",
+				Name:  flgRenewDryRunServer,
+				Usage: "CA hostname (and optionally :port) used by --" + flgRenewDryRun + ". The account is registered on the first dry run.",
+				Value: lego.LEDirectoryStaging,
 			},
+		),
+	}
+}
+
+// renewFlags the flags of the renewal, shared by the renew and daemon commands.
+func renewFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.IntFlag{
+			Name:  flgRenewDays,
+			Value: 30,
+			Usage: "The number of days left on a certificate to renew it.",
+		},
+		// TODO(ldez): in v5, remove this flag, use this behavior as default.
+		&cli.BoolFlag{
+			Name:  flgRenewDynamic,
+			Value: false,
+			Usage: "Compute dynamically, based on the lifetime of the certificate(s), when to renew: use 1/3rd of the lifetime left, or 1/2 of the lifetime for short-lived certificates). This supersedes --days and will be the default behavior in Lego v5.",
+		},
+		&cli.BoolFlag{
+			Name:  flgARIDisable,
+			Usage: "Do not use the renewalInfo endpoint (RFC9773) to check if a certificate should be renewed.",
+		},
+		&cli.DurationFlag{
+			Name:  flgARIWaitToRenewDuration,
+			Usage: "The maximum duration you're willing to sleep for a renewal time returned by the renewalInfo endpoint.",
+		},
+		&cli.BoolFlag{
+			Name:  flgReuseKey,
+			Usage: "Used to indicate you want to reuse your current private key for the new certificate.",
+		},
+		&cli.BoolFlag{
+			Name:  flgNoBundle,
+			Usage: "Do not create a certificate bundle by adding the issuers certificate to the new certificate.",
+		},
+		&cli.BoolFlag{
+			Name: flgMustStaple,
+			Usage: "Include the OCSP must staple TLS extension in the CSR and generated certificate." +
+				" Only works if the CSR is generated by lego.",
+		},
+		&cli.TimestampFlag{
+			Name:   flgNotBefore,
+			Usage:  "Set the notBefore field in the certificate (RFC3339 format)",
+			Layout: time.RFC3339,
+		},
+		&cli.TimestampFlag{
+			Name:   flgNotAfter,
+			Usage:  "Set the notAfter field in the certificate (RFC3339 format)",
+			Layout: time.RFC3339,
+		},
+		&cli.StringFlag{
+			Name: flgPreferredChain,
+			Usage: "If the CA offers multiple certificate chains, prefer the chain with an issuer matching this Subject Common Name." +
+				" If no match, the default offered chain will be used.",
+		},
+		&cli.StringFlag{
+			Name: flgSubject,
+			Usage: "Set the subject fields of the CSR (e.g. 'O=Acme Corp,OU=Infra,C=DE'), for the CAs honoring them." +
+				" Supported: C, O, OU, L, ST, STREET, POSTALCODE, SERIALNUMBER. Only works if the CSR is generated by lego.",
+		},
+		&cli.StringFlag{
+			Name:  flgProfile,
+			Usage: "If the CA offers multiple certificate profiles (draft-ietf-acme-profiles), choose this one.",
+		},
+		&cli.StringFlag{
+			Name:  flgAlwaysDeactivateAuthorizations,
+			Usage: "Force the authorizations to be relinquished even if the certificate request was successful.",
+		},
+		&cli.BoolFlag{
+			Name: flgDeactivateFailedAuthorizations,
+			Usage: "Relinquish the pending authorizations when the certificate request fails (RFC 8555 §7.5.2)." +
+				" Use --" + flgDeactivateFailedAuthorizations + "=false to keep them.",
+			Value: true,
+		},
+		&cli.BoolFlag{
+			Name: flgAllowPartial,
+			Usage: "If the validation of some domains fails, retry the order without these domains." +
+				" The certificate is issued for the domains that have been validated. Only works with --domains/-d.",
+		},
+		&cli.StringFlag{
+			Name: flgSANOrder,
+			Usage: "The order of the SANs of the CSR: 'ca' keeps the order of the identifiers returned by the CA, 'sorted' sorts them." +
+				" The first domain is always the first SAN. Only works if the CSR is generated by lego." +
+				" By default, the order used by the previous certificate.",
+		},
+		&cli.StringFlag{
+			Name:  flgRenewHook,
+			Usage: "Define a hook. The hook is executed only when the certificates are effectively renewed.",
+		},
+		&cli.DurationFlag{
+			Name:  flgRenewHookTimeout,
+			Usage: "Define the timeout for the hook execution.",
+			Value: 2 * time.Minute,
+		},
+		&cli.BoolFlag{
+			Name: flgNoRandomSleep,
+			Usage: "Do not add a random sleep before the renewal." +
+				" We do not recommend using this flag if you are doing your renewals in an automated way.",
+		},
+		&cli.BoolFlag{
+			Name:  flgForceCertDomains,
+			Usage: "Check and ensure that the cert's domain list matches those passed in the domains argument.",
+		},
+		&cli.StringFlag{
+			Name: flgRenewFailureHook,
+			Usage: "Define a hook executed when the renewal fails." +
+				" The event (LEGO_RENEWAL_EVENT) is 'failure-near-expiry' when the certificate expires within --" + flgRenewEscalationDays + ", 'failure' otherwise.",
+		},
+		&cli.DurationFlag{
+			Name:  flgRenewRetryInterval,
+			Usage: "After a failed renewal, the minimum interval before the next attempt, doubled after each consecutive failure (e.g. 1h). Disabled by default.",
+		},
+		&cli.DurationFlag{
+			Name:  flgRenewRetryMaxInterval,
+			Usage: "The maximum interval between two attempts after failed renewals.",
+			Value: 24 * time.Hour,
+		},
+		&cli.IntFlag{
+			Name:  flgRenewMaxAttemptsPerDay,
+			Usage: "The maximum number of failed renewal attempts per day (UTC) for a certificate. Unlimited by default.",
+		},
+		&cli.StringFlag{
+			Name: flgRenewWindow,
+			Usage: "Only renew during the maintenance windows: cron-like expressions ('minute hour day-of-month month day-of-week') separated by ';'" +
+				" (e.g. '* 2-4 * * 1-5'). The time zone can be set with a 'CRON_TZ=' prefix.",
+			EnvVars: []string{"LEGO_RENEW_WINDOW"},
+		},
+		&cli.IntFlag{
+			Name:  flgRenewWindowOverrideDays,
+			Usage: "The number of days before the expiry from which the renewal ignores the maintenance windows.",
+			Value: 7,
+		},
+		&cli.IntFlag{
+			Name:  flgRenewEscalationDays,
+			Usage: "The number of days before the expiry from which the renewal failures are escalated.",
+			Value: 7,
 		},
 	}
 }
 
 func renew(ctx *cli.Context) error {
-	account, keyType := setupAccount(ctx, NewAccountsStorage(ctx))
+	if ctx.IsSet(flgConfig) {
+		return runConfigFile(ctx)
+	}
 
-	if account.Registration == nil {
-		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
+	if ctx.Bool(flgRenewDryRun) {
+		setupDryRun(ctx)
 	}
 
 	certsStorage := NewCertificatesStorage(ctx)
 
+	// The dry run doesn't save the certificates.
+	if !ctx.Bool(flgRenewDryRun) {
+		err := preflightStorage(certsStorage)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	accountsStorage := NewAccountsStorage(ctx)
+
+	account, keyType := setupAccount(ctx, accountsStorage)
+
+	if account.Registration == nil {
+		if !ctx.Bool(flgRenewDryRun) {
+			log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
+		}
+
+		// The account of the test CA is registered on the first dry run.
+		client, err := newClient(ctx, account, keyType)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ensureRegistration(ctx, client, account, accountsStorage)
+	}
+
 	bundle := !ctx.Bool(flgNoBundle)
 
 	meta := map[string]string{
@@ -154,11 +301,14 @@ func renew(ctx *cli.Context) error {
 	}
 
 	// Domains
-	return renewForDomains(ctx, account, keyType, certsStorage, bundle, meta)
+	return renewForDomains(ctx, account, keyType, certsStorage, ctx.StringSlice(flgDomains), bundle, meta)
 }
 
-func renewForDomains(ctx *cli.Context, account *Account, keyType certcrypto.KeyType, certsStorage *CertificatesStorage, bundle bool, meta map[string]string) error {
-	domains := ctx.StringSlice(flgDomains)
+// renewForDomains renews the certificate of the domains if needed.
+// The errors are returned (the daemon continues with the other certificates), only the errors of the flags are fatal.
+func renewForDomains(ctx *cli.Context, account *Account, keyType certcrypto.KeyType, certsStorage *CertificatesStorage,
+	domains []string, bundle bool, meta map[string]string,
+) error {
 	domain := domains[0]
 
 	// load the cert resource from files.
@@ -166,49 +316,100 @@ func renewForDomains(ctx *cli.Context, account *Account, keyType certcrypto.KeyT
 	// as web servers would not be able to work with a combined file.
 	certificates, err := certsStorage.ReadCertificate(domain, certExt)
 	if err != nil {
-		log.Fatalf("Error while loading the certificate for domain %s\n\t%v", domain, err)
+		return fmt.Errorf("error while loading the certificate for domain %s: %w", domain, err)
 	}
 
 	cert := certificates[0]
 
+	if done, errS := renewStar(ctx, account, keyType, certsStorage, domain, cert, bundle, meta); done || errS != nil {
+		return errS
+	}
+
+	sanOrder, err := renewalSANOrder(ctx, certsStorage, domain)
+	if err != nil {
+		return err
+	}
+
+	forceDomains := ctx.Bool(flgForceCertDomains)
+
+	var change *domainChange
+
+	if ctx.Bool(flgRenewAdd) || ctx.Bool(flgRenewRemove) {
+		domains, change, err = changeDomains(certcrypto.ExtractDomains(cert), domains, ctx.Bool(flgRenewRemove), time.Now())
+		if err != nil {
+			return fmt.Errorf("[%s] %w", ctx.String(flgCertName), err)
+		}
+
+		domain = domains[0]
+
+		// The domains of the certificate must be exactly the new domains.
+		forceDomains = true
+	}
+
+	dryRun := ctx.Bool(flgRenewDryRun)
+
+	certDomains := certcrypto.ExtractDomains(cert)
+
 	var (
-		ariRenewalTime *time.Time
+		client         *lego.Client
 		replacesCertID string
 	)
 
-	var client *lego.Client
+	// The test CA of the dry run doesn't know the certificate: the dry run renews the certificate even if it's not due.
+	if !dryRun {
+		client, err = renewalClient(ctx, account, keyType, cert)
+		if err != nil {
+			return err
+		}
+
+		decision, errD := renewalDecision(ctx, client, certificates)
+		if errD != nil {
+			return fmt.Errorf("[%s] %w", domain, errD)
+		}
 
-	if !ctx.Bool(flgARIDisable) {
-		client = setupClient(ctx, account, keyType)
+		// The domains of the command line not covered by the certificate (ex: dropped by --allow-partial) are added by the renewal.
+		missing := missingDomains(certDomains, domains)
 
-		ariRenewalTime = getARIRenewalTime(ctx, cert, domain, client)
-		if ariRenewalTime != nil {
-			now := time.Now().UTC()
+		if !decision.Renew && len(missing) == 0 && (!forceDomains || slices.Equal(certDomains, domains)) {
+			log.Infof("[%s] %s: no renewal.", domain, decision.Reason)
+			return nil
+		}
 
-			// Figure out if we need to sleep before renewing.
-			if ariRenewalTime.After(now) {
-				log.Infof("[%s] Sleeping %s until renewal time %s", domain, ariRenewalTime.Sub(now), ariRenewalTime)
-				time.Sleep(ariRenewalTime.Sub(now))
-			}
+		if len(missing) > 0 && !forceDomains {
+			log.Infof("[%s] The certificate doesn't cover the domains %s: renewal.", domain, strings.Join(missing, ", "))
 		}
 
-		replacesCertID, err = certificate.MakeARICertID(cert)
+		// The changes of the domains don't wait.
+		if change == nil && len(missing) == 0 {
+			waitRenewalTime(domain, decision)
+		}
+
+		replacesCertID, err = renewalReplacesCertID(ctx, cert, domain)
 		if err != nil {
-			log.Fatalf("Error while construction the ARI CertID for domain %s\n\t%v", domain, err)
+			return err
 		}
 	}
 
-	forceDomains := ctx.Bool(flgForceCertDomains)
+	if !dryRun {
+		inWindow, errW := checkRenewalWindow(ctx, domain, cert, time.Now())
+		if errW != nil {
+			return errW
+		}
 
-	certDomains := certcrypto.ExtractDomains(cert)
+		if !inWindow {
+			return nil
+		}
+	}
 
-	if ariRenewalTime == nil && !needRenewal(cert, domain, ctx.Int(flgRenewDays), ctx.Bool(flgRenewDynamic)) &&
-		(!forceDomains || slices.Equal(certDomains, domains)) {
+	if !dryRun && !checkRenewalFailures(ctx, certsStorage, domain) {
 		return nil
 	}
 
 	if client == nil {
-		client = setupClient(ctx, account, keyType)
+		client, err = setupClient(ctx, account, keyType)
+		if err != nil {
+			return err
+		}
 	}
 
 	// This is just meant to be informal for the user.
@@ -220,18 +421,23 @@ func renewForDomains(ctx *cli.Context, account *Account, keyType certcrypto.KeyT
 	if ctx.Bool(flgReuseKey) {
 		keyBytes, errR := certsStorage.ReadFile(domain, keyExt)
 		if errR != nil {
-			log.Fatalf("Error while loading the private key for domain %s\n\t%v", domain, errR)
+			return fmt.Errorf("error while loading the private key for domain %s: %w", domain, errR)
 		}
 
 		privateKey, errR = certcrypto.ParsePEMPrivateKey(keyBytes)
 		if errR != nil {
 			return errR
 		}
+
+		privateKey = reusablePrivateKey(cert, domain, privateKey, meta)
+	} else if ctx.Bool(flgTLSA) {
+		// The TLSA records of the next key have been published with the current certificate.
+		privateKey = readNextKey(certsStorage, domain, keyType)
 	}
 
 	// https://github.com/go-acme/lego/issues/1656
 	// https://github.com/certbot/certbot/blob/284023a1b7672be2bd4018dd7623b3b92197d4b0/certbot/certbot/_internal/renewal.py#L435-L440
-	if !isatty.IsTerminal(os.Stdout.Fd()) && !ctx.Bool(flgNoRandomSleep) {
+	if !isatty.IsTerminal(os.Stdout.Fd()) && !ctx.Bool(flgNoRandomSleep) && !dryRun {
 		// https://github.com/certbot/certbot/blob/284023a1b7672be2bd4018dd7623b3b92197d4b0/certbot/certbot/_internal/renewal.py#L472
 		const jitter = 8 * time.Minute
 
@@ -247,6 +453,11 @@ func renewForDomains(ctx *cli.Context, account *Account, keyType certcrypto.KeyT
 		renewalDomains = merge(certDomains, domains)
 	}
 
+	err = checkReachability(ctx, client, renewalDomains)
+	if err != nil {
+		return err
+	}
+
 	request := certificate.ObtainRequest{
 		Domains:                        renewalDomains,
 		PrivateKey:                     privateKey,
@@ -257,20 +468,65 @@ func renewForDomains(ctx *cli.Context, account *Account, keyType certcrypto.KeyT
 		PreferredChain:                 ctx.String(flgPreferredChain),
 		Profile:                        ctx.String(flgProfile),
 		AlwaysDeactivateAuthorizations: ctx.Bool(flgAlwaysDeactivateAuthorizations),
+		KeepFailedAuthorizations:       !ctx.Bool(flgDeactivateFailedAuthorizations),
+		AllowPartial:                   ctx.Bool(flgAllowPartial),
+		SANOrder:                       sanOrder,
 	}
 
 	if replacesCertID != "" {
 		request.ReplacesCertID = replacesCertID
 	}
 
-	certRes, err := client.Certificate.Obtain(request)
+	request.MutateCSRTemplate, err = getSubjectMutator(ctx)
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
+	certRes, err := client.Certificate.ObtainContext(ctx.Context, request)
+	if err != nil {
+		if !dryRun {
+			handleRenewalFailure(ctx, certsStorage, domain, cert, meta, err)
+		}
+
+		return err
+	}
+
+	logDroppedDomains(certRes)
+
 	certRes.Domain = domain
 
-	certsStorage.SaveResource(certRes)
+	err = verifyCertificate(ctx, certRes, renewalDomains)
+	if err != nil {
+		return fmt.Errorf("[%s] the certificate has not been saved: %w", domain, err)
+	}
+
+	if dryRun {
+		log.Infof("[%s] dry run: the certificate has been obtained, it has not been saved.", domain)
+		return nil
+	}
+
+	previous := readStoredChain(ctx, certsStorage, domain)
+
+	err = certsStorage.SaveResource(certRes)
+	if err != nil {
+		return err
+	}
+
+	if change != nil {
+		err = certsStorage.AddDomainChange(domain, *change)
+		if err != nil {
+			log.Warnf("[%s] Could not record the change of the domains: %v", domain, err)
+		}
+	}
+
+	clearRenewalFailures(certsStorage, domain)
+
+	err = handleTLSA(ctx, certsStorage, certRes, previous, keyType)
+	if err != nil {
+		return fmt.Errorf("[%s] could not handle the TLSA records: %w", domain, err)
+	}
+
+	checkMTASTS(ctx, certRes)
 
 	addPathToMetadata(meta, domain, certRes, certsStorage)
 
@@ -280,12 +536,12 @@ func renewForDomains(ctx *cli.Context, account *Account, keyType certcrypto.KeyT
 func renewForCSR(ctx *cli.Context, account *Account, keyType certcrypto.KeyType, certsStorage *CertificatesStorage, bundle bool, meta map[string]string) error {
 	csr, err := readCSRFile(ctx.String(flgCSR))
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
 
 	domain, err := certcrypto.GetCSRMainDomain(csr)
 	if err != nil {
-		log.Fatalf("Error: %v", err)
+		return err
 	}
 
 	// load the cert resource from files.
@@ -293,44 +549,67 @@ func renewForCSR(ctx *cli.Context, account *Account, keyType certcrypto.KeyType,
 	// as web servers would not be able to work with a combined file.
 	certificates, err := certsStorage.ReadCertificate(domain, certExt)
 	if err != nil {
-		log.Fatalf("Error while loading the certificate for domain %s\n\t%v", domain, err)
+		return fmt.Errorf("error while loading the certificate for domain %s: %w", domain, err)
 	}
 
 	cert := certificates[0]
 
+	if done, errS := renewStar(ctx, account, keyType, certsStorage, domain, cert, bundle, meta); done || errS != nil {
+		return errS
+	}
+
+	dryRun := ctx.Bool(flgRenewDryRun)
+
 	var (
-		ariRenewalTime *time.Time
+		client         *lego.Client
 		replacesCertID string
 	)
 
-	var client *lego.Client
-
-	if !ctx.Bool(flgARIDisable) {
-		client = setupClient(ctx, account, keyType)
+	// The test CA of the dry run doesn't know the certificate: the dry run renews the certificate even if it's not due.
+	if !dryRun {
+		client, err = renewalClient(ctx, account, keyType, cert)
+		if err != nil {
+			return err
+		}
 
-		ariRenewalTime = getARIRenewalTime(ctx, cert, domain, client)
-		if ariRenewalTime != nil {
-			now := time.Now().UTC()
+		decision, errD := renewalDecision(ctx, client, certificates)
+		if errD != nil {
+			return fmt.Errorf("[%s] %w", domain, errD)
+		}
 
-			// Figure out if we need to sleep before renewing.
-			if ariRenewalTime.After(now) {
-				log.Infof("[%s] Sleeping %s until renewal time %s", domain, ariRenewalTime.Sub(now), ariRenewalTime)
-				time.Sleep(ariRenewalTime.Sub(now))
-			}
+		if !decision.Renew {
+			log.Infof("[%s] %s: no renewal.", domain, decision.Reason)
+			return nil
 		}
 
-		replacesCertID, err = certificate.MakeARICertID(cert)
+		waitRenewalTime(domain, decision)
+
+		replacesCertID, err = renewalReplacesCertID(ctx, cert, domain)
 		if err != nil {
-			log.Fatalf("Error while construction the ARI CertID for domain %s\n\t%v", domain, err)
+			return err
+		}
+	}
+
+	if !dryRun {
+		inWindow, errW := checkRenewalWindow(ctx, domain, cert, time.Now())
+		if errW != nil {
+			return errW
+		}
+
+		if !inWindow {
+			return nil
 		}
 	}
 
-	if ariRenewalTime == nil && !needRenewal(cert, domain, ctx.Int(flgRenewDays), ctx.Bool(flgRenewDynamic)) {
+	if !dryRun && !checkRenewalFailures(ctx, certsStorage, domain) {
 		return nil
 	}
 
 	if client == nil {
-		client = setupClient(ctx, account, keyType)
+		client, err = setupClient(ctx, account, keyType)
+		if err != nil {
+			return err
+		}
 	}
 
 	// This is just meant to be informal for the user.
@@ -345,102 +624,300 @@ func renewForCSR(ctx *cli.Context, account *Account, keyType certcrypto.KeyType,
 		PreferredChain:                 ctx.String(flgPreferredChain),
 		Profile:                        ctx.String(flgProfile),
 		AlwaysDeactivateAuthorizations: ctx.Bool(flgAlwaysDeactivateAuthorizations),
+		KeepFailedAuthorizations:       !ctx.Bool(flgDeactivateFailedAuthorizations),
 	}
 
 	if replacesCertID != "" {
 		request.ReplacesCertID = replacesCertID
 	}
 
-	certRes, err := client.Certificate.ObtainForCSR(request)
+	certRes, err := client.Certificate.ObtainForCSRContext(ctx.Context, request)
+	if err != nil {
+		if !dryRun {
+			handleRenewalFailure(ctx, certsStorage, domain, cert, meta, err)
+		}
+
+		return err
+	}
+
+	err = verifyCertificate(ctx, certRes, certcrypto.ExtractDomainsCSR(csr))
 	if err != nil {
-		log.Fatal(err)
+		return fmt.Errorf("[%s] the certificate has not been saved: %w", domain, err)
 	}
 
-	certsStorage.SaveResource(certRes)
+	if dryRun {
+		log.Infof("[%s] dry run: the certificate has been obtained, it has not been saved.", domain)
+		return nil
+	}
+
+	previous := readStoredChain(ctx, certsStorage, domain)
+
+	err = certsStorage.SaveResource(certRes)
+	if err != nil {
+		return err
+	}
+
+	clearRenewalFailures(certsStorage, domain)
+
+	err = handleTLSA(ctx, certsStorage, certRes, previous, keyType)
+	if err != nil {
+		return fmt.Errorf("[%s] could not handle the TLSA records: %w", domain, err)
+	}
+
+	checkMTASTS(ctx, certRes)
 
 	addPathToMetadata(meta, domain, certRes, certsStorage)
 
 	return launchHook(ctx.String(flgRenewHook), ctx.Duration(flgRenewHookTimeout), meta)
 }
 
-func needRenewal(x509Cert *x509.Certificate, domain string, days int, dynamic bool) bool {
-	if x509Cert.IsCA {
-		log.Fatalf("[%s] Certificate bundle starts with a CA certificate", domain)
+// setupDryRun replaces the CA (--server) by the test CA of the dry run (--dry-run-server):
+// the account and the certificates of the test CA are used instead of the production ones.
+func setupDryRun(ctx *cli.Context) {
+	server := ctx.String(flgRenewDryRunServer)
+
+	err := ctx.Set(flgServer, server)
+	if err != nil {
+		log.Fatalf("dry run: %v", err)
 	}
 
-	if dynamic {
-		return needRenewalDynamic(x509Cert, domain, time.Now())
+	log.Infof("dry run: the certificates are renewed with %s, even if they are not due; nothing is saved, and the hooks are not executed.", server)
+}
+
+// renewalSANOrder returns the order of the SANs of the renewed certificate (--san-order):
+// by default, the order used by the previous certificate, to not break the clients depending on it.
+func renewalSANOrder(ctx *cli.Context, certsStorage *CertificatesStorage, domain string) (certificate.SANOrder, error) {
+	sanOrder, err := getSANOrder(ctx)
+	if err != nil {
+		return "", err
 	}
 
-	if days < 0 {
-		return true
+	if ctx.IsSet(flgSANOrder) {
+		return sanOrder, nil
 	}
 
-	notAfter := int(time.Until(x509Cert.NotAfter).Hours() / 24.0)
-	if notAfter <= days {
-		return true
+	exists, err := certsStorage.ExistsFile(domain, resourceExt)
+	if err != nil {
+		return "", err
 	}
 
-	log.Printf("[%s] The certificate expires in %d days, the number of days defined to perform the renewal is %d: no renewal.",
-		domain, notAfter, days)
+	if !exists {
+		return sanOrder, nil
+	}
 
-	return false
+	resource, err := certsStorage.ReadResource(domain)
+	if err != nil {
+		return "", err
+	}
+
+	return resource.SANOrder, nil
 }
 
-func needRenewalDynamic(x509Cert *x509.Certificate, domain string, now time.Time) bool {
-	lifetime := x509Cert.NotAfter.Sub(x509Cert.NotBefore)
+// renewStar fetches the last certificate issued by the auto-renewal (STAR) order of the certificate.
+// It returns false if the certificate has not been issued by a STAR order, or if the order has ended:
+// in this case, the certificate is renewed with a new order.
+func renewStar(ctx *cli.Context, account *Account, keyType certcrypto.KeyType, certsStorage *CertificatesStorage,
+	domain string, cert *x509.Certificate, bundle bool, meta map[string]string,
+) (bool, error) {
+	// The STAR order belongs to the CA of the certificate, the dry run uses a new order.
+	if ctx.Bool(flgRenewDryRun) {
+		return false, nil
+	}
 
-	var divisor int64 = 3
-	if lifetime.Round(24*time.Hour).Hours()/24.0 <= 10 {
-		divisor = 2
+	exists, err := certsStorage.ExistsFile(domain, resourceExt)
+	if err != nil || !exists {
+		return false, err
 	}
 
-	dueDate := x509Cert.NotAfter.Add(-1 * time.Duration(lifetime.Nanoseconds()/divisor))
+	resource, err := certsStorage.ReadResource(domain)
+	if err != nil {
+		return false, err
+	}
 
-	if dueDate.Before(now) {
-		return true
+	if resource.StarCertificateURL == "" {
+		return false, nil
 	}
 
-	log.Infof("[%s] The certificate expires at %s, the renewal can be performed in %s: no renewal.",
-		domain, x509Cert.NotAfter.Format(time.RFC3339), dueDate.Sub(now))
+	client, err := setupClient(ctx, account, keyType)
+	if err != nil {
+		return false, err
+	}
 
-	return false
-}
+	certRes, err := client.Certificate.GetStarCertificate(ctx.Context, resource, bundle)
+	if certificate.IsAutoRenewalEnded(err) {
+		log.Warnf("[%s] The auto-renewal (STAR) order has ended, a new order is required: %v", domain, err)
+		return false, nil
+	}
 
-// getARIRenewalTime checks if the certificate needs to be renewed using the renewalInfo endpoint.
-func getARIRenewalTime(ctx *cli.Context, cert *x509.Certificate, domain string, client *lego.Client) *time.Time {
-	if cert.IsCA {
-		log.Fatalf("[%s] Certificate bundle starts with a CA certificate", domain)
+	if err != nil {
+		return false, fmt.Errorf("[%s] could not fetch the STAR certificate: %w", domain, err)
 	}
 
-	renewalInfo, err := client.Certificate.GetRenewalInfo(certificate.RenewalInfoRequest{Cert: cert})
+	newCert, err := certcrypto.ParsePEMCertificate(certRes.Certificate)
 	if err != nil {
-		if errors.Is(err, api.ErrNoARI) {
-			// The server does not advertise a renewal info endpoint.
-			log.Warnf("[%s] acme: %v", domain, err)
-			return nil
-		}
+		return false, fmt.Errorf("[%s] could not parse the STAR certificate: %w", domain, err)
+	}
+
+	if newCert.Equal(cert) {
+		log.Infof("[%s] No new STAR certificate: the current certificate expires at %s.", domain, cert.NotAfter.Format(time.RFC3339))
+		return true, nil
+	}
 
-		log.Warnf("[%s] acme: calling renewal info endpoint: %v", domain, err)
+	// The STAR certificates share the private key of the order.
+	certRes.PrivateKey, err = certsStorage.ReadFile(domain, keyExt)
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return false, fmt.Errorf("error while loading the private key for domain %s: %w", domain, err)
+	}
 
-		return nil
+	certRes.Domain = domain
+
+	err = verifyCertificate(ctx, certRes, certcrypto.ExtractDomains(cert))
+	if err != nil {
+		return true, fmt.Errorf("[%s] the certificate has not been saved: %w", domain, err)
+	}
+
+	previous := readStoredChain(ctx, certsStorage, domain)
+
+	err = certsStorage.SaveResource(certRes)
+	if err != nil {
+		return true, err
+	}
+
+	err = handleTLSA(ctx, certsStorage, certRes, previous, keyType)
+	if err != nil {
+		return true, fmt.Errorf("[%s] could not handle the TLSA records: %w", domain, err)
+	}
+
+	checkMTASTS(ctx, certRes)
+
+	addPathToMetadata(meta, domain, certRes, certsStorage)
+
+	return true, launchHook(ctx.String(flgRenewHook), ctx.Duration(flgRenewHookTimeout), meta)
+}
+
+// reusablePrivateKey returns the stored private key if it matches the certificate.
+// The key and the certificate can be out of sync (ex: after a partial restore of the files),
+// in this case a new private key is generated instead of failing.
+func reusablePrivateKey(cert *x509.Certificate, domain string, privateKey crypto.PrivateKey, meta map[string]string) crypto.PrivateKey {
+	err := certcrypto.MatchPrivateKey(cert, privateKey)
+	if err == nil {
+		return privateKey
+	}
+
+	log.Warnf("[%s] The stored private key cannot be reused (%v): a new private key will be generated.", domain, err)
+
+	meta[hookEnvCertKeyRegenerated] = "true"
+
+	return nil
+}
+
+// renewalClient creates the client used by the renewal decision:
+// the renewal information (ARI) and the revocation status (OCSP) are checked with the CA.
+// The client is nil if there is nothing to check with the CA (ARI disabled and no OCSP server).
+func renewalClient(ctx *cli.Context, account *Account, keyType certcrypto.KeyType, cert *x509.Certificate) (*lego.Client, error) {
+	if ctx.Bool(flgARIDisable) && len(cert.OCSPServer) == 0 {
+		return nil, nil
+	}
+
+	return setupClient(ctx, account, keyType)
+}
+
+// renewalDecision decides if the certificate must be renewed (certificate.NeedsRenewal):
+// the revocation status, the renewal information of the CA (ARI), and the dates of the certificate.
+func renewalDecision(ctx *cli.Context, client *lego.Client, certificates []*x509.Certificate) (*certificate.RenewalDecision, error) {
+	opts := certificate.RenewalOptions{
+		Days:              ctx.Int(flgRenewDays),
+		Dynamic:           ctx.Bool(flgRenewDynamic),
+		DisableARI:        ctx.Bool(flgARIDisable),
+		ARIWillingToSleep: ctx.Duration(flgARIWaitToRenewDuration),
+		CheckRevocation:   true,
+	}
+
+	if client != nil {
+		opts.Certifier = client.Certificate
 	}
 
+	return certificate.NeedsRenewalCertificates(ctx.Context, certificates, opts)
+}
+
+// waitRenewalTime sleeps until the renewal time suggested by the CA (ARI).
+func waitRenewalTime(domain string, decision *certificate.RenewalDecision) {
+	if !decision.Renew {
+		return
+	}
+
+	log.Infof("[%s] %s: renewal.", domain, decision.Reason)
+
 	now := time.Now().UTC()
 
-	renewalTime := renewalInfo.ShouldRenewAt(now, ctx.Duration(flgARIWaitToRenewDuration))
-	if renewalTime == nil {
-		log.Infof("[%s] acme: renewalInfo endpoint indicates that renewal is not needed", domain)
-		return nil
+	if decision.RenewAt.After(now) {
+		log.Infof("[%s] Sleeping %s until renewal time %s", domain, decision.RenewAt.Sub(now), decision.RenewAt)
+		time.Sleep(decision.RenewAt.Sub(now))
+	}
+}
+
+// renewalReplacesCertID returns the ARI CertID of the certificate replaced by the renewal (empty if ARI is disabled).
+func renewalReplacesCertID(ctx *cli.Context, cert *x509.Certificate, domain string) (string, error) {
+	if ctx.Bool(flgARIDisable) {
+		return "", nil
+	}
+
+	replacesCertID, err := certificate.MakeARICertID(cert)
+	if err != nil {
+		return "", fmt.Errorf("error while construction the ARI CertID for domain %s: %w", domain, err)
 	}
 
-	log.Infof("[%s] acme: renewalInfo endpoint indicates that renewal is needed", domain)
+	return replacesCertID, nil
+}
+
+// changeDomains adds (or removes) the domains to (or from) the domains of the certificate.
+// The change is nil if the domains are unchanged.
+func changeDomains(certDomains, domains []string, remove bool, now time.Time) ([]string, *domainChange, error) {
+	change := &domainChange{Date: now.UTC()}
 
-	if renewalInfo.ExplanationURL != "" {
-		log.Infof("[%s] acme: renewalInfo endpoint provided an explanation: %s", domain, renewalInfo.ExplanationURL)
+	var newDomains []string
+
+	if remove {
+		for _, domain := range certDomains {
+			if slices.Contains(domains, domain) {
+				change.Removed = append(change.Removed, domain)
+				continue
+			}
+
+			newDomains = append(newDomains, domain)
+		}
+
+		if len(newDomains) == 0 {
+			return nil, nil, errors.New("all the domains of the certificate cannot be removed")
+		}
+	} else {
+		for _, domain := range domains {
+			if !slices.Contains(certDomains, domain) && !slices.Contains(change.Added, domain) {
+				change.Added = append(change.Added, domain)
+			}
+		}
+
+		newDomains = merge(slices.Clone(certDomains), domains)
+	}
+
+	if len(change.Added) == 0 && len(change.Removed) == 0 {
+		return newDomains, nil, nil
+	}
+
+	return newDomains, change, nil
+}
+
+// missingDomains returns the domains not covered by the domains of the certificate.
+func missingDomains(certDomains, domains []string) []string {
+	var missing []string
+
+	for _, domain := range domains {
+		if !slices.Contains(certDomains, domain) && !slices.Contains(missing, domain) {
+			missing = append(missing, domain)
+		}
 	}
 
-	return renewalTime
+	return missing
 }
 
 func merge(prevDomains, nextDomains []string) []string {
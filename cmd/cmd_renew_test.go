@@ -1,11 +1,32 @@
 package cmd
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
+	"flag"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
 )
 
 func Test_merge(t *testing.T) {
@@ -57,6 +78,112 @@ func Test_merge(t *testing.T) {
 	}
 }
 
+func Test_changeDomains(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc           string
+		certDomains    []string
+		domains        []string
+		remove         bool
+		expected       []string
+		expectedChange *domainChange
+	}{
+		{
+			desc:           "add",
+			certDomains:    []string{"example.com", "www.example.com"},
+			domains:        []string{"new.example.com", "www.example.com", "new.example.com"},
+			expected:       []string{"example.com", "www.example.com", "new.example.com"},
+			expectedChange: &domainChange{Date: now, Added: []string{"new.example.com"}},
+		},
+		{
+			desc:        "add existing",
+			certDomains: []string{"example.com", "www.example.com"},
+			domains:     []string{"www.example.com"},
+			expected:    []string{"example.com", "www.example.com"},
+		},
+		{
+			desc:           "remove",
+			certDomains:    []string{"example.com", "www.example.com", "old.example.com"},
+			domains:        []string{"old.example.com", "unknown.example.com"},
+			remove:         true,
+			expected:       []string{"example.com", "www.example.com"},
+			expectedChange: &domainChange{Date: now, Removed: []string{"old.example.com"}},
+		},
+		{
+			desc:           "remove the first domain",
+			certDomains:    []string{"example.com", "www.example.com"},
+			domains:        []string{"example.com"},
+			remove:         true,
+			expected:       []string{"www.example.com"},
+			expectedChange: &domainChange{Date: now, Removed: []string{"example.com"}},
+		},
+		{
+			desc:        "remove unknown",
+			certDomains: []string{"example.com", "www.example.com"},
+			domains:     []string{"unknown.example.com"},
+			remove:      true,
+			expected:    []string{"example.com", "www.example.com"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			domains, change, err := changeDomains(test.certDomains, test.domains, test.remove, now)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, domains)
+			assert.Equal(t, test.expectedChange, change)
+		})
+	}
+}
+
+func Test_changeDomains_removeAll(t *testing.T) {
+	_, _, err := changeDomains([]string{"example.com"}, []string{"example.com"}, true, time.Now())
+	require.EqualError(t, err, "all the domains of the certificate cannot be removed")
+}
+
+func Test_missingDomains(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		certDomains []string
+		domains     []string
+		expected    []string
+	}{
+		{
+			desc:        "covered",
+			certDomains: []string{"example.com", "www.example.com"},
+			domains:     []string{"www.example.com", "example.com"},
+		},
+		{
+			desc:        "added on the command line",
+			certDomains: []string{"example.com", "www.example.com"},
+			domains:     []string{"example.com", "new.example.com", "new.example.com"},
+			expected:    []string{"new.example.com"},
+		},
+		{
+			desc:        "dropped by a partial issuance",
+			certDomains: []string{"example.com"},
+			domains:     []string{"example.com", "stale.example.com", "www.example.com"},
+			expected:    []string{"stale.example.com", "www.example.com"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			missing := missingDomains(test.certDomains, test.domains)
+			assert.Equal(t, test.expected, missing)
+
+			// The renewal domains (merge) cover the domains of the command line.
+			assert.Empty(t, missingDomains(merge(slices.Clone(test.certDomains), test.domains), test.domains))
+		})
+	}
+}
+
 func Test_needRenewal(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -108,47 +235,45 @@ func Test_needRenewal(t *testing.T) {
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
-			actual := needRenewal(test.x509Cert, "foo.com", test.days, false)
+			decision, err := renewalDecision(newRenewalContext(t, test.days, false), nil, []*x509.Certificate{test.x509Cert})
+			require.NoError(t, err)
 
-			assert.Equal(t, test.expected, actual)
+			assert.Equal(t, test.expected, decision.Renew)
 		})
 	}
 }
 
-func Test_needRenewalDynamic(t *testing.T) {
+func Test_renewalDecision(t *testing.T) {
 	testCases := []struct {
-		desc                string
-		now                 time.Time
-		notBefore, notAfter time.Time
-		expected            assert.BoolAssertionFunc
+		desc     string
+		notAfter time.Time
+		days     int
+		dynamic  bool
+		expected bool
 	}{
 		{
-			desc:      "higher than 1/3 of the certificate lifetime left (lifetime > 10 days)",
-			now:       time.Date(2025, 1, 19, 1, 1, 1, 1, time.UTC),
-			notBefore: time.Date(2025, 1, 1, 1, 1, 1, 1, time.UTC),
-			notAfter:  time.Date(2025, 1, 30, 1, 1, 1, 1, time.UTC),
-			expected:  assert.False,
+			desc:     "30 days, NotAfter 10 days",
+			notAfter: time.Now().Add(10 * 24 * time.Hour),
+			days:     30,
+			expected: true,
 		},
 		{
-			desc:      "lower than 1/3 of the certificate lifetime left(lifetime > 10 days)",
-			now:       time.Date(2025, 1, 21, 1, 1, 1, 1, time.UTC),
-			notBefore: time.Date(2025, 1, 1, 1, 1, 1, 1, time.UTC),
-			notAfter:  time.Date(2025, 1, 30, 1, 1, 1, 1, time.UTC),
-			expected:  assert.True,
+			desc:     "30 days, NotAfter 60 days",
+			notAfter: time.Now().Add(60 * 24 * time.Hour),
+			days:     30,
+			expected: false,
 		},
 		{
-			desc:      "higher than 1/2 of the certificate lifetime left (lifetime < 10 days)",
-			now:       time.Date(2025, 1, 4, 1, 1, 1, 1, time.UTC),
-			notBefore: time.Date(2025, 1, 1, 1, 1, 1, 1, time.UTC),
-			notAfter:  time.Date(2025, 1, 10, 1, 1, 1, 1, time.UTC),
-			expected:  assert.False,
+			desc:     "dynamic, lower than 1/3 of the certificate lifetime left",
+			notAfter: time.Now().Add(20 * 24 * time.Hour),
+			dynamic:  true,
+			expected: true,
 		},
 		{
-			desc:      "lower than 1/2 of the certificate lifetime left (lifetime < 10 days)",
-			now:       time.Date(2025, 1, 6, 1, 1, 1, 1, time.UTC),
-			notBefore: time.Date(2025, 1, 1, 1, 1, 1, 1, time.UTC),
-			notAfter:  time.Date(2025, 1, 10, 1, 1, 1, 1, time.UTC),
-			expected:  assert.True,
+			desc:     "dynamic, higher than 1/3 of the certificate lifetime left",
+			notAfter: time.Now().Add(40 * 24 * time.Hour),
+			dynamic:  true,
+			expected: false,
 		},
 	}
 
@@ -156,14 +281,304 @@ func Test_needRenewalDynamic(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Parallel()
 
-			x509Cert := &x509.Certificate{
-				NotBefore: test.notBefore,
-				NotAfter:  test.notAfter,
-			}
+			cert := createTestCertificate(t, test.notAfter)
+
+			decision, err := renewalDecision(newRenewalContext(t, test.days, test.dynamic), nil, []*x509.Certificate{cert})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, decision.Renew)
+		})
+	}
+}
+
+func Test_renewalDecision_CA(t *testing.T) {
+	ctx := cli.NewContext(cli.NewApp(), flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	ctx.Context = t.Context()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, privateKey.Public(), privateKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	_, err = renewalDecision(ctx, nil, []*x509.Certificate{cert})
+	require.EqualError(t, err, "the certificate bundle starts with a CA certificate")
+}
+
+func newRenewalContext(t *testing.T, days int, dynamic bool) *cli.Context {
+	t.Helper()
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Int(flgRenewDays, days, "")
+	flags.Bool(flgRenewDynamic, dynamic, "")
+	flags.Bool(flgARIDisable, true, "")
+
+	ctx := cli.NewContext(cli.NewApp(), flags, nil)
+	ctx.Context = t.Context()
+
+	return ctx
+}
+
+func createTestCertificate(t *testing.T, notAfter time.Time) *x509.Certificate {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    notAfter.Add(-90 * 24 * time.Hour),
+		NotAfter:     notAfter,
+		DNSNames:     []string{"example.com"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, privateKey.Public(), privateKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func Test_reusablePrivateKey(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"example.com"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, privateKey.Public(), privateKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc         string
+		privateKey   crypto.PrivateKey
+		expected     crypto.PrivateKey
+		expectedMeta map[string]string
+	}{
+		{
+			desc:         "matching key",
+			privateKey:   privateKey,
+			expected:     privateKey,
+			expectedMeta: map[string]string{},
+		},
+		{
+			desc:         "mismatching key",
+			privateKey:   otherKey,
+			expectedMeta: map[string]string{hookEnvCertKeyRegenerated: "true"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
 
-			ok := needRenewalDynamic(x509Cert, "example.com", test.now)
+			meta := map[string]string{}
 
-			test.expected(t, ok)
+			key := reusablePrivateKey(cert, "example.com", test.privateKey, meta)
+
+			assert.Equal(t, test.expected, key)
+			assert.Equal(t, test.expectedMeta, meta)
 		})
 	}
 }
+
+func Test_setupDryRun(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.String(flgServer, lego.LEDirectoryProduction, "")
+	flags.String(flgRenewDryRunServer, lego.LEDirectoryStaging, "")
+
+	ctx := cli.NewContext(cli.NewApp(), flags, nil)
+
+	setupDryRun(ctx)
+
+	assert.Equal(t, lego.LEDirectoryStaging, ctx.String(flgServer))
+}
+
+func Test_renew_dryRun(t *testing.T) {
+	path := t.TempDir()
+
+	production := writeProductionCertificate(t, path, "example.com")
+
+	server := newDryRunCA(t, false)
+
+	hookOutput := filepath.Join(t.TempDir(), "hook")
+
+	err := runDryRun(t, path, server.URL, hookOutput)
+	require.NoError(t, err)
+
+	// The certificate has not been saved, and the hooks have not been executed.
+	assert.Equal(t, production, readDirFiles(t, filepath.Join(path, "certificates")))
+	assert.NoFileExists(t, hookOutput)
+
+	// The account of the test CA is registered on the first dry run.
+	assert.DirExists(t, filepath.Join(path, "accounts", strings.ReplaceAll(strings.TrimPrefix(server.URL, "https://"), ":", "_")))
+}
+
+func Test_renew_dryRun_failure(t *testing.T) {
+	path := t.TempDir()
+
+	production := writeProductionCertificate(t, path, "example.com")
+
+	server := newDryRunCA(t, true)
+
+	hookOutput := filepath.Join(t.TempDir(), "hook")
+
+	err := runDryRun(t, path, server.URL, hookOutput)
+	require.ErrorContains(t, err, "oops")
+
+	// The failure is not recorded, and the failure hook has not been executed.
+	assert.Equal(t, production, readDirFiles(t, filepath.Join(path, "certificates")))
+	assert.NoFileExists(t, hookOutput)
+}
+
+// runDryRun runs the renew command with --dry-run, the hooks write in the hook output file.
+func runDryRun(t *testing.T, path, serverURL, hookOutput string) error {
+	t.Helper()
+
+	app := &cli.App{Flags: CreateFlags(""), Commands: CreateCommands()}
+
+	return app.RunContext(t.Context(), []string{
+		"lego",
+		"--path", path,
+		"--email", "test@example.com",
+		"--accept-tos",
+		"--domains", "example.com",
+		"--http",
+		"--tls-skip-verify",
+		"--reachability-check", reachabilityOff,
+		"renew",
+		"--dry-run",
+		"--dry-run-server", serverURL + "/dir",
+		"--no-random-sleep",
+		"--renew-hook", "touch " + hookOutput,
+		"--failure-hook", "touch " + hookOutput,
+	})
+}
+
+// newDryRunCA creates a test CA with valid authorizations (no challenge).
+func newDryRunCA(t *testing.T, failFinalize bool) *httptest.Server {
+	t.Helper()
+
+	certPEM, _ := createTestCertificatePEM(t, "example.com")
+
+	return tester.MockACMEServer().
+		Route("POST /account", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("Location", fmt.Sprintf("https://%s/account/1", req.Context().Value(http.LocalAddrContextKey)))
+
+			servermock.JSONEncode(acme.Account{Status: acme.StatusValid}).ServeHTTP(rw, req)
+		})).
+		Route("POST /newOrder", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			rw.Header().Set("Location", serverURL+"/order")
+
+			servermock.JSONEncode(acme.Order{
+				Status:         acme.StatusPending,
+				Identifiers:    []acme.Identifier{{Type: "dns", Value: "example.com"}},
+				Authorizations: []string{serverURL + "/authz"},
+				Finalize:       serverURL + "/finalize",
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /authz", servermock.JSONEncode(acme.Authorization{
+			Status:     acme.StatusValid,
+			Identifier: acme.Identifier{Type: "dns", Value: "example.com"},
+		})).
+		Route("POST /finalize", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if failFinalize {
+				rw.Header().Set("Content-Type", "application/problem+json")
+				rw.WriteHeader(http.StatusForbidden)
+				_, _ = rw.Write([]byte(`{"type":"urn:ietf:params:acme:error:unauthorized","detail":"oops"}`))
+
+				return
+			}
+
+			servermock.JSONEncode(acme.Order{
+				Status:      acme.StatusValid,
+				Certificate: fmt.Sprintf("https://%s/certificate", req.Context().Value(http.LocalAddrContextKey)),
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /certificate", servermock.RawStringResponse(string(certPEM))).
+		BuildHTTPS(t)
+}
+
+// writeProductionCertificate writes the certificate of the production CA, and returns the content of the certificates directory.
+func writeProductionCertificate(t *testing.T, path, domain string) map[string]string {
+	t.Helper()
+
+	dir := filepath.Join(path, "certificates")
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+
+	certPEM, key := createTestCertificatePEM(t, domain)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, domain+certExt), certPEM, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, domain+keyExt), certcrypto.PEMEncode(key), 0o600))
+
+	return readDirFiles(t, dir)
+}
+
+// createTestCertificatePEM creates a self-signed certificate for the domain.
+func createTestCertificatePEM(t *testing.T, domain string) ([]byte, crypto.PrivateKey) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		DNSNames:     []string{domain},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, privateKey.Public(), privateKey)
+	require.NoError(t, err)
+
+	return certcrypto.PEMEncode(certcrypto.DERCertificateBytes(der)), privateKey
+}
+
+// readDirFiles returns the content of the files of a directory (recursively).
+func readDirFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	files := map[string]string{}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		files[path] = string(data)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	return files
+}
@@ -1,37 +1,133 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+
 	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/lego"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/wait"
+	"github.com/mattn/go-isatty"
 	"github.com/urfave/cli/v2"
 )
 
 // Flag names.
 const (
-	flgKeep   = "keep"
-	flgReason = "reason"
+	flgKeep    = "keep"
+	flgReason  = "reason"
+	flgCert    = "cert"
+	flgCertKey = "cert-key"
+	flgSerial  = "serial"
+	flgIssuer  = "issuer"
+	flgAll     = "all"
+	flgYes     = "yes"
+	flgDelay   = "delay"
 )
 
+// revocationReasons the CRL reason names (RFC 5280) accepted by the reason flag.
+var revocationReasons = map[string]uint{
+	"unspecified":          acme.CRLReasonUnspecified,
+	"keycompromise":        acme.CRLReasonKeyCompromise,
+	"cacompromise":         acme.CRLReasonCACompromise,
+	"affiliationchanged":   acme.CRLReasonAffiliationChanged,
+	"superseded":           acme.CRLReasonSuperseded,
+	"cessationofoperation": acme.CRLReasonCessationOfOperation,
+	"certificatehold":      acme.CRLReasonCertificateHold,
+	"removefromcrl":        acme.CRLReasonRemoveFromCRL,
+	"privilegewithdrawn":   acme.CRLReasonPrivilegeWithdrawn,
+	"aacompromise":         acme.CRLReasonAACompromise,
+}
+
 func createRevoke() *cli.Command {
 	return &cli.Command{
 		Name:   "revoke",
 		Usage:  "Revoke a certificate",
 		Action: revoke,
+		Before: func(ctx *cli.Context) error {
+			if ctx.IsSet(flgCert) && ctx.IsSet(flgSerial) {
+				log.Fatalf("Please specify either --%s or --%s, but not both", flgCert, flgSerial)
+			}
+
+			if ctx.Bool(flgAll) && (ctx.IsSet(flgCert) || ctx.IsSet(flgSerial) || len(ctx.StringSlice(flgDomains)) > 0 || ctx.IsSet(flgCertName)) {
+				log.Fatalf("--%s cannot be used with --%s, --%s, --%s, or --%s/-d", flgAll, flgCert, flgSerial, flgCertName, flgDomains)
+			}
+
+			_, err := parseRevocationReason(ctx.String(flgReason))
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if ctx.IsSet(flgIssuer) && !ctx.IsSet(flgSerial) {
+				log.Fatalf("--%s requires --%s", flgIssuer, flgSerial)
+			}
+
+			if ctx.IsSet(flgCertKey) && !ctx.IsSet(flgCert) && !ctx.IsSet(flgSerial) {
+				log.Fatalf("--%s requires --%s or --%s", flgCertKey, flgCert, flgSerial)
+			}
+
+			return nil
+		},
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:    flgKeep,
 				Aliases: []string{"k"},
 				Usage:   "Keep the certificates after the revocation instead of archiving them.",
 			},
-			&cli.UintFlag{
+			&cli.StringFlag{
 				Name: flgReason,
 				Usage: "Identifies the reason for the certificate revocation." +
 					" See https://www.rfc-editor.org/rfc/rfc5280.html#section-5.3.1." +
-					" Valid values are:" +
+					" Valid values are (code or name):" +
 					" 0 (unspecified), 1 (keyCompromise), 2 (cACompromise), 3 (affiliationChanged)," +
 					" 4 (superseded), 5 (cessationOfOperation), 6 (certificateHold), 8 (removeFromCRL)," +
 					" 9 (privilegeWithdrawn), or 10 (aACompromise).",
-				Value: acme.CRLReasonUnspecified,
+				Value: strconv.FormatUint(uint64(acme.CRLReasonUnspecified), 10),
+			},
+			&cli.StringFlag{
+				Name:  flgCert,
+				Usage: "Revoke the certificate from this PEM file instead of a certificate from the storage (the certificate is not archived). Required to revoke a certificate issued elsewhere (not in the storage).",
+			},
+			&cli.StringFlag{
+				Name:  flgCertKey,
+				Usage: "Sign the revocation request with the private key of the certificate (PEM file) instead of the account key.",
+			},
+			&cli.StringFlag{
+				Name:  flgSerial,
+				Usage: "Revoke the certificate with this serial number (hexadecimal). The certificate is searched only in the storage (including the archives): use --cert for a certificate issued elsewhere.",
+			},
+			&cli.StringFlag{
+				Name:  flgIssuer,
+				Usage: "Path to the PEM encoded issuer certificate, used to identify the certificate to revoke by serial number.",
+			},
+			&cli.BoolFlag{
+				Name:  flgAll,
+				Usage: "Revoke all the certificates of the storage (e.g. during a key compromise incident).",
+			},
+			&cli.BoolFlag{
+				Name:    flgYes,
+				Aliases: []string{"y"},
+				Usage:   "Skip the confirmation of the revocation of all the certificates.",
+			},
+			&cli.DurationFlag{
+				Name:  flgDelay,
+				Usage: "Delay between two revocations when revoking all the certificates.",
+				Value: time.Second,
 			},
 		},
 	}
@@ -44,12 +140,31 @@ func revoke(ctx *cli.Context) error {
 		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", account.Email)
 	}
 
-	client := newClient(ctx, account, keyType)
+	client, err := newClient(ctx, account, keyType)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	certsStorage := NewCertificatesStorage(ctx)
+
+	if ctx.IsSet(flgCert) || ctx.IsSet(flgSerial) {
+		return revokeExternal(ctx, client, certsStorage)
+	}
+
+	if ctx.Bool(flgAll) {
+		return revokeAll(ctx, client, certsStorage)
+	}
+
 	certsStorage.CreateRootFolder()
 
-	for _, domain := range ctx.StringSlice(flgDomains) {
+	domains := ctx.StringSlice(flgDomains)
+
+	// All the domains of the certificate share the same files.
+	if name := ctx.String(flgCertName); name != "" {
+		domains = []string{name}
+	}
+
+	for _, domain := range domains {
 		log.Printf("Trying to revoke certificate for domain %s", domain)
 
 		certBytes, err := certsStorage.ReadFile(domain, certExt)
@@ -57,9 +172,9 @@ func revoke(ctx *cli.Context) error {
 			log.Fatalf("Error while revoking the certificate for domain %s\n\t%v", domain, err)
 		}
 
-		reason := ctx.Uint(flgReason)
+		reason, _ := parseRevocationReason(ctx.String(flgReason))
 
-		err = client.Certificate.RevokeWithReason(certBytes, &reason)
+		err = client.Certificate.RevokeWithReasonContext(ctx.Context, certBytes, &reason)
 		if err != nil {
 			log.Fatalf("Error while revoking the certificate for domain %s\n\t%v", domain, err)
 		}
@@ -82,3 +197,279 @@ func revoke(ctx *cli.Context) error {
 
 	return nil
 }
+
+// revokeExternal revokes a certificate identified by a file or by a serial number.
+// The certificate is not archived because it may not be managed by the storage.
+func revokeExternal(ctx *cli.Context, client *lego.Client, certsStorage *CertificatesStorage) error {
+	var (
+		certBytes []byte
+		err       error
+	)
+
+	if ctx.IsSet(flgCert) {
+		certBytes, err = os.ReadFile(ctx.String(flgCert))
+		if err != nil {
+			return fmt.Errorf("read certificate: %w", err)
+		}
+	} else {
+		certBytes, err = findCertificateBySerial(certsStorage, ctx.String(flgSerial), ctx.String(flgIssuer))
+		if err != nil {
+			return err
+		}
+	}
+
+	reason, _ := parseRevocationReason(ctx.String(flgReason))
+
+	if !ctx.IsSet(flgCertKey) {
+		err = client.Certificate.RevokeWithReasonContext(ctx.Context, certBytes, &reason)
+		if err != nil {
+			return fmt.Errorf("revoke certificate: %w", err)
+		}
+
+		log.Println("Certificate was revoked.")
+
+		return nil
+	}
+
+	privateKey, err := loadPrivateKey(ctx.String(flgCertKey))
+	if err != nil {
+		return fmt.Errorf("load certificate private key: %w", err)
+	}
+
+	err = client.Certificate.RevokeWithKeyContext(ctx.Context, certBytes, &reason, privateKey)
+	if err != nil {
+		return fmt.Errorf("revoke certificate: %w", err)
+	}
+
+	log.Println("Certificate was revoked (signed with the certificate key).")
+
+	return nil
+}
+
+// findCertificateBySerial searches a certificate in the storage (including the archives) by its serial number.
+// The ACME protocol requires the whole certificate to revoke it, a serial number is not enough.
+func findCertificateBySerial(certsStorage *CertificatesStorage, rawSerial, issuerPath string) ([]byte, error) {
+	serial, ok := new(big.Int).SetString(strings.ReplaceAll(strings.TrimPrefix(strings.ToLower(rawSerial), "0x"), ":", ""), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid serial number: %s", rawSerial)
+	}
+
+	var issuer *x509.Certificate
+
+	if issuerPath != "" {
+		issuerBytes, err := os.ReadFile(issuerPath)
+		if err != nil {
+			return nil, fmt.Errorf("read issuer: %w", err)
+		}
+
+		issuer, err = certcrypto.ParsePEMCertificate(issuerBytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse issuer: %w", err)
+		}
+	}
+
+	var matches []string
+
+	for _, dir := range []string{certsStorage.rootPath, certsStorage.archivePath} {
+		files, err := filepath.Glob(filepath.Join(dir, "*"+certExt))
+		if err != nil {
+			return nil, err
+		}
+
+		matches = append(matches, files...)
+	}
+
+	for _, filename := range matches {
+		if strings.HasSuffix(filename, issuerExt) {
+			continue
+		}
+
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		cert, err := certcrypto.ParsePEMCertificate(data)
+		if err != nil {
+			continue
+		}
+
+		if cert.SerialNumber.Cmp(serial) != 0 {
+			continue
+		}
+
+		if issuer != nil && cert.CheckSignatureFrom(issuer) != nil {
+			continue
+		}
+
+		log.Printf("Found the certificate %s", filename)
+
+		return data, nil
+	}
+
+	return nil, fmt.Errorf("no certificate with the serial number %s found in the storage: "+
+		"the ACME protocol requires the certificate itself, use --%s with the certificate file", rawSerial, flgCert)
+}
+
+type revocationResult struct {
+	name   string
+	serial string
+	err    error
+}
+
+// revokeAll revokes all the certificates of the storage, waiting between the calls to respect the rate limits of the CA.
+func revokeAll(ctx *cli.Context, client *lego.Client, certsStorage *CertificatesStorage) error {
+	names, err := certsStorage.listCertificateNames()
+	if err != nil {
+		return err
+	}
+
+	if len(names) == 0 {
+		log.Println("No certificates found.")
+		return nil
+	}
+
+	reason, _ := parseRevocationReason(ctx.String(flgReason))
+
+	if !ctx.Bool(flgYes) && !confirmRevokeAll(len(names), ctx.String(flgReason)) {
+		log.Fatal("Revocation aborted.")
+	}
+
+	results, err := revokeSequentially(ctx.Context, names, ctx.Duration(flgDelay), func(name string) revocationResult {
+		result := revocationResult{name: name}
+
+		result.serial, result.err = revokeStored(ctx.Context, client, certsStorage, name, reason)
+		if result.err == nil && !ctx.Bool(flgKeep) {
+			certsStorage.CreateArchiveFolder()
+
+			result.err = certsStorage.MoveToArchive(name)
+		}
+
+		return result
+	})
+
+	errR := revocationReport(os.Stdout, results)
+
+	if err != nil {
+		return fmt.Errorf("revocation interrupted after %d of %d certificates: %w", len(results), len(names), err)
+	}
+
+	return errR
+}
+
+// revokeSequentially revokes the certificates one by one, waiting for the delay between the calls.
+// It stops when the context is canceled.
+func revokeSequentially(ctx context.Context, names []string, delay time.Duration, revoke func(name string) revocationResult) ([]revocationResult, error) {
+	var results []revocationResult
+
+	for i, name := range names {
+		if i > 0 {
+			err := wait.Sleep(ctx, delay)
+			if err != nil {
+				return results, err
+			}
+		}
+
+		results = append(results, revoke(name))
+	}
+
+	return results, nil
+}
+
+// revokeStored revokes a certificate of the storage, and retries with a backoff when the CA rate limits the requests.
+func revokeStored(ctx context.Context, client *lego.Client, certsStorage *CertificatesStorage, name string, reason uint) (string, error) {
+	certBytes, err := certsStorage.ReadFile(name, certExt)
+	if err != nil {
+		return "", err
+	}
+
+	cert, err := certcrypto.ParsePEMCertificate(certBytes)
+	if err != nil {
+		return "", err
+	}
+
+	serial := fmt.Sprintf("%x", cert.SerialNumber)
+
+	log.Printf("Trying to revoke certificate %s (serial: %s)", name, serial)
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = 5 * time.Second
+
+	operation := func() error {
+		errR := client.Certificate.RevokeWithReasonContext(ctx, certBytes, &reason)
+
+		var rateLimitedErr *acme.RateLimitedError
+		if errR != nil && !errors.As(errR, &rateLimitedErr) {
+			return backoff.Permanent(errR)
+		}
+
+		return errR
+	}
+
+	err = wait.Retry(ctx, operation,
+		backoff.WithBackOff(bo),
+		backoff.WithMaxTries(5))
+
+	return serial, err
+}
+
+func revocationReport(w io.Writer, results []revocationResult) error {
+	var failed int
+
+	_, _ = fmt.Fprintln(w, "Revocation report:")
+
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+
+			_, _ = fmt.Fprintf(w, "  %s (serial: %s): failed: %v\n", result.name, result.serial, result.err)
+
+			continue
+		}
+
+		_, _ = fmt.Fprintf(w, "  %s (serial: %s): revoked\n", result.name, result.serial)
+	}
+
+	_, _ = fmt.Fprintf(w, "%d revoked, %d failed\n", len(results)-failed, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d certificates could not be revoked", failed, len(results))
+	}
+
+	return nil
+}
+
+func confirmRevokeAll(count int, reason string) bool {
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		log.Fatalf("The confirmation requires an interactive terminal, use --%s to revoke all the certificates.", flgYes)
+	}
+
+	fmt.Printf("Do you want to revoke %d certificates (reason: %s)? y/N\n", count, reason)
+
+	text, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		log.Fatalf("Could not read from console: %v", err)
+	}
+
+	switch strings.Trim(text, "\r\n") {
+	case "y", "Y":
+		return true
+	default:
+		return false
+	}
+}
+
+// parseRevocationReason parses a CRL reason from its code or its name (case-insensitive).
+func parseRevocationReason(raw string) (uint, error) {
+	if code, err := strconv.ParseUint(raw, 10, 32); err == nil {
+		if slices.Contains(slices.Collect(maps.Values(revocationReasons)), uint(code)) {
+			return uint(code), nil
+		}
+	}
+
+	if code, ok := revocationReasons[strings.ToLower(raw)]; ok {
+		return code, nil
+	}
+
+	return 0, fmt.Errorf("invalid revocation reason: %s", raw)
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_findCertificateBySerial(t *testing.T) {
+	storage := newTestCertificatesStorage(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	certPEM, err := certcrypto.GeneratePemCert(key, "example.com", nil)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(storage.archivePath, "1700000000.example.com.crt"), certPEM, 0o600)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate(certPEM)
+	require.NoError(t, err)
+
+	serial := fmt.Sprintf("%x", cert.SerialNumber)
+
+	data, err := findCertificateBySerial(storage, serial, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, certPEM, data)
+
+	_, err = findCertificateBySerial(storage, "0x"+serial+"01", "")
+	require.ErrorContains(t, err, "no certificate with the serial number")
+	require.ErrorContains(t, err, "use --cert with the certificate file")
+
+	_, err = findCertificateBySerial(storage, "xyz", "")
+	require.EqualError(t, err, "invalid serial number: xyz")
+}
+
+func Test_parseRevocationReason(t *testing.T) {
+	testCases := []struct {
+		raw      string
+		expected uint
+		err      string
+	}{
+		{raw: "0", expected: acme.CRLReasonUnspecified},
+		{raw: "5", expected: acme.CRLReasonCessationOfOperation},
+		{raw: "cessationOfOperation", expected: acme.CRLReasonCessationOfOperation},
+		{raw: "KEYCOMPROMISE", expected: acme.CRLReasonKeyCompromise},
+		{raw: "7", err: "invalid revocation reason: 7"},
+		{raw: "foo", err: "invalid revocation reason: foo"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.raw, func(t *testing.T) {
+			t.Parallel()
+
+			reason, err := parseRevocationReason(test.raw)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, reason)
+		})
+	}
+}
+
+func Test_revocationReport(t *testing.T) {
+	buf := new(bytes.Buffer)
+
+	err := revocationReport(buf, []revocationResult{
+		{name: "example.com", serial: "1a"},
+		{name: "example.org", serial: "2b", err: errors.New("boom")},
+	})
+	require.EqualError(t, err, "1 of 2 certificates could not be revoked")
+
+	expected := `Revocation report:
+  example.com (serial: 1a): revoked
+  example.org (serial: 2b): failed: boom
+1 revoked, 1 failed
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func Test_revokeSequentially(t *testing.T) {
+	var revoked []string
+
+	results, err := revokeSequentially(t.Context(), []string{"a.example.com", "b.example.com"}, time.Millisecond, func(name string) revocationResult {
+		revoked = append(revoked, name)
+
+		return revocationResult{name: name}
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, revoked)
+	assert.Len(t, results, 2)
+}
+
+func Test_revokeSequentially_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+
+	var revoked []string
+
+	results, err := revokeSequentially(ctx, []string{"a.example.com", "b.example.com"}, time.Hour, func(name string) revocationResult {
+		revoked = append(revoked, name)
+
+		// Interrupted during the delay before the next certificate.
+		cancel()
+
+		return revocationResult{name: name}
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, []string{"a.example.com"}, revoked)
+	assert.Len(t, results, 1)
+}
@@ -4,9 +4,12 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/lego"
 	"github.com/go-acme/lego/v4/log"
@@ -23,7 +26,15 @@ const (
 	flgPrivateKey                     = "private-key"
 	flgPreferredChain                 = "preferred-chain"
 	flgProfile                        = "profile"
+	flgSubject                        = "subject"
 	flgAlwaysDeactivateAuthorizations = "always-deactivate-authorizations"
+	flgDeactivateFailedAuthorizations = "deactivate-failed-authorizations"
+	flgAllowPartial                   = "allow-partial"
+	flgSANOrder                       = "san-order"
+	flgStarLifetime                   = "star.lifetime"
+	flgStarLifetimeAdjust             = "star.lifetime-adjust"
+	flgStarStartDate                  = "star.start-date"
+	flgStarEndDate                    = "star.end-date"
 	flgRunHook                        = "run-hook"
 	flgRunHookTimeout                 = "run-hook-timeout"
 )
@@ -33,6 +44,11 @@ func createRun() *cli.Command {
 		Name:  "run",
 		Usage: "Register an account, then create and install a certificate",
 		Before: func(ctx *cli.Context) error {
+			// The certificates are defined by the configuration file.
+			if ctx.IsSet(flgConfig) {
+				return nil
+			}
+
 			// we require either domains or csr, but not both
 			hasDomains := len(ctx.StringSlice(flgDomains)) > 0
 
@@ -45,6 +61,19 @@ func createRun() *cli.Command {
 				log.Fatal("Please specify --domains/-d (or --csr/-c if you already have a CSR)")
 			}
 
+			// The standard input is used by the domain list, so it can't be used by the TOS prompt.
+			if slices.Contains(ctx.StringSlice(flgDomains), domainsStdin) && !ctx.Bool(flgAcceptTOS) {
+				log.Fatalf("--%s/-d %s requires --%s", flgDomains, domainsStdin, flgAcceptTOS)
+			}
+
+			if err := validateAutoRenewalFlags(ctx); err != nil {
+				log.Fatal(err)
+			}
+
+			if _, err := getSANOrder(ctx); err != nil {
+				log.Fatal(err)
+			}
+
 			return nil
 		},
 		Action: run,
@@ -77,6 +106,11 @@ func createRun() *cli.Command {
 				Usage: "If the CA offers multiple certificate chains, prefer the chain with an issuer matching this Subject Common Name." +
 					" If no match, the default offered chain will be used.",
 			},
+			&cli.StringFlag{
+				Name: flgSubject,
+				Usage: "Set the subject fields of the CSR (e.g. 'O=Acme Corp,OU=Infra,C=DE'), for the CAs honoring them." +
+					" Supported: C, O, OU, L, ST, STREET, POSTALCODE, SERIALNUMBER. Only works if the CSR is generated by lego.",
+			},
 			&cli.StringFlag{
 				Name:  flgProfile,
 				Usage: "If the CA offers multiple certificate profiles (draft-ietf-acme-profiles), choose this one.",
@@ -85,6 +119,43 @@ func createRun() *cli.Command {
 				Name:  flgAlwaysDeactivateAuthorizations,
 				Usage: "Force the authorizations to be relinquished even if the certificate request was successful.",
 			},
+			&cli.BoolFlag{
+				Name: flgDeactivateFailedAuthorizations,
+				Usage: "Relinquish the pending authorizations when the certificate request fails (RFC 8555 §7.5.2)." +
+					" Use --" + flgDeactivateFailedAuthorizations + "=false to keep them.",
+				Value: true,
+			},
+			&cli.BoolFlag{
+				Name: flgAllowPartial,
+				Usage: "If the validation of some domains fails, retry the order without these domains." +
+					" The certificate is issued for the domains that have been validated. Only works with --domains/-d.",
+			},
+			&cli.StringFlag{
+				Name: flgSANOrder,
+				Usage: "The order of the SANs of the CSR: 'ca' keeps the order of the identifiers returned by the CA, 'sorted' sorts them." +
+					" The first domain is always the first SAN. Only works if the CSR is generated by lego.",
+				Value: string(certificate.SANOrderCA),
+			},
+			&cli.DurationFlag{
+				Name: flgStarLifetime,
+				Usage: "Request a Short-Term, Automatically Renewed (STAR) certificate (RFC 8739) with this lifetime:" +
+					" the CA issues a new certificate at every lifetime until --" + flgStarEndDate + "," +
+					" the 'renew' command fetches the new certificates without validation.",
+			},
+			&cli.DurationFlag{
+				Name:  flgStarLifetimeAdjust,
+				Usage: "The amount of \"left pad\" added to each STAR certificate (clock skew tolerance).",
+			},
+			&cli.TimestampFlag{
+				Name:   flgStarStartDate,
+				Usage:  "The earliest date of validity of the first STAR certificate (RFC3339 format). By default, as soon as the authorizations are valid.",
+				Layout: time.RFC3339,
+			},
+			&cli.TimestampFlag{
+				Name:   flgStarEndDate,
+				Usage:  "The latest date of validity of the last STAR certificate (RFC3339 format). Required with --" + flgStarLifetime + ".",
+				Layout: time.RFC3339,
+			},
 			&cli.StringFlag{
 				Name:  flgRunHook,
 				Usage: "Define a hook. The hook is executed when the certificates are effectively created.",
@@ -110,37 +181,115 @@ server. Making regular backups of this folder is ideal.
 `
 
 func run(ctx *cli.Context) error {
+	if ctx.IsSet(flgConfig) {
+		return runConfigFile(ctx)
+	}
+
+	domainGroups, err := readDomainGroups(ctx.StringSlice(flgDomains), os.Stdin)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(domainGroups) == 0 && ctx.String(flgCSR) == "" {
+		log.Fatal("The domain list is empty.")
+	}
+
+	if len(domainGroups) > 1 && ctx.String(flgCertName) != "" {
+		log.Fatalf("--%s cannot be used with several groups of domains: the certificates would have the same name", flgCertName)
+	}
+
+	certsStorage := NewCertificatesStorage(ctx)
+	certsStorage.CreateRootFolder()
+
+	err = preflightStorage(certsStorage)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	accountsStorage := NewAccountsStorage(ctx)
 
 	account, keyType := setupAccount(ctx, accountsStorage)
 
-	client := setupClient(ctx, account, keyType)
+	client, err := setupClient(ctx, account, keyType)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ensureRegistration(ctx, client, account, accountsStorage)
+
+	if len(domainGroups) == 0 {
+		// CSR
+		return obtainAndSave(ctx, client, account, certsStorage, nil)
+	}
 
-	if account.Registration == nil {
-		reg, err := register(ctx, client)
+	// A certificate per group of domains.
+	for _, domains := range domainGroups {
+		err := obtainAndSave(ctx, client, account, certsStorage, domains)
 		if err != nil {
-			log.Fatalf("Could not complete registration\n\t%v", err)
+			return err
 		}
+	}
 
-		account.Registration = reg
-		if err = accountsStorage.Save(account); err != nil {
-			log.Fatal(err)
-		}
+	return nil
+}
 
-		fmt.Printf(rootPathWarningMessage, accountsStorage.GetRootPath())
+// ensureRegistration registers the account, if it's not already registered.
+func ensureRegistration(ctx *cli.Context, client *lego.Client, account *Account, accountsStorage *AccountsStorage) {
+	if account.Registration != nil {
+		return
 	}
 
-	certsStorage := NewCertificatesStorage(ctx)
-	certsStorage.CreateRootFolder()
+	reg, err := register(ctx, client)
+	if err != nil {
+		log.Fatalf("Could not complete registration\n\t%v", err)
+	}
 
-	cert, err := obtainCertificate(ctx, client)
+	account.Registration = reg
+	if err = accountsStorage.Save(account); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf(rootPathWarningMessage, accountsStorage.GetRootPath())
+}
+
+func obtainAndSave(ctx *cli.Context, client *lego.Client, account *Account, certsStorage *CertificatesStorage, domains []string) error {
+	cert, err := obtainCertificate(ctx, client, domains)
 	if err != nil {
 		// Make sure to return a non-zero exit code if ObtainSANCertificate returned at least one error.
 		// Due to us not returning partial certificate we can just exit here instead of at the end.
 		log.Fatalf("Could not obtain certificates:\n\t%v", err)
 	}
 
-	certsStorage.SaveResource(cert)
+	logDroppedDomains(cert)
+
+	if domains == nil && ctx.Bool(flgVerify) {
+		// CSR
+		csr, errR := readCSRFile(ctx.String(flgCSR))
+		if errR != nil {
+			log.Fatalf("Could not read CSR file %q: %v", ctx.String(flgCSR), errR)
+		}
+
+		domains = certcrypto.ExtractDomainsCSR(csr)
+	}
+
+	err = verifyCertificate(ctx, cert, domains)
+	if err != nil {
+		log.Fatalf("[%s] The certificate has not been saved: %v", cert.Domain, err)
+	}
+
+	previous := readStoredChain(ctx, certsStorage, cert.Domain)
+
+	err = certsStorage.SaveResource(cert)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = handleTLSA(ctx, certsStorage, cert, previous, getKeyType(ctx))
+	if err != nil {
+		return fmt.Errorf("[%s] could not handle the TLSA records: %w", cert.Domain, err)
+	}
+
+	checkMTASTS(ctx, cert)
 
 	meta := map[string]string{
 		hookEnvAccountEmail: account.Email,
@@ -151,6 +300,15 @@ func run(ctx *cli.Context) error {
 	return launchHook(ctx.String(flgRunHook), ctx.Duration(flgRunHookTimeout), meta)
 }
 
+// logDroppedDomains reports the domains removed from the certificate by --allow-partial.
+func logDroppedDomains(cert *certificate.Resource) {
+	if len(cert.DroppedDomains) == 0 {
+		return
+	}
+
+	log.Warnf("[%s] The certificate has been issued without the domains: %s", cert.Domain, strings.Join(cert.DroppedDomains, ", "))
+}
+
 func handleTOS(ctx *cli.Context, client *lego.Client) bool {
 	// Check for a global accept override
 	if ctx.Bool(flgAcceptTOS) {
@@ -189,27 +347,55 @@ func register(ctx *cli.Context, client *lego.Client) (*registration.Resource, er
 
 	if ctx.Bool(flgEAB) {
 		kid := ctx.String(flgKID)
-		hmacEncoded := ctx.String(flgHMAC)
+
+		hmacEncoded, err := readEABHmac(ctx)
+		if err != nil {
+			return nil, err
+		}
 
 		if kid == "" || hmacEncoded == "" {
-			log.Fatalf("Requires arguments --%s and --%s.", flgKID, flgHMAC)
+			log.Fatalf("Requires arguments --%s and --%s (or --%s).", flgKID, flgHMAC, flgHMACFile)
 		}
 
-		return client.Registration.RegisterWithExternalAccountBinding(registration.RegisterEABOptions{
+		return client.Registration.RegisterWithExternalAccountBindingContext(ctx.Context, registration.RegisterEABOptions{
 			TermsOfServiceAgreed: accepted,
 			Kid:                  kid,
 			HmacEncoded:          hmacEncoded,
+			HmacEncoding:         api.EABEncoding(ctx.String(flgHMACEncoding)),
 		})
 	}
 
-	return client.Registration.Register(registration.RegisterOptions{TermsOfServiceAgreed: true})
+	return client.Registration.RegisterContext(ctx.Context, registration.RegisterOptions{TermsOfServiceAgreed: true})
 }
 
-func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Resource, error) {
+// readEABHmac gets the EAB HMAC key from the flag or from a file.
+// The key is decoded during the registration (see --hmac-encoding).
+func readEABHmac(ctx *cli.Context) (string, error) {
+	if ctx.IsSet(flgHMAC) && ctx.IsSet(flgHMACFile) {
+		return "", fmt.Errorf("'%s' and '%s' are mutually exclusive", flgHMAC, flgHMACFile)
+	}
+
+	if !ctx.IsSet(flgHMACFile) {
+		return strings.TrimSpace(ctx.String(flgHMAC)), nil
+	}
+
+	data, err := os.ReadFile(ctx.String(flgHMACFile))
+	if err != nil {
+		return "", fmt.Errorf("could not read the HMAC key file: %w", err)
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+func obtainCertificate(ctx *cli.Context, client *lego.Client, domains []string) (*certificate.Resource, error) {
 	bundle := !ctx.Bool(flgNoBundle)
 
-	domains := ctx.StringSlice(flgDomains)
 	if len(domains) > 0 {
+		err := checkReachability(ctx, client, domains)
+		if err != nil {
+			return nil, err
+		}
+
 		// obtain a certificate, generating a new private key
 		request := certificate.ObtainRequest{
 			Domains:                        domains,
@@ -220,18 +406,29 @@ func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Reso
 			PreferredChain:                 ctx.String(flgPreferredChain),
 			Profile:                        ctx.String(flgProfile),
 			AlwaysDeactivateAuthorizations: ctx.Bool(flgAlwaysDeactivateAuthorizations),
+			KeepFailedAuthorizations:       !ctx.Bool(flgDeactivateFailedAuthorizations),
+			AllowPartial:                   ctx.Bool(flgAllowPartial),
+			AutoRenewal:                    getAutoRenewal(ctx),
 		}
 
-		if ctx.IsSet(flgPrivateKey) {
-			var err error
+		request.MutateCSRTemplate, err = getSubjectMutator(ctx)
+		if err != nil {
+			return nil, err
+		}
 
+		request.SANOrder, err = getSANOrder(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if ctx.IsSet(flgPrivateKey) {
 			request.PrivateKey, err = loadPrivateKey(ctx.String(flgPrivateKey))
 			if err != nil {
 				return nil, fmt.Errorf("load private key: %w", err)
 			}
 		}
 
-		return client.Certificate.Obtain(request)
+		return client.Certificate.ObtainContext(ctx.Context, request)
 	}
 
 	// read the CSR
@@ -249,6 +446,8 @@ func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Reso
 		PreferredChain:                 ctx.String(flgPreferredChain),
 		Profile:                        ctx.String(flgProfile),
 		AlwaysDeactivateAuthorizations: ctx.Bool(flgAlwaysDeactivateAuthorizations),
+		KeepFailedAuthorizations:       !ctx.Bool(flgDeactivateFailedAuthorizations),
+		AutoRenewal:                    getAutoRenewal(ctx),
 	}
 
 	if ctx.IsSet(flgPrivateKey) {
@@ -260,5 +459,58 @@ func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Reso
 		}
 	}
 
-	return client.Certificate.ObtainForCSR(request)
+	return client.Certificate.ObtainForCSRContext(ctx.Context, request)
+}
+
+// validateAutoRenewalFlags checks the consistency of the STAR flags.
+func validateAutoRenewalFlags(ctx *cli.Context) error {
+	if !ctx.IsSet(flgStarLifetime) {
+		for _, name := range []string{flgStarLifetimeAdjust, flgStarStartDate, flgStarEndDate} {
+			if ctx.IsSet(name) {
+				return fmt.Errorf("--%s requires --%s", name, flgStarLifetime)
+			}
+		}
+
+		return nil
+	}
+
+	if !ctx.IsSet(flgStarEndDate) {
+		return fmt.Errorf("--%s requires --%s", flgStarLifetime, flgStarEndDate)
+	}
+
+	if ctx.IsSet(flgNotBefore) || ctx.IsSet(flgNotAfter) {
+		return fmt.Errorf("--%s cannot be used with --%s or --%s", flgStarLifetime, flgNotBefore, flgNotAfter)
+	}
+
+	if ctx.Duration(flgStarLifetime) <= 0 {
+		return fmt.Errorf("--%s must be positive", flgStarLifetime)
+	}
+
+	return nil
+}
+
+// getAutoRenewal returns the options of a STAR order, or nil if --star.lifetime is not set.
+func getAutoRenewal(ctx *cli.Context) *api.AutoRenewalOptions {
+	if !ctx.IsSet(flgStarLifetime) {
+		return nil
+	}
+
+	return &api.AutoRenewalOptions{
+		StartDate:      getTime(ctx, flgStarStartDate),
+		EndDate:        getTime(ctx, flgStarEndDate),
+		Lifetime:       ctx.Duration(flgStarLifetime),
+		LifetimeAdjust: ctx.Duration(flgStarLifetimeAdjust),
+	}
+}
+
+// getSANOrder returns the order of the SANs of the CSR (--san-order).
+func getSANOrder(ctx *cli.Context) (certificate.SANOrder, error) {
+	order := certificate.SANOrder(ctx.String(flgSANOrder))
+
+	switch order {
+	case "", certificate.SANOrderCA, certificate.SANOrderSorted:
+		return order, nil
+	default:
+		return "", fmt.Errorf("--%s: unknown value %q: expected '%s' or '%s'", flgSANOrder, order, certificate.SANOrderCA, certificate.SANOrderSorted)
+	}
 }
@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v2"
+)
+
+// configFile the declarative configuration of the certificates (--config), in YAML or TOML.
+//
+// Each certificate is obtained (or renewed) by running the command with the flags of the file:
+// the options and the environment variables of the file, then the fields and the options of the certificate,
+// then the flags of the command line.
+type configFile struct {
+	// Options the flags applied to all the certificates, by name (ex: "server", "email", "dns.resolvers").
+	Options map[string]any `yaml:"options" toml:"options"`
+
+	// Env the environment variables applied to all the certificates (ex: the credentials of the DNS providers).
+	Env map[string]string `yaml:"env" toml:"env"`
+
+	Certificates []configCertificate `yaml:"certificates" toml:"certificates"`
+}
+
+// configCertificate a certificate of the configuration file.
+type configCertificate struct {
+	// Name the name of the certificate files (--cert-name).
+	Name    string   `yaml:"name" toml:"name"`
+	Domains []string `yaml:"domains" toml:"domains"`
+
+	// Email the email of the account (--email).
+	Email   string `yaml:"email" toml:"email"`
+	KeyType string `yaml:"key-type" toml:"key-type"`
+
+	// The challenges (--http, --tls, --dns).
+	HTTP bool   `yaml:"http" toml:"http"`
+	TLS  bool   `yaml:"tls" toml:"tls"`
+	DNS  string `yaml:"dns" toml:"dns"`
+
+	// The hooks (--run-hook, --renew-hook).
+	RunHook   string `yaml:"run-hook" toml:"run-hook"`
+	RenewHook string `yaml:"renew-hook" toml:"renew-hook"`
+
+	// Options the other flags of the certificate, by name.
+	Options map[string]any `yaml:"options" toml:"options"`
+
+	// Env the environment variables of the certificate.
+	Env map[string]string `yaml:"env" toml:"env"`
+}
+
+// options returns the flags of the certificate, with the options applied to all the certificates.
+func (c configCertificate) options(defaults map[string]any) map[string]any {
+	options := maps.Clone(defaults)
+	if options == nil {
+		options = make(map[string]any)
+	}
+
+	maps.Copy(options, c.Options)
+
+	fields := map[string]any{
+		flgCertName:  c.Name,
+		flgEmail:     c.Email,
+		flgKeyType:   c.KeyType,
+		flgHTTP:      c.HTTP,
+		flgTLS:       c.TLS,
+		flgDNS:       c.DNS,
+		flgRunHook:   c.RunHook,
+		flgRenewHook: c.RenewHook,
+		flgDomains:   c.Domains,
+	}
+
+	for name, value := range fields {
+		switch v := value.(type) {
+		case string:
+			if v == "" {
+				continue
+			}
+		case bool:
+			if !v {
+				continue
+			}
+		case []string:
+			if len(v) == 0 {
+				continue
+			}
+		}
+
+		options[name] = value
+	}
+
+	return options
+}
+
+// readConfigFile reads a configuration file: TOML if the extension is ".toml", YAML otherwise.
+func readConfigFile(filename string) (*configFile, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &configFile{}
+
+	if strings.EqualFold(filepath.Ext(filename), ".toml") {
+		err = toml.Unmarshal(data, config)
+	} else {
+		err = yaml.UnmarshalStrict(data, config)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	if len(config.Certificates) == 0 {
+		return nil, fmt.Errorf("%s: no certificates", filename)
+	}
+
+	for i, cert := range config.Certificates {
+		_, hasCSR := cert.Options[flgCSR]
+		if len(cert.Domains) == 0 && !hasCSR {
+			return nil, fmt.Errorf("%s: certificate #%d: the domains are required", filename, i+1)
+		}
+	}
+
+	return config, nil
+}
+
+// runConfigFile runs the command for each certificate of the configuration file (--config).
+// The certificates are handled sequentially, the first failure stops the command.
+func runConfigFile(ctx *cli.Context) error {
+	filename := ctx.String(flgConfig)
+
+	config, err := readConfigFile(filename)
+	if err != nil {
+		return err
+	}
+
+	cliGlobal, cliCommand := commandLineArgs(os.Args, ctx.Lineage()[1].Args().Slice())
+
+	for i, cert := range config.Certificates {
+		global, local, err := optionsToArgs(ctx.App, ctx.Command, cert.options(config.Options))
+		if err != nil {
+			return fmt.Errorf("%s: certificate #%d: %w", filename, i+1, err)
+		}
+
+		args := slices.Concat([]string{ctx.App.Name}, global, cliGlobal, []string{ctx.Command.Name}, local, cliCommand)
+
+		env := maps.Clone(config.Env)
+		if env == nil {
+			env = make(map[string]string)
+		}
+
+		maps.Copy(env, cert.Env)
+
+		log.Infof("%s: certificate #%d (%s)", filename, i+1, strings.Join(cert.Domains, ", "))
+
+		err = withEnv(env, func() error {
+			return ctx.App.RunContext(ctx.Context, args)
+		})
+		if err != nil {
+			return fmt.Errorf("%s: certificate #%d: %w", filename, i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// optionsToArgs converts the options to the arguments of the global flags and of the flags of the command.
+// The options of the other commands are ignored (ex: "run-hook" with the renew command).
+func optionsToArgs(app *cli.App, command *cli.Command, options map[string]any) (global, local []string, err error) {
+	for _, name := range slices.Sorted(maps.Keys(options)) {
+		args := optionToArgs(name, options[name])
+
+		switch {
+		case hasFlag(app.Flags, name):
+			global = append(global, args...)
+
+		case hasFlag(command.Flags, name):
+			local = append(local, args...)
+
+		case !slices.ContainsFunc(app.Commands, func(c *cli.Command) bool { return hasFlag(c.Flags, name) }):
+			return nil, nil, fmt.Errorf("unknown option %q", name)
+		}
+	}
+
+	return global, local, nil
+}
+
+// optionToArgs converts an option to the arguments of a flag: a list is converted to a repeated flag.
+func optionToArgs(name string, value any) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+
+	case []string:
+		var args []string
+		for _, item := range v {
+			args = append(args, "--"+name+"="+item)
+		}
+
+		return args
+
+	case []any:
+		var args []string
+		for _, item := range v {
+			args = append(args, fmt.Sprintf("--%s=%v", name, item))
+		}
+
+		return args
+
+	default:
+		return []string{fmt.Sprintf("--%s=%v", name, v)}
+	}
+}
+
+func hasFlag(flags []cli.Flag, name string) bool {
+	return slices.ContainsFunc(flags, func(flag cli.Flag) bool {
+		return slices.Contains(flag.Names(), name)
+	})
+}
+
+// commandLineArgs splits the arguments of the command line into the global flags (without --config) and the flags of the command.
+// commandArgs are the arguments after the global flags: the name of the command, then its flags.
+func commandLineArgs(osArgs, commandArgs []string) (global, command []string) {
+	end := max(len(osArgs)-len(commandArgs), 1)
+
+	args := osArgs[1:end]
+
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--"+flgConfig || args[i] == "-"+flgConfig:
+			// The value is the next argument.
+			i++
+
+		case strings.HasPrefix(args[i], "--"+flgConfig+"=") || strings.HasPrefix(args[i], "-"+flgConfig+"="):
+			continue
+
+		default:
+			global = append(global, args[i])
+		}
+	}
+
+	if len(commandArgs) > 1 {
+		command = commandArgs[1:]
+	}
+
+	return global, command
+}
+
+// withEnv calls fn with the environment variables, then restores the previous values.
+func withEnv(env map[string]string, fn func() error) error {
+	previous := make(map[string]*string)
+
+	defer func() {
+		for name, value := range previous {
+			if value == nil {
+				_ = os.Unsetenv(name)
+				continue
+			}
+
+			_ = os.Setenv(name, *value)
+		}
+	}()
+
+	for name, value := range env {
+		if old, ok := os.LookupEnv(name); ok {
+			previous[name] = &old
+		} else {
+			previous[name] = nil
+		}
+
+		err := os.Setenv(name, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return fn()
+}
+
+// checkConfigFlags checks the usage of --config.
+func checkConfigFlags(ctx *cli.Context) error {
+	if !ctx.IsSet(flgConfig) {
+		return nil
+	}
+
+	if command := ctx.Args().First(); command != "run" && command != "renew" {
+		return fmt.Errorf("--%s only works with the run and renew commands", flgConfig)
+	}
+
+	if ctx.IsSet(flgDomains) || ctx.IsSet(flgCSR) || ctx.IsSet(flgCertName) {
+		return fmt.Errorf("--%s/-d, --%s/-c, and --%s cannot be used with --%s: the certificates are defined by the file", flgDomains, flgCSR, flgCertName, flgConfig)
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_readConfigFile(t *testing.T) {
+	testCases := []string{"config.yaml", "config.toml"}
+
+	for _, filename := range testCases {
+		t.Run(filename, func(t *testing.T) {
+			t.Parallel()
+
+			config, err := readConfigFile(filepath.Join("testdata", filename))
+			require.NoError(t, err)
+
+			app := &cli.App{Flags: CreateFlags(""), Commands: CreateCommands()}
+
+			require.Len(t, config.Certificates, 2)
+
+			assert.Equal(t, map[string]string{"CLOUDFLARE_DNS_API_TOKEN": "secret"}, config.Env)
+			assert.Equal(t, map[string]string{"CLOUDFLARE_DNS_API_TOKEN": "other"}, config.Certificates[1].Env)
+
+			global, local, err := optionsToArgs(app, createRun(), config.Certificates[0].options(config.Options))
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{
+				"--accept-tos=true",
+				"--cert-name=web-frontend",
+				"--domains=example.com",
+				"--domains=www.example.com",
+				"--email=you@example.com",
+				"--http=true",
+				"--server=https://acme-staging-v02.api.letsencrypt.org/directory",
+			}, global)
+			assert.Equal(t, []string{"--run-hook=./reload.sh"}, local)
+
+			global, local, err = optionsToArgs(app, createRenew(), config.Certificates[1].options(config.Options))
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{
+				"--accept-tos=true",
+				"--dns=cloudflare",
+				"--dns.resolvers=1.1.1.1:53",
+				"--dns.resolvers=8.8.8.8:53",
+				"--domains=api.example.com",
+				"--email=admin@example.com",
+				"--key-type=rsa2048",
+				"--server=https://acme-staging-v02.api.letsencrypt.org/directory",
+			}, global)
+			assert.Equal(t, []string{"--days=45"}, local)
+		})
+	}
+}
+
+func Test_readConfigFile_errors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		filename string
+		content  string
+		expected string
+	}{
+		{
+			desc:     "no certificates",
+			filename: "lego.yaml",
+			content:  "options:\n  email: you@example.com\n",
+			expected: "no certificates",
+		},
+		{
+			desc:     "no domains",
+			filename: "lego.yaml",
+			content:  "certificates:\n  - name: web\n",
+			expected: "certificate #1: the domains are required",
+		},
+		{
+			desc:     "unknown field",
+			filename: "lego.yaml",
+			content:  "certificates:\n  - domain: example.com\n",
+			expected: "field domain not found in type cmd.configCertificate",
+		},
+		{
+			desc:     "invalid TOML",
+			filename: "lego.toml",
+			content:  "[[certificates]\n",
+			expected: "toml:",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			filename := filepath.Join(t.TempDir(), test.filename)
+
+			err := os.WriteFile(filename, []byte(test.content), 0o600)
+			require.NoError(t, err)
+
+			_, err = readConfigFile(filename)
+			require.ErrorContains(t, err, test.expected)
+		})
+	}
+}
+
+func Test_optionsToArgs_unknown(t *testing.T) {
+	app := &cli.App{Flags: CreateFlags(""), Commands: CreateCommands()}
+
+	// A flag of another command is ignored.
+	global, local, err := optionsToArgs(app, createRun(), map[string]any{"days": 45})
+	require.NoError(t, err)
+
+	assert.Empty(t, global)
+	assert.Empty(t, local)
+
+	_, _, err = optionsToArgs(app, createRun(), map[string]any{"unknown": true})
+	require.EqualError(t, err, `unknown option "unknown"`)
+}
+
+func Test_commandLineArgs(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		osArgs          []string
+		commandArgs     []string
+		expectedGlobal  []string
+		expectedCommand []string
+	}{
+		{
+			desc:        "only config",
+			osArgs:      []string{"lego", "--config", "lego.yaml", "run"},
+			commandArgs: []string{"run"},
+		},
+		{
+			desc:            "global and command flags",
+			osArgs:          []string{"lego", "--path", "/data", "--config=lego.yaml", "-s", "https://example.com/dir", "renew", "--days", "45"},
+			commandArgs:     []string{"renew", "--days", "45"},
+			expectedGlobal:  []string{"--path", "/data", "-s", "https://example.com/dir"},
+			expectedCommand: []string{"--days", "45"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			global, command := commandLineArgs(test.osArgs, test.commandArgs)
+
+			assert.Equal(t, test.expectedGlobal, global)
+			assert.Equal(t, test.expectedCommand, command)
+		})
+	}
+}
+
+func Test_withEnv(t *testing.T) {
+	t.Setenv("LEGO_TEST_EXISTING", "before")
+
+	err := withEnv(map[string]string{"LEGO_TEST_EXISTING": "during", "LEGO_TEST_NEW": "new"}, func() error {
+		assert.Equal(t, "during", os.Getenv("LEGO_TEST_EXISTING"))
+		assert.Equal(t, "new", os.Getenv("LEGO_TEST_NEW"))
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "before", os.Getenv("LEGO_TEST_EXISTING"))
+
+	_, ok := os.LookupEnv("LEGO_TEST_NEW")
+	assert.False(t, ok)
+}
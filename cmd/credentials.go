@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/urfave/cli/v2"
+)
+
+// secretFlags the flags that can be defined by a systemd credential, indexed by credential names (the names of the environment variables).
+var secretFlags = map[string]string{
+	envEABKID:      flgKID,
+	envEABHMAC:     flgHMAC,
+	envPFXPassword: flgPFXPass,
+	envJKSPassword: flgJKSPass,
+}
+
+// setupCredentials defines the secret flags from the systemd credentials ($CREDENTIALS_DIRECTORY).
+// The flags and the environment variables take precedence.
+func setupCredentials(ctx *cli.Context) {
+	for name, flag := range secretFlags {
+		if ctx.IsSet(flag) || (flag == flgHMAC && ctx.IsSet(flgHMACFile)) {
+			continue
+		}
+
+		value, ok := env.GetCredential(name)
+		if !ok {
+			continue
+		}
+
+		err := ctx.Set(flag, value)
+		if err != nil {
+			log.Fatalf("Could not set --%s from the credential %s: %v", flag, name, err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_setupCredentials(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, envEABKID), []byte("kid-from-credential\n"), 0o600)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, envEABHMAC), []byte("hmac-from-credential\n"), 0o600)
+	require.NoError(t, err)
+
+	t.Setenv("CREDENTIALS_DIRECTORY", dir)
+
+	app := cli.NewApp()
+	app.Flags = CreateFlags(t.TempDir())
+	app.Action = func(ctx *cli.Context) error {
+		setupCredentials(ctx)
+
+		assert.Equal(t, "kid-from-credential", ctx.String(flgKID))
+		assert.Equal(t, "hmac-from-flag", ctx.String(flgHMAC))
+		assert.Equal(t, "changeit", ctx.String(flgPFXPass))
+
+		return nil
+	}
+
+	err = app.Run([]string{"lego", "--" + flgHMAC, "hmac-from-flag"})
+	require.NoError(t, err)
+}
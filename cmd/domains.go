@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// domainsStdin the value of --domains to read the domains from the standard input.
+	domainsStdin = "-"
+
+	// domainsFilePrefix the prefix of --domains to read the domains from a file.
+	domainsFilePrefix = "@"
+
+	// domainsGroupSeparator the line separating the domains of 2 certificates inside a domain list.
+	domainsGroupSeparator = "---"
+)
+
+// readDomainGroups reads the values of --domains.
+// A value can be a domain, a file (@domains.txt), or the standard input (-).
+// The domains are grouped by certificate: the group separators of the domain lists start a new certificate.
+func readDomainGroups(values []string, stdin io.Reader) ([][]string, error) {
+	var (
+		groups    [][]string
+		current   []string
+		stdinRead bool
+	)
+
+	for _, value := range values {
+		var err error
+
+		switch {
+		case value == domainsStdin:
+			if stdinRead {
+				return nil, errors.New("the standard input can be used only once as domain list")
+			}
+
+			stdinRead = true
+
+			groups, current, err = parseDomainList(stdin, groups, current)
+
+		case strings.HasPrefix(value, domainsFilePrefix):
+			groups, current, err = parseDomainFile(strings.TrimPrefix(value, domainsFilePrefix), groups, current)
+
+		default:
+			current = append(current, value)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("domain list %s: %w", value, err)
+		}
+	}
+
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+
+	return groups, nil
+}
+
+func parseDomainFile(filename string, groups [][]string, current []string) ([][]string, []string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	return parseDomainList(file, groups, current)
+}
+
+// parseDomainList parses a list of domains:
+// one or more domains per line (separated by spaces or commas), `#` starts a comment,
+// and a line `---` separates the domains of 2 certificates.
+func parseDomainList(reader io.Reader, groups [][]string, current []string) ([][]string, []string, error) {
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+
+		line = strings.TrimSpace(line)
+
+		if line == domainsGroupSeparator {
+			if len(current) > 0 {
+				groups = append(groups, current)
+			}
+
+			current = nil
+
+			continue
+		}
+
+		current = append(current, strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})...)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return groups, current, nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readDomainGroups(t *testing.T) {
+	dir := t.TempDir()
+
+	filename := filepath.Join(dir, "domains.txt")
+
+	content := `# main website
+example.com
+www.example.com # legacy
+
+---
+# API
+api.example.com, api2.example.com
+`
+
+	err := os.WriteFile(filename, []byte(content), 0o600)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		values   []string
+		stdin    string
+		expected [][]string
+	}{
+		{
+			desc:     "only domains",
+			values:   []string{"example.com", "www.example.com"},
+			expected: [][]string{{"example.com", "www.example.com"}},
+		},
+		{
+			desc:   "file",
+			values: []string{"@" + filename},
+			expected: [][]string{
+				{"example.com", "www.example.com"},
+				{"api.example.com", "api2.example.com"},
+			},
+		},
+		{
+			desc:   "domains and file",
+			values: []string{"foo.example.com", "@" + filename, "bar.example.com"},
+			expected: [][]string{
+				{"foo.example.com", "example.com", "www.example.com"},
+				{"api.example.com", "api2.example.com", "bar.example.com"},
+			},
+		},
+		{
+			desc:   "stdin",
+			values: []string{"-"},
+			stdin:  "a.example.com\n---\n---\nb.example.com\tc.example.com\n---\n",
+			expected: [][]string{
+				{"a.example.com"},
+				{"b.example.com", "c.example.com"},
+			},
+		},
+		{
+			desc:   "empty",
+			values: []string{"-"},
+			stdin:  "# nothing\n\n",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			groups, err := readDomainGroups(test.values, strings.NewReader(test.stdin))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, groups)
+		})
+	}
+}
+
+func Test_readDomainGroups_errors(t *testing.T) {
+	_, err := readDomainGroups([]string{"-", "-"}, strings.NewReader(""))
+	require.EqualError(t, err, "the standard input can be used only once as domain list")
+
+	_, err = readDomainGroups([]string{"@" + filepath.Join(t.TempDir(), "missing.txt")}, strings.NewReader(""))
+	require.ErrorContains(t, err, "missing.txt: no such file or directory")
+}
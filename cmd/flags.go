@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/platform/dialer"
 	"github.com/urfave/cli/v2"
 	"software.sslmate.com/src/go-pkcs12"
 )
@@ -14,6 +17,7 @@ import (
 const (
 	flgDomains                  = "domains"
 	flgServer                   = "server"
+	flgAcceptStagingRoots       = "accept-staging-roots"
 	flgAcceptTOS                = "accept-tos"
 	flgEmail                    = "email"
 	flgDisableCommonName        = "disable-cn"
@@ -21,9 +25,24 @@ const (
 	flgEAB                      = "eab"
 	flgKID                      = "kid"
 	flgHMAC                     = "hmac"
+	flgHMACFile                 = "hmac-file"
+	flgHMACEncoding             = "hmac-encoding"
 	flgKeyType                  = "key-type"
 	flgFilename                 = "filename"
+	flgCertName                 = "cert-name"
+	flgConfig                   = "config"
 	flgPath                     = "path"
+	flgInsecurePermissions      = "insecure-permissions"
+	flgStorage                  = "storage"
+	flgVaultAddr                = "vault-addr"
+	flgVaultPath                = "vault-path"
+	flgKubeconfig               = "kubeconfig"
+	flgKubernetesContext        = "kubernetes-context"
+	flgKubernetesNamespace      = "kubernetes-namespace"
+	flgChallengeMap             = "challenge-map"
+	flgDisableChallenge         = "disable-challenge"
+	flgChallengeConcurrency     = "challenge-concurrency"
+	flgReachabilityCheck        = "reachability-check"
 	flgHTTP                     = "http"
 	flgHTTPPort                 = "http.port"
 	flgHTTPDelay                = "http.delay"
@@ -31,15 +50,33 @@ const (
 	flgHTTPWebroot              = "http.webroot"
 	flgHTTPMemcachedHost        = "http.memcached-host"
 	flgHTTPS3Bucket             = "http.s3-bucket"
+	flgHTTPStateless            = "http.stateless"
+	flgHTTPSelfCheck            = "http.self-check"
 	flgTLS                      = "tls"
 	flgTLSPort                  = "tls.port"
 	flgTLSDelay                 = "tls.delay"
+	flgTLSSelfCheck             = "tls.self-check"
+	flgSelfCheckProxy           = "self-check-proxy"
+	flgSelfCheckResolvers       = "self-check-resolvers"
 	flgDNS                      = "dns"
+	flgDNSRoute                 = "dns.route"
+	flgDNSFallback              = "dns-fallback"
 	flgDNSDisableCP             = "dns.disable-cp"
 	flgDNSPropagationWait       = "dns.propagation-wait"
 	flgDNSPropagationDisableANS = "dns.propagation-disable-ans"
 	flgDNSPropagationRNS        = "dns.propagation-rns"
+	flgDNSPropagationWrongValue = "dns.propagation-fail-on-wrong-value"
+	flgDNSPropagationStability  = "dns.propagation-stability"
 	flgDNSResolvers             = "dns.resolvers"
+	flgDNSAuthoritativePort     = "dns.authoritative-port"
+	flgDNSQueryRetries          = "dns.query-retries"
+	flgDNSQueryTCPFallback      = "dns.query-tcp-fallback"
+	flgDNSExport                = "dns.export"
+	flgDNSExportFormat          = "dns.export.format"
+	flgDNSExportOnly            = "dns.export.only"
+	flgDNSExportTimeout         = "dns.export.timeout"
+	flgDNSJournal               = "dns.journal"
+	flgDNSCleanUpCheck          = "dns.cleanup-check"
 	flgHTTPTimeout              = "http-timeout"
 	flgTLSSkipVerify            = "tls-skip-verify"
 	flgDNSTimeout               = "dns-timeout"
@@ -47,21 +84,54 @@ const (
 	flgPFX                      = "pfx"
 	flgPFXPass                  = "pfx.pass"
 	flgPFXFormat                = "pfx.format"
+	flgJKS                      = "jks"
+	flgJKSPass                  = "jks.pass"
+	flgJKSAlias                 = "jks.alias"
+	flgMTA                      = "mta"
+	flgMTASTS                   = "mta-sts"
 	flgCertTimeout              = "cert.timeout"
+	flgVerify                   = "verify"
+	flgVerifyRoots              = "verify.roots"
+	flgTLSA                     = "tlsa"
+	flgTLSAParams               = "tlsa.params"
+	flgTLSAPort                 = "tlsa.port"
+	flgTLSAPublish              = "tlsa.publish"
 	flgOverallRequestLimit      = "overall-request-limit"
 	flgUserAgent                = "user-agent"
+	flgLowMemory                = "low-memory"
+	flgAddressFamily            = "address-family"
+	flgAddressFamilyDelay       = "address-family.fallback-delay"
+	flgMetricsListen            = "metrics-listen"
+	flgDebugACME                = "debug-acme"
 )
 
 const (
-	envEAB         = "LEGO_EAB"
-	envEABHMAC     = "LEGO_EAB_HMAC"
-	envEABKID      = "LEGO_EAB_KID"
-	envEmail       = "LEGO_EMAIL"
-	envPath        = "LEGO_PATH"
-	envPFX         = "LEGO_PFX"
-	envPFXFormat   = "LEGO_PFX_FORMAT"
-	envPFXPassword = "LEGO_PFX_PASSWORD"
-	envServer      = "LEGO_SERVER"
+	envEAB                 = "LEGO_EAB"
+	envEABHMAC             = "LEGO_EAB_HMAC"
+	envEABHMACFile         = "LEGO_EAB_HMAC_FILE"
+	envEABHMACEncoding     = "LEGO_EAB_HMAC_ENCODING"
+	envEABKID              = "LEGO_EAB_KID"
+	envAddrFamily          = "LEGO_ADDRESS_FAMILY"
+	envEmail               = "LEGO_EMAIL"
+	envInsecurePermissions = "LEGO_INSECURE_PERMISSIONS"
+	envJKS                 = "LEGO_JKS"
+	envJKSAlias            = "LEGO_JKS_ALIAS"
+	envJKSPassword         = "LEGO_JKS_PASSWORD"
+	envKubeconfig          = "KUBECONFIG"
+	envKubernetesNS        = "LEGO_KUBERNETES_NAMESPACE"
+	envLowMemory           = "LEGO_LOW_MEMORY"
+	envPath                = "LEGO_PATH"
+	envPFX                 = "LEGO_PFX"
+	envPFXFormat           = "LEGO_PFX_FORMAT"
+	envPFXPassword         = "LEGO_PFX_PASSWORD"
+	envSelfCheckProxy      = "LEGO_SELF_CHECK_PROXY"
+	envSelfCheckResolvers  = "LEGO_SELF_CHECK_RESOLVERS"
+	envServer              = "LEGO_SERVER"
+	envStorage             = "LEGO_STORAGE"
+	envVaultAddr           = "VAULT_ADDR"
+	envVaultNamespace      = "VAULT_NAMESPACE"
+	envVaultPath           = "LEGO_VAULT_PATH"
+	envVaultToken          = "VAULT_TOKEN"
 )
 
 func CreateFlags(defaultPath string) []cli.Flag {
@@ -69,7 +139,7 @@ func CreateFlags(defaultPath string) []cli.Flag {
 		&cli.StringSliceFlag{
 			Name:    flgDomains,
 			Aliases: []string{"d"},
-			Usage:   "Add a domain to the process. Can be specified multiple times.",
+			Usage:   "Add a domain to the process. Can be specified multiple times. With the run command, @file or - (stdin) reads a domain list (one domain per line, # for comments, --- to start another certificate).",
 		},
 		&cli.StringFlag{
 			Name:    flgServer,
@@ -78,6 +148,11 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Usage:   "CA hostname (and optionally :port). The server certificate must be trusted in order to avoid further modifications to the client.",
 			Value:   lego.LEDirectoryProduction,
 		},
+		&cli.BoolFlag{
+			Name: flgAcceptStagingRoots,
+			Usage: "Trust the bundled Let's Encrypt staging roots to verify the certificates (tests only)." +
+				" Requires the Let's Encrypt staging server.",
+		},
 		&cli.BoolFlag{
 			Name:    flgAcceptTOS,
 			Aliases: []string{"a"},
@@ -111,17 +186,38 @@ func CreateFlags(defaultPath string) []cli.Flag {
 		&cli.StringFlag{
 			Name:    flgHMAC,
 			EnvVars: []string{envEABHMAC},
-			Usage:   "MAC key from External CA. Should be in Base64 URL Encoding without padding format. Used for External Account Binding.",
+			Usage:   "MAC key from External CA. Hexadecimal, base64url, or base64 encoding (see --hmac-encoding). Used for External Account Binding.",
+		},
+		&cli.StringFlag{
+			Name:    flgHMACFile,
+			EnvVars: []string{envEABHMACFile},
+			Usage:   "Path to a file containing the MAC key from External CA. Used for External Account Binding.",
+		},
+		&cli.StringFlag{
+			Name:    flgHMACEncoding,
+			EnvVars: []string{envEABHMACEncoding},
+			Value:   string(api.EABEncodingAuto),
+			Usage:   "Encoding of the MAC key from External CA. Supported: auto, hex, base64, base64url. 'auto' detects the encoding, the other values are required for the keys valid in several encodings. The decoded key must be at least 32 bytes. Used for External Account Binding.",
 		},
 		&cli.StringFlag{
 			Name:    flgKeyType,
 			Aliases: []string{"k"},
 			Value:   "ec256",
-			Usage:   "Key type to use for private keys. Supported: rsa2048, rsa3072, rsa4096, rsa8192, ec256, ec384.",
+			Usage:   "Key type to use for private keys. Supported: rsa2048, rsa3072, rsa4096, rsa8192, ec256, ec384, ed25519 (not supported by all the CAs).",
 		},
 		&cli.StringFlag{
 			Name:  flgFilename,
-			Usage: "(deprecated) Filename of the generated certificate.",
+			Usage: fmt.Sprintf("(deprecated) Filename of the generated certificate. Use --%s instead.", flgCertName),
+		},
+		&cli.StringFlag{
+			Name: flgCertName,
+			Usage: "The name of the certificate files (ex: 'web-frontend'). Default: the first domain." +
+				" The paths of the files don't change when the domains of the certificate change.",
+		},
+		&cli.StringFlag{
+			Name: flgConfig,
+			Usage: "A configuration file (YAML or TOML) describing the certificates: the domains, the accounts, the key types, the challenges, and the hooks of each certificate." +
+				" Only works with the run and renew commands.",
 		},
 		&cli.StringFlag{
 			Name:    flgPath,
@@ -129,6 +225,66 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Usage:   "Directory to use for storing the data.",
 			Value:   defaultPath,
 		},
+		&cli.BoolFlag{
+			Name:    flgInsecurePermissions,
+			EnvVars: []string{envInsecurePermissions},
+			Usage: "Make the files of the filesystem storage readable by the group (0640, and the inherited access control lists on Windows)." +
+				" For the shared hosts only: by default, the files are readable only by the current user (and SYSTEM on Windows).",
+		},
+		&cli.StringFlag{
+			Name:    flgStorage,
+			EnvVars: []string{envStorage},
+			Usage: "Storage of the accounts and the certificates. Supported: filesystem, vault, kubernetes." +
+				" With vault, the token is read from the environment variable " + envVaultToken + " (or " + envVaultToken + "_FILE).",
+			Value: storageFileSystem,
+		},
+		&cli.StringFlag{
+			Name:    flgVaultAddr,
+			EnvVars: []string{envVaultAddr},
+			Usage:   "Address of the Vault server (ex: 'https://vault.example.com:8200'). Used with --storage vault.",
+		},
+		&cli.StringFlag{
+			Name:    flgVaultPath,
+			EnvVars: []string{envVaultPath},
+			Usage:   "Path of the storage in a Vault KV version 2 secrets engine: <mount>/<path> (ex: 'secret/lego'). Used with --storage vault.",
+			Value:   "secret/lego",
+		},
+		&cli.StringFlag{
+			Name:    flgKubeconfig,
+			EnvVars: []string{envKubeconfig},
+			Usage:   "Path of the kubeconfig file. Used with --storage kubernetes. Default: the in-cluster configuration (service account).",
+		},
+		&cli.StringFlag{
+			Name:  flgKubernetesContext,
+			Usage: "Context of the kubeconfig. Used with --storage kubernetes. Default: the current context.",
+		},
+		&cli.StringFlag{
+			Name:    flgKubernetesNamespace,
+			EnvVars: []string{envKubernetesNS},
+			Usage:   "Namespace of the secrets. Used with --storage kubernetes. Default: the namespace of the context, or of the service account.",
+		},
+		&cli.StringSliceFlag{
+			Name: flgChallengeMap,
+			Usage: "Force the challenge type for a domain (domain=challenge, ex: 'api.example.com=http-01', '*.example.com=dns-01')." +
+				" The challenge must be enabled. Can be specified multiple times.",
+		},
+		&cli.StringSliceFlag{
+			Name: flgDisableChallenge,
+			Usage: "Disable a challenge type for this run (ex: 'tls-alpn-01')." +
+				" The challenge type can be disabled only for a CA with challenge@ca-host (ex: 'http-01@acme-v02.api.letsencrypt.org'). Can be specified multiple times.",
+		},
+		&cli.IntFlag{
+			Name: flgChallengeConcurrency,
+			Usage: "The maximum number of authorizations solved at the same time." +
+				" The challenges of the HTTP-01/TLS-ALPN-01 servers and of the DNS providers with rate limits are always solved one by one.",
+			Value: 1,
+		},
+		&cli.StringFlag{
+			Name: flgReachabilityCheck,
+			Usage: "Check that the domains are publicly reachable (A/AAAA records with public addresses, or a NS delegation) before the order," +
+				" when the CA is a public CA. Supported: off, warn (report the internal-only domains), abort (stop before the order).",
+			Value: reachabilityOff,
+		},
 		&cli.BoolFlag{
 			Name:  flgHTTP,
 			Usage: "Use the HTTP-01 challenge to solve challenges. Can be mixed with other types of challenges.",
@@ -161,6 +317,15 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Name:  flgHTTPS3Bucket,
 			Usage: "Set the S3 bucket name to use for HTTP-01 based challenges. Challenges will be written to the S3 bucket.",
 		},
+		&cli.BoolFlag{
+			Name: flgHTTPStateless,
+			Usage: "Don't provision the HTTP-01 based challenges: the web server must answer '/.well-known/acme-challenge/<token>' with '<token>.<account thumbprint>'." +
+				" The account thumbprint is displayed, and the web server rule is checked before the validation.",
+		},
+		&cli.BoolFlag{
+			Name:  flgHTTPSelfCheck,
+			Usage: "Before the validation, fetch the HTTP-01 challenge from the domain to detect the misconfigurations (firewall, virtual host, etc.).",
+		},
 		&cli.BoolFlag{
 			Name:  flgTLS,
 			Usage: "Use the TLS-ALPN-01 challenge to solve challenges. Can be mixed with other types of challenges.",
@@ -175,10 +340,36 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Usage: "Delay between the start of the TLS listener (use for TLSALPN-01 based challenges) and the validation of the challenge.",
 			Value: 0,
 		},
+		&cli.BoolFlag{
+			Name:  flgTLSSelfCheck,
+			Usage: "Before the validation, fetch the TLS-ALPN-01 challenge certificate from the domain to detect the misconfigurations.",
+		},
+		&cli.StringFlag{
+			Name: flgSelfCheckProxy,
+			Usage: "The proxy (http, https, socks5) used by the self-checks to reach the domains (e.g. 'socks5://proxy.example.com:1080')." +
+				" Useful when the domains cannot be reached from the host itself (NAT hairpinning).",
+			EnvVars: []string{envSelfCheckProxy},
+		},
+		&cli.StringSliceFlag{
+			Name: flgSelfCheckResolvers,
+			Usage: "The DNS servers used by the self-checks to resolve the domains, instead of the system resolver and the hosts file (e.g. '1.1.1.1:53')." +
+				" Default: public DNS servers. Not used with a proxy.",
+			EnvVars: []string{envSelfCheckResolvers},
+		},
 		&cli.StringFlag{
 			Name:  flgDNS,
 			Usage: "Solve a DNS-01 challenge using the specified provider. Can be mixed with other types of challenges. Run 'lego dnshelp' for help on usage.",
 		},
+		&cli.StringSliceFlag{
+			Name: flgDNSRoute,
+			Usage: fmt.Sprintf("Use another DNS provider for the domains matching a pattern (pattern=provider, ex: '*.internal.example.com=rfc2136')."+
+				" The most specific pattern is used, the other domains use the provider of '%s'. Can be specified multiple times.", flgDNS),
+		},
+		&cli.StringSliceFlag{
+			Name: flgDNSFallback,
+			Usage: fmt.Sprintf("Use another DNS provider when the provider of '%s' fails to create the record (API outage, rate limit, etc.)."+
+				" Can be specified multiple times: the providers are tried in order.", flgDNS),
+		},
 		&cli.BoolFlag{
 			Name:  flgDNSDisableCP,
 			Usage: fmt.Sprintf("(deprecated) use %s instead.", flgDNSPropagationDisableANS),
@@ -191,6 +382,16 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Name:  flgDNSPropagationRNS,
 			Usage: "By setting this flag to true, use all the recursive nameservers to check the propagation of the TXT record.",
 		},
+		&cli.BoolFlag{
+			Name:  flgDNSPropagationWrongValue,
+			Usage: "By setting this flag to true, stops immediately the propagation check when an authoritative name server returns TXT records without the expected value.",
+		},
+		&cli.IntFlag{
+			Name: flgDNSPropagationStability,
+			Usage: "Require the TXT record to be observed in this number of consecutive propagation checks before the validation" +
+				" (anycast nameservers flapping between updated and stale POPs).",
+			Value: 1,
+		},
 		&cli.DurationFlag{
 			Name:  flgDNSPropagationWait,
 			Usage: "By setting this flag, disables all the propagation checks of the TXT record and uses a wait duration instead.",
@@ -199,9 +400,47 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Name: flgDNSResolvers,
 			Usage: "Set the resolvers to use for performing (recursive) CNAME resolving and apex domain determination." +
 				" For DNS-01 challenge verification, the authoritative DNS server is queried directly." +
-				" Supported: host:port." +
+				" Supported: host, host:port, IPv6, [IPv6]:port (the default port is 53)." +
 				" The default is to use the system resolvers, or Google's DNS resolvers if the system's cannot be determined.",
 		},
+		&cli.IntFlag{
+			Name:  flgDNSAuthoritativePort,
+			Usage: "Set the port used to query the authoritative nameservers during the propagation and cleanup checks (ex: test environments with the DNS on a non-standard port).",
+			Value: 53,
+		},
+		&cli.IntFlag{
+			Name:  flgDNSQueryRetries,
+			Usage: "Set the number of additional attempts of a failed DNS query on the same nameserver.",
+		},
+		&cli.BoolFlag{
+			Name:  flgDNSQueryTCPFallback,
+			Usage: "Retry a failed DNS query over TCP (e.g. UDP timeout). The fallback to TCP on truncated responses is always enabled.",
+		},
+		&cli.PathFlag{
+			Name:  flgDNSExport,
+			Usage: "Write the pending DNS-01 challenge records to this file, to apply them through an external pipeline.",
+		},
+		&cli.StringFlag{
+			Name:  flgDNSExportFormat,
+			Usage: "The format of the exported records. Supported: 'zonefile' (RFC 1035 fragment), 'nsupdate'.",
+			Value: dns01.ExportZoneFile,
+		},
+		&cli.BoolFlag{
+			Name:  flgDNSExportOnly,
+			Usage: fmt.Sprintf("Only export the records (no DNS provider): lego resumes when the records are found by the propagation check. Requires '%s', and replaces '%s'.", flgDNSExport, flgDNS),
+		},
+		&cli.DurationFlag{
+			Name:  flgDNSExportTimeout,
+			Usage: "The maximum time to wait for the exported records to be applied. Default: the propagation timeout of the DNS provider.",
+		},
+		&cli.DurationFlag{
+			Name:  flgDNSCleanUpCheck,
+			Usage: "After the cleanup, check that the DNS-01 challenge records have been removed (provider API and authoritative nameservers), waiting up to this duration (e.g. 2m). Disabled by default.",
+		},
+		&cli.BoolFlag{
+			Name:  flgDNSJournal,
+			Usage: "Record the DNS-01 challenge records created and deleted inside the journal of the storage directory (see 'lego dns journal'). Disabled by default.",
+		},
 		&cli.IntFlag{
 			Name:  flgHTTPTimeout,
 			Usage: "Set the HTTP timeout value to a specific value in seconds.",
@@ -232,15 +471,70 @@ func CreateFlags(defaultPath string) []cli.Flag {
 		},
 		&cli.StringFlag{
 			Name:    flgPFXFormat,
-			Usage:   "The encoding format to use when encrypting the .pfx (PCKS#12) file. Supported: RC2, DES, SHA256.",
+			Usage:   "The encoding format to use when encrypting the .pfx (PCKS#12) file. Supported: RC2 and DES (legacy), SHA256 (modern, AES-256).",
 			Value:   "RC2",
 			EnvVars: []string{envPFXFormat},
 		},
+		&cli.BoolFlag{
+			Name:    flgJKS,
+			Usage:   "Generate an additional .jks (Java KeyStore) file with the .key, the .crt, and the issuer .crt files (ex: Tomcat, Kafka).",
+			EnvVars: []string{envJKS},
+		},
+		&cli.StringFlag{
+			Name:    flgJKSPass,
+			Usage:   "The password of the .jks file and of its private key.",
+			Value:   "changeit",
+			EnvVars: []string{envJKSPassword},
+		},
+		&cli.StringFlag{
+			Name:        flgJKSAlias,
+			Usage:       "The alias of the private key entry of the .jks file.",
+			DefaultText: "the certificate name",
+			EnvVars:     []string{envJKSAlias},
+		},
+		&cli.StringSliceFlag{
+			Name: flgMTA,
+			Usage: "Generate an additional bundle for a mail server. Supported: postfix (.postfix.pem: key and chain)," +
+				" exim (.exim.crt: chain), dovecot (.dovecot.crt: chain). Can be specified multiple times.",
+		},
+		&cli.StringFlag{
+			Name:  flgMTASTS,
+			Usage: "Check the MTA-STS policy of the mail domain (ex: 'example.com') against the issued certificate.",
+		},
 		&cli.IntFlag{
 			Name:  flgCertTimeout,
 			Usage: "Set the certificate timeout value to a specific value in seconds. Only used when obtaining certificates.",
 			Value: 30,
 		},
+		&cli.BoolFlag{
+			Name: flgVerify,
+			Usage: "Verify the certificate before saving it: the private key, the chain, and the coverage of the domains." +
+				" If the verification fails, the existing files are not overwritten.",
+		},
+		&cli.StringFlag{
+			Name:  flgVerifyRoots,
+			Usage: "Path to a PEM bundle of the roots used by --verify. Defaults to the system trust store.",
+		},
+		&cli.BoolFlag{
+			Name: flgTLSA,
+			Usage: "Write the TLSA records (DANE) of the issued certificate next to the certificate (.tlsa)." +
+				" The records of the previous certificate are kept to allow the rollover of the key." +
+				" With the selector SPKI (ex: '3 1 1'), the next private key is generated in advance (.next.key), its records are added, and it's used by the next renewal.",
+		},
+		&cli.StringFlag{
+			Name:  flgTLSAParams,
+			Usage: "The parameters of the TLSA records: 'usage selector matching-type'.",
+			Value: "3 1 1",
+		},
+		&cli.IntFlag{
+			Name:  flgTLSAPort,
+			Usage: "The port of the service used in the name of the TLSA records (ex: 25 for SMTP).",
+			Value: 443,
+		},
+		&cli.BoolFlag{
+			Name:  flgTLSAPublish,
+			Usage: "Publish the TLSA records with the DNS provider (--dns). Requires a DNS provider supporting the TLSA records (rfc2136).",
+		},
 		&cli.IntFlag{
 			Name:  flgOverallRequestLimit,
 			Usage: "ACME overall requests limit.",
@@ -250,6 +544,34 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Name:  flgUserAgent,
 			Usage: "Add to the user-agent sent to the CA to identify an application embedding lego-cli",
 		},
+		&cli.BoolFlag{
+			Name:    flgLowMemory,
+			EnvVars: []string{envLowMemory},
+			Usage: "Reduce the memory usage (devices with little memory: routers, NAS, etc.)." +
+				" Lowers the memory limit of the runtime, caps the HTTP connections, and fetches the certificate chains one by one.",
+		},
+		&cli.StringFlag{
+			Name:    flgAddressFamily,
+			EnvVars: []string{envAddrFamily},
+			Usage: "The address family used by the connections to the CA, to the DNS providers, and to the nameservers." +
+				" Supported: auto, ipv4, ipv6, prefer-ipv4, prefer-ipv6.",
+			Value: string(dialer.Auto),
+		},
+		&cli.DurationFlag{
+			Name:  flgAddressFamilyDelay,
+			Usage: "The delay before trying the other address family when the connection with the preferred address family is not established (Happy Eyeballs).",
+			Value: dialer.DefaultFallbackDelay,
+		},
+		&cli.StringFlag{
+			Name: flgMetricsListen,
+			Usage: "Expose the Prometheus metrics (orders, challenges, DNS propagation, ACME requests) on this address (ex: ':9100')," +
+				" at the path /metrics, while lego is running.",
+		},
+		&cli.BoolFlag{
+			Name: flgDebugACME,
+			Usage: "Log each request to the ACME server and its response (URL, status, JWS protected header, payload, problem document) as JSON on the standard error," +
+				" with the secrets redacted.",
+		},
 	}
 }
 
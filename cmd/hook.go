@@ -14,13 +14,15 @@ import (
 )
 
 const (
-	hookEnvAccountEmail      = "LEGO_ACCOUNT_EMAIL"
-	hookEnvCertDomain        = "LEGO_CERT_DOMAIN"
-	hookEnvCertPath          = "LEGO_CERT_PATH"
-	hookEnvCertKeyPath       = "LEGO_CERT_KEY_PATH"
-	hookEnvIssuerCertKeyPath = "LEGO_ISSUER_CERT_PATH"
-	hookEnvCertPEMPath       = "LEGO_CERT_PEM_PATH"
-	hookEnvCertPFXPath       = "LEGO_CERT_PFX_PATH"
+	hookEnvAccountEmail       = "LEGO_ACCOUNT_EMAIL"
+	hookEnvCertDomain         = "LEGO_CERT_DOMAIN"
+	hookEnvCertPath           = "LEGO_CERT_PATH"
+	hookEnvCertKeyPath        = "LEGO_CERT_KEY_PATH"
+	hookEnvIssuerCertKeyPath  = "LEGO_ISSUER_CERT_PATH"
+	hookEnvCertPEMPath        = "LEGO_CERT_PEM_PATH"
+	hookEnvCertPFXPath        = "LEGO_CERT_PFX_PATH"
+	hookEnvCertJKSPath        = "LEGO_CERT_JKS_PATH"
+	hookEnvCertKeyRegenerated = "LEGO_CERT_KEY_REGENERATED"
 )
 
 func launchHook(hook string, timeout time.Duration, meta map[string]string) error {
@@ -101,4 +103,8 @@ func addPathToMetadata(meta map[string]string, domain string, certRes *certifica
 	if certsStorage.pfx {
 		meta[hookEnvCertPFXPath] = certsStorage.GetFileName(domain, pfxExt)
 	}
+
+	if certsStorage.jks {
+		meta[hookEnvCertJKSPath] = certsStorage.GetFileName(domain, jksExt)
+	}
 }
@@ -3,10 +3,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
+	"syscall"
 
 	"github.com/go-acme/lego/v4/cmd"
 	"github.com/go-acme/lego/v4/log"
@@ -38,7 +41,13 @@ func main() {
 
 	app.Commands = cmd.CreateCommands()
 
-	err = app.Run(os.Args)
+	// An interruption cancels the running operations: the challenges are cleaned up before exiting.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	err = app.RunContext(ctx, os.Args)
+
+	stop()
+
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"runtime/debug"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Low-memory mode settings (--low-memory).
+const (
+	lowMemoryGCPercent       = 50
+	lowMemoryLimit           = 32 << 20 // 32 MiB
+	lowMemoryMaxConnsPerHost = 2
+)
+
+// setupLowMemory configures the process for the devices with little memory (routers, NAS, etc.).
+// The environment variables GOGC and GOMEMLIMIT take precedence.
+func setupLowMemory(ctx *cli.Context) {
+	if !ctx.Bool(flgLowMemory) {
+		return
+	}
+
+	if os.Getenv("GOGC") == "" {
+		debug.SetGCPercent(lowMemoryGCPercent)
+	}
+
+	if os.Getenv("GOMEMLIMIT") == "" {
+		debug.SetMemoryLimit(lowMemoryLimit)
+	}
+
+	// Most of the DNS providers use the default transport:
+	// it is replaced by a dedicated transport, the original transport is not modified.
+	http.DefaultTransport = limitTransport(http.DefaultTransport)
+}
+
+// limitTransport returns a copy of the transport with a capped number of connections (and so of buffers).
+// The transport is returned as is if it's not an *http.Transport.
+func limitTransport(rt http.RoundTripper) http.RoundTripper {
+	tr, ok := rt.(*http.Transport)
+	if !ok {
+		return rt
+	}
+
+	tr = tr.Clone()
+	tr.MaxIdleConns = lowMemoryMaxConnsPerHost
+	tr.MaxIdleConnsPerHost = 1
+	tr.MaxConnsPerHost = lowMemoryMaxConnsPerHost
+	tr.IdleConnTimeout = 10 * time.Second
+
+	return tr
+}
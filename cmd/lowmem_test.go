@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_limitTransport(t *testing.T) {
+	original := &http.Transport{MaxIdleConns: 100}
+
+	rt := limitTransport(original)
+
+	tr, ok := rt.(*http.Transport)
+	require.True(t, ok)
+
+	assert.NotSame(t, original, tr)
+	assert.Equal(t, lowMemoryMaxConnsPerHost, tr.MaxIdleConns)
+	assert.Equal(t, lowMemoryMaxConnsPerHost, tr.MaxConnsPerHost)
+
+	// The original transport is not modified.
+	assert.Equal(t, 100, original.MaxIdleConns)
+	assert.Zero(t, original.MaxConnsPerHost)
+}
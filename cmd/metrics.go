@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/urfave/cli/v2"
+)
+
+// metricsCollector the collector of the Prometheus metrics (--metrics-listen), nil if the metrics are disabled.
+var metricsCollector *metrics.Collector
+
+// setupMetrics starts the server of the Prometheus metrics (--metrics-listen).
+// The server runs until the end of the process:
+// it is started only once (the certificates of a configuration file are handled by the same process).
+func setupMetrics(ctx *cli.Context) {
+	address := ctx.String(flgMetricsListen)
+	if address == "" || metricsCollector != nil {
+		return
+	}
+
+	collector := metrics.NewCollector()
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collector,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Fatalf("--%s: %v", flgMetricsListen, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		errS := server.Serve(listener)
+		if errS != nil && !errors.Is(errS, http.ErrServerClosed) {
+			log.Warnf("The metrics server has stopped: %v", errS)
+		}
+	}()
+
+	log.Infof("The metrics are available at http://%s/metrics", localAddress(listener.Addr().String()))
+
+	metricsCollector = collector
+}
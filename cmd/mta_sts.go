@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"bufio"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// lookupTXT resolves the TXT records (replaced inside the tests).
+var lookupTXT = net.LookupTXT
+
+// mtaSTSPolicy an MTA-STS policy (RFC 8461).
+type mtaSTSPolicy struct {
+	Version string
+	Mode    string
+	MaxAge  time.Duration
+	MX      []string
+}
+
+// checkMTASTS checks the MTA-STS policy of the mail domain (--mta-sts) against the issued certificate.
+// The problems are only reported: the certificate is already saved.
+func checkMTASTS(ctx *cli.Context, certRes *certificate.Resource) {
+	domain := ctx.String(flgMTASTS)
+	if domain == "" {
+		return
+	}
+
+	cert, err := certcrypto.ParsePEMCertificate(certRes.Certificate)
+	if err != nil {
+		log.Warnf("[%s] MTA-STS: %v", domain, err)
+		return
+	}
+
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+		// RFC 8461 section 3.3: HTTP 3xx redirects MUST NOT be followed.
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	warnings, err := checkMTASTSPolicy(client, domain, fmt.Sprintf("https://mta-sts.%s/.well-known/mta-sts.txt", domain), cert)
+	if err != nil {
+		log.Warnf("[%s] MTA-STS: %v", domain, err)
+		return
+	}
+
+	for _, warning := range warnings {
+		log.Warnf("[%s] MTA-STS: %s", domain, warning)
+	}
+
+	if len(warnings) == 0 {
+		log.Infof("[%s] MTA-STS: the policy matches the certificate", domain)
+	}
+}
+
+// checkMTASTSPolicy fetches the MTA-STS policy and returns the problems found.
+func checkMTASTSPolicy(client *http.Client, domain, policyURL string, cert *x509.Certificate) ([]string, error) {
+	var warnings []string
+
+	records, err := lookupTXT("_mta-sts." + domain)
+	if err != nil || !hasMTASTSRecord(records) {
+		warnings = append(warnings, fmt.Sprintf("no valid TXT record _mta-sts.%s: the senders will not fetch the policy", domain))
+	}
+
+	resp, err := client.Get(policyURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch the policy: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch the policy: unexpected status code: %d", resp.StatusCode)
+	}
+
+	policy, err := parseMTASTSPolicy(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch policy.Mode {
+	case "enforce":
+	case "testing", "none":
+		warnings = append(warnings, fmt.Sprintf("the policy is not enforced (mode: %s)", policy.Mode))
+	default:
+		return nil, fmt.Errorf("invalid mode: %q", policy.Mode)
+	}
+
+	// RFC 8461 section 3.2: a max_age of several weeks is recommended.
+	if policy.MaxAge < 24*time.Hour {
+		warnings = append(warnings, fmt.Sprintf("the max_age of the policy is short (%s): the senders will often refetch the policy", policy.MaxAge))
+	}
+
+	if policy.Mode != "none" && !matchMTASTS(policy.MX, cert.DNSNames) {
+		warnings = append(warnings, fmt.Sprintf("the certificate (%s) doesn't match any MX of the policy (%s): the senders will refuse to deliver",
+			strings.Join(cert.DNSNames, ", "), strings.Join(policy.MX, ", ")))
+	}
+
+	return warnings, nil
+}
+
+func hasMTASTSRecord(records []string) bool {
+	for _, record := range records {
+		fields := strings.Split(record, ";")
+
+		if strings.TrimSpace(fields[0]) != "v=STSv1" {
+			continue
+		}
+
+		for _, field := range fields[1:] {
+			if id, ok := strings.CutPrefix(strings.TrimSpace(field), "id="); ok && id != "" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// parseMTASTSPolicy parses an MTA-STS policy (RFC 8461 section 3.2).
+func parseMTASTSPolicy(r io.Reader) (*mtaSTSPolicy, error) {
+	policy := &mtaSTSPolicy{}
+
+	scanner := bufio.NewScanner(io.LimitReader(r, 64*1024))
+
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch strings.TrimSpace(key) {
+		case "version":
+			policy.Version = value
+		case "mode":
+			policy.Mode = value
+		case "max_age":
+			seconds, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid max_age: %w", err)
+			}
+
+			policy.MaxAge = time.Duration(seconds) * time.Second
+		case "mx":
+			policy.MX = append(policy.MX, strings.ToLower(value))
+		}
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("read the policy: %w", err)
+	}
+
+	if policy.Version != "STSv1" {
+		return nil, fmt.Errorf("unsupported policy version: %q", policy.Version)
+	}
+
+	if len(policy.MX) == 0 && policy.Mode != "none" {
+		return nil, errors.New("the policy has no MX")
+	}
+
+	return policy, nil
+}
+
+// matchMTASTS returns true if one of the names matches one of the MX patterns (RFC 8461 section 4.1).
+// A pattern "*.example.com" matches only one label ("mail.example.com", not "a.mail.example.com").
+func matchMTASTS(patterns, names []string) bool {
+	for _, pattern := range patterns {
+		for _, name := range names {
+			name = strings.ToLower(strings.TrimSuffix(name, "."))
+
+			if name == pattern {
+				return true
+			}
+
+			// A wildcard certificate covers the MX with one more label.
+			if suffix, ok := strings.CutPrefix(name, "*."); ok {
+				_, rest, found := strings.Cut(pattern, ".")
+				if found && !strings.HasPrefix(pattern, "*.") && rest == suffix {
+					return true
+				}
+
+				continue
+			}
+
+			if suffix, ok := strings.CutPrefix(pattern, "*."); ok {
+				label, rest, found := strings.Cut(name, ".")
+				if found && label != "" && label != "*" && rest == suffix {
+					return true
+				}
+
+				continue
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkMTASTSPolicy(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		records       []string
+		policy        string
+		names         []string
+		expected      []string
+		expectedError string
+	}{
+		{
+			desc:    "valid",
+			records: []string{"v=STSv1; id=20250101T000000;"},
+			policy:  "version: STSv1\r\nmode: enforce\r\nmx: mail.example.com\r\nmx: *.example.net\r\nmax_age: 604800\r\n",
+			names:   []string{"mx1.example.net"},
+		},
+		{
+			desc:    "wildcard certificate",
+			records: []string{"v=STSv1; id=1"},
+			policy:  "version: STSv1\nmode: enforce\nmx: mail.example.com\nmax_age: 604800\n",
+			names:   []string{"*.example.com"},
+		},
+		{
+			desc:    "problems",
+			records: []string{"v=spf1 -all"},
+			policy:  "version: STSv1\nmode: testing\nmx: mail.example.com\nmax_age: 3600\n",
+			names:   []string{"a.mail.example.com"},
+			expected: []string{
+				"no valid TXT record _mta-sts.example.com: the senders will not fetch the policy",
+				"the policy is not enforced (mode: testing)",
+				"the max_age of the policy is short (1h0m0s): the senders will often refetch the policy",
+				"the certificate (a.mail.example.com) doesn't match any MX of the policy (mail.example.com): the senders will refuse to deliver",
+			},
+		},
+		{
+			desc:          "invalid version",
+			records:       []string{"v=STSv1; id=1"},
+			policy:        "version: STSv2\nmode: enforce\nmx: mail.example.com\nmax_age: 604800\n",
+			expectedError: `unsupported policy version: "STSv2"`,
+		},
+		{
+			desc:          "invalid mode",
+			records:       []string{"v=STSv1; id=1"},
+			policy:        "version: STSv1\nmode: strict\nmx: mail.example.com\nmax_age: 604800\n",
+			expectedError: `invalid mode: "strict"`,
+		},
+		{
+			desc:          "no MX",
+			records:       []string{"v=STSv1; id=1"},
+			policy:        "version: STSv1\nmode: enforce\nmax_age: 604800\n",
+			expectedError: "the policy has no MX",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/.well-known/mta-sts.txt" {
+					http.NotFound(rw, req)
+					return
+				}
+
+				_, _ = fmt.Fprint(rw, test.policy)
+			}))
+			t.Cleanup(server.Close)
+
+			setupLookupTXT(t, test.records)
+
+			cert := &x509.Certificate{DNSNames: test.names}
+
+			warnings, err := checkMTASTSPolicy(server.Client(), "example.com", server.URL+"/.well-known/mta-sts.txt", cert)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, warnings)
+		})
+	}
+}
+
+func Test_checkMTASTSPolicy_notFound(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(server.Close)
+
+	setupLookupTXT(t, nil)
+
+	_, err := checkMTASTSPolicy(server.Client(), "example.com", server.URL+"/.well-known/mta-sts.txt", &x509.Certificate{})
+	require.EqualError(t, err, "fetch the policy: unexpected status code: 404")
+}
+
+func setupLookupTXT(t *testing.T, records []string) {
+	t.Helper()
+
+	original := lookupTXT
+
+	t.Cleanup(func() { lookupTXT = original })
+
+	lookupTXT = func(name string) ([]string, error) {
+		if name != "_mta-sts.example.com" || records == nil {
+			return nil, errors.New("not found")
+		}
+
+		return records, nil
+	}
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/dialer"
+	"github.com/urfave/cli/v2"
+)
+
+// setupNetwork configures the address family preference (--address-family) of the default transport.
+func setupNetwork(ctx *cli.Context) {
+	// Most of the DNS providers use the default transport:
+	// it is replaced by a dedicated transport, the original transport is not modified.
+	http.DefaultTransport = applyDialer(http.DefaultTransport, newDialer(ctx))
+}
+
+// setupTLSPolicy applies the TLS policy of the environment variables (LEGO_TLS_*) to the default transport.
+// The HTTP client of the ACME server already applies the policy.
+func setupTLSPolicy() {
+	policy, err := lego.TLSPolicyFromEnv()
+	if err != nil {
+		log.Fatalf("Could not create the TLS policy: %v", err)
+	}
+
+	tr, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		log.Fatalf("Could not apply the TLS policy: unsupported transport: %T", http.DefaultTransport)
+	}
+
+	if tr.TLSClientConfig == nil {
+		tr.TLSClientConfig = &tls.Config{}
+	}
+
+	// Most of the DNS providers use the default transport:
+	// the providers not covered are listed in the documentation (LEGO_TLS_*), and checked by the tests of the providers.
+	policy.Apply(tr.TLSClientConfig)
+}
+
+// getAddressFamily returns the address family preference (--address-family).
+func getAddressFamily(ctx *cli.Context) dialer.Family {
+	family, err := dialer.ParseFamily(ctx.String(flgAddressFamily))
+	if err != nil {
+		log.Fatalf("--%s: %v", flgAddressFamily, err)
+	}
+
+	return family
+}
+
+func newDialer(ctx *cli.Context) *dialer.Dialer {
+	if ctx.Duration(flgAddressFamilyDelay) < 0 {
+		log.Fatalf("--%s cannot be negative", flgAddressFamilyDelay)
+	}
+
+	return dialer.New(getAddressFamily(ctx), ctx.Duration(flgAddressFamilyDelay))
+}
+
+// applyDialer returns a copy of the transport using the dialer.
+// The transport is returned as is if it's not an *http.Transport.
+func applyDialer(rt http.RoundTripper, d *dialer.Dialer) http.RoundTripper {
+	tr, ok := rt.(*http.Transport)
+	if !ok {
+		return rt
+	}
+
+	tr = tr.Clone()
+	tr.DialContext = d.DialContext
+
+	return tr
+}
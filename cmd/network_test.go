@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/dialer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applyDialer(t *testing.T) {
+	original := &http.Transport{}
+
+	rt := applyDialer(original, dialer.New(dialer.IPv6, 0))
+
+	tr, ok := rt.(*http.Transport)
+	require.True(t, ok)
+
+	assert.NotSame(t, original, tr)
+	assert.NotNil(t, tr.DialContext)
+
+	// The original transport is not modified.
+	assert.Nil(t, original.DialContext)
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/go-acme/lego/v4/log"
+)
+
+// minFreeSpace the minimal free space of the certificates path: the certificate files, the metadata, and the archives.
+const minFreeSpace = 1 << 20 // 1 MiB
+
+// preflightStorage checks the certificates path before the issuance,
+// to fail early rather than when the files are written after a successful order:
+// the directory must be writable, and must have enough free space.
+// The private keys readable by all the users are reported as warnings.
+//
+// Only the filesystem storage is checked.
+func preflightStorage(certsStorage *CertificatesStorage) error {
+	if certsStorage.backend != certsStorage.fs {
+		return nil
+	}
+
+	dir := certsStorage.GetRootPath()
+
+	if _, err := os.Stat(dir); errors.Is(err, fs.ErrNotExist) {
+		// Nothing to renew.
+		return nil
+	}
+
+	err := checkWritable(dir)
+	if err != nil {
+		return fmt.Errorf("the certificates path %s is not writable, check the owner and the permissions of the directory (or use --%s): %w",
+			dir, flgPath, err)
+	}
+
+	free, err := freeSpace(dir)
+
+	switch {
+	case errors.Is(err, errors.ErrUnsupported):
+		// The free space is unknown on this platform.
+
+	case err != nil:
+		log.Warnf("Could not check the free space of %s: %v", dir, err)
+
+	case free < minFreeSpace:
+		return fmt.Errorf("not enough free space for the certificates path %s: %d bytes available, at least %d bytes are required",
+			dir, free, minFreeSpace)
+	}
+
+	for _, root := range []string{dir, certsStorage.fs.AccountsPath()} {
+		files, errK := insecureKeyFiles(root)
+		if errK != nil {
+			log.Warnf("Could not check the permissions of the private keys of %s: %v", root, errK)
+			continue
+		}
+
+		for _, file := range files {
+			log.Warnf("The private key %s is readable by all the users: restrict the permissions (ex: chmod 600 %s)", file, file)
+		}
+	}
+
+	return nil
+}
+
+// checkWritable checks that a file can be created in the directory.
+func checkWritable(dir string) error {
+	file, err := os.CreateTemp(dir, ".lego-preflight-*")
+	if err != nil {
+		return err
+	}
+
+	_ = file.Close()
+
+	return os.Remove(file.Name())
+}
+
+// insecureKeyFiles returns the private keys (.key) readable by all the users, inside the directory.
+func insecureKeyFiles(root string) ([]string, error) {
+	// The permission bits don't describe the access control lists of Windows.
+	if runtime.GOOS == "windows" {
+		return nil, nil
+	}
+
+	var files []string
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if d.IsDir() || !strings.HasSuffix(path, keyExt) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if info.Mode().Perm()&0o004 != 0 {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
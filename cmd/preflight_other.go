@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd && !windows
+
+package cmd
+
+import "errors"
+
+// freeSpace the free space is not checked on this platform.
+func freeSpace(string) (uint64, error) {
+	return 0, errors.ErrUnsupported
+}
@@ -0,0 +1,17 @@
+//go:build linux || darwin || freebsd
+
+package cmd
+
+import "golang.org/x/sys/unix"
+
+// freeSpace returns the space available to the user, in bytes, on the filesystem of the directory.
+func freeSpace(dir string) (uint64, error) {
+	var stat unix.Statfs_t
+
+	err := unix.Statfs(dir, &stat)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil //nolint:unconvert // the types of the fields depend on the platform.
+}
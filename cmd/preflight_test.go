@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_preflightStorage(t *testing.T) {
+	storage := newTestCertificatesStorage(t)
+
+	err := preflightStorage(storage)
+	require.NoError(t, err)
+
+	// The temporary file has been removed.
+	entries, err := os.ReadDir(storage.rootPath)
+	require.NoError(t, err)
+
+	assert.Empty(t, entries)
+}
+
+func Test_preflightStorage_notWritable(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("the permissions are not enforced")
+	}
+
+	storage := newTestCertificatesStorage(t)
+
+	err := os.Chmod(storage.rootPath, 0o500)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.Chmod(storage.rootPath, 0o700) })
+
+	err = preflightStorage(storage)
+	require.ErrorContains(t, err, "the certificates path "+storage.rootPath+" is not writable")
+}
+
+func Test_insecureKeyFiles(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the permission bits are not used on Windows")
+	}
+
+	dir := t.TempDir()
+
+	files := map[string]os.FileMode{
+		"secure.key":                     0o600,
+		"group.key":                      0o640,
+		"insecure.key":                   0o644,
+		"insecure.crt":                   0o644,
+		filepath.Join("keys", "all.key"): 0o666,
+	}
+
+	for name, perm := range files {
+		filename := filepath.Join(dir, name)
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(filename), 0o700))
+		require.NoError(t, os.WriteFile(filename, []byte("key"), perm))
+		require.NoError(t, os.Chmod(filename, perm))
+	}
+
+	insecure, err := insecureKeyFiles(dir)
+	require.NoError(t, err)
+
+	expected := []string{
+		filepath.Join(dir, "insecure.key"),
+		filepath.Join(dir, "keys", "all.key"),
+	}
+
+	assert.Equal(t, expected, insecure)
+}
+
+func Test_insecureKeyFiles_notExist(t *testing.T) {
+	insecure, err := insecureKeyFiles(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+
+	assert.Empty(t, insecure)
+}
@@ -0,0 +1,22 @@
+//go:build windows
+
+package cmd
+
+import "golang.org/x/sys/windows"
+
+// freeSpace returns the space available to the user, in bytes, on the volume of the directory.
+func freeSpace(dir string) (uint64, error) {
+	path, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	var available uint64
+
+	err = windows.GetDiskFreeSpaceEx(path, &available, nil, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	return available, nil
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	reachabilityOff   = "off"
+	reachabilityWarn  = "warn"
+	reachabilityAbort = "abort"
+)
+
+// internalSuffixes the special-use domain names (RFC 6761, RFC 6762, RFC 8375),
+// the private-use TLD (.internal), and the TLDs commonly used inside the private networks.
+var internalSuffixes = []string{
+	"localhost", "local", "internal", "home.arpa", "test", "invalid", "example",
+	"lan", "home", "corp", "intranet", "private",
+}
+
+// sharedAddressSpace the shared address space of the carrier-grade NATs (RFC 6598).
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// reachabilityResolver the DNS lookups of the reachability check.
+type reachabilityResolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// unreachableDomain a domain that a public CA can't validate.
+type unreachableDomain struct {
+	Domain string
+	Reason string
+}
+
+// validateReachabilityCheck checks the value of --reachability-check.
+func validateReachabilityCheck(ctx *cli.Context) error {
+	switch mode := ctx.String(flgReachabilityCheck); mode {
+	case reachabilityOff, reachabilityWarn, reachabilityAbort:
+		return nil
+	default:
+		return fmt.Errorf("invalid --%s value %q: supported values are %s, %s, %s",
+			flgReachabilityCheck, mode, reachabilityOff, reachabilityWarn, reachabilityAbort)
+	}
+}
+
+// checkReachability checks that the domains are publicly reachable before the order (--reachability-check).
+// A public CA can't validate the internal-only domains: the order would fail after the challenges,
+// with an error of the CA which doesn't explain the problem.
+//
+// The check is only done with the public CAs: the CAs publishing their CAA identities inside the Directory.
+func checkReachability(ctx *cli.Context, client *lego.Client, domains []string) error {
+	mode := ctx.String(flgReachabilityCheck)
+	if mode == reachabilityOff {
+		return nil
+	}
+
+	if len(client.GetCAAIdentities()) == 0 {
+		log.Infof("The reachability check is skipped: the CA is not a public CA (no CAA identities).")
+		return nil
+	}
+
+	unreachable := findUnreachableDomains(ctx.Context, newReachabilityResolver(ctx), domains)
+	if len(unreachable) == 0 {
+		return nil
+	}
+
+	if mode == reachabilityWarn {
+		for _, u := range unreachable {
+			log.Warnf("[%s] The domain is not publicly reachable: %s. The CA will probably not be able to validate it.", u.Domain, u.Reason)
+		}
+
+		return nil
+	}
+
+	var reasons []string
+	for _, u := range unreachable {
+		reasons = append(reasons, u.Domain+": "+u.Reason)
+	}
+
+	return fmt.Errorf("the domains are not publicly reachable, the CA can't validate them (use --%s=%s to order anyway): %s",
+		flgReachabilityCheck, reachabilityWarn, strings.Join(reasons, "; "))
+}
+
+// newReachabilityResolver returns the resolver of the reachability check: the resolvers of --dns.resolvers, or the system resolvers.
+func newReachabilityResolver(ctx *cli.Context) reachabilityResolver {
+	servers := dns01.ParseNameservers(ctx.StringSlice(flgDNSResolvers))
+	if len(servers) == 0 {
+		return net.DefaultResolver
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, servers[0])
+		},
+	}
+}
+
+// findUnreachableDomains returns the domains which are clearly internal-only.
+// The domains which can't be checked (ex: DNS timeout) are reported as warnings, and are not considered as unreachable.
+func findUnreachableDomains(ctx context.Context, resolver reachabilityResolver, domains []string) []unreachableDomain {
+	var unreachable []unreachableDomain
+
+	for _, domain := range domains {
+		reason, err := unreachableReason(ctx, resolver, domain)
+		if err != nil {
+			log.Warnf("[%s] Could not check the reachability of the domain: %v", domain, err)
+			continue
+		}
+
+		if reason != "" {
+			unreachable = append(unreachable, unreachableDomain{Domain: domain, Reason: reason})
+		}
+	}
+
+	return unreachable
+}
+
+// unreachableReason returns the reason why the domain is not publicly reachable, or an empty string.
+func unreachableReason(ctx context.Context, resolver reachabilityResolver, domain string) (string, error) {
+	if ip, err := netip.ParseAddr(domain); err == nil {
+		if !isPublicAddr(ip) {
+			return "private IP address", nil
+		}
+
+		return "", nil
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(domain, "*."), "."))
+
+	if !strings.Contains(name, ".") && name != "localhost" {
+		return "single-label name", nil
+	}
+
+	for _, suffix := range internalSuffixes {
+		if name == suffix || strings.HasSuffix(name, "."+suffix) {
+			return fmt.Sprintf("special-use or private-use name (.%s)", suffix), nil
+		}
+	}
+
+	// The wildcard domains are validated with the DNS-01 challenge: only the delegation matters.
+	if !strings.HasPrefix(domain, "*.") {
+		addrs, err := resolver.LookupIPAddr(ctx, name)
+		if err != nil && !isNotFound(err) {
+			return "", err
+		}
+
+		if len(addrs) > 0 {
+			if slices.ContainsFunc(addrs, func(addr net.IPAddr) bool {
+				ip, _ := netip.AddrFromSlice(addr.IP)
+				return isPublicAddr(ip.Unmap())
+			}) {
+				return "", nil
+			}
+
+			return "resolves only to private IP addresses", nil
+		}
+	}
+
+	// The DNS-01 challenge only requires a public delegation of the domain, or of a parent domain (the TLD excluded).
+	for parent := name; strings.Contains(parent, "."); parent = parent[strings.Index(parent, ".")+1:] {
+		ns, err := resolver.LookupNS(ctx, parent)
+		if err != nil && !isNotFound(err) {
+			return "", err
+		}
+
+		if len(ns) > 0 {
+			return "", nil
+		}
+	}
+
+	return "no A/AAAA records and no NS delegation", nil
+}
+
+// isNotFound returns true if the DNS error means that the name or the records don't exist.
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// isPublicAddr returns true if the IP address is routable on the Internet.
+func isPublicAddr(ip netip.Addr) bool {
+	return ip.IsValid() &&
+		ip.IsGlobalUnicast() &&
+		!ip.IsPrivate() &&
+		!sharedAddressSpace.Contains(ip)
+}
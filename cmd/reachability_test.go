@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReachabilityResolver struct {
+	addrs map[string][]string
+	ns    map[string][]string
+	err   error
+}
+
+func (r fakeReachabilityResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	values, ok := r.addrs[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	var addrs []net.IPAddr
+	for _, value := range values {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(value)})
+	}
+
+	return addrs, nil
+}
+
+func (r fakeReachabilityResolver) LookupNS(_ context.Context, name string) ([]*net.NS, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	values, ok := r.ns[name]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+	}
+
+	var ns []*net.NS
+	for _, value := range values {
+		ns = append(ns, &net.NS{Host: value})
+	}
+
+	return ns, nil
+}
+
+func Test_unreachableReason(t *testing.T) {
+	resolver := fakeReachabilityResolver{
+		addrs: map[string][]string{
+			"www.example.com":      {"93.184.215.14"},
+			"mixed.example.com":    {"10.0.0.1", "2001:4860:4860::8888"},
+			"intranet.example.com": {"10.0.0.1", "fd00::1"},
+			"cgnat.example.com":    {"100.64.0.1"},
+		},
+		ns: map[string][]string{
+			"example.com": {"a.iana-servers.net."},
+		},
+	}
+
+	testCases := []struct {
+		domain   string
+		expected string
+	}{
+		{domain: "www.example.com"},
+		{domain: "mixed.example.com"},
+		{domain: "intranet.example.com", expected: "resolves only to private IP addresses"},
+		{domain: "cgnat.example.com", expected: "resolves only to private IP addresses"},
+		{domain: "new.example.com"},
+		{domain: "*.example.com"},
+		{domain: "www.example.org", expected: "no A/AAAA records and no NS delegation"},
+		{domain: "*.example.org", expected: "no A/AAAA records and no NS delegation"},
+		{domain: "server", expected: "single-label name"},
+		{domain: "localhost", expected: "special-use or private-use name (.localhost)"},
+		{domain: "nas.home.arpa", expected: "special-use or private-use name (.home.arpa)"},
+		{domain: "app.corp.internal.", expected: "special-use or private-use name (.internal)"},
+		{domain: "printer.LOCAL", expected: "special-use or private-use name (.local)"},
+		{domain: "192.0.2.1"},
+		{domain: "192.168.1.1", expected: "private IP address"},
+		{domain: "127.0.0.1", expected: "private IP address"},
+		{domain: "2001:db8::1"},
+		{domain: "fe80::1", expected: "private IP address"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.domain, func(t *testing.T) {
+			t.Parallel()
+
+			reason, err := unreachableReason(t.Context(), resolver, test.domain)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, reason)
+		})
+	}
+}
+
+func Test_unreachableReason_error(t *testing.T) {
+	resolver := fakeReachabilityResolver{
+		err: &net.DNSError{Err: "i/o timeout", Name: "www.example.com", IsTimeout: true},
+	}
+
+	_, err := unreachableReason(t.Context(), resolver, "www.example.com")
+	require.Error(t, err)
+}
+
+func Test_findUnreachableDomains(t *testing.T) {
+	resolver := fakeReachabilityResolver{
+		addrs: map[string][]string{
+			"www.example.com": {"93.184.215.14"},
+		},
+	}
+
+	unreachable := findUnreachableDomains(t.Context(), resolver, []string{"www.example.com", "intranet.lan", "10.1.2.3"})
+
+	expected := []unreachableDomain{
+		{Domain: "intranet.lan", Reason: "special-use or private-use name (.lan)"},
+		{Domain: "10.1.2.3", Reason: "private IP address"},
+	}
+
+	assert.Equal(t, expected, unreachable)
+}
+
+func Test_findUnreachableDomains_lookupError(t *testing.T) {
+	resolver := fakeReachabilityResolver{err: errors.New("connection refused")}
+
+	unreachable := findUnreachableDomains(t.Context(), resolver, []string{"www.example.com"})
+
+	// The domains which can't be checked are not reported as unreachable.
+	assert.Empty(t, unreachable)
+}
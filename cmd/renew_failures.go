@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/storage"
+	"github.com/urfave/cli/v2"
+)
+
+const failuresExt = ".failures.json"
+
+// Renewal failure events (LEGO_RENEWAL_EVENT).
+const (
+	renewalEventFailure           = "failure"
+	renewalEventFailureNearExpiry = "failure-near-expiry"
+)
+
+const (
+	hookEnvRenewalEvent        = "LEGO_RENEWAL_EVENT"
+	hookEnvRenewalFailingSince = "LEGO_RENEWAL_FAILING_SINCE"
+	hookEnvRenewalAttempts     = "LEGO_RENEWAL_ATTEMPTS"
+	hookEnvRenewalError        = "LEGO_RENEWAL_ERROR"
+	hookEnvCertNotAfter        = "LEGO_CERT_NOT_AFTER"
+)
+
+// renewalFailures the state of the failing renewals of a certificate, stored next to the certificate.
+type renewalFailures struct {
+	FailingSince time.Time `json:"failingSince"`
+	LastAttempt  time.Time `json:"lastAttempt"`
+	NextAttempt  time.Time `json:"nextAttempt,omitzero"`
+
+	// Attempts the number of consecutive failed attempts.
+	Attempts int `json:"attempts"`
+	// DayAttempts the number of failed attempts during the (UTC) day of the last attempt.
+	DayAttempts int `json:"dayAttempts"`
+
+	LastError string `json:"lastError"`
+}
+
+// renewalPolicy the escalation policy of the failing renewals.
+type renewalPolicy struct {
+	// RetryInterval the minimum interval after the first failure, doubled after each failure.
+	// Zero disables the intervals.
+	RetryInterval    time.Duration
+	RetryMaxInterval time.Duration
+
+	// MaxAttemptsPerDay the maximum number of failed attempts per (UTC) day. Zero means unlimited.
+	MaxAttemptsPerDay int
+
+	// EscalationDays the number of days before the expiry when the failures are escalated.
+	EscalationDays int
+}
+
+func newRenewalPolicy(ctx *cli.Context) renewalPolicy {
+	return renewalPolicy{
+		RetryInterval:     ctx.Duration(flgRenewRetryInterval),
+		RetryMaxInterval:  ctx.Duration(flgRenewRetryMaxInterval),
+		MaxAttemptsPerDay: ctx.Int(flgRenewMaxAttemptsPerDay),
+		EscalationDays:    ctx.Int(flgRenewEscalationDays),
+	}
+}
+
+// postpone returns a reason if the renewal must not be attempted now.
+func (p renewalPolicy) postpone(failures *renewalFailures, now time.Time) string {
+	if failures == nil {
+		return ""
+	}
+
+	if p.RetryInterval > 0 && now.Before(failures.NextAttempt) {
+		return fmt.Sprintf("%d consecutive failures, next attempt after %s", failures.Attempts, failures.NextAttempt.Format(time.RFC3339))
+	}
+
+	if p.MaxAttemptsPerDay > 0 && sameDay(failures.LastAttempt, now) && failures.DayAttempts >= p.MaxAttemptsPerDay {
+		return fmt.Sprintf("the maximum number of attempts per day (%d) is reached", p.MaxAttemptsPerDay)
+	}
+
+	return ""
+}
+
+// record records a failed attempt.
+func (p renewalPolicy) record(failures *renewalFailures, now time.Time, err error) *renewalFailures {
+	if failures == nil {
+		failures = &renewalFailures{FailingSince: now}
+	}
+
+	if !sameDay(failures.LastAttempt, now) {
+		failures.DayAttempts = 0
+	}
+
+	failures.Attempts++
+	failures.DayAttempts++
+	failures.LastAttempt = now
+	failures.LastError = err.Error()
+	failures.NextAttempt = time.Time{}
+
+	if p.RetryInterval > 0 {
+		interval := p.RetryInterval
+
+		for i := 1; i < failures.Attempts && (p.RetryMaxInterval <= 0 || interval < p.RetryMaxInterval); i++ {
+			interval *= 2
+		}
+
+		if p.RetryMaxInterval > 0 {
+			interval = min(interval, p.RetryMaxInterval)
+		}
+
+		failures.NextAttempt = now.Add(interval)
+	}
+
+	return failures
+}
+
+// event returns the event of a failure: the failure is escalated when the certificate expires soon.
+func (p renewalPolicy) event(cert *x509.Certificate, now time.Time) string {
+	if p.EscalationDays > 0 && cert.NotAfter.Sub(now) <= time.Duration(p.EscalationDays)*24*time.Hour {
+		return renewalEventFailureNearExpiry
+	}
+
+	return renewalEventFailure
+}
+
+// checkRenewalFailures returns false (and logs the reason) if the renewal must be postponed because of the previous failures.
+func checkRenewalFailures(ctx *cli.Context, certsStorage *CertificatesStorage, domain string) bool {
+	failures, err := readRenewalFailures(certsStorage, domain)
+	if err != nil {
+		log.Warnf("[%s] Could not read the previous renewal failures: %v", domain, err)
+		return true
+	}
+
+	reason := newRenewalPolicy(ctx).postpone(failures, time.Now().UTC())
+	if reason == "" {
+		return true
+	}
+
+	log.Warnf("[%s] The renewal is postponed: %s (failing since %s, last error: %s)",
+		domain, reason, failures.FailingSince.Format(time.RFC3339), failures.LastError)
+
+	return false
+}
+
+// handleRenewalFailure records a failed renewal, and launches the failure hook.
+func handleRenewalFailure(ctx *cli.Context, certsStorage *CertificatesStorage, domain string, cert *x509.Certificate, meta map[string]string, renewErr error) {
+	policy := newRenewalPolicy(ctx)
+
+	now := time.Now().UTC()
+
+	failures, err := readRenewalFailures(certsStorage, domain)
+	if err != nil {
+		log.Warnf("[%s] Could not read the previous renewal failures: %v", domain, err)
+	}
+
+	failures = policy.record(failures, now, renewErr)
+
+	err = writeRenewalFailures(certsStorage, domain, failures)
+	if err != nil {
+		log.Warnf("[%s] Could not save the renewal failures: %v", domain, err)
+	}
+
+	event := policy.event(cert, now)
+	if event == renewalEventFailureNearExpiry {
+		log.Warnf("[%s] The renewal is failing since %s and the certificate expires on %s",
+			domain, failures.FailingSince.Format(time.RFC3339), cert.NotAfter.Format(time.RFC3339))
+	}
+
+	hook := ctx.String(flgRenewFailureHook)
+	if hook == "" {
+		return
+	}
+
+	meta[hookEnvCertDomain] = domain
+	meta[hookEnvCertPath] = certsStorage.GetFileName(domain, certExt)
+	meta[hookEnvCertNotAfter] = cert.NotAfter.Format(time.RFC3339)
+	meta[hookEnvRenewalEvent] = event
+	meta[hookEnvRenewalFailingSince] = failures.FailingSince.Format(time.RFC3339)
+	meta[hookEnvRenewalAttempts] = strconv.Itoa(failures.Attempts)
+	meta[hookEnvRenewalError] = failures.LastError
+
+	err = launchHook(hook, ctx.Duration(flgRenewHookTimeout), meta)
+	if err != nil {
+		log.Warnf("[%s] Failure hook: %v", domain, err)
+	}
+}
+
+// clearRenewalFailures removes the failures after a successful renewal.
+func clearRenewalFailures(certsStorage *CertificatesStorage, domain string) {
+	err := certsStorage.backend.DeleteCertificateFile(certsStorage.ctx, certsStorage.certName(domain), failuresExt)
+	if err != nil {
+		log.Warnf("[%s] Could not remove the renewal failures: %v", domain, err)
+	}
+}
+
+func readRenewalFailures(certsStorage *CertificatesStorage, domain string) (*renewalFailures, error) {
+	data, err := certsStorage.ReadFile(domain, failuresExt)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRenewalFailures(certsStorage.certName(domain)+failuresExt, data)
+}
+
+func parseRenewalFailures(filename string, data []byte) (*renewalFailures, error) {
+	failures := &renewalFailures{}
+
+	err := json.Unmarshal(data, failures)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return failures, nil
+}
+
+func writeRenewalFailures(certsStorage *CertificatesStorage, domain string, failures *renewalFailures) error {
+	data, err := json.MarshalIndent(failures, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return certsStorage.backend.WriteCertificateFile(certsStorage.ctx, certsStorage.certName(domain), failuresExt, data)
+}
+
+func sameDay(a, b time.Time) bool {
+	ya, ma, da := a.UTC().Date()
+	yb, mb, db := b.UTC().Date()
+
+	return ya == yb && ma == mb && da == db
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_renewalPolicy_record(t *testing.T) {
+	policy := renewalPolicy{RetryInterval: time.Hour, RetryMaxInterval: 6 * time.Hour}
+
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	failures := policy.record(nil, now, errors.New("boom"))
+
+	assert.Equal(t, &renewalFailures{
+		FailingSince: now,
+		LastAttempt:  now,
+		NextAttempt:  now.Add(time.Hour),
+		Attempts:     1,
+		DayAttempts:  1,
+		LastError:    "boom",
+	}, failures)
+
+	var intervals []time.Duration
+
+	for range 4 {
+		now = failures.NextAttempt
+		failures = policy.record(failures, now, errors.New("boom"))
+
+		intervals = append(intervals, failures.NextAttempt.Sub(now))
+	}
+
+	assert.Equal(t, []time.Duration{2 * time.Hour, 4 * time.Hour, 6 * time.Hour, 6 * time.Hour}, intervals)
+
+	assert.Equal(t, time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC), failures.FailingSince)
+	assert.Equal(t, 5, failures.Attempts)
+	// 10:00, 11:00, 13:00, 17:00, then 23:00.
+	assert.Equal(t, 5, failures.DayAttempts)
+
+	failures = policy.record(failures, failures.NextAttempt, errors.New("boom"))
+	assert.Equal(t, 6, failures.Attempts)
+	assert.Equal(t, 1, failures.DayAttempts)
+}
+
+func Test_renewalPolicy_record_noInterval(t *testing.T) {
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	failures := renewalPolicy{}.record(nil, now, errors.New("boom"))
+
+	assert.True(t, failures.NextAttempt.IsZero())
+}
+
+func Test_renewalPolicy_postpone(t *testing.T) {
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	failures := &renewalFailures{
+		FailingSince: now.Add(-48 * time.Hour),
+		LastAttempt:  now.Add(-time.Hour),
+		NextAttempt:  now.Add(time.Hour),
+		Attempts:     3,
+		DayAttempts:  3,
+	}
+
+	testCases := []struct {
+		desc     string
+		policy   renewalPolicy
+		failures *renewalFailures
+		expected string
+	}{
+		{
+			desc:   "no failures",
+			policy: renewalPolicy{RetryInterval: time.Hour, MaxAttemptsPerDay: 1},
+		},
+		{
+			desc:     "disabled",
+			failures: failures,
+		},
+		{
+			desc:     "retry interval",
+			policy:   renewalPolicy{RetryInterval: time.Hour},
+			failures: failures,
+			expected: "3 consecutive failures, next attempt after 2024-06-01T11:00:00Z",
+		},
+		{
+			desc:     "max attempts per day",
+			policy:   renewalPolicy{MaxAttemptsPerDay: 3},
+			failures: failures,
+			expected: "the maximum number of attempts per day (3) is reached",
+		},
+		{
+			desc:     "max attempts per day: not reached",
+			policy:   renewalPolicy{MaxAttemptsPerDay: 4},
+			failures: failures,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, test.policy.postpone(test.failures, now))
+		})
+	}
+
+	// The counter of the attempts is reset the next day.
+	assert.Empty(t, renewalPolicy{MaxAttemptsPerDay: 3}.postpone(failures, now.Add(24*time.Hour)))
+}
+
+func Test_renewalPolicy_event(t *testing.T) {
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	policy := renewalPolicy{EscalationDays: 7}
+
+	assert.Equal(t, renewalEventFailure, policy.event(&x509.Certificate{NotAfter: now.Add(8 * 24 * time.Hour)}, now))
+	assert.Equal(t, renewalEventFailureNearExpiry, policy.event(&x509.Certificate{NotAfter: now.Add(7 * 24 * time.Hour)}, now))
+	assert.Equal(t, renewalEventFailure, renewalPolicy{}.event(&x509.Certificate{NotAfter: now}, now))
+}
+
+func Test_renewalFailures_storage(t *testing.T) {
+	storage := newTestCertificatesStorage(t)
+
+	failures, err := readRenewalFailures(storage, "example.com")
+	require.NoError(t, err)
+	assert.Nil(t, failures)
+
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	expected := renewalPolicy{RetryInterval: time.Hour}.record(nil, now, errors.New("boom"))
+
+	err = writeRenewalFailures(storage, "example.com", expected)
+	require.NoError(t, err)
+
+	failures, err = readRenewalFailures(storage, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, expected, failures)
+
+	clearRenewalFailures(storage, "example.com")
+
+	assert.NoFileExists(t, storage.GetFileName("example.com", failuresExt))
+}
+
+func Test_renewalFailures_backend(t *testing.T) {
+	certsStorage := newTestCertificatesStorage(t)
+
+	// A remote backend (ex: Vault, Kubernetes): the files are not inside the local root path.
+	backend := storage.NewFileSystem(t.TempDir())
+	certsStorage.backend = backend
+
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	expected := renewalPolicy{RetryInterval: time.Hour}.record(nil, now, errors.New("boom"))
+
+	err := writeRenewalFailures(certsStorage, "example.com", expected)
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, certsStorage.GetFileName("example.com", failuresExt))
+	assert.FileExists(t, backend.CertificateFilePath("example.com", failuresExt))
+
+	failures, err := readRenewalFailures(certsStorage, "example.com")
+	require.NoError(t, err)
+	assert.Equal(t, expected, failures)
+
+	clearRenewalFailures(certsStorage, "example.com")
+
+	assert.NoFileExists(t, backend.CertificateFilePath("example.com", failuresExt))
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/urfave/cli/v2"
+)
+
+// checkRenewalWindow returns false (and logs the reason) if the renewal must be deferred to a maintenance window (--window).
+// The windows are ignored when the certificate expires within --window-override-days.
+func checkRenewalWindow(ctx *cli.Context, domain string, cert *x509.Certificate, now time.Time) (bool, error) {
+	if strings.TrimSpace(ctx.String(flgRenewWindow)) == "" {
+		return true, nil
+	}
+
+	windows, err := parseRenewalWindows(ctx.String(flgRenewWindow))
+	if err != nil {
+		return false, fmt.Errorf("--%s: %w", flgRenewWindow, err)
+	}
+
+	if inRenewalWindows(windows, now) {
+		return true, nil
+	}
+
+	overrideDays := ctx.Int(flgRenewWindowOverrideDays)
+	if cert.NotAfter.Sub(now) <= time.Duration(overrideDays)*24*time.Hour {
+		log.Warnf("[%s] Outside of the renewal windows, but the certificate expires on %s: renewing now", domain, cert.NotAfter.Format(time.RFC3339))
+		return true, nil
+	}
+
+	log.Infof("[%s] The renewal is deferred: outside of the renewal windows (%s)", domain, ctx.String(flgRenewWindow))
+
+	return false, nil
+}
+
+// parseRenewalWindows parses the windows separated by semicolons.
+func parseRenewalWindows(value string) ([]*renewalWindow, error) {
+	var windows []*renewalWindow
+
+	for expr := range strings.SplitSeq(value, ";") {
+		if strings.TrimSpace(expr) == "" {
+			continue
+		}
+
+		window, err := parseRenewalWindow(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		windows = append(windows, window)
+	}
+
+	return windows, nil
+}
+
+func inRenewalWindows(windows []*renewalWindow, now time.Time) bool {
+	for _, window := range windows {
+		if window.contains(now) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renewalWindow a maintenance window defined by a cron-like expression ("minute hour day-of-month month day-of-week"):
+// the renewal is allowed during the minutes matching the expression.
+// Ex: "* 2-4 * * 1-5" allows the renewals from 02:00 to 04:59, Monday to Friday.
+// The time zone can be defined with a "CRON_TZ=" prefix (ex: "CRON_TZ=Europe/Berlin * 2-4 * * *"), the local time zone is used by default.
+type renewalWindow struct {
+	expr string
+
+	location *time.Location
+
+	minutes  []bool
+	hours    []bool
+	days     []bool
+	months   []bool
+	weekdays []bool
+
+	// The cron semantic: when both the day of the month and the day of the week are restricted,
+	// a day matching one of them matches.
+	daysRestricted     bool
+	weekdaysRestricted bool
+}
+
+func parseRenewalWindow(expr string) (*renewalWindow, error) {
+	w := &renewalWindow{expr: expr, location: time.Local}
+
+	value := strings.TrimSpace(expr)
+
+	if tz, rest, ok := strings.Cut(value, " "); ok && strings.HasPrefix(tz, "CRON_TZ=") {
+		location, err := time.LoadLocation(strings.TrimPrefix(tz, "CRON_TZ="))
+		if err != nil {
+			return nil, fmt.Errorf("window %q: %w", expr, err)
+		}
+
+		w.location = location
+		value = rest
+	}
+
+	fields := strings.Fields(value)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("window %q: expected 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(fields))
+	}
+
+	var err error
+
+	w.minutes, err = parseCronField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("window %q: minute: %w", expr, err)
+	}
+
+	w.hours, err = parseCronField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("window %q: hour: %w", expr, err)
+	}
+
+	w.days, err = parseCronField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("window %q: day of month: %w", expr, err)
+	}
+
+	w.months, err = parseCronField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("window %q: month: %w", expr, err)
+	}
+
+	// 7 is also Sunday.
+	w.weekdays, err = parseCronField(fields[4], 0, 7)
+	if err != nil {
+		return nil, fmt.Errorf("window %q: day of week: %w", expr, err)
+	}
+
+	w.weekdays[0] = w.weekdays[0] || w.weekdays[7]
+
+	w.daysRestricted = fields[2] != "*"
+	w.weekdaysRestricted = fields[4] != "*"
+
+	return w, nil
+}
+
+// contains reports whether the time is inside the window.
+func (w *renewalWindow) contains(t time.Time) bool {
+	t = t.In(w.location)
+
+	if !w.minutes[t.Minute()] || !w.hours[t.Hour()] || !w.months[int(t.Month())] {
+		return false
+	}
+
+	day := w.days[t.Day()]
+	weekday := w.weekdays[int(t.Weekday())]
+
+	if w.daysRestricted && w.weekdaysRestricted {
+		return day || weekday
+	}
+
+	return day && weekday
+}
+
+// parseCronField parses a field of a cron expression: "*", "5", "1-5", "*/15", "1-10/2", and the lists of them ("1,3,5").
+func parseCronField(field string, minValue, maxValue int) ([]bool, error) {
+	values := make([]bool, maxValue+1)
+
+	for part := range strings.SplitSeq(field, ",") {
+		rng, stepValue, hasStep := strings.Cut(part, "/")
+
+		step := 1
+
+		if hasStep {
+			var err error
+
+			step, err = strconv.Atoi(stepValue)
+			if err != nil || step <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepValue)
+			}
+		}
+
+		start, end := minValue, maxValue
+
+		if rng != "*" {
+			first, last, isRange := strings.Cut(rng, "-")
+
+			var err error
+
+			start, err = parseCronValue(first, minValue, maxValue)
+			if err != nil {
+				return nil, err
+			}
+
+			end = start
+
+			switch {
+			case isRange:
+				end, err = parseCronValue(last, minValue, maxValue)
+				if err != nil {
+					return nil, err
+				}
+
+				if end < start {
+					return nil, fmt.Errorf("invalid range %q", rng)
+				}
+
+			case hasStep:
+				end = maxValue
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			values[i] = true
+		}
+	}
+
+	return values, nil
+}
+
+func parseCronValue(value string, minValue, maxValue int) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < minValue || v > maxValue {
+		return 0, fmt.Errorf("invalid value %q: expected a number between %d and %d", value, minValue, maxValue)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_parseRenewalWindow_errors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		expr     string
+		expected string
+	}{
+		{
+			desc:     "missing fields",
+			expr:     "* 2-4 * *",
+			expected: `window "* 2-4 * *": expected 5 fields (minute hour day-of-month month day-of-week), got 4`,
+		},
+		{
+			desc:     "out of range",
+			expr:     "* 24 * * *",
+			expected: `window "* 24 * * *": hour: invalid value "24": expected a number between 0 and 23`,
+		},
+		{
+			desc:     "reversed range",
+			expr:     "* * * * 5-1",
+			expected: `window "* * * * 5-1": day of week: invalid range "5-1"`,
+		},
+		{
+			desc:     "invalid step",
+			expr:     "*/0 * * * *",
+			expected: `window "*/0 * * * *": minute: invalid step "0"`,
+		},
+		{
+			desc:     "not a number",
+			expr:     "* * * jan *",
+			expected: `window "* * * jan *": month: invalid value "jan": expected a number between 1 and 12`,
+		},
+		{
+			desc:     "unknown time zone",
+			expr:     "CRON_TZ=Nowhere/City * * * * *",
+			expected: `window "CRON_TZ=Nowhere/City * * * * *": unknown time zone Nowhere/City`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseRenewalWindow(test.expr)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func Test_renewalWindow_contains(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		expr     string
+		date     time.Time
+		expected bool
+	}{
+		{
+			desc:     "any time",
+			expr:     "* * * * *",
+			date:     time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			desc:     "hour range: inside",
+			expr:     "* 2-4 * * *",
+			date:     time.Date(2024, 6, 1, 4, 59, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			desc: "hour range: outside",
+			expr: "* 2-4 * * *",
+			date: time.Date(2024, 6, 1, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "minute step: match",
+			expr:     "*/15 * * * *",
+			date:     time.Date(2024, 6, 1, 10, 45, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			desc: "minute step: no match",
+			expr: "*/15 * * * *",
+			date: time.Date(2024, 6, 1, 10, 46, 0, 0, time.UTC),
+		},
+		{
+			desc:     "step from a value",
+			expr:     "* 1/6 * * *",
+			date:     time.Date(2024, 6, 1, 19, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			desc:     "list",
+			expr:     "* 1,3,22-23 * * *",
+			date:     time.Date(2024, 6, 1, 22, 30, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			desc: "weekdays: Saturday",
+			expr: "* * * * 1-5",
+			date: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "Sunday as 7",
+			expr:     "* * * * 7",
+			date:     time.Date(2024, 6, 2, 10, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			desc:     "day of month or day of week: day of month",
+			expr:     "* * 1 * 1",
+			date:     time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			desc:     "day of month or day of week: day of week",
+			expr:     "* * 1 * 1",
+			date:     time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			desc: "day of month or day of week: none",
+			expr: "* * 1 * 1",
+			date: time.Date(2024, 6, 4, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			desc: "month",
+			expr: "* * * 1-5 *",
+			date: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			desc:     "time zone",
+			expr:     "CRON_TZ=Asia/Tokyo * 2-4 * * *",
+			date:     time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			window, err := parseRenewalWindow(test.expr)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, window.contains(test.date))
+		})
+	}
+}
+
+func Test_checkRenewalWindow(t *testing.T) {
+	now := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		window   string
+		notAfter time.Time
+		expected bool
+	}{
+		{
+			desc:     "no window",
+			notAfter: now.Add(30 * 24 * time.Hour),
+			expected: true,
+		},
+		{
+			desc:     "inside a window",
+			window:   "CRON_TZ=UTC * 2-4 * * *; CRON_TZ=UTC * 10 * * *",
+			notAfter: now.Add(30 * 24 * time.Hour),
+			expected: true,
+		},
+		{
+			desc:     "outside the windows",
+			window:   "CRON_TZ=UTC * 2-4 * * *",
+			notAfter: now.Add(30 * 24 * time.Hour),
+		},
+		{
+			desc:     "outside the windows: imminent expiry",
+			window:   "CRON_TZ=UTC * 2-4 * * *",
+			notAfter: now.Add(5 * 24 * time.Hour),
+			expected: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.String(flgRenewWindow, test.window, "")
+			flags.Int(flgRenewWindowOverrideDays, 7, "")
+
+			ctx := cli.NewContext(cli.NewApp(), flags, nil)
+
+			cert := &x509.Certificate{NotAfter: test.notAfter}
+
+			actual, err := checkRenewalWindow(ctx, "example.com", cert, now)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
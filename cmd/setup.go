@@ -7,6 +7,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"os"
 	"strings"
@@ -16,20 +17,35 @@ import (
 	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/lego"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/registration"
+	"github.com/go-acme/lego/v4/storage"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/urfave/cli/v2"
 )
 
-const filePerm os.FileMode = 0o600
+// The storages of the accounts and the certificates.
+const (
+	storageFileSystem = "filesystem"
+	storageVault      = "vault"
+	storageKubernetes = "kubernetes"
+)
 
 // setupClient creates a new client with challenge settings.
-func setupClient(ctx *cli.Context, account *Account, keyType certcrypto.KeyType) *lego.Client {
-	client := newClient(ctx, account, keyType)
+// The errors of the challenge settings (flags) are fatal.
+func setupClient(ctx *cli.Context, account *Account, keyType certcrypto.KeyType) (*lego.Client, error) {
+	client, err := newClient(ctx, account, keyType)
+	if err != nil {
+		return nil, err
+	}
 
 	setupChallenges(ctx, client)
 
-	return client
+	if ctx.Bool(flgHTTP) && ctx.Bool(flgHTTPStateless) {
+		logStatelessHTTPThumbprint(account)
+	}
+
+	return client, nil
 }
 
 func setupAccount(ctx *cli.Context, accountsStorage *AccountsStorage) (*Account, certcrypto.KeyType) {
@@ -46,7 +62,7 @@ func setupAccount(ctx *cli.Context, accountsStorage *AccountsStorage) (*Account,
 	return account, keyType
 }
 
-func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyType) *lego.Client {
+func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyType) (*lego.Client, error) {
 	config := lego.NewConfig(acc)
 	config.CADirURL = ctx.String(flgServer)
 
@@ -55,9 +71,18 @@ func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyTy
 		Timeout:             time.Duration(ctx.Int(flgCertTimeout)) * time.Second,
 		OverallRequestLimit: ctx.Int(flgOverallRequestLimit),
 		DisableCommonName:   ctx.Bool(flgDisableCommonName),
+		LowMemory:           ctx.Bool(flgLowMemory),
 	}
 	config.UserAgent = getUserAgent(ctx)
 
+	if metricsCollector != nil {
+		config.Metrics = metricsCollector
+	}
+
+	if ctx.Bool(flgDebugACME) {
+		config.DebugLogger = slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+
 	if ctx.IsSet(flgHTTPTimeout) {
 		config.HTTPClient.Timeout = time.Duration(ctx.Int(flgHTTPTimeout)) * time.Second
 	}
@@ -71,6 +96,12 @@ func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyTy
 		}
 	}
 
+	config.HTTPClient.Transport = applyDialer(config.HTTPClient.Transport, newDialer(ctx))
+
+	if ctx.Bool(flgLowMemory) {
+		config.HTTPClient.Transport = limitTransport(config.HTTPClient.Transport)
+	}
+
 	retryClient := retryablehttp.NewClient()
 	retryClient.RetryMax = 5
 	retryClient.HTTPClient = config.HTTPClient
@@ -85,14 +116,14 @@ func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyTy
 
 	client, err := lego.NewClient(config)
 	if err != nil {
-		log.Fatalf("Could not create client: %v", err)
+		return nil, fmt.Errorf("could not create client: %w", err)
 	}
 
 	if client.GetExternalAccountRequired() && !ctx.IsSet(flgEAB) {
-		log.Fatalf("Server requires External Account Binding. Use --%s with --%s and --%s.", flgEAB, flgKID, flgHMAC)
+		return nil, fmt.Errorf("server requires External Account Binding. Use --%s with --%s and --%s", flgEAB, flgKID, flgHMAC)
 	}
 
-	return client
+	return client, nil
 }
 
 // getKeyType the type from which private keys should be generated.
@@ -111,6 +142,8 @@ func getKeyType(ctx *cli.Context) certcrypto.KeyType {
 		return certcrypto.EC256
 	case "EC384":
 		return certcrypto.EC384
+	case "ED25519":
+		return certcrypto.ED25519
 	}
 
 	log.Fatalf("Unsupported KeyType: %s", keyType)
@@ -122,9 +155,9 @@ func getUserAgent(ctx *cli.Context) string {
 	return strings.TrimSpace(fmt.Sprintf("%s lego-cli/%s", ctx.String(flgUserAgent), ctx.App.Version))
 }
 
-func createNonExistingFolder(path string) error {
+func createNonExistingFolder(fs *storage.FileSystem, path string) error {
 	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return os.MkdirAll(path, 0o700)
+		return fs.MkdirAll(path)
 	} else if err != nil {
 		return err
 	}
@@ -210,3 +243,49 @@ func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, erro
 
 	return rt, nil
 }
+
+// newFileSystemStorage creates the filesystem storage of the accounts and the certificates (--path).
+func newFileSystemStorage(ctx *cli.Context) *storage.FileSystem {
+	fs := storage.NewFileSystem(ctx.String(flgPath))
+	fs.SetInsecurePermissions(ctx.Bool(flgInsecurePermissions))
+
+	return fs
+}
+
+// newStorageBackend creates the storage of the accounts and the certificates (--storage).
+func newStorageBackend(ctx *cli.Context, fs *storage.FileSystem) storage.Backend {
+	switch ctx.String(flgStorage) {
+	case storageFileSystem:
+		return fs
+
+	case storageVault:
+		backend, err := storage.NewVault(storage.VaultConfig{
+			Address:   ctx.String(flgVaultAddr),
+			Token:     env.GetOrFile(envVaultToken),
+			Path:      ctx.String(flgVaultPath),
+			Namespace: env.GetOrFile(envVaultNamespace),
+		})
+		if err != nil {
+			log.Fatalf("Could not create the storage: %v", err)
+		}
+
+		return backend
+
+	case storageKubernetes:
+		backend, err := storage.NewKubernetes(storage.KubernetesConfig{
+			Kubeconfig: ctx.String(flgKubeconfig),
+			Context:    ctx.String(flgKubernetesContext),
+			Namespace:  ctx.String(flgKubernetesNamespace),
+		})
+		if err != nil {
+			log.Fatalf("Could not create the storage: %v", err)
+		}
+
+		return backend
+
+	default:
+		log.Fatalf("Invalid storage: %s", ctx.String(flgStorage))
+
+		return nil
+	}
+}
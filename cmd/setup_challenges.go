@@ -3,52 +3,229 @@ package cmd
 import (
 	"fmt"
 	"net"
+	"net/url"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/challenge/http01"
+	"github.com/go-acme/lego/v4/challenge/selfcheck"
 	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
 	"github.com/go-acme/lego/v4/lego"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/providers/dns"
 	"github.com/go-acme/lego/v4/providers/http/memcached"
 	"github.com/go-acme/lego/v4/providers/http/s3"
+	"github.com/go-acme/lego/v4/providers/http/stateless"
 	"github.com/go-acme/lego/v4/providers/http/webroot"
 	"github.com/urfave/cli/v2"
 )
 
 func setupChallenges(ctx *cli.Context, client *lego.Client) {
-	if !ctx.Bool(flgHTTP) && !ctx.Bool(flgTLS) && !ctx.IsSet(flgDNS) {
+	if !ctx.Bool(flgHTTP) && !ctx.Bool(flgTLS) && !isDNSEnabled(ctx) {
 		log.Fatalf("No challenge selected. You must specify at least one challenge: `--%s`, `--%s`, `--%s`.", flgHTTP, flgTLS, flgDNS)
 	}
 
+	if ctx.IsSet(flgDNSRoute) && !isDNSEnabled(ctx) {
+		log.Fatalf("'%s' requires '%s'", flgDNSRoute, flgDNS)
+	}
+
+	if ctx.IsSet(flgDNSFallback) && !ctx.IsSet(flgDNS) {
+		log.Fatalf("'%s' requires '%s'", flgDNSFallback, flgDNS)
+	}
+
+	if ctx.Int(flgChallengeConcurrency) < 1 {
+		log.Fatalf("'%s' must be greater than 0", flgChallengeConcurrency)
+	}
+
+	client.Challenge.SetConcurrency(ctx.Int(flgChallengeConcurrency))
+
 	if ctx.Bool(flgHTTP) {
-		err := client.Challenge.SetHTTP01Provider(setupHTTPProvider(ctx), http01.SetDelay(ctx.Duration(flgHTTPDelay)))
+		opts := []http01.ChallengeOption{http01.SetDelay(ctx.Duration(flgHTTPDelay))}
+
+		if ctx.Bool(flgHTTPSelfCheck) {
+			opts = append(opts, http01.SetSelfCheck(getSelfCheckConfig(ctx)))
+		}
+
+		err := client.Challenge.SetHTTP01Provider(setupHTTPProvider(ctx), opts...)
 		if err != nil {
 			log.Fatal(err)
 		}
 	}
 
 	if ctx.Bool(flgTLS) {
-		err := client.Challenge.SetTLSALPN01Provider(setupTLSProvider(ctx), tlsalpn01.SetDelay(ctx.Duration(flgTLSDelay)))
+		opts := []tlsalpn01.ChallengeOption{tlsalpn01.SetDelay(ctx.Duration(flgTLSDelay))}
+
+		if ctx.Bool(flgTLSSelfCheck) {
+			opts = append(opts, tlsalpn01.SetSelfCheck(getSelfCheckConfig(ctx)))
+		}
+
+		err := client.Challenge.SetTLSALPN01Provider(setupTLSProvider(ctx), opts...)
 		if err != nil {
 			log.Fatal(err)
 		}
 	}
 
-	if ctx.IsSet(flgDNS) {
+	if isDNSEnabled(ctx) {
 		err := setupDNS(ctx, client)
 		if err != nil {
 			log.Fatal(err)
 		}
 	}
+
+	disabled, err := parseDisabledChallenges(ctx.StringSlice(flgDisableChallenge), ctx.String(flgServer))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, chlgType := range disabled {
+		client.Challenge.Disable(chlgType)
+	}
+
+	enabled := slices.DeleteFunc(enabledChallenges(ctx), func(chlgType challenge.Type) bool {
+		return slices.Contains(disabled, chlgType)
+	})
+
+	if len(enabled) == 0 {
+		log.Fatalf("All the selected challenges are disabled by `--%s`.", flgDisableChallenge)
+	}
+
+	if ctx.IsSet(flgChallengeMap) {
+		challengeMap, err := parseChallengeMap(ctx.StringSlice(flgChallengeMap), enabled)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for domain, chlgType := range challengeMap {
+			client.Challenge.SetChallengeType(domain, chlgType)
+		}
+	}
+}
+
+func enabledChallenges(ctx *cli.Context) []challenge.Type {
+	var types []challenge.Type
+
+	if ctx.Bool(flgHTTP) {
+		types = append(types, challenge.HTTP01)
+	}
+
+	if ctx.Bool(flgTLS) {
+		types = append(types, challenge.TLSALPN01)
+	}
+
+	if isDNSEnabled(ctx) {
+		types = append(types, challenge.DNS01)
+	}
+
+	return types
+}
+
+// isDNSEnabled returns true if the DNS-01 challenge is enabled: with a DNS provider or with the export only mode.
+func isDNSEnabled(ctx *cli.Context) bool {
+	return ctx.IsSet(flgDNS) || ctx.Bool(flgDNSExportOnly)
+}
+
+// parseChallengeMap parses the values of --challenge-map (domain=challenge).
+func parseChallengeMap(values []string, enabled []challenge.Type) (map[string]challenge.Type, error) {
+	challengeMap := make(map[string]challenge.Type)
+
+	for _, value := range values {
+		domain, chlg, ok := strings.Cut(value, "=")
+
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		chlgType := challenge.Type(strings.TrimSpace(chlg))
+
+		if !ok || domain == "" || chlgType == "" {
+			return nil, fmt.Errorf("--%s: invalid value %q, expected domain=challenge", flgChallengeMap, value)
+		}
+
+		if !slices.Contains(enabled, chlgType) {
+			return nil, fmt.Errorf("--%s: the challenge %q for %s is not enabled (enabled: %s)", flgChallengeMap, chlgType, domain, joinChallengeTypes(enabled))
+		}
+
+		challengeMap[domain] = chlgType
+	}
+
+	return challengeMap, nil
+}
+
+// parseDisabledChallenges parses the values of --disable-challenge (challenge or challenge@ca-host).
+// A value with a CA host only applies when the host of the server URL matches.
+func parseDisabledChallenges(values []string, serverURL string) ([]challenge.Type, error) {
+	var caHost string
+
+	if u, err := url.Parse(serverURL); err == nil {
+		caHost = strings.ToLower(u.Hostname())
+	}
+
+	var disabled []challenge.Type
+
+	for _, value := range values {
+		chlg, host, hasHost := strings.Cut(value, "@")
+
+		chlgType := challenge.Type(strings.TrimSpace(chlg))
+		host = strings.ToLower(strings.TrimSpace(host))
+
+		if !slices.Contains([]challenge.Type{challenge.HTTP01, challenge.TLSALPN01, challenge.DNS01}, chlgType) {
+			return nil, fmt.Errorf("--%s: unknown challenge %q in %q", flgDisableChallenge, chlgType, value)
+		}
+
+		if hasHost && host == "" {
+			return nil, fmt.Errorf("--%s: invalid value %q, expected challenge or challenge@ca-host", flgDisableChallenge, value)
+		}
+
+		if hasHost && host != caHost {
+			continue
+		}
+
+		if !slices.Contains(disabled, chlgType) {
+			disabled = append(disabled, chlgType)
+		}
+	}
+
+	return disabled, nil
+}
+
+func joinChallengeTypes(types []challenge.Type) string {
+	var names []string
+	for _, t := range types {
+		names = append(names, string(t))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func getSelfCheckConfig(ctx *cli.Context) selfcheck.Config {
+	config := selfcheck.Config{}
+
+	if ctx.IsSet(flgSelfCheckResolvers) {
+		err := dns01.CheckNameservers(ctx.StringSlice(flgSelfCheckResolvers))
+		if err != nil {
+			log.Fatalf("Invalid --%s: %v", flgSelfCheckResolvers, err)
+		}
+
+		config.Resolvers = dns01.ParseNameservers(ctx.StringSlice(flgSelfCheckResolvers))
+	}
+
+	if ctx.String(flgSelfCheckProxy) != "" {
+		proxyURL, err := url.Parse(ctx.String(flgSelfCheckProxy))
+		if err != nil || proxyURL.Host == "" {
+			log.Fatalf("Invalid --%s: %q", flgSelfCheckProxy, ctx.String(flgSelfCheckProxy))
+		}
+
+		config.Proxy = proxyURL
+	}
+
+	return config
 }
 
-//nolint:gocyclo // the complexity is expected.
 func setupHTTPProvider(ctx *cli.Context) challenge.Provider {
 	switch {
+	case ctx.Bool(flgHTTPStateless):
+		return stateless.NewHTTPProvider()
 	case ctx.IsSet(flgHTTPWebroot):
 		ps, err := webroot.NewHTTPProvider(ctx.String(flgHTTPWebroot))
 		if err != nil {
@@ -100,6 +277,16 @@ func setupHTTPProvider(ctx *cli.Context) challenge.Provider {
 	}
 }
 
+// logStatelessHTTPThumbprint displays the account thumbprint to use inside the web server rule of the stateless HTTP-01 mode.
+func logStatelessHTTPThumbprint(account *Account) {
+	thumbprint, err := acme.Thumbprint(account.GetPrivateKey())
+	if err != nil {
+		log.Fatalf("Could not compute the account thumbprint: %v", err)
+	}
+
+	log.Printf("Stateless HTTP-01: the web server must answer '/.well-known/acme-challenge/<token>' with '<token>.%s'", thumbprint)
+}
+
 func setupTLSProvider(ctx *cli.Context) challenge.Provider {
 	switch {
 	case ctx.IsSet(flgTLSPort):
@@ -133,14 +320,55 @@ func setupDNS(ctx *cli.Context, client *lego.Client) error {
 		return fmt.Errorf("'%s' cannot be negative", flgDNSPropagationWait)
 	}
 
-	provider, err := dns.NewDNSChallengeProviderByName(ctx.String(flgDNS))
+	err = dns01.CheckNameservers(ctx.StringSlice(flgDNSResolvers))
+	if err != nil {
+		return fmt.Errorf("'%s': %w", flgDNSResolvers, err)
+	}
+
+	if ctx.Int(flgDNSPropagationStability) < 1 {
+		return fmt.Errorf("'%s' must be greater than 0", flgDNSPropagationStability)
+	}
+
+	if port := ctx.Int(flgDNSAuthoritativePort); port < 1 || port > 65535 {
+		return fmt.Errorf("'%s' must be a valid port", flgDNSAuthoritativePort)
+	}
+
+	if ctx.Int(flgDNSQueryRetries) < 0 {
+		return fmt.Errorf("'%s' cannot be negative", flgDNSQueryRetries)
+	}
+
+	provider, err := setupDNSProvider(ctx)
+	if err != nil {
+		return err
+	}
+
+	if ctx.Duration(flgDNSCleanUpCheck) < 0 {
+		return fmt.Errorf("'%s' cannot be negative", flgDNSCleanUpCheck)
+	}
+
+	routes, err := parseDNSRoutes(ctx.StringSlice(flgDNSRoute))
+	if err != nil {
+		return err
+	}
+
+	name := ctx.String(flgDNS)
+	if ctx.Bool(flgDNSExportOnly) {
+		name = "export"
+	}
+
+	provider, err = wrapDNSProvider(ctx, provider, name)
 	if err != nil {
 		return err
 	}
 
 	servers := ctx.StringSlice(flgDNSResolvers)
 
-	err = client.Challenge.SetDNS01Provider(provider,
+	if len(servers) > 0 {
+		// The DNS providers find the zones with the package-level functions of dns01.
+		dns01.SetDefaultNameservers(servers)
+	}
+
+	opts := []dns01.ChallengeOption{
 		dns01.CondOption(len(servers) > 0,
 			dns01.AddRecursiveNameservers(dns01.ParseNameservers(ctx.StringSlice(flgDNSResolvers)))),
 
@@ -155,11 +383,149 @@ func setupDNS(ctx *cli.Context, client *lego.Client) error {
 		dns01.CondOption(ctx.Bool(flgDNSPropagationRNS),
 			dns01.RecursiveNSsPropagationRequirement()),
 
+		dns01.CondOption(ctx.Bool(flgDNSPropagationWrongValue),
+			dns01.FailOnWrongValue()),
+
+		dns01.CondOption(ctx.Int(flgDNSPropagationStability) > 1,
+			dns01.PropagationStability(ctx.Int(flgDNSPropagationStability))),
+
 		dns01.CondOption(ctx.IsSet(flgDNSTimeout),
 			dns01.AddDNSTimeout(time.Duration(ctx.Int(flgDNSTimeout))*time.Second)),
-	)
 
-	return err
+		dns01.CondOption(ctx.IsSet(flgDNSQueryRetries),
+			dns01.AddDNSQueryRetries(ctx.Int(flgDNSQueryRetries))),
+
+		dns01.CondOption(ctx.IsSet(flgDNSAuthoritativePort),
+			dns01.AuthoritativeNameserverPort(ctx.Int(flgDNSAuthoritativePort))),
+
+		dns01.CondOption(ctx.Bool(flgDNSQueryTCPFallback),
+			dns01.DNSTCPFallback(true)),
+
+		dns01.DNSAddressFamily(getAddressFamily(ctx)),
+	}
+
+	err = client.Challenge.SetDNS01Provider(provider, opts...)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range routes {
+		routeProvider, err := newDNSProvider(ctx, r.provider)
+		if err != nil {
+			return fmt.Errorf("--%s %s: %w", flgDNSRoute, r.pattern, err)
+		}
+
+		routeProvider, err = wrapDNSProvider(ctx, routeProvider, r.provider)
+		if err != nil {
+			return err
+		}
+
+		err = client.Challenge.SetDNS01ProviderFor(r.pattern, routeProvider, opts...)
+		if err != nil {
+			return fmt.Errorf("--%s: %w", flgDNSRoute, err)
+		}
+	}
+
+	return nil
+}
+
+// wrapDNSProvider adds the cleanup check (--dns.cleanup-check) and the journal (--dns.journal) to the DNS provider.
+func wrapDNSProvider(ctx *cli.Context, provider challenge.Provider, name string) (challenge.Provider, error) {
+	provider = dns01.WithCleanUpCheck(provider, ctx.Duration(flgDNSCleanUpCheck), 0)
+
+	return setupDNSJournal(ctx, provider, name)
+}
+
+type dnsRoute struct {
+	pattern  string
+	provider string
+}
+
+// parseDNSRoutes parses the values of --dns.route (pattern=provider).
+func parseDNSRoutes(values []string) ([]dnsRoute, error) {
+	var routes []dnsRoute
+
+	for _, value := range values {
+		pattern, provider, ok := strings.Cut(value, "=")
+
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		provider = strings.TrimSpace(provider)
+
+		if !ok || pattern == "" || provider == "" {
+			return nil, fmt.Errorf("--%s: invalid value %q, expected pattern=provider", flgDNSRoute, value)
+		}
+
+		routes = append(routes, dnsRoute{pattern: pattern, provider: provider})
+	}
+
+	return routes, nil
+}
+
+func setupDNSProvider(ctx *cli.Context) (challenge.Provider, error) {
+	if ctx.Bool(flgDNSExportOnly) && ctx.IsSet(flgDNS) {
+		return nil, fmt.Errorf("'%s' and '%s' are mutually exclusive", flgDNSExportOnly, flgDNS)
+	}
+
+	if ctx.Bool(flgDNSExportOnly) && ctx.String(flgDNSExport) == "" {
+		return nil, fmt.Errorf("'%s' requires '%s'", flgDNSExportOnly, flgDNSExport)
+	}
+
+	var provider challenge.Provider
+
+	if !ctx.Bool(flgDNSExportOnly) {
+		var err error
+
+		provider, err = newDNSProvider(ctx, ctx.String(flgDNS))
+		if err != nil {
+			return nil, err
+		}
+
+		provider, err = setupDNSFallbacks(ctx, provider)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if ctx.String(flgDNSExport) == "" {
+		return provider, nil
+	}
+
+	return dns01.WithExport(provider, dns01.ExportOptions{
+		Path:    ctx.String(flgDNSExport),
+		Format:  ctx.String(flgDNSExportFormat),
+		Timeout: ctx.Duration(flgDNSExportTimeout),
+	})
+}
+
+// newDNSProvider creates the DNS provider.
+// In the daemon, the provider is rebuilt when its credential files change.
+func newDNSProvider(ctx *cli.Context, name string) (challenge.Provider, error) {
+	if ctx.Command != nil && ctx.Command.Name == "daemon" {
+		return dns.NewDNSChallengeProviderByNameWithReload(name)
+	}
+
+	return dns.NewDNSChallengeProviderByName(name)
+}
+
+// setupDNSFallbacks adds the fallback providers (--dns-fallback) to the DNS provider.
+func setupDNSFallbacks(ctx *cli.Context, provider challenge.Provider) (challenge.Provider, error) {
+	names := ctx.StringSlice(flgDNSFallback)
+	if len(names) == 0 {
+		return provider, nil
+	}
+
+	var fallbacks []challenge.Provider
+
+	for _, name := range names {
+		fallback, err := newDNSProvider(ctx, strings.TrimSpace(name))
+		if err != nil {
+			return nil, fmt.Errorf("--%s %s: %w", flgDNSFallback, name, err)
+		}
+
+		fallbacks = append(fallbacks, fallback)
+	}
+
+	return dns01.NewMultiProvider(provider, fallbacks...), nil
 }
 
 func checkPropagationExclusiveOptions(ctx *cli.Context) error {
@@ -175,6 +541,10 @@ func checkPropagationExclusiveOptions(ctx *cli.Context) error {
 		return fmt.Errorf("'%s' and '%s' are mutually exclusive", flgDNSPropagationRNS, flgDNSPropagationWait)
 	}
 
+	if ctx.IsSet(flgDNSPropagationStability) && ctx.IsSet(flgDNSPropagationWait) {
+		return fmt.Errorf("'%s' and '%s' are mutually exclusive", flgDNSPropagationStability, flgDNSPropagationWait)
+	}
+
 	return nil
 }
 
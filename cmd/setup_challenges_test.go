@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"flag"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_parseChallengeMap(t *testing.T) {
+	enabled := []challenge.Type{challenge.HTTP01, challenge.DNS01}
+
+	testCases := []struct {
+		desc          string
+		values        []string
+		expected      map[string]challenge.Type
+		expectedError string
+	}{
+		{
+			desc:   "valid",
+			values: []string{"api.example.com=http-01", "*.example.com=dns-01", " WWW.example.com = dns-01 "},
+			expected: map[string]challenge.Type{
+				"api.example.com": challenge.HTTP01,
+				"*.example.com":   challenge.DNS01,
+				"www.example.com": challenge.DNS01,
+			},
+		},
+		{
+			desc:          "missing separator",
+			values:        []string{"api.example.com"},
+			expectedError: `--challenge-map: invalid value "api.example.com", expected domain=challenge`,
+		},
+		{
+			desc:          "missing challenge",
+			values:        []string{"api.example.com="},
+			expectedError: `--challenge-map: invalid value "api.example.com=", expected domain=challenge`,
+		},
+		{
+			desc:          "challenge not enabled",
+			values:        []string{"api.example.com=tls-alpn-01"},
+			expectedError: `--challenge-map: the challenge "tls-alpn-01" for api.example.com is not enabled (enabled: http-01, dns-01)`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			challengeMap, err := parseChallengeMap(test.values, enabled)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, challengeMap)
+		})
+	}
+}
+
+func Test_parseDNSRoutes(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		values        []string
+		expected      []dnsRoute
+		expectedError string
+	}{
+		{
+			desc:   "valid",
+			values: []string{"*.internal.example.com=rfc2136", " API.example.com = route53 "},
+			expected: []dnsRoute{
+				{pattern: "*.internal.example.com", provider: "rfc2136"},
+				{pattern: "api.example.com", provider: "route53"},
+			},
+		},
+		{
+			desc:          "missing separator",
+			values:        []string{"*.internal.example.com"},
+			expectedError: `--dns.route: invalid value "*.internal.example.com", expected pattern=provider`,
+		},
+		{
+			desc:          "missing provider",
+			values:        []string{"*.internal.example.com="},
+			expectedError: `--dns.route: invalid value "*.internal.example.com=", expected pattern=provider`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			routes, err := parseDNSRoutes(test.values)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, routes)
+		})
+	}
+}
+
+func Test_setupDNSFallbacks(t *testing.T) {
+	primary, err := dns01.NewDNSProviderManual()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc          string
+		fallbacks     []string
+		expectedError string
+	}{
+		{
+			desc: "no fallback",
+		},
+		{
+			desc:      "fallback",
+			fallbacks: []string{"manual"},
+		},
+		{
+			desc:          "unknown provider",
+			fallbacks:     []string{"unknown"},
+			expectedError: "--dns-fallback unknown: unrecognized DNS provider: unknown",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+
+			fallbackFlag := &cli.StringSliceFlag{Name: flgDNSFallback}
+			require.NoError(t, fallbackFlag.Apply(flags))
+
+			for _, name := range test.fallbacks {
+				require.NoError(t, flags.Set(flgDNSFallback, name))
+			}
+
+			ctx := cli.NewContext(cli.NewApp(), flags, nil)
+
+			provider, err := setupDNSFallbacks(ctx, primary)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if len(test.fallbacks) == 0 {
+				assert.Same(t, primary, provider)
+			} else {
+				assert.NotSame(t, primary, provider)
+			}
+		})
+	}
+}
+
+func Test_parseDisabledChallenges(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		values        []string
+		expected      []challenge.Type
+		expectedError string
+	}{
+		{
+			desc:     "valid",
+			values:   []string{"tls-alpn-01", " http-01 ", "tls-alpn-01"},
+			expected: []challenge.Type{challenge.TLSALPN01, challenge.HTTP01},
+		},
+		{
+			desc:     "matching CA",
+			values:   []string{"http-01@ACME.example.com"},
+			expected: []challenge.Type{challenge.HTTP01},
+		},
+		{
+			desc:   "other CA",
+			values: []string{"http-01@acme.example.org"},
+		},
+		{
+			desc:          "unknown challenge",
+			values:        []string{"foo-01"},
+			expectedError: `--disable-challenge: unknown challenge "foo-01" in "foo-01"`,
+		},
+		{
+			desc:          "missing CA host",
+			values:        []string{"dns-01@"},
+			expectedError: `--disable-challenge: invalid value "dns-01@", expected challenge or challenge@ca-host`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			disabled, err := parseDisabledChallenges(test.values, "https://acme.example.com:14000/dir")
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, disabled)
+		})
+	}
+}
+
+func Test_wrapDNSProvider_TXTRecordFinder(t *testing.T) {
+	serverURL := servermock.NewBuilder(
+		func(server *httptest.Server) (string, error) {
+			return server.URL, nil
+		}).
+		Route("GET /v2/domains/example.com/records",
+			servermock.RawStringResponse(`{
+			"domain_records": [
+				{"id": 1111111, "type": "TXT", "name": "_acme-challenge", "data": "found"}
+			],
+			"links": {}
+		}`)).
+		Build(t)
+
+	t.Setenv("DO_AUTH_TOKEN", "secret")
+	t.Setenv("DO_API_URL", serverURL)
+
+	dns01.ClearFqdnCache()
+
+	addr := dnsmock.NewServer().
+		Query("_acme-challenge.example.com. SOA", dnsmock.SOA("example.com.")).
+		Build(t)
+
+	dns01.SetDefaultNameservers([]string{addr.String()})
+
+	t.Cleanup(func() {
+		dns01.SetDefaultNameservers(nil)
+		dns01.ClearFqdnCache()
+	})
+
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+
+	for _, f := range []cli.Flag{
+		&cli.StringFlag{Name: flgPath, Value: t.TempDir()},
+		&cli.DurationFlag{Name: flgDNSCleanUpCheck},
+		&cli.BoolFlag{Name: flgDNSJournal},
+	} {
+		require.NoError(t, f.Apply(flags))
+	}
+
+	require.NoError(t, flags.Set(flgDNSCleanUpCheck, "1m"))
+	require.NoError(t, flags.Set(flgDNSJournal, "true"))
+
+	ctx := cli.NewContext(cli.NewApp(), flags, nil)
+	ctx.Command = &cli.Command{Name: "daemon"}
+
+	// The provider rebuilt when its credential files change.
+	provider, err := newDNSProvider(ctx, "digitalocean")
+	require.NoError(t, err)
+
+	provider, err = wrapDNSProvider(ctx, provider, "digitalocean")
+	require.NoError(t, err)
+
+	finder, ok := provider.(dns01.TXTRecordFinder)
+	require.True(t, ok)
+
+	found, err := finder.HasTXTRecord("_acme-challenge.example.com.", "found")
+	require.NoError(t, err)
+
+	assert.True(t, found)
+
+	found, err = finder.HasTXTRecord("_acme-challenge.example.com.", "other")
+	require.NoError(t, err)
+
+	assert.False(t, found)
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// getSubjectMutator returns the function setting the subject fields of the CSR (--subject),
+// or nil if the flag is not set.
+func getSubjectMutator(ctx *cli.Context) (func(*x509.CertificateRequest), error) {
+	if ctx.String(flgSubject) == "" {
+		return nil, nil
+	}
+
+	subject, err := parseSubject(ctx.String(flgSubject))
+	if err != nil {
+		return nil, fmt.Errorf("--%s: %w", flgSubject, err)
+	}
+
+	return func(template *x509.CertificateRequest) {
+		// Each CSR has its own copy of the subject: the mutator is called for each order.
+		name := cloneName(subject)
+		name.CommonName = template.Subject.CommonName
+
+		template.Subject = name
+	}, nil
+}
+
+// cloneName returns a deep copy of the name.
+func cloneName(name pkix.Name) pkix.Name {
+	name.Country = slices.Clone(name.Country)
+	name.Organization = slices.Clone(name.Organization)
+	name.OrganizationalUnit = slices.Clone(name.OrganizationalUnit)
+	name.Locality = slices.Clone(name.Locality)
+	name.Province = slices.Clone(name.Province)
+	name.StreetAddress = slices.Clone(name.StreetAddress)
+	name.PostalCode = slices.Clone(name.PostalCode)
+	name.Names = slices.Clone(name.Names)
+	name.ExtraNames = slices.Clone(name.ExtraNames)
+
+	return name
+}
+
+// parseSubject parses a subject DN (ex: "O=Acme Corp,OU=Infra,C=DE").
+// The attributes can be repeated, and a comma inside a value must be escaped ("O=Acme\, Inc.").
+// The CommonName is not allowed: it's defined by the domains.
+func parseSubject(value string) (pkix.Name, error) {
+	var name pkix.Name
+
+	for _, rdn := range splitEscaped(value, ',') {
+		key, val, ok := strings.Cut(rdn, "=")
+		if !ok {
+			return pkix.Name{}, fmt.Errorf("invalid attribute %q: expected 'key=value'", rdn)
+		}
+
+		key = strings.ToUpper(strings.TrimSpace(key))
+		val = strings.TrimSpace(val)
+
+		if val == "" {
+			return pkix.Name{}, fmt.Errorf("empty value for the attribute %q", key)
+		}
+
+		switch key {
+		case "C":
+			name.Country = append(name.Country, val)
+		case "O":
+			name.Organization = append(name.Organization, val)
+		case "OU":
+			name.OrganizationalUnit = append(name.OrganizationalUnit, val)
+		case "L":
+			name.Locality = append(name.Locality, val)
+		case "ST":
+			name.Province = append(name.Province, val)
+		case "STREET":
+			name.StreetAddress = append(name.StreetAddress, val)
+		case "POSTALCODE":
+			name.PostalCode = append(name.PostalCode, val)
+		case "SERIALNUMBER":
+			name.SerialNumber = val
+		case "CN":
+			return pkix.Name{}, fmt.Errorf("the attribute %q is not allowed: the common name is defined by the domains", key)
+		default:
+			return pkix.Name{}, fmt.Errorf("unsupported attribute %q", key)
+		}
+	}
+
+	return name, nil
+}
+
+// splitEscaped splits the value around the separator, except when the separator is escaped with a backslash.
+func splitEscaped(value string, sep rune) []string {
+	var (
+		parts   []string
+		current strings.Builder
+		escaped bool
+	)
+
+	for _, r := range value {
+		switch {
+		case escaped:
+			if r != sep && r != '\\' {
+				current.WriteRune('\\')
+			}
+
+			current.WriteRune(r)
+
+			escaped = false
+
+		case r == '\\':
+			escaped = true
+
+		case r == sep:
+			parts = append(parts, current.String())
+			current.Reset()
+
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	if escaped {
+		current.WriteRune('\\')
+	}
+
+	return append(parts, current.String())
+}
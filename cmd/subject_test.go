@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_parseSubject(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected pkix.Name
+	}{
+		{
+			desc:  "simple",
+			value: "O=Acme Corp,OU=Infra,C=DE",
+			expected: pkix.Name{
+				Organization:       []string{"Acme Corp"},
+				OrganizationalUnit: []string{"Infra"},
+				Country:            []string{"DE"},
+			},
+		},
+		{
+			desc:  "spaces and lowercase keys",
+			value: " o = Acme Corp , ou=Infra, ou=Web ",
+			expected: pkix.Name{
+				Organization:       []string{"Acme Corp"},
+				OrganizationalUnit: []string{"Infra", "Web"},
+			},
+		},
+		{
+			desc:  "escaped comma",
+			value: `O=Acme\, Inc.,L=Berlin`,
+			expected: pkix.Name{
+				Organization: []string{"Acme, Inc."},
+				Locality:     []string{"Berlin"},
+			},
+		},
+		{
+			desc:  "all attributes",
+			value: "C=DE,O=Acme,OU=Infra,L=Berlin,ST=Berlin,STREET=Main Street 1,POSTALCODE=10115,SERIALNUMBER=42",
+			expected: pkix.Name{
+				Country:            []string{"DE"},
+				Organization:       []string{"Acme"},
+				OrganizationalUnit: []string{"Infra"},
+				Locality:           []string{"Berlin"},
+				Province:           []string{"Berlin"},
+				StreetAddress:      []string{"Main Street 1"},
+				PostalCode:         []string{"10115"},
+				SerialNumber:       "42",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			name, err := parseSubject(test.value)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, name)
+		})
+	}
+}
+
+func Test_getSubjectMutator(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.String(flgSubject, "O=Acme Corp,OU=Infra,C=DE", "")
+
+	ctx := cli.NewContext(cli.NewApp(), flags, nil)
+
+	mutator, err := getSubjectMutator(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, mutator)
+
+	first := &x509.CertificateRequest{Subject: pkix.Name{CommonName: "example.com"}}
+	mutator(first)
+
+	// The CSR of the first order is modified after the mutation.
+	first.Subject.Organization[0] = "Modified"
+	first.Subject.Country = append(first.Subject.Country, "FR")
+
+	second := &x509.CertificateRequest{Subject: pkix.Name{CommonName: "example.org"}}
+	mutator(second)
+
+	expected := pkix.Name{
+		CommonName:         "example.org",
+		Country:            []string{"DE"},
+		Organization:       []string{"Acme Corp"},
+		OrganizationalUnit: []string{"Infra"},
+	}
+
+	assert.Equal(t, expected, second.Subject)
+	assert.Equal(t, "example.com", first.Subject.CommonName)
+}
+
+func Test_getSubjectMutator_notSet(t *testing.T) {
+	ctx := cli.NewContext(cli.NewApp(), flag.NewFlagSet("test", flag.ContinueOnError), nil)
+
+	mutator, err := getSubjectMutator(ctx)
+	require.NoError(t, err)
+
+	assert.Nil(t, mutator)
+}
+
+func Test_parseSubject_errors(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		value         string
+		expectedError string
+	}{
+		{
+			desc:          "missing value",
+			value:         "O=Acme,OU",
+			expectedError: `invalid attribute "OU": expected 'key=value'`,
+		},
+		{
+			desc:          "empty value",
+			value:         "O=",
+			expectedError: `empty value for the attribute "O"`,
+		},
+		{
+			desc:          "common name",
+			value:         "CN=example.com",
+			expectedError: `the attribute "CN" is not allowed: the common name is defined by the domains`,
+		},
+		{
+			desc:          "unsupported attribute",
+			value:         "UID=42",
+			expectedError: `unsupported attribute "UID"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := parseSubject(test.value)
+			require.EqualError(t, err, test.expectedError)
+		})
+	}
+}
@@ -0,0 +1,312 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/providers/dns"
+	"github.com/go-acme/lego/v4/storage"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	tlsaExt    = ".tlsa"
+	nextKeyExt = ".next.key"
+)
+
+// validateTLSAFlags checks the flags of the TLSA records before any certificate is issued.
+func validateTLSAFlags(ctx *cli.Context) error {
+	if ctx.Bool(flgTLSAPublish) && !ctx.Bool(flgTLSA) {
+		return fmt.Errorf("'%s' requires '%s'", flgTLSAPublish, flgTLSA)
+	}
+
+	if !ctx.Bool(flgTLSA) {
+		return nil
+	}
+
+	_, err := parseTLSAParams(ctx.String(flgTLSAParams))
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Bool(flgTLSAPublish) {
+		return nil
+	}
+
+	_, err = newTLSAProvider(ctx)
+
+	return err
+}
+
+// readStoredChain reads the stored certificate chain of a domain (leaf first), before it's replaced.
+// Returns nil if the TLSA records are not requested or if there is no stored certificate.
+func readStoredChain(ctx *cli.Context, certsStorage *CertificatesStorage, domain string) []*x509.Certificate {
+	if !ctx.Bool(flgTLSA) {
+		return nil
+	}
+
+	certs, err := certsStorage.ReadCertificate(domain, certExt)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		log.Warnf("[%s] Could not read the stored certificate: %v", domain, err)
+		return nil
+	}
+
+	if len(certs) > 1 {
+		return certs
+	}
+
+	issuers, err := certsStorage.ReadCertificate(domain, issuerExt)
+	if errors.Is(err, storage.ErrNotFound) {
+		return certs
+	}
+
+	if err != nil {
+		log.Warnf("[%s] Could not read the stored issuer certificate: %v", domain, err)
+		return certs
+	}
+
+	return append(certs, issuers...)
+}
+
+// handleTLSA writes the TLSA records (--tlsa) of the certificate next to the certificate,
+// and publishes them with the DNS provider (--tlsa.publish).
+// The records of the previous certificate are kept, so the clients with the previous records in cache
+// can still connect during the rollover.
+// When the records depend only on the key (ex: "3 1 1"), the records of the next private key are added:
+// the next key is generated now and used by the next renewal, so its records are published one renewal in advance.
+func handleTLSA(ctx *cli.Context, certsStorage *CertificatesStorage, certRes *certificate.Resource, previous []*x509.Certificate, keyType certcrypto.KeyType) error {
+	if !ctx.Bool(flgTLSA) {
+		return nil
+	}
+
+	opts, err := parseTLSAParams(ctx.String(flgTLSAParams))
+	if err != nil {
+		return err
+	}
+
+	opts.Port = ctx.Int(flgTLSAPort)
+
+	chain, err := certcrypto.ParsePEMBundle(certRes.Certificate)
+	if err != nil {
+		return err
+	}
+
+	if len(chain) == 1 && len(certRes.IssuerCertificate) > 0 {
+		issuers, errP := certcrypto.ParsePEMBundle(certRes.IssuerCertificate)
+		if errP != nil {
+			return errP
+		}
+
+		chain = append(chain, issuers...)
+	}
+
+	records, err := certificate.TLSARecords(chain, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(previous) > 0 {
+		previousRecords, errP := certificate.TLSARecords(previous, opts)
+		if errP != nil {
+			log.Warnf("[%s] Could not compute the TLSA records of the previous certificate: %v", certRes.Domain, errP)
+		}
+
+		for _, record := range previousRecords {
+			if !slices.Contains(records, record) {
+				records = append(records, record)
+			}
+		}
+	}
+
+	if certificate.SupportsNextKeyTLSA(opts) {
+		nextKey, errK := prepareNextKey(certsStorage, certRes.Domain, chain[0], keyType)
+		if errK != nil {
+			return errK
+		}
+
+		signer, ok := nextKey.(crypto.Signer)
+		if !ok {
+			return fmt.Errorf("unsupported next private key type: %T", nextKey)
+		}
+
+		nextRecords, errK := certificate.NextKeyTLSARecords(chain[0], signer.Public(), opts)
+		if errK != nil {
+			return errK
+		}
+
+		for _, record := range nextRecords {
+			if !slices.Contains(records, record) {
+				records = append(records, record)
+			}
+		}
+	}
+
+	var lines []string
+	for _, record := range records {
+		lines = append(lines, record.String())
+	}
+
+	err = certsStorage.WriteFile(certRes.Domain, tlsaExt, []byte(strings.Join(lines, "\n")+"\n"))
+	if err != nil {
+		return fmt.Errorf("write TLSA records: %w", err)
+	}
+
+	log.Infof("[%s] The TLSA records have been written to %s", certRes.Domain, certsStorage.GetFileName(certRes.Domain, tlsaExt))
+
+	if !ctx.Bool(flgTLSAPublish) {
+		return nil
+	}
+
+	return publishTLSA(ctx, certRes.Domain, records)
+}
+
+// prepareNextKey returns the next private key of the certificate, it's generated if needed.
+// A new key is generated when the stored key is already used by the certificate, or when its type is not the requested type.
+func prepareNextKey(certsStorage *CertificatesStorage, domain string, leaf *x509.Certificate, keyType certcrypto.KeyType) (crypto.PrivateKey, error) {
+	nextKey := readNextKey(certsStorage, domain, keyType)
+	if nextKey != nil && certcrypto.MatchPrivateKey(leaf, nextKey) != nil {
+		return nextKey, nil
+	}
+
+	nextKey, err := certcrypto.GeneratePrivateKey(keyType)
+	if err != nil {
+		return nil, fmt.Errorf("generate the next private key: %w", err)
+	}
+
+	err = certsStorage.WriteFile(domain, nextKeyExt, certcrypto.PEMEncode(nextKey))
+	if err != nil {
+		return nil, fmt.Errorf("write the next private key: %w", err)
+	}
+
+	log.Infof("[%s] The next private key has been written to %s", domain, certsStorage.GetFileName(domain, nextKeyExt))
+
+	return nextKey, nil
+}
+
+// readNextKey reads the next private key of the certificate (prepared by handleTLSA).
+// Returns nil if there is no usable next key.
+func readNextKey(certsStorage *CertificatesStorage, domain string, keyType certcrypto.KeyType) crypto.PrivateKey {
+	data, err := certsStorage.ReadFile(domain, nextKeyExt)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		log.Warnf("[%s] Could not read the next private key: %v", domain, err)
+		return nil
+	}
+
+	nextKey, err := certcrypto.ParsePEMPrivateKey(data)
+	if err != nil {
+		log.Warnf("[%s] Could not parse the next private key: %v", domain, err)
+		return nil
+	}
+
+	if !matchKeyType(nextKey, keyType) {
+		log.Warnf("[%s] The next private key is not a %s key, a new key is generated.", domain, keyType)
+		return nil
+	}
+
+	return nextKey
+}
+
+// matchKeyType returns true if the private key has the type keyType.
+func matchKeyType(privateKey crypto.PrivateKey, keyType certcrypto.KeyType) bool {
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return strconv.Itoa(key.N.BitLen()) == string(keyType)
+	case *ecdsa.PrivateKey:
+		return "P"+strings.TrimPrefix(key.Curve.Params().Name, "P-") == string(keyType)
+	case ed25519.PrivateKey:
+		return keyType == certcrypto.ED25519
+	default:
+		return false
+	}
+}
+
+// newTLSAProvider creates the DNS provider (--dns) used to publish the TLSA records.
+func newTLSAProvider(ctx *cli.Context) (dns01.TLSAProvider, error) {
+	if !ctx.IsSet(flgDNS) {
+		return nil, fmt.Errorf("'%s' requires '%s'", flgTLSAPublish, flgDNS)
+	}
+
+	provider, err := dns.NewDNSChallengeProviderByName(ctx.String(flgDNS))
+	if err != nil {
+		return nil, err
+	}
+
+	publisher, ok := provider.(dns01.TLSAProvider)
+	if !ok {
+		return nil, fmt.Errorf("'%s': the DNS provider %s doesn't support the TLSA records", flgTLSAPublish, ctx.String(flgDNS))
+	}
+
+	return publisher, nil
+}
+
+// publishTLSA replaces the TLSA records with the DNS provider.
+func publishTLSA(ctx *cli.Context, domain string, records []certificate.TLSARecord) error {
+	publisher, err := newTLSAProvider(ctx)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+
+	rdata := make(map[string][]string)
+
+	for _, record := range records {
+		if _, exists := rdata[record.Name]; !exists {
+			names = append(names, record.Name)
+		}
+
+		rdata[record.Name] = append(rdata[record.Name], record.RData())
+	}
+
+	for _, name := range names {
+		err = publisher.SetTLSA(name, rdata[name])
+		if err != nil {
+			return err
+		}
+
+		log.Infof("[%s] The TLSA records of %s have been published", domain, name)
+	}
+
+	return nil
+}
+
+// parseTLSAParams parses the value of --tlsa.params ("usage selector matching-type", ex: "3 1 1").
+func parseTLSAParams(value string) (certificate.TLSAOptions, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 3 {
+		return certificate.TLSAOptions{}, fmt.Errorf("--%s: invalid value %q, expected 'usage selector matching-type'", flgTLSAParams, value)
+	}
+
+	var params [3]uint8
+
+	for i, field := range fields {
+		v, err := strconv.ParseUint(field, 10, 8)
+		if err != nil {
+			return certificate.TLSAOptions{}, fmt.Errorf("--%s: invalid value %q: %w", flgTLSAParams, value, err)
+		}
+
+		params[i] = uint8(v)
+	}
+
+	return certificate.TLSAOptions{Usage: params[0], Selector: params[1], MatchingType: params[2]}, nil
+}
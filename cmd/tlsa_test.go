@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"flag"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func Test_parseTLSAParams(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		value         string
+		expected      certificate.TLSAOptions
+		expectedError string
+	}{
+		{
+			desc:     "DANE-EE",
+			value:    "3 1 1",
+			expected: certificate.TLSAOptions{Usage: 3, Selector: 1, MatchingType: 1},
+		},
+		{
+			desc:     "extra spaces",
+			value:    " 2  0 2 ",
+			expected: certificate.TLSAOptions{Usage: 2, Selector: 0, MatchingType: 2},
+		},
+		{
+			desc:          "missing field",
+			value:         "3 1",
+			expectedError: `--tlsa.params: invalid value "3 1", expected 'usage selector matching-type'`,
+		},
+		{
+			desc:          "not a number",
+			value:         "3 1 a",
+			expectedError: `--tlsa.params: invalid value "3 1 a": strconv.ParseUint: parsing "a": invalid syntax`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := parseTLSAParams(test.value)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, opts)
+		})
+	}
+}
+
+func Test_validateTLSAFlags(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		args          []string
+		expectedError string
+	}{
+		{
+			desc: "no TLSA records",
+		},
+		{
+			desc: "TLSA records",
+			args: []string{"--" + flgTLSA},
+		},
+		{
+			desc:          "publish without TLSA records",
+			args:          []string{"--" + flgTLSAPublish},
+			expectedError: "'tlsa.publish' requires 'tlsa'",
+		},
+		{
+			desc:          "invalid parameters",
+			args:          []string{"--" + flgTLSA, "--" + flgTLSAParams, "3 1"},
+			expectedError: `--tlsa.params: invalid value "3 1", expected 'usage selector matching-type'`,
+		},
+		{
+			desc:          "publish without DNS provider",
+			args:          []string{"--" + flgTLSA, "--" + flgTLSAPublish},
+			expectedError: "'tlsa.publish' requires 'dns'",
+		},
+		{
+			desc:          "DNS provider without TLSA support",
+			args:          []string{"--" + flgTLSA, "--" + flgTLSAPublish, "--" + flgDNS, "manual"},
+			expectedError: "'tlsa.publish': the DNS provider manual doesn't support the TLSA records",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.Bool(flgTLSA, false, "")
+			flags.Bool(flgTLSAPublish, false, "")
+			flags.String(flgTLSAParams, "3 1 1", "")
+			flags.String(flgDNS, "", "")
+
+			require.NoError(t, flags.Parse(test.args))
+
+			ctx := cli.NewContext(cli.NewApp(), flags, nil)
+
+			err := validateTLSAFlags(ctx)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_prepareNextKey(t *testing.T) {
+	certsStorage := newTestCertificatesStorage(t)
+
+	currentKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	require.NoError(t, err)
+
+	current := createTestCertificateWithKey(t, currentKey)
+
+	nextKey, err := prepareNextKey(certsStorage, "example.com", current, certcrypto.EC256)
+	require.NoError(t, err)
+
+	assert.True(t, matchKeyType(nextKey, certcrypto.EC256))
+
+	// The stored next key is reused until it's used by a certificate.
+	again, err := prepareNextKey(certsStorage, "example.com", current, certcrypto.EC256)
+	require.NoError(t, err)
+
+	assert.Equal(t, nextKey, again)
+
+	// The renewal uses the next key.
+	assert.Equal(t, nextKey, readNextKey(certsStorage, "example.com", certcrypto.EC256))
+
+	renewed := createTestCertificateWithKey(t, nextKey)
+
+	newNextKey, err := prepareNextKey(certsStorage, "example.com", renewed, certcrypto.EC256)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, nextKey, newNextKey)
+
+	// A key of another type is replaced.
+	assert.Nil(t, readNextKey(certsStorage, "example.com", certcrypto.EC384))
+}
+
+func Test_matchKeyType(t *testing.T) {
+	for _, keyType := range []certcrypto.KeyType{certcrypto.EC256, certcrypto.EC384, certcrypto.RSA2048, certcrypto.ED25519} {
+		t.Run(string(keyType), func(t *testing.T) {
+			t.Parallel()
+
+			privateKey, err := certcrypto.GeneratePrivateKey(keyType)
+			require.NoError(t, err)
+
+			assert.True(t, matchKeyType(privateKey, keyType))
+			assert.False(t, matchKeyType(privateKey, certcrypto.RSA4096))
+		})
+	}
+}
+
+func createTestCertificateWithKey(t *testing.T, privateKey crypto.PrivateKey) *x509.Certificate {
+	t.Helper()
+
+	signer, ok := privateKey.(crypto.Signer)
+	require.True(t, ok)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		DNSNames:     []string{"example.com"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, signer.Public(), signer)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/urfave/cli/v2"
+)
+
+// verifyCertificate verifies the issued certificate (--verify) before saving it.
+// The domains dropped by --allow-partial are not expected to be covered.
+func verifyCertificate(ctx *cli.Context, cert *certificate.Resource, domains []string) error {
+	if !ctx.Bool(flgVerify) {
+		return nil
+	}
+
+	roots, err := getVerifyRoots(ctx)
+	if err != nil {
+		return err
+	}
+
+	domains = slices.DeleteFunc(slices.Clone(domains), func(domain string) bool {
+		return slices.Contains(cert.DroppedDomains, domain)
+	})
+
+	return certificate.Verify(cert, certificate.VerifyOptions{Domains: domains, Roots: roots})
+}
+
+func getVerifyRoots(ctx *cli.Context) (*x509.CertPool, error) {
+	var pool *x509.CertPool
+
+	if ctx.IsSet(flgVerifyRoots) {
+		data, err := os.ReadFile(ctx.String(flgVerifyRoots))
+		if err != nil {
+			return nil, fmt.Errorf("read verification roots: %w", err)
+		}
+
+		roots, err := certcrypto.ParsePEMBundle(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse verification roots: %w", err)
+		}
+
+		pool = x509.NewCertPool()
+
+		for _, root := range roots {
+			pool.AddCert(root)
+		}
+	} else {
+		var err error
+
+		pool, err = x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+	}
+
+	if ctx.Bool(flgAcceptStagingRoots) {
+		err := lego.AddStagingRoots(pool)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pool, nil
+}
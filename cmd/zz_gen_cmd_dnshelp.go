@@ -266,6 +266,7 @@ func displayDNSHelp(w io.Writer, name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "ALICLOUD_ENDPOINT":	API endpoint override, ex: 'alidns.ap-southeast-1.aliyuncs.com' (Default: the endpoint of the region)`)
 		ew.writeln(`	- "ALICLOUD_HTTP_TIMEOUT":	API request timeout in seconds (Default: 10)`)
 		ew.writeln(`	- "ALICLOUD_LINE":	Line (Default: default)`)
 		ew.writeln(`	- "ALICLOUD_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 2)`)
@@ -377,7 +378,6 @@ func displayDNSHelp(w io.Writer, name string) error {
 		ew.writeln(`	- "ARTFILES_HTTP_TIMEOUT":	API request timeout in seconds (Default: 30)`)
 		ew.writeln(`	- "ARTFILES_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 2)`)
 		ew.writeln(`	- "ARTFILES_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation in seconds (Default: 360)`)
-		ew.writeln(`	- "ARTFILES_TTL":	The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)`)
 
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/artfiles`)
@@ -531,6 +531,7 @@ func displayDNSHelp(w io.Writer, name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "AZURE_AUTHORITY_HOST":	Microsoft Entra ID authority host override, ex: 'https://login.microsoftonline.us/' (Default: the authority host of the environment)`)
 		ew.writeln(`	- "AZURE_AUTH_METHOD":	Specify which authentication method to use`)
 		ew.writeln(`	- "AZURE_AUTH_MSI_TIMEOUT":	Managed Identity timeout duration`)
 		ew.writeln(`	- "AZURE_ENVIRONMENT":	Azure environment, one of: public, usgovernment, and china`)
@@ -538,6 +539,8 @@ func displayDNSHelp(w io.Writer, name string) error {
 		ew.writeln(`	- "AZURE_PRIVATE_ZONE":	Set to true to use Azure Private DNS Zones and not public`)
 		ew.writeln(`	- "AZURE_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation in seconds (Default: 120)`)
 		ew.writeln(`	- "AZURE_RESOURCE_GROUP":	DNS zone resource group`)
+		ew.writeln(`	- "AZURE_RESOURCE_MANAGER_AUDIENCE":	Azure Resource Manager audience override (Default: the audience of the environment)`)
+		ew.writeln(`	- "AZURE_RESOURCE_MANAGER_ENDPOINT":	Azure Resource Manager endpoint override, ex: an Azure Stack Hub endpoint (Default: the endpoint of the environment)`)
 		ew.writeln(`	- "AZURE_SERVICEDISCOVERY_FILTER":	Advanced ServiceDiscovery filter using Kusto query condition`)
 		ew.writeln(`	- "AZURE_SUBSCRIPTION_ID":	DNS zone subscription ID`)
 		ew.writeln(`	- "AZURE_TTL":	The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)`)
@@ -661,9 +664,9 @@ func displayDNSHelp(w io.Writer, name string) error {
 		ew.writeln(`Credentials:`)
 		ew.writeln(`	- "BLUECATV2_CONFIG_NAME":	Configuration name`)
 		ew.writeln(`	- "BLUECATV2_PASSWORD":	API password`)
+		ew.writeln(`	- "BLUECATV2_SERVER_URL":	The server URL: it should have a scheme, hostname, and port (if required) of the authoritative Bluecat BAM serve`)
 		ew.writeln(`	- "BLUECATV2_USERNAME":	API username`)
 		ew.writeln(`	- "BLUECATV2_VIEW_NAME":	DNS View Name`)
-		ew.writeln(`	- "BLUECAT_SERVER_URL":	The server URL: it should have a scheme, hostname, and port (if required) of the authoritative Bluecat BAM serve`)
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
@@ -975,6 +978,7 @@ func displayDNSHelp(w io.Writer, name string) error {
 
 		ew.writeln(`Additional Configuration:`)
 		ew.writeln(`	- "CONSTELLIX_HTTP_TIMEOUT":	API request timeout in seconds (Default: 30)`)
+		ew.writeln(`	- "CONSTELLIX_MAX_RETRIES":	Maximum number of retries when the API rejects the requests (HTTP 429 and 5xx) (Default: 5)`)
 		ew.writeln(`	- "CONSTELLIX_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 10)`)
 		ew.writeln(`	- "CONSTELLIX_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation in seconds (Default: 60)`)
 		ew.writeln(`	- "CONSTELLIX_TTL":	The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)`)
@@ -1101,9 +1105,12 @@ func displayDNSHelp(w io.Writer, name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "DESEC_BASE_URL":	The URL of the API of a self-hosted desec-stack instance (Default: https://desec.io/api/v1/)`)
 		ew.writeln(`	- "DESEC_HTTP_TIMEOUT":	API request timeout in seconds (Default: 30)`)
+		ew.writeln(`	- "DESEC_MAX_RETRIES":	Maximum number of retries of the API requests (throttling, server errors) (Default: 5)`)
 		ew.writeln(`	- "DESEC_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 4)`)
 		ew.writeln(`	- "DESEC_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation in seconds (Default: 120)`)
+		ew.writeln(`	- "DESEC_TOKEN_HEADER":	The name of the header used to send the token, the value of the header is the raw token (Default: Authorization with 'Token <token>')`)
 		ew.writeln(`	- "DESEC_TTL":	The TTL of the TXT record used for the DNS challenge in seconds (Default: 3600)`)
 
 		ew.writeln()
@@ -1674,6 +1681,7 @@ func displayDNSHelp(w io.Writer, name string) error {
 		ew.writeln(`	- "GANDIV5_HTTP_TIMEOUT":	API request timeout in seconds (Default: 10)`)
 		ew.writeln(`	- "GANDIV5_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 20)`)
 		ew.writeln(`	- "GANDIV5_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation in seconds (Default: 1200)`)
+		ew.writeln(`	- "GANDIV5_SHARING_ID":	Organization ID (sharing_id) used to scope the requests, required when the token has access to several organizations`)
 		ew.writeln(`	- "GANDIV5_TTL":	The TTL of the TXT record used for the DNS challenge in seconds (Default: 300)`)
 
 		ew.writeln()
@@ -1781,8 +1789,10 @@ func displayDNSHelp(w io.Writer, name string) error {
 
 		ew.writeln(`Additional Configuration:`)
 		ew.writeln(`	- "GODADDY_HTTP_TIMEOUT":	API request timeout in seconds (Default: 30)`)
+		ew.writeln(`	- "GODADDY_OTE":	Use the OTE (Operational Test Environment) endpoint instead of the production endpoint (boolean)`)
 		ew.writeln(`	- "GODADDY_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 2)`)
 		ew.writeln(`	- "GODADDY_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation in seconds (Default: 120)`)
+		ew.writeln(`	- "GODADDY_SHOPPER_ID":	Customer (shopper) ID, used by the resellers to manage the zones of a customer with delegated access`)
 		ew.writeln(`	- "GODADDY_TTL":	The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)`)
 
 		ew.writeln()
@@ -1919,7 +1929,6 @@ func displayDNSHelp(w io.Writer, name string) error {
 
 		ew.writeln(`Credentials:`)
 		ew.writeln(`	- "HOSTTECH_API_KEY":	API login`)
-		ew.writeln(`	- "HOSTTECH_PASSWORD":	API password`)
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
@@ -1988,6 +1997,7 @@ func displayDNSHelp(w io.Writer, name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "HUAWEICLOUD_ENDPOINT":	API endpoint override, ex: the endpoint of a sovereign cloud (Default: the endpoint of the region)`)
 		ew.writeln(`	- "HUAWEICLOUD_HTTP_TIMEOUT":	API request timeout in seconds (Default: 30)`)
 		ew.writeln(`	- "HUAWEICLOUD_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 2)`)
 		ew.writeln(`	- "HUAWEICLOUD_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation in seconds (Default: 60)`)
@@ -2003,15 +2013,16 @@ func displayDNSHelp(w io.Writer, name string) error {
 		ew.writeln(`Since:	'v4.3.0'`)
 		ew.writeln()
 
-		ew.writeln(`Credentials:`)
-		ew.writeln(`	- "HURRICANE_TOKENS":	TXT record names and tokens`)
-		ew.writeln()
-
 		ew.writeln(`Additional Configuration:`)
 		ew.writeln(`	- "HURRICANE_HTTP_TIMEOUT":	API request timeout in seconds (Default: 30)`)
+		ew.writeln(`	- "HURRICANE_PASSWORD":	Password of the web interface, used for the records without token`)
 		ew.writeln(`	- "HURRICANE_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 2)`)
 		ew.writeln(`	- "HURRICANE_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation (Default: 300)`)
 		ew.writeln(`	- "HURRICANE_SEQUENCE_INTERVAL":	Time between sequential requests in seconds (Default: 60)`)
+		ew.writeln(`	- "HURRICANE_TOKENS":	TXT record names and tokens`)
+		ew.writeln(`	- "HURRICANE_TOKENS_PATH":	Path to a file with the TXT record names and tokens (one pair per line)`)
+		ew.writeln(`	- "HURRICANE_TTL":	The TTL of the TXT records created through the web interface (Default: 300)`)
+		ew.writeln(`	- "HURRICANE_USERNAME":	Username of the web interface, used for the records without token`)
 
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/hurricane`)
@@ -2413,6 +2424,7 @@ func displayDNSHelp(w io.Writer, name string) error {
 
 		ew.writeln(`Additional Configuration:`)
 		ew.writeln(`	- "LIARA_HTTP_TIMEOUT":	API request timeout in seconds (Default: 30)`)
+		ew.writeln(`	- "LIARA_MAX_RETRIES":	Maximum number of retries when the API rejects the requests (HTTP 429 and 5xx) (Default: 5)`)
 		ew.writeln(`	- "LIARA_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 2)`)
 		ew.writeln(`	- "LIARA_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation in seconds (Default: 60)`)
 		ew.writeln(`	- "LIARA_TEAM_ID":	The team ID to access services in a team`)
@@ -3057,6 +3069,7 @@ func displayDNSHelp(w io.Writer, name string) error {
 
 		ew.writeln(`Additional Configuration:`)
 		ew.writeln(`	- "OCTENIUM_HTTP_TIMEOUT":	API request timeout in seconds (Default: 30)`)
+		ew.writeln(`	- "OCTENIUM_MAX_RETRIES":	Maximum number of retries when the API rejects the requests (HTTP 429 and 5xx) (Default: 5)`)
 		ew.writeln(`	- "OCTENIUM_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 2)`)
 		ew.writeln(`	- "OCTENIUM_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation in seconds (Default: 60)`)
 		ew.writeln(`	- "OCTENIUM_TTL":	The TTL of the TXT record used for the DNS challenge in seconds (Default: 120)`)
@@ -3707,6 +3720,7 @@ func displayDNSHelp(w io.Writer, name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "TENCENTCLOUD_ENDPOINT":	API endpoint override, ex: 'dnspod.intl.tencentcloudapi.com' (Default: dnspod.tencentcloudapi.com)`)
 		ew.writeln(`	- "TENCENTCLOUD_HTTP_TIMEOUT":	API request timeout in seconds (Default: 30)`)
 		ew.writeln(`	- "TENCENTCLOUD_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 2)`)
 		ew.writeln(`	- "TENCENTCLOUD_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation in seconds (Default: 60)`)
@@ -4093,7 +4107,6 @@ func displayDNSHelp(w io.Writer, name string) error {
 		ew.writeln(`	- "WEBSUPPORT_HTTP_TIMEOUT":	API request timeout in seconds (Default: 30)`)
 		ew.writeln(`	- "WEBSUPPORT_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 2)`)
 		ew.writeln(`	- "WEBSUPPORT_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation in seconds (Default: 60)`)
-		ew.writeln(`	- "WEBSUPPORT_SEQUENCE_INTERVAL":	Time between sequential requests in seconds (Default: 60)`)
 		ew.writeln(`	- "WEBSUPPORT_TTL":	The TTL of the TXT record used for the DNS challenge in seconds (Default: 600)`)
 
 		ew.writeln()
@@ -4195,6 +4208,7 @@ func displayDNSHelp(w io.Writer, name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "YANDEX_CLOUD_ENDPOINT":	API endpoint override (Default: api.cloud.yandex.net:443)`)
 		ew.writeln(`	- "YANDEX_CLOUD_POLLING_INTERVAL":	Time between DNS propagation check in seconds (Default: 2)`)
 		ew.writeln(`	- "YANDEX_CLOUD_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation in seconds (Default: 60)`)
 		ew.writeln(`	- "YANDEX_CLOUD_TTL":	The TTL of the TXT record used for the DNS challenge in seconds (Default: 60)`)
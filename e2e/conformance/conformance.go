@@ -0,0 +1,286 @@
+// Package conformance exercises the ACME client against an ACME server (ex: Pebble with the strict settings):
+// the accounts, the orders, the challenges, the renewals, the revocations, and the errors.
+package conformance
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/http01"
+	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Config the configuration of a conformance run.
+//
+// The certificate of the ACME server must be trusted:
+// the client uses the LEGO_CA_CERTIFICATES environment variable, like the lego clients.
+type Config struct {
+	// CADirURL the URL of the directory of the ACME server.
+	CADirURL string
+
+	// HTTPPort the port of the HTTP-01 challenge server (the port used by the ACME server for the validations).
+	HTTPPort string
+
+	// TLSPort the port of the TLS-ALPN-01 challenge server (the port used by the ACME server for the validations).
+	TLSPort string
+
+	// Domains the domains of the certificates (at least 2): the ACME server must resolve them to the host running the checks.
+	Domains []string
+}
+
+// Run runs the conformance checks, as subtests of t.
+// The checks are sequential: the nonces, the polling, and the errors must be handled without the help of the other checks.
+func Run(t *testing.T, config Config) {
+	t.Helper()
+
+	require.NotEmpty(t, config.CADirURL, "the URL of the directory is required")
+	require.GreaterOrEqual(t, len(config.Domains), 2, "at least 2 domains are required")
+
+	stats := &requestStats{}
+
+	t.Run("account", func(t *testing.T) {
+		client, user := newClient(t, config, stats)
+
+		reg := register(t, client, user)
+
+		queried, err := client.Registration.QueryRegistrationContext(t.Context())
+		require.NoError(t, err)
+
+		assert.Equal(t, reg.URI, queried.URI)
+		assert.Equal(t, acme.StatusValid, queried.Body.Status)
+
+		user.email = "conformance@example.com"
+
+		updated, err := client.Registration.UpdateRegistrationContext(t.Context(), registration.RegisterOptions{TermsOfServiceAgreed: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, reg.URI, updated.URI)
+		assert.Equal(t, []string{"mailto:conformance@example.com"}, updated.Body.Contact)
+	})
+
+	t.Run("obtain HTTP-01", func(t *testing.T) {
+		client, user := newClient(t, config, stats)
+
+		err := client.Challenge.SetHTTP01Provider(http01.NewProviderServer("", config.HTTPPort))
+		require.NoError(t, err)
+
+		register(t, client, user)
+
+		obtain(t, client, config.Domains)
+	})
+
+	t.Run("obtain TLS-ALPN-01", func(t *testing.T) {
+		client, user := newClient(t, config, stats)
+
+		err := client.Challenge.SetTLSALPN01Provider(tlsalpn01.NewProviderServer("", config.TLSPort))
+		require.NoError(t, err)
+
+		register(t, client, user)
+
+		obtain(t, client, config.Domains[:1])
+	})
+
+	t.Run("renew and revoke", func(t *testing.T) {
+		client, user := newClient(t, config, stats)
+
+		err := client.Challenge.SetHTTP01Provider(http01.NewProviderServer("", config.HTTPPort))
+		require.NoError(t, err)
+
+		register(t, client, user)
+
+		resource := obtain(t, client, config.Domains[:1])
+
+		cert, err := certcrypto.ParsePEMCertificate(resource.Certificate)
+		require.NoError(t, err)
+
+		renewalInfo, err := client.Certificate.GetRenewalInfoContext(t.Context(), certificate.RenewalInfoRequest{Cert: cert})
+		if err != nil {
+			// ARI is optional.
+			t.Logf("renewal info: %v", err)
+		} else {
+			assert.True(t, renewalInfo.SuggestedWindow.Start.Before(renewalInfo.SuggestedWindow.End))
+		}
+
+		renewed, err := client.Certificate.RenewWithOptionsContext(t.Context(), *resource, &certificate.RenewOptions{
+			Bundle:              true,
+			ReplacesCertificate: renewalInfo != nil,
+		})
+		require.NoError(t, err)
+
+		assert.NotEqual(t, resource.CertURL, renewed.CertURL)
+
+		err = client.Certificate.RevokeContext(t.Context(), renewed.Certificate)
+		require.NoError(t, err)
+
+		// A revoked certificate cannot be revoked again.
+		err = client.Certificate.RevokeContext(t.Context(), renewed.Certificate)
+		requireProblem(t, err, "urn:ietf:params:acme:error:alreadyRevoked")
+	})
+
+	t.Run("invalid challenge", func(t *testing.T) {
+		client, user := newClient(t, config, stats)
+
+		// The challenge is not presented: the validation fails.
+		err := client.Challenge.SetHTTP01Provider(&noopProvider{})
+		require.NoError(t, err)
+
+		register(t, client, user)
+
+		_, err = client.Certificate.ObtainContext(t.Context(), certificate.ObtainRequest{Domains: config.Domains[:1], Bundle: true})
+		requireProblem(t, err, "")
+	})
+
+	t.Run("unknown account", func(t *testing.T) {
+		client, _ := newClient(t, config, stats)
+
+		_, err := client.Registration.ResolveAccountByKeyContext(t.Context())
+		requireProblem(t, err, "urn:ietf:params:acme:error:accountDoesNotExist")
+	})
+
+	t.Logf("requests: %d, rejected nonces: %d", stats.requests.Load(), stats.badNonces.Load())
+}
+
+func newClient(t *testing.T, config Config, stats *requestStats) (*lego.Client, *user) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	u := &user{privateKey: privateKey}
+
+	legoConfig := lego.NewConfig(u)
+	legoConfig.CADirURL = config.CADirURL
+
+	legoConfig.HTTPClient.Transport = &statsTransport{next: legoConfig.HTTPClient.Transport, stats: stats}
+
+	client, err := lego.NewClient(legoConfig)
+	require.NoError(t, err)
+
+	return client, u
+}
+
+func register(t *testing.T, client *lego.Client, u *user) *registration.Resource {
+	t.Helper()
+
+	reg, err := client.Registration.RegisterContext(t.Context(), registration.RegisterOptions{TermsOfServiceAgreed: true})
+	require.NoError(t, err)
+
+	u.registration = reg
+
+	return reg
+}
+
+func obtain(t *testing.T, client *lego.Client, domains []string) *certificate.Resource {
+	t.Helper()
+
+	resource, err := client.Certificate.ObtainContext(t.Context(), certificate.ObtainRequest{Domains: domains, Bundle: true})
+	require.NoError(t, err)
+
+	require.NotNil(t, resource)
+	assert.Equal(t, domains[0], resource.Domain)
+	assert.NotEmpty(t, resource.CertURL)
+	assert.NotEmpty(t, resource.IssuerCertificate)
+
+	cert, err := certcrypto.ParsePEMCertificate(resource.Certificate)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, domains, certcrypto.ExtractDomains(cert))
+
+	return resource
+}
+
+// requireProblem checks that the error is a problem document of the ACME server, of the type (if not empty).
+func requireProblem(t *testing.T, err error, typ string) {
+	t.Helper()
+
+	require.Error(t, err)
+
+	var problem *acme.ProblemDetails
+
+	require.ErrorAs(t, err, &problem)
+
+	if typ == "" {
+		assert.True(t, strings.HasPrefix(problem.Type, "urn:ietf:params:acme:error:"), problem.Type)
+		return
+	}
+
+	assert.Equal(t, typ, problem.Type)
+}
+
+// requestStats the numbers of requests and of rejected nonces of all the clients.
+type requestStats struct {
+	requests  atomic.Int64
+	badNonces atomic.Int64
+}
+
+// statsTransport counts the requests and the rejected nonces.
+type statsTransport struct {
+	next  http.RoundTripper
+	stats *requestStats
+}
+
+func (s *statsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s.stats.requests.Add(1)
+
+	resp, err := s.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusBadRequest || resp.Header.Get("Content-Type") != "application/problem+json" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	var problem acme.ProblemDetails
+	if json.Unmarshal(body, &problem) == nil && problem.Type == acme.BadNonceErr {
+		s.stats.badNonces.Add(1)
+	}
+
+	return resp, nil
+}
+
+type user struct {
+	email        string
+	privateKey   crypto.PrivateKey
+	registration *registration.Resource
+}
+
+func (u *user) GetEmail() string                        { return u.email }
+func (u *user) GetRegistration() *registration.Resource { return u.registration }
+func (u *user) GetPrivateKey() crypto.PrivateKey        { return u.privateKey }
+
+// noopProvider a provider that doesn't present the challenges.
+type noopProvider struct{}
+
+func (*noopProvider) Present(string, string, string) error { return nil }
+
+func (*noopProvider) CleanUp(string, string, string) error { return nil }
+
+var _ challenge.Provider = (*noopProvider)(nil)
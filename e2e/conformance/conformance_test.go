@@ -0,0 +1,35 @@
+package conformance
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-acme/lego/v4/e2e/loader"
+)
+
+// The strictest settings supported by the retries of the client:
+// the validations are delayed randomly (up to PEBBLE_VA_SLEEPTIME seconds), half of the nonces are rejected,
+// and the authorizations are never reused.
+var load = loader.EnvLoader{
+	PebbleOptions: &loader.CmdOption{
+		HealthCheckURL: "https://localhost:16000/dir",
+		Args:           []string{"-strict", "-config", "fixtures/pebble-config-conformance.json"},
+		Env:            []string{"PEBBLE_VA_SLEEPTIME=10", "PEBBLE_WFE_NONCEREJECT=50", "PEBBLE_AUTHZREUSE=0"},
+		Dir:            "../",
+	},
+}
+
+func TestMain(m *testing.M) {
+	os.Exit(load.MainTest(m))
+}
+
+func TestConformance(t *testing.T) {
+	t.Setenv("LEGO_CA_CERTIFICATES", "../fixtures/certs/pebble.minica.pem")
+
+	Run(t, Config{
+		CADirURL: load.PebbleOptions.HealthCheckURL,
+		HTTPPort: "5006",
+		TLSPort:  "5005",
+		Domains:  []string{"acme.localhost", "conformance.lego.localhost"},
+	})
+}
@@ -60,7 +60,7 @@ func TestChallengeDNS_Run(t *testing.T) {
 	err := load.RunLego(
 		"--accept-tos",
 		"--dns", "exec",
-		"--dns.resolvers", ":8053",
+		"--dns.resolvers", "127.0.0.1:8053",
 		"--dns.disable-cp",
 		"-s", "https://localhost:15000/dir",
 		"-d", testDomain2,
@@ -96,7 +96,7 @@ func TestChallengeDNS_Client_Obtain(t *testing.T) {
 	require.NoError(t, err)
 
 	err = client.Challenge.SetDNS01Provider(provider,
-		dns01.AddRecursiveNameservers([]string{":8053"}),
+		dns01.AddRecursiveNameservers([]string{"127.0.0.1:8053"}),
 		dns01.DisableAuthoritativeNssPropagationRequirement())
 	require.NoError(t, err)
 
@@ -128,6 +128,63 @@ func TestChallengeDNS_Client_Obtain(t *testing.T) {
 	assert.Empty(t, resource.CSR)
 }
 
+func TestChallengeDNS_Client_ObtainContext(t *testing.T) {
+	err := os.Setenv("LEGO_CA_CERTIFICATES", "../fixtures/certs/pebble.minica.pem")
+	require.NoError(t, err)
+
+	defer func() { _ = os.Unsetenv("LEGO_CA_CERTIFICATES") }()
+
+	err = os.Setenv("EXEC_PATH", "../fixtures/update-dns.sh")
+	require.NoError(t, err)
+
+	defer func() { _ = os.Unsetenv("EXEC_PATH") }()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	user := &fakeUser{privateKey: privateKey}
+	config := lego.NewConfig(user)
+	config.CADirURL = "https://localhost:15000/dir"
+
+	client, err := lego.NewClient(config)
+	require.NoError(t, err)
+
+	provider, err := dns.NewDNSChallengeProviderByName("exec")
+	require.NoError(t, err)
+
+	err = client.Challenge.SetDNS01Provider(provider,
+		dns01.AddRecursiveNameservers([]string{"127.0.0.1:8053"}),
+		dns01.DisableAuthoritativeNssPropagationRequirement())
+	require.NoError(t, err)
+
+	reg, err := client.Registration.RegisterContext(t.Context(), registration.RegisterOptions{TermsOfServiceAgreed: true})
+	require.NoError(t, err)
+
+	user.registration = reg
+
+	domains := []string{testDomain2, testDomain1}
+
+	// https://github.com/letsencrypt/pebble/issues/285
+	privateKeyCSR, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	request := certificate.ObtainRequest{
+		Domains:    domains,
+		Bundle:     true,
+		PrivateKey: privateKeyCSR,
+	}
+	resource, err := client.Certificate.ObtainContext(t.Context(), request)
+	require.NoError(t, err)
+
+	require.NotNil(t, resource)
+	assert.Equal(t, "*.xn--lgo-bma.localhost", resource.Domain)
+	assert.Regexp(t, `https://localhost:15000/certZ/[\w\d]{14,}`, resource.CertURL)
+	assert.Regexp(t, `https://localhost:15000/certZ/[\w\d]{14,}`, resource.CertStableURL)
+	assert.NotEmpty(t, resource.Certificate)
+	assert.NotEmpty(t, resource.IssuerCertificate)
+	assert.Empty(t, resource.CSR)
+}
+
 func TestChallengeDNS_Client_Obtain_profile(t *testing.T) {
 	err := os.Setenv("LEGO_CA_CERTIFICATES", "../fixtures/certs/pebble.minica.pem")
 	require.NoError(t, err)
@@ -153,7 +210,7 @@ func TestChallengeDNS_Client_Obtain_profile(t *testing.T) {
 	require.NoError(t, err)
 
 	err = client.Challenge.SetDNS01Provider(provider,
-		dns01.AddRecursiveNameservers([]string{":8053"}),
+		dns01.AddRecursiveNameservers([]string{"127.0.0.1:8053"}),
 		dns01.DisableAuthoritativeNssPropagationRequirement())
 	require.NoError(t, err)
 
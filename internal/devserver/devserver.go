@@ -0,0 +1,292 @@
+// Package devserver implements a challenge test server for the local development (offline):
+// a DNS server answering the DNS-01 challenge records, an HTTP server answering the HTTP-01 challenges,
+// and a management API to set the challenges.
+//
+// The management API is compatible with pebble-challtestsrv (`/set-txt`, `/clear-txt`, `/add-http01`, `/del-http01`, etc.),
+// and with the httpreq DNS provider (`/present`, `/cleanup`).
+package devserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/miekg/dns"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+// Config the configuration of the server.
+type Config struct {
+	// DNSAddress the address of the DNS server (UDP and TCP), ex: ":8053".
+	DNSAddress string
+
+	// HTTPAddress the address of the HTTP-01 challenge server, ex: ":5002". Empty to disable it.
+	HTTPAddress string
+
+	// ManagementAddress the address of the management API, ex: ":8055".
+	ManagementAddress string
+
+	// DefaultIPv4 the IPv4 address returned for all the A queries. Empty to disable the A records.
+	DefaultIPv4 string
+
+	// DefaultIPv6 the IPv6 address returned for all the AAAA queries. Empty to disable the AAAA records.
+	DefaultIPv6 string
+}
+
+// Server a challenge test server.
+type Server struct {
+	config Config
+
+	mu          sync.RWMutex
+	txtRecords  map[string][]string
+	http01      map[string]string
+	defaultIPv4 net.IP
+	defaultIPv6 net.IP
+}
+
+// New creates a new Server.
+func New(config Config) (*Server, error) {
+	s := &Server{
+		config:     config,
+		txtRecords: make(map[string][]string),
+		http01:     make(map[string]string),
+	}
+
+	if config.DefaultIPv4 != "" {
+		if err := s.SetDefaultIPv4(config.DefaultIPv4); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.DefaultIPv6 != "" {
+		if err := s.SetDefaultIPv6(config.DefaultIPv6); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Start starts the servers, and blocks until the cancellation of the context.
+func (s *Server) Start(ctx context.Context) error {
+	var servers []func(ctx context.Context) error
+
+	for _, network := range []string{"udp", "tcp"} {
+		started := make(chan struct{})
+
+		server := &dns.Server{
+			Addr:              s.config.DNSAddress,
+			Net:               network,
+			Handler:           dns.HandlerFunc(s.serveDNS),
+			NotifyStartedFunc: func() { close(started) },
+		}
+
+		// The DNS server can only be shut down after its start.
+		shutdown := func(ctx context.Context) error {
+			select {
+			case <-started:
+				return server.ShutdownContext(ctx)
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		servers = append(servers, func(ctx context.Context) error {
+			log.Infof("devserver: DNS server listening on %s (%s)", s.config.DNSAddress, network)
+
+			return runServer(ctx, server.ListenAndServe, shutdown)
+		})
+	}
+
+	if s.config.HTTPAddress != "" {
+		server := &http.Server{Addr: s.config.HTTPAddress, Handler: s.http01Handler(), ReadHeaderTimeout: 5 * time.Second}
+
+		servers = append(servers, func(ctx context.Context) error {
+			log.Infof("devserver: HTTP-01 challenge server listening on %s", s.config.HTTPAddress)
+
+			return runServer(ctx, ignoreServerClosed(server.ListenAndServe), server.Shutdown)
+		})
+	}
+
+	server := &http.Server{Addr: s.config.ManagementAddress, Handler: s.managementHandler(), ReadHeaderTimeout: 5 * time.Second}
+
+	servers = append(servers, func(ctx context.Context) error {
+		log.Infof("devserver: management API listening on %s", s.config.ManagementAddress)
+
+		return runServer(ctx, ignoreServerClosed(server.ListenAndServe), server.Shutdown)
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, len(servers))
+
+	for _, run := range servers {
+		go func() {
+			err := run(ctx)
+
+			// Stops all the servers when a server fails.
+			cancel()
+
+			errs <- err
+		}()
+	}
+
+	var errAll error
+
+	for range servers {
+		errAll = errors.Join(errAll, <-errs)
+	}
+
+	return errAll
+}
+
+// SetTXT adds a TXT record.
+func (s *Server) SetTXT(fqdn, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := normalizeFQDN(fqdn)
+
+	s.txtRecords[key] = append(s.txtRecords[key], value)
+}
+
+// ClearTXT removes all the TXT records of the FQDN.
+func (s *Server) ClearTXT(fqdn string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.txtRecords, normalizeFQDN(fqdn))
+}
+
+// DeleteTXT removes a TXT record.
+func (s *Server) DeleteTXT(fqdn, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := normalizeFQDN(fqdn)
+
+	var values []string
+
+	for _, v := range s.txtRecords[key] {
+		if v != value {
+			values = append(values, v)
+		}
+	}
+
+	if len(values) == 0 {
+		delete(s.txtRecords, key)
+		return
+	}
+
+	s.txtRecords[key] = values
+}
+
+// AddHTTP01 adds the response of an HTTP-01 challenge.
+func (s *Server) AddHTTP01(token, content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.http01[token] = content
+}
+
+// DeleteHTTP01 removes the response of an HTTP-01 challenge.
+func (s *Server) DeleteHTTP01(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.http01, token)
+}
+
+// SetDefaultIPv4 sets the IPv4 address returned for all the A queries.
+func (s *Server) SetDefaultIPv4(value string) error {
+	ip := net.ParseIP(value)
+	if ip == nil || ip.To4() == nil {
+		return errors.New("invalid IPv4 address: " + value)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.defaultIPv4 = ip.To4()
+
+	return nil
+}
+
+// SetDefaultIPv6 sets the IPv6 address returned for all the AAAA queries.
+func (s *Server) SetDefaultIPv6(value string) error {
+	ip := net.ParseIP(value)
+	if ip == nil || ip.To4() != nil {
+		return errors.New("invalid IPv6 address: " + value)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.defaultIPv6 = ip
+
+	return nil
+}
+
+func (s *Server) getTXT(fqdn string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.txtRecords[normalizeFQDN(fqdn)]
+}
+
+func (s *Server) getHTTP01(token string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	content, ok := s.http01[token]
+
+	return content, ok
+}
+
+func (s *Server) getDefaultIPs() (net.IP, net.IP) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.defaultIPv4, s.defaultIPv6
+}
+
+func normalizeFQDN(fqdn string) string {
+	return dns.Fqdn(strings.ToLower(fqdn))
+}
+
+func runServer(ctx context.Context, listen func() error, shutdown func(ctx context.Context) error) error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- listen()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		return shutdown(shutdownCtx)
+	}
+}
+
+func ignoreServerClosed(listen func() error) func() error {
+	return func() error {
+		err := listen()
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+
+		return err
+	}
+}
@@ -0,0 +1,239 @@
+package devserver
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_answer(t *testing.T) {
+	s, err := New(Config{DefaultIPv4: "127.0.0.1"})
+	require.NoError(t, err)
+
+	s.SetTXT("_acme-challenge.Example.com", "value1")
+	s.SetTXT("_acme-challenge.example.com.", "value2")
+
+	testCases := []struct {
+		desc     string
+		name     string
+		qtype    uint16
+		expected []string
+	}{
+		{
+			desc:     "TXT",
+			name:     "_acme-challenge.example.com.",
+			qtype:    dns.TypeTXT,
+			expected: []string{"value1", "value2"},
+		},
+		{
+			desc:  "TXT: no record",
+			name:  "_acme-challenge.example.org.",
+			qtype: dns.TypeTXT,
+		},
+		{
+			desc:     "SOA: zone apex",
+			name:     "example.com.",
+			qtype:    dns.TypeSOA,
+			expected: []string{"localhost."},
+		},
+		{
+			desc:  "SOA: subdomain",
+			name:  "_acme-challenge.example.com.",
+			qtype: dns.TypeSOA,
+		},
+		{
+			desc:     "NS",
+			name:     "example.co.uk.",
+			qtype:    dns.TypeNS,
+			expected: []string{"localhost."},
+		},
+		{
+			desc:     "A",
+			name:     "www.example.com.",
+			qtype:    dns.TypeA,
+			expected: []string{"127.0.0.1"},
+		},
+		{
+			desc:  "AAAA: no default IPv6",
+			name:  "www.example.com.",
+			qtype: dns.TypeAAAA,
+		},
+		{
+			desc:  "CAA",
+			name:  "example.com.",
+			qtype: dns.TypeCAA,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			req := new(dns.Msg).SetQuestion(test.name, test.qtype)
+
+			resp := s.answer(req)
+
+			assert.Equal(t, dns.RcodeSuccess, resp.Rcode)
+			assert.True(t, resp.Authoritative)
+
+			var values []string
+
+			for _, rr := range resp.Answer {
+				switch r := rr.(type) {
+				case *dns.TXT:
+					values = append(values, strings.Join(r.Txt, ""))
+				case *dns.SOA:
+					values = append(values, r.Ns)
+				case *dns.NS:
+					values = append(values, r.Ns)
+				case *dns.A:
+					values = append(values, r.A.String())
+				}
+			}
+
+			assert.Equal(t, test.expected, values)
+		})
+	}
+}
+
+func TestServer_DeleteTXT(t *testing.T) {
+	s, err := New(Config{})
+	require.NoError(t, err)
+
+	s.SetTXT("_acme-challenge.example.com", "value1")
+	s.SetTXT("_acme-challenge.example.com", "value2")
+
+	s.DeleteTXT("_acme-challenge.example.com.", "value1")
+
+	assert.Equal(t, []string{"value2"}, s.getTXT("_acme-challenge.example.com"))
+
+	s.DeleteTXT("_acme-challenge.example.com.", "value2")
+
+	assert.Empty(t, s.txtRecords)
+}
+
+func TestNew_invalidIP(t *testing.T) {
+	_, err := New(Config{DefaultIPv4: "::1"})
+	require.EqualError(t, err, "invalid IPv4 address: ::1")
+
+	_, err = New(Config{DefaultIPv6: "127.0.0.1"})
+	require.EqualError(t, err, "invalid IPv6 address: 127.0.0.1")
+}
+
+func TestServer_managementHandler(t *testing.T) {
+	s, err := New(Config{})
+	require.NoError(t, err)
+
+	server := httptest.NewServer(s.managementHandler())
+	t.Cleanup(server.Close)
+
+	post := func(path, body string) int {
+		t.Helper()
+
+		resp, errP := http.Post(server.URL+path, "application/json", strings.NewReader(body))
+		require.NoError(t, errP)
+
+		_ = resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, post("/set-txt", `{"host":"_acme-challenge.example.com.","value":"a"}`))
+	assert.Equal(t, http.StatusOK, post("/present", `{"fqdn":"_acme-challenge.example.com.","value":"b"}`))
+	assert.Equal(t, []string{"a", "b"}, s.getTXT("_acme-challenge.example.com."))
+
+	assert.Equal(t, http.StatusOK, post("/cleanup", `{"fqdn":"_acme-challenge.example.com.","value":"a"}`))
+	assert.Equal(t, []string{"b"}, s.getTXT("_acme-challenge.example.com."))
+
+	assert.Equal(t, http.StatusOK, post("/clear-txt", `{"host":"_acme-challenge.example.com."}`))
+	assert.Empty(t, s.getTXT("_acme-challenge.example.com."))
+
+	assert.Equal(t, http.StatusOK, post("/add-http01", `{"token":"abc","content":"abc.xyz"}`))
+
+	content, ok := s.getHTTP01("abc")
+	assert.True(t, ok)
+	assert.Equal(t, "abc.xyz", content)
+
+	assert.Equal(t, http.StatusOK, post("/del-http01", `{"token":"abc"}`))
+
+	_, ok = s.getHTTP01("abc")
+	assert.False(t, ok)
+
+	assert.Equal(t, http.StatusOK, post("/set-default-ipv6", `{"ip":"::1"}`))
+
+	_, ipv6 := s.getDefaultIPs()
+	assert.Equal(t, net.IPv6loopback, ipv6)
+
+	assert.Equal(t, http.StatusBadRequest, post("/set-txt", `{"host":"_acme-challenge.example.com."}`))
+	assert.Equal(t, http.StatusBadRequest, post("/set-default-ipv4", `{"ip":"::1"}`))
+	assert.Equal(t, http.StatusBadRequest, post("/present", `{`))
+}
+
+func TestServer_http01Handler(t *testing.T) {
+	s, err := New(Config{})
+	require.NoError(t, err)
+
+	s.AddHTTP01("abc", "abc.xyz")
+
+	server := httptest.NewServer(s.http01Handler())
+	t.Cleanup(server.Close)
+
+	resp, err := http.Get(server.URL + "/.well-known/acme-challenge/abc")
+	require.NoError(t, err)
+
+	defer func() { _ = resp.Body.Close() }()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc.xyz", string(body))
+
+	resp, err = http.Get(server.URL + "/.well-known/acme-challenge/unknown")
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestWritePebbleConfig(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "pebble", "pebble-config.json")
+
+	certPath, err := WritePebbleConfig(filename, ":5003")
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(filepath.Dir(filename), "cert.pem"), certPath)
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), `"httpPort": 5003`)
+
+	certPEM, err := os.ReadFile(certPath)
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate(certPEM)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"localhost"}, cert.DNSNames)
+
+	keyPEM, err := os.ReadFile(filepath.Join(filepath.Dir(filename), "key.pem"))
+	require.NoError(t, err)
+
+	key, err := certcrypto.ParsePEMPrivateKey(keyPEM)
+	require.NoError(t, err)
+
+	require.NoError(t, certcrypto.MatchPrivateKey(cert, key))
+}
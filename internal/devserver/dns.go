@@ -0,0 +1,94 @@
+package devserver
+
+import (
+	"strings"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/miekg/dns"
+	"golang.org/x/net/publicsuffix"
+)
+
+// nameserver the name of the authoritative nameserver of all the zones:
+// "localhost" is resolved without DNS server, so the propagation checks of lego work offline.
+const nameserver = "localhost."
+
+const defaultTTL = 60
+
+func (s *Server) serveDNS(w dns.ResponseWriter, req *dns.Msg) {
+	err := w.WriteMsg(s.answer(req))
+	if err != nil {
+		log.Warnf("devserver: DNS response: %v", err)
+	}
+}
+
+// answer creates the response to a DNS query:
+//   - TXT: the records set with the management API.
+//   - SOA, NS: the registered domain (public suffix + 1 label) is the zone apex, served by "localhost".
+//   - A, AAAA: the default IP addresses.
+//   - the other types (ex: CAA): no record.
+func (s *Server) answer(req *dns.Msg) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetReply(req)
+	m.Authoritative = true
+
+	if req.Opcode != dns.OpcodeQuery {
+		m.Rcode = dns.RcodeNotImplemented
+		return m
+	}
+
+	ipv4, ipv6 := s.getDefaultIPs()
+
+	for _, q := range req.Question {
+		hdr := dns.RR_Header{Name: q.Name, Rrtype: q.Qtype, Class: dns.ClassINET, Ttl: defaultTTL}
+
+		switch q.Qtype {
+		case dns.TypeTXT:
+			for _, value := range s.getTXT(q.Name) {
+				m.Answer = append(m.Answer, &dns.TXT{Hdr: hdr, Txt: []string{value}})
+			}
+
+		case dns.TypeSOA:
+			if isZoneApex(q.Name) {
+				m.Answer = append(m.Answer, &dns.SOA{
+					Hdr:     hdr,
+					Ns:      nameserver,
+					Mbox:    "hostmaster." + nameserver,
+					Serial:  1,
+					Refresh: defaultTTL,
+					Retry:   defaultTTL,
+					Expire:  defaultTTL,
+					Minttl:  defaultTTL,
+				})
+			}
+
+		case dns.TypeNS:
+			if isZoneApex(q.Name) {
+				m.Answer = append(m.Answer, &dns.NS{Hdr: hdr, Ns: nameserver})
+			}
+
+		case dns.TypeA:
+			if ipv4 != nil {
+				m.Answer = append(m.Answer, &dns.A{Hdr: hdr, A: ipv4})
+			}
+
+		case dns.TypeAAAA:
+			if ipv6 != nil {
+				m.Answer = append(m.Answer, &dns.AAAA{Hdr: hdr, AAAA: ipv6})
+			}
+		}
+	}
+
+	return m
+}
+
+// isZoneApex reports whether the name is a registered domain (ex: "example.com.", "example.co.uk.", "lego.localhost.").
+func isZoneApex(name string) bool {
+	name = strings.ToLower(dns.CanonicalName(name))
+
+	apex, err := publicsuffix.EffectiveTLDPlusOne(strings.TrimSuffix(name, "."))
+	if err != nil {
+		return false
+	}
+
+	return dns.Fqdn(apex) == name
+}
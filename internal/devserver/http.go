@@ -0,0 +1,151 @@
+package devserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-acme/lego/v4/challenge/http01"
+	"github.com/go-acme/lego/v4/log"
+)
+
+// Management API payloads (pebble-challtestsrv).
+type (
+	hostRequest struct {
+		Host  string `json:"host"`
+		Value string `json:"value,omitempty"`
+	}
+
+	http01Request struct {
+		Token   string `json:"token"`
+		Content string `json:"content,omitempty"`
+	}
+
+	ipRequest struct {
+		IP string `json:"ip"`
+	}
+)
+
+// httpreqRequest the payload of the httpreq DNS provider (default mode).
+type httpreqRequest struct {
+	FQDN  string `json:"fqdn"`
+	Value string `json:"value"`
+}
+
+func (s *Server) http01Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+http01.ChallengePath("{token}"), func(rw http.ResponseWriter, req *http.Request) {
+		content, ok := s.getHTTP01(req.PathValue("token"))
+		if !ok {
+			http.NotFound(rw, req)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "text/plain")
+
+		_, _ = rw.Write([]byte(content))
+	})
+
+	return mux
+}
+
+func (s *Server) managementHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	// pebble-challtestsrv compatible endpoints.
+
+	mux.HandleFunc("POST /set-txt", handle(func(r hostRequest) error {
+		if r.Host == "" || r.Value == "" {
+			return fmt.Errorf("host and value are required: %+v", r)
+		}
+
+		s.SetTXT(r.Host, r.Value)
+
+		return nil
+	}))
+
+	mux.HandleFunc("POST /clear-txt", handle(func(r hostRequest) error {
+		if r.Host == "" {
+			return fmt.Errorf("host is required: %+v", r)
+		}
+
+		s.ClearTXT(r.Host)
+
+		return nil
+	}))
+
+	mux.HandleFunc("POST /add-http01", handle(func(r http01Request) error {
+		if r.Token == "" || r.Content == "" {
+			return fmt.Errorf("token and content are required: %+v", r)
+		}
+
+		s.AddHTTP01(r.Token, r.Content)
+
+		return nil
+	}))
+
+	mux.HandleFunc("POST /del-http01", handle(func(r http01Request) error {
+		if r.Token == "" {
+			return fmt.Errorf("token is required: %+v", r)
+		}
+
+		s.DeleteHTTP01(r.Token)
+
+		return nil
+	}))
+
+	mux.HandleFunc("POST /set-default-ipv4", handle(func(r ipRequest) error {
+		return s.SetDefaultIPv4(r.IP)
+	}))
+
+	mux.HandleFunc("POST /set-default-ipv6", handle(func(r ipRequest) error {
+		return s.SetDefaultIPv6(r.IP)
+	}))
+
+	// httpreq DNS provider compatible endpoints.
+
+	mux.HandleFunc("POST /present", handle(func(r httpreqRequest) error {
+		if r.FQDN == "" || r.Value == "" {
+			return fmt.Errorf("fqdn and value are required: %+v", r)
+		}
+
+		s.SetTXT(r.FQDN, r.Value)
+
+		return nil
+	}))
+
+	mux.HandleFunc("POST /cleanup", handle(func(r httpreqRequest) error {
+		if r.FQDN == "" || r.Value == "" {
+			return fmt.Errorf("fqdn and value are required: %+v", r)
+		}
+
+		s.DeleteTXT(r.FQDN, r.Value)
+
+		return nil
+	}))
+
+	return mux
+}
+
+func handle[T any](fn func(T) error) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		var payload T
+
+		err := json.NewDecoder(req.Body).Decode(&payload)
+		if err != nil {
+			http.Error(rw, fmt.Sprintf("invalid JSON payload: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		err = fn(payload)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		log.Infof("devserver: %s %+v", req.URL.Path, payload)
+
+		rw.WriteHeader(http.StatusOK)
+	}
+}
@@ -0,0 +1,138 @@
+package devserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+)
+
+const (
+	pebbleCertFile = "cert.pem"
+	pebbleKeyFile  = "key.pem"
+)
+
+// PebbleConfig the configuration file of Pebble.
+type PebbleConfig struct {
+	Pebble PebbleServerConfig `json:"pebble"`
+}
+
+// PebbleServerConfig the configuration of the Pebble server.
+type PebbleServerConfig struct {
+	ListenAddress                  string `json:"listenAddress"`
+	ManagementListenAddress        string `json:"managementListenAddress"`
+	Certificate                    string `json:"certificate"`
+	PrivateKey                     string `json:"privateKey"`
+	HTTPPort                       int    `json:"httpPort"`
+	TLSPort                        int    `json:"tlsPort"`
+	OCSPResponderURL               string `json:"ocspResponderURL"`
+	ExternalAccountBindingRequired bool   `json:"externalAccountBindingRequired"`
+}
+
+// WritePebbleConfig writes a Pebble configuration file,
+// and the self-signed certificate (localhost, 127.0.0.1, ::1) of the Pebble server in the same directory.
+// The certificate is used by lego through the LEGO_CA_CERTIFICATES environment variable.
+func WritePebbleConfig(filename, httpAddress string) (string, error) {
+	httpPort := 5002
+
+	if httpAddress != "" {
+		_, port, err := net.SplitHostPort(httpAddress)
+		if err != nil {
+			return "", fmt.Errorf("HTTP address: %w", err)
+		}
+
+		httpPort, err = strconv.Atoi(port)
+		if err != nil {
+			return "", fmt.Errorf("HTTP port: %w", err)
+		}
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(filename))
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	certPath := filepath.Join(dir, pebbleCertFile)
+	keyPath := filepath.Join(dir, pebbleKeyFile)
+
+	err = writeSelfSignedCertificate(certPath, keyPath)
+	if err != nil {
+		return "", fmt.Errorf("self-signed certificate: %w", err)
+	}
+
+	config := PebbleConfig{
+		Pebble: PebbleServerConfig{
+			ListenAddress:           "0.0.0.0:14000",
+			ManagementListenAddress: "0.0.0.0:15000",
+			Certificate:             certPath,
+			PrivateKey:              keyPath,
+			HTTPPort:                httpPort,
+			TLSPort:                 5001,
+		},
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	err = os.WriteFile(filename, data, 0o600)
+	if err != nil {
+		return "", err
+	}
+
+	return certPath, nil
+}
+
+func writeSelfSignedCertificate(certPath, keyPath string) error {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(certPath, certcrypto.PEMEncode(certcrypto.DERCertificateBytes(der)), 0o644)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(keyPath, certcrypto.PEMEncode(privateKey), 0o600)
+}
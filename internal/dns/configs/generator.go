@@ -0,0 +1,236 @@
+package main
+
+//go:generate go run .
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/go-acme/lego/v4/internal/dns/descriptors"
+)
+
+const (
+	root = "../../../"
+
+	outputFile = "zz_gen_config.go"
+)
+
+//go:embed config.go.tmpl
+var srcTemplate string
+
+type model struct {
+	descriptors.Generation
+
+	Name string
+	Code string
+}
+
+// Credentials returns the required fields.
+func (m model) Credentials() []descriptors.Field {
+	var fields []descriptors.Field
+
+	for _, field := range m.Fields {
+		if field.Credential {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// CredentialNames returns the names of the environment variables of the required fields.
+func (m model) CredentialNames() string {
+	var names []string
+	for _, field := range m.Credentials() {
+		names = append(names, m.Namespace+field.Env)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// Imports returns the import paths, other than the standard library, required by the default values.
+func (m model) Imports() ([]string, error) {
+	values := []string{m.Defaults.TTL, m.Defaults.PropagationTimeout, m.Defaults.PollingInterval, m.Defaults.HTTPTimeout}
+	for _, field := range m.Fields {
+		values = append(values, field.Default)
+	}
+
+	imports := []string{importPath("env")}
+
+	qualifier := regexp.MustCompile(`\b([a-z][a-z0-9]*)\.[A-Z]`)
+
+	for _, value := range values {
+		for _, match := range qualifier.FindAllStringSubmatch(value, -1) {
+			if match[1] == "time" {
+				continue
+			}
+
+			path := importPath(match[1])
+			if path == "" {
+				return nil, fmt.Errorf("unknown package %q in the default value %q", match[1], value)
+			}
+
+			if slices.Contains(imports, path) {
+				continue
+			}
+
+			imports = append(imports, path)
+		}
+	}
+
+	sort.Strings(imports)
+
+	return imports, nil
+}
+
+func main() {
+	err := generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate() error {
+	info, err := descriptors.GetProviderInformation(root)
+	if err != nil {
+		return err
+	}
+
+	tmpl := template.Must(template.New("").Funcs(map[string]any{
+		"goType":         goType,
+		"envGetter":      envGetter,
+		"optionalGetter": optionalGetter,
+	}).Parse(srcTemplate))
+
+	var count int
+
+	for _, provider := range info.Providers {
+		if provider.Generation == nil {
+			continue
+		}
+
+		err = generateConfig(tmpl, provider)
+		if err != nil {
+			return fmt.Errorf("%s: %w", provider.Code, err)
+		}
+
+		count++
+	}
+
+	fmt.Printf("Configuration for %d DNS providers has been generated.\n", count)
+
+	return nil
+}
+
+func generateConfig(tmpl *template.Template, provider descriptors.Provider) error {
+	m := model{
+		Generation: *provider.Generation,
+		Name:       provider.Name,
+		Code:       provider.Code,
+	}
+
+	if m.Package == "" {
+		m.Package = strings.ReplaceAll(provider.Code, "-", "")
+	}
+
+	if m.Namespace == "" {
+		return fmt.Errorf("missing namespace")
+	}
+
+	for _, field := range m.Fields {
+		if goType(field.Type) == "" {
+			return fmt.Errorf("unsupported type %q for the field %s", field.Type, field.Name)
+		}
+	}
+
+	if m.Defaults.TTL == "" || m.Defaults.PropagationTimeout == "" || m.Defaults.PollingInterval == "" {
+		return fmt.Errorf("missing default values")
+	}
+
+	b := &bytes.Buffer{}
+
+	err := tmpl.Execute(b, m)
+	if err != nil {
+		return err
+	}
+
+	// gofmt
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+
+	output := filepath.Join(root, filepath.Dir(provider.GeneratedFrom), outputFile)
+
+	return os.WriteFile(output, source, 0o644)
+}
+
+// importPath returns the import path of the packages allowed in the default values.
+func importPath(qualifier string) string {
+	switch qualifier {
+	case "backoff":
+		return "github.com/go-acme/lego/v4/providers/dns/internal/backoff"
+	case "dns":
+		return "github.com/miekg/dns"
+	case "dns01":
+		return "github.com/go-acme/lego/v4/challenge/dns01"
+	case "env":
+		return "github.com/go-acme/lego/v4/platform/config/env"
+	default:
+		return ""
+	}
+}
+
+func goType(fieldType string) string {
+	switch fieldType {
+	case "", "string":
+		return "string"
+	case "bool":
+		return "bool"
+	case "int":
+		return "int"
+	case "duration":
+		return "time.Duration"
+	default:
+		return ""
+	}
+}
+
+func envGetter(fieldType string) string {
+	switch fieldType {
+	case "bool":
+		return "lookup.GetOrDefaultBool"
+	case "int":
+		return "lookup.GetOrDefaultInt"
+	case "duration":
+		return "lookup.GetOrDefaultSecond"
+	default:
+		return "lookup.GetOrDefaultString"
+	}
+}
+
+// optionalGetter returns the expression to read the value of an optional field without default value.
+func optionalGetter(field descriptors.Field) string {
+	name := "Env" + field.Name
+
+	switch field.Type {
+	case "bool":
+		return fmt.Sprintf("env.GetOrDefaultBool(%s, false)", name)
+	case "int":
+		return fmt.Sprintf("env.GetOrDefaultInt(%s, 0)", name)
+	case "duration":
+		return fmt.Sprintf("env.GetOrDefaultSecond(%s, 0)", name)
+	default:
+		return fmt.Sprintf("env.GetOrFile(%s)", name)
+	}
+}
@@ -22,6 +22,7 @@ type Provider struct {
 	Configuration *Configuration // Environment variables
 	Links         *Links         // Links
 	Additional    string         // Extra documentation
+	Generation    *Generation    // Code generation of the configuration (optional)
 	GeneratedFrom string         // Source file
 }
 
@@ -30,6 +31,41 @@ type Configuration struct {
 	Additional  map[string]string
 }
 
+// Generation describes the configuration of a provider,
+// used to generate the Config/NewDefaultConfig/NewDNSProvider boilerplate.
+type Generation struct {
+	// Package name (Default: the code without dashes).
+	Package string
+	// Name of the environment variables namespace (ex: "EXAMPLE_").
+	Namespace string
+	// Provider specific fields.
+	Fields []Field
+	// Default values of the common fields (Go expressions).
+	Defaults Defaults
+}
+
+// Field describes a provider specific field of the Config.
+type Field struct {
+	// Name of the field of the Config.
+	Name string
+	// Environment variable suffix (without the namespace).
+	Env string
+	// Type of the field: "string" (default), "bool", "int", "duration" (seconds).
+	Type string
+	// Credential is true when the value is required.
+	Credential bool
+	// Default value (Go expression).
+	Default string
+}
+
+// Defaults describes the default values of the common fields (Go expressions).
+type Defaults struct {
+	TTL                string
+	PropagationTimeout string
+	PollingInterval    string
+	HTTPTimeout        string
+}
+
 type Links struct {
 	API      string
 	GoClient string
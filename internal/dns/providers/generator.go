@@ -19,12 +19,16 @@ import (
 const (
 	root = "../../../"
 
-	outputPath = "providers/dns/zz_gen_dns_providers.go"
+	outputPath     = "providers/dns/zz_gen_dns_providers.go"
+	testOutputPath = "providers/dns/zz_gen_dns_providers_test.go"
 )
 
 //go:embed dns_providers.go.tmpl
 var srcTemplate string
 
+//go:embed dns_providers_test.go.tmpl
+var testSrcTemplate string
+
 func main() {
 	err := generate()
 	if err != nil {
@@ -38,7 +42,24 @@ func generate() error {
 		return err
 	}
 
-	file, err := os.Create(filepath.Join(root, outputPath))
+	err = generateFile(outputPath, srcTemplate, info)
+	if err != nil {
+		return err
+	}
+
+	// Compile-time conformance of the providers.
+	err = generateFile(testOutputPath, testSrcTemplate, info)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Switch mapping for %d DNS providers has been generated.\n", len(info.Providers)+1)
+
+	return nil
+}
+
+func generateFile(output, src string, info *descriptors.Providers) error {
+	file, err := os.Create(filepath.Join(root, output))
 	if err != nil {
 		return err
 	}
@@ -52,7 +73,7 @@ func generate() error {
 			"cleanName": func(src string) string {
 				return strings.ReplaceAll(src, "-", "")
 			},
-		}).Parse(srcTemplate),
+		}).Parse(src),
 	).Execute(b, info)
 	if err != nil {
 		return err
@@ -65,11 +86,6 @@ func generate() error {
 	}
 
 	_, err = file.Write(source)
-	if err != nil {
-		return err
-	}
 
-	fmt.Printf("Switch mapping for %d DNS providers has been generated.\n", len(info.Providers)+1)
-
-	return nil
+	return err
 }
@@ -0,0 +1,142 @@
+// Package scaffold generates the skeleton of a new DNS provider.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var codePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// Provider describes the DNS provider to generate.
+type Provider struct {
+	Code  string // DNS code (ex: "example-dns")
+	Name  string // Real name of the DNS provider (Default: the code)
+	URL   string // DNS provider URL (Default: "https://example.com")
+	Since string // First lego version (Default: a placeholder "v4.x.0")
+}
+
+// Package returns the Go package name of the provider.
+func (p Provider) Package() string {
+	return strings.ReplaceAll(p.Code, "-", "")
+}
+
+// Namespace returns the namespace of the environment variables of the provider.
+func (p Provider) Namespace() string {
+	return strings.ToUpper(strings.ReplaceAll(p.Code, "-", "_")) + "_"
+}
+
+type file struct {
+	template string
+	output   string
+}
+
+// Generate writes the skeleton of the provider inside the lego repository located in root:
+// the provider, its TOML descriptor, the internal API client, and the tests.
+// The configuration (zz_gen_config.go), the registry of the providers, and the documentation
+// are generated from the TOML descriptor by `make generate-dns`.
+// It returns the paths of the created files.
+func Generate(root string, provider Provider) ([]string, error) {
+	if !codePattern.MatchString(provider.Code) {
+		return nil, fmt.Errorf("invalid provider code %q: only lowercase letters, digits and dashes are allowed", provider.Code)
+	}
+
+	if provider.Name == "" {
+		provider.Name = provider.Code
+	}
+
+	if provider.URL == "" {
+		provider.URL = "https://example.com"
+	}
+
+	if provider.Since == "" {
+		provider.Since = "v4.x.0"
+	}
+
+	_, err := os.Stat(filepath.Join(root, "providers", "dns", "zz_gen_dns_providers.go"))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not the root of the lego repository: %w", root, err)
+	}
+
+	dir := filepath.Join(root, "providers", "dns", provider.Package())
+
+	_, err = os.Stat(dir)
+	if err == nil {
+		return nil, fmt.Errorf("the provider %q already exists: %s", provider.Code, dir)
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	pkg := provider.Package()
+
+	files := []file{
+		{template: "provider.go.tmpl", output: pkg + ".go"},
+		{template: "provider_test.go.tmpl", output: pkg + "_test.go"},
+		{template: "provider.toml.tmpl", output: pkg + ".toml"},
+		{template: "client.go.tmpl", output: filepath.Join("internal", "client.go")},
+		{template: "client_test.go.tmpl", output: filepath.Join("internal", "client_test.go")},
+		{template: "types.go.tmpl", output: filepath.Join("internal", "types.go")},
+		{template: "fixtures/add_record-request.json", output: filepath.Join("internal", "fixtures", "add_record-request.json")},
+		{template: "fixtures/add_record.json", output: filepath.Join("internal", "fixtures", "add_record.json")},
+		{template: "fixtures/error.json", output: filepath.Join("internal", "fixtures", "error.json")},
+	}
+
+	var created []string
+
+	for _, f := range files {
+		output := filepath.Join(dir, f.output)
+
+		err = generateFile(f.template, output, provider)
+		if err != nil {
+			return created, fmt.Errorf("%s: %w", f.output, err)
+		}
+
+		created = append(created, output)
+	}
+
+	return created, nil
+}
+
+func generateFile(name, output string, provider Provider) error {
+	tmpl, err := template.ParseFS(templates, "templates/"+name)
+	if err != nil {
+		return err
+	}
+
+	b := &bytes.Buffer{}
+
+	err = tmpl.Execute(b, provider)
+	if err != nil {
+		return err
+	}
+
+	content := b.Bytes()
+
+	if strings.HasSuffix(output, ".go") {
+		// gofmt
+		content, err = format.Source(content)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.MkdirAll(filepath.Dir(output), 0o755)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(output, content, 0o644)
+}
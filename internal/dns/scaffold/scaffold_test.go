@@ -0,0 +1,113 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-acme/lego/v4/internal/dns/descriptors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRoot(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+
+	err := os.MkdirAll(filepath.Join(root, "providers", "dns"), 0o755)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(root, "providers", "dns", "zz_gen_dns_providers.go"), []byte("package dns\n"), 0o644)
+	require.NoError(t, err)
+
+	return root
+}
+
+func TestProvider(t *testing.T) {
+	provider := Provider{Code: "example-dns"}
+
+	assert.Equal(t, "exampledns", provider.Package())
+	assert.Equal(t, "EXAMPLE_DNS_", provider.Namespace())
+}
+
+func TestGenerate(t *testing.T) {
+	root := setupRoot(t)
+
+	files, err := Generate(root, Provider{Code: "example-dns", Name: "Example DNS", Since: "v4.30.0"})
+	require.NoError(t, err)
+
+	dir := filepath.Join(root, "providers", "dns", "exampledns")
+
+	expected := []string{
+		filepath.Join(dir, "exampledns.go"),
+		filepath.Join(dir, "exampledns_test.go"),
+		filepath.Join(dir, "exampledns.toml"),
+		filepath.Join(dir, "internal", "client.go"),
+		filepath.Join(dir, "internal", "client_test.go"),
+		filepath.Join(dir, "internal", "types.go"),
+		filepath.Join(dir, "internal", "fixtures", "add_record-request.json"),
+		filepath.Join(dir, "internal", "fixtures", "add_record.json"),
+		filepath.Join(dir, "internal", "fixtures", "error.json"),
+	}
+
+	assert.Equal(t, expected, files)
+
+	for _, file := range expected {
+		assert.FileExists(t, file)
+	}
+
+	var provider descriptors.Provider
+
+	_, err = toml.DecodeFile(filepath.Join(dir, "exampledns.toml"), &provider)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Example DNS", provider.Name)
+	assert.Equal(t, "example-dns", provider.Code)
+	assert.Equal(t, "v4.30.0", provider.Since)
+	assert.Contains(t, provider.Configuration.Credentials, "EXAMPLE_DNS_API_KEY")
+
+	require.NotNil(t, provider.Generation)
+	assert.Equal(t, "EXAMPLE_DNS_", provider.Generation.Namespace)
+
+	source, err := os.ReadFile(filepath.Join(dir, "exampledns.go"))
+	require.NoError(t, err)
+
+	assert.Contains(t, string(source), "package exampledns\n")
+	assert.Contains(t, string(source), `"github.com/go-acme/lego/v4/providers/dns/exampledns/internal"`)
+}
+
+func TestGenerate_errors(t *testing.T) {
+	root := setupRoot(t)
+
+	err := os.MkdirAll(filepath.Join(root, "providers", "dns", "existing"), 0o755)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		code     string
+		expected string
+	}{
+		{
+			desc:     "invalid code",
+			code:     "Example_DNS",
+			expected: `invalid provider code "Example_DNS": only lowercase letters, digits and dashes are allowed`,
+		},
+		{
+			desc:     "existing provider",
+			code:     "existing",
+			expected: `the provider "existing" already exists: ` + filepath.Join(root, "providers", "dns", "existing"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := Generate(root, Provider{Code: test.code})
+			require.EqualError(t, err, test.expected)
+		})
+	}
+
+	_, err = Generate(t.TempDir(), Provider{Code: "example"})
+	require.ErrorContains(t, err, "is not the root of the lego repository")
+}
@@ -0,0 +1,69 @@
+// Package faults implements failure injection points, to test the automation around lego (chaos testing).
+//
+// The injection points are only compiled with the `lego_faults` build tag:
+//
+//	go build -tags lego_faults ./cmd/lego
+//
+// Without the build tag, the injection points do nothing.
+// With the build tag, the failures are configured with environment variables:
+//
+//   - LEGO_FAULT_DROP_NONCE: the probability (0 to 1) to drop a nonce, the request is sent with an invalid nonce (badNonce).
+//   - LEGO_FAULT_VALIDATION_DELAY: the delay (ex: "30s") before responding to each challenge.
+//   - LEGO_FAULT_FAIL_FIRST_PRESENT: "true" to fail the first Present of the challenge providers.
+package faults
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Environment variables.
+const (
+	EnvDropNonce        = "LEGO_FAULT_DROP_NONCE"
+	EnvValidationDelay  = "LEGO_FAULT_VALIDATION_DELAY"
+	EnvFailFirstPresent = "LEGO_FAULT_FAIL_FIRST_PRESENT"
+)
+
+// ErrInjected the error of the injected failures.
+var ErrInjected = errors.New("injected failure")
+
+type config struct {
+	dropNonce        float64
+	validationDelay  time.Duration
+	failFirstPresent bool
+}
+
+func readConfig(getenv func(string) string) (config, error) {
+	var (
+		cfg  config
+		errs []error
+		err  error
+	)
+
+	if value := getenv(EnvDropNonce); value != "" {
+		cfg.dropNonce, err = strconv.ParseFloat(value, 64)
+		if err != nil || cfg.dropNonce < 0 || cfg.dropNonce > 1 {
+			errs = append(errs, fmt.Errorf("%s: invalid probability: %q", EnvDropNonce, value))
+			cfg.dropNonce = 0
+		}
+	}
+
+	if value := getenv(EnvValidationDelay); value != "" {
+		cfg.validationDelay, err = time.ParseDuration(value)
+		if err != nil || cfg.validationDelay < 0 {
+			errs = append(errs, fmt.Errorf("%s: invalid duration: %q", EnvValidationDelay, value))
+			cfg.validationDelay = 0
+		}
+	}
+
+	if value := getenv(EnvFailFirstPresent); value != "" {
+		cfg.failFirstPresent, err = strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid boolean: %q", EnvFailFirstPresent, value))
+		}
+	}
+
+	return cfg, errors.Join(errs...)
+}
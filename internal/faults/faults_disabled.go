@@ -0,0 +1,20 @@
+//go:build !lego_faults
+
+package faults
+
+import "time"
+
+// Nonce returns the nonce: the failure injection is disabled (no `lego_faults` build tag).
+func Nonce(nonce string) string {
+	return nonce
+}
+
+// ValidationDelay returns 0: the failure injection is disabled (no `lego_faults` build tag).
+func ValidationDelay() time.Duration {
+	return 0
+}
+
+// Present returns nil: the failure injection is disabled (no `lego_faults` build tag).
+func Present(string) error {
+	return nil
+}
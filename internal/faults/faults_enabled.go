@@ -0,0 +1,61 @@
+//go:build lego_faults
+
+package faults
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+)
+
+// invalidNonce the nonce sent instead of a dropped nonce.
+const invalidNonce = "lego-fault-invalid-nonce"
+
+var getConfig = sync.OnceValue(func() config {
+	cfg, err := readConfig(os.Getenv)
+	if err != nil {
+		log.Warnf("faults: %v", err)
+	}
+
+	if cfg.dropNonce > 0 || cfg.validationDelay > 0 || cfg.failFirstPresent {
+		log.Warnf("faults: the failure injection is enabled (drop nonce: %v, validation delay: %s, fail first present: %t)",
+			cfg.dropNonce, cfg.validationDelay, cfg.failFirstPresent)
+	}
+
+	return cfg
+})
+
+var presented atomic.Bool
+
+// Nonce returns an invalid nonce instead of the nonce, according to the probability of LEGO_FAULT_DROP_NONCE.
+func Nonce(nonce string) string {
+	p := getConfig().dropNonce
+	if p <= 0 || rand.Float64() >= p {
+		return nonce
+	}
+
+	log.Warnf("faults: drop the nonce %q", nonce)
+
+	return invalidNonce
+}
+
+// ValidationDelay returns the delay (LEGO_FAULT_VALIDATION_DELAY) before responding to a challenge.
+func ValidationDelay() time.Duration {
+	return getConfig().validationDelay
+}
+
+// Present returns an error for the first Present of the challenge providers (LEGO_FAULT_FAIL_FIRST_PRESENT).
+func Present(domain string) error {
+	if !getConfig().failFirstPresent || presented.Swap(true) {
+		return nil
+	}
+
+	log.Warnf("faults: [%s] fail the first Present", domain)
+
+	return fmt.Errorf("present: %w", ErrInjected)
+}
@@ -0,0 +1,24 @@
+//go:build lego_faults
+
+package faults
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresent(t *testing.T) {
+	t.Setenv(EnvFailFirstPresent, "true")
+
+	err := Present("example.com")
+	require.ErrorIs(t, err, ErrInjected)
+
+	err = Present("example.com")
+	require.NoError(t, err)
+}
+
+func TestNonce(t *testing.T) {
+	assert.Equal(t, "nonce", Nonce("nonce"))
+}
@@ -0,0 +1,50 @@
+package faults
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readConfig(t *testing.T) {
+	env := map[string]string{
+		EnvDropNonce:        "0.5",
+		EnvValidationDelay:  "30s",
+		EnvFailFirstPresent: "true",
+	}
+
+	cfg, err := readConfig(func(key string) string { return env[key] })
+	require.NoError(t, err)
+
+	expected := config{
+		dropNonce:        0.5,
+		validationDelay:  30 * time.Second,
+		failFirstPresent: true,
+	}
+
+	assert.Equal(t, expected, cfg)
+}
+
+func Test_readConfig_empty(t *testing.T) {
+	cfg, err := readConfig(func(string) string { return "" })
+	require.NoError(t, err)
+
+	assert.Equal(t, config{}, cfg)
+}
+
+func Test_readConfig_invalid(t *testing.T) {
+	env := map[string]string{
+		EnvDropNonce:        "2",
+		EnvValidationDelay:  "-1s",
+		EnvFailFirstPresent: "yes",
+	}
+
+	cfg, err := readConfig(func(key string) string { return env[key] })
+	require.EqualError(t, err, `LEGO_FAULT_DROP_NONCE: invalid probability: "2"
+LEGO_FAULT_VALIDATION_DELAY: invalid duration: "-1s"
+LEGO_FAULT_FAIL_FIRST_PRESENT: invalid boolean: "yes"`)
+
+	assert.Equal(t, config{}, cfg)
+}
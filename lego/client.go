@@ -1,16 +1,28 @@
 package lego
 
 import (
+	"crypto"
+	"crypto/ed25519"
 	"errors"
+	"fmt"
 	"net/url"
+	"slices"
 
+	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/challenge/resolver"
+	"github.com/go-acme/lego/v4/metrics"
+	"github.com/go-acme/lego/v4/platform/recorder"
 	"github.com/go-acme/lego/v4/registration"
+	"github.com/go-acme/lego/v4/tracing"
 )
 
 // Client is the user-friendly way to ACME.
+//
+// Each client has its own account, HTTP client (the HTTP client of the configuration is copied), nonces, and logger:
+// several clients, with different accounts and ACME servers, can be used concurrently in the same process.
 type Client struct {
 	Certificate  *certificate.Certifier
 	Challenge    *resolver.SolverManager
@@ -21,6 +33,8 @@ type Client struct {
 // NewClient creates a new ACME client on behalf of the user.
 // The client will depend on the ACME directory located at CADirURL for the rest of its actions.
 // A private key of type keyType (see KeyType constants) will be generated when requesting a new certificate if one isn't provided.
+// The options of the configuration wrapping the transport (TLSPolicy, Metrics, DebugLogger, TracerProvider) are applied to a copy of Config.HTTPClient:
+// the HTTP client of the configuration is not modified.
 func NewClient(config *Config) (*Client, error) {
 	if config == nil {
 		return nil, errors.New("a configuration must be provided")
@@ -35,6 +49,20 @@ func NewClient(config *Config) (*Client, error) {
 		return nil, errors.New("the HTTP client cannot be nil")
 	}
 
+	httpClient := config.HTTPClient
+
+	if config.TLSPolicy != nil {
+		transport, errTr := config.TLSPolicy.CloneTransport(config.HTTPClient.Transport)
+		if errTr != nil {
+			return nil, errTr
+		}
+
+		instrumented := *config.HTTPClient
+		instrumented.Transport = transport
+
+		httpClient = &instrumented
+	}
+
 	privateKey := config.User.GetPrivateKey()
 	if privateKey == nil {
 		return nil, errors.New("private key was nil")
@@ -45,20 +73,55 @@ func NewClient(config *Config) (*Client, error) {
 		kid = reg.URI
 	}
 
-	core, err := api.New(config.HTTPClient, config.UserAgent, config.CADirURL, kid, privateKey)
+	if config.Metrics != nil {
+		instrumented := *httpClient
+		instrumented.Transport = metrics.NewTransport(config.Metrics, httpClient.Transport)
+
+		httpClient = &instrumented
+	}
+
+	if config.DebugLogger != nil {
+		instrumented := *httpClient
+		instrumented.Transport = recorder.NewDebugLogger(config.DebugLogger, httpClient.Transport)
+
+		httpClient = &instrumented
+	}
+
+	if config.TracerProvider != nil {
+		instrumented := *httpClient
+		instrumented.Transport = tracing.NewTransport(tracing.Tracer(config.TracerProvider), httpClient.Transport)
+
+		httpClient = &instrumented
+	}
+
+	core, err := api.New(httpClient, config.UserAgent, config.CADirURL, kid, privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkEd25519Support(core.GetDirectory().Meta, privateKey, config.Certificate.KeyType)
 	if err != nil {
 		return nil, err
 	}
 
+	core.Logger = config.Logger
+	core.StructuredLogger = config.StructuredLogger
+	core.MetricsRecorder = config.Metrics
+	core.TracerProvider = config.TracerProvider
+
 	solversManager := resolver.NewSolversManager(core)
 
 	prober := resolver.NewProber(solversManager)
+	prober.SetEvents(config.Events)
 
 	options := certificate.CertifierOptions{
 		KeyType:             config.Certificate.KeyType,
 		Timeout:             config.Certificate.Timeout,
 		OverallRequestLimit: config.Certificate.OverallRequestLimit,
 		DisableCommonName:   config.Certificate.DisableCommonName,
+		Rand:                config.Certificate.Rand,
+		LowMemory:           config.Certificate.LowMemory,
+		Events:              config.Events,
 	}
 
 	certifier := certificate.NewCertifier(core, prober, options)
@@ -76,7 +139,53 @@ func (c *Client) GetToSURL() string {
 	return c.core.GetDirectory().Meta.TermsOfService
 }
 
+// GetProfiles returns the certificate profiles supported by the server (names and descriptions) from the Directory.
+// - https://www.ietf.org/id/draft-ietf-acme-profiles-00.html#section-3
+func (c *Client) GetProfiles() map[string]string {
+	return c.core.GetDirectory().Meta.Profiles
+}
+
 // GetExternalAccountRequired returns the External Account Binding requirement of the Directory.
 func (c *Client) GetExternalAccountRequired() bool {
 	return c.core.GetDirectory().Meta.ExternalAccountRequired
 }
+
+// GetMetaExtensions returns the fields of the meta object of the Directory unknown to lego (ex: an experimental extension of the CA).
+func (c *Client) GetMetaExtensions() acme.Extensions {
+	return c.core.GetDirectory().Meta.Extensions
+}
+
+// GetCAAIdentities returns the CAA identities of the CA (the domain names used inside the CAA records) from the Directory.
+// Only the publicly-trusted CAs publish them.
+func (c *Client) GetCAAIdentities() []string {
+	return c.core.GetDirectory().Meta.CaaIdentities
+}
+
+// noEd25519CAs the CAA identities (meta.caaIdentities) of the CAs known to reject the Ed25519 keys,
+// for the accounts and for the certificates.
+var noEd25519CAs = []string{"letsencrypt.org"}
+
+// checkEd25519Support rejects the Ed25519 keys (the account key, or the type of the certificate keys)
+// when the directory identifies a CA known to not support them.
+// The other CAs report the unsupported keys themselves (badSignatureAlgorithm, badCSR).
+func checkEd25519Support(meta acme.Meta, accountKey crypto.PrivateKey, keyType certcrypto.KeyType) error {
+	_, edAccount := accountKey.(ed25519.PrivateKey)
+
+	if !edAccount && keyType != certcrypto.ED25519 {
+		return nil
+	}
+
+	for _, identity := range meta.CaaIdentities {
+		if !slices.Contains(noEd25519CAs, identity) {
+			continue
+		}
+
+		if edAccount {
+			return fmt.Errorf("the CA (%s) doesn't support the Ed25519 account keys", identity)
+		}
+
+		return fmt.Errorf("the CA (%s) doesn't support the Ed25519 certificate keys", identity)
+	}
+
+	return nil
+}
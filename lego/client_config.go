@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
@@ -12,7 +13,11 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/metrics"
 	"github.com/go-acme/lego/v4/registration"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -46,6 +51,35 @@ type Config struct {
 	UserAgent   string
 	HTTPClient  *http.Client
 	Certificate CertificateConfig
+
+	// TLSPolicy the TLS policy applied to a copy of the transport of HTTPClient by NewClient (HTTPClient is not modified).
+	// The default HTTP client already applies the policy defined by the environment variables (see TLSPolicyFromEnv).
+	TLSPolicy *TLSPolicy
+
+	// Logger the logger of the client (ACME flow, challenges, etc.).
+	// If nil, the global logger (log.Logger) is used.
+	Logger log.StdLogger
+
+	// StructuredLogger the structured logger of the client (ex: *slog.Logger).
+	// The log entries have a level and a key/value context (domain, order URL, challenge type).
+	// If not nil, it's used instead of Logger.
+	StructuredLogger log.StructuredLogger
+
+	// Metrics records the metrics of the ACME operations (ex: metrics.NewCollector()).
+	// If nil, the metrics are not recorded.
+	Metrics metrics.Recorder
+
+	// TracerProvider provides the tracer of the ACME operations (ex: the TracerProvider of the OpenTelemetry SDK).
+	// If nil, the operations are not traced.
+	TracerProvider trace.TracerProvider
+
+	// DebugLogger logs each request to the ACME server and its response, at the debug level, with the secrets redacted.
+	// If nil, the requests are not logged.
+	DebugLogger log.StructuredLogger
+
+	// Events receives the events of the issuance lifecycle (orders, challenges, certificates).
+	// If nil, the events are ignored.
+	Events certificate.Events
 }
 
 func NewConfig(user registration.User) *Config {
@@ -65,13 +99,21 @@ type CertificateConfig struct {
 	Timeout             time.Duration
 	OverallRequestLimit int
 	DisableCommonName   bool
+
+	// Rand the source of randomness used to generate the private keys and the CSRs.
+	// If nil, crypto/rand.Reader is used.
+	Rand io.Reader
+
+	// LowMemory fetches the certificate chains one by one instead of keeping all the chains in memory.
+	LowMemory bool
 }
 
 // createDefaultHTTPClient Creates an HTTP client with a reasonable timeout value
 // and potentially a custom *x509.CertPool
-// based on the caCertificatesEnvVar environment variable (see the `initCertPool` function).
+// based on the caCertificatesEnvVar environment variable (see the `initCertPool` function),
+// and the TLS policy based on the environment variables (see the `initTLSPolicy` function).
 func createDefaultHTTPClient() *http.Client {
-	return &http.Client{
+	client := &http.Client{
 		Timeout: 2 * time.Minute,
 		Transport: &http.Transport{
 			Proxy: http.ProxyFromEnvironment,
@@ -87,6 +129,21 @@ func createDefaultHTTPClient() *http.Client {
 			},
 		},
 	}
+
+	initTLSPolicy().Apply(client.Transport.(*http.Transport).TLSClientConfig)
+
+	return client
+}
+
+// initTLSPolicy creates the TLS policy defined by the environment variables (see TLSPolicyFromEnv).
+// If there is an error parsing the environment variables then initTLSPolicy will panic.
+func initTLSPolicy() *TLSPolicy {
+	policy, err := TLSPolicyFromEnv()
+	if err != nil {
+		panic(fmt.Sprintf("create TLS policy: %v", err))
+	}
+
+	return policy
 }
 
 // initCertPool creates a *x509.CertPool populated with the PEM certificates
@@ -1,11 +1,22 @@
 package lego
 
 import (
+	"bytes"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
+	"fmt"
+	stdlog "log"
+	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
 
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/go-acme/lego/v4/registration"
 	"github.com/stretchr/testify/assert"
@@ -34,6 +45,97 @@ func TestNewClient(t *testing.T) {
 	assert.NotNil(t, client)
 }
 
+func TestNewClient_concurrent(t *testing.T) {
+	var accounts atomic.Int64
+
+	server := tester.MockACMEServer().
+		Route("POST /account", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			rw.Header().Set("Replay-Nonce", "12345")
+			rw.Header().Set("Location", fmt.Sprintf("https://%s/account/%d", req.Host, accounts.Add(1)))
+			rw.WriteHeader(http.StatusCreated)
+
+			_ = tester.WriteJSONResponse(rw, acme.Account{Status: acme.StatusValid})
+		})).
+		BuildHTTPS(t)
+
+	// The HTTP client is shared by all the clients.
+	httpClient := server.Client()
+	transport := httpClient.Transport
+
+	const nbClients = 30
+
+	type result struct {
+		email      string
+		accountURL string
+		logs       string
+	}
+
+	results := make([]result, nbClients)
+
+	var wg sync.WaitGroup
+
+	for i := range nbClients {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			user := &mockECUser{email: fmt.Sprintf("user%d@example.com", i), privatekey: key}
+
+			logs := &bytes.Buffer{}
+
+			config := NewConfig(user)
+			config.CADirURL = server.URL + "/dir"
+			config.HTTPClient = httpClient
+			config.Logger = stdlog.New(logs, "", 0)
+
+			client, err := NewClient(config)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			reg, err := client.Registration.RegisterContext(t.Context(), registration.RegisterOptions{TermsOfServiceAgreed: true})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, reg.URI, client.core.GetAccountURL())
+
+			results[i] = result{email: user.email, accountURL: reg.URI, logs: logs.String()}
+		}()
+	}
+
+	wg.Wait()
+
+	// The HTTP client of the configuration is not modified.
+	assert.Same(t, transport, httpClient.Transport)
+
+	accountURLs := make(map[string]struct{})
+
+	for _, res := range results {
+		accountURLs[res.accountURL] = struct{}{}
+
+		assert.Equal(t, "[INFO] acme: Registering account for "+res.email+"\n", res.logs)
+	}
+
+	assert.Len(t, accountURLs, nbClients)
+}
+
+type mockECUser struct {
+	email      string
+	regres     *registration.Resource
+	privatekey *ecdsa.PrivateKey
+}
+
+func (u *mockECUser) GetEmail() string                        { return u.email }
+func (u *mockECUser) GetRegistration() *registration.Resource { return u.regres }
+func (u *mockECUser) GetPrivateKey() crypto.PrivateKey        { return u.privatekey }
+
 type mockUser struct {
 	email      string
 	regres     *registration.Resource
@@ -43,3 +145,64 @@ type mockUser struct {
 func (u mockUser) GetEmail() string                        { return u.email }
 func (u mockUser) GetRegistration() *registration.Resource { return u.regres }
 func (u mockUser) GetPrivateKey() crypto.PrivateKey        { return u.privatekey }
+
+func Test_checkEd25519Support(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc       string
+		meta       acme.Meta
+		accountKey crypto.PrivateKey
+		keyType    certcrypto.KeyType
+		expected   string
+	}{
+		{
+			desc:       "no Ed25519 key",
+			meta:       acme.Meta{CaaIdentities: []string{"letsencrypt.org"}},
+			accountKey: ecKey,
+			keyType:    certcrypto.EC256,
+		},
+		{
+			desc:       "Ed25519 account key",
+			meta:       acme.Meta{CaaIdentities: []string{"letsencrypt.org"}},
+			accountKey: edKey,
+			keyType:    certcrypto.EC256,
+			expected:   "the CA (letsencrypt.org) doesn't support the Ed25519 account keys",
+		},
+		{
+			desc:       "Ed25519 certificate keys",
+			meta:       acme.Meta{CaaIdentities: []string{"letsencrypt.org"}},
+			accountKey: ecKey,
+			keyType:    certcrypto.ED25519,
+			expected:   "the CA (letsencrypt.org) doesn't support the Ed25519 certificate keys",
+		},
+		{
+			desc:       "other CA",
+			meta:       acme.Meta{CaaIdentities: []string{"example.com"}},
+			accountKey: edKey,
+			keyType:    certcrypto.ED25519,
+		},
+		{
+			desc:       "no CAA identities",
+			accountKey: edKey,
+			keyType:    certcrypto.ED25519,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkEd25519Support(test.meta, test.accountKey, test.keyType)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package lego
+
+import (
+	"crypto/x509"
+	_ "embed"
+	"fmt"
+	"strings"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+)
+
+//go:embed staging_roots.pem
+var stagingRoots []byte
+
+// IsStagingDirectory reports whether the directory URL is the Let's Encrypt staging.
+func IsStagingDirectory(caDirURL string) bool {
+	return strings.TrimSuffix(caDirURL, "/") == LEDirectoryStaging
+}
+
+// StagingRoots returns the bundled Let's Encrypt staging roots.
+// These roots are not trusted by the systems,
+// they allow verifying the certificates issued by the staging environment (tests).
+func StagingRoots() ([]*x509.Certificate, error) {
+	certs, err := certcrypto.ParsePEMBundle(stagingRoots)
+	if err != nil {
+		return nil, fmt.Errorf("staging roots: %w", err)
+	}
+
+	return certs, nil
+}
+
+// AddStagingRoots adds the bundled Let's Encrypt staging roots to the pool.
+func AddStagingRoots(pool *x509.CertPool) error {
+	roots, err := StagingRoots()
+	if err != nil {
+		return err
+	}
+
+	for _, root := range roots {
+		pool.AddCert(root)
+	}
+
+	return nil
+}
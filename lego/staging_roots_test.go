@@ -0,0 +1,27 @@
+package lego
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsStagingDirectory(t *testing.T) {
+	testCases := []struct {
+		caDirURL string
+		assert   assert.BoolAssertionFunc
+	}{
+		{caDirURL: LEDirectoryStaging, assert: assert.True},
+		{caDirURL: LEDirectoryStaging + "/", assert: assert.True},
+		{caDirURL: LEDirectoryProduction, assert: assert.False},
+		{caDirURL: "https://localhost:14000/dir", assert: assert.False},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.caDirURL, func(t *testing.T) {
+			t.Parallel()
+
+			test.assert(t, IsStagingDirectory(test.caDirURL))
+		})
+	}
+}
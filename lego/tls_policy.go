@@ -0,0 +1,203 @@
+package lego
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	// tlsMinVersionEnvVar is the environment variable name that can be used to
+	// define the minimum TLS version of the outbound connections (`1.0`, `1.1`, `1.2`, `1.3`).
+	tlsMinVersionEnvVar = "LEGO_TLS_MIN_VERSION"
+
+	// tlsCipherSuitesEnvVar is the environment variable name that can be used to
+	// define the cipher suites (TLS 1.0 to 1.2) of the outbound connections: a comma-separated list of names.
+	tlsCipherSuitesEnvVar = "LEGO_TLS_CIPHER_SUITES"
+
+	// tlsCurvePreferencesEnvVar is the environment variable name that can be used to
+	// define the key exchange mechanisms of the outbound connections, in order of preference: a comma-separated list of names.
+	tlsCurvePreferencesEnvVar = "LEGO_TLS_CURVE_PREFERENCES"
+)
+
+// curves the supported key exchange mechanisms.
+var curves = []tls.CurveID{tls.X25519MLKEM768, tls.X25519, tls.CurveP256, tls.CurveP384, tls.CurveP521}
+
+// TLSPolicy the TLS policy of the outbound connections.
+// The zero values keep the defaults of the crypto/tls package.
+type TLSPolicy struct {
+	// MinVersion the minimum TLS version (ex: tls.VersionTLS12).
+	MinVersion uint16
+
+	// CipherSuites the enabled cipher suites (TLS 1.0 to 1.2).
+	// The cipher suites of TLS 1.3 are not configurable.
+	CipherSuites []uint16
+
+	// CurvePreferences the key exchange mechanisms, in order of preference.
+	CurvePreferences []tls.CurveID
+}
+
+// TLSPolicyFromEnv creates a TLSPolicy from the environment variables
+// LEGO_TLS_MIN_VERSION, LEGO_TLS_CIPHER_SUITES, and LEGO_TLS_CURVE_PREFERENCES.
+// Returns nil if none of the environment variables is defined.
+func TLSPolicyFromEnv() (*TLSPolicy, error) {
+	minVersion := os.Getenv(tlsMinVersionEnvVar)
+	cipherSuites := os.Getenv(tlsCipherSuitesEnvVar)
+	curvePreferences := os.Getenv(tlsCurvePreferencesEnvVar)
+
+	if minVersion == "" && cipherSuites == "" && curvePreferences == "" {
+		return nil, nil
+	}
+
+	policy := &TLSPolicy{}
+
+	var err error
+
+	if minVersion != "" {
+		policy.MinVersion, err = ParseTLSVersion(minVersion)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", tlsMinVersionEnvVar, err)
+		}
+	}
+
+	if cipherSuites != "" {
+		policy.CipherSuites, err = ParseCipherSuites(strings.Split(cipherSuites, ","))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", tlsCipherSuitesEnvVar, err)
+		}
+	}
+
+	if curvePreferences != "" {
+		policy.CurvePreferences, err = ParseCurvePreferences(strings.Split(curvePreferences, ","))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", tlsCurvePreferencesEnvVar, err)
+		}
+	}
+
+	return policy, nil
+}
+
+// Apply applies the policy to the TLS configuration.
+func (p *TLSPolicy) Apply(config *tls.Config) {
+	if p == nil || config == nil {
+		return
+	}
+
+	if p.MinVersion != 0 {
+		config.MinVersion = p.MinVersion
+	}
+
+	if len(p.CipherSuites) > 0 {
+		config.CipherSuites = slices.Clone(p.CipherSuites)
+	}
+
+	if len(p.CurvePreferences) > 0 {
+		config.CurvePreferences = slices.Clone(p.CurvePreferences)
+	}
+}
+
+// CloneTransport returns a copy of the transport with the policy applied to its TLS configuration.
+// The transport is not modified: it can be shared with other HTTP clients.
+// Only *http.Transport is supported, a nil transport is a copy of http.DefaultTransport.
+func (p *TLSPolicy) CloneTransport(rt http.RoundTripper) (*http.Transport, error) {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	tr, ok := rt.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("TLS policy: unsupported transport: %T", rt)
+	}
+
+	clone := tr.Clone()
+
+	if clone.TLSClientConfig == nil {
+		clone.TLSClientConfig = &tls.Config{}
+	}
+
+	p.Apply(clone.TLSClientConfig)
+
+	return clone, nil
+}
+
+// ParseTLSVersion parses a TLS version (`1.0`, `1.1`, `1.2`, `1.3`).
+func ParseTLSVersion(value string) (uint16, error) {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "tls") {
+	case "1.0", "10":
+		return tls.VersionTLS10, nil
+	case "1.1", "11":
+		return tls.VersionTLS11, nil
+	case "1.2", "12":
+		return tls.VersionTLS12, nil
+	case "1.3", "13":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS version: %q", value)
+	}
+}
+
+// ParseCipherSuites parses the names of cipher suites (ex: `TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256`).
+// The insecure cipher suites are allowed, to talk to the legacy servers.
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	suites := append(tls.CipherSuites(), tls.InsecureCipherSuites()...)
+
+	var ids []uint16
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		index := slices.IndexFunc(suites, func(suite *tls.CipherSuite) bool {
+			return strings.EqualFold(suite.Name, name)
+		})
+
+		if index < 0 {
+			return nil, fmt.Errorf("unsupported cipher suite: %q", name)
+		}
+
+		ids = append(ids, suites[index].ID)
+	}
+
+	if len(ids) == 0 {
+		return nil, errors.New("no cipher suite")
+	}
+
+	return ids, nil
+}
+
+// ParseCurvePreferences parses the names of key exchange mechanisms (ex: `X25519`, `P256`, `X25519MLKEM768`).
+func ParseCurvePreferences(names []string) ([]tls.CurveID, error) {
+	var ids []tls.CurveID
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		// The names of the NIST curves are accepted with and without the prefix (ex: `CurveP256`, `P256`, `P-256`).
+		normalized := strings.ReplaceAll(name, "-", "")
+
+		index := slices.IndexFunc(curves, func(curve tls.CurveID) bool {
+			return strings.EqualFold(curve.String(), normalized) || strings.EqualFold(strings.TrimPrefix(curve.String(), "Curve"), normalized)
+		})
+
+		if index < 0 {
+			return nil, fmt.Errorf("unsupported curve: %q", name)
+		}
+
+		ids = append(ids, curves[index])
+	}
+
+	if len(ids) == 0 {
+		return nil, errors.New("no curve")
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,156 @@
+package lego
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSPolicyFromEnv(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		env           map[string]string
+		expected      *TLSPolicy
+		expectedError string
+	}{
+		{
+			desc: "no environment variables",
+		},
+		{
+			desc: "all",
+			env: map[string]string{
+				tlsMinVersionEnvVar:       "1.0",
+				tlsCipherSuitesEnvVar:     "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_RSA_WITH_AES_128_CBC_SHA",
+				tlsCurvePreferencesEnvVar: "X25519,P-256,CurveP384",
+			},
+			expected: &TLSPolicy{
+				MinVersion:       tls.VersionTLS10,
+				CipherSuites:     []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_RSA_WITH_AES_128_CBC_SHA},
+				CurvePreferences: []tls.CurveID{tls.X25519, tls.CurveP256, tls.CurveP384},
+			},
+		},
+		{
+			desc: "min version only",
+			env: map[string]string{
+				tlsMinVersionEnvVar: "TLS1.3",
+			},
+			expected: &TLSPolicy{MinVersion: tls.VersionTLS13},
+		},
+		{
+			desc: "invalid min version",
+			env: map[string]string{
+				tlsMinVersionEnvVar: "1.4",
+			},
+			expectedError: `LEGO_TLS_MIN_VERSION: unsupported TLS version: "1.4"`,
+		},
+		{
+			desc: "invalid cipher suite",
+			env: map[string]string{
+				tlsCipherSuitesEnvVar: "TLS_FOO",
+			},
+			expectedError: `LEGO_TLS_CIPHER_SUITES: unsupported cipher suite: "TLS_FOO"`,
+		},
+		{
+			desc: "invalid curve",
+			env: map[string]string{
+				tlsCurvePreferencesEnvVar: "P-224",
+			},
+			expectedError: `LEGO_TLS_CURVE_PREFERENCES: unsupported curve: "P-224"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Setenv(tlsMinVersionEnvVar, "")
+			t.Setenv(tlsCipherSuitesEnvVar, "")
+			t.Setenv(tlsCurvePreferencesEnvVar, "")
+
+			for k, v := range test.env {
+				t.Setenv(k, v)
+			}
+
+			policy, err := TLSPolicyFromEnv()
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, policy)
+		})
+	}
+}
+
+func TestTLSPolicy_CloneTransport(t *testing.T) {
+	policy := &TLSPolicy{MinVersion: tls.VersionTLS13}
+
+	tr := &http.Transport{TLSClientConfig: &tls.Config{}}
+
+	clone, err := policy.CloneTransport(tr)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint16(tls.VersionTLS13), clone.TLSClientConfig.MinVersion)
+	assert.Zero(t, tr.TLSClientConfig.MinVersion)
+
+	defaultTLSConfig := http.DefaultTransport.(*http.Transport).TLSClientConfig
+
+	clone, err = policy.CloneTransport(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint16(tls.VersionTLS13), clone.TLSClientConfig.MinVersion)
+	assert.Same(t, defaultTLSConfig, http.DefaultTransport.(*http.Transport).TLSClientConfig)
+
+	_, err = policy.CloneTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, nil }))
+	require.EqualError(t, err, "TLS policy: unsupported transport: lego.roundTripperFunc")
+}
+
+func TestNewClient_tlsPolicy(t *testing.T) {
+	server := tester.MockACMEServer().BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err, "Could not generate test key")
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     new(registration.Resource),
+		privatekey: key,
+	}
+
+	config := NewConfig(user)
+	config.CADirURL = server.URL + "/dir"
+	config.HTTPClient = server.Client()
+
+	httpClient := config.HTTPClient
+	transport := config.HTTPClient.Transport.(*http.Transport)
+	minVersion := transport.TLSClientConfig.MinVersion
+
+	config.TLSPolicy = &TLSPolicy{
+		MinVersion:       tls.VersionTLS13,
+		CurvePreferences: []tls.CurveID{tls.X25519},
+	}
+
+	client, err := NewClient(config)
+	require.NoError(t, err, "Could not create client")
+
+	assert.NotNil(t, client)
+
+	// The HTTP client of the configuration is not modified.
+	assert.Same(t, httpClient, config.HTTPClient)
+	assert.Same(t, transport, config.HTTPClient.Transport)
+	assert.Equal(t, minVersion, transport.TLSClientConfig.MinVersion)
+	assert.Nil(t, transport.TLSClientConfig.CurvePreferences)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
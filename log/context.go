@@ -0,0 +1,21 @@
+package log
+
+import "context"
+
+type printerKey struct{}
+
+// NewContext returns a copy of the context carrying the Printer.
+// The functions without access to the client (ex: wait.ForContext) write their log entries with this Printer.
+func NewContext(ctx context.Context, p Printer) context.Context {
+	return context.WithValue(ctx, printerKey{}, p)
+}
+
+// FromContext returns the Printer of the context,
+// or the Printer of the global loggers if the context doesn't carry a Printer.
+func FromContext(ctx context.Context) Printer {
+	if p, ok := ctx.Value(printerKey{}).(Printer); ok {
+		return p
+	}
+
+	return Printer{}
+}
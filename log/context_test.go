@@ -0,0 +1,23 @@
+package log
+
+import (
+	"bytes"
+	stdlog "log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	ctx := NewContext(t.Context(), With(stdlog.New(buf, "", 0)))
+
+	FromContext(ctx).Infof("Wait for %s", "propagation")
+
+	assert.Equal(t, "[INFO] Wait for propagation\n", buf.String())
+}
+
+func TestFromContext_empty(t *testing.T) {
+	assert.Equal(t, Printer{}, FromContext(t.Context()))
+}
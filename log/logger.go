@@ -1,6 +1,7 @@
 package log
 
 import (
+	"fmt"
 	"log"
 	"os"
 )
@@ -8,6 +9,10 @@ import (
 // Logger is an optional custom logger.
 var Logger StdLogger = log.New(os.Stderr, "", log.LstdFlags)
 
+// Structured is an optional structured logger.
+// If not nil, it's used instead of Logger, except for the fatal log entries.
+var Structured StructuredLogger
+
 // StdLogger interface for Standard Logger.
 type StdLogger interface {
 	Fatal(args ...any)
@@ -18,6 +23,14 @@ type StdLogger interface {
 	Printf(format string, args ...any)
 }
 
+// StructuredLogger interface for a leveled logger with key/value context (ex: *slog.Logger).
+type StructuredLogger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
 // Fatal writes a log entry.
 // It uses Logger if not nil, otherwise it uses the default log.Logger.
 func Fatal(args ...any) {
@@ -31,29 +44,159 @@ func Fatalf(format string, args ...any) {
 }
 
 // Print writes a log entry.
-// It uses Logger if not nil, otherwise it uses the default log.Logger.
+// It uses Structured if not nil, otherwise it uses Logger.
 func Print(args ...any) {
+	if Structured != nil {
+		Structured.Info(fmt.Sprint(args...))
+		return
+	}
+
 	Logger.Print(args...)
 }
 
 // Println writes a log entry.
-// It uses Logger if not nil, otherwise it uses the default log.Logger.
+// It uses Structured if not nil, otherwise it uses Logger.
 func Println(args ...any) {
-	Logger.Println(args...)
+	Printer{}.Println(args...)
 }
 
 // Printf writes a log entry.
-// It uses Logger if not nil, otherwise it uses the default log.Logger.
+// It uses Structured if not nil, otherwise it uses Logger.
 func Printf(format string, args ...any) {
-	Logger.Printf(format, args...)
+	Printer{}.Printf(format, args...)
 }
 
 // Warnf writes a log entry.
 func Warnf(format string, args ...any) {
-	Printf("[WARN] "+format, args...)
+	Printer{}.Warnf(format, args...)
 }
 
 // Infof writes a log entry.
 func Infof(format string, args ...any) {
-	Printf("[INFO] "+format, args...)
+	Printer{}.Infof(format, args...)
+}
+
+// Debugf writes a log entry.
+func Debugf(format string, args ...any) {
+	Printer{}.Debugf(format, args...)
+}
+
+// Errorf writes a log entry.
+func Errorf(format string, args ...any) {
+	Printer{}.Errorf(format, args...)
+}
+
+// Printer writes the log entries with a specific logger (ex: the logger of an ACME client).
+// It uses Structured or Logger if the logger is nil.
+type Printer struct {
+	logger     StdLogger
+	structured StructuredLogger
+
+	// args the key/value context of the log entries (only used by the structured loggers).
+	args []any
+}
+
+// With creates a Printer that writes the log entries with the logger.
+func With(logger StdLogger) Printer {
+	return Printer{logger: logger}
+}
+
+// WithStructured creates a Printer that writes the log entries with the structured logger.
+func WithStructured(logger StructuredLogger) Printer {
+	return Printer{structured: logger}
+}
+
+// With returns a Printer that adds the key/value pairs (ex: "domain", "example.com") to the log entries.
+// The key/value pairs are only used by the structured loggers:
+// the messages of the standard loggers already contain the important values.
+func (p Printer) With(args ...any) Printer {
+	p.args = append(p.args[:len(p.args):len(p.args)], args...)
+
+	return p
+}
+
+// Println writes a log entry.
+func (p Printer) Println(args ...any) {
+	if structured := p.getStructured(); structured != nil {
+		structured.Info(sprintln(args...), p.args...)
+		return
+	}
+
+	p.get().Println(args...)
+}
+
+// Printf writes a log entry.
+func (p Printer) Printf(format string, args ...any) {
+	if structured := p.getStructured(); structured != nil {
+		structured.Info(fmt.Sprintf(format, args...), p.args...)
+		return
+	}
+
+	p.get().Printf(format, args...)
+}
+
+// Warnf writes a log entry.
+func (p Printer) Warnf(format string, args ...any) {
+	if structured := p.getStructured(); structured != nil {
+		structured.Warn(fmt.Sprintf(format, args...), p.args...)
+		return
+	}
+
+	p.get().Printf("[WARN] "+format, args...)
+}
+
+// Infof writes a log entry.
+func (p Printer) Infof(format string, args ...any) {
+	if structured := p.getStructured(); structured != nil {
+		structured.Info(fmt.Sprintf(format, args...), p.args...)
+		return
+	}
+
+	p.get().Printf("[INFO] "+format, args...)
+}
+
+// Debugf writes a log entry.
+// The debug entries are only written by the structured loggers: the standard loggers don't have levels to filter them.
+func (p Printer) Debugf(format string, args ...any) {
+	if structured := p.getStructured(); structured != nil {
+		structured.Debug(fmt.Sprintf(format, args...), p.args...)
+	}
+}
+
+// Errorf writes a log entry.
+func (p Printer) Errorf(format string, args ...any) {
+	if structured := p.getStructured(); structured != nil {
+		structured.Error(fmt.Sprintf(format, args...), p.args...)
+		return
+	}
+
+	p.get().Printf("[ERROR] "+format, args...)
+}
+
+func (p Printer) get() StdLogger {
+	if p.logger != nil {
+		return p.logger
+	}
+
+	return Logger
+}
+
+func (p Printer) getStructured() StructuredLogger {
+	if p.structured != nil {
+		return p.structured
+	}
+
+	if p.logger != nil {
+		// An explicit standard logger has precedence over the global structured logger.
+		return nil
+	}
+
+	return Structured
+}
+
+// sprintln formats like fmt.Sprintln, without the trailing newline.
+func sprintln(args ...any) string {
+	msg := fmt.Sprintln(args...)
+
+	return msg[:len(msg)-1]
 }
@@ -0,0 +1,90 @@
+package log
+
+import (
+	"bytes"
+	stdlog "log"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrinter_structured(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	}))
+
+	printer := WithStructured(logger).With("domain", "example.com")
+
+	printer.With("challenge", "http-01").Infof("[%s] acme: Trying to solve %s", "example.com", "HTTP-01")
+	printer.Warnf("cleaning up failed: %v", "oops")
+	printer.Println("foo", "bar")
+	printer.Debugf("propagation check: %v", "NXDOMAIN")
+	printer.Errorf("challenge option error: %v", "oops")
+
+	expected := `level=INFO msg="[example.com] acme: Trying to solve HTTP-01" domain=example.com challenge=http-01
+level=WARN msg="cleaning up failed: oops" domain=example.com
+level=INFO msg="foo bar" domain=example.com
+level=DEBUG msg="propagation check: NXDOMAIN" domain=example.com
+level=ERROR msg="challenge option error: oops" domain=example.com
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestPrinter_std(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	printer := With(stdlog.New(buf, "", 0)).With("domain", "example.com")
+
+	printer.Infof("[%s] acme: Trying to solve %s", "example.com", "HTTP-01")
+	printer.Warnf("cleaning up failed: %v", "oops")
+	printer.Debugf("propagation check: %v", "NXDOMAIN")
+	printer.Errorf("challenge option error: %v", "oops")
+
+	// The debug entries are not written.
+	expected := `[INFO] [example.com] acme: Trying to solve HTTP-01
+[WARN] cleaning up failed: oops
+[ERROR] challenge option error: oops
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestPrinter_With(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == slog.LevelKey {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	}))
+
+	printer := WithStructured(logger).With("order", "https://example.com/order/1")
+
+	// The context of a derived printer doesn't leak into the other printers.
+	a := printer.With("domain", "a.example.com")
+	b := printer.With("domain", "b.example.com")
+
+	a.Infof("a")
+	b.Infof("b")
+
+	expected := `msg=a order=https://example.com/order/1 domain=a.example.com
+msg=b order=https://example.com/order/1 domain=b.example.com
+`
+
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,98 @@
+// Package metrics records the metrics of the ACME operations (orders, challenges, DNS propagation, ACME requests).
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// The results of the operations.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Recorder records the metrics of the ACME operations.
+// The methods must be safe for concurrent use.
+type Recorder interface {
+	// ObserveOrder records a certificate request (one or more ACME orders).
+	ObserveOrder(result string, duration time.Duration)
+
+	// ObserveChallenge records the resolution of a challenge.
+	ObserveChallenge(chlgType, result string)
+
+	// ObservePropagation records the wait for the propagation of a DNS record.
+	ObservePropagation(provider string, duration time.Duration)
+
+	// ObserveRoundTrip records an HTTP request to the ACME server.
+	// The status code is 0 if the request has failed.
+	ObserveRoundTrip(method string, statusCode int, duration time.Duration)
+}
+
+// Result returns the result of an operation.
+func Result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+
+	return ResultSuccess
+}
+
+// OrNoop returns the recorder, or a recorder that does nothing if the recorder is nil.
+func OrNoop(recorder Recorder) Recorder {
+	if recorder == nil {
+		return noop{}
+	}
+
+	return recorder
+}
+
+type noop struct{}
+
+func (noop) ObserveOrder(string, time.Duration) {}
+
+func (noop) ObserveChallenge(string, string) {}
+
+func (noop) ObservePropagation(string, time.Duration) {}
+
+func (noop) ObserveRoundTrip(string, int, time.Duration) {}
+
+// Transport records the latency of the HTTP requests.
+type Transport struct {
+	recorder Recorder
+	next     http.RoundTripper
+}
+
+// NewTransport creates a Transport.
+// If next is nil, http.DefaultTransport is used.
+func NewTransport(recorder Recorder, next http.RoundTripper) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Transport{recorder: recorder, next: next}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	resp, err := t.next.RoundTrip(req)
+
+	var statusCode int
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+
+	t.recorder.ObserveRoundTrip(req.Method, statusCode, time.Since(start))
+
+	return resp, err
+}
+
+func statusCodeLabel(statusCode int) string {
+	if statusCode == 0 {
+		return "error"
+	}
+
+	return strconv.Itoa(statusCode)
+}
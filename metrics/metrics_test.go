@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTrip struct {
+	method     string
+	statusCode int
+}
+
+type fakeRecorder struct {
+	noop
+
+	mu         sync.Mutex
+	roundTrips []roundTrip
+}
+
+func (f *fakeRecorder) ObserveRoundTrip(method string, statusCode int, _ time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.roundTrips = append(f.roundTrips, roundTrip{method: method, statusCode: statusCode})
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPost {
+			rw.WriteHeader(http.StatusCreated)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	recorder := &fakeRecorder{}
+
+	client := &http.Client{Transport: NewTransport(recorder, nil)}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	resp, err = client.Post(server.URL, "application/json", strings.NewReader("{}"))
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	server.Close()
+
+	_, err = client.Head(server.URL)
+	require.Error(t, err)
+
+	expected := []roundTrip{
+		{method: http.MethodGet, statusCode: http.StatusOK},
+		{method: http.MethodPost, statusCode: http.StatusCreated},
+		{method: http.MethodHead, statusCode: 0},
+	}
+
+	assert.Equal(t, expected, recorder.roundTrips)
+}
+
+func TestCollector(t *testing.T) {
+	collector := NewCollector()
+
+	collector.ObserveOrder(ResultSuccess, 10*time.Second)
+	collector.ObserveOrder(ResultFailure, 2*time.Second)
+	collector.ObserveChallenge("http-01", ResultSuccess)
+	collector.ObserveChallenge("dns-01", Result(errors.New("timeout")))
+	collector.ObserveChallenge("dns-01", Result(nil))
+	collector.ObservePropagation("cloudflare", 30*time.Second)
+	collector.ObserveRoundTrip(http.MethodPost, http.StatusCreated, 100*time.Millisecond)
+	collector.ObserveRoundTrip(http.MethodPost, 0, time.Second)
+
+	expected := `
+# HELP lego_challenges_total The number of challenges by type and result.
+# TYPE lego_challenges_total counter
+lego_challenges_total{result="failure",type="dns-01"} 1
+lego_challenges_total{result="success",type="dns-01"} 1
+lego_challenges_total{result="success",type="http-01"} 1
+# HELP lego_orders_total The number of certificate requests by result.
+# TYPE lego_orders_total counter
+lego_orders_total{result="failure"} 1
+lego_orders_total{result="success"} 1
+`
+
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "lego_orders_total", "lego_challenges_total")
+	require.NoError(t, err)
+
+	assert.Equal(t, 10, testutil.CollectAndCount(collector))
+}
+
+func TestOrNoop(t *testing.T) {
+	assert.Equal(t, noop{}, OrNoop(nil))
+
+	collector := NewCollector()
+
+	assert.Same(t, collector, OrNoop(collector))
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "lego"
+
+var _ prometheus.Collector = (*Collector)(nil)
+
+// Collector a Recorder exposing the metrics as Prometheus metrics.
+type Collector struct {
+	orders         *prometheus.CounterVec
+	orderDurations *prometheus.HistogramVec
+	challenges     *prometheus.CounterVec
+	propagations   *prometheus.HistogramVec
+	roundTrips     *prometheus.HistogramVec
+}
+
+// NewCollector creates a Collector.
+// The Collector must be registered (ex: prometheus.MustRegister(collector)).
+func NewCollector() *Collector {
+	return &Collector{
+		orders: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "orders_total",
+			Help:      "The number of certificate requests by result.",
+		}, []string{"result"}),
+		orderDurations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "order_duration_seconds",
+			Help:      "The duration of the certificate requests by result.",
+			Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1200},
+		}, []string{"result"}),
+		challenges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "challenges_total",
+			Help:      "The number of challenges by type and result.",
+		}, []string{"type", "result"}),
+		propagations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "dns_propagation_duration_seconds",
+			Help:      "The wait for the propagation of the DNS records by DNS provider.",
+			Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 1200},
+		}, []string{"provider"}),
+		roundTrips: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "acme_request_duration_seconds",
+			Help:      "The latency of the HTTP requests to the ACME server by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.orders.Describe(ch)
+	c.orderDurations.Describe(ch)
+	c.challenges.Describe(ch)
+	c.propagations.Describe(ch)
+	c.roundTrips.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.orders.Collect(ch)
+	c.orderDurations.Collect(ch)
+	c.challenges.Collect(ch)
+	c.propagations.Collect(ch)
+	c.roundTrips.Collect(ch)
+}
+
+// ObserveOrder implements Recorder.
+func (c *Collector) ObserveOrder(result string, duration time.Duration) {
+	c.orders.WithLabelValues(result).Inc()
+	c.orderDurations.WithLabelValues(result).Observe(duration.Seconds())
+}
+
+// ObserveChallenge implements Recorder.
+func (c *Collector) ObserveChallenge(chlgType, result string) {
+	c.challenges.WithLabelValues(chlgType, result).Inc()
+}
+
+// ObservePropagation implements Recorder.
+func (c *Collector) ObservePropagation(provider string, duration time.Duration) {
+	c.propagations.WithLabelValues(provider).Observe(duration.Seconds())
+}
+
+// ObserveRoundTrip implements Recorder.
+func (c *Collector) ObserveRoundTrip(method string, statusCode int, duration time.Duration) {
+	c.roundTrips.WithLabelValues(method, statusCodeLabel(statusCode)).Observe(duration.Seconds())
+}
@@ -0,0 +1,38 @@
+package env
+
+import (
+	"fmt"
+	"time"
+)
+
+// ValidateTTL checks that the TTL is within the bounds supported by a provider.
+// A bound equal to 0 is ignored.
+func ValidateTTL(ttl, minTTL, maxTTL int) error {
+	if minTTL > 0 && ttl < minTTL {
+		return fmt.Errorf("invalid TTL, TTL (%d) must be greater than %d", ttl, minTTL)
+	}
+
+	if maxTTL > 0 && ttl > maxTTL {
+		return fmt.Errorf("invalid TTL, TTL (%d) must be lower than %d", ttl, maxTTL)
+	}
+
+	return nil
+}
+
+// ValidatePropagation checks that the propagation timeout and the polling interval are consistent.
+// A value equal to 0 is considered as not defined.
+func ValidatePropagation(timeout, interval time.Duration) error {
+	if timeout < 0 {
+		return fmt.Errorf("invalid propagation timeout, the timeout (%s) must be positive", timeout)
+	}
+
+	if interval < 0 {
+		return fmt.Errorf("invalid polling interval, the interval (%s) must be positive", interval)
+	}
+
+	if timeout > 0 && interval > timeout {
+		return fmt.Errorf("invalid polling interval, the interval (%s) must be lower than the propagation timeout (%s)", interval, timeout)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package env
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTTL(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		ttl      int
+		minTTL   int
+		maxTTL   int
+		expected string
+	}{
+		{
+			desc:   "valid",
+			ttl:    120,
+			minTTL: 60,
+			maxTTL: 3600,
+		},
+		{
+			desc:   "no bounds",
+			ttl:    1,
+			minTTL: 0,
+			maxTTL: 0,
+		},
+		{
+			desc:     "lower than the minimum",
+			ttl:      1,
+			minTTL:   60,
+			expected: "invalid TTL, TTL (1) must be greater than 60",
+		},
+		{
+			desc:     "greater than the maximum",
+			ttl:      7200,
+			minTTL:   60,
+			maxTTL:   3600,
+			expected: "invalid TTL, TTL (7200) must be lower than 3600",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidateTTL(test.ttl, test.minTTL, test.maxTTL)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestValidatePropagation(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		timeout  time.Duration
+		interval time.Duration
+		expected string
+	}{
+		{
+			desc:     "valid",
+			timeout:  time.Minute,
+			interval: 2 * time.Second,
+		},
+		{
+			desc: "not defined",
+		},
+		{
+			desc:     "negative timeout",
+			timeout:  -time.Second,
+			expected: "invalid propagation timeout, the timeout (-1s) must be positive",
+		},
+		{
+			desc:     "negative interval",
+			interval: -time.Second,
+			expected: "invalid polling interval, the interval (-1s) must be positive",
+		},
+		{
+			desc:     "interval greater than the timeout",
+			timeout:  time.Second,
+			interval: time.Minute,
+			expected: "invalid polling interval, the interval (1m0s) must be lower than the propagation timeout (1s)",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidatePropagation(test.timeout, test.interval)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
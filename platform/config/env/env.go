@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -66,7 +67,7 @@ func GetWithFallback(groups ...[]string) (map[string]string, error) {
 			return nil, errors.New("undefined environment variable names")
 		}
 
-		value, envVar := getOneWithFallback(names[0], names[1:]...)
+		value, envVar := getOneWithFallback(Lookup, names[0], names[1:]...)
 		if value == "" {
 			missingEnvVars = append(missingEnvVars, envVar)
 			continue
@@ -83,7 +84,12 @@ func GetWithFallback(groups ...[]string) (map[string]string, error) {
 }
 
 func GetOneWithFallback[T any](main string, defaultValue T, fn func(string) (T, error), names ...string) T {
-	v, _ := getOneWithFallback(main, names...)
+	return GetOneWithFallbackFrom(Lookup, main, defaultValue, fn, names...)
+}
+
+// GetOneWithFallbackFrom is GetOneWithFallback with the variables read from lookup.
+func GetOneWithFallbackFrom[T any](lookup LookupFunc, main string, defaultValue T, fn func(string) (T, error), names ...string) T {
+	v, _ := getOneWithFallback(lookup, main, names...)
 
 	value, err := fn(v)
 	if err != nil {
@@ -93,14 +99,14 @@ func GetOneWithFallback[T any](main string, defaultValue T, fn func(string) (T,
 	return value
 }
 
-func getOneWithFallback(main string, names ...string) (string, string) {
-	value := GetOrFile(main)
+func getOneWithFallback(lookup LookupFunc, main string, names ...string) (string, string) {
+	value := lookup.GetOrFile(main)
 	if value != "" {
 		return value, main
 	}
 
 	for _, name := range names {
-		value := GetOrFile(name)
+		value := lookup.GetOrFile(name)
 		if value != "" {
 			return value, main
 		}
@@ -112,29 +118,49 @@ func getOneWithFallback(main string, names ...string) (string, string) {
 // GetOrDefaultString returns the given environment variable value as a string.
 // Returns the default if the env var cannot be found.
 func GetOrDefaultString(envVar, defaultValue string) string {
-	return getOrDefault(envVar, defaultValue, ParseString)
+	return LookupFunc(Lookup).GetOrDefaultString(envVar, defaultValue)
 }
 
 // GetOrDefaultBool returns the given environment variable value as a boolean.
 // Returns the default if the env var cannot be coopered to a boolean, or is not found.
 func GetOrDefaultBool(envVar string, defaultValue bool) bool {
-	return getOrDefault(envVar, defaultValue, strconv.ParseBool)
+	return LookupFunc(Lookup).GetOrDefaultBool(envVar, defaultValue)
 }
 
 // GetOrDefaultInt returns the given environment variable value as an integer.
 // Returns the default if the env var cannot be coopered to an int, or is not found.
 func GetOrDefaultInt(envVar string, defaultValue int) int {
-	return getOrDefault(envVar, defaultValue, strconv.Atoi)
+	return LookupFunc(Lookup).GetOrDefaultInt(envVar, defaultValue)
 }
 
 // GetOrDefaultSecond returns the given environment variable value as a time.Duration (second).
 // Returns the default if the env var cannot be coopered to an int, or is not found.
 func GetOrDefaultSecond(envVar string, defaultValue time.Duration) time.Duration {
-	return getOrDefault(envVar, defaultValue, ParseSecond)
+	return LookupFunc(Lookup).GetOrDefaultSecond(envVar, defaultValue)
+}
+
+// GetOrDefaultString is the package function GetOrDefaultString with the variables read from l.
+func (l LookupFunc) GetOrDefaultString(envVar, defaultValue string) string {
+	return getOrDefault(l, envVar, defaultValue, ParseString)
 }
 
-func getOrDefault[T any](envVar string, defaultValue T, fn func(string) (T, error)) T {
-	v, err := fn(GetOrFile(envVar))
+// GetOrDefaultBool is the package function GetOrDefaultBool with the variables read from l.
+func (l LookupFunc) GetOrDefaultBool(envVar string, defaultValue bool) bool {
+	return getOrDefault(l, envVar, defaultValue, strconv.ParseBool)
+}
+
+// GetOrDefaultInt is the package function GetOrDefaultInt with the variables read from l.
+func (l LookupFunc) GetOrDefaultInt(envVar string, defaultValue int) int {
+	return getOrDefault(l, envVar, defaultValue, strconv.Atoi)
+}
+
+// GetOrDefaultSecond is the package function GetOrDefaultSecond with the variables read from l.
+func (l LookupFunc) GetOrDefaultSecond(envVar string, defaultValue time.Duration) time.Duration {
+	return getOrDefault(l, envVar, defaultValue, ParseSecond)
+}
+
+func getOrDefault[T any](lookup LookupFunc, envVar string, defaultValue T, fn func(string) (T, error)) T {
+	v, err := fn(lookup.GetOrFile(envVar))
 	if err != nil {
 		return defaultValue
 	}
@@ -145,17 +171,24 @@ func getOrDefault[T any](envVar string, defaultValue T, fn func(string) (T, erro
 // GetOrFile Attempts to resolve 'key' as an environment variable.
 // Failing that, it will check to see if '<key>_FILE' exists.
 // If so, it will attempt to read from the referenced file to populate a value.
+// Failing that, it will look for a systemd credential named '<key>' (see [GetCredential]).
 func GetOrFile(envVar string) string {
-	envVarValue := os.Getenv(envVar)
+	return LookupFunc(Lookup).GetOrFile(envVar)
+}
+
+// GetOrFile is the package function GetOrFile with the variables read from l.
+func (l LookupFunc) GetOrFile(envVar string) string {
+	envVarValue, _ := l(envVar)
 	if envVarValue != "" {
 		return envVarValue
 	}
 
-	fileVar := envVar + "_FILE"
+	fileVar := envVar + fileSuffix
 
-	fileVarValue := os.Getenv(fileVar)
+	fileVarValue, _ := l(fileVar)
 	if fileVarValue == "" {
-		return envVarValue
+		value, _ := l.GetCredential(envVar)
+		return value
 	}
 
 	fileContents, err := os.ReadFile(fileVarValue)
@@ -167,6 +200,34 @@ func GetOrFile(envVar string) string {
 	return strings.TrimSuffix(string(fileContents), "\n")
 }
 
+// GetCredential reads the systemd credential named 'name'
+// from the directory defined by the 'CREDENTIALS_DIRECTORY' environment variable (LoadCredential=, SetCredential=, etc.).
+// Returns false if the directory is not defined or if the credential doesn't exist.
+func GetCredential(name string) (string, bool) {
+	return LookupFunc(Lookup).GetCredential(name)
+}
+
+// GetCredential is the package function GetCredential with the variables read from l.
+func (l LookupFunc) GetCredential(name string) (string, bool) {
+	dir, _ := l(credentialsDirectoryEnvVar)
+	if dir == "" || name == "" || strings.ContainsAny(name, `/\`) {
+		return "", false
+	}
+
+	path := filepath.Join(dir, name)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Failed to read the credential %s (defined by env var %s): %s", path, credentialsDirectoryEnvVar, err)
+		}
+
+		return "", false
+	}
+
+	return strings.TrimSuffix(string(content), "\n"), true
+}
+
 // ParseSecond parses env var value (string) to a second (time.Duration).
 func ParseSecond(s string) (time.Duration, error) {
 	v, err := strconv.Atoi(s)
@@ -2,6 +2,7 @@ package env
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -409,6 +410,47 @@ func TestGetOrFile_PrefersEnvVars(t *testing.T) {
 	assert.Equal(t, "lego_env", value)
 }
 
+func TestGetOrFile_ReadsCredentials(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "TEST_LEGO_ENV_VAR"), []byte("lego_credential\n"), 0o600)
+	require.NoError(t, err)
+
+	t.Setenv("CREDENTIALS_DIRECTORY", dir)
+	t.Setenv("TEST_LEGO_ENV_VAR", "")
+	t.Setenv("TEST_LEGO_ENV_VAR_FILE", "")
+
+	assert.Equal(t, "lego_credential", GetOrFile("TEST_LEGO_ENV_VAR"))
+
+	t.Setenv("TEST_LEGO_ENV_VAR", "lego_env")
+
+	assert.Equal(t, "lego_env", GetOrFile("TEST_LEGO_ENV_VAR"))
+}
+
+func TestGetCredential(t *testing.T) {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "TEST_LEGO_CREDENTIAL"), []byte("secret"), 0o600)
+	require.NoError(t, err)
+
+	t.Setenv("CREDENTIALS_DIRECTORY", dir)
+
+	value, ok := GetCredential("TEST_LEGO_CREDENTIAL")
+	assert.True(t, ok)
+	assert.Equal(t, "secret", value)
+
+	_, ok = GetCredential("TEST_LEGO_MISSING")
+	assert.False(t, ok)
+
+	_, ok = GetCredential("../TEST_LEGO_CREDENTIAL")
+	assert.False(t, ok)
+
+	t.Setenv("CREDENTIALS_DIRECTORY", "")
+
+	_, ok = GetCredential("TEST_LEGO_CREDENTIAL")
+	assert.False(t, ok)
+}
+
 func TestParsePairs(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -0,0 +1,98 @@
+package env
+
+import (
+	"maps"
+	"os"
+	"sync"
+)
+
+// LookupFunc looks up the value of an environment variable.
+type LookupFunc func(key string) (string, bool)
+
+var (
+	lookupMu sync.RWMutex
+	lookupFn LookupFunc = os.LookupEnv
+)
+
+// scopeMu serializes the calls of WithLookup.
+var scopeMu sync.Mutex
+
+// SetLookup replaces the source of the environment variables read by this package
+// (and so by the DNS providers): the process environment (os.LookupEnv) by default.
+// It allows an application embedding lego to isolate the configuration of the providers from its own environment:
+//
+//	// CLOUDFLARE_DNS_API_TOKEN is read from MYAPP_CLOUDFLARE_DNS_API_TOKEN.
+//	env.SetLookup(env.WithPrefix("MYAPP_"))
+//
+//	// The variables are only read from the map.
+//	env.SetLookup(env.FromMap(map[string]string{"CLOUDFLARE_DNS_API_TOKEN": "secret"}))
+//
+// A nil function restores the process environment.
+// The SDKs used by some providers (AWS, Azure, Google Cloud, etc.) read the process environment by themselves:
+// use the Config structs to configure them programmatically.
+func SetLookup(fn LookupFunc) {
+	lookupMu.Lock()
+	defer lookupMu.Unlock()
+
+	if fn == nil {
+		fn = os.LookupEnv
+	}
+
+	lookupFn = fn
+}
+
+// WithLookup calls fn with the variables read from lookup, then restores the previous source.
+// The source is process-wide: the calls of WithLookup are serialized,
+// and the other goroutines read the variables from lookup during the call of fn.
+func WithLookup(lookup LookupFunc, fn func()) {
+	scopeMu.Lock()
+	defer scopeMu.Unlock()
+
+	lookupMu.Lock()
+	previous := lookupFn
+	lookupFn = lookup
+	lookupMu.Unlock()
+
+	defer func() {
+		lookupMu.Lock()
+		lookupFn = previous
+		lookupMu.Unlock()
+	}()
+
+	fn()
+}
+
+// Lookup looks up the value of an environment variable from the source defined by SetLookup.
+func Lookup(key string) (string, bool) {
+	lookupMu.RLock()
+	fn := lookupFn
+	lookupMu.RUnlock()
+
+	return fn(key)
+}
+
+// Getenv returns the value of an environment variable from the source defined by SetLookup.
+// Returns an empty string if the variable is not defined.
+func Getenv(key string) string {
+	value, _ := Lookup(key)
+
+	return value
+}
+
+// WithPrefix returns a LookupFunc reading the process environment with a prefix added to the names of the variables.
+func WithPrefix(prefix string) LookupFunc {
+	return func(key string) (string, bool) {
+		return os.LookupEnv(prefix + key)
+	}
+}
+
+// FromMap returns a LookupFunc reading the variables from a map, without access to the process environment.
+func FromMap(values map[string]string) LookupFunc {
+	values = maps.Clone(values)
+
+	return func(key string) (string, bool) {
+		value, ok := values[key]
+
+		return value, ok
+	}
+}
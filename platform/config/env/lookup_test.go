@@ -0,0 +1,80 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLookup_withPrefix(t *testing.T) {
+	t.Setenv("TEST_LEGO_LOOKUP_VAR", "raw")
+	t.Setenv("MYAPP_TEST_LEGO_LOOKUP_VAR", "prefixed")
+
+	SetLookup(WithPrefix("MYAPP_"))
+	t.Cleanup(func() { SetLookup(nil) })
+
+	assert.Equal(t, "prefixed", GetOrFile("TEST_LEGO_LOOKUP_VAR"))
+
+	values, err := Get("TEST_LEGO_LOOKUP_VAR")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"TEST_LEGO_LOOKUP_VAR": "prefixed"}, values)
+}
+
+func TestSetLookup_fromMap(t *testing.T) {
+	t.Setenv("TEST_LEGO_LOOKUP_VAR", "raw")
+
+	file := filepath.Join(t.TempDir(), "secret")
+
+	err := os.WriteFile(file, []byte("from-file\n"), 0o600)
+	require.NoError(t, err)
+
+	values := map[string]string{
+		"TEST_LEGO_LOOKUP_INT":       "42",
+		"TEST_LEGO_LOOKUP_FILE_FILE": file,
+	}
+
+	SetLookup(FromMap(values))
+	t.Cleanup(func() { SetLookup(nil) })
+
+	// The map is copied.
+	values["TEST_LEGO_LOOKUP_INT"] = "1"
+
+	assert.Empty(t, GetOrFile("TEST_LEGO_LOOKUP_VAR"))
+	assert.Equal(t, 42, GetOrDefaultInt("TEST_LEGO_LOOKUP_INT", 0))
+	assert.Equal(t, "from-file", GetOrFile("TEST_LEGO_LOOKUP_FILE"))
+
+	_, err = Get("TEST_LEGO_LOOKUP_VAR")
+	require.EqualError(t, err, "some credentials information are missing: TEST_LEGO_LOOKUP_VAR")
+}
+
+func TestSetLookup_nil(t *testing.T) {
+	t.Setenv("TEST_LEGO_LOOKUP_VAR", "raw")
+
+	SetLookup(FromMap(nil))
+	t.Cleanup(func() { SetLookup(nil) })
+
+	assert.Empty(t, Getenv("TEST_LEGO_LOOKUP_VAR"))
+
+	SetLookup(nil)
+
+	assert.Equal(t, "raw", Getenv("TEST_LEGO_LOOKUP_VAR"))
+}
+
+func TestWithLookup(t *testing.T) {
+	t.Setenv("TEST_LEGO_LOOKUP_VAR", "raw")
+
+	var value string
+
+	WithLookup(FromMap(nil), func() {
+		value = GetOrDefaultString("TEST_LEGO_LOOKUP_VAR", "default")
+	})
+
+	assert.Equal(t, "default", value)
+
+	// The previous source is restored.
+	assert.Equal(t, "raw", GetOrFile("TEST_LEGO_LOOKUP_VAR"))
+}
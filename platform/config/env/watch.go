@@ -0,0 +1,89 @@
+package env
+
+import (
+	"crypto/sha256"
+	"os"
+	"sync"
+)
+
+const fileSuffix = "_FILE"
+
+// credentialsDirectoryEnvVar the directory of the systemd credentials.
+// https://systemd.io/CREDENTIALS/
+const credentialsDirectoryEnvVar = "CREDENTIALS_DIRECTORY"
+
+// FileWatcher detects the changes of the files referenced by the '<key>_FILE' environment variables.
+// It allows to notice credentials rotated by an external system (e.g. Vault agent).
+type FileWatcher struct {
+	mu    sync.Mutex
+	files map[string]string
+	state map[string][sha256.Size]byte
+}
+
+// NewFileWatcher creates a FileWatcher for the '<key>_FILE' environment variables of the keys
+// that are used as a fallback (i.e. '<key>' is not defined).
+func NewFileWatcher(keys ...string) *FileWatcher {
+	w := &FileWatcher{
+		files: CredentialFiles(keys...),
+		state: make(map[string][sha256.Size]byte),
+	}
+
+	for envVar, path := range w.files {
+		w.state[envVar] = fileHash(path)
+	}
+
+	return w
+}
+
+// Changed reports whether at least one of the watched files has changed since the previous call
+// (or since the creation of the FileWatcher), and returns the related environment variables.
+func (w *FileWatcher) Changed() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var changed []string
+
+	for envVar, path := range w.files {
+		hash := fileHash(path)
+		if hash == w.state[envVar] {
+			continue
+		}
+
+		w.state[envVar] = hash
+
+		changed = append(changed, envVar)
+	}
+
+	return changed
+}
+
+// CredentialFiles returns the files referenced by the '<key>_FILE' environment variables of the keys, indexed by environment variable names.
+// The variables are ignored when '<key>' is defined, because [GetOrFile] doesn't read the file in this case.
+func CredentialFiles(keys ...string) map[string]string {
+	files := make(map[string]string)
+
+	for _, key := range keys {
+		if os.Getenv(key) != "" {
+			continue
+		}
+
+		path := os.Getenv(key + fileSuffix)
+		if path == "" {
+			continue
+		}
+
+		files[key+fileSuffix] = path
+	}
+
+	return files
+}
+
+// fileHash returns the hash of the file content, or a zero value if the file cannot be read.
+func fileHash(path string) [sha256.Size]byte {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return [sha256.Size]byte{}
+	}
+
+	return sha256.Sum256(content)
+}
@@ -0,0 +1,47 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialFiles(t *testing.T) {
+	t.Setenv("TEST_LEGO_WATCH_A_FILE", "/a")
+	t.Setenv("TEST_LEGO_WATCH_B", "value")
+	t.Setenv("TEST_LEGO_WATCH_B_FILE", "/b")
+	t.Setenv("TEST_LEGO_WATCH_C_FILE", "")
+	t.Setenv("TEST_LEGO_WATCH_D_FILE", "/d")
+
+	files := CredentialFiles("TEST_LEGO_WATCH_A", "TEST_LEGO_WATCH_B", "TEST_LEGO_WATCH_C")
+
+	// D is not a key of the watcher.
+	assert.Equal(t, map[string]string{"TEST_LEGO_WATCH_A_FILE": "/a"}, files)
+}
+
+func TestFileWatcher_Changed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+
+	err := os.WriteFile(path, []byte("secret1"), 0o600)
+	require.NoError(t, err)
+
+	t.Setenv("TEST_LEGO_WATCH_TOKEN_FILE", path)
+
+	watcher := NewFileWatcher("TEST_LEGO_WATCH_TOKEN")
+
+	assert.Empty(t, watcher.Changed())
+
+	err = os.WriteFile(path, []byte("secret2"), 0o600)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"TEST_LEGO_WATCH_TOKEN_FILE"}, watcher.Changed())
+	assert.Empty(t, watcher.Changed())
+
+	err = os.Remove(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"TEST_LEGO_WATCH_TOKEN_FILE"}, watcher.Changed())
+}
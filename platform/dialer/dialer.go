@@ -0,0 +1,250 @@
+// Package dialer provides a dialer with an address family preference and the Happy Eyeballs algorithm (RFC 8305).
+package dialer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultFallbackDelay the delay before starting the connection with the other address family (RFC 8305 section 5).
+const DefaultFallbackDelay = 300 * time.Millisecond
+
+// Family the address family preference.
+type Family string
+
+const (
+	// Auto uses the order of the resolver (RFC 6724), with the fallback to the other address family.
+	Auto Family = "auto"
+	// IPv4 uses only IPv4.
+	IPv4 Family = "ipv4"
+	// IPv6 uses only IPv6.
+	IPv6 Family = "ipv6"
+	// PreferIPv4 tries IPv4 first, then IPv6 after the fallback delay.
+	PreferIPv4 Family = "prefer-ipv4"
+	// PreferIPv6 tries IPv6 first, then IPv4 after the fallback delay.
+	PreferIPv6 Family = "prefer-ipv6"
+)
+
+// Families all the supported address family preferences.
+var Families = []Family{Auto, IPv4, IPv6, PreferIPv4, PreferIPv6}
+
+// ParseFamily parses an address family preference.
+// An empty value is Auto.
+func ParseFamily(value string) (Family, error) {
+	if value == "" {
+		return Auto, nil
+	}
+
+	family := Family(strings.ToLower(value))
+
+	if !slices.Contains(Families, family) {
+		return "", fmt.Errorf("unsupported address family: %q", value)
+	}
+
+	return family, nil
+}
+
+// Network returns the network restricted to the address family (ex: "tcp" becomes "tcp6" with IPv6).
+// Only IPv4 and IPv6 restrict the network.
+func (f Family) Network(network string) string {
+	if network != "tcp" && network != "udp" {
+		return network
+	}
+
+	switch f {
+	case IPv4:
+		return network + "4"
+	case IPv6:
+		return network + "6"
+	default:
+		return network
+	}
+}
+
+// SortAddresses sorts the addresses (host:port) according to the address family preference:
+// the IP addresses of the preferred family first, then the hostnames, then the IP addresses of the other family.
+// The relative order of the addresses is kept.
+func SortAddresses(family Family, addresses []string) []string {
+	preferred := preferredFamily(family)
+	if preferred == "" {
+		return addresses
+	}
+
+	rank := func(address string) int {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			host = address
+		}
+
+		ip := net.ParseIP(strings.Trim(host, "[]"))
+
+		switch {
+		case ip == nil:
+			return 1
+		case (ip.To4() != nil) == (preferred == "4"):
+			return 0
+		default:
+			return 2
+		}
+	}
+
+	sorted := slices.Clone(addresses)
+
+	slices.SortStableFunc(sorted, func(a, b string) int {
+		return rank(a) - rank(b)
+	})
+
+	return sorted
+}
+
+// Dialer a dialer with an address family preference.
+type Dialer struct {
+	Family Family
+
+	// FallbackDelay the delay before starting the connection with the other address family.
+	// Defaults to DefaultFallbackDelay.
+	FallbackDelay time.Duration
+
+	Timeout   time.Duration
+	KeepAlive time.Duration
+}
+
+// New creates a new Dialer.
+func New(family Family, fallbackDelay time.Duration) *Dialer {
+	return &Dialer{
+		Family:        family,
+		FallbackDelay: fallbackDelay,
+		Timeout:       30 * time.Second,
+		KeepAlive:     30 * time.Second,
+	}
+}
+
+// DialContext connects to the address on the named network, according to the address family preference.
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if (d.Family != PreferIPv4 && d.Family != PreferIPv6) || (network != "tcp" && network != "udp") {
+		return d.netDialer().DialContext(ctx, d.Family.Network(network), address)
+	}
+
+	if d.Family == PreferIPv4 {
+		return d.dialParallel(ctx, network+"4", network+"6", address)
+	}
+
+	return d.dialParallel(ctx, network+"6", network+"4", address)
+}
+
+// dialParallel starts the connection with the primary network,
+// then starts the connection with the fallback network after the fallback delay, or as soon as the primary connection fails.
+// The first established connection wins.
+func (d *Dialer) dialParallel(ctx context.Context, primary, fallback, address string) (net.Conn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		conn    net.Conn
+		err     error
+		primary bool
+	}
+
+	results := make(chan result, 2)
+
+	dialer := d.netDialer()
+
+	start := func(network string, isPrimary bool) {
+		go func() {
+			conn, err := dialer.DialContext(ctx, network, address)
+			results <- result{conn: conn, err: err, primary: isPrimary}
+		}()
+	}
+
+	start(primary, true)
+
+	pending := 1
+
+	timer := time.NewTimer(d.fallbackDelay())
+	defer timer.Stop()
+
+	fallbackStarted := false
+
+	var primaryErr, fallbackErr error
+
+	for {
+		select {
+		case <-timer.C:
+			if !fallbackStarted {
+				start(fallback, false)
+
+				fallbackStarted = true
+				pending++
+			}
+
+		case res := <-results:
+			pending--
+
+			if res.err == nil {
+				if pending > 0 {
+					// Closes the connection of the other address family if it's established after the cancellation.
+					go func() {
+						late := <-results
+						if late.conn != nil {
+							_ = late.conn.Close()
+						}
+					}()
+				}
+
+				return res.conn, nil
+			}
+
+			if res.primary {
+				primaryErr = res.err
+			} else {
+				fallbackErr = res.err
+			}
+
+			if !fallbackStarted {
+				start(fallback, false)
+
+				fallbackStarted = true
+				pending++
+
+				continue
+			}
+
+			if pending == 0 {
+				return nil, errors.Join(primaryErr, fallbackErr)
+			}
+		}
+	}
+}
+
+func (d *Dialer) netDialer() *net.Dialer {
+	return &net.Dialer{
+		Timeout:       d.Timeout,
+		KeepAlive:     d.KeepAlive,
+		FallbackDelay: d.fallbackDelay(),
+	}
+}
+
+func (d *Dialer) fallbackDelay() time.Duration {
+	if d.FallbackDelay > 0 {
+		return d.FallbackDelay
+	}
+
+	return DefaultFallbackDelay
+}
+
+// preferredFamily returns the suffix of the networks ("4" or "6") of the preferred address family.
+func preferredFamily(family Family) string {
+	switch family {
+	case IPv4, PreferIPv4:
+		return "4"
+	case IPv6, PreferIPv6:
+		return "6"
+	default:
+		return ""
+	}
+}
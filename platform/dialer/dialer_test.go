@@ -0,0 +1,155 @@
+package dialer
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFamily(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		value         string
+		expected      Family
+		expectedError string
+	}{
+		{desc: "empty", value: "", expected: Auto},
+		{desc: "auto", value: "auto", expected: Auto},
+		{desc: "ipv6", value: "IPv6", expected: IPv6},
+		{desc: "prefer-ipv4", value: "prefer-ipv4", expected: PreferIPv4},
+		{desc: "unsupported", value: "ipv5", expectedError: `unsupported address family: "ipv5"`},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			family, err := ParseFamily(test.value)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, family)
+		})
+	}
+}
+
+func TestFamily_Network(t *testing.T) {
+	assert.Equal(t, "tcp", Auto.Network("tcp"))
+	assert.Equal(t, "tcp4", IPv4.Network("tcp"))
+	assert.Equal(t, "udp6", IPv6.Network("udp"))
+	assert.Equal(t, "tcp", PreferIPv6.Network("tcp"))
+	assert.Equal(t, "tcp4", IPv6.Network("tcp4"))
+	assert.Equal(t, "unix", IPv6.Network("unix"))
+}
+
+func TestSortAddresses(t *testing.T) {
+	addresses := []string{"192.0.2.1:53", "ns.example.com:53", "[2001:db8::1]:53", "198.51.100.1:53", "[2001:db8::2]:53"}
+
+	testCases := []struct {
+		desc     string
+		family   Family
+		expected []string
+	}{
+		{
+			desc:     "auto",
+			family:   Auto,
+			expected: addresses,
+		},
+		{
+			desc:     "ipv4",
+			family:   IPv4,
+			expected: []string{"192.0.2.1:53", "198.51.100.1:53", "ns.example.com:53", "[2001:db8::1]:53", "[2001:db8::2]:53"},
+		},
+		{
+			desc:     "prefer-ipv6",
+			family:   PreferIPv6,
+			expected: []string{"[2001:db8::1]:53", "[2001:db8::2]:53", "ns.example.com:53", "192.0.2.1:53", "198.51.100.1:53"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, SortAddresses(test.family, addresses))
+		})
+	}
+}
+
+func TestDialer_DialContext(t *testing.T) {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, errA := listener.Accept()
+			if errA != nil {
+				return
+			}
+
+			_ = conn.Close()
+		}
+	}()
+
+	testCases := []struct {
+		desc    string
+		family  Family
+		success bool
+	}{
+		{desc: "auto", family: Auto, success: true},
+		{desc: "ipv4", family: IPv4, success: true},
+		{desc: "ipv6", family: IPv6},
+		{desc: "prefer-ipv4", family: PreferIPv4, success: true},
+		// The IPv6 connection fails immediately: the IPv4 connection starts without waiting for the fallback delay.
+		{desc: "prefer-ipv6", family: PreferIPv6, success: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			dialer := New(test.family, time.Minute)
+
+			conn, err := dialer.DialContext(t.Context(), "tcp", listener.Addr().String())
+			if !test.success {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			_ = conn.Close()
+		})
+	}
+}
+
+func TestDialer_DialContext_fallbackDelay(t *testing.T) {
+	listener, err := net.Listen("tcp4", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+
+	dialer := New(PreferIPv6, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+
+	// "localhost" can be resolved to an IPv6 address without listener, or without route.
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort("localhost", port))
+	require.NoError(t, err)
+
+	_ = conn.Close()
+}
@@ -0,0 +1,91 @@
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+)
+
+// DebugLogger is an http.RoundTripper that logs the exchanges, with the same redaction as the Recorder.
+//
+// Only the JSON responses are logged (the problem documents as "problem"), not the other responses (ex: the certificates).
+type DebugLogger struct {
+	rt     http.RoundTripper
+	logger log.StructuredLogger
+}
+
+// NewDebugLogger creates a new DebugLogger.
+// The exchanges are logged at the debug level.
+func NewDebugLogger(logger log.StructuredLogger, rt http.RoundTripper) *DebugLogger {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	return &DebugLogger{rt: rt, logger: logger}
+}
+
+// RoundTrip executes the request and logs the exchange.
+func (d *DebugLogger) RoundTrip(req *http.Request) (*http.Response, error) {
+	args := []any{"method", req.Method, "url", req.URL.String()}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err == nil {
+			raw, _ := io.ReadAll(body)
+
+			_ = body.Close()
+
+			if len(raw) > 0 {
+				args = append(args, "request", redactJWS(raw))
+			}
+		}
+	}
+
+	start := time.Now()
+
+	resp, err := d.rt.RoundTrip(req)
+	if err != nil {
+		d.logger.Debug("acme: request failed", append(args, "duration", time.Since(start).Round(time.Millisecond), "error", err)...)
+
+		return nil, err
+	}
+
+	args = append(args,
+		"duration", time.Since(start).Round(time.Millisecond),
+		"status", resp.StatusCode,
+		"header", redactHeader(resp.Header),
+	)
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+
+	if mediaType == "application/json" || mediaType == "application/problem+json" {
+		respBody, err := io.ReadAll(resp.Body)
+
+		_ = resp.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+		var content any
+
+		if json.Unmarshal(respBody, &content) == nil {
+			if mediaType == "application/problem+json" {
+				args = append(args, "problem", content)
+			} else {
+				args = append(args, "response", redact(content))
+			}
+		}
+	}
+
+	d.logger.Debug("acme: request", args...)
+
+	return resp, nil
+}
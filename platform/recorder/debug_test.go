@@ -0,0 +1,135 @@
+package recorder
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugLogger_RoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Replay-Nonce", "secret-nonce")
+
+		switch req.URL.Path {
+		case "/new-acct":
+			rw.Header().Set("Content-Type", "application/json")
+			rw.Header().Set("Location", "https://example.com/acme/acct/1")
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"status":"valid","contact":["mailto:foo@example.com"]}`))
+
+		case "/new-order":
+			rw.Header().Set("Content-Type", "application/problem+json")
+			rw.WriteHeader(http.StatusForbidden)
+			_, _ = rw.Write([]byte(`{"type":"urn:ietf:params:acme:error:unauthorized","detail":"oops"}`))
+
+		default:
+			rw.Header().Set("Content-Type", "application/pem-certificate-chain")
+			_, _ = rw.Write([]byte("-----BEGIN CERTIFICATE-----"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	buf := &bytes.Buffer{}
+
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(_ []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey || attr.Key == "duration" {
+				return slog.Attr{}
+			}
+
+			return attr
+		},
+	}))
+
+	client := &http.Client{Transport: NewDebugLogger(logger, nil)}
+
+	body := `{"protected":"` + encodeSegment(`{"alg":"ES256","jwk":{"kty":"EC"},"nonce":"secret-nonce","url":"https://example.com/acme/new-acct"}`) + `",` +
+		`"payload":"` + encodeSegment(`{"termsOfServiceAgreed":true,"contact":["mailto:foo@example.com"],"externalAccountBinding":{"protected":"a","payload":"b","signature":"c"}}`) + `",` +
+		`"signature":"secret-signature"}`
+
+	resp, err := client.Post(server.URL+"/new-acct", "application/jose+json", strings.NewReader(body))
+	require.NoError(t, err)
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	// The response is not modified.
+	assert.JSONEq(t, `{"status":"valid","contact":["mailto:foo@example.com"]}`, string(raw))
+
+	body = `{"protected":"` + encodeSegment(`{"alg":"ES256","kid":"https://example.com/acme/acct/1","nonce":"secret-nonce","url":"https://example.com/acme/new-order"}`) + `",` +
+		`"payload":"` + encodeSegment(`{"identifiers":[{"type":"dns","value":"example.com"}]}`) + `",` +
+		`"signature":"secret-signature"}`
+
+	resp, err = client.Post(server.URL+"/new-order", "application/jose+json", strings.NewReader(body))
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	resp, err = client.Get(server.URL + "/cert/1")
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.NotContains(t, buf.String(), "secret")
+	assert.NotContains(t, buf.String(), "foo@example.com")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	assert.JSONEq(t, `{
+		"level": "DEBUG",
+		"msg": "acme: request",
+		"method": "POST",
+		"url": "`+server.URL+`/new-acct",
+		"request": {
+			"protected": {"alg":"ES256","jwk":"[redacted]","nonce":"[redacted]","url":"https://example.com/acme/new-acct"},
+			"payload": {"termsOfServiceAgreed":true,"contact":"[redacted]","externalAccountBinding":"[redacted]"}
+		},
+		"status": 201,
+		"header": {
+			"Content-Type": ["application/json"],
+			"Location": ["https://example.com/acme/acct/1"],
+			"Replay-Nonce": ["[redacted]"]
+		},
+		"response": {"status":"valid","contact":"[redacted]"}
+	}`, lines[0])
+
+	assert.JSONEq(t, `{
+		"level": "DEBUG",
+		"msg": "acme: request",
+		"method": "POST",
+		"url": "`+server.URL+`/new-order",
+		"request": {
+			"protected": {"alg":"ES256","kid":"https://example.com/acme/acct/1","nonce":"[redacted]","url":"https://example.com/acme/new-order"},
+			"payload": {"identifiers":[{"type":"dns","value":"example.com"}]}
+		},
+		"status": 403,
+		"header": {
+			"Content-Type": ["application/problem+json"],
+			"Replay-Nonce": ["[redacted]"]
+		},
+		"problem": {"type":"urn:ietf:params:acme:error:unauthorized","detail":"oops"}
+	}`, lines[1])
+
+	assert.JSONEq(t, `{
+		"level": "DEBUG",
+		"msg": "acme: request",
+		"method": "GET",
+		"url": "`+server.URL+`/cert/1",
+		"status": 200,
+		"header": {
+			"Content-Type": ["application/pem-certificate-chain"],
+			"Replay-Nonce": ["[redacted]"]
+		}
+	}`, lines[2])
+}
@@ -0,0 +1,195 @@
+// Package recorder records the ACME API exchanges into a replayable session file.
+//
+// The session file is a JSON Lines file: one Interaction per line, in the order of the requests.
+// The secrets (nonces, signatures, account keys, EAB, contacts) are redacted before the recording.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+)
+
+// EnvRecordSession is the environment variable name that can be used to
+// specify the path of the file where the ACME API exchanges are recorded.
+const EnvRecordSession = "LEGO_RECORD_SESSION"
+
+// Interaction a recorded request/response exchange.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request a recorded request.
+type Request struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// Response a recorded response.
+type Response struct {
+	StatusCode int           `json:"status"`
+	Header     http.Header   `json:"header,omitempty"`
+	Body       string        `json:"body,omitempty"`
+	Duration   time.Duration `json:"duration,omitempty"`
+}
+
+// Recorder is an http.RoundTripper that records the exchanges.
+type Recorder struct {
+	rt http.RoundTripper
+
+	mu       sync.Mutex
+	filename string
+}
+
+// NewRecorder creates a new Recorder.
+// The exchanges are appended to the file.
+// The file is opened for each exchange: no file is left open when the client is not used anymore.
+func NewRecorder(filename string, rt http.RoundTripper) (*Recorder, error) {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+
+	// Checks that the file can be written.
+	file, err := openSession(filename)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return nil, fmt.Errorf("recorder: %w", err)
+	}
+
+	return &Recorder{rt: rt, filename: filename}, nil
+}
+
+// RoundTrip executes the request and records the exchange.
+// A recording failure is logged: it doesn't fail the request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		_ = req.Body.Close()
+
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	start := time.Now()
+
+	resp, err := r.rt.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   RedactRequestBody(reqBody),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     redactHeader(resp.Header),
+			Body:       redactResponseBody(respBody),
+			Duration:   time.Since(start).Round(time.Millisecond),
+		},
+	}
+
+	err = r.write(interaction)
+	if err != nil {
+		log.Warnf("Unable to record the ACME exchange: %v", err)
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) write(interaction Interaction) error {
+	line, err := json.Marshal(interaction)
+	if err != nil {
+		return fmt.Errorf("recorder: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	file, err := openSession(r.filename)
+	if err != nil {
+		return fmt.Errorf("recorder: %w", err)
+	}
+
+	_, err = file.Write(append(line, '\n'))
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("recorder: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("recorder: %w", err)
+	}
+
+	return nil
+}
+
+func openSession(filename string) (*os.File, error) {
+	return os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+}
+
+// Load reads a session file.
+func Load(filename string) ([]Interaction, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse parses the content of a session file.
+func Parse(data []byte) ([]Interaction, error) {
+	var interactions []Interaction
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	for {
+		var interaction Interaction
+
+		err := decoder.Decode(&interaction)
+		if errors.Is(err, io.EOF) {
+			return interactions, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("recorder: interaction %d: %w", len(interactions), err)
+		}
+
+		interactions = append(interactions, interaction)
+	}
+}
@@ -0,0 +1,131 @@
+package recorder
+
+import (
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder_RoundTrip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Header().Set("Replay-Nonce", "secret-nonce")
+		rw.Header().Set("Location", "https://example.com/acme/acct/1")
+		rw.Header().Set("X-Custom", "ignored")
+		rw.WriteHeader(http.StatusCreated)
+
+		_, _ = rw.Write([]byte(`{"status":"valid","contact":["mailto:foo@example.com"],"key":{"kty":"EC","x":"secret"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	filename := filepath.Join(t.TempDir(), "session.jsonl")
+
+	rec, err := NewRecorder(filename, nil)
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: rec}
+
+	body := `{"protected":"` + encodeSegment(`{"alg":"ES256","jwk":{"kty":"EC"},"nonce":"secret-nonce","url":"https://example.com/acme/new-acct"}`) + `",` +
+		`"payload":"` + encodeSegment(`{"termsOfServiceAgreed":true,"contact":["mailto:foo@example.com"],"externalAccountBinding":{"protected":"a","payload":"b","signature":"c"}}`) + `",` +
+		`"signature":"secret-signature"}`
+
+	resp, err := client.Post(server.URL+"/acme/new-acct", "application/jose+json", strings.NewReader(body))
+	require.NoError(t, err)
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	// The response is not altered by the recording.
+	assert.JSONEq(t, `{"status":"valid","contact":["mailto:foo@example.com"],"key":{"kty":"EC","x":"secret"}}`, string(raw))
+	assert.Equal(t, "secret-nonce", resp.Header.Get("Replay-Nonce"))
+
+	interactions, err := Load(filename)
+	require.NoError(t, err)
+	require.Len(t, interactions, 1)
+
+	interaction := interactions[0]
+
+	assert.Equal(t, http.MethodPost, interaction.Request.Method)
+	assert.Equal(t, server.URL+"/acme/new-acct", interaction.Request.URL)
+	assert.JSONEq(t, `{
+		"protected": {"alg":"ES256","jwk":"[redacted]","nonce":"[redacted]","url":"https://example.com/acme/new-acct"},
+		"payload": {"termsOfServiceAgreed":true,"contact":"[redacted]","externalAccountBinding":"[redacted]"}
+	}`, string(interaction.Request.Body))
+
+	assert.Equal(t, http.StatusCreated, interaction.Response.StatusCode)
+	assert.Equal(t, http.Header{
+		"Content-Type": {"application/json"},
+		"Location":     {"https://example.com/acme/acct/1"},
+		"Replay-Nonce": {Redacted},
+	}, interaction.Response.Header)
+	assert.JSONEq(t, `{"status":"valid","contact":"[redacted]","key":"[redacted]"}`, interaction.Response.Body)
+}
+
+func TestRecorder_RoundTrip_writeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"status":"valid"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	filename := filepath.Join(t.TempDir(), "session.jsonl")
+
+	rec, err := NewRecorder(filename, nil)
+	require.NoError(t, err)
+
+	// The session file cannot be written anymore.
+	require.NoError(t, os.Remove(filename))
+	require.NoError(t, os.Mkdir(filename, 0o700))
+
+	client := &http.Client{Transport: rec}
+
+	resp, err := client.Get(server.URL + "/acme/order/1")
+	require.NoError(t, err)
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.JSONEq(t, `{"status":"valid"}`, string(raw))
+}
+
+func TestParse(t *testing.T) {
+	data := `{"request":{"method":"GET","url":"https://example.com/dir"},"response":{"status":200,"body":"{}"}}
+{"request":{"method":"HEAD","url":"https://example.com/nonce"},"response":{"status":200,"header":{"Replay-Nonce":["[redacted]"]}}}
+`
+
+	interactions, err := Parse([]byte(data))
+	require.NoError(t, err)
+
+	expected := []Interaction{
+		{
+			Request:  Request{Method: http.MethodGet, URL: "https://example.com/dir"},
+			Response: Response{StatusCode: http.StatusOK, Body: "{}"},
+		},
+		{
+			Request:  Request{Method: http.MethodHead, URL: "https://example.com/nonce"},
+			Response: Response{StatusCode: http.StatusOK, Header: http.Header{"Replay-Nonce": {Redacted}}},
+		},
+	}
+
+	assert.Equal(t, expected, interactions)
+}
+
+func TestParse_error(t *testing.T) {
+	_, err := Parse([]byte(`{"request":{"method":"GET"}}` + "\n" + `{"request":`))
+	require.EqualError(t, err, "recorder: interaction 1: unexpected EOF")
+}
+
+func encodeSegment(value string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
@@ -0,0 +1,167 @@
+package recorder
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+)
+
+// Redacted the replacement value of the secrets.
+const Redacted = "[redacted]"
+
+// recordedHeaders the response headers kept inside the recording.
+var recordedHeaders = []string{
+	"Content-Type",
+	"Link",
+	"Location",
+	"Replay-Nonce",
+	"Retry-After",
+}
+
+// redactedFields the JSON fields replaced by Redacted.
+// - nonce, signature: useless for the replay, and the signature can be used to identify the account key.
+// - jwk, key, oldKey: the account keys.
+// - externalAccountBinding: the EAB is a secret.
+// - contact: the email addresses of the account.
+var redactedFields = map[string]struct{}{
+	"nonce":                  {},
+	"signature":              {},
+	"jwk":                    {},
+	"key":                    {},
+	"oldKey":                 {},
+	"externalAccountBinding": {},
+	"contact":                {},
+}
+
+func redactHeader(header http.Header) http.Header {
+	redacted := http.Header{}
+
+	for _, name := range recordedHeaders {
+		values := header.Values(name)
+		if len(values) == 0 {
+			continue
+		}
+
+		if name == "Replay-Nonce" {
+			redacted.Set(name, Redacted)
+			continue
+		}
+
+		redacted[name] = values
+	}
+
+	return redacted
+}
+
+// RedactRequestBody returns the body of a request as recorded:
+// the JWS (flattened JSON serialization) is decoded to record a readable content, and the secrets are removed.
+// https://www.rfc-editor.org/rfc/rfc8555.html#section-6.2
+func RedactRequestBody(raw []byte) json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(redactJWS(raw))
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// redactJWS decodes the JWS (flattened JSON serialization), and removes the secrets.
+func redactJWS(raw []byte) any {
+	var jws struct {
+		Protected string `json:"protected"`
+		Payload   string `json:"payload"`
+	}
+
+	err := json.Unmarshal(raw, &jws)
+	if err != nil {
+		// Not a JWS: the content is not recorded to avoid leaking unknown data.
+		return Redacted
+	}
+
+	body := map[string]any{
+		"protected": decodeSegment(jws.Protected),
+		"payload":   decodeSegment(jws.Payload),
+	}
+
+	return redact(body)
+}
+
+func redactResponseBody(raw []byte) string {
+	var content any
+
+	err := json.Unmarshal(raw, &content)
+	if err != nil {
+		// Not a JSON content (ex: the PEM certificates), the content is public.
+		return string(raw)
+	}
+
+	data, err := json.Marshal(redact(content))
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+// decodeSegment decodes a base64url JWS segment, and its JSON content if possible.
+// The POST-as-GET requests have an empty payload.
+func decodeSegment(segment string) any {
+	if segment == "" {
+		return ""
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return Redacted
+	}
+
+	var content any
+
+	err = json.Unmarshal(data, &content)
+	if err != nil {
+		return Redacted
+	}
+
+	// The payload of some requests (ex: key change) is also a JWS.
+	if inner, ok := content.(map[string]any); ok {
+		if protected, ok := inner["protected"].(string); ok {
+			inner["protected"] = decodeSegment(protected)
+		}
+
+		if payload, ok := inner["payload"].(string); ok {
+			inner["payload"] = decodeSegment(payload)
+		}
+	}
+
+	return content
+}
+
+func redact(content any) any {
+	switch value := content.(type) {
+	case map[string]any:
+		for k, v := range value {
+			if _, ok := redactedFields[k]; ok {
+				value[k] = Redacted
+				continue
+			}
+
+			value[k] = redact(v)
+		}
+
+		return value
+
+	case []any:
+		for i, v := range value {
+			value[i] = redact(v)
+		}
+
+		return value
+
+	default:
+		return content
+	}
+}
@@ -26,6 +26,13 @@ func MockACMEServer() *servermock.Builder[*httptest.Server] {
 				RevokeCertURL: serverURL + "/revokeCert",
 				KeyChangeURL:  serverURL + "/keyChange",
 				RenewalInfo:   serverURL + "/renewalInfo",
+				Meta: acme.Meta{
+					Profiles: map[string]string{
+						"classic":    "The default profile.",
+						"shortlived": "A short-lived certificate.",
+					},
+					DelegationEnabled: true,
+				},
 			}).ServeHTTP(rw, req)
 		})).
 		Route("HEAD /nonce", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
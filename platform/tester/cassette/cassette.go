@@ -0,0 +1,388 @@
+// Package cassette records the API exchanges of the DNS provider clients and replays them in the tests,
+// so the behavior of the clients is checked without credentials.
+//
+// By default, the cassette is replayed: the test is skipped if the cassette has not been recorded.
+// With LEGO_RECORD_CASSETTES=true, the test uses the real API and the cassette is (re)recorded:
+// the values of the environment variables (credentials, domains) are replaced by placeholders,
+// the request headers are not recorded, and only the Content-Type header of the responses is kept.
+//
+// The cassette is a JSON Lines file: one Interaction per line, in the order of the requests.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// EnvRecord is the environment variable name used to record the cassettes against the real APIs.
+const EnvRecord = "LEGO_RECORD_CASSETTES"
+
+// Redacted the replacement value of the redacted JSON fields.
+const Redacted = "[redacted]"
+
+// Interaction a recorded request/response exchange.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request a recorded request.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response a recorded response.
+type Response struct {
+	StatusCode int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Cassette records or replays the API exchanges of a test.
+type Cassette struct {
+	t        *testing.T
+	filename string
+	record   bool
+
+	mu           sync.Mutex
+	replacements []string
+	redacted     []string
+	interactions []Interaction
+	used         []bool
+}
+
+// New creates a Cassette from a file.
+// In replay mode, the test is skipped if the file doesn't exist,
+// and all the interactions must have been used at the end of the test.
+// In record mode, the file is written at the end of the test.
+func New(t *testing.T, filename string) *Cassette {
+	t.Helper()
+
+	c := &Cassette{
+		t:        t,
+		filename: filename,
+		record:   isRecording(),
+	}
+
+	if c.record {
+		t.Cleanup(c.save)
+
+		return c
+	}
+
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		t.Skipf("cassette: %s is not recorded: run the test with %s=true and the credentials", filename, EnvRecord)
+	}
+
+	require.NoError(t, err)
+
+	c.interactions, err = Parse(data)
+	require.NoError(t, err)
+
+	c.used = make([]bool, len(c.interactions))
+
+	t.Cleanup(func() {
+		for i, interaction := range c.Remaining() {
+			t.Errorf("cassette: unused interaction %d: %s %s", i, interaction.Request.Method, interaction.Request.URL)
+		}
+	})
+
+	return c
+}
+
+// Env returns the value of the environment variable in record mode, and the placeholder in replay mode.
+// In record mode, the value is replaced by the placeholder inside the cassette,
+// and the test is skipped if the environment variable is not defined.
+func (c *Cassette) Env(name, placeholder string) string {
+	c.t.Helper()
+
+	if !c.record {
+		return placeholder
+	}
+
+	value := os.Getenv(name)
+	if value == "" {
+		c.t.Skipf("cassette: %s is required to record %s", name, c.filename)
+	}
+
+	c.mu.Lock()
+	c.replacements = append(c.replacements, value, placeholder)
+	c.mu.Unlock()
+
+	return value
+}
+
+// RedactJSONFields redacts the JSON fields (ex: account information) of the bodies in record mode.
+// The string values of the fields (and of their sub-fields) are replaced by Redacted.
+func (c *Cassette) RedactJSONFields(names ...string) {
+	c.mu.Lock()
+	c.redacted = append(c.redacted, names...)
+	c.mu.Unlock()
+}
+
+// Client creates an HTTP client using the cassette.
+func (c *Cassette) Client() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// RoundTrip records the exchange (record mode), or serves the recorded response (replay mode).
+// In replay mode, a request is matched with the first unused interaction with the same method and URL,
+// and the body of the request must be the recorded body.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+
+		reqBody, err = io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		_ = req.Body.Close()
+
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	if c.record {
+		return c.recordExchange(req, reqBody)
+	}
+
+	return c.replay(req, reqBody)
+}
+
+// Remaining returns the unused interactions.
+func (c *Cassette) Remaining() map[int]Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	remaining := make(map[int]Interaction)
+
+	for i, interaction := range c.interactions {
+		if !c.used[i] {
+			remaining[i] = interaction
+		}
+	}
+
+	return remaining
+}
+
+func (c *Cassette) recordExchange(req *http.Request, reqBody []byte) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Body:   string(reqBody),
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Body:       string(respBody),
+		},
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		interaction.Response.Header = http.Header{"Content-Type": {contentType}}
+	}
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, interaction)
+	c.mu.Unlock()
+
+	return resp, nil
+}
+
+func (c *Cassette) replay(req *http.Request, reqBody []byte) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.interactions {
+		if c.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != req.URL.String() {
+			continue
+		}
+
+		if !sameBody(interaction.Request.Body, string(reqBody)) {
+			return nil, fmt.Errorf("cassette: %s %s: unexpected body: %s (recorded: %s)",
+				req.Method, req.URL, reqBody, interaction.Request.Body)
+		}
+
+		c.used[i] = true
+
+		return newResponse(req, interaction.Response), nil
+	}
+
+	return nil, fmt.Errorf("cassette: unexpected request: %s %s", req.Method, req.URL)
+}
+
+func (c *Cassette) save() {
+	if c.t.Failed() || c.t.Skipped() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	replacer := strings.NewReplacer(c.replacements...)
+
+	buf := &bytes.Buffer{}
+
+	for _, interaction := range c.interactions {
+		interaction.Request.URL = replacer.Replace(interaction.Request.URL)
+		interaction.Request.Body = replacer.Replace(redactBody(interaction.Request.Body, c.redacted))
+		interaction.Response.Body = replacer.Replace(redactBody(interaction.Response.Body, c.redacted))
+
+		line, err := json.Marshal(interaction)
+		require.NoError(c.t, err)
+
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	require.NoError(c.t, os.MkdirAll(filepath.Dir(c.filename), 0o755))
+	require.NoError(c.t, os.WriteFile(c.filename, buf.Bytes(), 0o644))
+}
+
+// Parse parses the content of a cassette.
+func Parse(data []byte) ([]Interaction, error) {
+	var interactions []Interaction
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	for {
+		var interaction Interaction
+
+		err := decoder.Decode(&interaction)
+		if errors.Is(err, io.EOF) {
+			return interactions, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("cassette: interaction %d: %w", len(interactions), err)
+		}
+
+		interactions = append(interactions, interaction)
+	}
+}
+
+// redactBody redacts the JSON fields of a body.
+// The body is unchanged if it's not a JSON body.
+func redactBody(body string, names []string) string {
+	if len(names) == 0 {
+		return body
+	}
+
+	var data any
+
+	err := json.Unmarshal([]byte(body), &data)
+	if err != nil {
+		return body
+	}
+
+	raw, err := json.Marshal(redactFields(data, names, false))
+	if err != nil {
+		return body
+	}
+
+	return string(raw)
+}
+
+func redactFields(data any, names []string, redact bool) any {
+	switch value := data.(type) {
+	case map[string]any:
+		for k, v := range value {
+			value[k] = redactFields(v, names, redact || slices.Contains(names, k))
+		}
+
+		return value
+
+	case []any:
+		for i, v := range value {
+			value[i] = redactFields(v, names, redact)
+		}
+
+		return value
+
+	case string:
+		if redact {
+			return Redacted
+		}
+
+		return value
+
+	default:
+		return value
+	}
+}
+
+func isRecording() bool {
+	record, _ := strconv.ParseBool(os.Getenv(EnvRecord))
+
+	return record
+}
+
+// sameBody compares the bodies: the JSON bodies are compared semantically.
+func sameBody(recorded, actual string) bool {
+	if strings.TrimSpace(recorded) == strings.TrimSpace(actual) {
+		return true
+	}
+
+	var a, b any
+
+	if json.Unmarshal([]byte(recorded), &a) != nil || json.Unmarshal([]byte(actual), &b) != nil {
+		return false
+	}
+
+	ra, _ := json.Marshal(a)
+	rb, _ := json.Marshal(b)
+
+	return bytes.Equal(ra, rb)
+}
+
+func newResponse(req *http.Request, recorded Response) *http.Response {
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(recorded.StatusCode) + " " + http.StatusText(recorded.StatusCode),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,206 @@
+package cassette
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCassette(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer secret-token" {
+			http.Error(rw, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.Header().Set("Set-Cookie", "session=secret")
+
+		switch {
+		case req.Method == http.MethodPost && req.URL.Path == "/zones/private.example.org/records":
+			rw.WriteHeader(http.StatusCreated)
+			_, _ = rw.Write([]byte(`{"id":"123","zone":"private.example.org"}`))
+
+		case req.Method == http.MethodDelete && req.URL.Path == "/zones/private.example.org/records/123":
+			rw.WriteHeader(http.StatusNoContent)
+
+		default:
+			http.NotFound(rw, req)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	filename := filepath.Join(t.TempDir(), "cassettes", "create_delete.jsonl")
+
+	scenario := func(t *testing.T, c *Cassette) {
+		t.Helper()
+
+		token := c.Env("TEST_CASSETTE_TOKEN", "xxx")
+		domain := c.Env("TEST_CASSETTE_DOMAIN", "example.com")
+
+		client := c.Client()
+
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/zones/"+domain+"/records", strings.NewReader(`{"name": "_acme-challenge", "type": "TXT"}`))
+		require.NoError(t, err)
+
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+
+		raw, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		_ = resp.Body.Close()
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.JSONEq(t, `{"id":"123","zone":"`+domain+`"}`, string(raw))
+
+		req, err = http.NewRequest(http.MethodDelete, server.URL+"/zones/"+domain+"/records/123", http.NoBody)
+		require.NoError(t, err)
+
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		resp, err = client.Do(req)
+		require.NoError(t, err)
+
+		_ = resp.Body.Close()
+
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	}
+
+	t.Run("record", func(t *testing.T) {
+		t.Setenv(EnvRecord, "true")
+		t.Setenv("TEST_CASSETTE_TOKEN", "secret-token")
+		t.Setenv("TEST_CASSETTE_DOMAIN", "private.example.org")
+
+		scenario(t, New(t, filename))
+	})
+
+	data, err := os.ReadFile(filename)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(data), "secret")
+	assert.NotContains(t, string(data), "private.example.org")
+
+	interactions, err := Parse(data)
+	require.NoError(t, err)
+
+	expected := []Interaction{
+		{
+			Request: Request{
+				Method: http.MethodPost,
+				URL:    server.URL + "/zones/example.com/records",
+				Body:   `{"name": "_acme-challenge", "type": "TXT"}`,
+			},
+			Response: Response{
+				StatusCode: http.StatusCreated,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       `{"id":"123","zone":"example.com"}`,
+			},
+		},
+		{
+			Request: Request{
+				Method: http.MethodDelete,
+				URL:    server.URL + "/zones/example.com/records/123",
+			},
+			Response: Response{
+				StatusCode: http.StatusNoContent,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, interactions)
+
+	// The server is not used during the replay.
+	server.Close()
+
+	t.Run("replay", func(t *testing.T) {
+		t.Setenv(EnvRecord, "false")
+
+		scenario(t, New(t, filename))
+	})
+}
+
+func Test_redactBody(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		body     string
+		expected string
+	}{
+		{
+			desc:     "fields",
+			body:     `{"id":"123","owner":{"id":"456","email":"foo@example.com","type":"user"},"emails":["foo@example.com"],"ttl":120}`,
+			expected: `{"id":"123","owner":{"id":"[redacted]","email":"[redacted]","type":"[redacted]"},"emails":["[redacted]"],"ttl":120}`,
+		},
+		{
+			desc:     "array",
+			body:     `[{"email":"foo@example.com"},{"name":"example.com"}]`,
+			expected: `[{"email":"[redacted]"},{"name":"example.com"}]`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.JSONEq(t, test.expected, redactBody(test.body, []string{"owner", "email", "emails"}))
+		})
+	}
+
+	// not JSON
+	assert.Equal(t, "email=foo@example.com", redactBody("email=foo@example.com", []string{"email"}))
+}
+
+func TestCassette_RoundTrip_unexpectedBody(t *testing.T) {
+	c := &Cassette{
+		t: t,
+		interactions: []Interaction{
+			{
+				Request:  Request{Method: http.MethodPost, URL: "https://example.com/records", Body: `{"type":"TXT"}`},
+				Response: Response{StatusCode: http.StatusCreated},
+			},
+		},
+		used: []bool{false},
+	}
+
+	_, err := c.Client().Post("https://example.com/records", "application/json", strings.NewReader(`{"type":"A"}`))
+	require.EqualError(t, err, `Post "https://example.com/records": cassette: POST https://example.com/records: unexpected body: {"type":"A"} (recorded: {"type":"TXT"})`)
+
+	resp, err := c.Client().Post("https://example.com/records", "application/json", strings.NewReader(`{ "type": "TXT" }`))
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Empty(t, c.Remaining())
+}
+
+func TestCassette_RoundTrip_unexpectedRequest(t *testing.T) {
+	c := &Cassette{t: t}
+
+	_, err := c.Client().Get("https://example.com/records")
+	require.EqualError(t, err, `Get "https://example.com/records": cassette: unexpected request: GET https://example.com/records`)
+}
+
+func TestNew_notRecorded(t *testing.T) {
+	t.Setenv(EnvRecord, "")
+
+	var skipped bool
+
+	t.Run("replay", func(t *testing.T) {
+		t.Cleanup(func() { skipped = t.Skipped() })
+
+		New(t, filepath.Join(t.TempDir(), "missing.jsonl"))
+	})
+
+	assert.True(t, skipped)
+}
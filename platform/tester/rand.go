@@ -0,0 +1,31 @@
+package tester
+
+import (
+	"crypto/sha256"
+	"io"
+	"math/rand/v2"
+)
+
+// NewRand creates a deterministic source of randomness from a seed.
+// Only for tests: the same seed always produces the same keys.
+func NewRand(seed string) io.Reader {
+	return &deterministicReader{src: rand.NewChaCha8(sha256.Sum256([]byte(seed)))}
+}
+
+// deterministicReader a deterministic io.Reader.
+// The key generation of the standard library randomly reads (or not) a single byte
+// to prevent the reliance on a deterministic output:
+// the single byte reads are served without advancing the stream.
+type deterministicReader struct {
+	src *rand.ChaCha8
+}
+
+func (r *deterministicReader) Read(p []byte) (int, error) {
+	if len(p) == 1 {
+		p[0] = 0
+
+		return 1, nil
+	}
+
+	return r.src.Read(p)
+}
@@ -0,0 +1,154 @@
+// Package replay replays a session recorded with LEGO_RECORD_SESSION against the client.
+package replay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/recorder"
+	"github.com/stretchr/testify/require"
+)
+
+// Transport is an http.RoundTripper that serves the recorded responses.
+// A request is matched with the first unused interaction with the same method, URL, and JWS payload:
+// the order of the requests to different URLs (ex: nonces) or with different payloads doesn't matter,
+// and the identical requests (ex: the retries after a badNonce) are served in the recorded sequence.
+type Transport struct {
+	mu           sync.Mutex
+	interactions []recorder.Interaction
+	used         []bool
+	nonce        int
+}
+
+// NewTransport creates a Transport from a session file.
+// At the end of the test, all the interactions must have been used.
+func NewTransport(t *testing.T, filename string) *Transport {
+	t.Helper()
+
+	interactions, err := recorder.Load(filename)
+	require.NoError(t, err)
+
+	return newTransport(t, interactions)
+}
+
+// NewClient creates an HTTP client from a session file.
+func NewClient(t *testing.T, filename string) *http.Client {
+	t.Helper()
+
+	return &http.Client{Transport: NewTransport(t, filename)}
+}
+
+func newTransport(t *testing.T, interactions []recorder.Interaction) *Transport {
+	t.Helper()
+
+	tr := &Transport{
+		interactions: interactions,
+		used:         make([]bool, len(interactions)),
+	}
+
+	t.Cleanup(func() {
+		for i, interaction := range tr.Remaining() {
+			t.Errorf("replay: unused interaction %d: %s %s", i, interaction.Request.Method, interaction.Request.URL)
+		}
+	})
+
+	return tr
+}
+
+// RoundTrip serves the recorded response.
+func (tr *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var payload any
+
+	if req.Body != nil {
+		raw, err := io.ReadAll(req.Body)
+
+		_ = req.Body.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		payload = readPayload(recorder.RedactRequestBody(raw))
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	for i, interaction := range tr.interactions {
+		if tr.used[i] || interaction.Request.Method != req.Method || interaction.Request.URL != req.URL.String() {
+			continue
+		}
+
+		if !reflect.DeepEqual(readPayload(interaction.Request.Body), payload) {
+			continue
+		}
+
+		tr.used[i] = true
+
+		return tr.newResponse(req, interaction.Response), nil
+	}
+
+	return nil, fmt.Errorf("replay: unexpected request: %s %s", req.Method, req.URL)
+}
+
+// Remaining returns the unused interactions.
+func (tr *Transport) Remaining() map[int]recorder.Interaction {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	remaining := make(map[int]recorder.Interaction)
+
+	for i, interaction := range tr.interactions {
+		if !tr.used[i] {
+			remaining[i] = interaction
+		}
+	}
+
+	return remaining
+}
+
+// readPayload returns the payload of a recorded request body, or nil if the body is not a JWS.
+func readPayload(body json.RawMessage) any {
+	var jws struct {
+		Payload any `json:"payload"`
+	}
+
+	err := json.Unmarshal(body, &jws)
+	if err != nil {
+		return nil
+	}
+
+	return jws.Payload
+}
+
+func (tr *Transport) newResponse(req *http.Request, recorded recorder.Response) *http.Response {
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	// The nonces are redacted by the recorder, but the client needs different nonces.
+	if header.Get("Replay-Nonce") == recorder.Redacted {
+		tr.nonce++
+		header.Set("Replay-Nonce", "replay-nonce-"+strconv.Itoa(tr.nonce))
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(recorded.StatusCode) + " " + http.StatusText(recorded.StatusCode),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewBufferString(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}
+}
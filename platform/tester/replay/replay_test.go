@@ -0,0 +1,134 @@
+package replay
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/recorder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Replay-Nonce", "secret")
+
+		switch req.URL.Path {
+		case "/dir":
+			_, _ = rw.Write([]byte(`{"newNonce":"/nonce"}`))
+		case "/nonce":
+		default:
+			http.NotFound(rw, req)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	filename := filepath.Join(t.TempDir(), "session.jsonl")
+
+	rec, err := recorder.NewRecorder(filename, nil)
+	require.NoError(t, err)
+
+	recordClient := &http.Client{Transport: rec}
+
+	for _, uri := range []string{"/dir", "/nonce", "/nonce"} {
+		resp, errG := recordClient.Get(server.URL + uri)
+		require.NoError(t, errG)
+
+		_ = resp.Body.Close()
+	}
+
+	client := NewClient(t, filename)
+
+	resp, err := client.Get(server.URL + "/nonce")
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.Equal(t, "replay-nonce-1", resp.Header.Get("Replay-Nonce"))
+
+	resp, err = client.Get(server.URL + "/dir")
+	require.NoError(t, err)
+
+	raw, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"newNonce":"/nonce"}`, string(raw))
+	assert.Equal(t, "replay-nonce-2", resp.Header.Get("Replay-Nonce"))
+
+	resp, err = client.Get(server.URL + "/nonce")
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	// All the interactions have been used.
+	_, err = client.Get(server.URL + "/nonce")
+	require.ErrorContains(t, err, "replay: unexpected request: GET "+server.URL+"/nonce")
+}
+
+func TestTransport_payload(t *testing.T) {
+	tr := newTransport(t, []recorder.Interaction{
+		{
+			Request:  recorder.Request{Method: http.MethodPost, URL: "https://example.com/new-order", Body: json.RawMessage(`{"payload":{"identifiers":[{"type":"dns","value":"a.example.com"}]}}`)},
+			Response: recorder.Response{StatusCode: http.StatusCreated, Body: "a"},
+		},
+		{
+			Request:  recorder.Request{Method: http.MethodPost, URL: "https://example.com/new-order", Body: json.RawMessage(`{"payload":{"identifiers":[{"type":"dns","value":"b.example.com"}]}}`)},
+			Response: recorder.Response{StatusCode: http.StatusCreated, Body: "b"},
+		},
+	})
+
+	client := &http.Client{Transport: tr}
+
+	// The requests are not sent in the recorded order.
+	for _, domain := range []string{"b", "a"} {
+		resp, err := client.Post("https://example.com/new-order", "application/jose+json", strings.NewReader(newJWS(`{"identifiers":[{"type":"dns","value":"`+domain+`.example.com"}]}`)))
+		require.NoError(t, err)
+
+		raw, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		_ = resp.Body.Close()
+
+		assert.Equal(t, domain, string(raw))
+	}
+}
+
+func TestTransport_payload_unexpected(t *testing.T) {
+	tr := newTransport(t, nil)
+
+	client := &http.Client{Transport: tr}
+
+	_, err := client.Post("https://example.com/new-order", "application/jose+json", strings.NewReader(newJWS(`{}`)))
+	require.ErrorContains(t, err, "replay: unexpected request: POST https://example.com/new-order")
+}
+
+func TestTransport_Remaining(t *testing.T) {
+	tr := &Transport{
+		interactions: []recorder.Interaction{
+			{Request: recorder.Request{Method: http.MethodGet, URL: "https://example.com/dir"}},
+			{Request: recorder.Request{Method: http.MethodHead, URL: "https://example.com/nonce"}},
+		},
+		used: []bool{true, false},
+	}
+
+	remaining := tr.Remaining()
+
+	assert.Equal(t, map[int]recorder.Interaction{
+		1: {Request: recorder.Request{Method: http.MethodHead, URL: "https://example.com/nonce"}},
+	}, remaining)
+}
+
+func newJWS(payload string) string {
+	return `{"protected":"` + base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256"}`)) + `",` +
+		`"payload":"` + base64.RawURLEncoding.EncodeToString([]byte(payload)) + `",` +
+		`"signature":"sig"}`
+}
@@ -11,7 +11,13 @@ import (
 
 // For polls the given function 'f', once every 'interval', up to 'timeout'.
 func For(msg string, timeout, interval time.Duration, f func() (bool, error)) error {
-	log.Infof("Wait for %s [timeout: %s, interval: %s]", msg, timeout, interval)
+	return ForContext(context.Background(), msg, timeout, interval, f)
+}
+
+// ForContext polls the given function 'f', once every 'interval', up to 'timeout' or the cancellation of the context.
+// The log entries are written with the Printer of the context (see log.NewContext).
+func ForContext(ctx context.Context, msg string, timeout, interval time.Duration, f func() (bool, error)) error {
+	log.FromContext(ctx).Infof("Wait for %s [timeout: %s, interval: %s]", msg, timeout, interval)
 
 	var lastErr error
 
@@ -25,6 +31,8 @@ func For(msg string, timeout, interval time.Duration, f func() (bool, error)) er
 			}
 
 			return fmt.Errorf("%s: time limit exceeded: last error: %w", msg, lastErr)
+		case <-ctx.Done():
+			return fmt.Errorf("%s: %w", msg, context.Cause(ctx))
 		default:
 		}
 
@@ -37,7 +45,27 @@ func For(msg string, timeout, interval time.Duration, f func() (bool, error)) er
 			lastErr = err
 		}
 
-		time.Sleep(interval)
+		err = Sleep(ctx, interval)
+		if err != nil {
+			return fmt.Errorf("%s: %w", msg, err)
+		}
+	}
+}
+
+// Sleep pauses for the duration, or until the cancellation of the context.
+func Sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
 	}
 }
 
@@ -1,11 +1,16 @@
 package wait
 
 import (
+	"bytes"
+	"context"
 	"errors"
+	stdlog "log"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/go-acme/lego/v4/log"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -119,3 +124,40 @@ func TestFor_stop_with_error(t *testing.T) {
 
 	require.EqualValues(t, 1, io.Load())
 }
+
+func TestForContext_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+
+	var calls atomic.Int64
+
+	err := ForContext(ctx, "test", time.Minute, 10*time.Millisecond, func() (bool, error) {
+		if calls.Add(1) == 2 {
+			cancel()
+		}
+
+		return false, nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualValues(t, 2, calls.Load())
+}
+
+func TestSleep_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	err := Sleep(ctx, time.Minute)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestForContext_logger(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	ctx := log.NewContext(t.Context(), log.With(stdlog.New(buf, "", 0)))
+
+	err := ForContext(ctx, "test", time.Second, 10*time.Millisecond, func() (bool, error) {
+		return true, nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "[INFO] Wait for test [timeout: 1s, interval: 10ms]\n", buf.String())
+}
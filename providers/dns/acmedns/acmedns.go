@@ -49,6 +49,11 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{}
 }
 
@@ -33,12 +33,17 @@ type Config = active24.Config
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
 		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
 		},
 	}
 }
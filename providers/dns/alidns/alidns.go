@@ -14,6 +14,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/cloudendpoint"
 	"github.com/go-acme/lego/v4/providers/dns/internal/ptr"
 	"golang.org/x/net/idna"
 )
@@ -27,6 +28,7 @@ const (
 	EnvSecretKey     = envNamespace + "SECRET_KEY"
 	EnvSecurityToken = envNamespace + "SECURITY_TOKEN"
 	EnvRegionID      = envNamespace + "REGION_ID"
+	EnvEndpoint      = envNamespace + "ENDPOINT"
 	EnvLine          = envNamespace + "LINE"
 
 	EnvTTL                = envNamespace + "TTL"
@@ -46,6 +48,7 @@ type Config struct {
 	SecretKey          string
 	SecurityToken      string
 	RegionID           string
+	Endpoint           string
 	Line               string
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
@@ -55,11 +58,16 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, 600),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPTimeout:        env.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, 600),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		HTTPTimeout:        lookup.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
 	}
 }
 
@@ -76,6 +84,7 @@ type DNSProvider struct {
 func NewDNSProvider() (*DNSProvider, error) {
 	config := NewDefaultConfig()
 	config.RegionID = env.GetOrFile(EnvRegionID)
+	config.Endpoint = env.GetOrFile(EnvEndpoint)
 	config.Line = env.GetOrFile(EnvLine)
 
 	values, err := env.Get(EnvRAMRole)
@@ -110,6 +119,15 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		SetRegionId(config.RegionID).
 		SetReadTimeout(int(config.HTTPTimeout.Milliseconds()))
 
+	if config.Endpoint != "" {
+		endpoint, err := cloudendpoint.Host(config.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("alicloud: %w", err)
+		}
+
+		cfg = cfg.SetEndpoint(endpoint)
+	}
+
 	switch {
 	case config.RAMRole != "":
 		// https://www.alibabacloud.com/help/en/ecs/user-guide/attach-an-instance-ram-role-to-an-ecs-instance
@@ -89,6 +89,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 		ramRole   string
 		apiKey    string
 		secretKey string
+		endpoint  string
 		expected  string
 	}{
 		{
@@ -96,6 +97,12 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			apiKey:    "123",
 			secretKey: "456",
 		},
+		{
+			desc:      "success with endpoint",
+			apiKey:    "123",
+			secretKey: "456",
+			endpoint:  "https://alidns.ap-southeast-1.aliyuncs.com",
+		},
 		{
 			desc:    "success",
 			ramRole: "LegoInstanceRole",
@@ -114,6 +121,13 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			apiKey:   "123",
 			expected: "alicloud: ram role or credentials missing",
 		},
+		{
+			desc:      "invalid endpoint",
+			apiKey:    "123",
+			secretKey: "456",
+			endpoint:  "https:///path",
+			expected:  `alicloud: invalid endpoint "https:///path": missing host`,
+		},
 	}
 
 	for _, test := range testCases {
@@ -122,6 +136,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			config.APIKey = test.apiKey
 			config.SecretKey = test.secretKey
 			config.RAMRole = test.ramRole
+			config.Endpoint = test.endpoint
 
 			p, err := NewDNSProviderConfig(config)
 
@@ -51,11 +51,16 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPTimeout:        env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		HTTPTimeout:        lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
 	}
 }
 
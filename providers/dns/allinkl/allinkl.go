@@ -43,11 +43,16 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
 		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
 		},
 	}
 }
@@ -86,7 +86,7 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteRecord(t.Context(), "example.com", "12345678-1234-1234-1234-123456789abc")
-	require.EqualError(t, err, "401: Unauthorized")
+	require.EqualError(t, err, "401: Unauthorized [endpoint: DELETE /api/clouddns/v1/zone.json/example.com/records/12345678-1234-1234-1234-123456789abc]")
 }
 
 func TestClient_GetZone(t *testing.T) {
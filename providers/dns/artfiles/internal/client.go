@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
@@ -126,7 +127,7 @@ func (c *Client) do(req *http.Request) ([]byte, error) {
 	}
 
 	if resp.StatusCode/100 != 2 {
-		return nil, errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw)
+		return nil, providers.NewAPIError(req, resp, errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw))
 	}
 
 	return raw, nil
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -86,7 +87,7 @@ func (c *Client) do(req *http.Request, result any) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode/100 != 2 {
-		return parseError(req, resp)
+		return providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	if result == nil {
@@ -5,71 +5,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/axelname/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "AXELNAME_"
-
-	EnvNickname = envNamespace + "NICKNAME"
-	EnvToken    = envNamespace + "TOKEN"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-)
-
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	Nickname string
-	Token    string
-
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
 	client *internal.Client
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for Axelname.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvNickname, EnvToken)
-	if err != nil {
-		return nil, fmt.Errorf("axelname: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.Nickname = values[EnvNickname]
-	config.Token = values[EnvToken]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for Axelname.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -54,7 +54,7 @@ func TestClient_ListRecords_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.ListRecords(t.Context(), "example.com")
-	require.EqualError(t, err, "error: Domain not found (1)")
+	require.EqualError(t, err, "error: Domain not found (1) [endpoint: GET /dns_list]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -84,7 +84,7 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 	record := Record{ID: "74749"}
 
 	err := client.DeleteRecord(t.Context(), "example.com", record)
-	require.EqualError(t, err, "error: Domain not found (1)")
+	require.EqualError(t, err, "error: Domain not found (1) [endpoint: GET /dns_delete]")
 }
 
 func TestClient_AddRecord(t *testing.T) {
@@ -114,5 +114,5 @@ func TestClient_AddRecord_error(t *testing.T) {
 	record := Record{ID: "74749"}
 
 	err := client.AddRecord(t.Context(), "example.com", record)
-	require.EqualError(t, err, "error: Domain not found (1)")
+	require.EqualError(t, err, "error: Domain not found (1) [endpoint: GET /dns_add]")
 }
@@ -0,0 +1,68 @@
+// Code generated by 'make generate-dns'; DO NOT EDIT.
+
+package axelname
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "AXELNAME_"
+
+	EnvNickname = envNamespace + "NICKNAME"
+	EnvToken    = envNamespace + "TOKEN"
+
+	EnvTTL                = envNamespace + "TTL"
+	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
+	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
+)
+
+// Config is used to configure the creation of the DNSProvider.
+type Config struct {
+	Nickname           string
+	Token              string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
+	return &Config{
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		},
+	}
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Axelname.
+// Credentials must be passed in the environment variables:
+// AXELNAME_NICKNAME, AXELNAME_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get(EnvNickname, EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("axelname: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Nickname = values[EnvNickname]
+	config.Token = values[EnvToken]
+
+	return NewDNSProviderConfig(config)
+}
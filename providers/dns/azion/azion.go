@@ -10,67 +10,15 @@ import (
 
 	"github.com/aziontech/azionapi-go-sdk/idns"
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "AZION_"
-
-	EnvPersonalToken = envNamespace + "PERSONAL_TOKEN"
-	EnvPageSize      = envNamespace + "PAGE_SIZE"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-)
-
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	PersonalToken string
-	PageSize      int
-
-	PollingInterval    time.Duration
-	PropagationTimeout time.Duration
-	TTL                int
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		PageSize:           env.GetOrDefaultInt(EnvPageSize, 50),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
 	client *idns.APIClient
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for Azion.
-// Credentials must be passed in the environment variable: AZION_PERSONAL_TOKEN.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvPersonalToken)
-	if err != nil {
-		return nil, fmt.Errorf("azion: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.PersonalToken = values[EnvPersonalToken]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for Azion.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -0,0 +1,68 @@
+// Code generated by 'make generate-dns'; DO NOT EDIT.
+
+package azion
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "AZION_"
+
+	EnvPersonalToken = envNamespace + "PERSONAL_TOKEN"
+	EnvPageSize      = envNamespace + "PAGE_SIZE"
+
+	EnvTTL                = envNamespace + "TTL"
+	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
+	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
+)
+
+// Config is used to configure the creation of the DNSProvider.
+type Config struct {
+	PersonalToken      string
+	PageSize           int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
+	return &Config{
+		PageSize:           lookup.GetOrDefaultInt(EnvPageSize, 50),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		HTTPClient: &http.Client{
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		},
+	}
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Azion.
+// Credentials must be passed in the environment variables:
+// AZION_PERSONAL_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get(EnvPersonalToken)
+	if err != nil {
+		return nil, fmt.Errorf("azion: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.PersonalToken = values[EnvPersonalToken]
+
+	return NewDNSProviderConfig(config)
+}
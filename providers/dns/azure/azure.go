@@ -57,6 +57,9 @@ type Config struct {
 	ResourceGroup  string
 	PrivateZone    bool
 
+	// BypassDeprecation reactivates the deprecated provider.
+	BypassDeprecation bool
+
 	MetadataEndpoint        string
 	ResourceManagerEndpoint string
 	ActiveDirectoryEndpoint string
@@ -69,14 +72,20 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		ZoneName:                env.GetOrFile(EnvZoneName),
-		TTL:                     env.GetOrDefaultInt(EnvTTL, 60),
-		PropagationTimeout:      env.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
-		PollingInterval:         env.GetOrDefaultSecond(EnvPollingInterval, 2*time.Second),
-		MetadataEndpoint:        env.GetOrFile(EnvMetadataEndpoint),
+		ZoneName:                lookup.GetOrFile(EnvZoneName),
+		TTL:                     lookup.GetOrDefaultInt(EnvTTL, 60),
+		PropagationTimeout:      lookup.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
+		PollingInterval:         lookup.GetOrDefaultSecond(EnvPollingInterval, 2*time.Second),
+		MetadataEndpoint:        lookup.GetOrFile(EnvMetadataEndpoint),
 		ResourceManagerEndpoint: aazure.PublicCloud.ResourceManagerEndpoint,
 		ActiveDirectoryEndpoint: aazure.PublicCloud.ActiveDirectoryEndpoint,
+		BypassDeprecation:       lookup.GetOrDefaultBool(EnvLegoAzureBypassDeprecation, false),
 	}
 }
 
@@ -136,7 +145,7 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("azure: the configuration of the DNS provider is nil")
 	}
 
-	if !env.GetOrDefaultBool(EnvLegoAzureBypassDeprecation, false) {
+	if !config.BypassDeprecation {
 		var msg strings.Builder
 
 		msg.WriteString("azure: ")
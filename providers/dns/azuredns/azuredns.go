@@ -5,6 +5,7 @@ package azuredns
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
+	"github.com/go-acme/lego/v4/providers/dns/internal/cloudendpoint"
 )
 
 // Environment variables names.
@@ -19,11 +21,15 @@ const (
 	envNamespace = "AZURE_"
 
 	EnvEnvironment    = envNamespace + "ENVIRONMENT"
+	EnvAuthorityHost  = envNamespace + "AUTHORITY_HOST"
 	EnvSubscriptionID = envNamespace + "SUBSCRIPTION_ID"
 	EnvResourceGroup  = envNamespace + "RESOURCE_GROUP"
 	EnvZoneName       = envNamespace + "ZONE_NAME"
 	EnvPrivateZone    = envNamespace + "PRIVATE_ZONE"
 
+	EnvResourceManagerEndpoint = envNamespace + "RESOURCE_MANAGER_ENDPOINT"
+	EnvResourceManagerAudience = envNamespace + "RESOURCE_MANAGER_AUDIENCE"
+
 	EnvTenantID     = envNamespace + "TENANT_ID"
 	EnvClientID     = envNamespace + "CLIENT_ID"
 	EnvClientSecret = envNamespace + "CLIENT_SECRET"
@@ -92,11 +98,16 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		ZoneName:           env.GetOrFile(EnvZoneName),
-		TTL:                env.GetOrDefaultInt(EnvTTL, 60),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 2*time.Second),
+		ZoneName:           lookup.GetOrFile(EnvZoneName),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, 60),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, 2*time.Second),
 		Environment:        cloud.AzurePublic,
 	}
 }
@@ -110,22 +121,13 @@ type DNSProvider struct {
 func NewDNSProvider() (*DNSProvider, error) {
 	config := NewDefaultConfig()
 
-	environmentName := env.GetOrFile(EnvEnvironment)
-	if environmentName != "" {
-		switch environmentName {
-		case "china":
-			config.Environment = cloud.AzureChina
-		case "public":
-			config.Environment = cloud.AzurePublic
-		case "usgovernment":
-			config.Environment = cloud.AzureGovernment
-		default:
-			return nil, fmt.Errorf("azuredns: unknown environment %s", environmentName)
-		}
-	} else {
-		config.Environment = cloud.AzurePublic
+	environment, err := getEnvironment(env.GetOrFile(EnvEnvironment))
+	if err != nil {
+		return nil, fmt.Errorf("azuredns: %w", err)
 	}
 
+	config.Environment = environment
+
 	config.SubscriptionID = env.GetOrFile(EnvSubscriptionID)
 	config.ResourceGroup = env.GetOrFile(EnvResourceGroup)
 	config.PrivateZone = env.GetOrDefaultBool(EnvPrivateZone, false)
@@ -210,3 +212,61 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	return d.provider.CleanUp(domain, token, keyAuth)
 }
+
+// getEnvironment returns the configuration of the Azure cloud (public, china, or usgovernment),
+// with the endpoint overrides (ex: Azure Stack, sovereign clouds).
+func getEnvironment(name string) (cloud.Configuration, error) {
+	var environment cloud.Configuration
+
+	switch name {
+	case "", "public":
+		environment = cloud.AzurePublic
+	case "china":
+		environment = cloud.AzureChina
+	case "usgovernment":
+		environment = cloud.AzureGovernment
+	default:
+		return cloud.Configuration{}, fmt.Errorf("unknown environment %s", name)
+	}
+
+	authorityHost := env.GetOrFile(EnvAuthorityHost)
+	resourceManagerEndpoint := env.GetOrFile(EnvResourceManagerEndpoint)
+	resourceManagerAudience := env.GetOrFile(EnvResourceManagerAudience)
+
+	if authorityHost == "" && resourceManagerEndpoint == "" && resourceManagerAudience == "" {
+		return environment, nil
+	}
+
+	if authorityHost != "" {
+		u, err := cloudendpoint.URL(authorityHost)
+		if err != nil {
+			return cloud.Configuration{}, fmt.Errorf("authority host: %w", err)
+		}
+
+		environment.ActiveDirectoryAuthorityHost = u.String()
+	}
+
+	// The map of the services is shared by the predefined configurations.
+	services := maps.Clone(environment.Services)
+
+	resourceManager := services[cloud.ResourceManager]
+
+	if resourceManagerEndpoint != "" {
+		u, err := cloudendpoint.URL(resourceManagerEndpoint)
+		if err != nil {
+			return cloud.Configuration{}, fmt.Errorf("resource manager endpoint: %w", err)
+		}
+
+		resourceManager.Endpoint = u.String()
+	}
+
+	if resourceManagerAudience != "" {
+		resourceManager.Audience = resourceManagerAudience
+	}
+
+	services[cloud.ResourceManager] = resourceManager
+
+	environment.Services = services
+
+	return environment, nil
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,6 +14,9 @@ const envDomain = envNamespace + "DOMAIN"
 
 var envTest = tester.NewEnvTest(
 	EnvEnvironment,
+	EnvAuthorityHost,
+	EnvResourceManagerEndpoint,
+	EnvResourceManagerAudience,
 	EnvSubscriptionID,
 	EnvResourceGroup).
 	WithDomain(envDomain)
@@ -56,6 +60,61 @@ func TestNewDNSProvider(t *testing.T) {
 	}
 }
 
+func Test_getEnvironment(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		name     string
+		envVars  map[string]string
+		expected cloud.Configuration
+	}{
+		{
+			desc:     "default",
+			expected: cloud.AzurePublic,
+		},
+		{
+			desc:     "china",
+			name:     "china",
+			expected: cloud.AzureChina,
+		},
+		{
+			desc: "overrides",
+			name: "usgovernment",
+			envVars: map[string]string{
+				EnvAuthorityHost:           "login.example.com",
+				EnvResourceManagerEndpoint: "https://management.example.com/",
+				EnvResourceManagerAudience: "https://management.core.example.com/",
+			},
+			expected: cloud.Configuration{
+				ActiveDirectoryAuthorityHost: "https://login.example.com",
+				Services: map[cloud.ServiceName]cloud.ServiceConfiguration{
+					cloud.ResourceManager: {
+						Endpoint: "https://management.example.com/",
+						Audience: "https://management.core.example.com/",
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			environment, err := getEnvironment(test.name)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, environment)
+		})
+	}
+
+	// The predefined configurations are not modified.
+	assert.Equal(t, "https://management.usgovcloudapi.net", cloud.AzureGovernment.Services[cloud.ResourceManager].Endpoint)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
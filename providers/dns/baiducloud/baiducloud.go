@@ -8,64 +8,18 @@ import (
 
 	baidudns "github.com/baidubce/bce-sdk-go/services/dns"
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/ptr"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "BAIDUCLOUD_"
-
-	EnvAccessKeyID     = envNamespace + "ACCESS_KEY_ID"
-	EnvSecretAccessKey = envNamespace + "SECRET_ACCESS_KEY"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-)
-
 // 300 is the minimum TTL for free users.
 const defaultTTL = 300
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	AccessKeyID     string
-	SecretAccessKey string
-
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, defaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
 	client *baidudns.Client
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for Baidu Cloud.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvAccessKeyID, EnvSecretAccessKey)
-	if err != nil {
-		return nil, fmt.Errorf("baiducloud: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.AccessKeyID = values[EnvAccessKeyID]
-	config.SecretAccessKey = values[EnvSecretAccessKey]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for Baidu Cloud.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -105,7 +106,7 @@ func (c *Client) doRequest(ctx context.Context, data any, fragments ...string) (
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode/100 != 2 {
-		return nil, parseError(req, resp)
+		return nil, providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	raw, err := io.ReadAll(resp.Body)
@@ -71,7 +71,7 @@ func TestClient_CreateRecord_error(t *testing.T) {
 	}
 
 	_, err := client.CreateRecord(t.Context(), "example.com", record)
-	require.EqualError(t, err, "400: type: title: detail: instance: property1: a")
+	require.EqualError(t, err, "400: type: title: detail: instance: property1: a [endpoint: POST /domains/example.com/records]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -93,5 +93,5 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteRecord(t.Context(), "example.com", 123)
-	require.EqualError(t, err, "400: type: title: detail: instance: property1: a")
+	require.EqualError(t, err, "400: type: title: detail: instance: property1: a [endpoint: DELETE /domains/example.com/records/123]")
 }
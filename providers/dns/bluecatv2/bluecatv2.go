@@ -5,62 +5,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/bluecatv2/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "BLUECATV2_"
-
-	EnvServerURL  = envNamespace + "SERVER_URL"
-	EnvUsername   = envNamespace + "USERNAME"
-	EnvPassword   = envNamespace + "PASSWORD"
-	EnvConfigName = envNamespace + "CONFIG_NAME"
-	EnvViewName   = envNamespace + "VIEW_NAME"
-	EnvSkipDeploy = envNamespace + "SKIP_DEPLOY"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-)
-
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	ServerURL  string
-	Username   string
-	Password   string
-	ConfigName string
-	ViewName   string
-	SkipDeploy bool
-
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		SkipDeploy: env.GetOrDefaultBool(EnvSkipDeploy, false),
-
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
@@ -71,23 +23,6 @@ type DNSProvider struct {
 	recordIDsMu sync.Mutex
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for Bluecat v2.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvServerURL, EnvUsername, EnvPassword, EnvConfigName, EnvViewName)
-	if err != nil {
-		return nil, fmt.Errorf("bluecatv2: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.ServerURL = values[EnvServerURL]
-	config.Username = values[EnvUsername]
-	config.Password = values[EnvPassword]
-	config.ConfigName = values[EnvConfigName]
-	config.ViewName = values[EnvViewName]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for Bluecat v2.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -95,7 +95,7 @@ func TestClient_RetrieveZones_error(t *testing.T) {
 	}
 
 	_, err := client.RetrieveZones(mockToken(t.Context()), opts)
-	require.EqualError(t, err, "401: Unauthorized: InvalidAuthorizationToken: The provided authorization token is invalid")
+	require.EqualError(t, err, "401: Unauthorized: InvalidAuthorizationToken: The provided authorization token is invalid [endpoint: GET /api/v2/zones]")
 }
 
 func TestClient_RetrieveZoneDeployments(t *testing.T) {
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	querystring "github.com/google/go-querystring/query"
 )
@@ -107,7 +108,7 @@ func (c *Client) do(ctx context.Context, endpoint *url.URL) error {
 	}
 
 	if resp.StatusCode/100 != 2 {
-		return errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw)
+		return providers.NewAPIError(req, resp, errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw))
 	}
 
 	if !strings.HasPrefix(string(raw), "good: update done") && !strings.HasPrefix(string(raw), "good: remove done") {
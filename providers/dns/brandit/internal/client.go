@@ -15,6 +15,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -164,7 +165,7 @@ func (c *Client) do(ctx context.Context, query url.Values, result any) error {
 	}
 
 	if apiError.Code > 299 || apiError.Status != "success" {
-		return apiError
+		return providers.NewAPIError(req, resp, apiError)
 	}
 
 	if result == nil {
@@ -1,10 +1,12 @@
 package internal
 
 import (
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -78,6 +80,12 @@ func TestClient_StatusDomain_error(t *testing.T) {
 
 	_, err := client.StatusDomain(t.Context(), "example.com")
 	require.ErrorIs(t, err, APIError{Code: 402, Status: "error", Message: "Invalid user."})
+
+	var apiErr *providers.APIError
+	require.ErrorAs(t, err, &apiErr)
+
+	assert.Equal(t, http.StatusOK, apiErr.StatusCode)
+	assert.EqualError(t, err, "code: 402, status: error, message: Invalid user. [endpoint: POST /]")
 }
 
 func TestClient_ListRecords(t *testing.T) {
@@ -19,64 +19,16 @@ import (
 	"golang.org/x/net/publicsuffix"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "BUNNY_"
-
-	EnvAPIKey = envNamespace + "API_KEY"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-)
-
 const minTTL = 60
 
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	APIKey string
-
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 120*time.Second),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
 	client *bunny.Client
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for bunny.
-// Credentials must be passed in the environment variable: BUNNY_API_KEY.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvAPIKey)
-	if err != nil {
-		return nil, fmt.Errorf("bunny: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.APIKey = values[EnvAPIKey]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for bunny.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -87,8 +39,14 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("bunny: credentials missing")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("bunny: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL, 0)
+	if err != nil {
+		return nil, fmt.Errorf("bunny: %w", err)
+	}
+
+	err = env.ValidatePropagation(config.PropagationTimeout, config.PollingInterval)
+	if err != nil {
+		return nil, fmt.Errorf("bunny: %w", err)
 	}
 
 	if config.HTTPClient == nil {
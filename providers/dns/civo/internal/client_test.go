@@ -84,7 +84,7 @@ func TestClient_ListDNSRecords_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.ListDNSRecords(t.Context(), "7088fcea-7658-43e6-97fa-273f901978fd")
-	require.EqualError(t, err, "database_account_not_found: Failed to find the account within the internal database")
+	require.EqualError(t, err, "database_account_not_found: Failed to find the account within the internal database [endpoint: GET /dns/7088fcea-7658-43e6-97fa-273f901978fd/records]")
 }
 
 func TestClient_ListDNSRecords_error_raw(t *testing.T) {
@@ -100,7 +100,7 @@ func TestClient_ListDNSRecords_error_raw(t *testing.T) {
 		Build(t)
 
 	_, err := client.ListDNSRecords(t.Context(), "7088fcea-7658-43e6-97fa-273f901978fd")
-	require.EqualError(t, err, "unexpected status code: [status code: 404] body: Not Found")
+	require.EqualError(t, err, "unexpected status code: [status code: 404] body: Not Found [endpoint: GET /dns/7088fcea-7658-43e6-97fa-273f901978fd/records]")
 }
 
 func TestClient_CreateDNSRecord(t *testing.T) {
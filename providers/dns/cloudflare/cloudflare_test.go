@@ -217,6 +217,7 @@ func TestNewDNSProviderWithToken(t *testing.T) {
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc      string
+		ttl       int
 		authEmail string
 		authKey   string
 		authToken string
@@ -224,38 +225,52 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}{
 		{
 			desc:      "success with email and api key",
+			ttl:       minTTL,
 			authEmail: "test@example.com",
 			authKey:   "123",
 		},
 		{
 			desc:      "success with api token",
+			ttl:       minTTL,
 			authToken: "012345abcdef",
 		},
 		{
 			desc:      "prefer api token",
+			ttl:       minTTL,
 			authToken: "012345abcdef",
 			authEmail: "test@example.com",
 			authKey:   "123",
 		},
 		{
 			desc:     "missing credentials",
+			ttl:      minTTL,
 			expected: "cloudflare: invalid credentials: authEmail, authKey or authToken must be set",
 		},
 		{
 			desc:     "missing email",
+			ttl:      minTTL,
 			authKey:  "123",
 			expected: "cloudflare: invalid credentials: authEmail and authKey must be set together",
 		},
 		{
 			desc:      "missing api key",
+			ttl:       minTTL,
 			authEmail: "test@example.com",
 			expected:  "cloudflare: invalid credentials: authEmail and authKey must be set together",
 		},
+		{
+			desc:      "invalid TTL",
+			ttl:       119,
+			authEmail: "test@example.com",
+			authKey:   "123",
+			expected:  "cloudflare: invalid TTL, TTL (119) must be greater than 120",
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
+			config.TTL = test.ttl
 			config.AuthEmail = test.authEmail
 			config.AuthKey = test.authKey
 			config.AuthToken = test.authToken
@@ -79,7 +79,7 @@ func TestClient_CreateDNSRecord_error(t *testing.T) {
 	}
 
 	_, err := client.CreateDNSRecord(t.Context(), "023e105f4ecef8ad9ca31a8372d0c353", record)
-	require.EqualError(t, err, "[status code 400] 6003: Invalid request headers; 6103: Invalid format for X-Auth-Key header")
+	require.EqualError(t, err, "[status code 400] 6003: Invalid request headers; 6103: Invalid format for X-Auth-Key header [endpoint: POST /zones/023e105f4ecef8ad9ca31a8372d0c353/dns_records]")
 }
 
 func TestClient_DeleteDNSRecord(t *testing.T) {
@@ -100,7 +100,7 @@ func TestClient_DeleteDNSRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteDNSRecord(context.Background(), "023e105f4ecef8ad9ca31a8372d0c353", "xxx")
-	require.EqualError(t, err, "[status code 400] 6003: Invalid request headers; 6103: Invalid format for X-Auth-Key header")
+	require.EqualError(t, err, "[status code 400] 6003: Invalid request headers; 6103: Invalid format for X-Auth-Key header [endpoint: DELETE /zones/023e105f4ecef8ad9ca31a8372d0c353/dns_records/xxx]")
 }
 
 func TestClient_ZonesByName(t *testing.T) {
@@ -172,5 +172,5 @@ func TestClient_ZonesByName_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.ZonesByName(context.Background(), "example.com")
-	require.EqualError(t, err, "[status code 400] 6003: Invalid request headers; 6103: Invalid format for X-Auth-Key header")
+	require.EqualError(t, err, "[status code 400] 6003: Invalid request headers; 6103: Invalid format for X-Auth-Key header [endpoint: GET /zones]")
 }
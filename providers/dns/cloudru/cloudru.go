@@ -5,61 +5,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"strconv"
 	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/cloudru/internal"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "CLOUDRU_"
-
-	EnvServiceInstanceID = envNamespace + "SERVICE_INSTANCE_ID"
-	EnvKeyID             = envNamespace + "KEY_ID"
-	EnvSecret            = envNamespace + "SECRET"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvSequenceInterval   = envNamespace + "SEQUENCE_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-)
-
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	ServiceInstanceID string
-	KeyID             string
-	Secret            string
-
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	SequenceInterval   time.Duration
-	HTTPClient         *http.Client
-	TTL                int
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 5*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 5*time.Second),
-		SequenceInterval:   env.GetOrDefaultSecond(EnvSequenceInterval, dns01.DefaultPropagationTimeout),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 type DNSProvider struct {
 	config *Config
 	client *internal.Client
@@ -68,23 +25,6 @@ type DNSProvider struct {
 	recordsMu sync.Mutex
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for cloud.ru.
-// Credentials must be passed in the environment variables:
-// CLOUDRU_SERVICE_INSTANCE_ID, CLOUDRU_KEY_ID, and CLOUDRU_SECRET.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvServiceInstanceID, EnvKeyID, EnvSecret)
-	if err != nil {
-		return nil, fmt.Errorf("cloudru: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.ServiceInstanceID = values[EnvServiceInstanceID]
-	config.KeyID = values[EnvKeyID]
-	config.Secret = values[EnvSecret]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for cloud.ru.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -17,6 +18,10 @@ type token string
 
 const tokenKey token = "token"
 
+// tokenExpirationMargin the token is renewed when it expires in less than this duration
+// (duration of the requests, clock skew).
+const tokenExpirationMargin = time.Minute
+
 // obtainToken Logs into cloud.ru and acquires a bearer token for use in future API calls.
 // https://cloud.ru/ru/docs/clouddns/ug/topics/api-ref_authentication.html
 func (c *Client) obtainToken(ctx context.Context) (*Token, error) {
@@ -40,7 +45,7 @@ func (c *Client) obtainToken(ctx context.Context) (*Token, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, parseError(req, resp)
+		return nil, providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	raw, err := io.ReadAll(resp.Body)
@@ -64,11 +69,13 @@ func (c *Client) obtainToken(ctx context.Context) (*Token, error) {
 	return &tok, nil
 }
 
+// CreateAuthenticatedContext adds the token to the context.
+// The token is reused until it is about to expire.
 func (c *Client) CreateAuthenticatedContext(ctx context.Context) (context.Context, error) {
 	c.muToken.Lock()
 	defer c.muToken.Unlock()
 
-	if c.token != nil && time.Now().Before(c.token.Deadline) {
+	if c.token != nil && time.Now().Add(tokenExpirationMargin).Before(c.token.Deadline) {
 		// Already authenticated, stop now
 		return context.WithValue(ctx, tokenKey, c.token), nil
 	}
@@ -78,6 +85,8 @@ func (c *Client) CreateAuthenticatedContext(ctx context.Context) (context.Contex
 		return nil, err
 	}
 
+	c.token = tok
+
 	return context.WithValue(ctx, tokenKey, tok), nil
 }
 
@@ -2,9 +2,11 @@ package internal
 
 import (
 	"context"
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
 	"github.com/stretchr/testify/assert"
@@ -84,3 +86,38 @@ func TestClient_CreateAuthenticatedContext(t *testing.T) {
 	assert.NotZero(t, tok.Deadline)
 	assert.Equal(t, "xxx", tok.AccessToken)
 }
+
+func TestClient_CreateAuthenticatedContext_reuse(t *testing.T) {
+	var calls int
+
+	client := servermock.NewBuilder[*Client](setupIdentityClient,
+		servermock.CheckHeader().
+			WithContentTypeFromURLEncoded(),
+	).
+		Route("POST /", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			calls++
+
+			servermock.JSONEncode(Token{
+				AccessToken: "xxx",
+				ExpiresIn:   3600,
+				TokenType:   "Bearer",
+			}).ServeHTTP(rw, req)
+		})).
+		Build(t)
+
+	_, err := client.CreateAuthenticatedContext(t.Context())
+	require.NoError(t, err)
+
+	_, err = client.CreateAuthenticatedContext(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, calls)
+
+	// the token is about to expire.
+	client.token.Deadline = time.Now().Add(tokenExpirationMargin / 2)
+
+	_, err = client.CreateAuthenticatedContext(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
 )
 
 // Environment variables names.
@@ -37,6 +38,12 @@ func NewDefaultConfig() *Config {
 	return &Config{}
 }
 
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider.
+// The provider doesn't read environment variables.
+func NewDefaultConfigFrom(_ env.LookupFunc) *Config {
+	return NewDefaultConfig()
+}
+
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct{}
 
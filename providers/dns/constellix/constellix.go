@@ -12,71 +12,19 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/constellix/internal"
+	"github.com/go-acme/lego/v4/providers/dns/internal/backoff"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
-	"github.com/hashicorp/go-retryablehttp"
-)
-
-// Environment variables names.
-const (
-	envNamespace = "CONSTELLIX_"
-
-	EnvAPIKey    = envNamespace + "API_KEY"
-	EnvSecretKey = envNamespace + "SECRET_KEY"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
 )
 
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	APIKey             string
-	SecretKey          string
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, 60),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 10*time.Second),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
 	client *internal.Client
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for Constellix.
-// Credentials must be passed in the environment variables:
-// CONSTELLIX_API_KEY and CONSTELLIX_SECRET_KEY.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvAPIKey, EnvSecretKey)
-	if err != nil {
-		return nil, fmt.Errorf("constellix: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.APIKey = values[EnvAPIKey]
-	config.SecretKey = values[EnvSecretKey]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for Constellix.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -92,12 +40,10 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("constellix: %w", err)
 	}
 
-	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = 5
-	retryClient.HTTPClient = tr.Wrap(config.HTTPClient)
-	retryClient.Backoff = backoff
+	retryConfig := backoff.NewDefaultConfig(config.MaxRetries)
+	retryConfig.Delay = rateLimitDelay
 
-	client := internal.NewClient(clientdebug.Wrap(retryClient.StandardClient()))
+	client := internal.NewClient(clientdebug.Wrap(backoff.Wrap(tr.Wrap(config.HTTPClient), retryConfig)))
 
 	return &DNSProvider{config: config, client: client}, nil
 }
@@ -285,17 +231,16 @@ func containsValue(record *internal.Record, value string) bool {
 	})
 }
 
-func backoff(minimum, maximum time.Duration, attemptNum int, resp *http.Response) time.Duration {
-	if resp != nil {
-		// https://api.dns.constellix.com/v4/docs#section/Using-the-API/Rate-Limiting
-		if resp.StatusCode == http.StatusTooManyRequests {
-			if s, ok := resp.Header["X-Ratelimit-Reset"]; ok {
-				if sleep, err := strconv.ParseInt(s[0], 10, 64); err == nil {
-					return time.Second * time.Duration(sleep)
-				}
+// rateLimitDelay returns the delay requested by the API when the requests are throttled.
+func rateLimitDelay(resp *http.Response) time.Duration {
+	// https://api.dns.constellix.com/v4/docs#section/Using-the-API/Rate-Limiting
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if s, ok := resp.Header["X-Ratelimit-Reset"]; ok {
+			if sleep, err := strconv.ParseInt(s[0], 10, 64); err == nil {
+				return time.Second * time.Duration(sleep)
 			}
 		}
 	}
 
-	return retryablehttp.DefaultBackoff(minimum, maximum, attemptNum, resp)
+	return backoff.RetryAfter(resp)
 }
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -64,7 +65,7 @@ func (c *Client) do(req *http.Request, result any) error {
 
 	err = checkResponse(resp)
 	if err != nil {
-		return err
+		return providers.NewAPIError(req, resp, err)
 	}
 
 	raw, err := io.ReadAll(resp.Body)
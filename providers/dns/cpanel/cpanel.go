@@ -6,35 +6,18 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"net/http"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/cpanel/internal/cpanel"
 	"github.com/go-acme/lego/v4/providers/dns/cpanel/internal/shared"
 	"github.com/go-acme/lego/v4/providers/dns/cpanel/internal/whm"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "CPANEL_"
-
-	EnvMode     = envNamespace + "MODE"
-	EnvUsername = envNamespace + "USERNAME"
-	EnvToken    = envNamespace + "TOKEN"
-	EnvBaseURL  = envNamespace + "BASE_URL"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-)
-
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
 type apiClient interface {
@@ -44,54 +27,12 @@ type apiClient interface {
 	DeleteRecord(ctx context.Context, serial uint32, domain string, lineIndex int) (*shared.ZoneSerial, error)
 }
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	Mode               string
-	Username           string
-	Token              string
-	BaseURL            string
-	TTL                int
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		Mode:               env.GetOrDefaultString(EnvMode, "cpanel"),
-		TTL:                env.GetOrDefaultInt(EnvTTL, 300),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
 	client apiClient
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for CPanel.
-// Credentials must be passed in the environment variables:
-// CPANEL_USERNAME, CPANEL_TOKEN, CPANEL_BASE_URL, CPANEL_NAMESERVER.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvUsername, EnvToken, EnvBaseURL)
-	if err != nil {
-		return nil, fmt.Errorf("cpanel: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.Username = values[EnvUsername]
-	config.Token = values[EnvToken]
-	config.BaseURL = values[EnvBaseURL]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for CPanel.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
@@ -79,7 +80,7 @@ func (c *Client) do(req *http.Request, result any) error {
 	if resp.StatusCode/100 != 2 {
 		raw, _ := io.ReadAll(resp.Body)
 
-		return errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw)
+		return providers.NewAPIError(req, resp, errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw))
 	}
 
 	if result == nil {
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"golang.org/x/net/html"
@@ -91,7 +92,7 @@ func (c *Client) update(ctx context.Context, params map[string]string) error {
 	if resp.StatusCode/100 != 2 {
 		raw, _ := io.ReadAll(resp.Body)
 
-		return errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw)
+		return providers.NewAPIError(req, resp, errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw))
 	}
 
 	raw, err := io.ReadAll(resp.Body)
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	querystring "github.com/google/go-querystring/query"
 )
@@ -178,11 +179,11 @@ func (c *Client) do(req *http.Request, result any) error {
 	switch req.Method {
 	case http.MethodPut:
 		if resp.StatusCode != http.StatusCreated {
-			return parseError(req, resp)
+			return providers.NewAPIError(req, resp, parseError(req, resp))
 		}
 	default:
 		if resp.StatusCode != http.StatusOK {
-			return parseError(req, resp)
+			return providers.NewAPIError(req, resp, parseError(req, resp))
 		}
 	}
 
@@ -7,12 +7,17 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
+	"github.com/go-acme/lego/v4/providers/dns/internal/consistency"
 	"github.com/nrdcg/desec"
 )
 
@@ -22,10 +27,14 @@ const (
 
 	EnvToken = envNamespace + "TOKEN"
 
+	EnvBaseURL     = envNamespace + "BASE_URL"
+	EnvTokenHeader = envNamespace + "TOKEN_HEADER"
+
 	EnvTTL                = envNamespace + "TTL"
 	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
 	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
 	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
+	EnvMaxRetries         = envNamespace + "MAX_RETRIES"
 )
 
 // https://github.com/desec-io/desec-stack/issues/216
@@ -36,21 +45,35 @@ var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
 // Config is used to configure the creation of the DNSProvider.
 type Config struct {
-	Token              string
+	Token string
+
+	// BaseURL the URL of the API of a self-hosted desec-stack instance (ex: https://desec.example.com/api/v1/).
+	BaseURL string
+	// TokenHeader the name of the header used to send the token (the value of the header is the raw token).
+	// If empty, the token is sent with the Authorization header ("Token <token>").
+	TokenHeader string
+
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	TTL                int
+	MaxRetries         int
 	HTTPClient         *http.Client
 }
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, defaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 120*time.Second),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 4*time.Second),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, defaultTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 120*time.Second),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, 4*time.Second),
+		MaxRetries:         lookup.GetOrDefaultInt(EnvMaxRetries, 5),
 		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
 		},
 	}
 }
@@ -59,6 +82,13 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	config *Config
 	client *desec.Client
+
+	// records contains the TXT values managed by the provider for each RRSet.
+	// They are merged with the current values of the RRSet:
+	// the wildcard and apex challenges of an RRSet are written with a single request,
+	// even if the API doesn't return a value written just before.
+	records   map[string][]string
+	recordsMu sync.Mutex
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for deSEC.
@@ -71,6 +101,8 @@ func NewDNSProvider() (*DNSProvider, error) {
 
 	config := NewDefaultConfig()
 	config.Token = values[EnvToken]
+	config.BaseURL = env.GetOrDefaultString(EnvBaseURL, "")
+	config.TokenHeader = env.GetOrDefaultString(EnvTokenHeader, "")
 
 	return NewDNSProviderConfig(config)
 }
@@ -90,13 +122,45 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		opts.HTTPClient = config.HTTPClient
 	}
 
-	opts.HTTPClient = clientdebug.Wrap(opts.HTTPClient)
+	// The throttling (HTTP 429) is handled by the retry policy of the client: it waits the delay of the Retry-After header.
+	opts.RetryMax = config.MaxRetries
+
+	httpClient := *opts.HTTPClient
+
+	token := config.Token
+
+	if config.TokenHeader != "" && !strings.EqualFold(config.TokenHeader, "Authorization") {
+		rt := httpClient.Transport
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+
+		httpClient.Transport = &tokenHeaderTransport{rt: rt, name: config.TokenHeader, token: config.Token}
+
+		// The token is sent by the transport.
+		token = ""
+	}
+
+	opts.HTTPClient = clientdebug.Wrap(&httpClient)
 
 	opts.Logger = log.Default()
 
-	client := desec.New(config.Token, opts)
+	client := desec.New(token, opts)
+
+	if config.BaseURL != "" {
+		baseURL, err := url.Parse(config.BaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("desec: invalid base URL: %w", err)
+		}
 
-	return &DNSProvider{config: config, client: client}, nil
+		client.BaseURL = baseURL.String()
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string][]string),
+	}, nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
@@ -122,38 +186,25 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 
 	domainName := dns01.UnFqdn(authZone)
 
-	quotedValue := fmt.Sprintf(`%q`, info.Value)
+	d.recordsMu.Lock()
+	defer d.recordsMu.Unlock()
 
-	rrSet, err := d.client.Records.Get(ctx, domainName, recordName, "TXT")
+	current, err := d.getRecords(ctx, domainName, recordName, false)
 	if err != nil {
-		var nf *desec.NotFoundError
-		if !errors.As(err, &nf) {
-			return fmt.Errorf("desec: failed to get records: domainName=%s, recordName=%s: %w", domainName, recordName, err)
-		}
-
-		// Not found case -> create
-		_, err = d.client.Records.Create(ctx, desec.RRSet{
-			Domain:  domainName,
-			SubName: recordName,
-			Type:    "TXT",
-			Records: []string{quotedValue},
-			TTL:     d.config.TTL,
-		})
-		if err != nil {
-			return fmt.Errorf("desec: failed to create records: domainName=%s, recordName=%s: %w", domainName, recordName, err)
-		}
-
-		return nil
+		return fmt.Errorf("desec: failed to get records: domainName=%s, recordName=%s: %w", domainName, recordName, err)
 	}
 
-	// update
-	records := append(rrSet.Records, quotedValue)
+	value := fmt.Sprintf(`%q`, info.Value)
 
-	_, err = d.client.Records.Update(ctx, domainName, recordName, "TXT", desec.RRSet{Records: records})
+	managed := appendMissing(d.records[info.EffectiveFQDN], value)
+
+	err = d.upsertRecords(ctx, domainName, recordName, appendMissing(current, managed...))
 	if err != nil {
 		return fmt.Errorf("desec: failed to update records: domainName=%s, recordName=%s: %w", domainName, recordName, err)
 	}
 
+	d.records[info.EffectiveFQDN] = managed
+
 	return nil
 }
 
@@ -174,23 +225,111 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 
 	domainName := dns01.UnFqdn(authZone)
 
-	rrSet, err := d.client.Records.Get(ctx, domainName, recordName, "TXT")
+	d.recordsMu.Lock()
+	defer d.recordsMu.Unlock()
+
+	value := fmt.Sprintf(`%q`, info.Value)
+
+	managed := slices.DeleteFunc(slices.Clone(d.records[info.EffectiveFQDN]), func(record string) bool {
+		return record == value
+	})
+
+	// The RRSet can be unknown for a short period after its creation.
+	current, err := d.getRecords(ctx, domainName, recordName, true)
 	if err != nil {
 		return fmt.Errorf("desec: failed to get records: domainName=%s, recordName=%s: %w", domainName, recordName, err)
 	}
 
-	records := make([]string, 0)
-
-	for _, record := range rrSet.Records {
-		if record != fmt.Sprintf(`%q`, info.Value) {
-			records = append(records, record)
-		}
-	}
+	records := slices.DeleteFunc(appendMissing(current, managed...), func(record string) bool {
+		return record == value
+	})
 
-	_, err = d.client.Records.Update(ctx, domainName, recordName, "TXT", desec.RRSet{Records: records})
+	err = d.upsertRecords(ctx, domainName, recordName, records)
 	if err != nil {
 		return fmt.Errorf("desec: failed to update records: domainName=%s, recordName=%s: %w", domainName, recordName, err)
 	}
 
+	if len(managed) == 0 {
+		delete(d.records, info.EffectiveFQDN)
+	} else {
+		d.records[info.EffectiveFQDN] = managed
+	}
+
 	return nil
 }
+
+// getRecords returns the current values of the TXT RRSet (empty if the RRSet doesn't exist).
+// If retryNotFound is true, the RRSet is expected to exist: the request is retried while the API doesn't know it.
+func (d *DNSProvider) getRecords(ctx context.Context, domainName, recordName string, retryNotFound bool) ([]string, error) {
+	get := func() (*desec.RRSet, error) {
+		return d.client.Records.Get(ctx, domainName, recordName, "TXT")
+	}
+
+	var (
+		rrSet *desec.RRSet
+		err   error
+	)
+
+	if retryNotFound {
+		rrSet, err = consistency.RetryOnNotFound(ctx, get, isNotFound)
+	} else {
+		rrSet, err = get()
+	}
+
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return rrSet.Records, nil
+}
+
+// upsertRecords creates, updates, or deletes (empty records) the TXT RRSet with a single request.
+func (d *DNSProvider) upsertRecords(ctx context.Context, domainName, recordName string, records []string) error {
+	rrSet := desec.RRSet{
+		SubName: recordName,
+		Type:    "TXT",
+		Records: records,
+		TTL:     d.config.TTL,
+	}
+
+	_, err := d.client.Records.BulkUpdate(ctx, desec.OnlyFields, domainName, []desec.RRSet{rrSet})
+
+	return err
+}
+
+// tokenHeaderTransport sends the token with a custom header (ex: behind a reverse proxy of a self-hosted instance).
+type tokenHeaderTransport struct {
+	rt    http.RoundTripper
+	name  string
+	token string
+}
+
+func (t *tokenHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(t.name, t.token)
+
+	return t.rt.RoundTrip(req)
+}
+
+func isNotFound(err error) bool {
+	var nf *desec.NotFoundError
+
+	return errors.As(err, &nf)
+}
+
+// appendMissing appends the values that are not already in the records.
+func appendMissing(records []string, values ...string) []string {
+	records = slices.Clone(records)
+
+	for _, value := range values {
+		if !slices.Contains(records, value) {
+			records = append(records, value)
+		}
+	}
+
+	return records
+}
@@ -1,16 +1,26 @@
 package desec
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/nrdcg/desec"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 const envDomain = envNamespace + "DOMAIN"
 
-var envTest = tester.NewEnvTest(EnvToken).WithDomain(envDomain)
+var envTest = tester.NewEnvTest(EnvToken, EnvBaseURL, EnvTokenHeader).WithDomain(envDomain)
 
 func TestNewDNSProvider(t *testing.T) {
 	testCases := []struct {
@@ -88,6 +98,212 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestNewDNSProviderConfig_selfHosted(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		tokenHeader string
+		// defaultClient keeps the HTTP client of the default configuration.
+		defaultClient bool
+		checker       *servermock.HeaderLink
+	}{
+		{
+			desc:    "default token header",
+			checker: servermock.CheckHeader().WithAuthorization("Token secret"),
+		},
+		{
+			desc:        "custom token header",
+			tokenHeader: "X-Auth-Token",
+			checker:     servermock.CheckHeader().With("X-Auth-Token", "secret"),
+		},
+		{
+			desc:          "custom token header with the default HTTP client",
+			tokenHeader:   "X-Auth-Token",
+			defaultClient: true,
+			checker:       servermock.CheckHeader().With("X-Auth-Token", "secret"),
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			provider := servermock.NewBuilder(
+				func(server *httptest.Server) (*DNSProvider, error) {
+					config := NewDefaultConfig()
+					config.Token = "secret"
+					config.BaseURL = server.URL + "/custom/api/v1/"
+					config.TokenHeader = test.tokenHeader
+
+					if !test.defaultClient {
+						config.HTTPClient = server.Client()
+					}
+
+					return NewDNSProviderConfig(config)
+				},
+				test.checker,
+			).
+				Route("GET /custom/api/v1/domains/example.com/rrsets/_acme-challenge/TXT/",
+					servermock.RawStringResponse(`{"subname":"_acme-challenge","type":"TXT","records":["\"value\""],"ttl":3600}`)).
+				Build(t)
+
+			rrSet, err := provider.client.Records.Get(t.Context(), "example.com", "_acme-challenge", "TXT")
+			require.NoError(t, err)
+
+			assert.Equal(t, []string{`"value"`}, rrSet.Records)
+		})
+	}
+}
+
+func TestDNSProvider_Present_CleanUp(t *testing.T) {
+	mockZone(t)
+
+	apex := fmt.Sprintf("%q", dns01.GetChallengeInfo("example.com", "apex").Value)
+	wildcard := fmt.Sprintf("%q", dns01.GetChallengeInfo("example.com", "wildcard").Value)
+
+	// The apex and wildcard challenges share the same RRSet.
+	// The RRSet written by the first Present is not yet returned by the API.
+	getResponses := []string{
+		`{"subname":"_acme-challenge","type":"TXT","records":["\"other\""],"ttl":3600}`,
+		`{"subname":"_acme-challenge","type":"TXT","records":["\"other\""],"ttl":3600}`,
+		`{"subname":"_acme-challenge","type":"TXT","records":["\"other\"",` + jsonString(t, apex) + `,` + jsonString(t, wildcard) + `],"ttl":3600}`,
+	}
+
+	var getCalls atomic.Int32
+
+	var written [][]string
+
+	provider := mockBuilder().
+		Route("GET /domains/example.com/rrsets/_acme-challenge/TXT/",
+			http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				_, _ = rw.Write([]byte(getResponses[getCalls.Add(1)-1]))
+			})).
+		Route("PATCH /domains/example.com/rrsets/",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				var rrSets []desec.RRSet
+
+				err := json.NewDecoder(req.Body).Decode(&rrSets)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				written = append(written, rrSets[0].Records)
+
+				_, _ = rw.Write([]byte(`[]`))
+			})).
+		Build(t)
+
+	require.NoError(t, provider.Present("example.com", "", "apex"))
+	require.NoError(t, provider.Present("example.com", "", "wildcard"))
+	require.NoError(t, provider.CleanUp("example.com", "", "apex"))
+
+	expected := [][]string{
+		{`"other"`, apex},
+		{`"other"`, apex, wildcard},
+		{`"other"`, wildcard},
+	}
+
+	assert.Equal(t, expected, written)
+	assert.Equal(t, map[string][]string{"_acme-challenge.example.com.": {wildcard}}, provider.records)
+}
+
+func TestDNSProvider_CleanUp_notFoundAfterCreate(t *testing.T) {
+	mockZone(t)
+
+	value := fmt.Sprintf("%q", dns01.GetChallengeInfo("example.com", "keyAuth").Value)
+
+	var getCalls atomic.Int32
+
+	var written [][]string
+
+	provider := mockBuilder().
+		Route("GET /domains/example.com/rrsets/_acme-challenge/TXT/",
+			http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				// The RRSet is unknown during a short period after its creation.
+				if getCalls.Add(1) == 1 {
+					http.Error(rw, `{"detail":"Not found."}`, http.StatusNotFound)
+					return
+				}
+
+				_, _ = rw.Write([]byte(`{"subname":"_acme-challenge","type":"TXT","records":["\"other\"",` + jsonString(t, value) + `],"ttl":3600}`))
+			})).
+		Route("PATCH /domains/example.com/rrsets/",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				var rrSets []desec.RRSet
+
+				err := json.NewDecoder(req.Body).Decode(&rrSets)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				written = append(written, rrSets[0].Records)
+
+				_, _ = rw.Write([]byte(`[]`))
+			})).
+		Build(t)
+
+	require.NoError(t, provider.CleanUp("example.com", "", "keyAuth"))
+
+	assert.Equal(t, int32(2), getCalls.Load())
+	assert.Equal(t, [][]string{{`"other"`}}, written)
+}
+
+func TestDNSProvider_throttling(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		maxRetries    int
+		expectedCalls int32
+		requireErr    require.ErrorAssertionFunc
+	}{
+		{
+			desc:          "retries",
+			maxRetries:    2,
+			expectedCalls: 3,
+			requireErr:    require.NoError,
+		},
+		{
+			desc:          "no retries",
+			maxRetries:    0,
+			expectedCalls: 1,
+			requireErr:    require.Error,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			var calls atomic.Int32
+
+			provider := servermock.NewBuilder(
+				func(server *httptest.Server) (*DNSProvider, error) {
+					config := NewDefaultConfig()
+					config.Token = "secret"
+					config.BaseURL = server.URL
+					config.MaxRetries = test.maxRetries
+					config.HTTPClient = server.Client()
+
+					return NewDNSProviderConfig(config)
+				},
+			).
+				Route("GET /domains/example.com/rrsets/_acme-challenge/TXT/",
+					http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+						if calls.Add(1) < 3 {
+							rw.Header().Set("Retry-After", "0")
+							http.Error(rw, `{"detail": "Request was throttled. Expected available in 0 seconds."}`, http.StatusTooManyRequests)
+
+							return
+						}
+
+						_, _ = rw.Write([]byte(`{"subname":"_acme-challenge","type":"TXT","records":[],"ttl":3600}`))
+					})).
+				Build(t)
+
+			_, err := provider.client.Records.Get(t.Context(), "example.com", "_acme-challenge", "TXT")
+			test.requireErr(t, err)
+
+			assert.Equal(t, test.expectedCalls, calls.Load())
+		})
+	}
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -117,3 +333,44 @@ func TestLiveCleanUp(t *testing.T) {
 	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
 	require.NoError(t, err)
 }
+
+func mockBuilder() *servermock.Builder[*DNSProvider] {
+	return servermock.NewBuilder(
+		func(server *httptest.Server) (*DNSProvider, error) {
+			config := NewDefaultConfig()
+			config.Token = "secret"
+			config.BaseURL = server.URL
+			config.HTTPClient = server.Client()
+
+			return NewDNSProviderConfig(config)
+		},
+		servermock.CheckHeader().WithAuthorization("Token secret"),
+	)
+}
+
+// mockZone answers the SOA requests used to find the zone (example.com) of the challenges.
+func mockZone(t *testing.T) {
+	t.Helper()
+
+	dns01.ClearFqdnCache()
+
+	addr := dnsmock.NewServer().
+		Query("_acme-challenge.example.com. SOA", dnsmock.SOA("example.com.")).
+		Build(t)
+
+	dns01.SetDefaultNameservers([]string{addr.String()})
+
+	t.Cleanup(func() {
+		dns01.SetDefaultNameservers(nil)
+		dns01.ClearFqdnCache()
+	})
+}
+
+func jsonString(t *testing.T, value string) string {
+	t.Helper()
+
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+
+	return string(data)
+}
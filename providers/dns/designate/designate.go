@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"os"
 	"slices"
 	"sync"
 	"time"
@@ -53,16 +52,26 @@ type Config struct {
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	TTL                int
-	opts               gophercloud.AuthOptions
+
+	// AuthOptions the authentication of the OpenStack client.
+	AuthOptions gophercloud.AuthOptions
+	// RegionName the region of the DNS service.
+	RegionName string
 }
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		ZoneName:           env.GetOrFile(EnvZoneName),
-		TTL:                env.GetOrDefaultInt(EnvTTL, 10),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 10*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 10*time.Second),
+		ZoneName:           lookup.GetOrFile(EnvZoneName),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, 10),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 10*time.Minute),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, 10*time.Second),
+		RegionName:         lookup.GetOrFile(EnvRegionName),
 	}
 }
 
@@ -90,14 +99,14 @@ func NewDNSProvider() (*DNSProvider, error) {
 			return nil, fmt.Errorf("designate: %w", erro)
 		}
 
-		config.opts = *opts
+		config.AuthOptions = *opts
 	} else {
 		opts, err := openstack.AuthOptionsFromEnv()
 		if err != nil {
 			return nil, fmt.Errorf("designate: %w", err)
 		}
 
-		config.opts = opts
+		config.AuthOptions = opts
 	}
 
 	return NewDNSProviderConfig(config)
@@ -109,13 +118,13 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("designate: the configuration of the DNS provider is nil")
 	}
 
-	provider, err := openstack.AuthenticatedClient(config.opts)
+	provider, err := openstack.AuthenticatedClient(config.AuthOptions)
 	if err != nil {
 		return nil, fmt.Errorf("designate: failed to authenticate: %w", err)
 	}
 
 	dnsClient, err := openstack.NewDNSV2(provider, gophercloud.EndpointOpts{
-		Region: os.Getenv("OS_REGION_NAME"),
+		Region: config.RegionName,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("designate: failed to get DNS provider: %w", err)
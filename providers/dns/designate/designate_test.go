@@ -245,10 +245,10 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
-			config.opts.TenantName = test.tenantName
-			config.opts.Password = test.password
-			config.opts.Username = test.userName
-			config.opts.IdentityEndpoint = test.authURL
+			config.AuthOptions.TenantName = test.tenantName
+			config.AuthOptions.Password = test.password
+			config.AuthOptions.Username = test.userName
+			config.AuthOptions.IdentityEndpoint = test.authURL
 
 			p, err := NewDNSProviderConfig(config)
 
@@ -30,7 +30,12 @@ const (
 	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
 )
 
-var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
+var (
+	_ challenge.ProviderTimeout = (*DNSProvider)(nil)
+	_ dns01.TXTRecordFinder     = (*DNSProvider)(nil)
+)
+
+var errRecordNotFound = errors.New("unknown record ID")
 
 // Config is used to configure the creation of the DNSProvider.
 type Config struct {
@@ -44,13 +49,18 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		BaseURL:            env.GetOrDefaultString(EnvAPIUrl, internal.DefaultBaseURL),
-		TTL:                env.GetOrDefaultInt(EnvTTL, 30),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 5*time.Second),
+		BaseURL:            lookup.GetOrDefaultString(EnvAPIUrl, internal.DefaultBaseURL),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, 30),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, 5*time.Second),
 		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
 		},
 	}
 }
@@ -142,6 +152,8 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 
 // CleanUp removes the TXT record matching the specified parameters.
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	ctx := context.Background()
+
 	info := dns01.GetChallengeInfo(domain, keyAuth)
 
 	authZone, err := dns01.FindZoneByFqdn(info.EffectiveFQDN)
@@ -155,10 +167,15 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	d.recordIDsMu.Unlock()
 
 	if !ok {
-		return fmt.Errorf("digitalocean: unknown record ID for '%s'", info.EffectiveFQDN)
+		// The record ID is unknown (e.g. the record was created by another process):
+		// fallback to a lookup of the record.
+		recordID, err = d.findTxtRecordID(ctx, authZone, info.EffectiveFQDN, info.Value)
+		if err != nil {
+			return fmt.Errorf("digitalocean: %w", err)
+		}
 	}
 
-	err = d.client.RemoveTxtRecord(context.Background(), authZone, recordID)
+	err = d.client.RemoveTxtRecord(ctx, authZone, recordID)
 	if err != nil {
 		return fmt.Errorf("digitalocean: %w", err)
 	}
@@ -170,3 +187,37 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 
 	return nil
 }
+
+// HasTXTRecord reports whether a TXT record with the value exists for the FQDN.
+func (d *DNSProvider) HasTXTRecord(fqdn, value string) (bool, error) {
+	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	if err != nil {
+		return false, fmt.Errorf("digitalocean: could not find zone for %q: %w", fqdn, err)
+	}
+
+	_, err = d.findTxtRecordID(context.Background(), authZone, fqdn, value)
+	if errors.Is(err, errRecordNotFound) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("digitalocean: %w", err)
+	}
+
+	return true, nil
+}
+
+func (d *DNSProvider) findTxtRecordID(ctx context.Context, authZone, fqdn, value string) (int, error) {
+	records, err := d.client.ListTxtRecords(ctx, authZone, fqdn)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, record := range records {
+		if record.Data == value {
+			return record.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w for '%s'", errRecordNotFound, fqdn)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -141,3 +142,48 @@ func TestDNSProvider_CleanUp(t *testing.T) {
 	err := provider.CleanUp("example.com", "token", "")
 	require.NoError(t, err)
 }
+
+func TestDNSProvider_CleanUp_unknownRecordID(t *testing.T) {
+	provider := mockProvider().
+		Route("GET /v2/domains/example.com/records",
+			servermock.RawStringResponse(`{
+			"domain_records": [
+				{"id": 1111111, "type": "TXT", "name": "_acme-challenge", "data": "other"},
+				{"id": 1234567, "type": "TXT", "name": "_acme-challenge", "data": "w6uP8Tcg6K2QR905Rms8iXTlksL6OD1KOWBxTK7wxPI"}
+			],
+			"links": {}
+		}`),
+			servermock.CheckQueryParameter().
+				With("type", "TXT").
+				With("name", "_acme-challenge.example.com")).
+		Route("DELETE /v2/domains/example.com/records/1234567",
+			servermock.Noop().
+				WithStatusCode(http.StatusNoContent)).
+		Build(t)
+
+	err := provider.CleanUp("example.com", "token", "foobar")
+	require.NoError(t, err)
+}
+
+func TestDNSProvider_HasTXTRecord(t *testing.T) {
+	provider := mockProvider().
+		Route("GET /v2/domains/example.com/records",
+			servermock.RawStringResponse(`{
+			"domain_records": [
+				{"id": 1111111, "type": "TXT", "name": "_acme-challenge", "data": "other"}
+			],
+			"links": {}
+		}`),
+			servermock.CheckQueryParameter().
+				With("type", "TXT").
+				With("name", "_acme-challenge.example.com")).
+		Build(t)
+
+	found, err := provider.HasTXTRecord("_acme-challenge.example.com.", "other")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	found, err = provider.HasTXTRecord("_acme-challenge.example.com.", "w6uP8Tcg6K2QR905Rms8iXTlksL6OD1KOWBxTK7wxPI")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
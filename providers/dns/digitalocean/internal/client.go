@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	"golang.org/x/oauth2"
 )
@@ -19,6 +20,9 @@ import (
 // DefaultBaseURL default API endpoint.
 const DefaultBaseURL = "https://api.digitalocean.com"
 
+// maxPerPage the maximum number of records by page allowed by the API.
+const maxPerPage = 200
+
 // Client the Digital Ocean API client.
 type Client struct {
 	BaseURL    *url.URL
@@ -54,6 +58,42 @@ func (c *Client) AddTxtRecord(ctx context.Context, zone string, record Record) (
 	return respData, nil
 }
 
+// ListTxtRecords returns all the TXT records with the given FQDN.
+// The records are filtered server-side and all the pages are fetched.
+func (c *Client) ListTxtRecords(ctx context.Context, zone, fqdn string) ([]Record, error) {
+	endpoint := c.BaseURL.JoinPath("v2", "domains", dns01.UnFqdn(zone), "records")
+
+	query := endpoint.Query()
+	query.Set("type", "TXT")
+	query.Set("name", dns01.UnFqdn(fqdn))
+	query.Set("per_page", strconv.Itoa(maxPerPage))
+
+	var records []Record
+
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		endpoint.RawQuery = query.Encode()
+
+		req, err := newJSONRequest(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		respData := &RecordsResponse{}
+
+		err = c.do(req, respData)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, respData.DomainRecords...)
+
+		if respData.Links == nil || respData.Links.Pages == nil || respData.Links.Pages.Next == "" {
+			return records, nil
+		}
+	}
+}
+
 func (c *Client) RemoveTxtRecord(ctx context.Context, zone string, recordID int) error {
 	endpoint := c.BaseURL.JoinPath("v2", "domains", dns01.UnFqdn(zone), "records", strconv.Itoa(recordID))
 
@@ -74,7 +114,7 @@ func (c *Client) do(req *http.Request, result any) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode >= http.StatusBadRequest {
-		return parseError(req, resp)
+		return providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	if result == nil {
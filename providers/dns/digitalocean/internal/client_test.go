@@ -52,6 +52,57 @@ func TestClient_AddTxtRecord(t *testing.T) {
 	assert.Equal(t, expected, newRecord)
 }
 
+func TestClient_ListTxtRecords(t *testing.T) {
+	client := mockBuilder().
+		Route("GET /v2/domains/example.com/records",
+			servermock.ResponseFromFixture("domains-records_GET.json"),
+			servermock.CheckQueryParameter().Strict().
+				With("type", "TXT").
+				With("name", "_acme-challenge.example.com").
+				With("per_page", "200").
+				With("page", "1")).
+		Build(t)
+
+	records, err := client.ListTxtRecords(t.Context(), "example.com", "_acme-challenge.example.com.")
+	require.NoError(t, err)
+
+	expected := []Record{{
+		ID:   1234567,
+		Type: "TXT",
+		Name: "_acme-challenge",
+		Data: "w6uP8Tcg6K2QR905Rms8iXTlksL6OD1KOWBxTK7wxPI",
+		TTL:  30,
+	}}
+
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_ListTxtRecords_pagination(t *testing.T) {
+	client := mockBuilder().
+		Route("GET /v2/domains/example.com/records",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				switch req.URL.Query().Get("page") {
+				case "1":
+					_, _ = rw.Write([]byte(`{"domain_records":[{"id":1,"type":"TXT","data":"a"}],"links":{"pages":{"next":"https://api.digitalocean.com/v2/domains/example.com/records?page=2"}}}`))
+				case "2":
+					_, _ = rw.Write([]byte(`{"domain_records":[{"id":2,"type":"TXT","data":"b"}],"links":{}}`))
+				default:
+					rw.WriteHeader(http.StatusBadRequest)
+				}
+			})).
+		Build(t)
+
+	records, err := client.ListTxtRecords(t.Context(), "example.com", "_acme-challenge.example.com.")
+	require.NoError(t, err)
+
+	expected := []Record{
+		{ID: 1, Type: "TXT", Data: "a"},
+		{ID: 2, Type: "TXT", Data: "b"},
+	}
+
+	assert.Equal(t, expected, records)
+}
+
 func TestClient_RemoveTxtRecord(t *testing.T) {
 	client := mockBuilder().
 		Route("DELETE /v2/domains/example.com/records/1234567",
@@ -7,6 +7,20 @@ type TxtRecordResponse struct {
 	DomainRecord Record `json:"domain_record"`
 }
 
+// RecordsResponse represents a page of domain records.
+type RecordsResponse struct {
+	DomainRecords []Record `json:"domain_records"`
+	Links         *Links   `json:"links,omitempty"`
+}
+
+type Links struct {
+	Pages *Pages `json:"pages,omitempty"`
+}
+
+type Pages struct {
+	Next string `json:"next,omitempty"`
+}
+
 type Record struct {
 	ID   int    `json:"id,omitempty"`
 	Type string `json:"type,omitempty"`
@@ -70,7 +70,7 @@ func TestClient_SetRecord_error(t *testing.T) {
 	}
 
 	err := client.SetRecord(t.Context(), "example.com", record)
-	require.EqualError(t, err, "[status code 500] Cannot View Dns Record: OOPS")
+	require.EqualError(t, err, "[status code 500] Cannot View Dns Record: OOPS [endpoint: POST /CMD_API_DNS_CONTROL]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -111,5 +111,5 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 	}
 
 	err := client.DeleteRecord(t.Context(), "example.com", record)
-	require.EqualError(t, err, "[status code 500] Cannot View Dns Record: OOPS")
+	require.EqualError(t, err, "[status code 500] Cannot View Dns Record: OOPS [endpoint: POST /CMD_API_DNS_CONTROL]")
 }
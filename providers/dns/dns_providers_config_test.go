@@ -0,0 +1,226 @@
+package dns
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/go-acme/lego/v4/internal/dns/descriptors"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/stretchr/testify/require"
+)
+
+// The providers that can't be created with fake values.
+var configCoverageSkipped = map[string]string{
+	"acme-dns":      "the storage depends on the values",
+	"azure":         "the SDK validates the credentials",
+	"azuredns":      "the SDK requests a token",
+	"cloudxns":      "shut down",
+	"conoha":        "requests a token",
+	"conohav3":      "requests a token",
+	"designate":     "the OpenStack variables are managed by the client",
+	"edgedns":       "the variables are managed by the client",
+	"gcloud":        "requires a service account",
+	"googledomains": "shut down",
+	"huaweicloud":   "requests the project ID",
+	"hyperone":      "requires a passport file",
+	"iwantmyname":   "shut down",
+	"manual":        "no configuration",
+	"namecheap":     "requests the client IP",
+	"nicru":         "requests a token",
+	"oraclecloud":   "requires a private key",
+	"rackspace":     "requests a token",
+	"sakuracloud":   "requests the API",
+	"yandexcloud":   "requires an IAM token",
+}
+
+// The variables that can't be set with fake values.
+var configCoverageSkippedVariables = map[string]string{
+	"REGRU_TLS_CERT": "requires a key pair",
+	"REGRU_TLS_KEY":  "requires a key pair",
+}
+
+// The variables (not credentials) used as credentials to create the provider:
+// the credentials of these providers are alternatives.
+var configCoverageAlternativeCredentials = map[string][]string{
+	"hurricane": {"HURRICANE_TOKENS"},
+}
+
+// Specific values of the variables (the other variables use generic values).
+var configCoverageValues = map[string][]string{
+	"BLUECATV2_SERVER_URL":        {"https://lego.example.com", "https://lego2.example.com"},
+	"CPANEL_MODE":                 {"cpanel", "whm"},
+	"DNSHOMEDE_CREDENTIALS":       {"example.com:lego", "example.com:lego2"},
+	"EDGEONE_ZONES_MAPPING":       {"example.com:lego", "example.com:lego2"},
+	"HURRICANE_TOKENS":            {"example.com:lego", "example.com:lego2"},
+	"MYADDR_PRIVATE_KEYS_MAPPING": {"example.com:lego", "example.com:lego2"},
+	"RFC2136_TSIG_ALGORITHM":      {"hmac-sha256.", "hmac-sha512."},
+	"OVH_ENDPOINT":                {"ovh-eu", "ovh-ca"},
+	"SCW_ACCESS_KEY":              {"SCWXXXXXXXXXXXXXXXXX", "SCWXXXXXXXXXXXXXXXXY"},
+	"SCW_PROJECT_ID":              {"00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000001"},
+	"SCW_SECRET_KEY":              {"00000000-0000-0000-0000-000000000000", "00000000-0000-0000-0000-000000000001"},
+	"SYSE_CREDENTIALS":            {"example.com:lego", "example.com:lego2"},
+}
+
+// TestConfigCoverage checks that every environment variable of the provider descriptions (TOML files) is reflected in the Config:
+// the embedders can configure the providers without the environment variables.
+func TestConfigCoverage(t *testing.T) {
+	info, err := descriptors.GetProviderInformation("../../")
+	require.NoError(t, err)
+
+	// The content of the files is also a valid list of pairs (ex: HURRICANE_TOKENS_PATH).
+	var files []string
+
+	for _, content := range []string{"example.com:lego", "example.com:lego2"} {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+		files = append(files, file)
+	}
+
+	for _, desc := range info.Providers {
+		t.Run(desc.Code, func(t *testing.T) {
+			if reason, ok := configCoverageSkipped[desc.Code]; ok {
+				t.Skip(reason)
+			}
+
+			if desc.Configuration == nil {
+				t.Skip("no environment variables")
+			}
+
+			credentials := slices.Sorted(maps.Keys(desc.Configuration.Credentials))
+			credentials = append(credentials, configCoverageAlternativeCredentials[desc.Code]...)
+
+			variables := slices.Concat(credentials, slices.Sorted(maps.Keys(desc.Configuration.Additional)))
+			variables = slices.Compact(slices.Sorted(slices.Values(variables)))
+
+			for _, name := range variables {
+				description := desc.Configuration.Credentials[name] + desc.Configuration.Additional[name]
+				if strings.HasPrefix(description, "Managed by") {
+					// Read by the SDK of the provider.
+					continue
+				}
+
+				if _, ok := configCoverageSkippedVariables[name]; ok {
+					continue
+				}
+
+				if !configCovers(desc.Code, name, credentials, files) {
+					t.Errorf("%s is not reflected in the Config", name)
+				}
+			}
+		})
+	}
+}
+
+// configCovers returns true if 2 values of the variable lead to different configurations.
+// The credentials can be alternatives (ex: a token or a key/secret pair): the combinations of the other credentials are tried.
+func configCovers(code, name string, credentials, files []string) bool {
+	others := slices.DeleteFunc(slices.Clone(credentials), func(s string) bool { return s == name })
+
+	for mask := range 1 << len(others) {
+		base := map[string]string{}
+
+		for i, other := range others {
+			if mask&(1<<i) == 0 {
+				base[other] = configCoverageValue(other, files)[0]
+			}
+		}
+
+		var configs []any
+
+		for _, value := range configCoverageValue(name, files) {
+			values := maps.Clone(base)
+			values[name] = value
+
+			config, err := readProviderConfig(code, values)
+			if err != nil {
+				continue
+			}
+
+			for _, c := range configs {
+				if !reflect.DeepEqual(c, config) {
+					return true
+				}
+			}
+
+			configs = append(configs, config)
+		}
+	}
+
+	return false
+}
+
+func configCoverageValue(name string, files []string) []string {
+	if values, ok := configCoverageValues[name]; ok {
+		return values
+	}
+
+	switch {
+	case strings.HasSuffix(name, "_TTL"):
+		return []string{"3600", "7200", "600", "86400", "120", "60"}
+
+	case strings.HasSuffix(name, "_TIMEOUT"):
+		return []string{"4242", "42"}
+
+	case strings.HasSuffix(name, "_INTERVAL"):
+		return []string{"1", "3", "7"}
+
+	case strings.HasSuffix(name, "_FILE") || strings.Contains(name, "PATH"):
+		return files
+
+	case strings.Contains(name, "URL") || strings.Contains(name, "ENDPOINT") || strings.Contains(name, "SERVER") || strings.Contains(name, "HOST"):
+		return []string{"https://lego.example.com/api/", "https://lego2.example.com/api/"}
+
+	case strings.HasSuffix(name, "_ID") || strings.HasSuffix(name, "_PORT"):
+		return []string{"1234", "4321"}
+
+	default:
+		return []string{"lego", "lego2", "4321", "true", "false"}
+	}
+}
+
+// readProviderConfig creates the provider from the variables, and returns its Config.
+func readProviderConfig(code string, values map[string]string) (any, error) {
+	var (
+		provider any
+		err      error
+	)
+
+	env.WithLookup(env.FromMap(values), func() {
+		provider, err = NewDNSChallengeProviderByName(code)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	v := reflect.ValueOf(provider)
+
+	// Some providers wrap another provider (ex: the providers based on the same API).
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		v = v.Elem()
+
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+
+		field := v.FieldByName("config")
+		if field.IsValid() {
+			return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem().Interface(), nil
+		}
+
+		if v.NumField() != 1 {
+			break
+		}
+
+		v = v.Field(0)
+	}
+
+	return nil, fmt.Errorf("no config field in %T", provider)
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var envTest = tester.NewEnvTest("EXEC_PATH")
+var envTest = tester.NewEnvTest("EXEC_PATH", "EXEC_PROPAGATION_TIMEOUT", "EXEC_POLLING_INTERVAL")
 
 func TestKnownDNSProviderSuccess(t *testing.T) {
 	defer envTest.RestoreEnv()
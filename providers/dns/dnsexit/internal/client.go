@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
@@ -96,7 +97,7 @@ func (c *Client) do(req *http.Request) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode > http.StatusBadRequest {
-		return parseError(req, resp)
+		return providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	raw, err := io.ReadAll(resp.Body)
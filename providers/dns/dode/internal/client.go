@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -78,7 +79,8 @@ func (c *Client) UpdateTxtRecord(ctx context.Context, fqdn, txt string, clearRec
 
 	body := string(raw)
 	if !response.Success {
-		return fmt.Errorf("request to change TXT record for do.de returned the following error result (%s); used url [%s]", body, endpoint)
+		return providers.NewAPIError(req, resp,
+			fmt.Errorf("request to change TXT record for do.de returned the following error result (%s); used url [%s]", body, endpoint))
 	}
 
 	return nil
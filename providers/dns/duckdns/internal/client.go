@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	"github.com/miekg/dns"
 )
@@ -79,7 +80,8 @@ func (c *Client) UpdateTxtRecord(ctx context.Context, domain, txt string, clearR
 
 	body := string(raw)
 	if body != "OK" {
-		return fmt.Errorf("request to change TXT record for DuckDNS returned the following result (%s) this does not match expectation (OK) used url [%s]", body, endpoint)
+		return providers.NewAPIError(req, resp,
+			fmt.Errorf("request to change TXT record for DuckDNS returned the following result (%s) this does not match expectation (OK) used url [%s]", body, endpoint))
 	}
 
 	return nil
@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -134,7 +135,7 @@ func (c *Client) do(req *http.Request) (*APIResponse, error) {
 	}
 
 	if resp.StatusCode >= http.StatusBadRequest {
-		return nil, fmt.Errorf("%s: %w", response.Messages, errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw))
+		return nil, fmt.Errorf("%s: %w", response.Messages, providers.NewAPIError(req, resp, errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw)))
 	}
 
 	if resp.StatusCode == http.StatusTemporaryRedirect {
@@ -143,7 +144,7 @@ func (c *Client) do(req *http.Request) (*APIResponse, error) {
 	}
 
 	if response.Status == "failure" {
-		return nil, fmt.Errorf("%s: %w", response.Messages, errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw))
+		return nil, fmt.Errorf("%s: %w", response.Messages, providers.NewAPIError(req, resp, errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw)))
 	}
 
 	return &response, nil
@@ -15,6 +15,7 @@ import (
 	"github.com/cenkalti/backoff/v5"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/wait"
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -49,10 +50,6 @@ func (c *Client) GetRecords(ctx context.Context, hostname, recordType string) ([
 		return nil, err
 	}
 
-	if apiResp.StatusCode/100 != 2 {
-		return nil, fmt.Errorf("API error: %w", apiResp.APIException)
-	}
-
 	return apiResp.DNSRecords, nil
 }
 
@@ -72,10 +69,6 @@ func (c *Client) AddNewRecord(ctx context.Context, domainID int64, record DNSRec
 		return err
 	}
 
-	if apiResp.StatusCode/100 != 2 {
-		return fmt.Errorf("API error: %w", apiResp.APIException)
-	}
-
 	return nil
 }
 
@@ -90,10 +83,6 @@ func (c *Client) DeleteRecord(ctx context.Context, domainID, recordID int64) err
 		return err
 	}
 
-	if apiResp.StatusCode/100 != 2 {
-		return fmt.Errorf("API error: %w", apiResp)
-	}
-
 	return nil
 }
 
@@ -108,10 +97,6 @@ func (c *Client) GetRootDomain(ctx context.Context, hostname string) (*DNSHostna
 		return nil, err
 	}
 
-	if apiResp.StatusCode/100 != 2 {
-		return nil, fmt.Errorf("API error: %w", apiResp.APIException)
-	}
-
 	return &apiResp, nil
 }
 
@@ -166,5 +151,12 @@ func (c *Client) do(ctx context.Context, method, uri string, body []byte, result
 		return backoff.Permanent(errutils.NewUnmarshalError(req, resp.StatusCode, raw, err))
 	}
 
+	// The API returns the status of the operation inside the response body.
+	if r, ok := result.(interface{ apiError() error }); ok {
+		if errAPI := r.apiError(); errAPI != nil {
+			return backoff.Permanent(providers.NewAPIError(req, resp, fmt.Errorf("API error: %w", errAPI)))
+		}
+	}
+
 	return nil
 }
@@ -60,7 +60,7 @@ func TestGetRootDomain(t *testing.T) {
 			status:  http.StatusNotImplemented,
 			file:    "get_root_domain_invalid.json",
 			expected: expected{
-				error: "API error: 501: Argument Exception: Invalid.",
+				error: "API error: 501: Argument Exception: Invalid. [endpoint: GET /dns/getroot/test.lego.freeddns.org]",
 			},
 		},
 	}
@@ -150,7 +150,7 @@ func TestGetRecords(t *testing.T) {
 			status:  http.StatusNotImplemented,
 			file:    "get_records_invalid.json",
 			expected: expected{
-				error: "API error: 501: Argument Exception: Invalid.",
+				error: "API error: 501: Argument Exception: Invalid. [endpoint: GET /dns/record/_acme-challenge.lego.freeddns.org]",
 			},
 		},
 	}
@@ -204,7 +204,7 @@ func TestAddNewRecord(t *testing.T) {
 			status:  http.StatusNotImplemented,
 			file:    "add_new_record_invalid.json",
 			expected: expected{
-				error: "API error: 501: Argument Exception: Invalid.",
+				error: "API error: 501: Argument Exception: Invalid. [endpoint: POST /dns/9007481/record]",
 			},
 		},
 	}
@@ -264,7 +264,7 @@ func TestDeleteRecord(t *testing.T) {
 			status:  http.StatusNotImplemented,
 			file:    "delete_record_invalid.json",
 			expected: expected{
-				error: "API error: 501: Argument Exception: Invalid.",
+				error: "API error: 501: Argument Exception: Invalid. [endpoint: DELETE /dns/9007481/record/6041418]",
 			},
 		},
 	}
@@ -13,6 +13,15 @@ func (a APIException) Error() string {
 	return fmt.Sprintf("%d: %s: %s", a.StatusCode, a.Type, a.Message)
 }
 
+// apiError returns the exception if the status code of the response body is not a success.
+func (a *APIException) apiError() error {
+	if a == nil || a.StatusCode/100 == 2 {
+		return nil
+	}
+
+	return a
+}
+
 // APIResponse defines model for apiResponse.
 type APIResponse struct {
 	Exception  *APIException `json:"exception,omitempty"`
@@ -305,7 +305,7 @@ func TestDNSProvider_Cleanup_WhenHttpError_ReturnsError(t *testing.T) {
 
 	err := provider.CleanUp("example.com", "token", "keyAuth")
 
-	expectedError := fmt.Sprintf("easydns: unexpected status code: [status code: 406] body: %v", errorMessage)
+	expectedError := fmt.Sprintf("easydns: unexpected status code: [status code: 406] body: %v [endpoint: DELETE /zones/records/example.com/123456]", errorMessage)
 	require.EqualError(t, err, expectedError)
 }
 
@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	querystring "github.com/google/go-querystring/query"
 )
@@ -144,7 +145,7 @@ func (c *Client) do(req *http.Request, result any) error {
 	switch req.Method {
 	case http.MethodPost:
 		if resp.StatusCode != http.StatusCreated {
-			return parseError(req, resp)
+			return providers.NewAPIError(req, resp, parseError(req, resp))
 		}
 	default:
 		if resp.StatusCode == http.StatusNoContent {
@@ -152,7 +153,7 @@ func (c *Client) do(req *http.Request, result any) error {
 		}
 
 		if resp.StatusCode != http.StatusOK {
-			return parseError(req, resp)
+			return providers.NewAPIError(req, resp, parseError(req, resp))
 		}
 	}
 
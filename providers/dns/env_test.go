@@ -0,0 +1,241 @@
+package dns
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestProviders_environment checks that the providers only read the environment variables
+// when the configuration is created (NewDefaultConfig, NewDNSProvider):
+// the providers created with NewDNSProviderConfig are fully defined by their Config.
+func TestProviders_environment(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("*", "*.go"))
+	require.NoError(t, err)
+
+	packages := map[string][]string{}
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") || strings.HasPrefix(file, "internal"+string(filepath.Separator)) {
+			continue
+		}
+
+		packages[filepath.Dir(file)] = append(packages[filepath.Dir(file)], file)
+	}
+
+	for dir, files := range packages {
+		pkg := parseEnvUsage(t, files)
+
+		allowed := pkg.reachable(func(name string) bool {
+			return name == "NewDefaultConfig" || strings.HasPrefix(name, "NewDNSProvider") && name != "NewDNSProviderConfig"
+		})
+
+		forbidden := pkg.reachable(func(name string) bool {
+			return name == "NewDNSProviderConfig" || name == "init" || name == varDeclaration || strings.Contains(name, ".")
+		})
+
+		for name, position := range pkg.reads {
+			if forbidden[name] {
+				t.Errorf("%s: %s: environment variable read outside of NewDefaultConfig/NewDNSProvider (reachable from NewDNSProviderConfig or the DNSProvider)", dir, position)
+				continue
+			}
+
+			if !allowed[name] {
+				t.Errorf("%s: %s: environment variable read outside of NewDefaultConfig/NewDNSProvider", dir, position)
+			}
+		}
+	}
+}
+
+// varDeclaration the pseudo function of the package-level variables.
+const varDeclaration = "<var>"
+
+type envUsage struct {
+	// reads the functions reading the environment variables, and the position of the first read.
+	reads map[string]token.Position
+
+	// calls the functions referenced by each function.
+	calls map[string]map[string]bool
+}
+
+func parseEnvUsage(t *testing.T, files []string) *envUsage {
+	t.Helper()
+
+	fset := token.NewFileSet()
+
+	var parsed []*ast.File
+
+	funcs := map[string]bool{}
+	methods := map[string][]string{}
+
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		require.NoError(t, err)
+
+		parsed = append(parsed, f)
+
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+
+			name := funcName(fn)
+
+			funcs[name] = true
+
+			if fn.Recv != nil {
+				methods[fn.Name.Name] = append(methods[fn.Name.Name], name)
+			}
+		}
+	}
+
+	usage := &envUsage{
+		reads: map[string]token.Position{},
+		calls: map[string]map[string]bool{},
+	}
+
+	for _, f := range parsed {
+		imports := map[string]bool{}
+
+		for _, spec := range f.Imports {
+			path := strings.Trim(spec.Path.Value, `"`)
+
+			switch {
+			case spec.Name != nil:
+				imports[spec.Name.Name] = true
+			default:
+				// The name of the package is approximated by the last element of the path (ignoring the major version).
+				elements := strings.Split(path, "/")
+				last := elements[len(elements)-1]
+
+				if len(elements) > 1 && len(last) > 1 && last[0] == 'v' && strings.Trim(last[1:], "0123456789") == "" {
+					last = elements[len(elements)-2]
+				}
+
+				imports[last] = true
+			}
+		}
+
+		for _, decl := range f.Decls {
+			name := varDeclaration
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				name = funcName(fn)
+			}
+
+			if usage.calls[name] == nil {
+				usage.calls[name] = map[string]bool{}
+			}
+
+			var visit func(n ast.Node) bool
+
+			visit = func(n ast.Node) bool {
+				switch node := n.(type) {
+				case *ast.Ident:
+					if funcs[node.Name] {
+						usage.calls[name][node.Name] = true
+					}
+
+				case *ast.SelectorExpr:
+					if isEnvRead(node) {
+						if _, exists := usage.reads[name]; !exists {
+							usage.reads[name] = fset.Position(node.Pos())
+						}
+					}
+
+					if pkg, ok := node.X.(*ast.Ident); ok && imports[pkg.Name] {
+						// Function of another package.
+						return false
+					}
+
+					for _, method := range methods[node.Sel.Name] {
+						usage.calls[name][method] = true
+					}
+
+					ast.Inspect(node.X, visit)
+
+					return false
+				}
+
+				return true
+			}
+
+			ast.Inspect(decl, visit)
+		}
+	}
+
+	return usage
+}
+
+// reachable returns the functions reachable from the roots.
+func (u *envUsage) reachable(root func(name string) bool) map[string]bool {
+	visited := map[string]bool{}
+
+	var queue []string
+
+	for name := range u.calls {
+		if root(name) {
+			visited[name] = true
+			queue = append(queue, name)
+		}
+	}
+
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		for callee := range u.calls[name] {
+			if !visited[callee] {
+				visited[callee] = true
+				queue = append(queue, callee)
+			}
+		}
+	}
+
+	return visited
+}
+
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+
+	if index, ok := typ.(*ast.IndexExpr); ok {
+		typ = index.X
+	}
+
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name + "." + fn.Name.Name
+	}
+
+	return "?." + fn.Name.Name
+}
+
+func isEnvRead(sel *ast.SelectorExpr) bool {
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	switch pkg.Name {
+	case "env":
+		// The parsers, the validators, and the type of the lookup don't read the environment.
+		return !strings.HasPrefix(sel.Sel.Name, "Parse") && !strings.HasPrefix(sel.Sel.Name, "Validate") && sel.Sel.Name != "LookupFunc"
+
+	case "os":
+		return sel.Sel.Name == "Getenv" || sel.Sel.Name == "LookupEnv" || sel.Sel.Name == "Environ"
+
+	default:
+		return false
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -265,10 +266,10 @@ func (c *Client) rpcCall(ctx context.Context, call *methodCall, result response)
 	}
 
 	if result.faultCode() != 0 {
-		return RPCError{
+		return providers.NewAPIError(req, resp, RPCError{
 			FaultCode:   result.faultCode(),
 			FaultString: result.faultString(),
-		}
+		})
 	}
 
 	return nil
@@ -24,6 +24,7 @@ const (
 
 	EnvAPIKey              = envNamespace + "API_KEY"
 	EnvPersonalAccessToken = envNamespace + "PERSONAL_ACCESS_TOKEN"
+	EnvSharingID           = envNamespace + "SHARING_ID"
 
 	EnvTTL                = envNamespace + "TTL"
 	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
@@ -46,6 +47,7 @@ type Config struct {
 	BaseURL             string
 	APIKey              string // Deprecated use PersonalAccessToken
 	PersonalAccessToken string
+	SharingID           string
 	PropagationTimeout  time.Duration
 	PollingInterval     time.Duration
 	TTL                 int
@@ -54,12 +56,17 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 20*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 20*time.Second),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, minTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 20*time.Minute),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, 20*time.Second),
 		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
 		},
 	}
 }
@@ -79,12 +86,14 @@ type DNSProvider struct {
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Gandi.
-// Credentials must be passed in the environment variable: GANDIV5_API_KEY.
+// Credentials must be passed in the environment variable: GANDIV5_PERSONAL_ACCESS_TOKEN or GANDIV5_API_KEY.
+// The organization can be selected with the environment variable: GANDIV5_SHARING_ID.
 func NewDNSProvider() (*DNSProvider, error) {
 	// TODO(ldez): rewrite this when APIKey will be removed.
 	config := NewDefaultConfig()
 	config.APIKey = env.GetOrFile(EnvAPIKey)
 	config.PersonalAccessToken = env.GetOrFile(EnvPersonalAccessToken)
+	config.SharingID = env.GetOrFile(EnvSharingID)
 
 	return NewDNSProviderConfig(config)
 }
@@ -103,11 +112,18 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("gandiv5: credentials information are missing")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("gandiv5: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL, 0)
+	if err != nil {
+		return nil, fmt.Errorf("gandiv5: %w", err)
+	}
+
+	err = env.ValidatePropagation(config.PropagationTimeout, config.PollingInterval)
+	if err != nil {
+		return nil, fmt.Errorf("gandiv5: %w", err)
 	}
 
 	client := internal.NewClient(config.APIKey, config.PersonalAccessToken)
+	client.SharingID = config.SharingID
 
 	if config.BaseURL != "" {
 		baseURL, err := url.Parse(config.BaseURL)
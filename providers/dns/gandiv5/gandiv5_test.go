@@ -9,7 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-var envTest = tester.NewEnvTest(EnvAPIKey, EnvPersonalAccessToken)
+var envTest = tester.NewEnvTest(EnvAPIKey, EnvPersonalAccessToken, EnvSharingID)
 
 func TestNewDNSProvider(t *testing.T) {
 	testCases := []struct {
@@ -57,22 +57,32 @@ func TestNewDNSProvider(t *testing.T) {
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc     string
+		ttl      int
 		apiKey   string
 		expected string
 	}{
 		{
 			desc:   "success",
+			ttl:    minTTL,
 			apiKey: "123",
 		},
 		{
 			desc:     "missing credentials",
+			ttl:      minTTL,
 			expected: "gandiv5: credentials information are missing",
 		},
+		{
+			desc:     "invalid TTL",
+			ttl:      299,
+			apiKey:   "123",
+			expected: "gandiv5: invalid TTL, TTL (299) must be greater than 300",
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
+			config.TTL = test.ttl
 			config.APIKey = test.apiKey
 
 			p, err := NewDNSProviderConfig(config)
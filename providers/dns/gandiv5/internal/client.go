@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -25,6 +26,10 @@ type Client struct {
 	apiKey string
 	pat    string
 
+	// SharingID is the organization ID used to scope the requests.
+	// It is required when the token has access to multiple organizations.
+	SharingID string
+
 	BaseURL    *url.URL
 	HTTPClient *http.Client
 }
@@ -129,6 +134,12 @@ func (c *Client) DeleteTXTRecord(ctx context.Context, domain, name string) error
 }
 
 func (c *Client) do(req *http.Request, result any) error {
+	if c.SharingID != "" {
+		query := req.URL.Query()
+		query.Set("sharing_id", c.SharingID)
+		req.URL.RawQuery = query.Encode()
+	}
+
 	if c.apiKey != "" {
 		req.Header.Set(authorizationHeader, "Apikey "+c.apiKey)
 	}
@@ -201,7 +212,7 @@ func checkResponse(req *http.Request, resp *http.Response) error {
 		return nil
 	}
 
-	return parseError(req, resp)
+	return providers.NewAPIError(req, resp, parseError(req, resp))
 }
 
 func parseError(req *http.Request, resp *http.Response) error {
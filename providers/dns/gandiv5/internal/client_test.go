@@ -52,3 +52,17 @@ func TestClient_DeleteTXTRecord(t *testing.T) {
 	err := client.DeleteTXTRecord(t.Context(), "example.com", "foo")
 	require.NoError(t, err)
 }
+
+func TestClient_DeleteTXTRecord_sharingID(t *testing.T) {
+	client := mockBuilder("", "secret-pat").
+		Route("DELETE /domains/example.com/records/foo/TXT",
+			servermock.ResponseFromFixture("api_response.json"),
+			servermock.CheckQueryParameter().Strict().
+				With("sharing_id", "org-123")).
+		Build(t)
+
+	client.SharingID = "org-123"
+
+	err := client.DeleteTXTRecord(t.Context(), "example.com", "foo")
+	require.NoError(t, err)
+}
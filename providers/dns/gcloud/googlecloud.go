@@ -63,14 +63,19 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		Debug:                     env.GetOrDefaultBool(EnvDebug, false),
-		ZoneID:                    env.GetOrDefaultString(EnvZoneID, ""),
-		AllowPrivateZone:          env.GetOrDefaultBool(EnvAllowPrivateZone, false),
-		ImpersonateServiceAccount: env.GetOrDefaultString(EnvImpersonateServiceAccount, ""),
-		TTL:                       env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout:        env.GetOrDefaultSecond(EnvPropagationTimeout, 180*time.Second),
-		PollingInterval:           env.GetOrDefaultSecond(EnvPollingInterval, 5*time.Second),
+		Debug:                     lookup.GetOrDefaultBool(EnvDebug, false),
+		ZoneID:                    lookup.GetOrDefaultString(EnvZoneID, ""),
+		AllowPrivateZone:          lookup.GetOrDefaultBool(EnvAllowPrivateZone, false),
+		ImpersonateServiceAccount: lookup.GetOrDefaultString(EnvImpersonateServiceAccount, ""),
+		TTL:                       lookup.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
+		PropagationTimeout:        lookup.GetOrDefaultSecond(EnvPropagationTimeout, 180*time.Second),
+		PollingInterval:           lookup.GetOrDefaultSecond(EnvPollingInterval, 5*time.Second),
 	}
 }
 
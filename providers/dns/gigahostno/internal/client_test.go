@@ -101,7 +101,7 @@ func TestClient_GetZones_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetZones(mockContext(t))
-	require.EqualError(t, err, "401: 401 Unauthorized: 401 Unauthorized")
+	require.EqualError(t, err, "401: 401 Unauthorized: 401 Unauthorized [endpoint: GET /dns/zones]")
 }
 
 func TestClient_GetZoneRecords(t *testing.T) {
@@ -5,7 +5,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"sync"
 	"time"
 
@@ -16,45 +15,10 @@ import (
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "GLESYS_"
-
-	EnvAPIUser = envNamespace + "API_USER"
-	EnvAPIKey  = envNamespace + "API_KEY"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-)
-
 const minTTL = 60
 
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	APIUser            string
-	APIKey             string
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 20*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 20*time.Second),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
@@ -64,22 +28,6 @@ type DNSProvider struct {
 	inProgressMu  sync.Mutex
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for GleSYS.
-// Credentials must be passed in the environment variables:
-// GLESYS_API_USER and GLESYS_API_KEY.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvAPIUser, EnvAPIKey)
-	if err != nil {
-		return nil, fmt.Errorf("glesys: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.APIUser = values[EnvAPIUser]
-	config.APIKey = values[EnvAPIKey]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for GleSYS.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -90,8 +38,14 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("glesys: incomplete credentials provided")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("glesys: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL, 0)
+	if err != nil {
+		return nil, fmt.Errorf("glesys: %w", err)
+	}
+
+	err = env.ValidatePropagation(config.PropagationTimeout, config.PollingInterval)
+	if err != nil {
+		return nil, fmt.Errorf("glesys: %w", err)
 	}
 
 	client := internal.NewClient(config.APIUser, config.APIKey)
@@ -78,36 +78,49 @@ func TestNewDNSProvider(t *testing.T) {
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc     string
+		ttl      int
 		apiUser  string
 		apiKey   string
 		expected string
 	}{
 		{
 			desc:    "success",
+			ttl:     minTTL,
 			apiUser: "A",
 			apiKey:  "B",
 		},
 		{
 			desc:     "missing credentials",
+			ttl:      minTTL,
 			expected: "glesys: incomplete credentials provided",
 		},
 		{
 			desc:     "missing api user",
+			ttl:      minTTL,
 			apiUser:  "",
 			apiKey:   "B",
 			expected: "glesys: incomplete credentials provided",
 		},
 		{
 			desc:     "missing api key",
+			ttl:      minTTL,
 			apiUser:  "A",
 			apiKey:   "",
 			expected: "glesys: incomplete credentials provided",
 		},
+		{
+			desc:     "invalid TTL",
+			ttl:      59,
+			apiUser:  "A",
+			apiKey:   "B",
+			expected: "glesys: invalid TTL, TTL (59) must be greater than 60",
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
+			config.TTL = test.ttl
 			config.APIKey = test.apiKey
 			config.APIUser = test.apiUser
 
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge"
@@ -21,6 +22,8 @@ const (
 
 	EnvAPIKey    = envNamespace + "API_KEY"
 	EnvAPISecret = envNamespace + "API_SECRET"
+	EnvShopperID = envNamespace + "SHOPPER_ID"
+	EnvOTE       = envNamespace + "OTE"
 
 	EnvTTL                = envNamespace + "TTL"
 	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
@@ -36,6 +39,9 @@ var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 type Config struct {
 	APIKey             string
 	APISecret          string
+	ShopperID          string
+	OTE                bool
+	BaseURL            string
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	TTL                int
@@ -44,12 +50,18 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 120*time.Second),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		OTE:                lookup.GetOrDefaultBool(EnvOTE, false),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, minTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 120*time.Second),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
 		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
 		},
 	}
 }
@@ -72,6 +84,7 @@ func NewDNSProvider() (*DNSProvider, error) {
 	config := NewDefaultConfig()
 	config.APIKey = values[EnvAPIKey]
 	config.APISecret = values[EnvAPISecret]
+	config.ShopperID = env.GetOrFile(EnvShopperID)
 
 	return NewDNSProviderConfig(config)
 }
@@ -86,11 +99,32 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("godaddy: credentials missing")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("godaddy: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL, 0)
+	if err != nil {
+		return nil, fmt.Errorf("godaddy: %w", err)
+	}
+
+	err = env.ValidatePropagation(config.PropagationTimeout, config.PollingInterval)
+	if err != nil {
+		return nil, fmt.Errorf("godaddy: %w", err)
 	}
 
 	client := internal.NewClient(config.APIKey, config.APISecret)
+	client.ShopperID = config.ShopperID
+
+	baseURL := config.BaseURL
+	if baseURL == "" && config.OTE {
+		baseURL = internal.OTEBaseURL
+	}
+
+	if baseURL != "" {
+		var err error
+
+		client.BaseURL, err = url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("godaddy: %w", err)
+		}
+	}
 
 	if config.HTTPClient != nil {
 		client.HTTPClient = config.HTTPClient
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/providers/dns/godaddy/internal"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -11,7 +13,9 @@ const envDomain = envNamespace + "DOMAIN"
 
 var envTest = tester.NewEnvTest(
 	EnvAPIKey,
-	EnvAPISecret).
+	EnvAPISecret,
+	EnvShopperID,
+	EnvOTE).
 	WithDomain(envDomain)
 
 func TestNewDNSProvider(t *testing.T) {
@@ -77,34 +81,47 @@ func TestNewDNSProvider(t *testing.T) {
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc      string
+		ttl       int
 		apiKey    string
 		apiSecret string
 		expected  string
 	}{
 		{
 			desc:      "success",
+			ttl:       minTTL,
 			apiKey:    "123",
 			apiSecret: "456",
 		},
 		{
 			desc:     "missing credentials",
+			ttl:      minTTL,
 			expected: "godaddy: credentials missing",
 		},
 		{
 			desc:      "missing api key",
+			ttl:       minTTL,
 			apiSecret: "456",
 			expected:  "godaddy: credentials missing",
 		},
 		{
 			desc:     "missing secret key",
+			ttl:      minTTL,
 			apiKey:   "123",
 			expected: "godaddy: credentials missing",
 		},
+		{
+			desc:      "invalid TTL",
+			ttl:       599,
+			apiKey:    "123",
+			apiSecret: "456",
+			expected:  "godaddy: invalid TTL, TTL (599) must be greater than 600",
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
+			config.TTL = test.ttl
 			config.APIKey = test.apiKey
 			config.APISecret = test.apiSecret
 
@@ -121,6 +138,51 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestNewDNSProviderConfig_baseURL(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		ote      bool
+		baseURL  string
+		expected string
+	}{
+		{
+			desc:     "production",
+			expected: internal.DefaultBaseURL,
+		},
+		{
+			desc:     "OTE",
+			ote:      true,
+			expected: internal.OTEBaseURL,
+		},
+		{
+			desc:     "custom base URL",
+			baseURL:  "https://example.com",
+			expected: "https://example.com",
+		},
+		{
+			desc:     "custom base URL has precedence over OTE",
+			ote:      true,
+			baseURL:  "https://example.com",
+			expected: "https://example.com",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = "123"
+			config.APISecret = "456"
+			config.OTE = test.ote
+			config.BaseURL = test.baseURL
+
+			p, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, p.client.BaseURL.String())
+		})
+	}
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
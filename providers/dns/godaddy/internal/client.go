@@ -10,19 +10,29 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
 // DefaultBaseURL represents the API endpoint to call.
 const DefaultBaseURL = "https://api.godaddy.com"
 
-const authorizationHeader = "Authorization"
+// OTEBaseURL represents the API endpoint of the OTE (Operational Test Environment).
+const OTEBaseURL = "https://api.ote-godaddy.com"
+
+const (
+	authorizationHeader = "Authorization"
+	shopperIDHeader     = "X-Shopper-Id"
+)
 
 type Client struct {
 	apiKey    string
 	apiSecret string
 
-	baseURL    *url.URL
+	// ShopperID is the customer ID used by the resellers to act on behalf of a customer (delegated access).
+	ShopperID string
+
+	BaseURL    *url.URL
 	HTTPClient *http.Client
 }
 
@@ -32,7 +42,7 @@ func NewClient(apiKey, apiSecret string) *Client {
 	return &Client{
 		apiKey:     apiKey,
 		apiSecret:  apiSecret,
-		baseURL:    baseURL,
+		BaseURL:    baseURL,
 		HTTPClient: &http.Client{Timeout: 5 * time.Second},
 	}
 }
@@ -40,7 +50,7 @@ func NewClient(apiKey, apiSecret string) *Client {
 // GetRecords retrieves DNS Records for the specified Domain.
 // https://developer.godaddy.com/doc/endpoint/domains#/v1/recordGet
 func (c *Client) GetRecords(ctx context.Context, domainZone, rType, recordName string) ([]DNSRecord, error) {
-	endpoint := c.baseURL.JoinPath("v1", "domains", domainZone, "records", rType, recordName)
+	endpoint := c.BaseURL.JoinPath("v1", "domains", domainZone, "records", rType, recordName)
 
 	req, err := newJSONRequest(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
@@ -60,7 +70,7 @@ func (c *Client) GetRecords(ctx context.Context, domainZone, rType, recordName s
 // UpdateTxtRecords replaces all DNS Records for the specified Domain with the specified Type.
 // https://developer.godaddy.com/doc/endpoint/domains#/v1/recordReplaceType
 func (c *Client) UpdateTxtRecords(ctx context.Context, records []DNSRecord, domainZone, recordName string) error {
-	endpoint := c.baseURL.JoinPath("v1", "domains", domainZone, "records", "TXT", recordName)
+	endpoint := c.BaseURL.JoinPath("v1", "domains", domainZone, "records", "TXT", recordName)
 
 	req, err := newJSONRequest(ctx, http.MethodPut, endpoint, records)
 	if err != nil {
@@ -73,7 +83,7 @@ func (c *Client) UpdateTxtRecords(ctx context.Context, records []DNSRecord, doma
 // DeleteTxtRecords deletes all DNS Records for the specified Domain with the specified Type and Name.
 // https://developer.godaddy.com/doc/endpoint/domains#/v1/recordDeleteTypeName
 func (c *Client) DeleteTxtRecords(ctx context.Context, domainZone, recordName string) error {
-	endpoint := c.baseURL.JoinPath("v1", "domains", domainZone, "records", "TXT", recordName)
+	endpoint := c.BaseURL.JoinPath("v1", "domains", domainZone, "records", "TXT", recordName)
 
 	req, err := newJSONRequest(ctx, http.MethodDelete, endpoint, nil)
 	if err != nil {
@@ -86,6 +96,10 @@ func (c *Client) DeleteTxtRecords(ctx context.Context, domainZone, recordName st
 func (c *Client) do(req *http.Request, result any) error {
 	req.Header.Set(authorizationHeader, fmt.Sprintf("sso-key %s:%s", c.apiKey, c.apiSecret))
 
+	if c.ShopperID != "" {
+		req.Header.Set(shopperIDHeader, c.ShopperID)
+	}
+
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		return errutils.NewHTTPDoError(req, err)
@@ -94,7 +108,7 @@ func (c *Client) do(req *http.Request, result any) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode/100 != 2 {
-		return parseError(req, resp)
+		return providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	if result == nil {
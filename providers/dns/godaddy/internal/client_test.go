@@ -16,7 +16,7 @@ func mockBuilder() *servermock.Builder[*Client] {
 		func(server *httptest.Server) (*Client, error) {
 			client := NewClient("key", "secret")
 			client.HTTPClient = server.Client()
-			client.baseURL, _ = url.Parse(server.URL)
+			client.BaseURL, _ = url.Parse(server.URL)
 
 			return client, nil
 		},
@@ -51,7 +51,7 @@ func TestClient_GetRecords_errors(t *testing.T) {
 		Build(t)
 
 	records, err := client.GetRecords(t.Context(), "example.com", "TXT", "")
-	require.EqualError(t, err, "[status code: 422] INVALID_BODY: Request body doesn't fulfill schema, see details in `fields`")
+	require.EqualError(t, err, "[status code: 422] INVALID_BODY: Request body doesn't fulfill schema, see details in `fields` [endpoint: GET /v1/domains/example.com/records/TXT]")
 	assert.Nil(t, records)
 }
 
@@ -91,7 +91,7 @@ func TestClient_UpdateTxtRecords_errors(t *testing.T) {
 	}
 
 	err := client.UpdateTxtRecords(t.Context(), records, "example.com", "lego")
-	require.EqualError(t, err, "[status code: 422] INVALID_BODY: Request body doesn't fulfill schema, see details in `fields`")
+	require.EqualError(t, err, "[status code: 422] INVALID_BODY: Request body doesn't fulfill schema, see details in `fields` [endpoint: PUT /v1/domains/example.com/records/TXT/lego]")
 }
 
 func TestClient_DeleteTxtRecords(t *testing.T) {
@@ -111,5 +111,19 @@ func TestClient_DeleteTxtRecords_errors(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteTxtRecords(t.Context(), "example.com", "foo")
-	require.EqualError(t, err, "[status code: 409] ACCESS_DENIED: Authenticated user is not allowed access [test: content (path=/foo) (pathRelated=/bar)]")
+	require.EqualError(t, err, "[status code: 409] ACCESS_DENIED: Authenticated user is not allowed access [test: content (path=/foo) (pathRelated=/bar)] [endpoint: DELETE /v1/domains/example.com/records/TXT/foo]")
+}
+
+func TestClient_GetRecords_shopperID(t *testing.T) {
+	client := mockBuilder().
+		Route("GET /v1/domains/example.com/records/TXT/",
+			servermock.ResponseFromFixture("getrecords.json"),
+			servermock.CheckHeader().
+				With("X-Shopper-Id", "123456")).
+		Build(t)
+
+	client.ShopperID = "123456"
+
+	_, err := client.GetRecords(t.Context(), "example.com", "TXT", "")
+	require.NoError(t, err)
 }
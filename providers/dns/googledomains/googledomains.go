@@ -8,6 +8,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
 )
 
 // Environment variables names.
@@ -35,6 +36,12 @@ func NewDefaultConfig() *Config {
 	return &Config{}
 }
 
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider.
+// The provider doesn't read environment variables.
+func NewDefaultConfigFrom(_ env.LookupFunc) *Config {
+	return NewDefaultConfig()
+}
+
 type DNSProvider struct{}
 
 // NewDNSProvider returns the Google Domains DNS provider with a default configuration.
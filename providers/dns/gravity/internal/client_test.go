@@ -65,7 +65,7 @@ func TestClient_Login_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.Login(t.Context())
-	require.EqualError(t, err, "status: UNAUTHENTICATED, error: unauthenticated, additionalProp1: string")
+	require.EqualError(t, err, "status: UNAUTHENTICATED, error: unauthenticated, additionalProp1: string [endpoint: POST /api/v1/auth/login]")
 }
 
 func TestClient_Me(t *testing.T) {
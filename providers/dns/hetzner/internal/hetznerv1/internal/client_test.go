@@ -68,7 +68,7 @@ func TestClient_AddRRSetRecords_error_invalid_input(t *testing.T) {
 	}}
 
 	_, err := client.AddRRSetRecords(t.Context(), "example.com", "TXT", "www", 0, records)
-	require.EqualError(t, err, "invalid_input: invalid input in field 'broken_field': is too longfield: broken_field: is too long")
+	require.EqualError(t, err, "invalid_input: invalid input in field 'broken_field': is too longfield: broken_field: is too long [endpoint: POST /zones/example.com/rrsets/www/TXT/actions/add_records]")
 }
 
 func TestClient_AddRRSetRecords_error_resource_limit_exceeded(t *testing.T) {
@@ -84,7 +84,7 @@ func TestClient_AddRRSetRecords_error_resource_limit_exceeded(t *testing.T) {
 	}}
 
 	_, err := client.AddRRSetRecords(t.Context(), "example.com", "TXT", "www", 0, records)
-	require.EqualError(t, err, "resource_limit_exceeded: project limit exceededlimit: project_limit")
+	require.EqualError(t, err, "resource_limit_exceeded: project limit exceededlimit: project_limit [endpoint: POST /zones/example.com/rrsets/www/TXT/actions/add_records]")
 }
 
 func TestClient_AddRRSetRecords_error_deprecated_api_endpoint(t *testing.T) {
@@ -100,7 +100,7 @@ func TestClient_AddRRSetRecords_error_deprecated_api_endpoint(t *testing.T) {
 	}}
 
 	_, err := client.AddRRSetRecords(t.Context(), "example.com", "TXT", "www", 0, records)
-	require.EqualError(t, err, "deprecated_api_endpoint: API functionality was removed: https://docs.hetzner.cloud/changelog#2023-07-20-foo-endpoint-is-deprecated")
+	require.EqualError(t, err, "deprecated_api_endpoint: API functionality was removed: https://docs.hetzner.cloud/changelog#2023-07-20-foo-endpoint-is-deprecated [endpoint: POST /zones/example.com/rrsets/www/TXT/actions/add_records]")
 }
 
 func TestClient_RemoveRRSetRecords(t *testing.T) {
@@ -69,7 +69,7 @@ func TestClient_GetDNSRecords_error(t *testing.T) {
 
 	_, err := client.GetDNSRecords(t.Context(), "example.com")
 
-	require.EqualError(t, err, "26a91bd9-f8c8-4a83-9df9-83e23d696fe3: Unauthenticated")
+	require.EqualError(t, err, "26a91bd9-f8c8-4a83-9df9-83e23d696fe3: Unauthenticated [endpoint: GET /api/dns/v1/zones/example.com]")
 }
 
 func TestClient_UpdateDNSRecords(t *testing.T) {
@@ -117,7 +117,7 @@ func TestClient_UpdateDNSRecords_error(t *testing.T) {
 
 	err := client.UpdateDNSRecords(t.Context(), "example.com", zone)
 
-	require.EqualError(t, err, "26a91bd9-f8c8-4a83-9df9-83e23d696fe3: The name field is required. (and 1 more error): field_1: The field_1 field is required., The field_1 must be a number.")
+	require.EqualError(t, err, "26a91bd9-f8c8-4a83-9df9-83e23d696fe3: The name field is required. (and 1 more error): field_1: The field_1 field is required., The field_1 must be a number. [endpoint: PUT /api/dns/v1/zones/example.com]")
 }
 
 func TestClient_DeleteDNSRecords(t *testing.T) {
@@ -150,5 +150,5 @@ func TestClient_DeleteDNSRecords_error(t *testing.T) {
 
 	err := client.DeleteDNSRecords(t.Context(), "example.com", filters)
 
-	require.EqualError(t, err, "26a91bd9-f8c8-4a83-9df9-83e23d696fe3: Unauthenticated")
+	require.EqualError(t, err, "26a91bd9-f8c8-4a83-9df9-83e23d696fe3: Unauthenticated [endpoint: DELETE /api/dns/v1/zones/example.com]")
 }
@@ -77,7 +77,7 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteRecord(context.Background(), "example.com", "12345")
-	require.EqualError(t, err, "[status code: 401] Something went wrong")
+	require.EqualError(t, err, "[status code: 401] Something went wrong [endpoint: DELETE /domains/example.com/dns]")
 }
 
 func TestClient_DeleteRecord_error_other(t *testing.T) {
@@ -88,5 +88,5 @@ func TestClient_DeleteRecord_error_other(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteRecord(context.Background(), "example.com", "12345")
-	require.EqualError(t, err, "[status code: 404] Resource not found")
+	require.EqualError(t, err, "[status code: 404] Resource not found [endpoint: DELETE /domains/example.com/dns]")
 }
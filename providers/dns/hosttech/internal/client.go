@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	"golang.org/x/oauth2"
 )
@@ -174,7 +175,7 @@ func (c *Client) do(req *http.Request, result any) error {
 		return nil
 
 	default:
-		return parseError(req, resp)
+		return providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 }
 
@@ -93,7 +93,7 @@ func TestClient_GetZone_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetZone(t.Context(), "123")
-	require.EqualError(t, err, "401: Unauthenticated.")
+	require.EqualError(t, err, "401: Unauthenticated. [endpoint: GET /user/v1/zones/123]")
 }
 
 func TestClient_GetRecords(t *testing.T) {
@@ -189,7 +189,7 @@ func TestClient_GetRecords_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetRecords(t.Context(), "123", "TXT")
-	require.EqualError(t, err, "401: Unauthenticated.")
+	require.EqualError(t, err, "401: Unauthenticated. [endpoint: GET /user/v1/zones/123/records]")
 }
 
 func TestClient_AddRecord(t *testing.T) {
@@ -238,7 +238,7 @@ func TestClient_AddRecord_error(t *testing.T) {
 	}
 
 	_, err := client.AddRecord(t.Context(), "123", record)
-	require.EqualError(t, err, "401: The given data was invalid. type: [Darf nicht leer sein.]")
+	require.EqualError(t, err, "401: The given data was invalid. type: [Darf nicht leer sein.] [endpoint: POST /user/v1/zones/123/records]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -260,5 +260,5 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteRecord(t.Context(), "123", "6")
-	require.EqualError(t, err, "401: Unauthenticated.")
+	require.EqualError(t, err, "401: Unauthenticated. [endpoint: DELETE /user/v1/zones/123/records/6]")
 }
@@ -120,7 +120,7 @@ func TestNewDNSProvider_Present(t *testing.T) {
 		{
 			desc:          "error",
 			builder:       mockBuilder(""),
-			expectedError: "httpreq: unexpected status code: [status code: 404] body: 404 page not found",
+			expectedError: "httpreq: unexpected status code: [status code: 404] body: 404 page not found [endpoint: POST /present]",
 		},
 		{
 			desc: "success raw mode",
@@ -132,13 +132,13 @@ func TestNewDNSProvider_Present(t *testing.T) {
 		{
 			desc:          "error raw mode",
 			builder:       mockBuilder("RAW"),
-			expectedError: "httpreq: unexpected status code: [status code: 404] body: 404 page not found",
+			expectedError: "httpreq: unexpected status code: [status code: 404] body: 404 page not found [endpoint: POST /present]",
 		},
 		{
 			desc: "basic auth fail",
 			builder: mockBuilderWithBasicAuth("nope", "nope").
 				Route("/present", servermock.Noop()),
-			expectedError: `httpreq: unexpected status code: [status code: 400] body: invalid credentials: got [username: "nope", password: "nope"], want [username: "user", password: "secret"]`,
+			expectedError: `httpreq: unexpected status code: [status code: 400] body: invalid credentials: got [username: "nope", password: "nope"], want [username: "user", password: "secret"] [endpoint: POST /present]`,
 		},
 		{
 			desc: "basic auth success",
@@ -181,7 +181,7 @@ func TestNewDNSProvider_Cleanup(t *testing.T) {
 		{
 			desc:          "error",
 			builder:       mockBuilder(""),
-			expectedError: "httpreq: unexpected status code: [status code: 404] body: 404 page not found",
+			expectedError: "httpreq: unexpected status code: [status code: 404] body: 404 page not found [endpoint: POST /cleanup]",
 		},
 		{
 			desc: "success raw mode",
@@ -193,13 +193,13 @@ func TestNewDNSProvider_Cleanup(t *testing.T) {
 		{
 			desc:          "error raw mode",
 			builder:       mockBuilder("RAW"),
-			expectedError: "httpreq: unexpected status code: [status code: 404] body: 404 page not found",
+			expectedError: "httpreq: unexpected status code: [status code: 404] body: 404 page not found [endpoint: POST /cleanup]",
 		},
 		{
 			desc: "basic auth fail",
 			builder: mockBuilderWithBasicAuth("test", "example").
 				Route("/cleanup", servermock.Noop()),
-			expectedError: `httpreq: unexpected status code: [status code: 400] body: invalid credentials: got [username: "test", password: "example"], want [username: "user", password: "secret"]`,
+			expectedError: `httpreq: unexpected status code: [status code: 400] body: invalid credentials: got [username: "test", password: "example"], want [username: "user", password: "secret"] [endpoint: POST /cleanup]`,
 		},
 		{
 			desc: "basic auth success",
@@ -16,9 +16,11 @@ import (
 	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/platform/wait"
 	"github.com/go-acme/lego/v4/providers/dns/huaweicloud/internal"
+	"github.com/go-acme/lego/v4/providers/dns/internal/cloudendpoint"
 	"github.com/go-acme/lego/v4/providers/dns/internal/ptr"
 	hwauthbasic "github.com/huaweicloud/huaweicloud-sdk-go-v3/core/auth/basic"
 	hwconfig "github.com/huaweicloud/huaweicloud-sdk-go-v3/core/config"
+	hwcoreregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/core/region"
 	hwdns "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/dns/v2"
 	hwmodel "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/dns/v2/model"
 	hwregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/dns/v2/region"
@@ -31,6 +33,7 @@ const (
 	EnvAccessKeyID     = envNamespace + "ACCESS_KEY_ID"
 	EnvSecretAccessKey = envNamespace + "SECRET_ACCESS_KEY"
 	EnvRegion          = envNamespace + "REGION"
+	EnvEndpoint        = envNamespace + "ENDPOINT"
 
 	EnvTTL                = envNamespace + "TTL"
 	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
@@ -38,6 +41,12 @@ const (
 	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
 )
 
+// The regions of Huawei Cloud Europe (sovereign cloud) are not known by the SDK.
+var partitions = cloudendpoint.Partitions{
+	{Name: "public", Domain: "myhuaweicloud.com"},
+	{Name: "eu", Domain: "myhuaweicloud.eu", Regions: []string{"eu-west-101"}},
+}
+
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
 // Config is used to configure the creation of the DNSProvider.
@@ -45,6 +54,7 @@ type Config struct {
 	AccessKeyID     string
 	SecretAccessKey string
 	Region          string
+	Endpoint        string
 
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
@@ -54,11 +64,16 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		TTL:                int32(env.GetOrDefaultInt(EnvTTL, 300)),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPTimeout:        env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		TTL:                int32(lookup.GetOrDefaultInt(EnvTTL, 300)),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		HTTPTimeout:        lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
 	}
 }
 
@@ -84,6 +99,7 @@ func NewDNSProvider() (*DNSProvider, error) {
 	config.AccessKeyID = values[EnvAccessKeyID]
 	config.SecretAccessKey = values[EnvSecretAccessKey]
 	config.Region = values[EnvRegion]
+	config.Endpoint = env.GetOrFile(EnvEndpoint)
 
 	return NewDNSProviderConfig(config)
 }
@@ -106,9 +122,9 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("huaweicloud: crendential build: %w", err)
 	}
 
-	region, err := hwregion.SafeValueOf(config.Region)
+	region, err := getRegion(config)
 	if err != nil {
-		return nil, fmt.Errorf("huaweicloud: safe region: %w", err)
+		return nil, fmt.Errorf("huaweicloud: %w", err)
 	}
 
 	client, err := hwdns.DnsClientBuilder().
@@ -295,3 +311,33 @@ func (d *DNSProvider) getZoneID(authZone string) (string, error) {
 
 	return "", fmt.Errorf("zone %q not found", authZone)
 }
+
+// getRegion returns the region with its endpoint:
+// the endpoint override, the endpoint known by the SDK, or the endpoint of a region of a sovereign cloud.
+func getRegion(config *Config) (*hwcoreregion.Region, error) {
+	if config.Endpoint != "" {
+		endpoint, err := cloudendpoint.URL(config.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		return hwcoreregion.NewRegion(config.Region, endpoint.String()), nil
+	}
+
+	region, err := hwregion.SafeValueOf(config.Region)
+	if err == nil {
+		return region, nil
+	}
+
+	partition, errP := partitions.Lookup(config.Region)
+	if errP != nil || len(partition.Regions) == 0 {
+		return nil, fmt.Errorf("safe region: %w", err)
+	}
+
+	endpoint, err := partitions.Endpoint("https://dns.{region}.{domain}", config.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	return hwcoreregion.NewRegion(config.Region, endpoint), nil
+}
@@ -6,12 +6,13 @@ import (
 
 	"github.com/go-acme/lego/v4/platform/tester"
 	hwregion "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/dns/v2/region"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 const envDomain = envNamespace + "DOMAIN"
 
-var envTest = tester.NewEnvTest(EnvAccessKeyID, EnvSecretAccessKey, EnvRegion).
+var envTest = tester.NewEnvTest(EnvAccessKeyID, EnvSecretAccessKey, EnvRegion, EnvEndpoint).
 	WithDomain(envDomain)
 
 func TestNewDNSProvider(t *testing.T) {
@@ -135,6 +136,49 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func Test_getRegion(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		region   string
+		endpoint string
+		expected string
+	}{
+		{
+			desc:     "region known by the SDK",
+			region:   hwregion.CN_EAST_2.Id,
+			expected: "https://dns.cn-east-2.myhuaweicloud.com",
+		},
+		{
+			desc:     "region of Huawei Cloud Europe",
+			region:   "eu-west-101",
+			expected: "https://dns.eu-west-101.myhuaweicloud.eu",
+		},
+		{
+			desc:     "endpoint override",
+			region:   "cn-east-2",
+			endpoint: "dns.example.com",
+			expected: "https://dns.example.com",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			region, err := getRegion(&Config{Region: test.region, Endpoint: test.endpoint})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.region, region.Id)
+			assert.Equal(t, []string{test.expected}, region.Endpoints)
+		})
+	}
+}
+
+func Test_getRegion_unknown(t *testing.T) {
+	_, err := getRegion(&Config{Region: "xx-unknown-1"})
+	require.ErrorContains(t, err, "safe region: region id 'xx-unknown-1' is not in the following supported regions")
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
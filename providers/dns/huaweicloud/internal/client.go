@@ -18,7 +18,12 @@ limitations under the License.
 package internal
 
 import (
+	"errors"
+
+	"github.com/go-acme/lego/v4/providers"
 	httpclient "github.com/huaweicloud/huaweicloud-sdk-go-v3/core"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/def"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
 	hwdns "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/dns/v2"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/dns/v2/model"
 )
@@ -35,7 +40,7 @@ func (c *DnsClient) ShowRecordSet(request *model.ShowRecordSetRequest) (*model.S
 	requestDef := hwdns.GenReqDefForShowRecordSet()
 
 	if resp, err := c.HcClient.Sync(request, requestDef); err != nil {
-		return nil, err
+		return nil, wrapError(requestDef, err)
 	} else {
 		return resp.(*model.ShowRecordSetResponse), nil
 	}
@@ -45,7 +50,7 @@ func (c *DnsClient) CreateRecordSet(request *model.CreateRecordSetRequest) (*mod
 	requestDef := hwdns.GenReqDefForCreateRecordSet()
 
 	if resp, err := c.HcClient.Sync(request, requestDef); err != nil {
-		return nil, err
+		return nil, wrapError(requestDef, err)
 	} else {
 		return resp.(*model.CreateRecordSetResponse), nil
 	}
@@ -55,7 +60,7 @@ func (c *DnsClient) UpdateRecordSet(request *model.UpdateRecordSetRequest) (*mod
 	requestDef := hwdns.GenReqDefForUpdateRecordSet()
 
 	if resp, err := c.HcClient.Sync(request, requestDef); err != nil {
-		return nil, err
+		return nil, wrapError(requestDef, err)
 	} else {
 		return resp.(*model.UpdateRecordSetResponse), nil
 	}
@@ -65,7 +70,7 @@ func (c *DnsClient) DeleteRecordSet(request *model.DeleteRecordSetRequest) (*mod
 	requestDef := hwdns.GenReqDefForDeleteRecordSet()
 
 	if resp, err := c.HcClient.Sync(request, requestDef); err != nil {
-		return nil, err
+		return nil, wrapError(requestDef, err)
 	} else {
 		return resp.(*model.DeleteRecordSetResponse), nil
 	}
@@ -75,7 +80,7 @@ func (c *DnsClient) ListRecordSetsByZone(request *model.ListRecordSetsByZoneRequ
 	requestDef := hwdns.GenReqDefForListRecordSetsByZone()
 
 	if resp, err := c.HcClient.Sync(request, requestDef); err != nil {
-		return nil, err
+		return nil, wrapError(requestDef, err)
 	} else {
 		return resp.(*model.ListRecordSetsByZoneResponse), nil
 	}
@@ -85,8 +90,24 @@ func (c *DnsClient) ListPublicZones(request *model.ListPublicZonesRequest) (*mod
 	requestDef := hwdns.GenReqDefForListPublicZones()
 
 	if resp, err := c.HcClient.Sync(request, requestDef); err != nil {
-		return nil, err
+		return nil, wrapError(requestDef, err)
 	} else {
 		return resp.(*model.ListPublicZonesResponse), nil
 	}
 }
+
+// wrapError wraps the errors of the SDK inside a providers.APIError.
+func wrapError(requestDef *def.HttpRequestDef, err error) error {
+	apiErr := &providers.APIError{
+		Endpoint: requestDef.Method + " " + requestDef.Path,
+		Err:      err,
+	}
+
+	var respErr *sdkerr.ServiceResponseError
+	if errors.As(err, &respErr) {
+		apiErr.StatusCode = respErr.StatusCode
+		apiErr.RequestID = respErr.RequestId
+	}
+
+	return apiErr
+}
@@ -1,10 +1,14 @@
 package hurricane
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge"
@@ -18,7 +22,12 @@ import (
 const (
 	envNamespace = "HURRICANE_"
 
-	EnvTokens = envNamespace + "TOKENS"
+	EnvTokens     = envNamespace + "TOKENS"
+	EnvTokensPath = envNamespace + "TOKENS_PATH"
+	EnvUsername   = envNamespace + "USERNAME"
+	EnvPassword   = envNamespace + "PASSWORD"
+
+	EnvTTL = envNamespace + "TTL"
 
 	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
 	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
@@ -30,7 +39,15 @@ var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
 // Config is used to configure the creation of the DNSProvider.
 type Config struct {
-	Credentials        map[string]string
+	// Credentials the dynamic DNS keys by record name (without the `_acme-challenge.` component).
+	Credentials map[string]string
+
+	// Username and Password of the web interface (dns.he.net),
+	// used to manage the records without dynamic DNS key.
+	Username string
+	Password string
+
+	TTL                int
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	SequenceInterval   time.Duration
@@ -39,12 +56,18 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 300*time.Second),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		SequenceInterval:   env.GetOrDefaultSecond(EnvSequenceInterval, dns01.DefaultPropagationTimeout),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, 300),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 300*time.Second),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		SequenceInterval:   lookup.GetOrDefaultSecond(EnvSequenceInterval, dns01.DefaultPropagationTimeout),
 		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
 		},
 	}
 }
@@ -53,20 +76,44 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	config *Config
 	client *internal.Client
+
+	// session is nil if the credentials of the web interface are not provided.
+	session *internal.SessionClient
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Hurricane Electric.
 func NewDNSProvider() (*DNSProvider, error) {
 	config := NewDefaultConfig()
 
-	values, err := env.Get(EnvTokens)
-	if err != nil {
-		return nil, fmt.Errorf("hurricane: %w", err)
+	config.Username = env.GetOrFile(EnvUsername)
+	config.Password = env.GetOrFile(EnvPassword)
+
+	credentials := make(map[string]string)
+
+	if filename := env.GetOrDefaultString(EnvTokensPath, ""); filename != "" {
+		fromFile, err := readTokensFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("hurricane: credentials: %w", err)
+		}
+
+		maps.Copy(credentials, fromFile)
 	}
 
-	credentials, err := env.ParsePairs(values[EnvTokens])
-	if err != nil {
-		return nil, fmt.Errorf("hurricane: credentials: %w", err)
+	// The tokens are optional when they are read from the file or when the web session can be used.
+	optional := len(credentials) > 0 || config.Username != "" && config.Password != ""
+
+	if !optional || env.GetOrFile(EnvTokens) != "" {
+		values, err := env.Get(EnvTokens)
+		if err != nil {
+			return nil, fmt.Errorf("hurricane: %w", err)
+		}
+
+		fromEnv, err := env.ParsePairs(values[EnvTokens])
+		if err != nil {
+			return nil, fmt.Errorf("hurricane: credentials: %w", err)
+		}
+
+		maps.Copy(credentials, fromEnv)
 	}
 
 	config.Credentials = credentials
@@ -74,12 +121,15 @@ func NewDNSProvider() (*DNSProvider, error) {
 	return NewDNSProviderConfig(config)
 }
 
+// NewDNSProviderConfig return a DNSProvider instance configured for Hurricane Electric.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
 		return nil, errors.New("hurricane: the configuration of the DNS provider is nil")
 	}
 
-	if len(config.Credentials) == 0 {
+	withSession := config.Username != "" && config.Password != ""
+
+	if len(config.Credentials) == 0 && !withSession {
 		return nil, errors.New("hurricane: credentials missing")
 	}
 
@@ -91,14 +141,43 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 
 	client.HTTPClient = clientdebug.Wrap(client.HTTPClient)
 
-	return &DNSProvider{config: config, client: client}, nil
+	provider := &DNSProvider{config: config, client: client}
+
+	if withSession {
+		session, err := internal.NewSessionClient(config.Username, config.Password)
+		if err != nil {
+			return nil, fmt.Errorf("hurricane: %w", err)
+		}
+
+		if config.HTTPClient != nil {
+			// The session client uses its own cookie jar.
+			httpClient := *config.HTTPClient
+			session.HTTPClient = &httpClient
+		}
+
+		session.HTTPClient = clientdebug.Wrap(session.HTTPClient)
+
+		provider.session = session
+	}
+
+	return provider, nil
 }
 
 // Present updates a TXT record to fulfill the dns-01 challenge.
+// Without dynamic DNS key for the record name, the record is created with the web interface.
 func (d *DNSProvider) Present(domain, _, keyAuth string) error {
 	info := dns01.GetChallengeInfo(domain, keyAuth)
 
-	err := d.client.UpdateTxtRecord(context.Background(), dns01.UnFqdn(info.EffectiveFQDN), info.Value)
+	hostname := dns01.UnFqdn(info.EffectiveFQDN)
+
+	var err error
+
+	if d.session != nil && !d.client.HasCredential(hostname) {
+		err = d.session.AddTXTRecord(context.Background(), hostname, info.Value, d.config.TTL)
+	} else {
+		err = d.client.UpdateTxtRecord(context.Background(), hostname, info.Value)
+	}
+
 	if err != nil {
 		return fmt.Errorf("hurricane: %w", err)
 	}
@@ -107,10 +186,20 @@ func (d *DNSProvider) Present(domain, _, keyAuth string) error {
 }
 
 // CleanUp updates the TXT record matching the specified parameters.
+// Without dynamic DNS key for the record name, the record is deleted with the web interface.
 func (d *DNSProvider) CleanUp(domain, _, keyAuth string) error {
 	info := dns01.GetChallengeInfo(domain, keyAuth)
 
-	err := d.client.UpdateTxtRecord(context.Background(), dns01.UnFqdn(info.EffectiveFQDN), ".")
+	hostname := dns01.UnFqdn(info.EffectiveFQDN)
+
+	var err error
+
+	if d.session != nil && !d.client.HasCredential(hostname) {
+		err = d.session.DeleteTXTRecord(context.Background(), hostname, info.Value)
+	} else {
+		err = d.client.UpdateTxtRecord(context.Background(), hostname, ".")
+	}
+
 	if err != nil {
 		return fmt.Errorf("hurricane: %w", err)
 	}
@@ -124,6 +213,46 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
+// readTokensFile reads the dynamic DNS keys from a file: one `record-name:key` pair by line.
+// The empty lines and the lines starting with `#` are ignored.
+func readTokensFile(filename string) (map[string]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = file.Close() }()
+
+	credentials := make(map[string]string)
+
+	scanner := bufio.NewScanner(file)
+
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, token, ok := strings.Cut(line, ":")
+
+		name = strings.TrimSpace(name)
+		token = strings.TrimSpace(token)
+
+		if !ok || name == "" || token == "" {
+			return nil, fmt.Errorf("%s:%d: incorrect pair", filename, lineNumber)
+		}
+
+		credentials[name] = token
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials, nil
+}
+
 // Sequential All DNS challenges for this provider will be resolved sequentially.
 // Returns the interval between each iteration.
 func (d *DNSProvider) Sequential() time.Duration {
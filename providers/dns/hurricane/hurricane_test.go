@@ -1,6 +1,8 @@
 package hurricane
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-acme/lego/v4/platform/tester"
@@ -9,7 +11,12 @@ import (
 
 const envDomain = envNamespace + "DOMAIN"
 
-var envTest = tester.NewEnvTest(EnvTokens).WithDomain(envDomain)
+var envTest = tester.NewEnvTest(
+	EnvTokens,
+	EnvTokensPath,
+	EnvUsername,
+	EnvPassword).
+	WithDomain(envDomain)
 
 func TestNewDNSProvider(t *testing.T) {
 	testCases := []struct {
@@ -50,6 +57,40 @@ func TestNewDNSProvider(t *testing.T) {
 			},
 			expected: "hurricane: some credentials information are missing: HURRICANE_TOKENS",
 		},
+		{
+			desc: "success with tokens file",
+			envVars: map[string]string{
+				EnvTokensPath: "# comment\n\nexample.org:123\nexample.com: 456\n",
+			},
+		},
+		{
+			desc: "success with tokens file and tokens",
+			envVars: map[string]string{
+				EnvTokensPath: "example.org:123\n",
+				EnvTokens:     "example.com:456",
+			},
+		},
+		{
+			desc: "invalid tokens file",
+			envVars: map[string]string{
+				EnvTokensPath: "example.org:123\nexample.com\n",
+			},
+			expected: "hurricane: credentials: tokens.txt:2: incorrect pair",
+		},
+		{
+			desc: "success with username and password",
+			envVars: map[string]string{
+				EnvUsername: "user",
+				EnvPassword: "secret",
+			},
+		},
+		{
+			desc: "missing password",
+			envVars: map[string]string{
+				EnvUsername: "user",
+			},
+			expected: "hurricane: some credentials information are missing: HURRICANE_TOKENS",
+		},
 	}
 
 	for _, test := range testCases {
@@ -58,6 +99,15 @@ func TestNewDNSProvider(t *testing.T) {
 
 			envTest.ClearEnv()
 
+			if content, ok := test.envVars[EnvTokensPath]; ok {
+				t.Chdir(t.TempDir())
+
+				err := os.WriteFile(filepath.Join(".", "tokens.txt"), []byte(content), 0o600)
+				require.NoError(t, err)
+
+				test.envVars[EnvTokensPath] = "tokens.txt"
+			}
+
 			envTest.Apply(test.envVars)
 
 			p, err := NewDNSProvider()
@@ -77,6 +127,8 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc     string
 		creds    map[string]string
+		username string
+		password string
 		expected string
 	}{
 		{
@@ -91,16 +143,28 @@ func TestNewDNSProviderConfig(t *testing.T) {
 				"example.net": "789",
 			},
 		},
+		{
+			desc:     "success with username and password",
+			username: "user",
+			password: "secret",
+		},
 		{
 			desc:     "missing credentials",
 			expected: "hurricane: credentials missing",
 		},
+		{
+			desc:     "missing password",
+			username: "user",
+			expected: "hurricane: credentials missing",
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
 			config.Credentials = test.creds
+			config.Username = test.username
+			config.Password = test.password
 
 			p, err := NewDNSProviderConfig(config)
 
@@ -51,6 +51,15 @@ func NewClient(credentials map[string]string) *Client {
 	}
 }
 
+// HasCredential returns true if a dynamic DNS key is configured for the record name.
+func (c *Client) HasCredential(hostname string) bool {
+	c.credMu.Lock()
+	_, ok := c.credentials[strings.TrimPrefix(hostname, "_acme-challenge.")]
+	c.credMu.Unlock()
+
+	return ok
+}
+
 // UpdateTxtRecord updates a TXT record.
 func (c *Client) UpdateTxtRecord(ctx context.Context, hostname, txt string) error {
 	domain := strings.TrimPrefix(hostname, "_acme-challenge.")
@@ -0,0 +1,317 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
+	"golang.org/x/net/publicsuffix"
+)
+
+const defaultSessionBaseURL = "https://dns.he.net/"
+
+var (
+	// <img alt="delete" title="delete" src="/include/images/delete.png" onclick="delete_dom(this);" name="example.com" value="123456">
+	zoneExp = regexp.MustCompile(`<img[^>]+name="([^"]+)"[^>]+value="(\d+)"`)
+
+	// <tr class="dns_tr" id="7890" title="..." onclick="editRow(this)"> ... </tr>
+	recordExp = regexp.MustCompile(`(?s)<tr class="dns_tr[^"]*" id="(\d+)"[^>]*>(.*?)</tr>`)
+
+	// <td width="95%" class="dns_view">_acme-challenge.example.com</td>
+	recordNameExp = regexp.MustCompile(`class="dns_view">([^<]+)</td>`)
+
+	// <td align="center" ><span class="rrlabel TXT" data="TXT" alt="TXT" >TXT</span></td>
+	recordTypeExp = regexp.MustCompile(`class="rrlabel ([A-Z0-9]+)"`)
+
+	// <td align="left" data="&quot;value&quot;">&quot;value&quot;</td>
+	recordDataExp = regexp.MustCompile(`data="([^"]*)"`)
+
+	// <div id="dns_err" onclick="hideThis(this);">This record already exists.</div>
+	errorExp = regexp.MustCompile(`(?s)<div id="dns_err"[^>]*>(.*?)</div>`)
+)
+
+// SessionClient the Hurricane Electric client based on the web interface (dns.he.net).
+// It's used when no dynamic DNS key is configured for a record name.
+type SessionClient struct {
+	HTTPClient *http.Client
+
+	baseURL  string
+	username string
+	password string
+
+	mu       sync.Mutex
+	loggedIn bool
+}
+
+// NewSessionClient creates a new SessionClient.
+func NewSessionClient(username, password string) (*SessionClient, error) {
+	if username == "" || password == "" {
+		return nil, errors.New("credentials missing")
+	}
+
+	return &SessionClient{
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    defaultSessionBaseURL,
+		username:   username,
+		password:   password,
+	}, nil
+}
+
+// AddTXTRecord creates a TXT record.
+func (c *SessionClient) AddTXTRecord(ctx context.Context, hostname, txt string, ttl int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	zoneID, err := c.findZoneID(ctx, hostname)
+	if err != nil {
+		return err
+	}
+
+	data := url.Values{}
+	data.Set("account", "")
+	data.Set("menu", "edit_zone")
+	data.Set("Type", "TXT")
+	data.Set("hosted_dns_zoneid", zoneID)
+	data.Set("hosted_dns_recordid", "")
+	data.Set("hosted_dns_editzone", "1")
+	data.Set("Priority", "")
+	data.Set("Name", hostname)
+	data.Set("Content", txt)
+	data.Set("TTL", strconv.Itoa(ttl))
+	data.Set("hosted_dns_editrecord", "Submit")
+
+	page, err := c.post(ctx, "index.cgi", data)
+	if err != nil {
+		return err
+	}
+
+	return checkPageError(page)
+}
+
+// DeleteTXTRecord deletes the TXT record matching the hostname and the value.
+func (c *SessionClient) DeleteTXTRecord(ctx context.Context, hostname, txt string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	zoneID, err := c.findZoneID(ctx, hostname)
+	if err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("hosted_dns_zoneid", zoneID)
+	query.Set("menu", "edit_zone")
+	query.Set("hosted_dns_editzone", "")
+
+	page, err := c.get(ctx, "index.cgi", query)
+	if err != nil {
+		return err
+	}
+
+	recordID := findTXTRecordID(page, hostname, txt)
+	if recordID == "" {
+		return fmt.Errorf("TXT record %s not found in the zone %s", hostname, zoneID)
+	}
+
+	data := url.Values{}
+	data.Set("menu", "edit_zone")
+	data.Set("hosted_dns_zoneid", zoneID)
+	data.Set("hosted_dns_recordid", recordID)
+	data.Set("hosted_dns_editzone", "1")
+	data.Set("hosted_dns_delrecord", "1")
+	data.Set("hosted_dns_delconfirm", "delete")
+
+	page, err = c.post(ctx, "index.cgi", data)
+	if err != nil {
+		return err
+	}
+
+	return checkPageError(page)
+}
+
+// findZoneID finds the ID of the zone that contains the hostname (the longest matching zone).
+// The caller must hold the lock.
+func (c *SessionClient) findZoneID(ctx context.Context, hostname string) (string, error) {
+	page, err := c.login(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var zoneName, zoneID string
+
+	for _, match := range zoneExp.FindAllStringSubmatch(page, -1) {
+		name := strings.ToLower(match[1])
+
+		if hostname != name && !strings.HasSuffix(hostname, "."+name) {
+			continue
+		}
+
+		if len(name) > len(zoneName) {
+			zoneName, zoneID = name, match[2]
+		}
+	}
+
+	if zoneID == "" {
+		return "", fmt.Errorf("zone not found for %s", hostname)
+	}
+
+	return zoneID, nil
+}
+
+// login logs in (once, or again when the session has expired) and returns the page with the list of the zones.
+// The caller must hold the lock.
+func (c *SessionClient) login(ctx context.Context) (string, error) {
+	if c.loggedIn {
+		page, err := c.get(ctx, "", nil)
+		if err != nil {
+			return "", err
+		}
+
+		if isLoggedIn(page) {
+			return page, nil
+		}
+
+		// The session has expired.
+		c.loggedIn = false
+	}
+
+	if c.HTTPClient.Jar == nil {
+		jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+		if err != nil {
+			return "", err
+		}
+
+		c.HTTPClient.Jar = jar
+	}
+
+	// Gets the session cookie.
+	_, err := c.get(ctx, "", nil)
+	if err != nil {
+		return "", err
+	}
+
+	data := url.Values{}
+	data.Set("email", c.username)
+	data.Set("pass", c.password)
+	data.Set("submit", "Login!")
+
+	page, err := c.post(ctx, "", data)
+	if err != nil {
+		return "", err
+	}
+
+	if !isLoggedIn(page) {
+		return "", errors.New("login failed: invalid credentials or two-factor authentication enabled")
+	}
+
+	c.loggedIn = true
+
+	return page, nil
+}
+
+func (c *SessionClient) get(ctx context.Context, path string, query url.Values) (string, error) {
+	endpoint, err := url.JoinPath(c.baseURL, path)
+	if err != nil {
+		return "", err
+	}
+
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("unable to create request: %w", err)
+	}
+
+	return c.do(req)
+}
+
+func (c *SessionClient) post(ctx context.Context, path string, data url.Values) (string, error) {
+	endpoint, err := url.JoinPath(c.baseURL, path)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("unable to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return c.do(req)
+}
+
+func (c *SessionClient) do(req *http.Request) (string, error) {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", errutils.NewHTTPDoError(req, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", errutils.NewUnexpectedResponseStatusCodeError(req, resp)
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errutils.NewReadResponseError(req, resp.StatusCode, err)
+	}
+
+	return string(raw), nil
+}
+
+// isLoggedIn checks if the page is displayed to a logged-in user.
+func isLoggedIn(page string) bool {
+	return strings.Contains(page, "?action=logout")
+}
+
+// findTXTRecordID finds the ID of the TXT record matching the hostname and the value inside the page of a zone.
+func findTXTRecordID(page, hostname, txt string) string {
+	for _, row := range recordExp.FindAllStringSubmatch(page, -1) {
+		name := recordNameExp.FindStringSubmatch(row[2])
+		if name == nil || !strings.EqualFold(strings.TrimSpace(html.UnescapeString(name[1])), hostname) {
+			continue
+		}
+
+		rrType := recordTypeExp.FindStringSubmatch(row[2])
+		if rrType == nil || rrType[1] != "TXT" {
+			continue
+		}
+
+		for _, data := range recordDataExp.FindAllStringSubmatch(row[2], -1) {
+			if strings.Trim(html.UnescapeString(data[1]), `"`) == txt {
+				return row[1]
+			}
+		}
+	}
+
+	return ""
+}
+
+func checkPageError(page string) error {
+	match := errorExp.FindStringSubmatch(page)
+	if match == nil {
+		return nil
+	}
+
+	msg := strings.TrimSpace(html.UnescapeString(match[1]))
+	if msg == "" {
+		return nil
+	}
+
+	return errors.New(msg)
+}
@@ -0,0 +1,141 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSessionClient(server *httptest.Server) (*SessionClient, error) {
+	client, err := NewSessionClient("user@example.com", "secret")
+	if err != nil {
+		return nil, err
+	}
+
+	client.baseURL = server.URL
+	client.HTTPClient = server.Client()
+
+	return client, nil
+}
+
+func sessionMockBuilder() *servermock.Builder[*SessionClient] {
+	return servermock.NewBuilder[*SessionClient](setupSessionClient).
+		Route("GET /{$}", servermock.ResponseFromFixture("zones.html")).
+		Route("POST /{$}", servermock.ResponseFromFixture("zones.html"),
+			servermock.CheckForm().Strict().
+				With("email", "user@example.com").
+				With("pass", "secret").
+				With("submit", "Login!"))
+}
+
+func TestSessionClient_AddTXTRecord(t *testing.T) {
+	client := sessionMockBuilder().
+		Route("POST /index.cgi", servermock.ResponseFromFixture("zone.html"),
+			servermock.CheckForm().Strict().
+				With("account", "").
+				With("menu", "edit_zone").
+				With("Type", "TXT").
+				With("hosted_dns_zoneid", "654321").
+				With("hosted_dns_recordid", "").
+				With("hosted_dns_editzone", "1").
+				With("Priority", "").
+				With("Name", "_acme-challenge.www.sub.example.com").
+				With("Content", "foo").
+				With("TTL", "300").
+				With("hosted_dns_editrecord", "Submit")).
+		Build(t)
+
+	err := client.AddTXTRecord(t.Context(), "_acme-challenge.www.sub.example.com", "foo", 300)
+	require.NoError(t, err)
+}
+
+func TestSessionClient_AddTXTRecord_error(t *testing.T) {
+	client := sessionMockBuilder().
+		Route("POST /index.cgi", servermock.ResponseFromFixture("record_error.html")).
+		Build(t)
+
+	err := client.AddTXTRecord(t.Context(), "_acme-challenge.www.example.com", "foo", 300)
+	require.EqualError(t, err, "This record already exists.")
+}
+
+func TestSessionClient_AddTXTRecord_zoneNotFound(t *testing.T) {
+	client := sessionMockBuilder().Build(t)
+
+	err := client.AddTXTRecord(t.Context(), "_acme-challenge.www.example.org", "foo", 300)
+	require.EqualError(t, err, "zone not found for _acme-challenge.www.example.org")
+}
+
+func TestSessionClient_AddTXTRecord_loginFailed(t *testing.T) {
+	client := servermock.NewBuilder[*SessionClient](setupSessionClient).
+		Route("GET /{$}", servermock.ResponseFromFixture("login_failed.html")).
+		Route("POST /{$}", servermock.ResponseFromFixture("login_failed.html")).
+		Build(t)
+
+	err := client.AddTXTRecord(t.Context(), "_acme-challenge.www.example.com", "foo", 300)
+	require.EqualError(t, err, "login failed: invalid credentials or two-factor authentication enabled")
+}
+
+func TestSessionClient_AddTXTRecord_sessionExpired(t *testing.T) {
+	var gets, logins atomic.Int32
+
+	client := servermock.NewBuilder[*SessionClient](setupSessionClient).
+		Route("GET /{$}", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			// The second call of the list of the zones happens after the expiration of the session.
+			if gets.Add(1) == 2 {
+				servermock.ResponseFromFixture("login_failed.html").ServeHTTP(rw, req)
+				return
+			}
+
+			servermock.ResponseFromFixture("zones.html").ServeHTTP(rw, req)
+		})).
+		Route("POST /{$}", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			logins.Add(1)
+
+			servermock.ResponseFromFixture("zones.html").ServeHTTP(rw, req)
+		})).
+		Route("POST /index.cgi", servermock.ResponseFromFixture("zone.html")).
+		Build(t)
+
+	err := client.AddTXTRecord(t.Context(), "_acme-challenge.www.example.com", "foo", 300)
+	require.NoError(t, err)
+
+	err = client.AddTXTRecord(t.Context(), "_acme-challenge.www.example.com", "bar", 300)
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), logins.Load())
+}
+
+func TestSessionClient_DeleteTXTRecord(t *testing.T) {
+	client := sessionMockBuilder().
+		Route("GET /index.cgi", servermock.ResponseFromFixture("zone.html"),
+			servermock.CheckQueryParameter().Strict().
+				With("hosted_dns_zoneid", "123456").
+				With("menu", "edit_zone").
+				With("hosted_dns_editzone", "")).
+		Route("POST /index.cgi", servermock.ResponseFromFixture("zone.html"),
+			servermock.CheckForm().Strict().
+				With("menu", "edit_zone").
+				With("hosted_dns_zoneid", "123456").
+				With("hosted_dns_recordid", "3333").
+				With("hosted_dns_editzone", "1").
+				With("hosted_dns_delrecord", "1").
+				With("hosted_dns_delconfirm", "delete")).
+		Build(t)
+
+	err := client.DeleteTXTRecord(t.Context(), "_acme-challenge.www.example.com", "foo")
+	require.NoError(t, err)
+}
+
+func TestSessionClient_DeleteTXTRecord_notFound(t *testing.T) {
+	client := sessionMockBuilder().
+		Route("GET /index.cgi", servermock.ResponseFromFixture("zone.html")).
+		Build(t)
+
+	err := client.DeleteTXTRecord(t.Context(), "_acme-challenge.www.example.com", "bar")
+	require.EqualError(t, err, "TXT record _acme-challenge.www.example.com not found in the zone 123456")
+}
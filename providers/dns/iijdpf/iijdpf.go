@@ -43,11 +43,16 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		Endpoint:           env.GetOrDefaultString(EnvAPIEndpoint, dpfapi.DefaultEndpoint),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 660*time.Second),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 5*time.Second),
-		TTL:                env.GetOrDefaultInt(EnvTTL, 300),
+		Endpoint:           lookup.GetOrDefaultString(EnvAPIEndpoint, dpfapi.DefaultEndpoint),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 660*time.Second),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, 5*time.Second),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, 300),
 	}
 }
 
@@ -69,17 +69,22 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		DNSView:       env.GetOrDefaultString(EnvDNSView, "External"),
-		WapiVersion:   env.GetOrDefaultString(EnvWApiVersion, "2.11"),
-		Port:          env.GetOrDefaultString(EnvPort, "443"),
-		SSLVerify:     env.GetOrDefaultBool(EnvSSLVerify, true),
-		CACertificate: env.GetOrDefaultString(EnvCACertificate, ""),
-
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPTimeout:        env.GetOrDefaultInt(EnvHTTPTimeout, 30),
+		DNSView:       lookup.GetOrDefaultString(EnvDNSView, "External"),
+		WapiVersion:   lookup.GetOrDefaultString(EnvWApiVersion, "2.11"),
+		Port:          lookup.GetOrDefaultString(EnvPort, "443"),
+		SSLVerify:     lookup.GetOrDefaultBool(EnvSSLVerify, true),
+		CACertificate: lookup.GetOrDefaultString(EnvCACertificate, ""),
+
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		HTTPTimeout:        lookup.GetOrDefaultInt(EnvHTTPTimeout, 30),
 	}
 }
 
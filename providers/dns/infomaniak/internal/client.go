@@ -14,6 +14,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	"golang.org/x/oauth2"
 )
@@ -146,7 +147,8 @@ func (c *Client) do(req *http.Request, result Response) error {
 	}
 
 	if result.GetResult() != "success" {
-		return fmt.Errorf("%d: unexpected API result (%s): %w", resp.StatusCode, result.GetResult(), result.GetError())
+		return providers.NewAPIError(req, resp,
+			fmt.Errorf("%d: unexpected API result (%s): %w", resp.StatusCode, result.GetResult(), result.GetError()))
 	}
 
 	return nil
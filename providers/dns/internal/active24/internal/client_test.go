@@ -72,7 +72,7 @@ func TestClient_GetServices_errors(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetServices(t.Context())
-	require.EqualError(t, err, "401: No username or password.")
+	require.EqualError(t, err, "401: No username or password. [endpoint: GET /v1/user/self/service]")
 }
 
 func TestClient_GetRecords(t *testing.T) {
@@ -117,7 +117,7 @@ func TestClient_GetRecords_errors(t *testing.T) {
 	}
 
 	_, err := client.GetRecords(t.Context(), "aaa", filter)
-	require.EqualError(t, err, "403: /errors/httpException: This action is unauthorized.")
+	require.EqualError(t, err, "403: /errors/httpException: This action is unauthorized. [endpoint: GET /v2/service/aaa/dns/record]")
 }
 
 func TestClient_CreateRecord(t *testing.T) {
@@ -139,7 +139,7 @@ func TestClient_CreateRecord_errors(t *testing.T) {
 		Build(t)
 
 	err := client.CreateRecord(t.Context(), "aaa", Record{})
-	require.EqualError(t, err, "403: /errors/httpException: This action is unauthorized.")
+	require.EqualError(t, err, "403: /errors/httpException: This action is unauthorized. [endpoint: POST /v2/service/aaa/dns/record]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -161,7 +161,7 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteRecord(t.Context(), "aaa", "123")
-	require.EqualError(t, err, "403: /errors/httpException: This action is unauthorized.")
+	require.EqualError(t, err, "403: /errors/httpException: This action is unauthorized. [endpoint: DELETE /v2/service/aaa/dns/record/123]")
 }
 
 func TestClient_sign(t *testing.T) {
@@ -0,0 +1,144 @@
+// Package backoff provides the retry policy of the requests rejected by the APIs (HTTP 429 and 5xx),
+// with an exponential backoff, for the HTTP clients of the providers.
+package backoff
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// Default values of the Config.
+const (
+	DefaultMaxRetries      = 5
+	DefaultInitialInterval = 1 * time.Second
+	DefaultMaxInterval     = 30 * time.Second
+)
+
+// Config configures the retries.
+type Config struct {
+	// MaxRetries the maximum number of retries of a request (0 disables the retries).
+	MaxRetries int
+
+	// InitialInterval the delay before the first retry, doubled at each retry.
+	InitialInterval time.Duration
+
+	// MaxInterval the maximum delay between two retries.
+	MaxInterval time.Duration
+
+	// MaxDelay the maximum delay accepted when the server requests a delay (ex: Retry-After).
+	// A longer requested delay is an error. 0 means no limit.
+	MaxDelay time.Duration
+
+	// Delay returns the delay requested by the server, or 0.
+	// Defaults to RetryAfter.
+	Delay func(resp *http.Response) time.Duration
+}
+
+// NewDefaultConfig returns the default configuration with the maximum number of retries.
+func NewDefaultConfig(maxRetries int) Config {
+	return Config{
+		MaxRetries:      maxRetries,
+		InitialInterval: DefaultInitialInterval,
+		MaxInterval:     DefaultMaxInterval,
+	}
+}
+
+// Wrap returns an HTTP client retrying the requests of the client with the retry policy of the Config.
+// The retries are handled by retryablehttp: the request body is replayed, and the context of the request is respected.
+func Wrap(client *http.Client, config Config) *http.Client {
+	if config.InitialInterval <= 0 {
+		config.InitialInterval = DefaultInitialInterval
+	}
+
+	if config.MaxInterval <= 0 {
+		config.MaxInterval = DefaultMaxInterval
+	}
+
+	if config.Delay == nil {
+		config.Delay = RetryAfter
+	}
+
+	retryClient := retryablehttp.NewClient()
+	retryClient.HTTPClient = client
+	retryClient.RetryMax = config.MaxRetries
+	retryClient.RetryWaitMin = config.InitialInterval
+	retryClient.RetryWaitMax = config.MaxInterval
+	retryClient.CheckRetry = config.checkRetry
+	retryClient.Backoff = config.backoff
+	retryClient.ErrorHandler = errorHandler
+	retryClient.Logger = log.Logger
+
+	return retryClient.StandardClient()
+}
+
+// checkRetry retries the responses HTTP 429 and 5xx (except 501), and the connection errors.
+// The delays requested by the server beyond MaxDelay are errors.
+func (c Config) checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if ctx.Err() != nil {
+		return false, ctx.Err()
+	}
+
+	if err != nil {
+		return retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests &&
+		(resp.StatusCode < http.StatusInternalServerError || resp.StatusCode == http.StatusNotImplemented) {
+		return false, nil
+	}
+
+	if requested := c.Delay(resp); c.MaxDelay > 0 && requested > c.MaxDelay {
+		return false, fmt.Errorf("throttled by the API: the requested delay (%s) exceeds the maximum delay (%s)", requested, c.MaxDelay)
+	}
+
+	return true, nil
+}
+
+// backoff returns the delay requested by the server, or the exponential delay of the attempt.
+func (c Config) backoff(minimum, maximum time.Duration, attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if requested := c.Delay(resp); requested > 0 {
+			return requested
+		}
+	}
+
+	return retryablehttp.DefaultBackoff(minimum, maximum, attempt, nil)
+}
+
+// errorHandler returns the last response when the retries are exhausted (the callers handle the status code),
+// and the errors of the retry policy.
+func errorHandler(resp *http.Response, err error, _ int) (*http.Response, error) {
+	if err == nil {
+		return resp, nil
+	}
+
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+
+	return nil, err
+}
+
+// RetryAfter returns the delay of the Retry-After header (seconds or HTTP date), or 0.
+func RetryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+
+	if retryTime, err := http.ParseTime(value); err == nil {
+		return max(time.Until(retryTime), 0)
+	}
+
+	return 0
+}
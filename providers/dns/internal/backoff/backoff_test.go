@@ -0,0 +1,221 @@
+package backoff
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig(maxRetries int) Config {
+	return Config{
+		MaxRetries:      maxRetries,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     5 * time.Millisecond,
+	}
+}
+
+func TestWrap(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		method        string
+		statusCodes   []int
+		maxRetries    int
+		expectedCalls int32
+		expectedCode  int
+	}{
+		{
+			desc:          "success",
+			method:        http.MethodGet,
+			statusCodes:   []int{http.StatusOK},
+			maxRetries:    3,
+			expectedCalls: 1,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			desc:          "too many requests then success",
+			method:        http.MethodPost,
+			statusCodes:   []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusCreated},
+			maxRetries:    3,
+			expectedCalls: 3,
+			expectedCode:  http.StatusCreated,
+		},
+		{
+			desc:          "server error then success",
+			method:        http.MethodPost,
+			statusCodes:   []int{http.StatusBadGateway, http.StatusOK},
+			maxRetries:    3,
+			expectedCalls: 2,
+			expectedCode:  http.StatusOK,
+		},
+		{
+			desc:          "retries exhausted",
+			method:        http.MethodGet,
+			statusCodes:   []int{http.StatusServiceUnavailable},
+			maxRetries:    2,
+			expectedCalls: 3,
+			expectedCode:  http.StatusServiceUnavailable,
+		},
+		{
+			desc:          "not implemented",
+			method:        http.MethodGet,
+			statusCodes:   []int{http.StatusNotImplemented},
+			maxRetries:    3,
+			expectedCalls: 1,
+			expectedCode:  http.StatusNotImplemented,
+		},
+		{
+			desc:          "client error",
+			method:        http.MethodGet,
+			statusCodes:   []int{http.StatusBadRequest},
+			maxRetries:    3,
+			expectedCalls: 1,
+			expectedCode:  http.StatusBadRequest,
+		},
+		{
+			desc:          "retries disabled",
+			method:        http.MethodGet,
+			statusCodes:   []int{http.StatusTooManyRequests},
+			expectedCalls: 1,
+			expectedCode:  http.StatusTooManyRequests,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, _ := io.ReadAll(req.Body)
+				assert.Equal(t, "content", string(body))
+
+				call := int(calls.Add(1))
+
+				rw.WriteHeader(test.statusCodes[min(call, len(test.statusCodes))-1])
+			}))
+			t.Cleanup(server.Close)
+
+			client := Wrap(&http.Client{}, testConfig(test.maxRetries))
+
+			req, err := http.NewRequestWithContext(t.Context(), test.method, server.URL, strings.NewReader("content"))
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+
+			_ = resp.Body.Close()
+
+			assert.Equal(t, test.expectedCode, resp.StatusCode)
+			assert.Equal(t, test.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestWrap_maxDelay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Retry-After", "3600")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	config := testConfig(3)
+	config.MaxDelay = time.Minute
+
+	client := Wrap(&http.Client{}, config)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	require.ErrorContains(t, err, "throttled by the API: the requested delay (1h0m0s) exceeds the maximum delay (1m0s)")
+}
+
+func TestWrap_delay(t *testing.T) {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			rw.Header().Set("X-Ratelimit-Reset", "1")
+			rw.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		rw.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	config := testConfig(3)
+
+	var requested atomic.Bool
+
+	config.Delay = func(resp *http.Response) time.Duration {
+		requested.Store(resp.Header.Get("X-Ratelimit-Reset") == "1")
+		return time.Millisecond
+	}
+
+	client := Wrap(&http.Client{}, config)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.True(t, requested.Load())
+}
+
+func TestWrap_contextCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Retry-After", "60")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	t.Cleanup(server.Close)
+
+	client := Wrap(&http.Client{}, testConfig(3))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, http.NoBody)
+	require.NoError(t, err)
+
+	_, err = client.Do(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRetryAfter(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected time.Duration
+	}{
+		{desc: "empty", value: "", expected: 0},
+		{desc: "seconds", value: "120", expected: 2 * time.Minute},
+		{desc: "past date", value: "Wed, 21 Oct 2015 07:28:00 GMT", expected: 0},
+		{desc: "invalid", value: "foo", expected: 0},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &http.Response{Header: http.Header{}}
+			resp.Header.Set("Retry-After", test.value)
+
+			assert.Equal(t, test.expected, RetryAfter(resp))
+		})
+	}
+}
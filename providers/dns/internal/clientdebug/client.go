@@ -119,7 +119,7 @@ func (d *DumpTransport) redact(content []byte) string {
 
 // Wrap wraps an HTTP client Transport with the [DumpTransport].
 func Wrap(client *http.Client, opts ...Option) *http.Client {
-	val, found := os.LookupEnv("LEGO_DEBUG_DNS_API_HTTP_CLIENT")
+	val, found := env.Lookup("LEGO_DEBUG_DNS_API_HTTP_CLIENT")
 	if !found {
 		return client
 	}
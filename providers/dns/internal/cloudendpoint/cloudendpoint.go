@@ -0,0 +1,96 @@
+// Package cloudendpoint builds the endpoints of the APIs of the cloud providers,
+// for the public clouds and for the sovereign/government clouds.
+package cloudendpoint
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Partition a group of regions of a cloud provider sharing the same domain (ex: a sovereign cloud).
+type Partition struct {
+	// Name of the partition (ex: "public", "eu").
+	Name string
+	// Domain of the endpoints (ex: "myhuaweicloud.com").
+	Domain string
+	// Regions of the partition.
+	// The partition without regions is the default partition.
+	Regions []string
+}
+
+// Partitions the partitions of a cloud provider.
+type Partitions []Partition
+
+// Lookup returns the partition of a region.
+// The default partition is returned when the region is not explicitly part of a partition.
+func (p Partitions) Lookup(region string) (Partition, error) {
+	var fallback *Partition
+
+	for i, partition := range p {
+		if len(partition.Regions) == 0 {
+			if fallback == nil {
+				fallback = &p[i]
+			}
+
+			continue
+		}
+
+		if slices.Contains(partition.Regions, region) {
+			return partition, nil
+		}
+	}
+
+	if fallback == nil {
+		return Partition{}, fmt.Errorf("no partition for the region %q", region)
+	}
+
+	return *fallback, nil
+}
+
+// Endpoint builds the endpoint of a region from a template.
+// The placeholders "{region}" and "{domain}" are replaced by the region and the domain of its partition.
+func (p Partitions) Endpoint(template, region string) (string, error) {
+	partition, err := p.Lookup(region)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.NewReplacer("{region}", region, "{domain}", partition.Domain).Replace(template), nil
+}
+
+// URL parses an endpoint override: the scheme "https" is used when the scheme is missing.
+func URL(endpoint string) (*url.URL, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		return nil, errors.New("empty endpoint")
+	}
+
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q: missing host", endpoint)
+	}
+
+	return u, nil
+}
+
+// Host returns the host (and the port) of an endpoint override, without the scheme and the path.
+// It's used by the SDKs expecting a hostname instead of a URL.
+func Host(endpoint string) (string, error) {
+	u, err := URL(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	return u.Host, nil
+}
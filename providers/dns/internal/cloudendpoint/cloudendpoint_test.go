@@ -0,0 +1,107 @@
+package cloudendpoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPartitions = Partitions{
+	{Name: "public", Domain: "example.com"},
+	{Name: "eu", Domain: "example.eu", Regions: []string{"eu-west-101"}},
+}
+
+func TestPartitions_Endpoint(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		region   string
+		expected string
+	}{
+		{
+			desc:     "default partition",
+			region:   "cn-north-4",
+			expected: "https://dns.cn-north-4.example.com",
+		},
+		{
+			desc:     "sovereign partition",
+			region:   "eu-west-101",
+			expected: "https://dns.eu-west-101.example.eu",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint, err := testPartitions.Endpoint("https://dns.{region}.{domain}", test.region)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, endpoint)
+		})
+	}
+}
+
+func TestPartitions_Lookup_noDefault(t *testing.T) {
+	partitions := Partitions{
+		{Name: "eu", Domain: "example.eu", Regions: []string{"eu-west-101"}},
+	}
+
+	_, err := partitions.Lookup("cn-north-4")
+	require.EqualError(t, err, `no partition for the region "cn-north-4"`)
+}
+
+func TestURL(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		endpoint string
+		expected string
+	}{
+		{
+			desc:     "URL",
+			endpoint: "https://management.chinacloudapi.cn/",
+			expected: "https://management.chinacloudapi.cn/",
+		},
+		{
+			desc:     "host",
+			endpoint: "dnspod.intl.tencentcloudapi.com",
+			expected: "https://dnspod.intl.tencentcloudapi.com",
+		},
+		{
+			desc:     "host and port",
+			endpoint: " api.yandexcloud.kz:443 ",
+			expected: "https://api.yandexcloud.kz:443",
+		},
+		{
+			desc:     "HTTP",
+			endpoint: "http://localhost:8080/api",
+			expected: "http://localhost:8080/api",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			u, err := URL(test.endpoint)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, u.String())
+		})
+	}
+}
+
+func TestURL_errors(t *testing.T) {
+	_, err := URL("")
+	require.EqualError(t, err, "empty endpoint")
+
+	_, err = URL("https:///path")
+	require.EqualError(t, err, `invalid endpoint "https:///path": missing host`)
+}
+
+func TestHost(t *testing.T) {
+	host, err := Host("https://api.yandexcloud.kz:443/path")
+	require.NoError(t, err)
+
+	assert.Equal(t, "api.yandexcloud.kz:443", host)
+}
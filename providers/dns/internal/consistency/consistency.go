@@ -0,0 +1,75 @@
+// Package consistency provides helpers to deal with the eventual consistency of the DNS provider APIs.
+package consistency
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+)
+
+const (
+	defaultInitialInterval = 500 * time.Millisecond
+	defaultMaxElapsedTime  = 30 * time.Second
+)
+
+// Option configures the retries.
+type Option func(*options)
+
+type options struct {
+	initialInterval time.Duration
+	maxElapsedTime  time.Duration
+}
+
+// WithInitialInterval sets the delay before the first retry.
+func WithInitialInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.initialInterval = interval
+	}
+}
+
+// WithMaxElapsedTime sets the maximum duration of the retries.
+func WithMaxElapsedTime(timeout time.Duration) Option {
+	return func(o *options) {
+		o.maxElapsedTime = timeout
+	}
+}
+
+// RetryOnNotFound calls the operation until it succeeds, or fails with an error not identified as a "not found" error.
+// Some APIs respond with a "not found" error during a short period after the creation of a resource (read-after-write):
+// those errors are retried with an exponential backoff, bounded by a maximum elapsed time.
+func RetryOnNotFound[T any](ctx context.Context, operation func() (T, error), isNotFound func(error) bool, opts ...Option) (T, error) {
+	o := &options{
+		initialInterval: defaultInitialInterval,
+		maxElapsedTime:  defaultMaxElapsedTime,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = o.initialInterval
+
+	return backoff.Retry(ctx,
+		func() (T, error) {
+			result, err := operation()
+			if err != nil && !isNotFound(err) {
+				return result, backoff.Permanent(err)
+			}
+
+			return result, err
+		},
+		backoff.WithBackOff(bo),
+		backoff.WithMaxElapsedTime(o.maxElapsedTime),
+	)
+}
+
+// DoOnNotFound is similar to RetryOnNotFound, but for operations without result.
+func DoOnNotFound(ctx context.Context, operation func() error, isNotFound func(error) bool, opts ...Option) error {
+	_, err := RetryOnNotFound(ctx, func() (struct{}, error) {
+		return struct{}{}, operation()
+	}, isNotFound, opts...)
+
+	return err
+}
@@ -0,0 +1,54 @@
+package consistency
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errNotFound = errors.New("not found")
+
+func isNotFound(err error) bool {
+	return errors.Is(err, errNotFound)
+}
+
+func TestRetryOnNotFound(t *testing.T) {
+	var calls int
+
+	result, err := RetryOnNotFound(t.Context(), func() (string, error) {
+		calls++
+
+		if calls < 3 {
+			return "", errNotFound
+		}
+
+		return "ok", nil
+	}, isNotFound, WithInitialInterval(time.Millisecond))
+	require.NoError(t, err)
+
+	assert.Equal(t, "ok", result)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryOnNotFound_permanent(t *testing.T) {
+	var calls int
+
+	_, err := RetryOnNotFound(t.Context(), func() (string, error) {
+		calls++
+
+		return "", errors.New("boom")
+	}, isNotFound, WithInitialInterval(time.Millisecond))
+	require.EqualError(t, err, "boom")
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestDoOnNotFound_timeout(t *testing.T) {
+	err := DoOnNotFound(t.Context(), func() error {
+		return errNotFound
+	}, isNotFound, WithInitialInterval(time.Millisecond), WithMaxElapsedTime(20*time.Millisecond))
+	require.ErrorIs(t, err, errNotFound)
+}
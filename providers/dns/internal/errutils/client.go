@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strconv"
+
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers"
 )
 
 const legoDebugClientVerboseError = "LEGO_DEBUG_CLIENT_VERBOSE_ERROR"
@@ -25,7 +27,7 @@ func NewHTTPDoError(req *http.Request, err error) *HTTPDoError {
 func (h HTTPDoError) Error() string {
 	msg := "unable to communicate with the API server:"
 
-	if ok, _ := strconv.ParseBool(os.Getenv(legoDebugClientVerboseError)); ok {
+	if ok, _ := strconv.ParseBool(env.Getenv(legoDebugClientVerboseError)); ok {
 		msg += fmt.Sprintf(" [request: %s %s]", h.req.Method, h.req.URL)
 	}
 
@@ -55,7 +57,7 @@ func NewReadResponseError(req *http.Request, statusCode int, err error) *ReadRes
 func (r ReadResponseError) Error() string {
 	msg := "unable to read response body:"
 
-	if ok, _ := strconv.ParseBool(os.Getenv(legoDebugClientVerboseError)); ok {
+	if ok, _ := strconv.ParseBool(env.Getenv(legoDebugClientVerboseError)); ok {
 		msg += fmt.Sprintf(" [request: %s %s]", r.req.Method, r.req.URL)
 	}
 
@@ -88,7 +90,7 @@ func NewUnmarshalError(req *http.Request, statusCode int, body []byte, err error
 func (u UnmarshalError) Error() string {
 	msg := "unable to unmarshal response:"
 
-	if ok, _ := strconv.ParseBool(os.Getenv(legoDebugClientVerboseError)); ok {
+	if ok, _ := strconv.ParseBool(env.Getenv(legoDebugClientVerboseError)); ok {
 		msg += fmt.Sprintf(" [request: %s %s]", u.req.Method, u.req.URL)
 	}
 
@@ -117,15 +119,18 @@ func NewUnexpectedStatusCodeError(req *http.Request, statusCode int, body []byte
 	return &UnexpectedStatusCodeError{req: req, StatusCode: statusCode, Body: bytes.TrimSpace(body)}
 }
 
-func NewUnexpectedResponseStatusCodeError(req *http.Request, resp *http.Response) *UnexpectedStatusCodeError {
+// NewUnexpectedResponseStatusCodeError creates a new UnexpectedStatusCodeError from the response,
+// wrapped inside a providers.APIError.
+func NewUnexpectedResponseStatusCodeError(req *http.Request, resp *http.Response) error {
 	raw, _ := io.ReadAll(resp.Body)
-	return &UnexpectedStatusCodeError{req: req, StatusCode: resp.StatusCode, Body: bytes.TrimSpace(raw)}
+
+	return providers.NewAPIError(req, resp, &UnexpectedStatusCodeError{req: req, StatusCode: resp.StatusCode, Body: bytes.TrimSpace(raw)})
 }
 
 func (u UnexpectedStatusCodeError) Error() string {
 	msg := "unexpected status code:"
 
-	if ok, _ := strconv.ParseBool(os.Getenv(legoDebugClientVerboseError)); ok {
+	if ok, _ := strconv.ParseBool(env.Getenv(legoDebugClientVerboseError)); ok {
 		msg += fmt.Sprintf(" [request: %s %s]", u.req.Method, u.req.URL)
 	}
 
@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -134,7 +135,7 @@ func (c *Client) doRequest(ctx context.Context, method string, endpoint *url.URL
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode/100 != 2 {
-		return parseError(resp)
+		return providers.NewAPIError(req, resp, parseError(resp))
 	}
 
 	if result == nil {
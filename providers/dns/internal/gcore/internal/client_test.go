@@ -50,7 +50,7 @@ func TestClient_GetZone_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetZone(t.Context(), "example.com")
-	require.EqualError(t, err, "get zone example.com: 500: oops")
+	require.EqualError(t, err, "get zone example.com: 500: oops [endpoint: GET /v2/zones/example.com]")
 }
 
 func TestClient_GetRRSet(t *testing.T) {
@@ -79,7 +79,7 @@ func TestClient_GetRRSet_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetRRSet(t.Context(), "example.com", "foo.example.com")
-	require.EqualError(t, err, "get txt records example.com -> foo.example.com: 500: oops")
+	require.EqualError(t, err, "get txt records example.com -> foo.example.com: 500: oops [endpoint: GET /v2/zones/example.com/foo.example.com/TXT]")
 }
 
 func TestClient_DeleteRRSet(t *testing.T) {
@@ -127,7 +127,7 @@ func TestClient_AddRRSet_add_error(t *testing.T) {
 		Build(t)
 
 	err := client.AddRRSet(t.Context(), "test.example.com", "my.test.example.com", testRecordContent, testTTL)
-	require.EqualError(t, err, "400: oops")
+	require.EqualError(t, err, "400: oops [endpoint: POST /v2/zones/test.example.com/my.test.example.com/TXT]")
 }
 
 func TestClient_AddRRSet_update(t *testing.T) {
@@ -161,5 +161,5 @@ func TestClient_AddRRSet_update_error(t *testing.T) {
 		Build(t)
 
 	err := client.AddRRSet(t.Context(), "test.example.com", "my.test.example.com", testRecordContent, testTTL)
-	require.EqualError(t, err, "400: oops")
+	require.EqualError(t, err, "400: oops [endpoint: PUT /v2/zones/test.example.com/my.test.example.com/TXT]")
 }
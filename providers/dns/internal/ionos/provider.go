@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	ionos "github.com/go-acme/lego/v4/providers/dns/internal/ionos/internal"
 )
@@ -45,8 +46,14 @@ func NewDNSProviderConfig(config *Config, baseURL string) (*DNSProvider, error)
 		return nil, errors.New("credentials missing")
 	}
 
-	if config.TTL < MinTTL {
-		return nil, fmt.Errorf("invalid TTL, TTL (%d) must be greater than %d", config.TTL, MinTTL)
+	err := env.ValidateTTL(config.TTL, MinTTL, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	err = env.ValidatePropagation(config.PropagationTimeout, config.PollingInterval)
+	if err != nil {
+		return nil, err
 	}
 
 	client, err := ionos.NewClient(config.APIKey)
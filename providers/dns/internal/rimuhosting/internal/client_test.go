@@ -296,7 +296,7 @@ func TestClient_DoActions_error(t *testing.T) {
 				"value":   []string{"txttxtx"},
 				"api_key": []string{"apikeyvaluehere"},
 			},
-			expected: "ERROR: No zone found for example.com",
+			expected: "ERROR: No zone found for example.com [endpoint: GET /]",
 		},
 		{
 			desc: "DELETE error",
@@ -311,7 +311,7 @@ func TestClient_DoActions_error(t *testing.T) {
 				"value":   []string{"txttxtx"},
 				"api_key": []string{"apikeyvaluehere"},
 			},
-			expected: "ERROR: No zone found for example.com",
+			expected: "ERROR: No zone found for example.com [endpoint: GET /]",
 		},
 	}
 
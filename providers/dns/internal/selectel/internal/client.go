@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -122,7 +123,7 @@ func (c *Client) do(req *http.Request, result any) (int, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode/100 != 2 {
-		return resp.StatusCode, parseError(req, resp)
+		return resp.StatusCode, providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	if result == nil {
@@ -47,7 +47,7 @@ func TestClient_ListRecords_error(t *testing.T) {
 
 	records, err := client.ListRecords(t.Context(), 123)
 
-	require.EqualError(t, err, "request failed with status code 401: API error: 400 - error description - field that the error occurred in")
+	require.EqualError(t, err, "request failed with status code 401: API error: 400 - error description - field that the error occurred in [endpoint: GET /123/records/]")
 	assert.Nil(t, records)
 }
 
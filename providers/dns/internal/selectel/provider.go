@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/internal/selectel/internal"
 )
@@ -47,8 +48,14 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("credentials missing")
 	}
 
-	if config.TTL < MinTTL {
-		return nil, fmt.Errorf("invalid TTL, TTL (%d) must be greater than %d", config.TTL, MinTTL)
+	err := env.ValidateTTL(config.TTL, MinTTL, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	err = env.ValidatePropagation(config.PropagationTimeout, config.PollingInterval)
+	if err != nil {
+		return nil, err
 	}
 
 	client := internal.NewClient(config.Token)
@@ -59,8 +66,6 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 
 	client.HTTPClient = clientdebug.Wrap(client.HTTPClient)
 
-	var err error
-
 	client.BaseURL, err = url.Parse(config.BaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("%w", err)
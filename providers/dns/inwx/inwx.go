@@ -9,49 +9,12 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/nrdcg/goinwx"
 	"github.com/pquerna/otp/totp"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "INWX_"
-
-	EnvUsername     = envNamespace + "USERNAME"
-	EnvPassword     = envNamespace + "PASSWORD"
-	EnvSharedSecret = envNamespace + "SHARED_SECRET"
-	EnvSandbox      = envNamespace + "SANDBOX"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-)
-
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	Username           string
-	Password           string
-	SharedSecret       string
-	Sandbox            bool
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL: env.GetOrDefaultInt(EnvTTL, 300),
-		// INWX has rather unstable propagation delays, thus using a larger default value
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 6*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		Sandbox:            env.GetOrDefaultBool(EnvSandbox, false),
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config         *Config
@@ -59,23 +22,6 @@ type DNSProvider struct {
 	previousUnlock time.Time
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for Dyn DNS.
-// Credentials must be passed in the environment variables:
-// INWX_USERNAME, INWX_PASSWORD, and INWX_SHARED_SECRET.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvUsername, EnvPassword)
-	if err != nil {
-		return nil, fmt.Errorf("inwx: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.Username = values[EnvUsername]
-	config.Password = values[EnvPassword]
-	config.SharedSecret = env.GetOrFile(EnvSharedSecret)
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for Dyn DNS.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
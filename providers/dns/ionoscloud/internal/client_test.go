@@ -74,7 +74,7 @@ func TestClient_RetrieveZones_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.RetrieveZones(t.Context(), "example.com")
-	require.EqualError(t, err, "401: paas-auth-1: Unauthorized, wrong or no api key provided to process this request")
+	require.EqualError(t, err, "401: paas-auth-1: Unauthorized, wrong or no api key provided to process this request [endpoint: GET /zones]")
 }
 
 func TestClient_CreateRecord(t *testing.T) {
@@ -12,51 +12,10 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/ispconfig/internal"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "ISPCONFIG_"
-
-	EnvServerURL = envNamespace + "SERVER_URL"
-	EnvUsername  = envNamespace + "USERNAME"
-	EnvPassword  = envNamespace + "PASSWORD"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-	EnvInsecureSkipVerify = envNamespace + "INSECURE_SKIP_VERIFY"
-)
-
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	ServerURL string
-	Username  string
-	Password  string
-
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-	HTTPClient         *http.Client
-	InsecureSkipVerify bool
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
@@ -66,22 +25,6 @@ type DNSProvider struct {
 	recordIDsMu sync.Mutex
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for ISPConfig.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvServerURL, EnvUsername, EnvPassword)
-	if err != nil {
-		return nil, fmt.Errorf("ispconfig: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.ServerURL = values[EnvServerURL]
-	config.Username = values[EnvUsername]
-	config.Password = values[EnvPassword]
-	config.InsecureSkipVerify = env.GetOrDefaultBool(EnvInsecureSkipVerify, false)
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for ISPConfig.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -48,7 +48,7 @@ func TestClient_AddTXTRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.AddTXTRecord(t.Context(), "example.com", "_acme-challenge.example.com.", "token")
-	require.EqualError(t, err, "unexpected status code: [status code: 401] body: Missing or invalid token.")
+	require.EqualError(t, err, "unexpected status code: [status code: 401] body: Missing or invalid token. [endpoint: POST /ddns/update.php]")
 }
 
 func TestClient_DeleteTXTRecord(t *testing.T) {
@@ -79,5 +79,5 @@ func TestClient_DeleteTXTRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteTXTRecord(t.Context(), "example.com", "_acme-challenge.example.com.", "token")
-	require.EqualError(t, err, "unexpected status code: [status code: 401] body: Missing or invalid token.")
+	require.EqualError(t, err, "unexpected status code: [status code: 401] body: Missing or invalid token. [endpoint: DELETE /ddns/update.php]")
 }
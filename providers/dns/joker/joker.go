@@ -3,7 +3,6 @@ package joker
 
 import (
 	"net/http"
-	"os"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge"
@@ -49,15 +48,20 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		APIMode:            env.GetOrDefaultString(EnvMode, modeDMAPI),
-		Debug:              env.GetOrDefaultBool(EnvDebug, false),
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		SequenceInterval:   env.GetOrDefaultSecond(EnvSequenceInterval, dns01.DefaultPropagationTimeout),
+		APIMode:            lookup.GetOrDefaultString(EnvMode, modeDMAPI),
+		Debug:              lookup.GetOrDefaultBool(EnvDebug, false),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		SequenceInterval:   lookup.GetOrDefaultSecond(EnvSequenceInterval, dns01.DefaultPropagationTimeout),
 		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 60*time.Second),
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, 60*time.Second),
 		},
 	}
 }
@@ -65,7 +69,7 @@ func NewDefaultConfig() *Config {
 // NewDNSProvider returns a DNSProvider instance configured for Joker.
 // Credentials must be passed in the environment variable JOKER_API_KEY.
 func NewDNSProvider() (challenge.ProviderTimeout, error) {
-	if os.Getenv(EnvMode) == modeSVC {
+	if env.GetOrFile(EnvMode) == modeSVC {
 		return newSvcProvider()
 	}
 
@@ -62,7 +62,7 @@ func TestClient_ListDomains_error(t *testing.T) {
 
 	_, err := client.ListDomains(t.Context())
 
-	require.EqualError(t, err, "401 Unauthorized: API key is missing or invalid.")
+	require.EqualError(t, err, "401 Unauthorized: API key is missing or invalid. [endpoint: GET /api/v2/domains]")
 }
 
 func TestClient_ListDomainRecords(t *testing.T) {
@@ -107,7 +107,7 @@ func TestClient_ListDomainRecords_error(t *testing.T) {
 
 	_, err := client.ListDomainRecords(t.Context(), 8)
 
-	require.EqualError(t, err, "401 Unauthorized: API key is missing or invalid.")
+	require.EqualError(t, err, "401 Unauthorized: API key is missing or invalid. [endpoint: GET /api/v2/dns/8]")
 }
 
 func TestClient_UpdateDomainRecords(t *testing.T) {
@@ -165,5 +165,5 @@ func TestClient_UpdateDomainRecords_error(t *testing.T) {
 
 	_, err := client.UpdateDomainRecords(t.Context(), 123, records)
 
-	require.EqualError(t, err, "401 Unauthorized: API key is missing or invalid.")
+	require.EqualError(t, err, "401 Unauthorized: API key is missing or invalid. [endpoint: PUT /api/v2/dns/123]")
 }
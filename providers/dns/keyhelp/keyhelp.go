@@ -5,52 +5,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/keyhelp/internal"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "KEYHELP_"
-
-	EnvBaseURL = envNamespace + "BASE_URL"
-	EnvAPIKey  = envNamespace + "API_KEY"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-)
-
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	BaseURL string
-	APIKey  string
-
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
@@ -60,20 +22,6 @@ type DNSProvider struct {
 	domainIDsMu sync.Mutex
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for KeyHelp.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvBaseURL, EnvAPIKey)
-	if err != nil {
-		return nil, fmt.Errorf("keyhelp: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.BaseURL = values[EnvBaseURL]
-	config.APIKey = values[EnvAPIKey]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for KeyHelp.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
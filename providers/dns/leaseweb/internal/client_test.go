@@ -89,7 +89,7 @@ func TestClient_GetRRSet_error_404(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetRRSet(t.Context(), "example.com", "_acme-challenge.example.com.", "TXT")
-	require.EqualError(t, err, "404: Resource not found (289346a1-3eaf-4da4-b707-62ef12eb08be)")
+	require.EqualError(t, err, "404: Resource not found (289346a1-3eaf-4da4-b707-62ef12eb08be) [endpoint: GET /domains/example.com/resourceRecordSets/_acme-challenge.example.com./TXT]")
 
 	target := &NotFoundError{}
 	require.ErrorAs(t, err, &target)
@@ -145,5 +145,5 @@ func TestClient_DeleteRRSet_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteRRSet(t.Context(), "example.com", "_acme-challenge.example.com.", "TXT")
-	require.EqualError(t, err, "401: You are not authorized to view this resource. (289346a1-3eaf-4da4-b707-62ef12eb08be)")
+	require.EqualError(t, err, "401: You are not authorized to view this resource. (289346a1-3eaf-4da4-b707-62ef12eb08be) [endpoint: DELETE /domains/example.com/resourceRecordSets/_acme-challenge.example.com./TXT]")
 }
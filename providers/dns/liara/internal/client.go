@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	"golang.org/x/oauth2"
 )
@@ -57,7 +58,7 @@ func (c *Client) GetRecords(ctx context.Context, domainName string) ([]Record, e
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, parseError(req, resp)
+		return nil, providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	raw, err := io.ReadAll(resp.Body)
@@ -92,7 +93,7 @@ func (c *Client) CreateRecord(ctx context.Context, domainName string, record Rec
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, parseError(req, resp)
+		return nil, providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	raw, err := io.ReadAll(resp.Body)
@@ -127,7 +128,7 @@ func (c *Client) GetRecord(ctx context.Context, domainName, recordID string) (*R
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, parseError(req, resp)
+		return nil, providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	raw, err := io.ReadAll(resp.Body)
@@ -162,7 +163,7 @@ func (c *Client) DeleteRecord(ctx context.Context, domainName, recordID string)
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
-		return parseError(req, resp)
+		return providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	return nil
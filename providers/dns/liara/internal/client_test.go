@@ -177,5 +177,5 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteRecord(t.Context(), "example.com", "123")
-	require.EqualError(t, err, "[status code: 401] Unauthorized: Invalid token missing header")
+	require.EqualError(t, err, "[status code: 401] Unauthorized: Invalid token missing header [endpoint: DELETE /api/v1/zones/example.com/dns-records/123]")
 }
@@ -56,6 +56,26 @@ func TestCredentialsFromEnv(t *testing.T) {
 	assert.Equal(t, expected, cs)
 }
 
+func TestNewDNSProviderConfig_staticCredentials(t *testing.T) {
+	config := NewDefaultConfig()
+	config.AccessKeyID = "abc"
+	config.Region = "us-east-1"
+
+	_, err := NewDNSProviderConfig(config)
+	require.EqualError(t, err, "lightsail: AccessKeyID and SecretAccessKey must be supplied together")
+
+	config.SecretAccessKey = "123"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	cs, err := provider.client.Options().Credentials.Retrieve(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, "abc", cs.AccessKeyID)
+	assert.Equal(t, "123", cs.SecretAccessKey)
+}
+
 func TestDNSProvider_Present(t *testing.T) {
 	provider := servermock.NewBuilder(
 		func(server *httptest.Server) (*DNSProvider, error) {
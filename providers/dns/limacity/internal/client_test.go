@@ -53,7 +53,7 @@ func TestClient_GetDomains_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetDomains(t.Context())
-	require.EqualError(t, err, "[status code: 400] status: invalid_resource, details: name: [muss ausgefüllt werden]")
+	require.EqualError(t, err, "[status code: 400] status: invalid_resource, details: name: [muss ausgefüllt werden] [endpoint: GET /domains.json]")
 }
 
 func TestClient_GetRecords(t *testing.T) {
@@ -91,7 +91,7 @@ func TestClient_GetRecords_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetRecords(t.Context(), 123)
-	require.EqualError(t, err, "[status code: 400] status: invalid_resource, details: name: [muss ausgefüllt werden]")
+	require.EqualError(t, err, "[status code: 400] status: invalid_resource, details: name: [muss ausgefüllt werden] [endpoint: GET /domains/123/records.json]")
 }
 
 func TestClient_AddRecord(t *testing.T) {
@@ -127,7 +127,7 @@ func TestClient_AddRecord_error(t *testing.T) {
 	}
 
 	err := client.AddRecord(t.Context(), 123, record)
-	require.EqualError(t, err, "[status code: 400] status: invalid_resource, details: name: [muss ausgefüllt werden]")
+	require.EqualError(t, err, "[status code: 400] status: invalid_resource, details: name: [muss ausgefüllt werden] [endpoint: POST /domains/123/records.json]")
 }
 
 func TestClient_UpdateRecord(t *testing.T) {
@@ -149,7 +149,7 @@ func TestClient_UpdateRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.UpdateRecord(t.Context(), 123, 456, Record{})
-	require.EqualError(t, err, "[status code: 400] status: invalid_resource, details: name: [muss ausgefüllt werden]")
+	require.EqualError(t, err, "[status code: 400] status: invalid_resource, details: name: [muss ausgefüllt werden] [endpoint: PUT /domains/123/records/456]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -170,5 +170,5 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteRecord(t.Context(), 123, 456)
-	require.EqualError(t, err, "[status code: 400] status: invalid_resource, details: name: [muss ausgefüllt werden]")
+	require.EqualError(t, err, "[status code: 400] status: invalid_resource, details: name: [muss ausgefüllt werden] [endpoint: DELETE /domains/123/records/456]")
 }
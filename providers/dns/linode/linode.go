@@ -34,6 +34,9 @@ const (
 	minTTL             = 300
 	dnsUpdateFreqMins  = 15
 	dnsUpdateFudgeSecs = 120
+
+	// maxPageSize the maximum page size allowed by the API.
+	maxPageSize = 500
 )
 
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
@@ -49,11 +52,16 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 120*time.Second),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 15*time.Second),
-		HTTPTimeout:        env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, minTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 120*time.Second),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, 15*time.Second),
+		HTTPTimeout:        lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
 	}
 }
 
@@ -92,8 +100,14 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("linode: Linode Access Token missing")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("linode: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL, 0)
+	if err != nil {
+		return nil, fmt.Errorf("linode: %w", err)
+	}
+
+	err = env.ValidatePropagation(config.PropagationTimeout, config.PollingInterval)
+	if err != nil {
+		return nil, fmt.Errorf("linode: %w", err)
 	}
 
 	oauth2Client := &http.Client{
@@ -163,8 +177,21 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		return err
 	}
 
-	// Get all TXT records for the specified domain.
-	listOpts := linodego.NewListOptions(0, `{"type":"TXT"}`)
+	// Get the TXT records matching the name, filtered server-side.
+	filter, err := json.Marshal(map[string]any{
+		"type": linodego.RecordTypeTXT,
+		"+or": []map[string]string{
+			{"name": zone.resourceName},
+			{"name": dns01.UnFqdn(info.EffectiveFQDN)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create JSON filter: %w", err)
+	}
+
+	// The page 0 means that all the pages are fetched.
+	listOpts := linodego.NewListOptions(0, string(filter))
+	listOpts.PageSize = maxPageSize
 
 	resources, err := d.client.ListDomainRecords(ctx, zone.domainID, listOpts)
 	if err != nil {
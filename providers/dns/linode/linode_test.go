@@ -6,7 +6,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
 	"github.com/linode/linodego"
 	"github.com/stretchr/testify/assert"
@@ -61,22 +63,32 @@ func TestNewDNSProvider(t *testing.T) {
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc     string
+		ttl      int
 		apiKey   string
 		expected string
 	}{
 		{
 			desc:   "success",
+			ttl:    minTTL,
 			apiKey: "123",
 		},
 		{
 			desc:     "missing credentials",
+			ttl:      minTTL,
 			expected: "linode: Linode Access Token missing",
 		},
+		{
+			desc:     "invalid TTL",
+			ttl:      299,
+			apiKey:   "123",
+			expected: "linode: invalid TTL, TTL (299) must be greater than 300",
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
+			config.TTL = test.ttl
 			config.Token = test.apiKey
 
 			p, err := NewDNSProviderConfig(config)
@@ -297,6 +309,73 @@ func TestDNSProvider_CleanUp(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_CleanUp_pagination(t *testing.T) {
+	defer envTest.RestoreEnv()
+
+	os.Setenv(EnvToken, "testing")
+
+	mockZone(t)
+
+	record := func(id int, name string) linodego.DomainRecord {
+		return linodego.DomainRecord{
+			ID:     id,
+			Name:   name,
+			Target: "ElbOJKOkFWiZLQeoxf-wb3IpOsQCdvoM0y_wn0TEkxM",
+			Type:   "TXT",
+		}
+	}
+
+	var deleted bool
+
+	provider := mockBuilder().
+		Route("GET /v4/domains",
+			servermock.JSONEncode(&linodego.DomainsPagedResponse{
+				PageOptions: &linodego.PageOptions{
+					Pages:   1,
+					Results: 1,
+					Page:    1,
+				},
+				Data: []linodego.Domain{{
+					Domain: "example.com",
+					ID:     1234,
+				}},
+			})).
+		Route("GET /v4/domains/1234/records",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				response := &linodego.DomainRecordsPagedResponse{
+					PageOptions: &linodego.PageOptions{
+						Pages:   2,
+						Results: 2,
+						Page:    1,
+					},
+					Data: []linodego.DomainRecord{record(1, "_acme-challenge.sub")},
+				}
+
+				if req.URL.Query().Get("page") == "2" {
+					response.Page = 2
+					response.Data = []linodego.DomainRecord{record(2, "_acme-challenge")}
+				}
+
+				servermock.JSONEncode(response).ServeHTTP(rw, req)
+			}),
+			servermock.CheckQueryParameter().
+				With("page_size", "500"),
+			servermock.CheckHeader().
+				With("X-Filter", `{"+or":[{"name":"_acme-challenge"},{"name":"_acme-challenge.example.com"}],"type":"TXT"}`)).
+		Route("DELETE /v4/domains/1234/records/2",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				deleted = true
+
+				servermock.RawStringResponse("{}").WithHeader("Content-Type", "application/json").ServeHTTP(rw, req)
+			})).
+		Build(t)
+
+	err := provider.CleanUp("example.com", "", "dGVzdGluZw==")
+	require.NoError(t, err)
+
+	assert.True(t, deleted, "the record of the second page must be deleted")
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("Skipping live test")
@@ -323,3 +402,21 @@ func mockBuilder() *servermock.Builder[*DNSProvider] {
 		return p, nil
 	})
 }
+
+// mockZone answers the SOA requests used to find the zone (example.com) of the challenges.
+func mockZone(t *testing.T) {
+	t.Helper()
+
+	dns01.ClearFqdnCache()
+
+	addr := dnsmock.NewServer().
+		Query("_acme-challenge.example.com. SOA", dnsmock.SOA("example.com.")).
+		Build(t)
+
+	dns01.SetDefaultNameservers([]string{addr.String()})
+
+	t.Cleanup(func() {
+		dns01.SetDefaultNameservers(nil)
+		dns01.ClearFqdnCache()
+	})
+}
@@ -244,7 +244,7 @@ func TestClient_rpcCall_404(t *testing.T) {
 	}
 
 	err := client.rpcCall(t.Context(), call, &responseString{})
-	require.EqualError(t, err, "unexpected status code: [status code: 404] body: <?xml version='1.0' encoding='UTF-8'?>")
+	require.EqualError(t, err, "unexpected status code: [status code: 404] body: <?xml version='1.0' encoding='UTF-8'?> [endpoint: POST /]")
 }
 
 func TestClient_rpcCall_RPCError(t *testing.T) {
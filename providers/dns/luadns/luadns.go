@@ -5,7 +5,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -17,45 +16,10 @@ import (
 	"github.com/go-acme/lego/v4/providers/dns/luadns/internal"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "LUADNS_"
-
-	EnvAPIUsername = envNamespace + "API_USERNAME"
-	EnvAPIToken    = envNamespace + "API_TOKEN"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-)
-
 const minTTL = 300
 
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	APIUsername        string
-	APIToken           string
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 120*time.Second),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
@@ -65,22 +29,6 @@ type DNSProvider struct {
 	records   map[string]*internal.DNSRecord
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for LuaDNS.
-// Credentials must be passed in the environment variables:
-// LUADNS_API_USERNAME and LUADNS_API_TOKEN.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvAPIUsername, EnvAPIToken)
-	if err != nil {
-		return nil, fmt.Errorf("luadns: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.APIUsername = values[EnvAPIUsername]
-	config.APIToken = values[EnvAPIToken]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for LuaDNS.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -91,8 +39,14 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("luadns: credentials missing")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("luadns: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL, 0)
+	if err != nil {
+		return nil, fmt.Errorf("luadns: %w", err)
+	}
+
+	err = env.ValidatePropagation(config.PropagationTimeout, config.PollingInterval)
+	if err != nil {
+		return nil, fmt.Errorf("luadns: %w", err)
 	}
 
 	client := internal.NewClient(config.APIUsername, config.APIToken)
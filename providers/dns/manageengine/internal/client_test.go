@@ -117,7 +117,7 @@ func TestClient_GetAllZones_error(t *testing.T) {
 	_, err := client.GetAllZones(t.Context())
 	require.Error(t, err)
 
-	require.EqualError(t, err, "[status code: 401] Authentication credentials were not provided.")
+	require.EqualError(t, err, "[status code: 401] Authentication credentials were not provided. [endpoint: GET /dns/domain]")
 }
 
 func TestClient_GetAllZoneRecords(t *testing.T) {
@@ -170,7 +170,7 @@ func TestClient_GetAllZoneRecords_error(t *testing.T) {
 	_, err := client.GetAllZoneRecords(t.Context(), 4)
 	require.Error(t, err)
 
-	require.EqualError(t, err, "[status code: 401] Authentication credentials were not provided.")
+	require.EqualError(t, err, "[status code: 401] Authentication credentials were not provided. [endpoint: GET /dns/domain/4/records/SPF_TXT]")
 }
 
 func TestClient_DeleteZoneRecord(t *testing.T) {
@@ -192,7 +192,7 @@ func TestClient_DeleteZoneRecord_error(t *testing.T) {
 	err := client.DeleteZoneRecord(t.Context(), 4, 6)
 	require.Error(t, err)
 
-	require.EqualError(t, err, "[status code: 401] Authentication credentials were not provided.")
+	require.EqualError(t, err, "[status code: 401] Authentication credentials were not provided. [endpoint: DELETE /dns/domain/4/records/SPF_TXT/6]")
 }
 
 func TestClient_CreateZoneRecord(t *testing.T) {
@@ -241,7 +241,7 @@ func TestClient_CreateZoneRecord_error(t *testing.T) {
 	err := client.CreateZoneRecord(t.Context(), 4, record)
 	require.Error(t, err)
 
-	require.EqualError(t, err, "[status code: 401] Authentication credentials were not provided.")
+	require.EqualError(t, err, "[status code: 401] Authentication credentials were not provided. [endpoint: POST /dns/domain/4/records/SPF_TXT/]")
 }
 
 func TestClient_CreateZoneRecord_error_bad_request(t *testing.T) {
@@ -258,7 +258,7 @@ func TestClient_CreateZoneRecord_error_bad_request(t *testing.T) {
 	err := client.CreateZoneRecord(t.Context(), 4, record)
 	require.Error(t, err)
 
-	require.EqualError(t, err, "[status code: 400] Invalid record format, Record should be in list.")
+	require.EqualError(t, err, "[status code: 400] Invalid record format, Record should be in list. [endpoint: POST /dns/domain/4/records/SPF_TXT/]")
 }
 
 func TestClient_UpdateZoneRecord(t *testing.T) {
@@ -298,5 +298,5 @@ func TestClient_UpdateZoneRecord_error(t *testing.T) {
 	err := client.UpdateZoneRecord(t.Context(), record)
 	require.Error(t, err)
 
-	require.EqualError(t, err, "[status code: 401] Authentication credentials were not provided.")
+	require.EqualError(t, err, "[status code: 401] Authentication credentials were not provided. [endpoint: PUT /dns/domain/4/records/SPF_TXT/6/]")
 }
@@ -10,62 +10,16 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/manageengine/internal"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "MANAGEENGINE_"
-
-	EnvClientID     = envNamespace + "CLIENT_ID"
-	EnvClientSecret = envNamespace + "CLIENT_SECRET"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-)
-
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	ClientID     string
-	ClientSecret string
-
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
 	client *internal.Client
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for ManageEngine CloudDNS.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvClientID, EnvClientSecret)
-	if err != nil {
-		return nil, fmt.Errorf("manageengine: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.ClientID = values[EnvClientID]
-	config.ClientSecret = values[EnvClientSecret]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for ManageEngine CloudDNS.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
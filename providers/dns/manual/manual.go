@@ -1,13 +1,40 @@
 package manual
 
 import (
+	"errors"
+
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
 )
 
+// Config is used to configure the creation of the DNSProvider.
+// The manual provider has no options.
+type Config struct{}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return &Config{}
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider.
+// The provider doesn't read environment variables.
+func NewDefaultConfigFrom(_ env.LookupFunc) *Config {
+	return NewDefaultConfig()
+}
+
 // DNSProvider is an implementation of the ChallengeProvider interface.
 type DNSProvider = dns01.DNSProviderManual
 
 // NewDNSProvider returns a DNSProvider instance.
 func NewDNSProvider() (*DNSProvider, error) {
+	return NewDNSProviderConfig(NewDefaultConfig())
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for the manual resolution.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("manual: the configuration of the DNS provider is nil")
+	}
+
 	return &DNSProvider{}, nil
 }
@@ -10,42 +10,11 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/nzdjb/go-metaname"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "METANAME_"
-
-	EnvAccountReference = envNamespace + "ACCOUNT_REFERENCE"
-	EnvAPIKey           = envNamespace + "API_KEY"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-)
-
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	AccountReference   string
-	APIKey             string
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
@@ -55,21 +24,6 @@ type DNSProvider struct {
 	recordsMu sync.Mutex
 }
 
-// NewDNSProvider returns a new DNS provider
-// using environment variable METANAME_API_KEY for adding and removing the DNS record.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvAccountReference, EnvAPIKey)
-	if err != nil {
-		return nil, fmt.Errorf("metaname: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.AccountReference = values[EnvAccountReference]
-	config.APIKey = values[EnvAPIKey]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for Metaname.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
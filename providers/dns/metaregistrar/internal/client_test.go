@@ -65,12 +65,12 @@ func TestClient_UpdateDNSZone_error(t *testing.T) {
 		{
 			desc:     "authentication error",
 			filename: "error.json",
-			expected: "invalid_token: the supplied token is invalid",
+			expected: "invalid_token: the supplied token is invalid [endpoint: PATCH /dnszone/example.com]",
 		},
 		{
 			desc:     "API error",
 			filename: "error-response.json",
-			expected: "error: does_not_exist: This server does not exist",
+			expected: "error: does_not_exist: This server does not exist [endpoint: PATCH /dnszone/example.com]",
 		},
 	}
 
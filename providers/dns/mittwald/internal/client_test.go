@@ -52,7 +52,7 @@ func TestClient_ListDomains_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.ListDomains(t.Context())
-	require.EqualError(t, err, "[status code 400] ValidationError: Validation failed [format: should be string (.address.street, email)]")
+	require.EqualError(t, err, "[status code 400] ValidationError: Validation failed [format: should be string (.address.street, email)] [endpoint: GET /domains]")
 }
 
 func TestClient_ListDNSZones(t *testing.T) {
@@ -154,5 +154,5 @@ func TestClient_DeleteDNSZone_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteDNSZone(t.Context(), "my-zone-id")
-	assert.EqualError(t, err, "[status code 500] InternalServerError: Something went wrong")
+	assert.EqualError(t, err, "[status code 500] InternalServerError: Something went wrong [endpoint: DELETE /dns-zones/my-zone-id]")
 }
@@ -49,7 +49,7 @@ func TestClient_AddTXTRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.AddTXTRecord(t.Context(), "example", "txt")
-	require.EqualError(t, err, `unexpected status code: [status code: 400] body: invalid value for "key"`)
+	require.EqualError(t, err, `unexpected status code: [status code: 400] body: invalid value for "key" [endpoint: POST /update]`)
 }
 
 func TestClient_AddTXTRecord_error_credentials(t *testing.T) {
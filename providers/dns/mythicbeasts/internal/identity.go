@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -35,7 +36,7 @@ func (c *Client) obtainToken(ctx context.Context) (*Token, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, parseError(req, resp)
+		return nil, providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	raw, err := io.ReadAll(resp.Body)
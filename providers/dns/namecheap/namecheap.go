@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge"
@@ -49,9 +51,14 @@ const (
 
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
+// maxUpdateAttempts the maximum number of attempts to update the host records
+// when they are modified by a third party during the update.
+const maxUpdateAttempts = 3
+
 // Config is used to configure the creation of the DNSProvider.
 type Config struct {
 	Debug              bool
+	Sandbox            bool
 	BaseURL            string
 	APIUser            string
 	APIKey             string
@@ -64,19 +71,27 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
+	sandbox := lookup.GetOrDefaultBool(EnvSandbox, false)
+
 	baseURL := internal.DefaultBaseURL
-	if env.GetOrDefaultBool(EnvSandbox, false) {
+	if sandbox {
 		baseURL = internal.SandboxBaseURL
 	}
 
 	return &Config{
 		BaseURL:            baseURL,
-		Debug:              env.GetOrDefaultBool(EnvDebug, false),
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, time.Hour),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 15*time.Second),
+		Sandbox:            sandbox,
+		Debug:              lookup.GetOrDefaultBool(EnvDebug, false),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, time.Hour),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, 15*time.Second),
 		HTTPClient: &http.Client{
-			Timeout:   env.GetOrDefaultSecond(EnvHTTPTimeout, time.Minute),
+			Timeout:   lookup.GetOrDefaultSecond(EnvHTTPTimeout, time.Minute),
 			Transport: defaultTransport(envNamespace),
 		},
 	}
@@ -86,6 +101,10 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	config *Config
 	client *internal.Client
+
+	// hostsMu serializes the updates of the host records:
+	// the API only allows to replace the whole list of records.
+	hostsMu sync.Mutex
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for namecheap.
@@ -124,7 +143,15 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	}
 
 	client := internal.NewClient(config.APIUser, config.APIKey, config.ClientIP)
-	client.BaseURL = config.BaseURL
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	// The sandbox is used unless a custom URL is defined.
+	if config.Sandbox && (config.BaseURL == "" || config.BaseURL == internal.DefaultBaseURL) {
+		client.BaseURL = internal.SandboxBaseURL
+	}
 
 	if config.HTTPClient != nil {
 		client.HTTPClient = config.HTTPClient
@@ -149,13 +176,6 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return fmt.Errorf("namecheap: %w", err)
 	}
 
-	ctx := context.Background()
-
-	records, err := d.client.GetHosts(ctx, pr.sld, pr.tld)
-	if err != nil {
-		return fmt.Errorf("namecheap: %w", err)
-	}
-
 	record := internal.Record{
 		Name:    pr.key,
 		Type:    "TXT",
@@ -164,15 +184,9 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		TTL:     strconv.Itoa(d.config.TTL),
 	}
 
-	records = append(records, record)
-
-	if d.config.Debug {
-		for _, h := range records {
-			log.Printf("%-5.5s %-30.30s %-6s %-70.70s", h.Type, h.Name, h.TTL, h.Address)
-		}
-	}
-
-	err = d.client.SetHosts(ctx, pr.sld, pr.tld, records)
+	err = d.updateHosts(context.Background(), pr, func(records []internal.Record) ([]internal.Record, bool) {
+		return append(records, record), true
+	})
 	if err != nil {
 		return fmt.Errorf("namecheap: %w", err)
 	}
@@ -188,37 +202,102 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		return fmt.Errorf("namecheap: %w", err)
 	}
 
-	ctx := context.Background()
+	err = d.updateHosts(context.Background(), pr, func(records []internal.Record) ([]internal.Record, bool) {
+		// Find the challenge TXT record and remove it if found.
+		var (
+			found      bool
+			newRecords []internal.Record
+		)
 
-	records, err := d.client.GetHosts(ctx, pr.sld, pr.tld)
+		for _, h := range records {
+			if pr.matches(h) {
+				found = true
+			} else {
+				newRecords = append(newRecords, h)
+			}
+		}
+
+		return newRecords, found
+	})
 	if err != nil {
 		return fmt.Errorf("namecheap: %w", err)
 	}
 
-	// Find the challenge TXT record and remove it if found.
-	var (
-		found      bool
-		newRecords []internal.Record
-	)
-
-	for _, h := range records {
-		if h.Name == pr.key && h.Type == "TXT" {
-			found = true
-		} else {
-			newRecords = append(newRecords, h)
-		}
-	}
+	return nil
+}
 
-	if !found {
-		return nil
+// updateHosts replaces the host records with the result of the update function.
+// The API only allows to replace all the host records at once,
+// so to avoid wiping records created by a third party during the update:
+//   - the host records are read again right before the write, and compared with the snapshot the update was computed from:
+//     if they have changed, the update is computed again from the new snapshot.
+//   - the host records are read after the write: if records have been lost (concurrent write of a third party),
+//     the update is rolled back (the lost records are restored, the records added by the update are removed),
+//     and the update is retried.
+func (d *DNSProvider) updateHosts(ctx context.Context, pr *pseudoRecord, update func([]internal.Record) ([]internal.Record, bool)) error {
+	d.hostsMu.Lock()
+	defer d.hostsMu.Unlock()
+
+	snapshot, err := d.client.GetHosts(ctx, pr.sld, pr.tld)
+	if err != nil {
+		return err
 	}
 
-	err = d.client.SetHosts(ctx, pr.sld, pr.tld, newRecords)
-	if err != nil {
-		return fmt.Errorf("namecheap: %w", err)
+	for range maxUpdateAttempts {
+		newRecords, changed := update(slices.Clone(snapshot))
+		if !changed {
+			return nil
+		}
+
+		current, err := d.client.GetHosts(ctx, pr.sld, pr.tld)
+		if err != nil {
+			return err
+		}
+
+		if diff := diffRecords(snapshot, current); len(diff) > 0 {
+			log.Infof("namecheap: the host records of %s changed during the update (%s), retrying", pr.domain, formatRecords(diff))
+
+			snapshot = current
+
+			continue
+		}
+
+		if d.config.Debug {
+			for _, h := range newRecords {
+				log.Printf("%-5.5s %-30.30s %-6s %-70.70s", h.Type, h.Name, h.TTL, h.Address)
+			}
+		}
+
+		err = d.client.SetHosts(ctx, pr.sld, pr.tld, newRecords)
+		if err != nil {
+			return err
+		}
+
+		after, err := d.client.GetHosts(ctx, pr.sld, pr.tld)
+		if err != nil {
+			return fmt.Errorf("verify host records: %w", err)
+		}
+
+		// The records of the update, and the records of the snapshot preserved by the update.
+		missing := missingRecords(newRecords, after)
+		if len(missing) == 0 {
+			return nil
+		}
+
+		log.Warnf("namecheap: host records lost during the update of %s (%s), rolling back and retrying", pr.domain, formatRecords(missing))
+
+		rollback := rollbackRecords(snapshot, newRecords, after)
+
+		err = d.client.SetHosts(ctx, pr.sld, pr.tld, rollback)
+		if err != nil {
+			return fmt.Errorf("host records lost during the update of %s: %s: rollback: %w", pr.domain, formatRecords(missing), err)
+		}
+
+		// The next attempt is computed from the records restored by the rollback.
+		snapshot = rollback
 	}
 
-	return nil
+	return fmt.Errorf("the host records of %s changed during the update, aborted after %d attempts", pr.domain, maxUpdateAttempts)
 }
 
 // A pseudoRecord represents all the data needed to specify a dns-01 challenge to lets-encrypt.
@@ -232,6 +311,12 @@ type pseudoRecord struct {
 	host     string
 }
 
+// matches returns true if the host record is the challenge record.
+// The names of the hosts are case-insensitive, and the TXT values can be returned quoted.
+func (pr *pseudoRecord) matches(r internal.Record) bool {
+	return r.Type == "TXT" && strings.EqualFold(r.Name, pr.key) && strings.Trim(r.Address, `"`) == pr.keyValue
+}
+
 // newPseudoRecord builds a challenge record from a domain name and a challenge authentication key.
 func newPseudoRecord(domain, keyAuth string) (*pseudoRecord, error) {
 	domain = dns01.UnFqdn(domain)
@@ -262,3 +347,52 @@ func newPseudoRecord(domain, keyAuth string) (*pseudoRecord, error) {
 		host:     host,
 	}, nil
 }
+
+// recordKey identifies a host record.
+func recordKey(r internal.Record) string {
+	return strings.Join([]string{r.Type, r.Name, r.Address, r.MXPref}, "|")
+}
+
+// diffRecords returns the records that are only present in one of the lists.
+func diffRecords(a, b []internal.Record) []internal.Record {
+	diff := missingRecords(a, b)
+
+	return append(diff, missingRecords(b, a)...)
+}
+
+// missingRecords returns the records of the expected list that are missing in the actual list.
+func missingRecords(expected, actual []internal.Record) []internal.Record {
+	keys := make(map[string]struct{}, len(actual))
+	for _, r := range actual {
+		keys[recordKey(r)] = struct{}{}
+	}
+
+	var missing []internal.Record
+
+	for _, r := range expected {
+		if _, ok := keys[recordKey(r)]; !ok {
+			missing = append(missing, r)
+		}
+	}
+
+	return missing
+}
+
+// rollbackRecords returns the host records without the update:
+// the current records, without the records added by the update, and with the original records lost or removed by the update.
+func rollbackRecords(original, updated, current []internal.Record) []internal.Record {
+	added := missingRecords(updated, original)
+
+	rollback := missingRecords(current, added)
+
+	return append(rollback, missingRecords(original, rollback)...)
+}
+
+func formatRecords(records []internal.Record) string {
+	var parts []string
+	for _, r := range records {
+		parts = append(parts, fmt.Sprintf("%s %s %s", r.Type, r.Name, r.Address))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -1,10 +1,23 @@
 package namecheap
 
 import (
+	"bytes"
+	"fmt"
+	"html"
+	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
+	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers/dns/namecheap/internal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -49,9 +62,29 @@ func TestDNSProvider_Present(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			ch, _ := newPseudoRecord(test.domain, "")
 
+			challenge, _ := newPseudoRecord(test.domain, "dummyKey")
+
+			var written atomic.Bool
+
 			provider := mockBuilder().
 				Route("GET /",
-					servermock.ResponseFromInternal(test.getHostsResponse),
+					http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+						if !written.Load() {
+							servermock.ResponseFromInternal(test.getHostsResponse).ServeHTTP(rw, req)
+							return
+						}
+
+						// The host records after the write contain the challenge record.
+						data, err := os.ReadFile(filepath.Join("internal", "fixtures", test.getHostsResponse))
+						if err != nil {
+							http.Error(rw, err.Error(), http.StatusInternalServerError)
+							return
+						}
+
+						host := fmt.Sprintf(`<host Name=%q Type="TXT" Address=%q MXPref="10" TTL="120" />`, challenge.key, challenge.keyValue)
+
+						_, _ = rw.Write(bytes.Replace(data, []byte("</DomainDNSGetHostsResult>"), []byte(host+"</DomainDNSGetHostsResult>"), 1))
+					}),
 					servermock.CheckForm().Strict().
 						With("ClientIp", "10.0.0.1").
 						With("Command", "namecheap.domains.dns.getHosts").
@@ -62,7 +95,11 @@ func TestDNSProvider_Present(t *testing.T) {
 						With("ApiUser", "foo"),
 				).
 				Route("POST /",
-					servermock.ResponseFromInternal(test.setHostsResponse),
+					http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+						written.Store(true)
+
+						servermock.ResponseFromInternal(test.setHostsResponse).ServeHTTP(rw, req)
+					}),
 					servermock.CheckForm().
 						With("ClientIp", "10.0.0.1").
 						With("Command", "namecheap.domains.dns.setHosts").
@@ -124,6 +161,217 @@ func TestDNSProvider_CleanUp(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_Present_hostsChanged(t *testing.T) {
+	var reads, writes int
+
+	provider := mockBuilder().
+		Route("GET /", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			reads++
+
+			// The host records change between each read.
+			fixture := "getHosts_success1.xml"
+			if reads%2 == 0 {
+				fixture = "getHosts_success2.xml"
+			}
+
+			servermock.ResponseFromInternal(fixture).ServeHTTP(rw, req)
+		})).
+		Route("POST /", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			writes++
+
+			servermock.ResponseFromInternal("setHosts_success1.xml").ServeHTTP(rw, req)
+		})).
+		Build(t)
+
+	err := provider.Present("test.example.com", "", "dummyKey")
+	require.EqualError(t, err, "namecheap: the host records of test.example.com changed during the update, aborted after 3 attempts")
+
+	// The snapshot, and a read before the write by attempt.
+	assert.Equal(t, 1+maxUpdateAttempts, reads)
+	// The host records are never written.
+	assert.Zero(t, writes)
+}
+
+func TestDNSProvider_Present_recordAddedBeforeWrite(t *testing.T) {
+	hosts := newFakeHosts(internal.Record{Type: "A", Name: "www", Address: "10.0.0.2", MXPref: "10", TTL: "1200"})
+
+	thirdParty := internal.Record{Type: "A", Name: "home", Address: "10.0.0.1", MXPref: "10", TTL: "1799"}
+
+	// A third party adds a record between the snapshot and the write of the provider.
+	hosts.onGet = func(read int, records []internal.Record) []internal.Record {
+		if read == 2 {
+			return append(records, thirdParty)
+		}
+
+		return records
+	}
+
+	provider := hosts.build(t)
+
+	err := provider.Present("test.example.com", "", "dummyKey")
+	require.NoError(t, err)
+
+	ch, err := newPseudoRecord("test.example.com", "dummyKey")
+	require.NoError(t, err)
+
+	challenge := internal.Record{Type: "TXT", Name: ch.key, Address: ch.keyValue, MXPref: "10", TTL: "120"}
+
+	// The update is computed from the records with the record of the third party: the record is not overwritten.
+	expected := [][]internal.Record{
+		{hosts.initial[0], thirdParty, challenge},
+	}
+
+	assert.Equal(t, expected, hosts.writes)
+	assert.Equal(t, expected[0], hosts.records)
+}
+
+func TestDNSProvider_Present_concurrentWrite(t *testing.T) {
+	hosts := newFakeHosts(internal.Record{Type: "A", Name: "www", Address: "10.0.0.2", MXPref: "10", TTL: "1200"})
+
+	thirdParty := internal.Record{Type: "A", Name: "home", Address: "10.0.0.1", MXPref: "10", TTL: "1799"}
+
+	// A third party writes the host records at the same time as the first write of the provider.
+	hosts.onSet = func(write int, records []internal.Record) []internal.Record {
+		if write == 1 {
+			return append(slices.Clone(hosts.initial), thirdParty)
+		}
+
+		return records
+	}
+
+	provider := hosts.build(t)
+
+	err := provider.Present("test.example.com", "", "dummyKey")
+	require.NoError(t, err)
+
+	ch, err := newPseudoRecord("test.example.com", "dummyKey")
+	require.NoError(t, err)
+
+	challenge := internal.Record{Type: "TXT", Name: ch.key, Address: ch.keyValue, MXPref: "10", TTL: "120"}
+
+	expected := [][]internal.Record{
+		// The update.
+		{hosts.initial[0], challenge},
+		// The rollback: the records of the third party are kept, the record of the update is removed.
+		{hosts.initial[0], thirdParty},
+		// The retry.
+		{hosts.initial[0], thirdParty, challenge},
+	}
+
+	assert.Equal(t, expected, hosts.writes)
+	assert.Equal(t, expected[2], hosts.records)
+}
+
+func TestDNSProvider_CleanUp_concurrentWrite(t *testing.T) {
+	ch, err := newPseudoRecord("test.example.com", "dummyKey")
+	require.NoError(t, err)
+
+	www := internal.Record{Type: "A", Name: "www", Address: "10.0.0.2", MXPref: "10", TTL: "1200"}
+
+	// The API can return the names in another case and the TXT values quoted.
+	challenge := internal.Record{Type: "TXT", Name: strings.ToUpper(ch.key), Address: strconv.Quote(ch.keyValue), MXPref: "10", TTL: "120"}
+
+	hosts := newFakeHosts(www, challenge)
+
+	thirdParty := internal.Record{Type: "A", Name: "home", Address: "10.0.0.1", MXPref: "10", TTL: "1799"}
+
+	// A third party writes the host records (without the record www) at the same time as the first write of the provider.
+	hosts.onSet = func(write int, records []internal.Record) []internal.Record {
+		if write == 1 {
+			return []internal.Record{challenge, thirdParty}
+		}
+
+		return records
+	}
+
+	provider := hosts.build(t)
+
+	err = provider.CleanUp("test.example.com", "", "dummyKey")
+	require.NoError(t, err)
+
+	expected := [][]internal.Record{
+		// The update.
+		{www},
+		// The rollback: the lost record and the removed record are restored.
+		{challenge, thirdParty, www},
+		// The retry.
+		{thirdParty, www},
+	}
+
+	assert.Equal(t, expected, hosts.writes)
+	assert.Equal(t, expected[2], hosts.records)
+}
+
+func TestDNSProvider_Present_concurrentWrite_aborted(t *testing.T) {
+	hosts := newFakeHosts(internal.Record{Type: "A", Name: "www", Address: "10.0.0.2", MXPref: "10", TTL: "1200"})
+
+	// A third party always overwrites the records of the provider.
+	hosts.onSet = func(write int, records []internal.Record) []internal.Record {
+		if write%2 == 1 {
+			return []internal.Record{{Type: "A", Name: "home", Address: "10.0.0." + strconv.Itoa(write), MXPref: "10", TTL: "1799"}}
+		}
+
+		return records
+	}
+
+	provider := hosts.build(t)
+
+	err := provider.Present("test.example.com", "", "dummyKey")
+	require.EqualError(t, err, "namecheap: the host records of test.example.com changed during the update, aborted after 3 attempts")
+
+	assert.Len(t, hosts.writes, 2*maxUpdateAttempts)
+}
+
+func TestDNSProvider_Present_rollbackError(t *testing.T) {
+	var calls int
+
+	provider := mockBuilder().
+		Route("GET /", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			calls++
+
+			// The host records after the write don't contain the initial records.
+			fixture := "getHosts_success1.xml"
+			if calls > 2 {
+				fixture = "getHosts_success2.xml"
+			}
+
+			servermock.ResponseFromInternal(fixture).ServeHTTP(rw, req)
+		})).
+		Route("POST /", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			// The rollback fails.
+			fixture := "setHosts_success1.xml"
+			if calls > 2 {
+				fixture = "setHosts_errorBadAPIKey1.xml"
+			}
+
+			servermock.ResponseFromInternal(fixture).ServeHTTP(rw, req)
+		})).
+		Build(t)
+
+	err := provider.Present("test.example.com", "", "dummyKey")
+	require.ErrorContains(t, err, "namecheap: host records lost during the update of test.example.com: ")
+	require.ErrorContains(t, err, ": rollback: API Key is invalid or API access has not been enabled [1011102]")
+}
+
+func TestNewDNSProviderConfig_sandbox(t *testing.T) {
+	config := NewDefaultConfig()
+	config.APIUser = envTestUser
+	config.APIKey = envTestKey
+	config.ClientIP = envTestClientIP
+	config.Sandbox = true
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, internal.SandboxBaseURL, provider.client.BaseURL)
+}
+
+func TestNewDefaultConfig_baseURL(t *testing.T) {
+	assert.Equal(t, internal.DefaultBaseURL, NewDefaultConfigFrom(env.FromMap(nil)).BaseURL)
+
+	assert.Equal(t, internal.SandboxBaseURL, NewDefaultConfigFrom(env.FromMap(map[string]string{EnvSandbox: "true"})).BaseURL)
+}
+
 func Test_newPseudoRecord_domainSplit(t *testing.T) {
 	tests := []struct {
 		domain string
@@ -187,3 +435,92 @@ func mockBuilder() *servermock.Builder[*DNSProvider] {
 		return NewDNSProviderConfig(config)
 	})
 }
+
+// fakeHosts simulates the host records of the API.
+type fakeHosts struct {
+	mu sync.Mutex
+
+	initial []internal.Record
+	records []internal.Record
+	writes  [][]internal.Record
+
+	reads int
+
+	// onGet returns the records stored before the read (ex: to simulate the write of a third party).
+	onGet func(read int, records []internal.Record) []internal.Record
+
+	// onSet returns the records stored after the write (ex: to simulate the write of a third party).
+	onSet func(write int, records []internal.Record) []internal.Record
+}
+
+func newFakeHosts(records ...internal.Record) *fakeHosts {
+	return &fakeHosts{initial: records, records: slices.Clone(records)}
+}
+
+func (f *fakeHosts) build(t *testing.T) *DNSProvider {
+	t.Helper()
+
+	return mockBuilder().
+		Route("GET /", http.HandlerFunc(f.getHosts)).
+		Route("POST /", http.HandlerFunc(f.setHosts)).
+		Build(t)
+}
+
+func (f *fakeHosts) getHosts(rw http.ResponseWriter, _ *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.reads++
+
+	if f.onGet != nil {
+		f.records = f.onGet(f.reads, f.records)
+	}
+
+	var hosts strings.Builder
+
+	for _, r := range f.records {
+		_, _ = fmt.Fprintf(&hosts, `<host Name=%q Type=%q Address=%q MXPref=%q TTL=%q />`, r.Name, r.Type, html.EscapeString(r.Address), r.MXPref, r.TTL)
+	}
+
+	_, _ = fmt.Fprintf(rw, `<?xml version="1.0" encoding="utf-8"?>
+<ApiResponse Status="OK" xmlns="http://api.namecheap.com/xml.response">
+    <Errors />
+    <CommandResponse Type="namecheap.domains.dns.getHosts">
+        <DomainDNSGetHostsResult Domain="example.com">%s</DomainDNSGetHostsResult>
+    </CommandResponse>
+</ApiResponse>`, hosts.String())
+}
+
+func (f *fakeHosts) setHosts(rw http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	err := req.ParseForm()
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var records []internal.Record
+
+	for i := 1; req.PostForm.Has("HostName" + strconv.Itoa(i)); i++ {
+		ind := strconv.Itoa(i)
+
+		records = append(records, internal.Record{
+			Type:    req.PostForm.Get("RecordType" + ind),
+			Name:    req.PostForm.Get("HostName" + ind),
+			Address: req.PostForm.Get("Address" + ind),
+			MXPref:  req.PostForm.Get("MXPref" + ind),
+			TTL:     req.PostForm.Get("TTL" + ind),
+		})
+	}
+
+	f.writes = append(f.writes, records)
+
+	f.records = records
+	if f.onSet != nil {
+		f.records = f.onSet(len(f.writes), records)
+	}
+
+	servermock.ResponseFromInternal("setHosts_success1.xml").ServeHTTP(rw, req)
+}
@@ -4,7 +4,6 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
-	"sync"
 
 	"github.com/go-acme/lego/v4/platform/config/env"
 	"golang.org/x/net/http/httpproxy"
@@ -20,12 +19,6 @@ const (
 	envRequestMethod   = "REQUEST_METHOD"
 )
 
-// Allows lazy loading of the proxy.
-var (
-	envProxyOnce      sync.Once
-	envProxyFuncValue func(*url.URL) (*url.URL, error)
-)
-
 func defaultTransport(namespace string) http.RoundTripper {
 	tr, ok := http.DefaultTransport.(*http.Transport)
 	if !ok {
@@ -38,30 +31,24 @@ func defaultTransport(namespace string) http.RoundTripper {
 	return clone
 }
 
+// proxyFromEnvironment reads the proxy configuration when the transport is created,
+// the environment variables are not read when the requests are sent.
+//
 // Inspired by:
 // - https://pkg.go.dev/net/http#ProxyFromEnvironment
 // - https://pkg.go.dev/golang.org/x/net/http/httpproxy#FromEnvironment
-func envProxyFunc(namespace string) func(*url.URL) (*url.URL, error) {
-	envProxyOnce.Do(func() {
-		cfg := &httpproxy.Config{
-			HTTPProxy:  getEnv(namespace, envHTTPProxy, envHTTPProxyLower),
-			HTTPSProxy: getEnv(namespace, envHTTPSProxy, envHTTPSProxyLower),
-			NoProxy:    getEnv(namespace, envNoProxy, envNoProxyLower),
-			CGI:        env.GetOneWithFallback(namespace+envRequestMethod, "", env.ParseString, envRequestMethod) != "",
-		}
-
-		envProxyFuncValue = cfg.ProxyFunc()
-	})
+func proxyFromEnvironment(namespace string) func(req *http.Request) (*url.URL, error) {
+	cfg := &httpproxy.Config{
+		HTTPProxy:  getEnv(namespace, envHTTPProxy, envHTTPProxyLower),
+		HTTPSProxy: getEnv(namespace, envHTTPSProxy, envHTTPSProxyLower),
+		NoProxy:    getEnv(namespace, envNoProxy, envNoProxyLower),
+		CGI:        env.GetOneWithFallback(namespace+envRequestMethod, "", env.ParseString, envRequestMethod) != "",
+	}
 
-	return envProxyFuncValue
-}
+	proxyFunc := cfg.ProxyFunc()
 
-// Inspired by:
-// - https://pkg.go.dev/net/http#ProxyFromEnvironment
-// - https://pkg.go.dev/golang.org/x/net/http/httpproxy#FromEnvironment
-func proxyFromEnvironment(namespace string) func(req *http.Request) (*url.URL, error) {
 	return func(req *http.Request) (*url.URL, error) {
-		return envProxyFunc(namespace)(req.URL)
+		return proxyFunc(req.URL)
 	}
 }
 
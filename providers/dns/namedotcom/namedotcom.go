@@ -4,7 +4,6 @@ package namedotcom
 import (
 	"errors"
 	"fmt"
-	"net/http"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge"
@@ -14,71 +13,17 @@ import (
 	"github.com/namedotcom/go/v4/namecom"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "NAMECOM_"
-
-	EnvUsername = envNamespace + "USERNAME"
-	EnvAPIToken = envNamespace + "API_TOKEN"
-	EnvServer   = envNamespace + "SERVER"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-)
-
 // according to https://www.name.com/api-docs/DNS#CreateRecord
 const minTTL = 300
 
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	Username           string
-	APIToken           string
-	Server             string
-	TTL                int
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 15*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 20*time.Second),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	client *namecom.NameCom
 	config *Config
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for namedotcom.
-// Credentials must be passed in the environment variables:
-// NAMECOM_USERNAME and NAMECOM_API_TOKEN.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvUsername, EnvAPIToken)
-	if err != nil {
-		return nil, fmt.Errorf("namedotcom: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.Username = values[EnvUsername]
-	config.APIToken = values[EnvAPIToken]
-	config.Server = env.GetOrFile(EnvServer)
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for namedotcom.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -93,8 +38,14 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("namedotcom: API token is required")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("namedotcom: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL, 0)
+	if err != nil {
+		return nil, fmt.Errorf("namedotcom: %w", err)
+	}
+
+	err = env.ValidatePropagation(config.PropagationTimeout, config.PollingInterval)
+	if err != nil {
+		return nil, fmt.Errorf("namedotcom: %w", err)
 	}
 
 	client := namecom.New(config.Username, config.APIToken)
@@ -79,36 +79,49 @@ func TestNewDNSProvider(t *testing.T) {
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc     string
+		ttl      int
 		apiToken string
 		username string
 		expected string
 	}{
 		{
 			desc:     "success",
+			ttl:      minTTL,
 			apiToken: "A",
 			username: "B",
 		},
 		{
 			desc:     "missing credentials",
+			ttl:      minTTL,
 			expected: "namedotcom: username is required",
 		},
 		{
 			desc:     "missing API token",
+			ttl:      minTTL,
 			apiToken: "",
 			username: "B",
 			expected: "namedotcom: API token is required",
 		},
 		{
 			desc:     "missing username",
+			ttl:      minTTL,
 			apiToken: "A",
 			username: "",
 			expected: "namedotcom: username is required",
 		},
+		{
+			desc:     "invalid TTL",
+			ttl:      299,
+			apiToken: "A",
+			username: "B",
+			expected: "namedotcom: invalid TTL, TTL (299) must be greater than 300",
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
+			config.TTL = test.ttl
 			config.Username = test.username
 			config.APIToken = test.apiToken
 
@@ -17,6 +17,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -192,7 +193,7 @@ func (d *Client) doRequest(ctx context.Context, method string, params []any, res
 	}
 
 	if resp.StatusCode/100 != 2 {
-		return errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw)
+		return providers.NewAPIError(req, resp, errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw))
 	}
 
 	var rpcResp APIResponse
@@ -3,61 +3,17 @@ package namesurfer
 
 import (
 	"context"
-	"crypto/tls"
 	"errors"
 	"fmt"
-	"net/http"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/namesurfer/internal"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "NAMESURFER_"
-
-	EnvBaseURL   = envNamespace + "BASE_URL"
-	EnvAPIKey    = envNamespace + "API_KEY"
-	EnvAPISecret = envNamespace + "API_SECRET"
-	EnvView      = envNamespace + "VIEW"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-	EnvInsecureSkipVerify = envNamespace + "INSECURE_SKIP_VERIFY"
-)
-
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	BaseURL   string
-	APIKey    string
-	APISecret string
-	View      string
-
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, 300),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
@@ -67,28 +23,6 @@ type DNSProvider struct {
 	zonesMu sync.Mutex
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for FusionLayer NameSurfer.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvBaseURL, EnvAPIKey, EnvAPISecret)
-	if err != nil {
-		return nil, fmt.Errorf("namesurfer: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.BaseURL = values[EnvBaseURL]
-	config.APIKey = values[EnvAPIKey]
-	config.APISecret = values[EnvAPISecret]
-	config.View = env.GetOrDefaultString(EnvView, "")
-
-	if env.GetOrDefaultBool(EnvInsecureSkipVerify, false) {
-		config.HTTPClient.Transport = &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-	}
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for FusionLayer NameSurfer.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
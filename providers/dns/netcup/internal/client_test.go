@@ -167,7 +167,7 @@ func TestClient_GetDNSRecords_errors(t *testing.T) {
 		{
 			desc:     "HTTP error",
 			handler:  servermock.Noop().WithStatusCode(http.StatusInternalServerError),
-			expected: `error when sending the request: unexpected status code: [status code: 500] body: `,
+			expected: `error when sending the request: unexpected status code: [status code: 500] body:  [endpoint: POST /]`,
 		},
 		{
 			desc:     "API error",
@@ -37,7 +37,7 @@ func TestClient_Login_errors(t *testing.T) {
 		{
 			desc:     "HTTP error",
 			handler:  servermock.Noop().WithStatusCode(http.StatusInternalServerError),
-			expected: `loging error: unexpected status code: [status code: 500] body: `,
+			expected: `loging error: unexpected status code: [status code: 500] body:  [endpoint: POST /]`,
 		},
 		{
 			desc:     "API error",
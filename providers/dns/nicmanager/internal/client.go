@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	"github.com/pquerna/otp/totp"
 )
@@ -144,7 +145,7 @@ func (c *Client) do(req *http.Request, expectedStatusCode int, result any) error
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != expectedStatusCode {
-		return parseError(req, resp)
+		return providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	if result == nil {
@@ -66,7 +66,7 @@ func TestClient_GetZone_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetZone(t.Context(), "foo")
-	require.EqualError(t, err, "404: Not Found")
+	require.EqualError(t, err, "404: Not Found [endpoint: GET /anycast/foo]")
 }
 
 func TestClient_AddRecord(t *testing.T) {
@@ -102,7 +102,7 @@ func TestClient_AddRecord_error(t *testing.T) {
 	}
 
 	err := client.AddRecord(t.Context(), "zonedomain.tld", record)
-	require.EqualError(t, err, "401: Not Found")
+	require.EqualError(t, err, "401: Not Found [endpoint: POST /anycast/zonedomain.tld/records]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -124,5 +124,5 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteRecord(t.Context(), "zonedomain.tld", 6)
-	require.EqualError(t, err, "404: Not Found")
+	require.EqualError(t, err, "404: Not Found [endpoint: DELETE /anycast/zonedomain.tld/records/6]")
 }
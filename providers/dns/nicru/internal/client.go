@@ -10,6 +10,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -214,7 +215,8 @@ func (c *Client) do(req *http.Request) (*Response, error) {
 	}
 
 	if apiResponse.Status != successStatus {
-		return nil, fmt.Errorf("[status code=%d] %s: %w", resp.StatusCode, apiResponse.Status, apiResponse.Errors.Error)
+		return nil, providers.NewAPIError(req, resp,
+			fmt.Errorf("[status code=%d] %s: %w", resp.StatusCode, apiResponse.Status, apiResponse.Errors.Error))
 	}
 
 	return apiResponse, nil
@@ -1,11 +1,13 @@
 package internal
 
 import (
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
 
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -120,6 +122,12 @@ func TestClient_ListZones_error(t *testing.T) {
 		Text: "Access token expired or not found",
 		Code: "4097",
 	})
+
+	var apiErr *providers.APIError
+	require.ErrorAs(t, err, &apiErr)
+
+	assert.Equal(t, http.StatusOK, apiErr.StatusCode)
+	assert.Contains(t, err.Error(), "[endpoint: GET /zones]")
 }
 
 func TestClient_GetZonesByService(t *testing.T) {
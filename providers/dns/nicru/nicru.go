@@ -10,69 +10,18 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/nicru/internal"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "NICRU_"
-
-	EnvUsername  = envNamespace + "USER"
-	EnvPassword  = envNamespace + "PASSWORD"
-	EnvServiceID = envNamespace + "SERVICE_ID"
-	EnvSecret    = envNamespace + "SECRET"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-)
-
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	TTL                int
-	Username           string
-	Password           string
-	ServiceID          string
-	Secret             string
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, 30),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 10*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 1*time.Minute),
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	client *internal.Client
 	config *Config
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for RU Center.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvUsername, EnvPassword, EnvServiceID, EnvSecret)
-	if err != nil {
-		return nil, fmt.Errorf("nicru: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.Username = values[EnvUsername]
-	config.Password = values[EnvPassword]
-	config.ServiceID = values[EnvServiceID]
-	config.Secret = values[EnvSecret]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for RU Center.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
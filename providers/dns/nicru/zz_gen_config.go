@@ -0,0 +1,67 @@
+// Code generated by 'make generate-dns'; DO NOT EDIT.
+
+package nicru
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-acme/lego/v4/platform/config/env"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "NICRU_"
+
+	EnvUsername  = envNamespace + "USER"
+	EnvPassword  = envNamespace + "PASSWORD"
+	EnvServiceID = envNamespace + "SERVICE_ID"
+	EnvSecret    = envNamespace + "SECRET"
+
+	EnvTTL                = envNamespace + "TTL"
+	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
+)
+
+// Config is used to configure the creation of the DNSProvider.
+type Config struct {
+	Username           string
+	Password           string
+	ServiceID          string
+	Secret             string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
+	return &Config{
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, 30),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 10*time.Minute),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, 1*time.Minute),
+	}
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for RU CENTER.
+// Credentials must be passed in the environment variables:
+// NICRU_USER, NICRU_PASSWORD, NICRU_SERVICE_ID, NICRU_SECRET.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get(EnvUsername, EnvPassword, EnvServiceID, EnvSecret)
+	if err != nil {
+		return nil, fmt.Errorf("nicru: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Username = values[EnvUsername]
+	config.Password = values[EnvPassword]
+	config.ServiceID = values[EnvServiceID]
+	config.Secret = values[EnvSecret]
+
+	return NewDNSProviderConfig(config)
+}
@@ -72,7 +72,7 @@ func TestClient_ChangeResourceRecordSets_errors(t *testing.T) {
 </ErrorResponse>
 `,
 			statusCode: http.StatusUnauthorized,
-			expected:   "Sender(AuthFailed): The request signature we calculated does not match the signature you provided.",
+			expected:   "Sender(AuthFailed): The request signature we calculated does not match the signature you provided. [endpoint: POST /2012-12-12N2013-12-16/hostedzone/example.com/rrset]",
 		},
 		{
 			desc:         "response body error",
@@ -84,7 +84,7 @@ func TestClient_ChangeResourceRecordSets_errors(t *testing.T) {
 			desc:         "error message error",
 			responseBody: "foo",
 			statusCode:   http.StatusInternalServerError,
-			expected:     "unexpected status code: [status code: 500] body: foo",
+			expected:     "unexpected status code: [status code: 500] body: foo [endpoint: POST /2012-12-12N2013-12-16/hostedzone/example.com/rrset]",
 		},
 	}
 
@@ -147,7 +147,7 @@ func TestClient_GetChange_errors(t *testing.T) {
 </ErrorResponse>
 `,
 			statusCode: http.StatusUnauthorized,
-			expected:   "Sender(AuthFailed): The request signature we calculated does not match the signature you provided.",
+			expected:   "Sender(AuthFailed): The request signature we calculated does not match the signature you provided. [endpoint: GET /2012-12-12N2013-12-16/change/12345]",
 		},
 		{
 			desc:         "response body error",
@@ -159,7 +159,7 @@ func TestClient_GetChange_errors(t *testing.T) {
 			desc:         "error message error",
 			responseBody: "foo",
 			statusCode:   http.StatusInternalServerError,
-			expected:     "unexpected status code: [status code: 500] body: foo",
+			expected:     "unexpected status code: [status code: 500] body: foo [endpoint: GET /2012-12-12N2013-12-16/change/12345]",
 		},
 	}
 
@@ -12,7 +12,7 @@ import (
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
-const apiEndpoint = "https://njal.la/api/1/"
+const defaultBaseURL = "https://njal.la/api/1/"
 
 const authorizationHeader = "Authorization"
 
@@ -20,16 +20,16 @@ const authorizationHeader = "Authorization"
 type Client struct {
 	token string
 
-	apiEndpoint string
-	HTTPClient  *http.Client
+	BaseURL    string
+	HTTPClient *http.Client
 }
 
 // NewClient creates a new Client.
 func NewClient(token string) *Client {
 	return &Client{
-		token:       token,
-		apiEndpoint: apiEndpoint,
-		HTTPClient:  &http.Client{Timeout: 5 * time.Second},
+		token:      token,
+		BaseURL:    defaultBaseURL,
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
 	}
 }
 
@@ -40,7 +40,7 @@ func (c *Client) AddRecord(ctx context.Context, record Record) (*Record, error)
 		Params: record,
 	}
 
-	req, err := newJSONRequest(ctx, http.MethodPost, c.apiEndpoint, data)
+	req, err := newJSONRequest(ctx, http.MethodPost, c.BaseURL, data)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +65,7 @@ func (c *Client) RemoveRecord(ctx context.Context, id, domain string) error {
 		},
 	}
 
-	req, err := newJSONRequest(ctx, http.MethodPost, c.apiEndpoint, data)
+	req, err := newJSONRequest(ctx, http.MethodPost, c.BaseURL, data)
 	if err != nil {
 		return err
 	}
@@ -87,7 +87,7 @@ func (c *Client) ListRecords(ctx context.Context, domain string) ([]Record, erro
 		},
 	}
 
-	req, err := newJSONRequest(ctx, http.MethodPost, c.apiEndpoint, data)
+	req, err := newJSONRequest(ctx, http.MethodPost, c.BaseURL, data)
 	if err != nil {
 		return nil, err
 	}
@@ -11,7 +11,7 @@ import (
 
 func setupClient(server *httptest.Server) (*Client, error) {
 	client := NewClient("secret")
-	client.apiEndpoint = server.URL
+	client.BaseURL = server.URL
 	client.HTTPClient = server.Client()
 
 	return client, nil
@@ -1,10 +1,17 @@
 package njalla
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers/dns/njalla/internal"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -90,6 +97,65 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_CleanUp_notFoundAfterCreate(t *testing.T) {
+	var removeCalls atomic.Int32
+
+	provider := servermock.NewBuilder(
+		func(server *httptest.Server) (*DNSProvider, error) {
+			config := NewDefaultConfig()
+			config.Token = "secret"
+			config.HTTPClient = server.Client()
+
+			p, err := NewDNSProviderConfig(config)
+			if err != nil {
+				return nil, err
+			}
+
+			p.client.BaseURL = server.URL
+
+			return p, nil
+		},
+		servermock.CheckHeader().WithJSONHeaders().
+			WithAuthorization("Njalla secret"),
+	).
+		Route("POST /",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				var data internal.APIRequest
+
+				err := json.NewDecoder(req.Body).Decode(&data)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				switch data.Method {
+				case "add-record":
+					_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":{"id":"123"}}`))
+
+				case "remove-record":
+					// The record is unknown during a short period after its creation.
+					if removeCalls.Add(1) == 1 {
+						_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","error":{"code":404,"message":"record not found"}}`))
+						return
+					}
+
+					_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":{}}`))
+
+				default:
+					http.Error(rw, "unexpected method: "+data.Method, http.StatusBadRequest)
+				}
+			})).
+		Build(t)
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), removeCalls.Load())
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -57,7 +57,7 @@ func TestClient_ListDomains_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.ListDomains(t.Context(), "example.com")
-	require.EqualError(t, err, "unexpected status code: [status code: 400] body: ")
+	require.EqualError(t, err, "unexpected status code: [status code: 400] body:  [endpoint: GET /domains]")
 }
 
 func TestClient_ListDomains_api_error(t *testing.T) {
@@ -100,7 +100,7 @@ func TestClient_ListDNSRecords_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.ListDNSRecords(t.Context(), "abc", "TXT")
-	require.EqualError(t, err, "unexpected status code: [status code: 400] body: ")
+	require.EqualError(t, err, "unexpected status code: [status code: 400] body:  [endpoint: POST /domains/dns-records/list]")
 }
 
 func TestClient_ListDNSRecords_api_error(t *testing.T) {
@@ -161,7 +161,7 @@ func TestClient_AddDNSRecord_error(t *testing.T) {
 	}
 
 	_, err := client.AddDNSRecord(t.Context(), "abc", record)
-	require.EqualError(t, err, "unexpected status code: [status code: 400] body: ")
+	require.EqualError(t, err, "unexpected status code: [status code: 400] body:  [endpoint: POST /domains/dns-records/add]")
 }
 
 func TestClient_AddDNSRecord_api_error(t *testing.T) {
@@ -210,7 +210,7 @@ func TestClient_DeleteDNSRecord_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.DeleteDNSRecord(t.Context(), "abc", 123)
-	require.EqualError(t, err, "unexpected status code: [status code: 400] body: ")
+	require.EqualError(t, err, "unexpected status code: [status code: 400] body:  [endpoint: POST /domains/dns-records/delete]")
 }
 
 func TestClient_DeleteDNSRecord_api_error(t *testing.T) {
@@ -0,0 +1,204 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/config/env"
+)
+
+// newDNSProviderWithOptions creates a provider from its default configuration and the options.
+// The default configuration is created without the environment variables: only the options configure the provider.
+func newDNSProviderWithOptions[C any, P challenge.Provider, D func(env.LookupFunc) *C | func(env.LookupFunc) (*C, error)](
+	newDefaultConfig D, newProvider func(*C) (P, error), opts map[string]string,
+) (challenge.Provider, error) {
+	var (
+		config *C
+		err    error
+	)
+
+	switch fn := any(newDefaultConfig).(type) {
+	case func(env.LookupFunc) *C:
+		config = fn(env.FromMap(nil))
+
+	case func(env.LookupFunc) (*C, error):
+		config, err = fn(env.FromMap(nil))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = setOptions(config, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := newProvider(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return provider, nil
+}
+
+// setOptions sets the fields of the configuration from the options.
+//
+// The keys of the options are the names of the fields (case-insensitive),
+// the fields of the nested structs are separated by dots (ex: "HTTPClient.Timeout").
+//
+// The supported types are: string, bool, integers, floats, time.Duration (Go duration or seconds),
+// []string (comma-separated), map[string]string (comma-separated key:value pairs), and *url.URL.
+func setOptions(config any, opts map[string]string) error {
+	root := reflect.ValueOf(config).Elem()
+
+	var errAll error
+
+	for _, key := range slices.Sorted(maps.Keys(opts)) {
+		field, err := findField(root, key)
+		if err != nil {
+			errAll = errors.Join(errAll, fmt.Errorf("option %q: %w", key, err))
+			continue
+		}
+
+		err = setValue(field, opts[key])
+		if err != nil {
+			errAll = errors.Join(errAll, fmt.Errorf("option %q: %w", key, err))
+		}
+	}
+
+	return errAll
+}
+
+func findField(value reflect.Value, key string) (reflect.Value, error) {
+	for name := range strings.SplitSeq(key, ".") {
+		if value.Kind() == reflect.Pointer {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+
+			value = value.Elem()
+		}
+
+		if value.Kind() != reflect.Struct {
+			return reflect.Value{}, errors.New("unknown option")
+		}
+
+		field, ok := value.Type().FieldByNameFunc(func(fieldName string) bool {
+			return strings.EqualFold(fieldName, name)
+		})
+		if !ok || !field.IsExported() {
+			return reflect.Value{}, errors.New("unknown option")
+		}
+
+		value = value.FieldByIndex(field.Index)
+	}
+
+	return value, nil
+}
+
+func setValue(field reflect.Value, raw string) error {
+	switch field.Interface().(type) {
+	case time.Duration:
+		d, err := parseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(int64(d))
+
+		return nil
+
+	case *url.URL:
+		u, err := url.Parse(raw)
+		if err != nil {
+			return err
+		}
+
+		field.Set(reflect.ValueOf(u))
+
+		return nil
+
+	case []string:
+		var values []string
+
+		for value := range strings.SplitSeq(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+
+		field.Set(reflect.ValueOf(values))
+
+		return nil
+
+	case map[string]string:
+		pairs, err := env.ParsePairs(raw)
+		if err != nil {
+			return err
+		}
+
+		field.Set(reflect.ValueOf(pairs))
+
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+
+	case reflect.Bool:
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(v)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(v)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetUint(v)
+
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(v)
+
+	default:
+		return fmt.Errorf("unsupported type: %s", field.Type())
+	}
+
+	return nil
+}
+
+// parseDuration parses a Go duration (ex: "2m30s") or a number of seconds (as the environment variables).
+func parseDuration(raw string) (time.Duration, error) {
+	if d, err := env.ParseSecond(raw); err == nil {
+		return d, nil
+	}
+
+	return time.ParseDuration(raw)
+}
@@ -0,0 +1,212 @@
+package dns
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/cloudflare"
+	"github.com/go-acme/lego/v4/providers/dns/exec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDNSChallengeProviderByNameWithOptions(t *testing.T) {
+	defer envTest.RestoreEnv()
+
+	envTest.Apply(map[string]string{
+		"EXEC_PATH": "from-env",
+	})
+
+	provider, err := NewDNSChallengeProviderByNameWithOptions("exec", map[string]string{
+		"Program":            "/usr/bin/hook",
+		"mode":               "RAW",
+		"PropagationTimeout": "2m",
+		"PollingInterval":    "5",
+	})
+	require.NoError(t, err)
+
+	require.IsType(t, &exec.DNSProvider{}, provider)
+
+	timeout, interval := provider.(*exec.DNSProvider).Timeout()
+
+	assert.Equal(t, 2*time.Minute, timeout)
+	assert.Equal(t, 5*time.Second, interval)
+}
+
+func TestNewDNSChallengeProviderByNameWithOptions_ignoreEnv(t *testing.T) {
+	defer envTest.RestoreEnv()
+
+	envTest.Apply(map[string]string{
+		exec.EnvPropagationTimeout: "600",
+		exec.EnvPollingInterval:    "30",
+	})
+
+	provider, err := NewDNSChallengeProviderByNameWithOptions("exec", map[string]string{
+		"Program": "/usr/bin/hook",
+	})
+	require.NoError(t, err)
+
+	require.IsType(t, &exec.DNSProvider{}, provider)
+
+	// The environment variables of the provider are ignored: the default values are used.
+	timeout, interval := provider.(*exec.DNSProvider).Timeout()
+
+	assert.Equal(t, dns01.DefaultPropagationTimeout, timeout)
+	assert.Equal(t, dns01.DefaultPollingInterval, interval)
+}
+
+func TestNewDNSChallengeProviderByNameWithOptions_concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for _, token := range []string{"a", "b", "c", "d"} {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			provider, err := NewDNSChallengeProviderByNameWithOptions("cloudflare", map[string]string{
+				"AuthToken":          token,
+				"HTTPClient.Timeout": "10s",
+			})
+			assert.NoError(t, err)
+			assert.IsType(t, &cloudflare.DNSProvider{}, provider)
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestNewDNSChallengeProviderByNameWithOptions_processEnv(t *testing.T) {
+	defer envTest.RestoreEnv()
+
+	envTest.Apply(map[string]string{
+		"EXEC_PATH": "from-env",
+	})
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for range 100 {
+			_, err := NewDNSChallengeProviderByNameWithOptions("exec", map[string]string{
+				"Program": "/usr/bin/hook",
+			})
+			assert.NoError(t, err)
+		}
+	}()
+
+	// The environment read by the other goroutines is not changed during the creation of the providers.
+	for {
+		select {
+		case <-done:
+			return
+		default:
+			require.Equal(t, "from-env", env.GetOrFile("EXEC_PATH"))
+		}
+	}
+}
+
+func TestNewDNSChallengeProviderByNameWithOptions_errors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		name     string
+		opts     map[string]string
+		expected string
+	}{
+		{
+			desc:     "unknown provider",
+			name:     "foo",
+			expected: "unrecognized DNS provider: foo",
+		},
+		{
+			desc:     "unknown option",
+			name:     "cloudflare",
+			opts:     map[string]string{"AuthToken": "secret", "Foo": "bar"},
+			expected: `option "Foo": unknown option`,
+		},
+		{
+			desc:     "invalid value",
+			name:     "cloudflare",
+			opts:     map[string]string{"AuthToken": "secret", "TTL": "abc"},
+			expected: `option "TTL": strconv.ParseInt: parsing "abc": invalid syntax`,
+		},
+		{
+			desc:     "provider error",
+			name:     "cloudflare",
+			opts:     map[string]string{},
+			expected: "cloudflare: invalid credentials: authEmail, authKey or authToken must be set",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := NewDNSChallengeProviderByNameWithOptions(test.name, test.opts)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func Test_setOptions(t *testing.T) {
+	type nested struct {
+		Value string
+	}
+
+	type config struct {
+		Name     string
+		Enabled  bool
+		TTL      int
+		Port     uint16
+		Ratio    float64
+		Timeout  time.Duration
+		Endpoint *url.URL
+		Servers  []string
+		Mapping  map[string]string
+		Nested   *nested
+		Client   *http.Client
+		private  string
+	}
+
+	cfg := &config{Client: &http.Client{}}
+
+	err := setOptions(cfg, map[string]string{
+		"name":           "foo",
+		"Enabled":        "true",
+		"TTL":            "120",
+		"Port":           "8053",
+		"Ratio":          "0.5",
+		"Timeout":        "90",
+		"Endpoint":       "https://api.example.com/v1",
+		"Servers":        "a, b,c",
+		"Mapping":        "example.com:1, example.org:2",
+		"Nested.Value":   "bar",
+		"Client.Timeout": "1m30s",
+	})
+	require.NoError(t, err)
+
+	expected := &config{
+		Name:     "foo",
+		Enabled:  true,
+		TTL:      120,
+		Port:     8053,
+		Ratio:    0.5,
+		Timeout:  90 * time.Second,
+		Endpoint: &url.URL{Scheme: "https", Host: "api.example.com", Path: "/v1"},
+		Servers:  []string{"a", "b", "c"},
+		Mapping:  map[string]string{"example.com": "1", "example.org": "2"},
+		Nested:   &nested{Value: "bar"},
+		Client:   &http.Client{Timeout: 90 * time.Second},
+	}
+
+	assert.Equal(t, expected, cfg)
+
+	err = setOptions(cfg, map[string]string{"private": "foo", "Client.Transport": "foo", "Name.Value": "foo"})
+	require.EqualError(t, err, `option "Client.Transport": unsupported type: http.RoundTripper`+"\n"+
+		`option "Name.Value": unknown option`+"\n"+
+		`option "private": unknown option`)
+}
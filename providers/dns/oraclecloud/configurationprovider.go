@@ -16,6 +16,12 @@ import (
 
 type environmentConfigurationProvider struct {
 	values map[string]string
+
+	// The private key is read with the other values,
+	// the error is reported when the key is used.
+	privateKey         []byte
+	privateKeyErr      error
+	privateKeyPassword string
 }
 
 func newEnvironmentConfigurationProvider() (*environmentConfigurationProvider, error) {
@@ -29,18 +35,22 @@ func newEnvironmentConfigurationProvider() (*environmentConfigurationProvider, e
 		return nil, err
 	}
 
+	privateKey, privateKeyErr := getPrivateKey()
+
 	return &environmentConfigurationProvider{
-		values: values,
+		values:             values,
+		privateKey:         privateKey,
+		privateKeyErr:      privateKeyErr,
+		privateKeyPassword: env.GetOneWithFallback(EnvPrivKeyPass, "", env.ParseString, altEnvPrivateKeyPassword, altEnvTFVarPrivateKeyPassword),
 	}, nil
 }
 
 func (p *environmentConfigurationProvider) PrivateRSAKey() (*rsa.PrivateKey, error) {
-	privateKey, err := getPrivateKey()
-	if err != nil {
-		return nil, err
+	if p.privateKeyErr != nil {
+		return nil, p.privateKeyErr
 	}
 
-	return common.PrivateKeyFromBytesWithPassword(privateKey, []byte(p.privateKeyPassword()))
+	return common.PrivateKeyFromBytesWithPassword(p.privateKey, []byte(p.privateKeyPassword))
 }
 
 func (p *environmentConfigurationProvider) KeyID() (string, error) {
@@ -83,10 +93,6 @@ func (p *environmentConfigurationProvider) AuthType() (common.AuthConfig, error)
 	return common.AuthConfig{AuthType: common.UnknownAuthenticationType}, errors.New("unsupported, keep the interface")
 }
 
-func (p *environmentConfigurationProvider) privateKeyPassword() string {
-	return env.GetOneWithFallback(EnvPrivKeyPass, "", env.ParseString, altEnvPrivateKeyPassword, altEnvTFVarPrivateKeyPassword)
-}
-
 func getPrivateKey() ([]byte, error) {
 	base64EnvKeys := []string{envPrivKey, altEnvPrivateKey}
 
@@ -115,7 +121,7 @@ func getPrivateKey() ([]byte, error) {
 
 func getEnvWithStrictFallback(keys ...string) string {
 	for _, key := range keys {
-		envVarValue := os.Getenv(key)
+		envVarValue := env.Getenv(key)
 		if envVarValue != "" {
 			return envVarValue
 		}
@@ -126,7 +132,7 @@ func getEnvWithStrictFallback(keys ...string) string {
 
 func getEnvFileWithStrictFallback(keys ...string) []byte {
 	for _, key := range keys {
-		fileVarValue := os.Getenv(key)
+		fileVarValue := env.Getenv(key)
 		if fileVarValue == "" {
 			continue
 		}
@@ -358,19 +358,21 @@ func TestLiveCleanUp(t *testing.T) {
 }
 
 func mockConfigurationProvider(keyPassphrase string) *environmentConfigurationProvider {
-	envTest.Apply(map[string]string{
-		envPrivKey: mustGeneratePrivateKey("secret"),
-	})
+	block, err := generatePrivateKey("secret")
+	if err != nil {
+		panic(err)
+	}
 
 	return &environmentConfigurationProvider{
 		values: map[string]string{
 			EnvCompartmentOCID:   "test",
-			EnvPrivKeyPass:       keyPassphrase,
 			EnvTenancyOCID:       "test",
 			EnvUserOCID:          "test",
 			EnvPubKeyFingerprint: "test",
 			EnvRegion:            "test",
 		},
+		privateKey:         pem.EncodeToMemory(block),
+		privateKeyPassword: keyPassphrase,
 	}
 }
 
@@ -23,6 +23,7 @@ type Client struct {
 
 	IdentityEndpoint string
 	token            string
+	tokenDeadline    time.Time
 	muToken          sync.Mutex
 
 	baseURL   *url.URL
@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
@@ -14,9 +15,22 @@ import (
 // DefaultIdentityEndpoint the default API identity endpoint.
 const DefaultIdentityEndpoint = "https://iam.eu-de.otc.t-systems.com:443/v3/auth/tokens"
 
+// tokenExpirationMargin the token is renewed when it expires in less than this duration
+// (duration of the requests, clock skew).
+const tokenExpirationMargin = 5 * time.Minute
+
 // Login Starts a new OTC API Session. Authenticates using userName, password
 // and receives a token to be used in for subsequent requests.
+// The token is reused until it is about to expire.
 func (c *Client) Login(ctx context.Context) error {
+	c.muToken.Lock()
+	valid := c.token != "" && time.Now().Add(tokenExpirationMargin).Before(c.tokenDeadline)
+	c.muToken.Unlock()
+
+	if valid {
+		return nil
+	}
+
 	payload := LoginRequest{
 		Auth: Auth{
 			Identity: Identity{
@@ -48,6 +62,7 @@ func (c *Client) Login(ctx context.Context) error {
 	defer c.muToken.Unlock()
 
 	c.token = token
+	c.tokenDeadline = getTokenDeadline(tokenResp.Token, time.Now())
 
 	if c.token == "" {
 		return errors.New("unable to get auth token")
@@ -106,6 +121,24 @@ func (c *Client) obtainUserToken(ctx context.Context, payload LoginRequest) (*To
 	return &newToken, token, nil
 }
 
+// getTokenDeadline computes the expiration of the token with the local clock:
+// the lifetime of the token (issued_at to expires_at) is added to the current time,
+// so the clock skew between lego and the server doesn't matter.
+// The zero time is returned if the lifetime is unknown (the token is not reused).
+func getTokenDeadline(token Token, now time.Time) time.Time {
+	issuedAt, err := time.Parse(time.RFC3339, token.IssuedAt)
+	if err != nil {
+		return time.Time{}
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, token.ExpiresAt)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return now.Add(expiresAt.Sub(issuedAt))
+}
+
 func getBaseURL(tokenResp *TokenResponse) (*url.URL, error) {
 	var endpoints []Endpoint
 
@@ -1,9 +1,11 @@
 package internal
 
 import (
+	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
 	"github.com/stretchr/testify/assert"
@@ -33,4 +35,80 @@ func TestClient_Login(t *testing.T) {
 
 	assert.Equal(t, serverURL.JoinPath("v2").String(), client.baseURL.String())
 	assert.Equal(t, fakeOTCToken, client.token)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), client.tokenDeadline, time.Minute)
+}
+
+func TestClient_Login_reuse(t *testing.T) {
+	var calls int
+
+	client := servermock.NewBuilder(
+		func(server *httptest.Server) (*Client, error) {
+			client := NewClient("user", "secret", "example.com", "test")
+			client.HTTPClient = server.Client()
+			client.IdentityEndpoint = server.URL + "/v3/auth/token"
+
+			return client, nil
+		},
+		servermock.CheckHeader().WithJSONHeaders(),
+	).
+		Route("POST /v3/auth/token", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			calls++
+
+			IdentityHandlerMock()(rw, req)
+		})).
+		Build(t)
+
+	err := client.Login(t.Context())
+	require.NoError(t, err)
+
+	err = client.Login(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, calls)
+
+	// the token is about to expire.
+	client.tokenDeadline = time.Now().Add(tokenExpirationMargin / 2)
+
+	err = client.Login(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+}
+
+func Test_getTokenDeadline(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc     string
+		token    Token
+		expected time.Time
+	}{
+		{
+			desc: "lifetime",
+			token: Token{
+				IssuedAt:  "2025-06-01T14:00:00.000000Z",
+				ExpiresAt: "2025-06-02T14:00:00.000000Z",
+			},
+			expected: now.Add(24 * time.Hour),
+		},
+		{
+			desc:  "missing dates",
+			token: Token{},
+		},
+		{
+			desc: "invalid date",
+			token: Token{
+				IssuedAt:  "2025-06-01T14:00:00.000000Z",
+				ExpiresAt: "tomorrow",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, getTokenDeadline(test.token, now))
+		})
+	}
 }
@@ -13,6 +13,8 @@ func IdentityHandlerMock() http.HandlerFunc {
 
 		_, _ = fmt.Fprintf(w, `{
 		  "token": {
+		    "issued_at": "2025-01-01T00:00:00.000000Z",
+		    "expires_at": "2025-01-02T00:00:00.000000Z",
 		    "catalog": [
 		      {
 			"type": "dns",
@@ -58,6 +58,11 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	tr := &http.Transport{}
 
 	defaultTransport, ok := http.DefaultTransport.(*http.Transport)
@@ -69,15 +74,15 @@ func NewDefaultConfig() *Config {
 	tr.DisableKeepAlives = true
 
 	return &Config{
-		PrivateZone:      env.GetOrDefaultBool(EnvPrivateZone, false),
-		IdentityEndpoint: env.GetOrDefaultString(EnvIdentityEndpoint, defaultIdentityEndpoint),
+		PrivateZone:      lookup.GetOrDefaultBool(EnvPrivateZone, false),
+		IdentityEndpoint: lookup.GetOrDefaultString(EnvIdentityEndpoint, defaultIdentityEndpoint),
 
-		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		SequenceInterval:   env.GetOrDefaultSecond(EnvSequenceInterval, dns01.DefaultPropagationTimeout),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, minTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		SequenceInterval:   lookup.GetOrDefaultSecond(EnvSequenceInterval, dns01.DefaultPropagationTimeout),
 		HTTPClient: &http.Client{
-			Timeout:   env.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
+			Timeout:   lookup.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
 			Transport: tr,
 		},
 	}
@@ -117,8 +122,14 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("otc: credentials missing")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("otc: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL, 0)
+	if err != nil {
+		return nil, fmt.Errorf("otc: %w", err)
+	}
+
+	err = env.ValidatePropagation(config.PropagationTimeout, config.PollingInterval)
+	if err != nil {
+		return nil, fmt.Errorf("otc: %w", err)
 	}
 
 	client := internal.NewClient(config.UserName, config.Password, config.DomainName, config.ProjectName)
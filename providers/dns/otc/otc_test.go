@@ -114,6 +114,7 @@ func TestNewDNSProvider(t *testing.T) {
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc        string
+		ttl         int
 		domainName  string
 		projectName string
 		username    string
@@ -122,6 +123,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}{
 		{
 			desc:        "success",
+			ttl:         minTTL,
 			domainName:  "example.com",
 			projectName: "test",
 			username:    "user",
@@ -129,10 +131,12 @@ func TestNewDNSProviderConfig(t *testing.T) {
 		},
 		{
 			desc:     "missing credentials",
+			ttl:      minTTL,
 			expected: "otc: credentials missing",
 		},
 		{
 			desc:        "missing domain name",
+			ttl:         minTTL,
 			domainName:  "",
 			projectName: "test",
 			username:    "user",
@@ -141,6 +145,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 		},
 		{
 			desc:        "missing project name",
+			ttl:         minTTL,
 			domainName:  "example.com",
 			projectName: "",
 			username:    "user",
@@ -149,6 +154,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 		},
 		{
 			desc:        "missing username",
+			ttl:         minTTL,
 			domainName:  "example.com",
 			projectName: "test",
 			username:    "",
@@ -157,17 +163,28 @@ func TestNewDNSProviderConfig(t *testing.T) {
 		},
 		{
 			desc:        "missing password ",
+			ttl:         minTTL,
 			domainName:  "example.com",
 			projectName: "test",
 			username:    "user",
 			password:    "",
 			expected:    "otc: credentials missing",
 		},
+		{
+			desc:        "invalid TTL",
+			ttl:         299,
+			domainName:  "example.com",
+			projectName: "test",
+			username:    "user",
+			password:    "secret",
+			expected:    "otc: invalid TTL, TTL (299) must be greater than 300",
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
+			config.TTL = test.ttl
 			config.DomainName = test.domainName
 			config.ProjectName = test.projectName
 			config.UserName = test.username
@@ -35,7 +35,7 @@ var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
 // Config is used to configure the creation of the DNSProvider.
 type Config struct {
-	baseURL  string
+	BaseURL  string
 	Username string
 	Password string
 
@@ -47,12 +47,17 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, 300),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, 300),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
 		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
 		},
 	}
 }
@@ -76,7 +81,7 @@ func NewDNSProvider() (*DNSProvider, error) {
 	}
 
 	config := NewDefaultConfig()
-	config.baseURL = values[EnvServerBaseURL]
+	config.BaseURL = values[EnvServerBaseURL]
 	config.Username = values[EnvUsername]
 	config.Password = values[EnvPassword]
 
@@ -89,13 +94,13 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("plesk: the configuration of the DNS provider is nil")
 	}
 
-	if config.baseURL == "" {
+	if config.BaseURL == "" {
 		return nil, errors.New("plesk: missing server base URL")
 	}
 
-	baseURL, err := url.Parse(config.baseURL)
+	baseURL, err := url.Parse(config.BaseURL)
 	if err != nil {
-		return nil, fmt.Errorf("plesk: failed to parse base URL (%s): %w", config.baseURL, err)
+		return nil, fmt.Errorf("plesk: failed to parse base URL (%s): %w", config.BaseURL, err)
 	}
 
 	if config.Username == "" || config.Password == "" {
@@ -127,7 +127,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
-			config.baseURL = test.baseURL
+			config.BaseURL = test.baseURL
 			config.Username = test.username
 			config.Password = test.password
 
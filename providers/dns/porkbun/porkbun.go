@@ -14,48 +14,14 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
+	"github.com/go-acme/lego/v4/providers/dns/internal/consistency"
 	"github.com/nrdcg/porkbun"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "PORKBUN_"
-
-	EnvSecretAPIKey = envNamespace + "SECRET_API_KEY"
-	EnvAPIKey       = envNamespace + "API_KEY"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-)
-
 const minTTL = 300
 
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	APIKey             string
-	SecretAPIKey       string
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 10*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 10*time.Second),
-		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
@@ -65,22 +31,6 @@ type DNSProvider struct {
 	recordIDsMu sync.Mutex
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for Porkbun.
-// Credentials must be passed in the environment variables:
-// PORKBUN_SECRET_API_KEY, PORKBUN_PAPI_KEY.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvSecretAPIKey, EnvAPIKey)
-	if err != nil {
-		return nil, fmt.Errorf("porkbun: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.SecretAPIKey = values[EnvSecretAPIKey]
-	config.APIKey = values[EnvAPIKey]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for Porkbun.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -91,8 +41,14 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("porkbun: some credentials information are missing")
 	}
 
-	if config.TTL < minTTL {
-		return nil, fmt.Errorf("porkbun: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	err := env.ValidateTTL(config.TTL, minTTL, 0)
+	if err != nil {
+		return nil, fmt.Errorf("porkbun: %w", err)
+	}
+
+	err = env.ValidatePropagation(config.PropagationTimeout, config.PollingInterval)
+	if err != nil {
+		return nil, fmt.Errorf("porkbun: %w", err)
 	}
 
 	client := porkbun.New(config.SecretAPIKey, config.APIKey)
@@ -166,7 +122,10 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 
 	ctx := context.Background()
 
-	err = d.client.DeleteRecord(ctx, dns01.UnFqdn(zoneName), recordID)
+	// The record can be unknown for a short period after its creation.
+	err = consistency.DoOnNotFound(ctx, func() error {
+		return d.client.DeleteRecord(ctx, dns01.UnFqdn(zoneName), recordID)
+	}, isNotFound)
 	if err != nil {
 		return fmt.Errorf("porkbun: failed to delete record: %w", err)
 	}
@@ -192,3 +151,9 @@ func splitDomain(fqdn string) (string, string, error) {
 
 	return zone, subDomain, nil
 }
+
+func isNotFound(err error) bool {
+	var serverErr *porkbun.ServerError
+
+	return errors.As(err, &serverErr) && serverErr.StatusCode == http.StatusNotFound
+}
@@ -1,9 +1,17 @@
 package porkbun
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
 	"testing"
 
+	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -75,34 +83,47 @@ func TestNewDNSProvider(t *testing.T) {
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc         string
+		ttl          int
 		secretAPIKey string
 		apiKey       string
 		expected     string
 	}{
 		{
 			desc:         "success",
+			ttl:          minTTL,
 			secretAPIKey: "secret",
 			apiKey:       "key",
 		},
 		{
 			desc:     "missing secret API key",
+			ttl:      minTTL,
 			apiKey:   "key",
 			expected: "porkbun: some credentials information are missing",
 		},
 		{
 			desc:         "missing API key",
+			ttl:          minTTL,
 			secretAPIKey: "secret",
 			expected:     "porkbun: some credentials information are missing",
 		},
 		{
 			desc:     "missing all credentials",
+			ttl:      minTTL,
 			expected: "porkbun: some credentials information are missing",
 		},
+		{
+			desc:         "invalid TTL",
+			ttl:          299,
+			secretAPIKey: "secret",
+			apiKey:       "key",
+			expected:     "porkbun: invalid TTL, TTL (299) must be greater than 300",
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
+			config.TTL = test.ttl
 			config.SecretAPIKey = test.secretAPIKey
 			config.APIKey = test.apiKey
 
@@ -119,6 +140,51 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_CleanUp_notFoundAfterCreate(t *testing.T) {
+	mockZone(t)
+
+	var deleteCalls atomic.Int32
+
+	provider := servermock.NewBuilder(
+		func(server *httptest.Server) (*DNSProvider, error) {
+			config := NewDefaultConfig()
+			config.APIKey = "key"
+			config.SecretAPIKey = "secret"
+			config.HTTPClient = server.Client()
+
+			p, err := NewDNSProviderConfig(config)
+			if err != nil {
+				return nil, err
+			}
+
+			p.client.BaseURL, _ = url.Parse(server.URL)
+
+			return p, nil
+		},
+	).
+		Route("POST /dns/create/example.com",
+			servermock.RawStringResponse(`{"status":"SUCCESS","id":123}`)).
+		Route("POST /dns/delete/example.com/123",
+			http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				// The record is unknown during a short period after its creation.
+				if deleteCalls.Add(1) == 1 {
+					http.Error(rw, `{"status":"ERROR","message":"Invalid record ID."}`, http.StatusNotFound)
+					return
+				}
+
+				_, _ = rw.Write([]byte(`{"status":"SUCCESS"}`))
+			})).
+		Build(t)
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	err = provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), deleteCalls.Load())
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -146,3 +212,21 @@ func TestLiveCleanUp(t *testing.T) {
 	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
 	require.NoError(t, err)
 }
+
+// mockZone answers the SOA requests used to find the zone (example.com) of the challenges.
+func mockZone(t *testing.T) {
+	t.Helper()
+
+	dns01.ClearFqdnCache()
+
+	addr := dnsmock.NewServer().
+		Query("_acme-challenge.example.com. SOA", dnsmock.SOA("example.com.")).
+		Build(t)
+
+	dns01.SetDefaultNameservers([]string{addr.String()})
+
+	t.Cleanup(func() {
+		dns01.SetDefaultNameservers(nil)
+		dns01.ClearFqdnCache()
+	})
+}
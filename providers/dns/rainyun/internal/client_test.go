@@ -56,7 +56,7 @@ func TestClient_ListDomains_error(t *testing.T) {
 	_, err := client.ListDomains(t.Context())
 	require.Error(t, err)
 
-	assert.EqualError(t, err, "30039: 密钥认证错误或已失效")
+	assert.EqualError(t, err, "30039: 密钥认证错误或已失效 [endpoint: GET /domain]")
 }
 
 func TestClient_ListRecords(t *testing.T) {
@@ -103,7 +103,7 @@ func TestClient_ListRecords_error(t *testing.T) {
 	_, err := client.ListRecords(t.Context(), 123)
 	require.Error(t, err)
 
-	assert.EqualError(t, err, "30039: 密钥认证错误或已失效")
+	assert.EqualError(t, err, "30039: 密钥认证错误或已失效 [endpoint: GET /domain/123/dns]")
 }
 
 func TestClient_AddRecord(t *testing.T) {
@@ -141,7 +141,7 @@ func TestClient_AddRecord_error(t *testing.T) {
 	err := client.AddRecord(t.Context(), 123, record)
 	require.Error(t, err)
 
-	assert.EqualError(t, err, "30039: 密钥认证错误或已失效")
+	assert.EqualError(t, err, "30039: 密钥认证错误或已失效 [endpoint: POST /domain/123/dns]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -163,5 +163,5 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 	err := client.DeleteRecord(t.Context(), 123, 456)
 	require.Error(t, err)
 
-	assert.EqualError(t, err, "30039: 密钥认证错误或已失效")
+	assert.EqualError(t, err, "30039: 密钥认证错误或已失效 [endpoint: DELETE /domain/123/dns]")
 }
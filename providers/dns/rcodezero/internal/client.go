@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	"github.com/miekg/dns"
 )
@@ -60,7 +61,7 @@ func (c *Client) do(req *http.Request) (*APIResponse, error) {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode/100 != 2 {
-		return nil, parseError(req, resp)
+		return nil, providers.NewAPIError(req, resp, parseError(req, resp))
 	}
 
 	result := &APIResponse{}
@@ -0,0 +1,172 @@
+package dns
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
+)
+
+// NewDNSChallengeProviderByNameWithReload is like NewDNSChallengeProviderByName,
+// but the provider is rebuilt when a credential file of the provider (referenced by a '<key>_FILE' environment variable) changes.
+// The changes are checked before each Present.
+// The CleanUp of a challenge is always done by the provider instance that has presented it.
+//
+// It's intended for the long-running processes: the files are read before each challenge.
+func NewDNSChallengeProviderByNameWithReload(name string) (challenge.Provider, error) {
+	return newReloadingProvider(name, env.NewFileWatcher(dnsProviderCredentials(name)...), NewDNSChallengeProviderByName)
+}
+
+type sequential interface {
+	Sequential() time.Duration
+}
+
+type reloadingProvider struct {
+	name    string
+	watcher *env.FileWatcher
+	factory func(name string) (challenge.Provider, error)
+
+	mu        sync.Mutex
+	current   challenge.Provider
+	presented map[string]challenge.Provider
+}
+
+func newReloadingProvider(name string, watcher *env.FileWatcher, factory func(name string) (challenge.Provider, error)) (challenge.Provider, error) {
+	provider, err := factory(name)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &reloadingProvider{
+		name:      name,
+		watcher:   watcher,
+		factory:   factory,
+		current:   provider,
+		presented: make(map[string]challenge.Provider),
+	}
+
+	if _, ok := provider.(sequential); ok {
+		return &reloadingSequentialProvider{reloadingProvider: r}, nil
+	}
+
+	return r, nil
+}
+
+func (r *reloadingProvider) Present(domain, token, keyAuth string) error {
+	provider := r.reload()
+
+	key := challengeKey(domain, token, keyAuth)
+
+	err := provider.Present(domain, token, keyAuth)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		// The entry of a previous attempt must not be used by the CleanUp of this attempt.
+		delete(r.presented, key)
+
+		return err
+	}
+
+	r.presented[key] = provider
+
+	return nil
+}
+
+func (r *reloadingProvider) CleanUp(domain, token, keyAuth string) error {
+	key := challengeKey(domain, token, keyAuth)
+
+	r.mu.Lock()
+
+	provider, ok := r.presented[key]
+	if !ok {
+		provider = r.current
+	}
+
+	delete(r.presented, key)
+
+	r.mu.Unlock()
+
+	return provider.CleanUp(domain, token, keyAuth)
+}
+
+func (r *reloadingProvider) Timeout() (timeout, interval time.Duration) {
+	r.mu.Lock()
+	provider := r.current
+	r.mu.Unlock()
+
+	if p, ok := provider.(challenge.ProviderTimeout); ok {
+		return p.Timeout()
+	}
+
+	return dns01.DefaultPropagationTimeout, dns01.DefaultPollingInterval
+}
+
+// Name returns the name of the wrapped provider (used in the metrics and the traces).
+func (r *reloadingProvider) Name() string {
+	return r.name
+}
+
+// HasTXTRecord forwards dns01.TXTRecordFinder to the current provider.
+func (r *reloadingProvider) HasTXTRecord(fqdn, value string) (bool, error) {
+	r.mu.Lock()
+	provider := r.current
+	r.mu.Unlock()
+
+	finder, ok := provider.(dns01.TXTRecordFinder)
+	if !ok {
+		return false, nil
+	}
+
+	return finder.HasTXTRecord(fqdn, value)
+}
+
+// reload rebuilds the provider if the credential files have changed, and returns the provider to use.
+// If the provider cannot be rebuilt, the previous instance is kept.
+func (r *reloadingProvider) reload() challenge.Provider {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changed := r.watcher.Changed()
+	if len(changed) == 0 {
+		return r.current
+	}
+
+	log.Infof("%s: credential files changed (%s), rebuilding the DNS provider", r.name, strings.Join(changed, ", "))
+
+	provider, err := r.factory(r.name)
+	if err != nil {
+		log.Warnf("%s: failed to rebuild the DNS provider, the previous configuration is kept: %v", r.name, err)
+		return r.current
+	}
+
+	r.current = provider
+
+	return provider
+}
+
+// reloadingSequentialProvider is a reloadingProvider for the providers that require sequential solving.
+type reloadingSequentialProvider struct {
+	*reloadingProvider
+}
+
+func (r *reloadingSequentialProvider) Sequential() time.Duration {
+	r.mu.Lock()
+	provider := r.current
+	r.mu.Unlock()
+
+	if p, ok := provider.(sequential); ok {
+		return p.Sequential()
+	}
+
+	return 0
+}
+
+func challengeKey(domain, token, keyAuth string) string {
+	return domain + "|" + token + "|" + keyAuth
+}
@@ -0,0 +1,189 @@
+package dns
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvider struct {
+	id         string
+	presentErr error
+
+	presented []string
+	cleaned   []string
+}
+
+func (f *fakeProvider) Present(domain, _, _ string) error {
+	if f.presentErr != nil {
+		return f.presentErr
+	}
+
+	f.presented = append(f.presented, domain)
+
+	return nil
+}
+
+func (f *fakeProvider) CleanUp(domain, _, _ string) error {
+	f.cleaned = append(f.cleaned, domain)
+	return nil
+}
+
+func (f *fakeProvider) Timeout() (timeout, interval time.Duration) {
+	return time.Minute, time.Second
+}
+
+func TestNewDNSChallengeProviderByNameWithReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+
+	err := os.WriteFile(path, []byte("secret1"), 0o600)
+	require.NoError(t, err)
+
+	t.Setenv("TEST_LEGO_RELOAD_TOKEN_FILE", path)
+
+	var instances []*fakeProvider
+
+	factory := func(name string) (challenge.Provider, error) {
+		p := &fakeProvider{id: env.GetOrFile("TEST_LEGO_RELOAD_TOKEN")}
+		instances = append(instances, p)
+
+		return p, nil
+	}
+
+	provider, err := newReloadingProvider("fake", env.NewFileWatcher("TEST_LEGO_RELOAD_TOKEN"), factory)
+	require.NoError(t, err)
+
+	require.Len(t, instances, 1)
+
+	err = provider.Present("a.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	require.Len(t, instances, 1)
+
+	err = os.WriteFile(path, []byte("secret2"), 0o600)
+	require.NoError(t, err)
+
+	err = provider.Present("b.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	require.Len(t, instances, 2)
+
+	assert.Equal(t, "secret1", instances[0].id)
+	assert.Equal(t, "secret2", instances[1].id)
+
+	// The cleanup is done by the instance that has presented the challenge.
+	err = provider.CleanUp("a.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	err = provider.CleanUp("b.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a.example.com"}, instances[0].presented)
+	assert.Equal(t, []string{"a.example.com"}, instances[0].cleaned)
+	assert.Equal(t, []string{"b.example.com"}, instances[1].presented)
+	assert.Equal(t, []string{"b.example.com"}, instances[1].cleaned)
+
+	p, ok := provider.(challenge.ProviderTimeout)
+	require.True(t, ok)
+
+	timeout, interval := p.Timeout()
+	assert.Equal(t, time.Minute, timeout)
+	assert.Equal(t, time.Second, interval)
+}
+
+func TestReloadingProvider_Present_error(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+
+	err := os.WriteFile(path, []byte("secret1"), 0o600)
+	require.NoError(t, err)
+
+	t.Setenv("TEST_LEGO_RELOAD_TOKEN_FILE", path)
+
+	var instances []*fakeProvider
+
+	factory := func(name string) (challenge.Provider, error) {
+		p := &fakeProvider{id: env.GetOrFile("TEST_LEGO_RELOAD_TOKEN")}
+		if p.id == "secret2" {
+			p.presentErr = errors.New("unauthorized")
+		}
+
+		instances = append(instances, p)
+
+		return p, nil
+	}
+
+	provider, err := newReloadingProvider("fake", env.NewFileWatcher("TEST_LEGO_RELOAD_TOKEN"), factory)
+	require.NoError(t, err)
+
+	err = provider.Present("a.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	err = os.WriteFile(path, []byte("secret2"), 0o600)
+	require.NoError(t, err)
+
+	// A new attempt of the same challenge fails with the new credentials.
+	err = provider.Present("a.example.com", "token", "keyAuth")
+	require.EqualError(t, err, "unauthorized")
+
+	require.Len(t, instances, 2)
+
+	// The cleanup of the failed attempt is not done by the instance of the previous attempt.
+	err = provider.CleanUp("a.example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Empty(t, instances[0].cleaned)
+	assert.Equal(t, []string{"a.example.com"}, instances[1].cleaned)
+}
+
+func TestReloadingProvider_Name(t *testing.T) {
+	factory := func(name string) (challenge.Provider, error) {
+		return &fakeProvider{}, nil
+	}
+
+	provider, err := newReloadingProvider("cloudflare", env.NewFileWatcher(), factory)
+	require.NoError(t, err)
+
+	// The metrics and the traces use the name of the wrapped provider, not the name of the package of the wrapper.
+	named, ok := provider.(interface{ Name() string })
+	require.True(t, ok)
+
+	assert.Equal(t, "cloudflare", named.Name())
+}
+
+type fakeFinderProvider struct {
+	fakeProvider
+}
+
+func (f *fakeFinderProvider) HasTXTRecord(_, value string) (bool, error) {
+	return value == "found", nil
+}
+
+func TestReloadingProvider_HasTXTRecord(t *testing.T) {
+	factory := func(name string) (challenge.Provider, error) {
+		return &fakeFinderProvider{}, nil
+	}
+
+	provider, err := newReloadingProvider("cloudflare", env.NewFileWatcher(), factory)
+	require.NoError(t, err)
+
+	finder, ok := provider.(dns01.TXTRecordFinder)
+	require.True(t, ok)
+
+	found, err := finder.HasTXTRecord("_acme-challenge.example.com.", "found")
+	require.NoError(t, err)
+
+	assert.True(t, found)
+
+	found, err = finder.HasTXTRecord("_acme-challenge.example.com.", "other")
+	require.NoError(t, err)
+
+	assert.False(t, found)
+}
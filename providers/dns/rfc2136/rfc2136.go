@@ -10,92 +10,20 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/rfc2136/internal"
 	"github.com/miekg/dns"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "RFC2136_"
-
-	EnvTSIGFile = envNamespace + "TSIG_FILE"
-
-	EnvTSIGKey       = envNamespace + "TSIG_KEY"
-	EnvTSIGSecret    = envNamespace + "TSIG_SECRET"
-	EnvTSIGAlgorithm = envNamespace + "TSIG_ALGORITHM"
-
-	EnvNameserver = envNamespace + "NAMESERVER"
-	EnvDNSTimeout = envNamespace + "DNS_TIMEOUT"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvSequenceInterval   = envNamespace + "SEQUENCE_INTERVAL"
+var (
+	_ challenge.ProviderTimeout = (*DNSProvider)(nil)
+	_ dns01.TLSAProvider        = (*DNSProvider)(nil)
 )
 
-var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
-
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	Nameserver string
-
-	TSIGFile string
-
-	TSIGAlgorithm string
-	TSIGKey       string
-	TSIGSecret    string
-
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-	SequenceInterval   time.Duration
-	DNSTimeout         time.Duration
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TSIGAlgorithm:      env.GetOrDefaultString(EnvTSIGAlgorithm, dns.HmacSHA1),
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, env.GetOrDefaultSecond("RFC2136_TIMEOUT", dns01.DefaultPropagationTimeout)),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		SequenceInterval:   env.GetOrDefaultSecond(EnvSequenceInterval, dns01.DefaultPropagationTimeout),
-		DNSTimeout:         env.GetOrDefaultSecond(EnvDNSTimeout, 10*time.Second),
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for rfc2136
-// dynamic update. Configured with environment variables:
-// RFC2136_NAMESERVER: Network address in the form "host" or "host:port".
-// RFC2136_TSIG_ALGORITHM: Defaults to hmac-md5.sig-alg.reg.int. (HMAC-MD5).
-// See https://github.com/miekg/dns/blob/master/tsig.go for supported values.
-// RFC2136_TSIG_KEY: Name of the secret key as defined in DNS server configuration.
-// RFC2136_TSIG_SECRET: Secret key payload.
-// RFC2136_PROPAGATION_TIMEOUT: DNS propagation timeout in time.ParseDuration format. (60s)
-// To disable TSIG authentication, leave the RFC2136_TSIG* variables unset.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvNameserver)
-	if err != nil {
-		return nil, fmt.Errorf("rfc2136: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.Nameserver = values[EnvNameserver]
-
-	config.TSIGFile = env.GetOrDefaultString(EnvTSIGFile, "")
-
-	config.TSIGKey = env.GetOrFile(EnvTSIGKey)
-	config.TSIGSecret = env.GetOrFile(EnvTSIGSecret)
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for rfc2136.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -117,11 +45,14 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		config.TSIGSecret = key.Secret
 	}
 
-	// Append the default DNS port if none is specified.
+	// Append the default DNS port if none is specified (host, IPv6 with or without brackets).
 	if _, _, err := net.SplitHostPort(config.Nameserver); err != nil {
-		if strings.Contains(err.Error(), "missing port") {
+		switch {
+		case strings.Contains(err.Error(), "missing port"):
+			config.Nameserver = net.JoinHostPort(strings.Trim(config.Nameserver, "[]"), "53")
+		case net.ParseIP(config.Nameserver) != nil:
 			config.Nameserver = net.JoinHostPort(config.Nameserver, "53")
-		} else {
+		default:
 			return nil, fmt.Errorf("rfc2136: %w", err)
 		}
 	}
@@ -214,6 +145,46 @@ func (d *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 		return fmt.Errorf("unexpected action: %s", action)
 	}
 
+	return d.send(m)
+}
+
+// SetTLSA replaces the TLSA records of the FQDN.
+// The records are the RDATA in the presentation format (ex: "3 1 1 <hex>").
+func (d *DNSProvider) SetTLSA(fqdn string, records []string) error {
+	zone, err := dns01.FindZoneByFqdnCustom(fqdn, []string{d.config.Nameserver})
+	if err != nil {
+		return fmt.Errorf("rfc2136: %w", err)
+	}
+
+	var rrs []dns.RR
+
+	for _, record := range records {
+		rr, errR := dns.NewRR(fmt.Sprintf("%s %d IN TLSA %s", fqdn, d.config.TTL, record))
+		if errR != nil {
+			return fmt.Errorf("rfc2136: invalid TLSA record %q: %w", record, errR)
+		}
+
+		rrs = append(rrs, rr)
+	}
+
+	m := new(dns.Msg).SetUpdate(zone)
+
+	m.RemoveRRset([]dns.RR{&dns.TLSA{
+		Hdr: dns.RR_Header{Name: fqdn, Rrtype: dns.TypeTLSA, Class: dns.ClassINET},
+	}})
+
+	m.Insert(rrs)
+
+	err = d.send(m)
+	if err != nil {
+		return fmt.Errorf("rfc2136: failed to set TLSA records: %w", err)
+	}
+
+	return nil
+}
+
+// send sends the update message to the nameserver.
+func (d *DNSProvider) send(m *dns.Msg) error {
 	// Setup client
 	c := &dns.Client{Timeout: d.config.DNSTimeout}
 
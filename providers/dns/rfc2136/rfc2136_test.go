@@ -10,6 +10,7 @@ import (
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/go-acme/lego/v4/platform/tester/dnsmock"
 	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -102,6 +103,32 @@ func TestNewDNSProvider(t *testing.T) {
 	}
 }
 
+func TestNewDNSProviderConfig_nameserver(t *testing.T) {
+	testCases := []struct {
+		nameserver string
+		expected   string
+	}{
+		{nameserver: "ns.example.com", expected: "ns.example.com:53"},
+		{nameserver: "ns.example.com:5353", expected: "ns.example.com:5353"},
+		{nameserver: "192.0.2.1", expected: "192.0.2.1:53"},
+		{nameserver: "2001:db8::1", expected: "[2001:db8::1]:53"},
+		{nameserver: "[2001:db8::1]", expected: "[2001:db8::1]:53"},
+		{nameserver: "[2001:db8::1]:5353", expected: "[2001:db8::1]:5353"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.nameserver, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Nameserver = test.nameserver
+
+			p, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, p.config.Nameserver)
+		})
+	}
+}
+
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc          string
@@ -236,6 +263,59 @@ func TestDNSProvider_Present_success_updatePacket(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_SetTLSA(t *testing.T) {
+	dns01.ClearFqdnCache()
+
+	reqChan := make(chan *dns.Msg, 1)
+
+	addr := dnsmock.NewServer().
+		Query("_443._tcp.www.example.com. SOA", dnsmock.SOA(fakeZone)).
+		Update(fakeZone+" SOA", func(w dns.ResponseWriter, req *dns.Msg) {
+			dnsmock.Noop(w, req)
+
+			reqChan <- req
+		}).
+		Build(t)
+
+	config := NewDefaultConfig()
+	config.Nameserver = addr.String()
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.SetTLSA("_443._tcp.www.example.com.", []string{"3 1 1 abcd", "3 1 1 ef01"})
+	require.NoError(t, err)
+
+	select {
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for request")
+
+	case rcvMsg := <-reqChan:
+		require.Len(t, rcvMsg.Ns, 3)
+
+		assert.Equal(t, dns.RR_Header{Name: "_443._tcp.www.example.com.", Rrtype: dns.TypeTLSA, Class: dns.ClassANY}, *rcvMsg.Ns[0].Header())
+		assert.Equal(t, "_443._tcp.www.example.com.\t120\tIN\tTLSA\t3 1 1 abcd", rcvMsg.Ns[1].String())
+		assert.Equal(t, "_443._tcp.www.example.com.\t120\tIN\tTLSA\t3 1 1 ef01", rcvMsg.Ns[2].String())
+	}
+}
+
+func TestDNSProvider_SetTLSA_invalid(t *testing.T) {
+	dns01.ClearFqdnCache()
+
+	addr := dnsmock.NewServer().
+		Query("_443._tcp.www.example.com. SOA", dnsmock.SOA(fakeZone)).
+		Build(t)
+
+	config := NewDefaultConfig()
+	config.Nameserver = addr.String()
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.SetTLSA("_443._tcp.www.example.com.", []string{"3 1 x abcd"})
+	require.ErrorContains(t, err, `rfc2136: invalid TLSA record "3 1 x abcd"`)
+}
+
 func TestDNSProvider_Present_error(t *testing.T) {
 	dns01.ClearFqdnCache()
 
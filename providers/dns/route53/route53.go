@@ -73,18 +73,23 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		HostedZoneID:  env.GetOrFile(EnvHostedZoneID),
-		MaxRetries:    env.GetOrDefaultInt(EnvMaxRetries, 5),
-		AssumeRoleArn: env.GetOrDefaultString(EnvAssumeRoleArn, ""),
-		ExternalID:    env.GetOrDefaultString(EnvExternalID, ""),
-		PrivateZone:   env.GetOrDefaultBool(EnvPrivateZone, false),
+		HostedZoneID:  lookup.GetOrFile(EnvHostedZoneID),
+		MaxRetries:    lookup.GetOrDefaultInt(EnvMaxRetries, 5),
+		AssumeRoleArn: lookup.GetOrDefaultString(EnvAssumeRoleArn, ""),
+		ExternalID:    lookup.GetOrDefaultString(EnvExternalID, ""),
+		PrivateZone:   lookup.GetOrDefaultBool(EnvPrivateZone, false),
 
-		WaitForRecordSetsChanged: env.GetOrDefaultBool(EnvWaitForRecordSetsChanged, true),
+		WaitForRecordSetsChanged: lookup.GetOrDefaultBool(EnvWaitForRecordSetsChanged, true),
 
-		TTL:                env.GetOrDefaultInt(EnvTTL, 10),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 4*time.Second),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, 10),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, 4*time.Second),
 	}
 }
 
@@ -74,7 +74,7 @@ func TestClient_AddRecord_error(t *testing.T) {
 	}
 
 	_, err := client.AddRecord(t.Context(), "example.com", record)
-	require.EqualError(t, err, "add record: [status code: 401] Unauthenticated")
+	require.EqualError(t, err, "add record: [status code: 401] Unauthenticated [endpoint: POST /zones/example.com/records]")
 }
 
 func TestClient_RemoveRecord(t *testing.T) {
@@ -96,5 +96,5 @@ func TestClient_RemoveRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.RemoveRecord(t.Context(), "example.com", 1234567)
-	require.EqualError(t, err, "remove record: [status code: 401] Unauthenticated")
+	require.EqualError(t, err, "remove record: [status code: 401] Unauthenticated [endpoint: DELETE /zones/example.com/records/1234567]")
 }
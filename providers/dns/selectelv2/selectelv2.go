@@ -73,16 +73,21 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		BaseURL:    env.GetOrDefaultString(EnvBaseURL, defaultBaseURL),
-		AuthRegion: env.GetOrDefaultString(EnvAuthRegion, defaultAuthRegion),
-		AuthURL:    env.GetOrDefaultString(EnvAuthURL, defaultAuthURL),
+		BaseURL:    lookup.GetOrDefaultString(EnvBaseURL, defaultBaseURL),
+		AuthRegion: lookup.GetOrDefaultString(EnvAuthRegion, defaultAuthRegion),
+		AuthURL:    lookup.GetOrDefaultString(EnvAuthURL, defaultAuthURL),
 
-		TTL:                env.GetOrDefaultInt(EnvTTL, defaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, defaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, defaultPollingInterval),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, defaultTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, defaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, defaultPollingInterval),
 		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, defaultHTTPTimeout),
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, defaultHTTPTimeout),
 		},
 	}
 }
@@ -37,5 +37,5 @@ func TestClient_UpdateTXTRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.UpdateTXTRecord(t.Context(), "123456", "txt")
-	require.EqualError(t, err, "unexpected status code: [status code: 400] body: ")
+	require.EqualError(t, err, "unexpected status code: [status code: 400] body:  [endpoint: GET /]")
 }
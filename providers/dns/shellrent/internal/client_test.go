@@ -54,7 +54,7 @@ func TestClient_ListServices_error_status(t *testing.T) {
 		Build(t)
 
 	_, err := client.ListServices(t.Context())
-	require.EqualError(t, err, "code 2: Token di autorizzazione non valido")
+	require.EqualError(t, err, "code 2: Token di autorizzazione non valido [endpoint: GET /purchase]")
 }
 
 func TestClient_GetServiceDetails(t *testing.T) {
@@ -87,7 +87,7 @@ func TestClient_GetServiceDetails_error_status(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetServiceDetails(t.Context(), 123)
-	require.EqualError(t, err, "code 2: Token di autorizzazione non valido")
+	require.EqualError(t, err, "code 2: Token di autorizzazione non valido [endpoint: GET /purchase/details/123]")
 }
 
 func TestClient_GetDomainDetails(t *testing.T) {
@@ -120,7 +120,7 @@ func TestClient_GetDomainDetails_error_status(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetDomainDetails(t.Context(), 123)
-	require.EqualError(t, err, "code 2: Token di autorizzazione non valido")
+	require.EqualError(t, err, "code 2: Token di autorizzazione non valido [endpoint: GET /domain/details/123]")
 }
 
 func TestClient_CreateRecord(t *testing.T) {
@@ -153,7 +153,7 @@ func TestClient_CreateRecord_error_status(t *testing.T) {
 		Build(t)
 
 	_, err := client.CreateRecord(t.Context(), 123, Record{})
-	require.EqualError(t, err, "code 2: Token di autorizzazione non valido")
+	require.EqualError(t, err, "code 2: Token di autorizzazione non valido [endpoint: POST /dns_record/store/123]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -182,7 +182,7 @@ func TestClient_DeleteRecord_error_status(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteRecord(t.Context(), 123, 456)
-	require.EqualError(t, err, "code 2: Token di autorizzazione non valido")
+	require.EqualError(t, err, "code 2: Token di autorizzazione non valido [endpoint: DELETE /dns_record/remove/123/456]")
 }
 
 func TestTTLRounder(t *testing.T) {
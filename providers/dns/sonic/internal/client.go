@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -90,7 +91,7 @@ func (c *Client) SetRecord(ctx context.Context, hostname, value string, ttl int)
 	}
 
 	if r.Result != 200 {
-		return fmt.Errorf("API response code: %d, %s", r.Result, r.Message)
+		return providers.NewAPIError(req, resp, fmt.Errorf("API response code: %d, %s", r.Result, r.Message))
 	}
 
 	return nil
@@ -60,7 +60,7 @@ func TestClient_AddRecord_error(t *testing.T) {
 	}
 
 	err := client.AddRecord(t.Context(), "example.com", record)
-	require.EqualError(t, err, "^$, name: The domain name contains invalid characters")
+	require.EqualError(t, err, "^$, name: The domain name contains invalid characters [endpoint: PUT /dns/records/example.com]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -93,7 +93,7 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 	}
 
 	err := client.DeleteRecord(t.Context(), "example.com", record)
-	require.EqualError(t, err, "^$, name: The domain name contains invalid characters")
+	require.EqualError(t, err, "^$, name: The domain name contains invalid characters [endpoint: DELETE /dns/records/example.com]")
 }
 
 func TestClient_GetRecords(t *testing.T) {
@@ -120,5 +120,5 @@ func TestClient_GetRecords_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetRecords(t.Context(), "example.com")
-	require.EqualError(t, err, "^$, name: The domain name contains invalid characters")
+	require.EqualError(t, err, "^$, name: The domain name contains invalid characters [endpoint: GET /dns/records/example.com]")
 }
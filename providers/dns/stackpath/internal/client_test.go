@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -55,8 +56,16 @@ func TestClient_GetZoneRecords_apiError(t *testing.T) {
 
 	_, err := client.GetZoneRecords(t.Context(), "foo1", &Zone{ID: "A", Domain: "test"})
 
+	var errResp *ErrorResponse
+	require.ErrorAs(t, err, &errResp)
+
 	expected := &ErrorResponse{Code: 401, Message: "an unauthorized request is attempted."}
-	assert.Equal(t, expected, err)
+	assert.Equal(t, expected, errResp)
+
+	var apiErr *providers.APIError
+	require.ErrorAs(t, err, &apiErr)
+
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
 }
 
 func TestClient_GetZones(t *testing.T) {
@@ -77,7 +77,7 @@ func TestClient_CreateRecord_error(t *testing.T) {
 	}
 
 	_, err := client.CreateRecord(t.Context(), "example.com", record)
-	require.EqualError(t, err, "unexpected status code: [status code: 401] body: Unauthorized")
+	require.EqualError(t, err, "unexpected status code: [status code: 401] body: Unauthorized [endpoint: POST /dns/example.com]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -98,5 +98,5 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 		Build(t)
 
 	err := client.DeleteRecord(t.Context(), "example.com", "1234")
-	require.EqualError(t, err, "unexpected status code: [status code: 401] body: Unauthorized")
+	require.EqualError(t, err, "unexpected status code: [status code: 401] body: Unauthorized [endpoint: DELETE /dns/example.com/1234]")
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/cloudendpoint"
 	dnspod "github.com/go-acme/tencentclouddnspod/v20210323"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
 	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
@@ -24,6 +25,7 @@ const (
 	EnvSecretKey    = envNamespace + "SECRET_KEY"
 	EnvRegion       = envNamespace + "REGION"
 	EnvSessionToken = envNamespace + "SESSION_TOKEN"
+	EnvEndpoint     = envNamespace + "ENDPOINT"
 
 	EnvTTL                = envNamespace + "TTL"
 	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
@@ -31,6 +33,8 @@ const (
 	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
 )
 
+const defaultEndpoint = "dnspod.tencentcloudapi.com"
+
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
 // Config is used to configure the creation of the DNSProvider.
@@ -39,6 +43,7 @@ type Config struct {
 	SecretKey    string
 	Region       string
 	SessionToken string
+	Endpoint     string
 
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
@@ -48,11 +53,16 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, 600),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPTimeout:        env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, 600),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		HTTPTimeout:        lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
 	}
 }
 
@@ -75,6 +85,7 @@ func NewDNSProvider() (*DNSProvider, error) {
 	config.SecretKey = values[EnvSecretKey]
 	config.Region = env.GetOrDefaultString(EnvRegion, "")
 	config.SessionToken = env.GetOrDefaultString(EnvSessionToken, "")
+	config.Endpoint = env.GetOrDefaultString(EnvEndpoint, "")
 
 	return NewDNSProviderConfig(config)
 }
@@ -96,8 +107,19 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("tencentcloud: credentials missing")
 	}
 
+	endpoint := defaultEndpoint
+
+	if config.Endpoint != "" {
+		var err error
+
+		endpoint, err = cloudendpoint.Host(config.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("tencentcloud: %w", err)
+		}
+	}
+
 	cpf := profile.NewClientProfile()
-	cpf.HttpProfile.Endpoint = "dnspod.tencentcloudapi.com"
+	cpf.HttpProfile.Endpoint = endpoint
 	cpf.HttpProfile.ReqTimeout = int(math.Round(config.HTTPTimeout.Seconds()))
 
 	client, err := dnspod.NewClient(credential, config.Region, cpf)
@@ -79,6 +79,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 		desc      string
 		secretID  string
 		secretKey string
+		endpoint  string
 		expected  string
 	}{
 		{
@@ -86,6 +87,12 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			secretID:  "123",
 			secretKey: "456",
 		},
+		{
+			desc:      "success with endpoint",
+			secretID:  "123",
+			secretKey: "456",
+			endpoint:  "dnspod.intl.tencentcloudapi.com",
+		},
 		{
 			desc:     "missing credentials",
 			expected: "tencentcloud: credentials missing",
@@ -100,6 +107,13 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			secretID: "123",
 			expected: "tencentcloud: credentials missing",
 		},
+		{
+			desc:      "invalid endpoint",
+			secretID:  "123",
+			secretKey: "456",
+			endpoint:  "https:///path",
+			expected:  `tencentcloud: invalid endpoint "https:///path": missing host`,
+		},
 	}
 
 	for _, test := range testCases {
@@ -107,6 +121,7 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			config := NewDefaultConfig()
 			config.SecretID = test.secretID
 			config.SecretKey = test.secretKey
+			config.Endpoint = test.endpoint
 
 			p, err := NewDNSProviderConfig(config)
 
@@ -58,7 +58,7 @@ func TestClient_CreateRecord_error(t *testing.T) {
 	_, err := client.CreateRecord(t.Context(), "example.com.", DNSRecord{})
 	require.Error(t, err)
 
-	assert.EqualError(t, err, "400: Value must be a number conforming to the specified constraints (bad_request) [15095f25-aac3-4d60-a788-96cb5136f186]")
+	assert.EqualError(t, err, "400: Value must be a number conforming to the specified constraints (bad_request) [15095f25-aac3-4d60-a788-96cb5136f186] [endpoint: POST /v1/domains/example.com/dns-records]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -82,5 +82,5 @@ func TestClient_DeleteRecord_error(t *testing.T) {
 	err := client.DeleteRecord(t.Context(), "example.com.", 123)
 	require.Error(t, err)
 
-	assert.EqualError(t, err, "401: Unauthorized (unauthorized) [15095f25-aac3-4d60-a788-96cb5136f186]")
+	assert.EqualError(t, err, "401: Unauthorized (unauthorized) [15095f25-aac3-4d60-a788-96cb5136f186] [endpoint: DELETE /v1/domains/example.com/dns-records/123]")
 }
@@ -0,0 +1,242 @@
+package dns
+
+import (
+	"crypto/tls"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v4/internal/dns/descriptors"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/stretchr/testify/require"
+)
+
+// The providers not covered by the TLS policy applied to the default transport (LEGO_TLS_*).
+// This list must be kept in sync with the documentation of the CLI options (docs/content/usage/cli/Options.md).
+var tlsPolicyNotCovered = map[string]string{
+	"acme-dns":     "SDK",
+	"alidns":       "SDK",
+	"aliesa":       "SDK",
+	"auroradns":    "SDK",
+	"azure":        "SDK",
+	"azuredns":     "SDK",
+	"baiducloud":   "SDK",
+	"designate":    "SDK",
+	"dnsimple":     "SDK",
+	"dnsmadeeasy":  "dedicated TLS configuration",
+	"edgedns":      "SDK",
+	"edgeone":      "SDK",
+	"exec":         "external program",
+	"exoscale":     "SDK",
+	"gcloud":       "SDK",
+	"huaweicloud":  "SDK",
+	"ibmcloud":     "SDK",
+	"iij":          "SDK",
+	"iijdpf":       "SDK",
+	"infoblox":     "SDK",
+	"inwx":         "SDK",
+	"jdcloud":      "SDK",
+	"lightsail":    "SDK",
+	"linode":       "SDK",
+	"liquidweb":    "SDK",
+	"manageengine": "SDK",
+	"manual":       "no API",
+	"metaname":     "SDK",
+	"namesilo":     "SDK",
+	"nicru":        "SDK",
+	"oraclecloud":  "SDK",
+	"rfc2136":      "DNS protocol",
+	"route53":      "SDK",
+	"stackpath":    "SDK",
+	"tencentcloud": "SDK",
+	"ultradns":     "SDK",
+	"vkcloud":      "SDK",
+	"volcengine":   "SDK",
+	"yandexcloud":  "SDK",
+}
+
+// TestTLSPolicyCoverage_documentation checks that the providers not covered by the TLS policy are documented.
+func TestTLSPolicyCoverage_documentation(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("..", "..", "docs", "content", "usage", "cli", "Options.md"))
+	require.NoError(t, err)
+
+	_, section, found := strings.Cut(string(content), "### LEGO_TLS_MIN_VERSION")
+	require.True(t, found)
+
+	section, _, _ = strings.Cut(section, "\n### ")
+
+	for _, code := range slices.Sorted(maps.Keys(tlsPolicyNotCovered)) {
+		if !strings.Contains(section, "`"+code+"`") {
+			t.Errorf("%s is not documented", code)
+		}
+	}
+}
+
+// Specific values used to create the providers (in addition to the credentials).
+var tlsPolicyValues = map[string]map[string]string{
+	"exec": {"EXEC_PATH": "lego"},
+}
+
+// TestTLSPolicyCoverage checks the boundary of the TLS policy applied to the default transport:
+// the HTTP client of a covered provider uses the default transport, or a transport cloned from it.
+func TestTLSPolicyCoverage(t *testing.T) {
+	info, err := descriptors.GetProviderInformation("../../")
+	require.NoError(t, err)
+
+	setTLSPolicyMarker(t)
+
+	var files []string
+
+	for _, content := range []string{"example.com:lego", "example.com:lego2"} {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+		files = append(files, file)
+	}
+
+	for _, desc := range info.Providers {
+		t.Run(desc.Code, func(t *testing.T) {
+			_, notCovered := tlsPolicyNotCovered[desc.Code]
+
+			config, err := readTLSPolicyConfig(desc, files)
+			if err != nil {
+				if reason, ok := configCoverageSkipped[desc.Code]; ok {
+					t.Skip(reason)
+				}
+
+				require.NoError(t, err)
+			}
+
+			covered := usesTLSPolicy(reflect.ValueOf(config))
+
+			switch {
+			case covered && notCovered:
+				t.Errorf("the provider uses the TLS policy: remove it from the list of the providers not covered")
+			case !covered && !notCovered:
+				t.Errorf("the provider doesn't use the TLS policy: add it to the list of the providers not covered")
+			}
+		})
+	}
+}
+
+// setTLSPolicyMarker replaces the default transport by a transport with a recognizable TLS policy (like the CLI does).
+func setTLSPolicyMarker(t *testing.T) {
+	t.Helper()
+
+	original := http.DefaultTransport
+
+	tr, err := (&lego.TLSPolicy{MinVersion: tls.VersionTLS13}).CloneTransport(original)
+	require.NoError(t, err)
+
+	http.DefaultTransport = tr
+
+	t.Cleanup(func() { http.DefaultTransport = original })
+}
+
+// readTLSPolicyConfig creates the provider with the first combination of credentials accepted by the provider.
+func readTLSPolicyConfig(desc descriptors.Provider, files []string) (any, error) {
+	var credentials []string
+	if desc.Configuration != nil {
+		credentials = slices.Sorted(maps.Keys(desc.Configuration.Credentials))
+	}
+
+	credentials = append(credentials, configCoverageAlternativeCredentials[desc.Code]...)
+
+	var (
+		config any
+		err    error
+	)
+
+	for mask := range 1 << len(credentials) {
+		values := maps.Clone(tlsPolicyValues[desc.Code])
+		if values == nil {
+			values = map[string]string{}
+		}
+
+		for i, name := range credentials {
+			if mask&(1<<i) == 0 {
+				values[name] = configCoverageValue(name, files)[0]
+			}
+		}
+
+		config, err = readProviderConfig(desc.Code, values)
+		if err == nil {
+			return config, nil
+		}
+	}
+
+	return nil, err
+}
+
+// usesTLSPolicy returns true if the config has an HTTP client based on the default transport.
+// A nil HTTP client is replaced by a client based on the default transport.
+func usesTLSPolicy(v reflect.Value) bool {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return false
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := range v.NumField() {
+		field := v.Field(i)
+
+		if field.Type() != reflect.TypeFor[*http.Client]() || !field.CanInterface() {
+			continue
+		}
+
+		client := field.Interface().(*http.Client)
+		if client == nil {
+			return true
+		}
+
+		return usesDefaultTransport(client.Transport)
+	}
+
+	return false
+}
+
+// usesDefaultTransport returns true if the transport is the default transport, a clone of it, or a wrapper of them.
+func usesDefaultTransport(rt http.RoundTripper) bool {
+	if rt == nil || rt == http.DefaultTransport {
+		return true
+	}
+
+	if tr, ok := rt.(*http.Transport); ok {
+		return tr.TLSClientConfig != nil && tr.TLSClientConfig.MinVersion == tls.VersionTLS13
+	}
+
+	// The wrappers (ex: the authentication transports) delegate to an underlying transport.
+	v := reflect.ValueOf(rt)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := range v.NumField() {
+		field := v.Field(i)
+
+		if field.Type() != reflect.TypeFor[http.RoundTripper]() || !field.CanInterface() {
+			continue
+		}
+
+		next, _ := field.Interface().(http.RoundTripper)
+
+		return usesDefaultTransport(next)
+	}
+
+	return false
+}
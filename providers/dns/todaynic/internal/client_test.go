@@ -75,7 +75,7 @@ func TestClient_AddRecord_error(t *testing.T) {
 	}
 
 	_, err := client.AddRecord(t.Context(), record)
-	require.EqualError(t, err, "host.repeat (2d5876b2-f272-43e9-acc1-4c6a3d3683b1)")
+	require.EqualError(t, err, "host.repeat (2d5876b2-f272-43e9-acc1-4c6a3d3683b1) [endpoint: GET /api/dns/add-domain-record.json]")
 }
 
 func TestClient_DeleteRecord(t *testing.T) {
@@ -8,7 +8,6 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/ultradns/ultradns-go-sdk/pkg/client"
 	"github.com/ultradns/ultradns-go-sdk/pkg/record"
@@ -16,19 +15,6 @@ import (
 	"github.com/ultradns/ultradns-go-sdk/pkg/zone"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "ULTRADNS_"
-
-	EnvUsername = envNamespace + "USERNAME"
-	EnvPassword = envNamespace + "PASSWORD"
-	EnvEndpoint = envNamespace + "ENDPOINT"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-)
-
 const defaultEndpoint = "https://api.ultradns.com/"
 
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
@@ -39,43 +25,6 @@ type DNSProvider struct {
 	client *client.Client
 }
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	Username string
-	Password string
-	Endpoint string
-
-	TTL                int
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		Endpoint:           env.GetOrDefaultString(EnvEndpoint, defaultEndpoint),
-		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 4*time.Second),
-	}
-}
-
-// NewDNSProvider returns a DNSProvider instance configured for ultradns.
-// Credentials must be passed in the environment variables:
-// ULTRADNS_USERNAME and ULTRADNS_PASSWORD.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvUsername, EnvPassword)
-	if err != nil {
-		return nil, fmt.Errorf("ultradns: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.Username = values[EnvUsername]
-	config.Password = values[EnvPassword]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for ultradns.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -109,13 +58,13 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	authZone, err := dns01.FindZoneByFqdn(info.EffectiveFQDN)
 
 	if err != nil {
-	 	return fmt.Errorf("ultradns: could not find zone for domain %q: %w", domain, err)
+		return fmt.Errorf("ultradns: could not find zone for domain %q: %w", domain, err)
 	}
 
-	zoneService,err := zone.Get(d.client)
+	zoneService, err := zone.Get(d.client)
 	if err != nil {
 		return fmt.Errorf("ultradns: %w", err)
-	} 
+	}
 
 	_, resZone, err := zoneService.ReadZone(authZone)
 
@@ -125,7 +74,7 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	if resZone.OriginalZoneName != "" {
 		zoneOrAlias = resZone.OriginalZoneName
 		EffectiveFQDN = "_acme-challenge." + zoneOrAlias
-	} 
+	}
 
 	if err != nil {
 		return fmt.Errorf("ultradns: %w", err)
@@ -199,9 +148,9 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 
 	_, err = recordService.Delete(rrSetKeyData)
 	if err != nil {
-		return nil 
+		return nil
 	}
 
 	return nil
 
-}
\ No newline at end of file
+}
@@ -45,12 +45,17 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, 10),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 12*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, time.Minute),
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, 10),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 12*time.Minute),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, time.Minute),
 		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+			Timeout: lookup.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
 		},
 	}
 }
@@ -143,7 +143,7 @@ func TestDNSProvider_Present(t *testing.T) {
 				Route("GET /domains/example.com",
 					servermock.ResponseFromFixture("error_failToFindZone.json").
 						WithStatusCode(http.StatusUnauthorized)),
-			expectedError: `versio: [status code: 401] 401: ObjectDoesNotExist|Domain not found`,
+			expectedError: `versio: [status code: 401] 401: ObjectDoesNotExist|Domain not found [endpoint: GET /domains/example.com]`,
 		},
 		{
 			desc: "FailToCreateTXT",
@@ -155,7 +155,7 @@ func TestDNSProvider_Present(t *testing.T) {
 				Route("POST /domains/example.com/update",
 					servermock.ResponseFromFixture("error_failToCreateTXT.json").
 						WithStatusCode(http.StatusBadRequest)),
-			expectedError: `versio: [status code: 400] 400: ProcessError|DNS record invalid type _acme-challenge.example.eu. TST`,
+			expectedError: `versio: [status code: 400] 400: ProcessError|DNS record invalid type _acme-challenge.example.eu. TST [endpoint: POST /domains/example.com/update]`,
 		},
 	}
 
@@ -199,7 +199,7 @@ func TestDNSProvider_CleanUp(t *testing.T) {
 				Route("GET /domains/example.com",
 					servermock.ResponseFromFixture("error_failToFindZone.json").
 						WithStatusCode(http.StatusUnauthorized)),
-			expectedError: `versio: [status code: 401] 401: ObjectDoesNotExist|Domain not found`,
+			expectedError: `versio: [status code: 401] 401: ObjectDoesNotExist|Domain not found [endpoint: GET /domains/example.com]`,
 		},
 	}
 
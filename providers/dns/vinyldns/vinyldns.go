@@ -5,84 +5,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/internal/clientdebug"
 	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/vinyldns/go-vinyldns/vinyldns"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "VINYLDNS_"
-
-	EnvAccessKey  = envNamespace + "ACCESS_KEY"
-	EnvSecretKey  = envNamespace + "SECRET_KEY"
-	EnvHost       = envNamespace + "HOST"
-	EnvQuoteValue = envNamespace + "QUOTE_VALUE"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
-)
-
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	AccessKey  string
-	SecretKey  string
-	Host       string
-	QuoteValue bool
-
-	TTL                int
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	HTTPClient         *http.Client
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, 30),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 4*time.Second),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	client *vinyldns.Client
 	config *Config
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for VinylDNS.
-// Credentials must be passed in the environment variables:
-// VINYLDNS_ACCESS_KEY, VINYLDNS_SECRET_KEY, VINYLDNS_HOST.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvAccessKey, EnvSecretKey, EnvHost)
-	if err != nil {
-		return nil, fmt.Errorf("vinyldns: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.AccessKey = values[EnvAccessKey]
-	config.SecretKey = values[EnvSecretKey]
-	config.Host = values[EnvHost]
-	config.QuoteValue = env.GetOrDefaultBool(EnvQuoteValue, false)
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for VinylDNS.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
 )
@@ -94,9 +95,9 @@ func (c *Client) request(method string, endpoint *url.URL, options *gophercloud.
 		return fmt.Errorf("auth: %w", err)
 	}
 
-	_, err := c.openstack.Request(method, endpoint.String(), options)
+	resp, err := c.openstack.Request(method, endpoint.String(), options)
 	if err != nil {
-		return fmt.Errorf("request: %w", err)
+		return providers.NewEndpointAPIError(method, endpoint, resp, fmt.Errorf("request: %w", err))
 	}
 
 	return nil
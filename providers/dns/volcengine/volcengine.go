@@ -56,15 +56,20 @@ type Config struct {
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
 	return &Config{
-		Scheme: env.GetOrDefaultString(EnvScheme, "https"),
-		Host:   env.GetOrDefaultString(EnvHost, "open.volcengineapi.com"),
-		Region: env.GetOrDefaultString(EnvRegion, volc.DefaultRegion),
-
-		TTL:                env.GetOrDefaultInt(EnvTTL, defaultTTL),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 4*time.Minute),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 10*time.Second),
-		HTTPTimeout:        env.GetOrDefaultSecond(EnvHTTPTimeout, volc.Timeout*time.Second),
+		Scheme: lookup.GetOrDefaultString(EnvScheme, "https"),
+		Host:   lookup.GetOrDefaultString(EnvHost, "open.volcengineapi.com"),
+		Region: lookup.GetOrDefaultString(EnvRegion, volc.DefaultRegion),
+
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, defaultTTL),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, 4*time.Minute),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, 10*time.Second),
+		HTTPTimeout:        lookup.GetOrDefaultSecond(EnvHTTPTimeout, volc.Timeout*time.Second),
 	}
 }
 
@@ -216,6 +216,69 @@ func TestDNSProvider_getHostedZone(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_findTxtRecords(t *testing.T) {
+	type recordsBase struct {
+		Records []govultr.DomainRecord `json:"records"`
+		Meta    *govultr.Meta          `json:"meta"`
+	}
+
+	var pageCount int
+
+	provider := servermock.NewBuilder(
+		func(server *httptest.Server) (*DNSProvider, error) {
+			client := govultr.NewClient(server.Client())
+			err := client.SetBaseURL(server.URL)
+			require.NoError(t, err)
+
+			return &DNSProvider{client: client}, nil
+		},
+	).
+		Route("GET /v2/domains",
+			servermock.JSONEncode(map[string]any{
+				"domains": []govultr.Domain{{Domain: "example.com"}},
+				"meta":    &govultr.Meta{Total: 1, Links: &govultr.Links{}},
+			})).
+		Route("GET /v2/domains/example.com/records",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				pageCount++
+
+				response := recordsBase{
+					Records: []govultr.DomainRecord{
+						{ID: "1", Type: "TXT", Name: "_acme-challenge", Data: `"a"`},
+						{ID: "2", Type: "A", Name: "_acme-challenge", Data: "127.0.0.1"},
+					},
+					Meta: &govultr.Meta{Total: 4, Links: &govultr.Links{Next: "page2"}},
+				}
+
+				if req.URL.Query().Get("cursor") == "page2" {
+					response = recordsBase{
+						Records: []govultr.DomainRecord{
+							{ID: "3", Type: "TXT", Name: "_acme-challenge", Data: `"b"`},
+							{ID: "4", Type: "TXT", Name: "www", Data: `"c"`},
+						},
+						Meta: &govultr.Meta{Total: 4, Links: &govultr.Links{}},
+					}
+				}
+
+				servermock.JSONEncode(response).ServeHTTP(rw, req)
+			}),
+			servermock.CheckQueryParameter().
+				With("per_page", "500")).
+		Build(t)
+
+	zone, records, err := provider.findTxtRecords(t.Context(), "example.com", "_acme-challenge.example.com.")
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", zone)
+	assert.Equal(t, 2, pageCount)
+
+	expected := []govultr.DomainRecord{
+		{ID: "1", Type: "TXT", Name: "_acme-challenge", Data: `"a"`},
+		{ID: "3", Type: "TXT", Name: "_acme-challenge", Data: `"b"`},
+	}
+	assert.Equal(t, expected, records)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
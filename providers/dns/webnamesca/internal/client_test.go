@@ -60,7 +60,7 @@ func TestClient_AddTXTRecord_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.AddTXTRecord(t.Context(), "example.com", "foo.example.com", "value")
-	require.EqualError(t, err, "message: User does not exist., details: string, logiD: 35579, result: {}")
+	require.EqualError(t, err, "message: User does not exist., details: string, logiD: 35579, result: {} [endpoint: POST /domains/example.com/add-txt-record]")
 }
 
 func TestClient_DeleteTXTRecord(t *testing.T) {
@@ -92,5 +92,5 @@ func TestClient_DeleteTXTRecord_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.DeleteTXTRecord(t.Context(), "example.com", "foo.example.com", "value")
-	require.EqualError(t, err, "message: User does not exist., details: string, logiD: 35579, result: {}")
+	require.EqualError(t, err, "message: User does not exist., details: string, logiD: 35579, result: {} [endpoint: DELETE /domains/example.com/delete-txt-record]")
 }
@@ -75,30 +75,42 @@ func TestNewDNSProvider(t *testing.T) {
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc     string
+		ttl      int
 		username string
 		password string
 		expected string
 	}{
 		{
 			desc:     "success",
+			ttl:      minTTL,
 			username: "admin@example.com",
 			password: "secret",
 		},
 		{
 			desc:     "missing username",
+			ttl:      minTTL,
 			password: "secret",
 			expected: "wedos: some credentials information are missing",
 		},
 		{
 			desc:     "missing WAPI password",
+			ttl:      minTTL,
 			username: "admin@example.com",
 			expected: "wedos: some credentials information are missing",
 		},
+		{
+			desc:     "invalid TTL",
+			ttl:      299,
+			username: "admin@example.com",
+			password: "secret",
+			expected: "wedos: invalid TTL, TTL (299) must be greater than 300",
+		},
 	}
 
 	for _, test := range testCases {
 		t.Run(test.desc, func(t *testing.T) {
 			config := NewDefaultConfig()
+			config.TTL = test.ttl
 			config.Username = test.username
 			config.Password = test.password
 
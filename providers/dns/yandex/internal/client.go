@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 	querystring "github.com/google/go-querystring/query"
 )
@@ -121,7 +122,7 @@ func (c *Client) do(req *http.Request, result Response) error {
 	}
 
 	if result.GetSuccess() != successCode {
-		return fmt.Errorf("error during operation: %s %s", result.GetSuccess(), result.GetError())
+		return providers.NewAPIError(req, resp, fmt.Errorf("error during operation: %s %s", result.GetSuccess(), result.GetError()))
 	}
 
 	return nil
@@ -66,7 +66,7 @@ func TestAddRecord_error(t *testing.T) {
 	}
 
 	_, err := client.AddRecord(t.Context(), data)
-	require.EqualError(t, err, "error during operation: error bad things")
+	require.EqualError(t, err, "error during operation: error bad things [endpoint: POST /add]")
 }
 
 func TestRemoveRecord(t *testing.T) {
@@ -105,7 +105,7 @@ func TestRemoveRecord_error(t *testing.T) {
 	}
 
 	_, err := client.RemoveRecord(t.Context(), data)
-	require.EqualError(t, err, "error during operation: error bad things")
+	require.EqualError(t, err, "error during operation: error bad things [endpoint: POST /del]")
 }
 
 func TestGetRecords(t *testing.T) {
@@ -129,5 +129,5 @@ func TestGetRecords_error(t *testing.T) {
 		Build(t)
 
 	_, err := client.GetRecords(t.Context(), "example.com")
-	require.EqualError(t, err, "error during operation: error bad things")
+	require.EqualError(t, err, "error during operation: error bad things [endpoint: GET /list]")
 }
@@ -7,13 +7,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/challenge/dns01"
-	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/cloudendpoint"
 	ycdnsproto "github.com/yandex-cloud/go-genproto/yandex/cloud/dns/v1"
 	ycdns "github.com/yandex-cloud/go-sdk/services/dns/v1"
 	ycsdk "github.com/yandex-cloud/go-sdk/v2"
@@ -22,59 +23,14 @@ import (
 	"github.com/yandex-cloud/go-sdk/v2/pkg/options"
 )
 
-// Environment variables names.
-const (
-	envNamespace = "YANDEX_CLOUD_"
-
-	EnvIamToken = envNamespace + "IAM_TOKEN"
-	EnvFolderID = envNamespace + "FOLDER_ID"
-
-	EnvTTL                = envNamespace + "TTL"
-	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
-	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
-)
-
 var _ challenge.ProviderTimeout = (*DNSProvider)(nil)
 
-// Config is used to configure the creation of the DNSProvider.
-type Config struct {
-	IamToken string
-	FolderID string
-
-	PropagationTimeout time.Duration
-	PollingInterval    time.Duration
-	TTL                int
-}
-
-// NewDefaultConfig returns a default configuration for the DNSProvider.
-func NewDefaultConfig() *Config {
-	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, 60),
-		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
-		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-	}
-}
-
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	client ycdns.DnsZoneClient
 	config *Config
 }
 
-// NewDNSProvider returns a DNSProvider instance configured for Yandex Cloud.
-func NewDNSProvider() (*DNSProvider, error) {
-	values, err := env.Get(EnvIamToken, EnvFolderID)
-	if err != nil {
-		return nil, fmt.Errorf("yandexcloud: %w", err)
-	}
-
-	config := NewDefaultConfig()
-	config.IamToken = values[EnvIamToken]
-	config.FolderID = values[EnvFolderID]
-
-	return NewDNSProviderConfig(config)
-}
-
 // NewDNSProviderConfig return a DNSProvider instance configured for Yandex Cloud.
 func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	if config == nil {
@@ -94,7 +50,18 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("yandexcloud: iam token is malformed: %w", err)
 	}
 
-	sdk, err := ycsdk.Build(context.Background(), options.WithCredentials(creds))
+	opts := []options.Option{options.WithCredentials(creds)}
+
+	if config.Endpoint != "" {
+		endpoint, errE := getDiscoveryEndpoint(config.Endpoint)
+		if errE != nil {
+			return nil, fmt.Errorf("yandexcloud: %w", errE)
+		}
+
+		opts = append(opts, options.WithDiscoveryEndpoint(endpoint))
+	}
+
+	sdk, err := ycsdk.Build(context.Background(), opts...)
 	if err != nil {
 		return nil, errors.New("yandexcloud: unable to build yandex cloud sdk")
 	}
@@ -326,3 +293,17 @@ func appendRecordSetData(record *ycdnsproto.RecordSet, value string) bool {
 
 	return true
 }
+
+// getDiscoveryEndpoint returns the endpoint (host:port) used by the SDK to discover the endpoints of the services.
+func getDiscoveryEndpoint(endpoint string) (string, error) {
+	u, err := cloudendpoint.URL(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	if u.Port() != "" {
+		return u.Host, nil
+	}
+
+	return net.JoinHostPort(u.Hostname(), "443"), nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -138,6 +139,41 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func Test_getDiscoveryEndpoint(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		endpoint string
+		expected string
+	}{
+		{
+			desc:     "host and port",
+			endpoint: "api.yandexcloud.kz:443",
+			expected: "api.yandexcloud.kz:443",
+		},
+		{
+			desc:     "host",
+			endpoint: "api.yandexcloud.kz",
+			expected: "api.yandexcloud.kz:443",
+		},
+		{
+			desc:     "URL",
+			endpoint: "https://api.yandexcloud.kz/",
+			expected: "api.yandexcloud.kz:443",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			endpoint, err := getDiscoveryEndpoint(test.endpoint)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, endpoint)
+		})
+	}
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -0,0 +1,65 @@
+// Code generated by 'make generate-dns'; DO NOT EDIT.
+
+package yandexcloud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "YANDEX_CLOUD_"
+
+	EnvIamToken = envNamespace + "IAM_TOKEN"
+	EnvFolderID = envNamespace + "FOLDER_ID"
+	EnvEndpoint = envNamespace + "ENDPOINT"
+
+	EnvTTL                = envNamespace + "TTL"
+	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
+)
+
+// Config is used to configure the creation of the DNSProvider.
+type Config struct {
+	IamToken           string
+	FolderID           string
+	Endpoint           string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return NewDefaultConfigFrom(env.Lookup)
+}
+
+// NewDefaultConfigFrom returns a default configuration for the DNSProvider with the environment variables read from lookup.
+func NewDefaultConfigFrom(lookup env.LookupFunc) *Config {
+	return &Config{
+		TTL:                lookup.GetOrDefaultInt(EnvTTL, 60),
+		PropagationTimeout: lookup.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    lookup.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+	}
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Yandex Cloud.
+// Credentials must be passed in the environment variables:
+// YANDEX_CLOUD_IAM_TOKEN, YANDEX_CLOUD_FOLDER_ID.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get(EnvIamToken, EnvFolderID)
+	if err != nil {
+		return nil, fmt.Errorf("yandexcloud: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.IamToken = values[EnvIamToken]
+	config.FolderID = values[EnvFolderID]
+	config.Endpoint = env.GetOrFile(EnvEndpoint)
+
+	return NewDNSProviderConfig(config)
+}
@@ -11,6 +11,7 @@ import (
 	"slices"
 	"time"
 
+	"github.com/go-acme/lego/v4/providers"
 	"github.com/go-acme/lego/v4/providers/dns/internal/errutils"
 )
 
@@ -78,7 +79,7 @@ func (c *Client) do(req *http.Request) error {
 	if resp.StatusCode/100 != 2 {
 		raw, _ := io.ReadAll(resp.Body)
 
-		return errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw)
+		return providers.NewAPIError(req, resp, errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw))
 	}
 
 	raw, err := io.ReadAll(resp.Body)
@@ -101,7 +102,7 @@ func (c *Client) do(req *http.Request) error {
 
 	err = xml.Unmarshal(raw, &apiErr)
 	if err != nil {
-		return errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw)
+		return providers.NewAPIError(req, resp, errutils.NewUnexpectedStatusCodeError(req, resp.StatusCode, raw))
 	}
 
 	return fmt.Errorf("[status code: %d] %w", resp.StatusCode, apiErr)
@@ -161,12 +161,12 @@ func TestDNSProvider_Present(t *testing.T) {
 			desc: "invalid auth",
 			builder: mockBuilder("nope", "nope").
 				Route("POST /dns/"+hostedZone+"/txt", nil),
-			expectedError: "zoneee: unexpected status code: [status code: 401] body: Unauthorized",
+			expectedError: "zoneee: unexpected status code: [status code: 401] body: Unauthorized [endpoint: POST /dns/example.com/txt]",
 		},
 		{
 			desc:          "error",
 			builder:       mockBuilder(fakeUsername, fakeAPIKey),
-			expectedError: "zoneee: unexpected status code: [status code: 404] body: 404 page not found",
+			expectedError: "zoneee: unexpected status code: [status code: 404] body: 404 page not found [endpoint: POST /dns/example.com/txt]",
 		},
 	}
 
@@ -219,12 +219,12 @@ func TestDNSProvider_Cleanup(t *testing.T) {
 			desc: "invalid auth",
 			builder: mockBuilder("nope", "nope").
 				Route("GET /dns/"+hostedZone+"/txt", nil),
-			expectedError: "zoneee: unexpected status code: [status code: 401] body: Unauthorized",
+			expectedError: "zoneee: unexpected status code: [status code: 401] body: Unauthorized [endpoint: GET /dns/example.com/txt]",
 		},
 		{
 			desc:          "error",
 			builder:       mockBuilder(fakeUsername, fakeAPIKey),
-			expectedError: "zoneee: unexpected status code: [status code: 404] body: 404 page not found",
+			expectedError: "zoneee: unexpected status code: [status code: 404] body: 404 page not found [endpoint: GET /dns/example.com/txt]",
 		},
 	}
 
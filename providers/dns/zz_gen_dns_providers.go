@@ -582,3 +582,770 @@ func NewDNSChallengeProviderByName(name string) (challenge.Provider, error) {
 		return nil, fmt.Errorf("unrecognized DNS provider: %s", name)
 	}
 }
+
+// NewDNSChallengeProviderByNameWithOptions creates a DNS provider from its name and options, without the environment variables.
+// The options are the fields of the Config of the provider (ex: "AuthToken", "TTL", "HTTPClient.Timeout"),
+// the other fields keep the values of NewDefaultConfig.
+// The providers can be created concurrently with different options.
+func NewDNSChallengeProviderByNameWithOptions(name string, opts map[string]string) (challenge.Provider, error) {
+	switch name {
+	case "acme-dns", "acmedns":
+		return newDNSProviderWithOptions(acmedns.NewDefaultConfigFrom, acmedns.NewDNSProviderConfig, opts)
+	case "active24":
+		return newDNSProviderWithOptions(active24.NewDefaultConfigFrom, active24.NewDNSProviderConfig, opts)
+	case "alidns":
+		return newDNSProviderWithOptions(alidns.NewDefaultConfigFrom, alidns.NewDNSProviderConfig, opts)
+	case "aliesa":
+		return newDNSProviderWithOptions(aliesa.NewDefaultConfigFrom, aliesa.NewDNSProviderConfig, opts)
+	case "allinkl":
+		return newDNSProviderWithOptions(allinkl.NewDefaultConfigFrom, allinkl.NewDNSProviderConfig, opts)
+	case "alwaysdata":
+		return newDNSProviderWithOptions(alwaysdata.NewDefaultConfigFrom, alwaysdata.NewDNSProviderConfig, opts)
+	case "anexia":
+		return newDNSProviderWithOptions(anexia.NewDefaultConfigFrom, anexia.NewDNSProviderConfig, opts)
+	case "artfiles":
+		return newDNSProviderWithOptions(artfiles.NewDefaultConfigFrom, artfiles.NewDNSProviderConfig, opts)
+	case "arvancloud":
+		return newDNSProviderWithOptions(arvancloud.NewDefaultConfigFrom, arvancloud.NewDNSProviderConfig, opts)
+	case "auroradns":
+		return newDNSProviderWithOptions(auroradns.NewDefaultConfigFrom, auroradns.NewDNSProviderConfig, opts)
+	case "autodns":
+		return newDNSProviderWithOptions(autodns.NewDefaultConfigFrom, autodns.NewDNSProviderConfig, opts)
+	case "axelname":
+		return newDNSProviderWithOptions(axelname.NewDefaultConfigFrom, axelname.NewDNSProviderConfig, opts)
+	case "azion":
+		return newDNSProviderWithOptions(azion.NewDefaultConfigFrom, azion.NewDNSProviderConfig, opts)
+	case "azure":
+		return newDNSProviderWithOptions(azure.NewDefaultConfigFrom, azure.NewDNSProviderConfig, opts)
+	case "azuredns":
+		return newDNSProviderWithOptions(azuredns.NewDefaultConfigFrom, azuredns.NewDNSProviderConfig, opts)
+	case "baiducloud":
+		return newDNSProviderWithOptions(baiducloud.NewDefaultConfigFrom, baiducloud.NewDNSProviderConfig, opts)
+	case "beget":
+		return newDNSProviderWithOptions(beget.NewDefaultConfigFrom, beget.NewDNSProviderConfig, opts)
+	case "binarylane":
+		return newDNSProviderWithOptions(binarylane.NewDefaultConfigFrom, binarylane.NewDNSProviderConfig, opts)
+	case "bindman":
+		return newDNSProviderWithOptions(bindman.NewDefaultConfigFrom, bindman.NewDNSProviderConfig, opts)
+	case "bluecat":
+		return newDNSProviderWithOptions(bluecat.NewDefaultConfigFrom, bluecat.NewDNSProviderConfig, opts)
+	case "bluecatv2":
+		return newDNSProviderWithOptions(bluecatv2.NewDefaultConfigFrom, bluecatv2.NewDNSProviderConfig, opts)
+	case "bookmyname":
+		return newDNSProviderWithOptions(bookmyname.NewDefaultConfigFrom, bookmyname.NewDNSProviderConfig, opts)
+	case "brandit":
+		return newDNSProviderWithOptions(brandit.NewDefaultConfigFrom, brandit.NewDNSProviderConfig, opts)
+	case "bunny":
+		return newDNSProviderWithOptions(bunny.NewDefaultConfigFrom, bunny.NewDNSProviderConfig, opts)
+	case "checkdomain":
+		return newDNSProviderWithOptions(checkdomain.NewDefaultConfigFrom, checkdomain.NewDNSProviderConfig, opts)
+	case "civo":
+		return newDNSProviderWithOptions(civo.NewDefaultConfigFrom, civo.NewDNSProviderConfig, opts)
+	case "clouddns":
+		return newDNSProviderWithOptions(clouddns.NewDefaultConfigFrom, clouddns.NewDNSProviderConfig, opts)
+	case "cloudflare":
+		return newDNSProviderWithOptions(cloudflare.NewDefaultConfigFrom, cloudflare.NewDNSProviderConfig, opts)
+	case "cloudns":
+		return newDNSProviderWithOptions(cloudns.NewDefaultConfigFrom, cloudns.NewDNSProviderConfig, opts)
+	case "cloudru":
+		return newDNSProviderWithOptions(cloudru.NewDefaultConfigFrom, cloudru.NewDNSProviderConfig, opts)
+	case "cloudxns":
+		return newDNSProviderWithOptions(cloudxns.NewDefaultConfigFrom, cloudxns.NewDNSProviderConfig, opts)
+	case "com35":
+		return newDNSProviderWithOptions(com35.NewDefaultConfigFrom, com35.NewDNSProviderConfig, opts)
+	case "conoha":
+		return newDNSProviderWithOptions(conoha.NewDefaultConfigFrom, conoha.NewDNSProviderConfig, opts)
+	case "conohav3":
+		return newDNSProviderWithOptions(conohav3.NewDefaultConfigFrom, conohav3.NewDNSProviderConfig, opts)
+	case "constellix":
+		return newDNSProviderWithOptions(constellix.NewDefaultConfigFrom, constellix.NewDNSProviderConfig, opts)
+	case "corenetworks":
+		return newDNSProviderWithOptions(corenetworks.NewDefaultConfigFrom, corenetworks.NewDNSProviderConfig, opts)
+	case "cpanel":
+		return newDNSProviderWithOptions(cpanel.NewDefaultConfigFrom, cpanel.NewDNSProviderConfig, opts)
+	case "czechia":
+		return newDNSProviderWithOptions(czechia.NewDefaultConfigFrom, czechia.NewDNSProviderConfig, opts)
+	case "ddnss":
+		return newDNSProviderWithOptions(ddnss.NewDefaultConfigFrom, ddnss.NewDNSProviderConfig, opts)
+	case "derak":
+		return newDNSProviderWithOptions(derak.NewDefaultConfigFrom, derak.NewDNSProviderConfig, opts)
+	case "desec":
+		return newDNSProviderWithOptions(desec.NewDefaultConfigFrom, desec.NewDNSProviderConfig, opts)
+	case "designate":
+		return newDNSProviderWithOptions(designate.NewDefaultConfigFrom, designate.NewDNSProviderConfig, opts)
+	case "digitalocean":
+		return newDNSProviderWithOptions(digitalocean.NewDefaultConfigFrom, digitalocean.NewDNSProviderConfig, opts)
+	case "directadmin":
+		return newDNSProviderWithOptions(directadmin.NewDefaultConfigFrom, directadmin.NewDNSProviderConfig, opts)
+	case "dnsexit":
+		return newDNSProviderWithOptions(dnsexit.NewDefaultConfigFrom, dnsexit.NewDNSProviderConfig, opts)
+	case "dnshomede":
+		return newDNSProviderWithOptions(dnshomede.NewDefaultConfigFrom, dnshomede.NewDNSProviderConfig, opts)
+	case "dnsimple":
+		return newDNSProviderWithOptions(dnsimple.NewDefaultConfigFrom, dnsimple.NewDNSProviderConfig, opts)
+	case "dnsmadeeasy":
+		return newDNSProviderWithOptions(dnsmadeeasy.NewDefaultConfigFrom, dnsmadeeasy.NewDNSProviderConfig, opts)
+	case "dnspod":
+		return newDNSProviderWithOptions(dnspod.NewDefaultConfigFrom, dnspod.NewDNSProviderConfig, opts)
+	case "dode":
+		return newDNSProviderWithOptions(dode.NewDefaultConfigFrom, dode.NewDNSProviderConfig, opts)
+	case "domeneshop", "domainnameshop":
+		return newDNSProviderWithOptions(domeneshop.NewDefaultConfigFrom, domeneshop.NewDNSProviderConfig, opts)
+	case "dreamhost":
+		return newDNSProviderWithOptions(dreamhost.NewDefaultConfigFrom, dreamhost.NewDNSProviderConfig, opts)
+	case "duckdns":
+		return newDNSProviderWithOptions(duckdns.NewDefaultConfigFrom, duckdns.NewDNSProviderConfig, opts)
+	case "dyn":
+		return newDNSProviderWithOptions(dyn.NewDefaultConfigFrom, dyn.NewDNSProviderConfig, opts)
+	case "dyndnsfree":
+		return newDNSProviderWithOptions(dyndnsfree.NewDefaultConfigFrom, dyndnsfree.NewDNSProviderConfig, opts)
+	case "dynu":
+		return newDNSProviderWithOptions(dynu.NewDefaultConfigFrom, dynu.NewDNSProviderConfig, opts)
+	case "easydns":
+		return newDNSProviderWithOptions(easydns.NewDefaultConfigFrom, easydns.NewDNSProviderConfig, opts)
+	case "edgecenter":
+		return newDNSProviderWithOptions(edgecenter.NewDefaultConfigFrom, edgecenter.NewDNSProviderConfig, opts)
+	case "edgedns", "fastdns":
+		return newDNSProviderWithOptions(edgedns.NewDefaultConfigFrom, edgedns.NewDNSProviderConfig, opts)
+	case "edgeone":
+		return newDNSProviderWithOptions(edgeone.NewDefaultConfigFrom, edgeone.NewDNSProviderConfig, opts)
+	case "efficientip":
+		return newDNSProviderWithOptions(efficientip.NewDefaultConfigFrom, efficientip.NewDNSProviderConfig, opts)
+	case "epik":
+		return newDNSProviderWithOptions(epik.NewDefaultConfigFrom, epik.NewDNSProviderConfig, opts)
+	case "exec":
+		return newDNSProviderWithOptions(exec.NewDefaultConfigFrom, exec.NewDNSProviderConfig, opts)
+	case "exoscale":
+		return newDNSProviderWithOptions(exoscale.NewDefaultConfigFrom, exoscale.NewDNSProviderConfig, opts)
+	case "f5xc":
+		return newDNSProviderWithOptions(f5xc.NewDefaultConfigFrom, f5xc.NewDNSProviderConfig, opts)
+	case "freemyip":
+		return newDNSProviderWithOptions(freemyip.NewDefaultConfigFrom, freemyip.NewDNSProviderConfig, opts)
+	case "gandi":
+		return newDNSProviderWithOptions(gandi.NewDefaultConfigFrom, gandi.NewDNSProviderConfig, opts)
+	case "gandiv5":
+		return newDNSProviderWithOptions(gandiv5.NewDefaultConfigFrom, gandiv5.NewDNSProviderConfig, opts)
+	case "gcloud":
+		return newDNSProviderWithOptions(gcloud.NewDefaultConfigFrom, gcloud.NewDNSProviderConfig, opts)
+	case "gcore":
+		return newDNSProviderWithOptions(gcore.NewDefaultConfigFrom, gcore.NewDNSProviderConfig, opts)
+	case "gigahostno":
+		return newDNSProviderWithOptions(gigahostno.NewDefaultConfigFrom, gigahostno.NewDNSProviderConfig, opts)
+	case "glesys":
+		return newDNSProviderWithOptions(glesys.NewDefaultConfigFrom, glesys.NewDNSProviderConfig, opts)
+	case "godaddy":
+		return newDNSProviderWithOptions(godaddy.NewDefaultConfigFrom, godaddy.NewDNSProviderConfig, opts)
+	case "googledomains":
+		return newDNSProviderWithOptions(googledomains.NewDefaultConfigFrom, googledomains.NewDNSProviderConfig, opts)
+	case "gravity":
+		return newDNSProviderWithOptions(gravity.NewDefaultConfigFrom, gravity.NewDNSProviderConfig, opts)
+	case "hetzner":
+		return newDNSProviderWithOptions(hetzner.NewDefaultConfigFrom, hetzner.NewDNSProviderConfig, opts)
+	case "hostingde":
+		return newDNSProviderWithOptions(hostingde.NewDefaultConfigFrom, hostingde.NewDNSProviderConfig, opts)
+	case "hostinger":
+		return newDNSProviderWithOptions(hostinger.NewDefaultConfigFrom, hostinger.NewDNSProviderConfig, opts)
+	case "hostingnl":
+		return newDNSProviderWithOptions(hostingnl.NewDefaultConfigFrom, hostingnl.NewDNSProviderConfig, opts)
+	case "hosttech":
+		return newDNSProviderWithOptions(hosttech.NewDefaultConfigFrom, hosttech.NewDNSProviderConfig, opts)
+	case "httpnet":
+		return newDNSProviderWithOptions(httpnet.NewDefaultConfigFrom, httpnet.NewDNSProviderConfig, opts)
+	case "httpreq":
+		return newDNSProviderWithOptions(httpreq.NewDefaultConfigFrom, httpreq.NewDNSProviderConfig, opts)
+	case "huaweicloud":
+		return newDNSProviderWithOptions(huaweicloud.NewDefaultConfigFrom, huaweicloud.NewDNSProviderConfig, opts)
+	case "hurricane":
+		return newDNSProviderWithOptions(hurricane.NewDefaultConfigFrom, hurricane.NewDNSProviderConfig, opts)
+	case "hyperone":
+		return newDNSProviderWithOptions(hyperone.NewDefaultConfigFrom, hyperone.NewDNSProviderConfig, opts)
+	case "ibmcloud":
+		return newDNSProviderWithOptions(ibmcloud.NewDefaultConfigFrom, ibmcloud.NewDNSProviderConfig, opts)
+	case "iij":
+		return newDNSProviderWithOptions(iij.NewDefaultConfigFrom, iij.NewDNSProviderConfig, opts)
+	case "iijdpf":
+		return newDNSProviderWithOptions(iijdpf.NewDefaultConfigFrom, iijdpf.NewDNSProviderConfig, opts)
+	case "infoblox":
+		return newDNSProviderWithOptions(infoblox.NewDefaultConfigFrom, infoblox.NewDNSProviderConfig, opts)
+	case "infomaniak":
+		return newDNSProviderWithOptions(infomaniak.NewDefaultConfigFrom, infomaniak.NewDNSProviderConfig, opts)
+	case "internetbs":
+		return newDNSProviderWithOptions(internetbs.NewDefaultConfigFrom, internetbs.NewDNSProviderConfig, opts)
+	case "inwx":
+		return newDNSProviderWithOptions(inwx.NewDefaultConfigFrom, inwx.NewDNSProviderConfig, opts)
+	case "ionos":
+		return newDNSProviderWithOptions(ionos.NewDefaultConfigFrom, ionos.NewDNSProviderConfig, opts)
+	case "ionoscloud":
+		return newDNSProviderWithOptions(ionoscloud.NewDefaultConfigFrom, ionoscloud.NewDNSProviderConfig, opts)
+	case "ipv64":
+		return newDNSProviderWithOptions(ipv64.NewDefaultConfigFrom, ipv64.NewDNSProviderConfig, opts)
+	case "ispconfig":
+		return newDNSProviderWithOptions(ispconfig.NewDefaultConfigFrom, ispconfig.NewDNSProviderConfig, opts)
+	case "ispconfigddns":
+		return newDNSProviderWithOptions(ispconfigddns.NewDefaultConfigFrom, ispconfigddns.NewDNSProviderConfig, opts)
+	case "iwantmyname":
+		return newDNSProviderWithOptions(iwantmyname.NewDefaultConfigFrom, iwantmyname.NewDNSProviderConfig, opts)
+	case "jdcloud":
+		return newDNSProviderWithOptions(jdcloud.NewDefaultConfigFrom, jdcloud.NewDNSProviderConfig, opts)
+	case "joker":
+		return newDNSProviderWithOptions(joker.NewDefaultConfigFrom, joker.NewDNSProviderConfig, opts)
+	case "keyhelp":
+		return newDNSProviderWithOptions(keyhelp.NewDefaultConfigFrom, keyhelp.NewDNSProviderConfig, opts)
+	case "leaseweb":
+		return newDNSProviderWithOptions(leaseweb.NewDefaultConfigFrom, leaseweb.NewDNSProviderConfig, opts)
+	case "liara":
+		return newDNSProviderWithOptions(liara.NewDefaultConfigFrom, liara.NewDNSProviderConfig, opts)
+	case "lightsail":
+		return newDNSProviderWithOptions(lightsail.NewDefaultConfigFrom, lightsail.NewDNSProviderConfig, opts)
+	case "limacity":
+		return newDNSProviderWithOptions(limacity.NewDefaultConfigFrom, limacity.NewDNSProviderConfig, opts)
+	case "linode", "linodev4":
+		return newDNSProviderWithOptions(linode.NewDefaultConfigFrom, linode.NewDNSProviderConfig, opts)
+	case "liquidweb":
+		return newDNSProviderWithOptions(liquidweb.NewDefaultConfigFrom, liquidweb.NewDNSProviderConfig, opts)
+	case "loopia":
+		return newDNSProviderWithOptions(loopia.NewDefaultConfigFrom, loopia.NewDNSProviderConfig, opts)
+	case "luadns":
+		return newDNSProviderWithOptions(luadns.NewDefaultConfigFrom, luadns.NewDNSProviderConfig, opts)
+	case "mailinabox":
+		return newDNSProviderWithOptions(mailinabox.NewDefaultConfigFrom, mailinabox.NewDNSProviderConfig, opts)
+	case "manageengine":
+		return newDNSProviderWithOptions(manageengine.NewDefaultConfigFrom, manageengine.NewDNSProviderConfig, opts)
+	case "manual":
+		return newDNSProviderWithOptions(manual.NewDefaultConfigFrom, manual.NewDNSProviderConfig, opts)
+	case "metaname":
+		return newDNSProviderWithOptions(metaname.NewDefaultConfigFrom, metaname.NewDNSProviderConfig, opts)
+	case "metaregistrar":
+		return newDNSProviderWithOptions(metaregistrar.NewDefaultConfigFrom, metaregistrar.NewDNSProviderConfig, opts)
+	case "mijnhost":
+		return newDNSProviderWithOptions(mijnhost.NewDefaultConfigFrom, mijnhost.NewDNSProviderConfig, opts)
+	case "mittwald":
+		return newDNSProviderWithOptions(mittwald.NewDefaultConfigFrom, mittwald.NewDNSProviderConfig, opts)
+	case "myaddr":
+		return newDNSProviderWithOptions(myaddr.NewDefaultConfigFrom, myaddr.NewDNSProviderConfig, opts)
+	case "mydnsjp":
+		return newDNSProviderWithOptions(mydnsjp.NewDefaultConfigFrom, mydnsjp.NewDNSProviderConfig, opts)
+	case "mythicbeasts":
+		return newDNSProviderWithOptions(mythicbeasts.NewDefaultConfigFrom, mythicbeasts.NewDNSProviderConfig, opts)
+	case "namecheap":
+		return newDNSProviderWithOptions(namecheap.NewDefaultConfigFrom, namecheap.NewDNSProviderConfig, opts)
+	case "namedotcom":
+		return newDNSProviderWithOptions(namedotcom.NewDefaultConfigFrom, namedotcom.NewDNSProviderConfig, opts)
+	case "namesilo":
+		return newDNSProviderWithOptions(namesilo.NewDefaultConfigFrom, namesilo.NewDNSProviderConfig, opts)
+	case "namesurfer":
+		return newDNSProviderWithOptions(namesurfer.NewDefaultConfigFrom, namesurfer.NewDNSProviderConfig, opts)
+	case "nearlyfreespeech":
+		return newDNSProviderWithOptions(nearlyfreespeech.NewDefaultConfigFrom, nearlyfreespeech.NewDNSProviderConfig, opts)
+	case "neodigit":
+		return newDNSProviderWithOptions(neodigit.NewDefaultConfigFrom, neodigit.NewDNSProviderConfig, opts)
+	case "netcup":
+		return newDNSProviderWithOptions(netcup.NewDefaultConfigFrom, netcup.NewDNSProviderConfig, opts)
+	case "netlify":
+		return newDNSProviderWithOptions(netlify.NewDefaultConfigFrom, netlify.NewDNSProviderConfig, opts)
+	case "nicmanager":
+		return newDNSProviderWithOptions(nicmanager.NewDefaultConfigFrom, nicmanager.NewDNSProviderConfig, opts)
+	case "nicru":
+		return newDNSProviderWithOptions(nicru.NewDefaultConfigFrom, nicru.NewDNSProviderConfig, opts)
+	case "nifcloud":
+		return newDNSProviderWithOptions(nifcloud.NewDefaultConfigFrom, nifcloud.NewDNSProviderConfig, opts)
+	case "njalla":
+		return newDNSProviderWithOptions(njalla.NewDefaultConfigFrom, njalla.NewDNSProviderConfig, opts)
+	case "nodion":
+		return newDNSProviderWithOptions(nodion.NewDefaultConfigFrom, nodion.NewDNSProviderConfig, opts)
+	case "ns1":
+		return newDNSProviderWithOptions(ns1.NewDefaultConfigFrom, ns1.NewDNSProviderConfig, opts)
+	case "octenium":
+		return newDNSProviderWithOptions(octenium.NewDefaultConfigFrom, octenium.NewDNSProviderConfig, opts)
+	case "oraclecloud":
+		return newDNSProviderWithOptions(oraclecloud.NewDefaultConfigFrom, oraclecloud.NewDNSProviderConfig, opts)
+	case "otc":
+		return newDNSProviderWithOptions(otc.NewDefaultConfigFrom, otc.NewDNSProviderConfig, opts)
+	case "ovh":
+		return newDNSProviderWithOptions(ovh.NewDefaultConfigFrom, ovh.NewDNSProviderConfig, opts)
+	case "pdns":
+		return newDNSProviderWithOptions(pdns.NewDefaultConfigFrom, pdns.NewDNSProviderConfig, opts)
+	case "plesk":
+		return newDNSProviderWithOptions(plesk.NewDefaultConfigFrom, plesk.NewDNSProviderConfig, opts)
+	case "porkbun":
+		return newDNSProviderWithOptions(porkbun.NewDefaultConfigFrom, porkbun.NewDNSProviderConfig, opts)
+	case "rackspace":
+		return newDNSProviderWithOptions(rackspace.NewDefaultConfigFrom, rackspace.NewDNSProviderConfig, opts)
+	case "rainyun":
+		return newDNSProviderWithOptions(rainyun.NewDefaultConfigFrom, rainyun.NewDNSProviderConfig, opts)
+	case "rcodezero":
+		return newDNSProviderWithOptions(rcodezero.NewDefaultConfigFrom, rcodezero.NewDNSProviderConfig, opts)
+	case "regfish":
+		return newDNSProviderWithOptions(regfish.NewDefaultConfigFrom, regfish.NewDNSProviderConfig, opts)
+	case "regru":
+		return newDNSProviderWithOptions(regru.NewDefaultConfigFrom, regru.NewDNSProviderConfig, opts)
+	case "rfc2136":
+		return newDNSProviderWithOptions(rfc2136.NewDefaultConfigFrom, rfc2136.NewDNSProviderConfig, opts)
+	case "rimuhosting":
+		return newDNSProviderWithOptions(rimuhosting.NewDefaultConfigFrom, rimuhosting.NewDNSProviderConfig, opts)
+	case "route53":
+		return newDNSProviderWithOptions(route53.NewDefaultConfigFrom, route53.NewDNSProviderConfig, opts)
+	case "safedns":
+		return newDNSProviderWithOptions(safedns.NewDefaultConfigFrom, safedns.NewDNSProviderConfig, opts)
+	case "sakuracloud":
+		return newDNSProviderWithOptions(sakuracloud.NewDefaultConfigFrom, sakuracloud.NewDNSProviderConfig, opts)
+	case "scaleway":
+		return newDNSProviderWithOptions(scaleway.NewDefaultConfigFrom, scaleway.NewDNSProviderConfig, opts)
+	case "selectel":
+		return newDNSProviderWithOptions(selectel.NewDefaultConfigFrom, selectel.NewDNSProviderConfig, opts)
+	case "selectelv2":
+		return newDNSProviderWithOptions(selectelv2.NewDefaultConfigFrom, selectelv2.NewDNSProviderConfig, opts)
+	case "selfhostde":
+		return newDNSProviderWithOptions(selfhostde.NewDefaultConfigFrom, selfhostde.NewDNSProviderConfig, opts)
+	case "servercow":
+		return newDNSProviderWithOptions(servercow.NewDefaultConfigFrom, servercow.NewDNSProviderConfig, opts)
+	case "shellrent":
+		return newDNSProviderWithOptions(shellrent.NewDefaultConfigFrom, shellrent.NewDNSProviderConfig, opts)
+	case "simply":
+		return newDNSProviderWithOptions(simply.NewDefaultConfigFrom, simply.NewDNSProviderConfig, opts)
+	case "sonic":
+		return newDNSProviderWithOptions(sonic.NewDefaultConfigFrom, sonic.NewDNSProviderConfig, opts)
+	case "spaceship":
+		return newDNSProviderWithOptions(spaceship.NewDefaultConfigFrom, spaceship.NewDNSProviderConfig, opts)
+	case "stackpath":
+		return newDNSProviderWithOptions(stackpath.NewDefaultConfigFrom, stackpath.NewDNSProviderConfig, opts)
+	case "syse":
+		return newDNSProviderWithOptions(syse.NewDefaultConfigFrom, syse.NewDNSProviderConfig, opts)
+	case "technitium":
+		return newDNSProviderWithOptions(technitium.NewDefaultConfigFrom, technitium.NewDNSProviderConfig, opts)
+	case "tencentcloud":
+		return newDNSProviderWithOptions(tencentcloud.NewDefaultConfigFrom, tencentcloud.NewDNSProviderConfig, opts)
+	case "timewebcloud":
+		return newDNSProviderWithOptions(timewebcloud.NewDefaultConfigFrom, timewebcloud.NewDNSProviderConfig, opts)
+	case "todaynic":
+		return newDNSProviderWithOptions(todaynic.NewDefaultConfigFrom, todaynic.NewDNSProviderConfig, opts)
+	case "transip":
+		return newDNSProviderWithOptions(transip.NewDefaultConfigFrom, transip.NewDNSProviderConfig, opts)
+	case "ultradns":
+		return newDNSProviderWithOptions(ultradns.NewDefaultConfigFrom, ultradns.NewDNSProviderConfig, opts)
+	case "uniteddomains":
+		return newDNSProviderWithOptions(uniteddomains.NewDefaultConfigFrom, uniteddomains.NewDNSProviderConfig, opts)
+	case "variomedia":
+		return newDNSProviderWithOptions(variomedia.NewDefaultConfigFrom, variomedia.NewDNSProviderConfig, opts)
+	case "vegadns":
+		return newDNSProviderWithOptions(vegadns.NewDefaultConfigFrom, vegadns.NewDNSProviderConfig, opts)
+	case "vercel":
+		return newDNSProviderWithOptions(vercel.NewDefaultConfigFrom, vercel.NewDNSProviderConfig, opts)
+	case "versio":
+		return newDNSProviderWithOptions(versio.NewDefaultConfigFrom, versio.NewDNSProviderConfig, opts)
+	case "vinyldns":
+		return newDNSProviderWithOptions(vinyldns.NewDefaultConfigFrom, vinyldns.NewDNSProviderConfig, opts)
+	case "virtualname":
+		return newDNSProviderWithOptions(virtualname.NewDefaultConfigFrom, virtualname.NewDNSProviderConfig, opts)
+	case "vkcloud":
+		return newDNSProviderWithOptions(vkcloud.NewDefaultConfigFrom, vkcloud.NewDNSProviderConfig, opts)
+	case "volcengine":
+		return newDNSProviderWithOptions(volcengine.NewDefaultConfigFrom, volcengine.NewDNSProviderConfig, opts)
+	case "vscale":
+		return newDNSProviderWithOptions(vscale.NewDefaultConfigFrom, vscale.NewDNSProviderConfig, opts)
+	case "vultr":
+		return newDNSProviderWithOptions(vultr.NewDefaultConfigFrom, vultr.NewDNSProviderConfig, opts)
+	case "webnames", "webnamesru":
+		return newDNSProviderWithOptions(webnames.NewDefaultConfigFrom, webnames.NewDNSProviderConfig, opts)
+	case "webnamesca":
+		return newDNSProviderWithOptions(webnamesca.NewDefaultConfigFrom, webnamesca.NewDNSProviderConfig, opts)
+	case "websupport":
+		return newDNSProviderWithOptions(websupport.NewDefaultConfigFrom, websupport.NewDNSProviderConfig, opts)
+	case "wedos":
+		return newDNSProviderWithOptions(wedos.NewDefaultConfigFrom, wedos.NewDNSProviderConfig, opts)
+	case "westcn":
+		return newDNSProviderWithOptions(westcn.NewDefaultConfigFrom, westcn.NewDNSProviderConfig, opts)
+	case "yandex":
+		return newDNSProviderWithOptions(yandex.NewDefaultConfigFrom, yandex.NewDNSProviderConfig, opts)
+	case "yandex360":
+		return newDNSProviderWithOptions(yandex360.NewDefaultConfigFrom, yandex360.NewDNSProviderConfig, opts)
+	case "yandexcloud":
+		return newDNSProviderWithOptions(yandexcloud.NewDefaultConfigFrom, yandexcloud.NewDNSProviderConfig, opts)
+	case "zoneedit":
+		return newDNSProviderWithOptions(zoneedit.NewDefaultConfigFrom, zoneedit.NewDNSProviderConfig, opts)
+	case "zoneee":
+		return newDNSProviderWithOptions(zoneee.NewDefaultConfigFrom, zoneee.NewDNSProviderConfig, opts)
+	case "zonomi":
+		return newDNSProviderWithOptions(zonomi.NewDefaultConfigFrom, zonomi.NewDNSProviderConfig, opts)
+	default:
+		return nil, fmt.Errorf("unrecognized DNS provider: %s", name)
+	}
+}
+
+// dnsProviderCredentials returns the environment variables of the credentials of a DNS provider (from its description).
+func dnsProviderCredentials(name string) []string {
+	switch name {
+	case "acme-dns", "acmedns":
+		return []string{"ACME_DNS_API_BASE", "ACME_DNS_STORAGE_BASE_URL", "ACME_DNS_STORAGE_PATH"}
+	case "active24":
+		return []string{"ACTIVE24_API_KEY", "ACTIVE24_SECRET"}
+	case "alidns":
+		return []string{"ALICLOUD_ACCESS_KEY", "ALICLOUD_RAM_ROLE", "ALICLOUD_SECRET_KEY", "ALICLOUD_SECURITY_TOKEN"}
+	case "aliesa":
+		return []string{"ALIESA_ACCESS_KEY", "ALIESA_RAM_ROLE", "ALIESA_SECRET_KEY", "ALIESA_SECURITY_TOKEN"}
+	case "allinkl":
+		return []string{"ALL_INKL_LOGIN", "ALL_INKL_PASSWORD"}
+	case "alwaysdata":
+		return []string{"ALWAYSDATA_API_KEY"}
+	case "anexia":
+		return []string{"ANEXIA_TOKEN"}
+	case "artfiles":
+		return []string{"ARTFILES_PASSWORD", "ARTFILES_USERNAME"}
+	case "arvancloud":
+		return []string{"ARVANCLOUD_API_KEY"}
+	case "auroradns":
+		return []string{"AURORA_API_KEY", "AURORA_SECRET"}
+	case "autodns":
+		return []string{"AUTODNS_API_PASSWORD", "AUTODNS_API_USER"}
+	case "axelname":
+		return []string{"AXELNAME_NICKNAME", "AXELNAME_TOKEN"}
+	case "azion":
+		return []string{"AZION_PERSONAL_TOKEN"}
+	case "azure":
+		return []string{"AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET", "AZURE_ENVIRONMENT", "AZURE_RESOURCE_GROUP", "AZURE_SUBSCRIPTION_ID", "AZURE_TENANT_ID", "instance metadata service"}
+	case "azuredns":
+		return []string{"AZURE_CLIENT_CERTIFICATE_PATH", "AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET", "AZURE_TENANT_ID"}
+	case "baiducloud":
+		return []string{"BAIDUCLOUD_ACCESS_KEY_ID", "BAIDUCLOUD_SECRET_ACCESS_KEY"}
+	case "beget":
+		return []string{"BEGET_PASSWORD", "BEGET_USERNAME"}
+	case "binarylane":
+		return []string{"BINARYLANE_API_TOKEN"}
+	case "bindman":
+		return []string{"BINDMAN_MANAGER_ADDRESS"}
+	case "bluecat":
+		return []string{"BLUECAT_CONFIG_NAME", "BLUECAT_DNS_VIEW", "BLUECAT_PASSWORD", "BLUECAT_SERVER_URL", "BLUECAT_USER_NAME"}
+	case "bluecatv2":
+		return []string{"BLUECATV2_CONFIG_NAME", "BLUECATV2_PASSWORD", "BLUECATV2_SERVER_URL", "BLUECATV2_USERNAME", "BLUECATV2_VIEW_NAME"}
+	case "bookmyname":
+		return []string{"BOOKMYNAME_PASSWORD", "BOOKMYNAME_USERNAME"}
+	case "brandit":
+		return []string{"BRANDIT_API_KEY", "BRANDIT_API_USERNAME"}
+	case "bunny":
+		return []string{"BUNNY_API_KEY"}
+	case "checkdomain":
+		return []string{"CHECKDOMAIN_TOKEN"}
+	case "civo":
+		return []string{"CIVO_TOKEN"}
+	case "clouddns":
+		return []string{"CLOUDDNS_CLIENT_ID", "CLOUDDNS_EMAIL", "CLOUDDNS_PASSWORD"}
+	case "cloudflare":
+		return []string{"CF_API_EMAIL", "CF_API_KEY", "CF_DNS_API_TOKEN", "CF_ZONE_API_TOKEN", "CLOUDFLARE_API_KEY", "CLOUDFLARE_DNS_API_TOKEN", "CLOUDFLARE_EMAIL", "CLOUDFLARE_ZONE_API_TOKEN"}
+	case "cloudns":
+		return []string{"CLOUDNS_AUTH_ID", "CLOUDNS_AUTH_PASSWORD"}
+	case "cloudru":
+		return []string{"CLOUDRU_KEY_ID", "CLOUDRU_SECRET", "CLOUDRU_SERVICE_INSTANCE_ID"}
+	case "cloudxns":
+		return []string{"CLOUDXNS_API_KEY", "CLOUDXNS_SECRET_KEY"}
+	case "com35":
+		return []string{"COM35_PASSWORD", "COM35_USERNAME"}
+	case "conoha":
+		return []string{"CONOHA_API_PASSWORD", "CONOHA_API_USERNAME", "CONOHA_TENANT_ID"}
+	case "conohav3":
+		return []string{"CONOHAV3_API_PASSWORD", "CONOHAV3_API_USER_ID", "CONOHAV3_TENANT_ID"}
+	case "constellix":
+		return []string{"CONSTELLIX_API_KEY", "CONSTELLIX_SECRET_KEY"}
+	case "corenetworks":
+		return []string{"CORENETWORKS_LOGIN", "CORENETWORKS_PASSWORD"}
+	case "cpanel":
+		return []string{"CPANEL_BASE_URL", "CPANEL_TOKEN", "CPANEL_USERNAME"}
+	case "czechia":
+		return []string{"CZECHIA_TOKEN"}
+	case "ddnss":
+		return []string{"DDNSS_KEY"}
+	case "derak":
+		return []string{"DERAK_API_KEY"}
+	case "desec":
+		return []string{"DESEC_TOKEN"}
+	case "designate":
+		return []string{"OS_APPLICATION_CREDENTIAL_ID", "OS_APPLICATION_CREDENTIAL_NAME", "OS_APPLICATION_CREDENTIAL_SECRET", "OS_AUTH_URL", "OS_PASSWORD", "OS_PROJECT_NAME", "OS_REGION_NAME", "OS_USERNAME", "OS_USER_ID"}
+	case "digitalocean":
+		return []string{"DO_AUTH_TOKEN"}
+	case "directadmin":
+		return []string{"DIRECTADMIN_API_URL", "DIRECTADMIN_PASSWORD", "DIRECTADMIN_USERNAME"}
+	case "dnsexit":
+		return []string{"DNSEXIT_API_KEY"}
+	case "dnshomede":
+		return []string{"DNSHOMEDE_CREDENTIALS"}
+	case "dnsimple":
+		return []string{"DNSIMPLE_OAUTH_TOKEN"}
+	case "dnsmadeeasy":
+		return []string{"DNSMADEEASY_API_KEY", "DNSMADEEASY_API_SECRET"}
+	case "dnspod":
+		return []string{"DNSPOD_API_KEY"}
+	case "dode":
+		return []string{"DODE_TOKEN"}
+	case "domeneshop", "domainnameshop":
+		return []string{"DOMENESHOP_API_SECRET", "DOMENESHOP_API_TOKEN"}
+	case "dreamhost":
+		return []string{"DREAMHOST_API_KEY"}
+	case "duckdns":
+		return []string{"DUCKDNS_TOKEN"}
+	case "dyn":
+		return []string{"DYN_CUSTOMER_NAME", "DYN_PASSWORD", "DYN_USER_NAME"}
+	case "dyndnsfree":
+		return []string{"DYNDNSFREE_PASSWORD", "DYNDNSFREE_USERNAME"}
+	case "dynu":
+		return []string{"DYNU_API_KEY"}
+	case "easydns":
+		return []string{"EASYDNS_KEY", "EASYDNS_TOKEN"}
+	case "edgecenter":
+		return []string{"EDGECENTER_PERMANENT_API_TOKEN"}
+	case "edgedns", "fastdns":
+		return []string{"AKAMAI_ACCESS_TOKEN", "AKAMAI_CLIENT_SECRET", "AKAMAI_CLIENT_TOKEN", "AKAMAI_EDGERC", "AKAMAI_EDGERC_SECTION", "AKAMAI_HOST"}
+	case "edgeone":
+		return []string{"EDGEONE_SECRET_ID", "EDGEONE_SECRET_KEY"}
+	case "efficientip":
+		return []string{"EFFICIENTIP_DNS_NAME", "EFFICIENTIP_HOSTNAME", "EFFICIENTIP_PASSWORD", "EFFICIENTIP_USERNAME"}
+	case "epik":
+		return []string{"EPIK_SIGNATURE"}
+	case "exoscale":
+		return []string{"EXOSCALE_API_KEY", "EXOSCALE_API_SECRET"}
+	case "f5xc":
+		return []string{"F5XC_API_TOKEN", "F5XC_GROUP_NAME", "F5XC_TENANT_NAME"}
+	case "freemyip":
+		return []string{"FREEMYIP_TOKEN"}
+	case "gandi":
+		return []string{"GANDI_API_KEY"}
+	case "gandiv5":
+		return []string{"GANDIV5_API_KEY", "GANDIV5_PERSONAL_ACCESS_TOKEN"}
+	case "gcloud":
+		return []string{"Application Default Credentials", "GCE_PROJECT", "GCE_SERVICE_ACCOUNT", "GCE_SERVICE_ACCOUNT_FILE"}
+	case "gcore":
+		return []string{"GCORE_PERMANENT_API_TOKEN"}
+	case "gigahostno":
+		return []string{"GIGAHOSTNO_PASSWORD", "GIGAHOSTNO_USERNAME"}
+	case "glesys":
+		return []string{"GLESYS_API_KEY", "GLESYS_API_USER"}
+	case "godaddy":
+		return []string{"GODADDY_API_KEY", "GODADDY_API_SECRET"}
+	case "googledomains":
+		return []string{"GOOGLE_DOMAINS_ACCESS_TOKEN"}
+	case "gravity":
+		return []string{"GRAVITY_PASSWORD", "GRAVITY_SERVER_URL", "GRAVITY_USERNAME"}
+	case "hetzner":
+		return []string{"HETZNER_API_TOKEN"}
+	case "hostingde":
+		return []string{"HOSTINGDE_API_KEY"}
+	case "hostinger":
+		return []string{"HOSTINGER_API_TOKEN"}
+	case "hostingnl":
+		return []string{"HOSTINGNL_API_KEY"}
+	case "hosttech":
+		return []string{"HOSTTECH_API_KEY"}
+	case "httpnet":
+		return []string{"HTTPNET_API_KEY"}
+	case "httpreq":
+		return []string{"HTTPREQ_ENDPOINT", "HTTPREQ_MODE"}
+	case "huaweicloud":
+		return []string{"HUAWEICLOUD_ACCESS_KEY_ID", "HUAWEICLOUD_REGION", "HUAWEICLOUD_SECRET_ACCESS_KEY"}
+	case "ibmcloud":
+		return []string{"SOFTLAYER_API_KEY", "SOFTLAYER_USERNAME"}
+	case "iij":
+		return []string{"IIJ_API_ACCESS_KEY", "IIJ_API_SECRET_KEY", "IIJ_DO_SERVICE_CODE"}
+	case "iijdpf":
+		return []string{"IIJ_DPF_API_TOKEN", "IIJ_DPF_DPM_SERVICE_CODE"}
+	case "infoblox":
+		return []string{"INFOBLOX_HOST", "INFOBLOX_PASSWORD", "INFOBLOX_USERNAME"}
+	case "infomaniak":
+		return []string{"INFOMANIAK_ACCESS_TOKEN"}
+	case "internetbs":
+		return []string{"INTERNET_BS_API_KEY", "INTERNET_BS_PASSWORD"}
+	case "inwx":
+		return []string{"INWX_PASSWORD", "INWX_USERNAME"}
+	case "ionos":
+		return []string{"IONOS_API_KEY"}
+	case "ionoscloud":
+		return []string{"IONOSCLOUD_API_TOKEN"}
+	case "ipv64":
+		return []string{"IPV64_API_KEY"}
+	case "ispconfig":
+		return []string{"ISPCONFIG_PASSWORD", "ISPCONFIG_SERVER_URL", "ISPCONFIG_USERNAME"}
+	case "ispconfigddns":
+		return []string{"ISPCONFIG_DDNS_SERVER_URL", "ISPCONFIG_DDNS_TOKEN"}
+	case "iwantmyname":
+		return []string{"IWANTMYNAME_PASSWORD", "IWANTMYNAME_USERNAME"}
+	case "jdcloud":
+		return []string{"JDCLOUD_ACCESS_KEY_ID", "JDCLOUD_ACCESS_KEY_SECRET"}
+	case "joker":
+		return []string{"JOKER_API_KEY", "JOKER_API_MODE", "JOKER_PASSWORD", "JOKER_USERNAME"}
+	case "keyhelp":
+		return []string{"KEYHELP_API_KEY", "KEYHELP_BASE_URL"}
+	case "leaseweb":
+		return []string{"LEASEWEB_API_KEY"}
+	case "liara":
+		return []string{"LIARA_API_KEY"}
+	case "lightsail":
+		return []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "DNS_ZONE"}
+	case "limacity":
+		return []string{"LIMACITY_API_KEY"}
+	case "linode", "linodev4":
+		return []string{"LINODE_TOKEN"}
+	case "liquidweb":
+		return []string{"LWAPI_PASSWORD", "LWAPI_USERNAME"}
+	case "loopia":
+		return []string{"LOOPIA_API_PASSWORD", "LOOPIA_API_USER"}
+	case "luadns":
+		return []string{"LUADNS_API_TOKEN", "LUADNS_API_USERNAME"}
+	case "mailinabox":
+		return []string{"MAILINABOX_BASE_URL", "MAILINABOX_EMAIL", "MAILINABOX_PASSWORD"}
+	case "manageengine":
+		return []string{"MANAGEENGINE_CLIENT_ID", "MANAGEENGINE_CLIENT_SECRET"}
+	case "metaname":
+		return []string{"METANAME_ACCOUNT_REFERENCE", "METANAME_API_KEY"}
+	case "metaregistrar":
+		return []string{"METAREGISTRAR_API_TOKEN"}
+	case "mijnhost":
+		return []string{"MIJNHOST_API_KEY"}
+	case "mittwald":
+		return []string{"MITTWALD_TOKEN"}
+	case "myaddr":
+		return []string{"MYADDR_PRIVATE_KEYS_MAPPING"}
+	case "mydnsjp":
+		return []string{"MYDNSJP_MASTER_ID", "MYDNSJP_PASSWORD"}
+	case "mythicbeasts":
+		return []string{"MYTHICBEASTS_PASSWORD", "MYTHICBEASTS_USERNAME"}
+	case "namecheap":
+		return []string{"NAMECHEAP_API_KEY", "NAMECHEAP_API_USER"}
+	case "namedotcom":
+		return []string{"NAMECOM_API_TOKEN", "NAMECOM_USERNAME"}
+	case "namesilo":
+		return []string{"NAMESILO_API_KEY"}
+	case "namesurfer":
+		return []string{"NAMESURFER_API_KEY", "NAMESURFER_API_SECRET", "NAMESURFER_BASE_URL"}
+	case "nearlyfreespeech":
+		return []string{"NEARLYFREESPEECH_API_KEY", "NEARLYFREESPEECH_LOGIN"}
+	case "neodigit":
+		return []string{"NEODIGIT_TOKEN"}
+	case "netcup":
+		return []string{"NETCUP_API_KEY", "NETCUP_API_PASSWORD", "NETCUP_CUSTOMER_NUMBER"}
+	case "netlify":
+		return []string{"NETLIFY_TOKEN"}
+	case "nicmanager":
+		return []string{"NICMANAGER_API_EMAIL", "NICMANAGER_API_LOGIN", "NICMANAGER_API_PASSWORD", "NICMANAGER_API_USERNAME"}
+	case "nicru":
+		return []string{"NICRU_PASSWORD", "NICRU_SECRET", "NICRU_SERVICE_ID", "NICRU_SERVICE_NAME", "NICRU_USER"}
+	case "nifcloud":
+		return []string{"NIFCLOUD_ACCESS_KEY_ID", "NIFCLOUD_SECRET_ACCESS_KEY"}
+	case "njalla":
+		return []string{"NJALLA_TOKEN"}
+	case "nodion":
+		return []string{"NODION_API_TOKEN"}
+	case "ns1":
+		return []string{"NS1_API_KEY"}
+	case "octenium":
+		return []string{"OCTENIUM_API_KEY"}
+	case "oraclecloud":
+		return []string{"OCI_COMPARTMENT_OCID", "OCI_FINGERPRINT", "OCI_PRIVATE_KEY_PASSWORD", "OCI_PRIVATE_KEY_PATH", "OCI_REGION", "OCI_TENANCY_OCID", "OCI_USER_OCID"}
+	case "otc":
+		return []string{"OTC_DOMAIN_NAME", "OTC_PASSWORD", "OTC_PROJECT_NAME", "OTC_USER_NAME"}
+	case "ovh":
+		return []string{"OVH_ACCESS_TOKEN", "OVH_APPLICATION_KEY", "OVH_APPLICATION_SECRET", "OVH_CLIENT_ID", "OVH_CLIENT_SECRET", "OVH_CONSUMER_KEY", "OVH_ENDPOINT"}
+	case "pdns":
+		return []string{"PDNS_API_KEY", "PDNS_API_URL"}
+	case "plesk":
+		return []string{"PLESK_PASSWORD", "PLESK_SERVER_BASE_URL", "PLESK_USERNAME"}
+	case "porkbun":
+		return []string{"PORKBUN_API_KEY", "PORKBUN_SECRET_API_KEY"}
+	case "rackspace":
+		return []string{"RACKSPACE_API_KEY", "RACKSPACE_USER"}
+	case "rainyun":
+		return []string{"RAINYUN_API_KEY"}
+	case "rcodezero":
+		return []string{"RCODEZERO_API_TOKEN"}
+	case "regfish":
+		return []string{"REGFISH_API_KEY"}
+	case "regru":
+		return []string{"REGRU_PASSWORD", "REGRU_USERNAME"}
+	case "rfc2136":
+		return []string{"RFC2136_NAMESERVER", "RFC2136_TSIG_ALGORITHM", "RFC2136_TSIG_KEY", "RFC2136_TSIG_SECRET"}
+	case "rimuhosting":
+		return []string{"RIMUHOSTING_API_KEY"}
+	case "route53":
+		return []string{"AWS_ACCESS_KEY_ID", "AWS_ASSUME_ROLE_ARN", "AWS_EXTERNAL_ID", "AWS_HOSTED_ZONE_ID", "AWS_PROFILE", "AWS_REGION", "AWS_SDK_LOAD_CONFIG", "AWS_SECRET_ACCESS_KEY", "AWS_WAIT_FOR_RECORD_SETS_CHANGED"}
+	case "safedns":
+		return []string{"SAFEDNS_AUTH_TOKEN"}
+	case "sakuracloud":
+		return []string{"SAKURACLOUD_ACCESS_TOKEN", "SAKURACLOUD_ACCESS_TOKEN_SECRET"}
+	case "scaleway":
+		return []string{"SCW_PROJECT_ID", "SCW_SECRET_KEY"}
+	case "selectel":
+		return []string{"SELECTEL_API_TOKEN"}
+	case "selectelv2":
+		return []string{"SELECTELV2_ACCOUNT_ID", "SELECTELV2_PASSWORD", "SELECTELV2_PROJECT_ID", "SELECTELV2_USERNAME"}
+	case "selfhostde":
+		return []string{"SELFHOSTDE_PASSWORD", "SELFHOSTDE_RECORDS_MAPPING", "SELFHOSTDE_USERNAME"}
+	case "servercow":
+		return []string{"SERVERCOW_PASSWORD", "SERVERCOW_USERNAME"}
+	case "shellrent":
+		return []string{"SHELLRENT_TOKEN", "SHELLRENT_USERNAME"}
+	case "simply":
+		return []string{"SIMPLY_ACCOUNT_NAME", "SIMPLY_API_KEY"}
+	case "sonic":
+		return []string{"SONIC_API_KEY", "SONIC_USER_ID"}
+	case "spaceship":
+		return []string{"SPACESHIP_API_KEY", "SPACESHIP_API_SECRET"}
+	case "stackpath":
+		return []string{"STACKPATH_CLIENT_ID", "STACKPATH_CLIENT_SECRET", "STACKPATH_STACK_ID"}
+	case "syse":
+		return []string{"SYSE_CREDENTIALS"}
+	case "technitium":
+		return []string{"TECHNITIUM_API_TOKEN", "TECHNITIUM_SERVER_BASE_URL"}
+	case "tencentcloud":
+		return []string{"TENCENTCLOUD_SECRET_ID", "TENCENTCLOUD_SECRET_KEY"}
+	case "timewebcloud":
+		return []string{"TIMEWEBCLOUD_AUTH_TOKEN"}
+	case "todaynic":
+		return []string{"TODAYNIC_API_KEY", "TODAYNIC_AUTH_USER_ID"}
+	case "transip":
+		return []string{"TRANSIP_ACCOUNT_NAME", "TRANSIP_PRIVATE_KEY_PATH"}
+	case "ultradns":
+		return []string{"ULTRADNS_PASSWORD", "ULTRADNS_USERNAME"}
+	case "uniteddomains":
+		return []string{"UNITEDDOMAINS_API_KEY"}
+	case "variomedia":
+		return []string{"VARIOMEDIA_API_TOKEN"}
+	case "vegadns":
+		return []string{"SECRET_VEGADNS_KEY", "SECRET_VEGADNS_SECRET", "VEGADNS_URL"}
+	case "vercel":
+		return []string{"VERCEL_API_TOKEN"}
+	case "versio":
+		return []string{"VERSIO_PASSWORD", "VERSIO_USERNAME"}
+	case "vinyldns":
+		return []string{"VINYLDNS_ACCESS_KEY", "VINYLDNS_HOST", "VINYLDNS_SECRET_KEY"}
+	case "virtualname":
+		return []string{"VIRTUALNAME_TOKEN"}
+	case "vkcloud":
+		return []string{"VK_CLOUD_PASSWORD", "VK_CLOUD_PROJECT_ID", "VK_CLOUD_USERNAME"}
+	case "volcengine":
+		return []string{"VOLC_ACCESSKEY", "VOLC_SECRETKEY"}
+	case "vscale":
+		return []string{"VSCALE_API_TOKEN"}
+	case "vultr":
+		return []string{"VULTR_API_KEY"}
+	case "webnames", "webnamesru":
+		return []string{"WEBNAMESRU_API_KEY"}
+	case "webnamesca":
+		return []string{"WEBNAMESCA_API_KEY", "WEBNAMESCA_API_USER"}
+	case "websupport":
+		return []string{"WEBSUPPORT_API_KEY", "WEBSUPPORT_SECRET"}
+	case "wedos":
+		return []string{"WEDOS_USERNAME", "WEDOS_WAPI_PASSWORD"}
+	case "westcn":
+		return []string{"WESTCN_PASSWORD", "WESTCN_USERNAME"}
+	case "yandex":
+		return []string{"YANDEX_PDD_TOKEN"}
+	case "yandex360":
+		return []string{"YANDEX360_OAUTH_TOKEN", "YANDEX360_ORG_ID"}
+	case "yandexcloud":
+		return []string{"YANDEX_CLOUD_FOLDER_ID", "YANDEX_CLOUD_IAM_TOKEN"}
+	case "zoneedit":
+		return []string{"ZONEEDIT_AUTH_TOKEN", "ZONEEDIT_USER"}
+	case "zoneee":
+		return []string{"ZONEEE_API_KEY", "ZONEEE_API_USER"}
+	case "zonomi":
+		return []string{"ZONOMI_API_KEY"}
+	default:
+		return nil
+	}
+}
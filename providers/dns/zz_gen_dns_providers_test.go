@@ -0,0 +1,394 @@
+// Code generated by 'make generate-dns'; DO NOT EDIT.
+
+package dns
+
+import (
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/providers/dns/acmedns"
+	"github.com/go-acme/lego/v4/providers/dns/active24"
+	"github.com/go-acme/lego/v4/providers/dns/alidns"
+	"github.com/go-acme/lego/v4/providers/dns/aliesa"
+	"github.com/go-acme/lego/v4/providers/dns/allinkl"
+	"github.com/go-acme/lego/v4/providers/dns/alwaysdata"
+	"github.com/go-acme/lego/v4/providers/dns/anexia"
+	"github.com/go-acme/lego/v4/providers/dns/artfiles"
+	"github.com/go-acme/lego/v4/providers/dns/arvancloud"
+	"github.com/go-acme/lego/v4/providers/dns/auroradns"
+	"github.com/go-acme/lego/v4/providers/dns/autodns"
+	"github.com/go-acme/lego/v4/providers/dns/axelname"
+	"github.com/go-acme/lego/v4/providers/dns/azion"
+	"github.com/go-acme/lego/v4/providers/dns/azure"
+	"github.com/go-acme/lego/v4/providers/dns/azuredns"
+	"github.com/go-acme/lego/v4/providers/dns/baiducloud"
+	"github.com/go-acme/lego/v4/providers/dns/beget"
+	"github.com/go-acme/lego/v4/providers/dns/binarylane"
+	"github.com/go-acme/lego/v4/providers/dns/bindman"
+	"github.com/go-acme/lego/v4/providers/dns/bluecat"
+	"github.com/go-acme/lego/v4/providers/dns/bluecatv2"
+	"github.com/go-acme/lego/v4/providers/dns/bookmyname"
+	"github.com/go-acme/lego/v4/providers/dns/brandit"
+	"github.com/go-acme/lego/v4/providers/dns/bunny"
+	"github.com/go-acme/lego/v4/providers/dns/checkdomain"
+	"github.com/go-acme/lego/v4/providers/dns/civo"
+	"github.com/go-acme/lego/v4/providers/dns/clouddns"
+	"github.com/go-acme/lego/v4/providers/dns/cloudflare"
+	"github.com/go-acme/lego/v4/providers/dns/cloudns"
+	"github.com/go-acme/lego/v4/providers/dns/cloudru"
+	"github.com/go-acme/lego/v4/providers/dns/cloudxns"
+	"github.com/go-acme/lego/v4/providers/dns/com35"
+	"github.com/go-acme/lego/v4/providers/dns/conoha"
+	"github.com/go-acme/lego/v4/providers/dns/conohav3"
+	"github.com/go-acme/lego/v4/providers/dns/constellix"
+	"github.com/go-acme/lego/v4/providers/dns/corenetworks"
+	"github.com/go-acme/lego/v4/providers/dns/cpanel"
+	"github.com/go-acme/lego/v4/providers/dns/czechia"
+	"github.com/go-acme/lego/v4/providers/dns/ddnss"
+	"github.com/go-acme/lego/v4/providers/dns/derak"
+	"github.com/go-acme/lego/v4/providers/dns/desec"
+	"github.com/go-acme/lego/v4/providers/dns/designate"
+	"github.com/go-acme/lego/v4/providers/dns/digitalocean"
+	"github.com/go-acme/lego/v4/providers/dns/directadmin"
+	"github.com/go-acme/lego/v4/providers/dns/dnsexit"
+	"github.com/go-acme/lego/v4/providers/dns/dnshomede"
+	"github.com/go-acme/lego/v4/providers/dns/dnsimple"
+	"github.com/go-acme/lego/v4/providers/dns/dnsmadeeasy"
+	"github.com/go-acme/lego/v4/providers/dns/dnspod"
+	"github.com/go-acme/lego/v4/providers/dns/dode"
+	"github.com/go-acme/lego/v4/providers/dns/domeneshop"
+	"github.com/go-acme/lego/v4/providers/dns/dreamhost"
+	"github.com/go-acme/lego/v4/providers/dns/duckdns"
+	"github.com/go-acme/lego/v4/providers/dns/dyn"
+	"github.com/go-acme/lego/v4/providers/dns/dyndnsfree"
+	"github.com/go-acme/lego/v4/providers/dns/dynu"
+	"github.com/go-acme/lego/v4/providers/dns/easydns"
+	"github.com/go-acme/lego/v4/providers/dns/edgecenter"
+	"github.com/go-acme/lego/v4/providers/dns/edgedns"
+	"github.com/go-acme/lego/v4/providers/dns/edgeone"
+	"github.com/go-acme/lego/v4/providers/dns/efficientip"
+	"github.com/go-acme/lego/v4/providers/dns/epik"
+	"github.com/go-acme/lego/v4/providers/dns/exec"
+	"github.com/go-acme/lego/v4/providers/dns/exoscale"
+	"github.com/go-acme/lego/v4/providers/dns/f5xc"
+	"github.com/go-acme/lego/v4/providers/dns/freemyip"
+	"github.com/go-acme/lego/v4/providers/dns/gandi"
+	"github.com/go-acme/lego/v4/providers/dns/gandiv5"
+	"github.com/go-acme/lego/v4/providers/dns/gcloud"
+	"github.com/go-acme/lego/v4/providers/dns/gcore"
+	"github.com/go-acme/lego/v4/providers/dns/gigahostno"
+	"github.com/go-acme/lego/v4/providers/dns/glesys"
+	"github.com/go-acme/lego/v4/providers/dns/godaddy"
+	"github.com/go-acme/lego/v4/providers/dns/googledomains"
+	"github.com/go-acme/lego/v4/providers/dns/gravity"
+	"github.com/go-acme/lego/v4/providers/dns/hetzner"
+	"github.com/go-acme/lego/v4/providers/dns/hostingde"
+	"github.com/go-acme/lego/v4/providers/dns/hostinger"
+	"github.com/go-acme/lego/v4/providers/dns/hostingnl"
+	"github.com/go-acme/lego/v4/providers/dns/hosttech"
+	"github.com/go-acme/lego/v4/providers/dns/httpnet"
+	"github.com/go-acme/lego/v4/providers/dns/httpreq"
+	"github.com/go-acme/lego/v4/providers/dns/huaweicloud"
+	"github.com/go-acme/lego/v4/providers/dns/hurricane"
+	"github.com/go-acme/lego/v4/providers/dns/hyperone"
+	"github.com/go-acme/lego/v4/providers/dns/ibmcloud"
+	"github.com/go-acme/lego/v4/providers/dns/iij"
+	"github.com/go-acme/lego/v4/providers/dns/iijdpf"
+	"github.com/go-acme/lego/v4/providers/dns/infoblox"
+	"github.com/go-acme/lego/v4/providers/dns/infomaniak"
+	"github.com/go-acme/lego/v4/providers/dns/internetbs"
+	"github.com/go-acme/lego/v4/providers/dns/inwx"
+	"github.com/go-acme/lego/v4/providers/dns/ionos"
+	"github.com/go-acme/lego/v4/providers/dns/ionoscloud"
+	"github.com/go-acme/lego/v4/providers/dns/ipv64"
+	"github.com/go-acme/lego/v4/providers/dns/ispconfig"
+	"github.com/go-acme/lego/v4/providers/dns/ispconfigddns"
+	"github.com/go-acme/lego/v4/providers/dns/iwantmyname"
+	"github.com/go-acme/lego/v4/providers/dns/jdcloud"
+	"github.com/go-acme/lego/v4/providers/dns/joker"
+	"github.com/go-acme/lego/v4/providers/dns/keyhelp"
+	"github.com/go-acme/lego/v4/providers/dns/leaseweb"
+	"github.com/go-acme/lego/v4/providers/dns/liara"
+	"github.com/go-acme/lego/v4/providers/dns/lightsail"
+	"github.com/go-acme/lego/v4/providers/dns/limacity"
+	"github.com/go-acme/lego/v4/providers/dns/linode"
+	"github.com/go-acme/lego/v4/providers/dns/liquidweb"
+	"github.com/go-acme/lego/v4/providers/dns/loopia"
+	"github.com/go-acme/lego/v4/providers/dns/luadns"
+	"github.com/go-acme/lego/v4/providers/dns/mailinabox"
+	"github.com/go-acme/lego/v4/providers/dns/manageengine"
+	"github.com/go-acme/lego/v4/providers/dns/manual"
+	"github.com/go-acme/lego/v4/providers/dns/metaname"
+	"github.com/go-acme/lego/v4/providers/dns/metaregistrar"
+	"github.com/go-acme/lego/v4/providers/dns/mijnhost"
+	"github.com/go-acme/lego/v4/providers/dns/mittwald"
+	"github.com/go-acme/lego/v4/providers/dns/myaddr"
+	"github.com/go-acme/lego/v4/providers/dns/mydnsjp"
+	"github.com/go-acme/lego/v4/providers/dns/mythicbeasts"
+	"github.com/go-acme/lego/v4/providers/dns/namecheap"
+	"github.com/go-acme/lego/v4/providers/dns/namedotcom"
+	"github.com/go-acme/lego/v4/providers/dns/namesilo"
+	"github.com/go-acme/lego/v4/providers/dns/namesurfer"
+	"github.com/go-acme/lego/v4/providers/dns/nearlyfreespeech"
+	"github.com/go-acme/lego/v4/providers/dns/neodigit"
+	"github.com/go-acme/lego/v4/providers/dns/netcup"
+	"github.com/go-acme/lego/v4/providers/dns/netlify"
+	"github.com/go-acme/lego/v4/providers/dns/nicmanager"
+	"github.com/go-acme/lego/v4/providers/dns/nicru"
+	"github.com/go-acme/lego/v4/providers/dns/nifcloud"
+	"github.com/go-acme/lego/v4/providers/dns/njalla"
+	"github.com/go-acme/lego/v4/providers/dns/nodion"
+	"github.com/go-acme/lego/v4/providers/dns/ns1"
+	"github.com/go-acme/lego/v4/providers/dns/octenium"
+	"github.com/go-acme/lego/v4/providers/dns/oraclecloud"
+	"github.com/go-acme/lego/v4/providers/dns/otc"
+	"github.com/go-acme/lego/v4/providers/dns/ovh"
+	"github.com/go-acme/lego/v4/providers/dns/pdns"
+	"github.com/go-acme/lego/v4/providers/dns/plesk"
+	"github.com/go-acme/lego/v4/providers/dns/porkbun"
+	"github.com/go-acme/lego/v4/providers/dns/rackspace"
+	"github.com/go-acme/lego/v4/providers/dns/rainyun"
+	"github.com/go-acme/lego/v4/providers/dns/rcodezero"
+	"github.com/go-acme/lego/v4/providers/dns/regfish"
+	"github.com/go-acme/lego/v4/providers/dns/regru"
+	"github.com/go-acme/lego/v4/providers/dns/rfc2136"
+	"github.com/go-acme/lego/v4/providers/dns/rimuhosting"
+	"github.com/go-acme/lego/v4/providers/dns/route53"
+	"github.com/go-acme/lego/v4/providers/dns/safedns"
+	"github.com/go-acme/lego/v4/providers/dns/sakuracloud"
+	"github.com/go-acme/lego/v4/providers/dns/scaleway"
+	"github.com/go-acme/lego/v4/providers/dns/selectel"
+	"github.com/go-acme/lego/v4/providers/dns/selectelv2"
+	"github.com/go-acme/lego/v4/providers/dns/selfhostde"
+	"github.com/go-acme/lego/v4/providers/dns/servercow"
+	"github.com/go-acme/lego/v4/providers/dns/shellrent"
+	"github.com/go-acme/lego/v4/providers/dns/simply"
+	"github.com/go-acme/lego/v4/providers/dns/sonic"
+	"github.com/go-acme/lego/v4/providers/dns/spaceship"
+	"github.com/go-acme/lego/v4/providers/dns/stackpath"
+	"github.com/go-acme/lego/v4/providers/dns/syse"
+	"github.com/go-acme/lego/v4/providers/dns/technitium"
+	"github.com/go-acme/lego/v4/providers/dns/tencentcloud"
+	"github.com/go-acme/lego/v4/providers/dns/timewebcloud"
+	"github.com/go-acme/lego/v4/providers/dns/todaynic"
+	"github.com/go-acme/lego/v4/providers/dns/transip"
+	"github.com/go-acme/lego/v4/providers/dns/ultradns"
+	"github.com/go-acme/lego/v4/providers/dns/uniteddomains"
+	"github.com/go-acme/lego/v4/providers/dns/variomedia"
+	"github.com/go-acme/lego/v4/providers/dns/vegadns"
+	"github.com/go-acme/lego/v4/providers/dns/vercel"
+	"github.com/go-acme/lego/v4/providers/dns/versio"
+	"github.com/go-acme/lego/v4/providers/dns/vinyldns"
+	"github.com/go-acme/lego/v4/providers/dns/virtualname"
+	"github.com/go-acme/lego/v4/providers/dns/vkcloud"
+	"github.com/go-acme/lego/v4/providers/dns/volcengine"
+	"github.com/go-acme/lego/v4/providers/dns/vscale"
+	"github.com/go-acme/lego/v4/providers/dns/vultr"
+	"github.com/go-acme/lego/v4/providers/dns/webnames"
+	"github.com/go-acme/lego/v4/providers/dns/webnamesca"
+	"github.com/go-acme/lego/v4/providers/dns/websupport"
+	"github.com/go-acme/lego/v4/providers/dns/wedos"
+	"github.com/go-acme/lego/v4/providers/dns/westcn"
+	"github.com/go-acme/lego/v4/providers/dns/yandex"
+	"github.com/go-acme/lego/v4/providers/dns/yandex360"
+	"github.com/go-acme/lego/v4/providers/dns/yandexcloud"
+	"github.com/go-acme/lego/v4/providers/dns/zoneedit"
+	"github.com/go-acme/lego/v4/providers/dns/zoneee"
+	"github.com/go-acme/lego/v4/providers/dns/zonomi"
+)
+
+// The providers can be created from their Config, without the environment variables.
+var (
+	_ = assertConfigConstructors(acmedns.NewDefaultConfig, acmedns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(active24.NewDefaultConfig, active24.NewDNSProviderConfig)
+	_ = assertConfigConstructors(alidns.NewDefaultConfig, alidns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(aliesa.NewDefaultConfig, aliesa.NewDNSProviderConfig)
+	_ = assertConfigConstructors(allinkl.NewDefaultConfig, allinkl.NewDNSProviderConfig)
+	_ = assertConfigConstructors(alwaysdata.NewDefaultConfig, alwaysdata.NewDNSProviderConfig)
+	_ = assertConfigConstructors(anexia.NewDefaultConfig, anexia.NewDNSProviderConfig)
+	_ = assertConfigConstructors(artfiles.NewDefaultConfig, artfiles.NewDNSProviderConfig)
+	_ = assertConfigConstructors(arvancloud.NewDefaultConfig, arvancloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(auroradns.NewDefaultConfig, auroradns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(autodns.NewDefaultConfig, autodns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(axelname.NewDefaultConfig, axelname.NewDNSProviderConfig)
+	_ = assertConfigConstructors(azion.NewDefaultConfig, azion.NewDNSProviderConfig)
+	_ = assertConfigConstructors(azure.NewDefaultConfig, azure.NewDNSProviderConfig)
+	_ = assertConfigConstructors(azuredns.NewDefaultConfig, azuredns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(baiducloud.NewDefaultConfig, baiducloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(beget.NewDefaultConfig, beget.NewDNSProviderConfig)
+	_ = assertConfigConstructors(binarylane.NewDefaultConfig, binarylane.NewDNSProviderConfig)
+	_ = assertConfigConstructors(bindman.NewDefaultConfig, bindman.NewDNSProviderConfig)
+	_ = assertConfigConstructors(bluecat.NewDefaultConfig, bluecat.NewDNSProviderConfig)
+	_ = assertConfigConstructors(bluecatv2.NewDefaultConfig, bluecatv2.NewDNSProviderConfig)
+	_ = assertConfigConstructors(bookmyname.NewDefaultConfig, bookmyname.NewDNSProviderConfig)
+	_ = assertConfigConstructors(brandit.NewDefaultConfig, brandit.NewDNSProviderConfig)
+	_ = assertConfigConstructors(bunny.NewDefaultConfig, bunny.NewDNSProviderConfig)
+	_ = assertConfigConstructors(checkdomain.NewDefaultConfig, checkdomain.NewDNSProviderConfig)
+	_ = assertConfigConstructors(civo.NewDefaultConfig, civo.NewDNSProviderConfig)
+	_ = assertConfigConstructors(clouddns.NewDefaultConfig, clouddns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(cloudflare.NewDefaultConfig, cloudflare.NewDNSProviderConfig)
+	_ = assertConfigConstructors(cloudns.NewDefaultConfig, cloudns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(cloudru.NewDefaultConfig, cloudru.NewDNSProviderConfig)
+	_ = assertConfigConstructors(cloudxns.NewDefaultConfig, cloudxns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(com35.NewDefaultConfig, com35.NewDNSProviderConfig)
+	_ = assertConfigConstructors(conoha.NewDefaultConfig, conoha.NewDNSProviderConfig)
+	_ = assertConfigConstructors(conohav3.NewDefaultConfig, conohav3.NewDNSProviderConfig)
+	_ = assertConfigConstructors(constellix.NewDefaultConfig, constellix.NewDNSProviderConfig)
+	_ = assertConfigConstructors(corenetworks.NewDefaultConfig, corenetworks.NewDNSProviderConfig)
+	_ = assertConfigConstructors(cpanel.NewDefaultConfig, cpanel.NewDNSProviderConfig)
+	_ = assertConfigConstructors(czechia.NewDefaultConfig, czechia.NewDNSProviderConfig)
+	_ = assertConfigConstructors(ddnss.NewDefaultConfig, ddnss.NewDNSProviderConfig)
+	_ = assertConfigConstructors(derak.NewDefaultConfig, derak.NewDNSProviderConfig)
+	_ = assertConfigConstructors(desec.NewDefaultConfig, desec.NewDNSProviderConfig)
+	_ = assertConfigConstructors(designate.NewDefaultConfig, designate.NewDNSProviderConfig)
+	_ = assertConfigConstructors(digitalocean.NewDefaultConfig, digitalocean.NewDNSProviderConfig)
+	_ = assertConfigConstructors(directadmin.NewDefaultConfig, directadmin.NewDNSProviderConfig)
+	_ = assertConfigConstructors(dnsexit.NewDefaultConfig, dnsexit.NewDNSProviderConfig)
+	_ = assertConfigConstructors(dnshomede.NewDefaultConfig, dnshomede.NewDNSProviderConfig)
+	_ = assertConfigConstructors(dnsimple.NewDefaultConfig, dnsimple.NewDNSProviderConfig)
+	_ = assertConfigConstructors(dnsmadeeasy.NewDefaultConfig, dnsmadeeasy.NewDNSProviderConfig)
+	_ = assertConfigConstructors(dnspod.NewDefaultConfig, dnspod.NewDNSProviderConfig)
+	_ = assertConfigConstructors(dode.NewDefaultConfig, dode.NewDNSProviderConfig)
+	_ = assertConfigConstructors(domeneshop.NewDefaultConfig, domeneshop.NewDNSProviderConfig)
+	_ = assertConfigConstructors(dreamhost.NewDefaultConfig, dreamhost.NewDNSProviderConfig)
+	_ = assertConfigConstructors(duckdns.NewDefaultConfig, duckdns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(dyn.NewDefaultConfig, dyn.NewDNSProviderConfig)
+	_ = assertConfigConstructors(dyndnsfree.NewDefaultConfig, dyndnsfree.NewDNSProviderConfig)
+	_ = assertConfigConstructors(dynu.NewDefaultConfig, dynu.NewDNSProviderConfig)
+	_ = assertConfigConstructors(easydns.NewDefaultConfig, easydns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(edgecenter.NewDefaultConfig, edgecenter.NewDNSProviderConfig)
+	_ = assertConfigConstructors(edgedns.NewDefaultConfig, edgedns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(edgeone.NewDefaultConfig, edgeone.NewDNSProviderConfig)
+	_ = assertConfigConstructors(efficientip.NewDefaultConfig, efficientip.NewDNSProviderConfig)
+	_ = assertConfigConstructors(epik.NewDefaultConfig, epik.NewDNSProviderConfig)
+	_ = assertConfigConstructors(exec.NewDefaultConfig, exec.NewDNSProviderConfig)
+	_ = assertConfigConstructors(exoscale.NewDefaultConfig, exoscale.NewDNSProviderConfig)
+	_ = assertConfigConstructors(f5xc.NewDefaultConfig, f5xc.NewDNSProviderConfig)
+	_ = assertConfigConstructors(freemyip.NewDefaultConfig, freemyip.NewDNSProviderConfig)
+	_ = assertConfigConstructors(gandi.NewDefaultConfig, gandi.NewDNSProviderConfig)
+	_ = assertConfigConstructors(gandiv5.NewDefaultConfig, gandiv5.NewDNSProviderConfig)
+	_ = assertConfigConstructors(gcloud.NewDefaultConfig, gcloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(gcore.NewDefaultConfig, gcore.NewDNSProviderConfig)
+	_ = assertConfigConstructors(gigahostno.NewDefaultConfig, gigahostno.NewDNSProviderConfig)
+	_ = assertConfigConstructors(glesys.NewDefaultConfig, glesys.NewDNSProviderConfig)
+	_ = assertConfigConstructors(godaddy.NewDefaultConfig, godaddy.NewDNSProviderConfig)
+	_ = assertConfigConstructors(googledomains.NewDefaultConfig, googledomains.NewDNSProviderConfig)
+	_ = assertConfigConstructors(gravity.NewDefaultConfig, gravity.NewDNSProviderConfig)
+	_ = assertConfigConstructors(hetzner.NewDefaultConfig, hetzner.NewDNSProviderConfig)
+	_ = assertConfigConstructors(hostingde.NewDefaultConfig, hostingde.NewDNSProviderConfig)
+	_ = assertConfigConstructors(hostinger.NewDefaultConfig, hostinger.NewDNSProviderConfig)
+	_ = assertConfigConstructors(hostingnl.NewDefaultConfig, hostingnl.NewDNSProviderConfig)
+	_ = assertConfigConstructors(hosttech.NewDefaultConfig, hosttech.NewDNSProviderConfig)
+	_ = assertConfigConstructors(httpnet.NewDefaultConfig, httpnet.NewDNSProviderConfig)
+	_ = assertConfigConstructors(httpreq.NewDefaultConfig, httpreq.NewDNSProviderConfig)
+	_ = assertConfigConstructors(huaweicloud.NewDefaultConfig, huaweicloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(hurricane.NewDefaultConfig, hurricane.NewDNSProviderConfig)
+	_ = assertConfigConstructors(hyperone.NewDefaultConfig, hyperone.NewDNSProviderConfig)
+	_ = assertConfigConstructors(ibmcloud.NewDefaultConfig, ibmcloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(iij.NewDefaultConfig, iij.NewDNSProviderConfig)
+	_ = assertConfigConstructors(iijdpf.NewDefaultConfig, iijdpf.NewDNSProviderConfig)
+	_ = assertConfigConstructors(infoblox.NewDefaultConfig, infoblox.NewDNSProviderConfig)
+	_ = assertConfigConstructors(infomaniak.NewDefaultConfig, infomaniak.NewDNSProviderConfig)
+	_ = assertConfigConstructors(internetbs.NewDefaultConfig, internetbs.NewDNSProviderConfig)
+	_ = assertConfigConstructors(inwx.NewDefaultConfig, inwx.NewDNSProviderConfig)
+	_ = assertConfigConstructors(ionos.NewDefaultConfig, ionos.NewDNSProviderConfig)
+	_ = assertConfigConstructors(ionoscloud.NewDefaultConfig, ionoscloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(ipv64.NewDefaultConfig, ipv64.NewDNSProviderConfig)
+	_ = assertConfigConstructors(ispconfig.NewDefaultConfig, ispconfig.NewDNSProviderConfig)
+	_ = assertConfigConstructors(ispconfigddns.NewDefaultConfig, ispconfigddns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(iwantmyname.NewDefaultConfig, iwantmyname.NewDNSProviderConfig)
+	_ = assertConfigConstructors(jdcloud.NewDefaultConfig, jdcloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(joker.NewDefaultConfig, joker.NewDNSProviderConfig)
+	_ = assertConfigConstructors(keyhelp.NewDefaultConfig, keyhelp.NewDNSProviderConfig)
+	_ = assertConfigConstructors(leaseweb.NewDefaultConfig, leaseweb.NewDNSProviderConfig)
+	_ = assertConfigConstructors(liara.NewDefaultConfig, liara.NewDNSProviderConfig)
+	_ = assertConfigConstructors(lightsail.NewDefaultConfig, lightsail.NewDNSProviderConfig)
+	_ = assertConfigConstructors(limacity.NewDefaultConfig, limacity.NewDNSProviderConfig)
+	_ = assertConfigConstructors(linode.NewDefaultConfig, linode.NewDNSProviderConfig)
+	_ = assertConfigConstructors(liquidweb.NewDefaultConfig, liquidweb.NewDNSProviderConfig)
+	_ = assertConfigConstructors(loopia.NewDefaultConfig, loopia.NewDNSProviderConfig)
+	_ = assertConfigConstructors(luadns.NewDefaultConfig, luadns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(mailinabox.NewDefaultConfig, mailinabox.NewDNSProviderConfig)
+	_ = assertConfigConstructors(manageengine.NewDefaultConfig, manageengine.NewDNSProviderConfig)
+	_ = assertConfigConstructors(manual.NewDefaultConfig, manual.NewDNSProviderConfig)
+	_ = assertConfigConstructors(metaname.NewDefaultConfig, metaname.NewDNSProviderConfig)
+	_ = assertConfigConstructors(metaregistrar.NewDefaultConfig, metaregistrar.NewDNSProviderConfig)
+	_ = assertConfigConstructors(mijnhost.NewDefaultConfig, mijnhost.NewDNSProviderConfig)
+	_ = assertConfigConstructors(mittwald.NewDefaultConfig, mittwald.NewDNSProviderConfig)
+	_ = assertConfigConstructors(myaddr.NewDefaultConfig, myaddr.NewDNSProviderConfig)
+	_ = assertConfigConstructors(mydnsjp.NewDefaultConfig, mydnsjp.NewDNSProviderConfig)
+	_ = assertConfigConstructors(mythicbeasts.NewDefaultConfig, mythicbeasts.NewDNSProviderConfig)
+	_ = assertConfigConstructors(namecheap.NewDefaultConfig, namecheap.NewDNSProviderConfig)
+	_ = assertConfigConstructors(namedotcom.NewDefaultConfig, namedotcom.NewDNSProviderConfig)
+	_ = assertConfigConstructors(namesilo.NewDefaultConfig, namesilo.NewDNSProviderConfig)
+	_ = assertConfigConstructors(namesurfer.NewDefaultConfig, namesurfer.NewDNSProviderConfig)
+	_ = assertConfigConstructors(nearlyfreespeech.NewDefaultConfig, nearlyfreespeech.NewDNSProviderConfig)
+	_ = assertConfigConstructors(neodigit.NewDefaultConfig, neodigit.NewDNSProviderConfig)
+	_ = assertConfigConstructors(netcup.NewDefaultConfig, netcup.NewDNSProviderConfig)
+	_ = assertConfigConstructors(netlify.NewDefaultConfig, netlify.NewDNSProviderConfig)
+	_ = assertConfigConstructors(nicmanager.NewDefaultConfig, nicmanager.NewDNSProviderConfig)
+	_ = assertConfigConstructors(nicru.NewDefaultConfig, nicru.NewDNSProviderConfig)
+	_ = assertConfigConstructors(nifcloud.NewDefaultConfig, nifcloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(njalla.NewDefaultConfig, njalla.NewDNSProviderConfig)
+	_ = assertConfigConstructors(nodion.NewDefaultConfig, nodion.NewDNSProviderConfig)
+	_ = assertConfigConstructors(ns1.NewDefaultConfig, ns1.NewDNSProviderConfig)
+	_ = assertConfigConstructors(octenium.NewDefaultConfig, octenium.NewDNSProviderConfig)
+	_ = assertConfigConstructors(oraclecloud.NewDefaultConfig, oraclecloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(otc.NewDefaultConfig, otc.NewDNSProviderConfig)
+	_ = assertConfigConstructors(ovh.NewDefaultConfig, ovh.NewDNSProviderConfig)
+	_ = assertConfigConstructors(pdns.NewDefaultConfig, pdns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(plesk.NewDefaultConfig, plesk.NewDNSProviderConfig)
+	_ = assertConfigConstructors(porkbun.NewDefaultConfig, porkbun.NewDNSProviderConfig)
+	_ = assertConfigConstructors(rackspace.NewDefaultConfig, rackspace.NewDNSProviderConfig)
+	_ = assertConfigConstructors(rainyun.NewDefaultConfig, rainyun.NewDNSProviderConfig)
+	_ = assertConfigConstructors(rcodezero.NewDefaultConfig, rcodezero.NewDNSProviderConfig)
+	_ = assertConfigConstructors(regfish.NewDefaultConfig, regfish.NewDNSProviderConfig)
+	_ = assertConfigConstructors(regru.NewDefaultConfig, regru.NewDNSProviderConfig)
+	_ = assertConfigConstructors(rfc2136.NewDefaultConfig, rfc2136.NewDNSProviderConfig)
+	_ = assertConfigConstructors(rimuhosting.NewDefaultConfig, rimuhosting.NewDNSProviderConfig)
+	_ = assertConfigConstructors(route53.NewDefaultConfig, route53.NewDNSProviderConfig)
+	_ = assertConfigConstructors(safedns.NewDefaultConfig, safedns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(sakuracloud.NewDefaultConfig, sakuracloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(scaleway.NewDefaultConfig, scaleway.NewDNSProviderConfig)
+	_ = assertConfigConstructors(selectel.NewDefaultConfig, selectel.NewDNSProviderConfig)
+	_ = assertConfigConstructors(selectelv2.NewDefaultConfig, selectelv2.NewDNSProviderConfig)
+	_ = assertConfigConstructors(selfhostde.NewDefaultConfig, selfhostde.NewDNSProviderConfig)
+	_ = assertConfigConstructors(servercow.NewDefaultConfig, servercow.NewDNSProviderConfig)
+	_ = assertConfigConstructors(shellrent.NewDefaultConfig, shellrent.NewDNSProviderConfig)
+	_ = assertConfigConstructors(simply.NewDefaultConfig, simply.NewDNSProviderConfig)
+	_ = assertConfigConstructors(sonic.NewDefaultConfig, sonic.NewDNSProviderConfig)
+	_ = assertConfigConstructors(spaceship.NewDefaultConfig, spaceship.NewDNSProviderConfig)
+	_ = assertConfigConstructors(stackpath.NewDefaultConfig, stackpath.NewDNSProviderConfig)
+	_ = assertConfigConstructors(syse.NewDefaultConfig, syse.NewDNSProviderConfig)
+	_ = assertConfigConstructors(technitium.NewDefaultConfig, technitium.NewDNSProviderConfig)
+	_ = assertConfigConstructors(tencentcloud.NewDefaultConfig, tencentcloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(timewebcloud.NewDefaultConfig, timewebcloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(todaynic.NewDefaultConfig, todaynic.NewDNSProviderConfig)
+	_ = assertConfigConstructors(transip.NewDefaultConfig, transip.NewDNSProviderConfig)
+	_ = assertConfigConstructors(ultradns.NewDefaultConfig, ultradns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(uniteddomains.NewDefaultConfig, uniteddomains.NewDNSProviderConfig)
+	_ = assertConfigConstructors(variomedia.NewDefaultConfig, variomedia.NewDNSProviderConfig)
+	_ = assertConfigConstructors(vegadns.NewDefaultConfig, vegadns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(vercel.NewDefaultConfig, vercel.NewDNSProviderConfig)
+	_ = assertConfigConstructors(versio.NewDefaultConfig, versio.NewDNSProviderConfig)
+	_ = assertConfigConstructors(vinyldns.NewDefaultConfig, vinyldns.NewDNSProviderConfig)
+	_ = assertConfigConstructors(virtualname.NewDefaultConfig, virtualname.NewDNSProviderConfig)
+	_ = assertConfigConstructors(vkcloud.NewDefaultConfig, vkcloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(volcengine.NewDefaultConfig, volcengine.NewDNSProviderConfig)
+	_ = assertConfigConstructors(vscale.NewDefaultConfig, vscale.NewDNSProviderConfig)
+	_ = assertConfigConstructors(vultr.NewDefaultConfig, vultr.NewDNSProviderConfig)
+	_ = assertConfigConstructors(webnames.NewDefaultConfig, webnames.NewDNSProviderConfig)
+	_ = assertConfigConstructors(webnamesca.NewDefaultConfig, webnamesca.NewDNSProviderConfig)
+	_ = assertConfigConstructors(websupport.NewDefaultConfig, websupport.NewDNSProviderConfig)
+	_ = assertConfigConstructors(wedos.NewDefaultConfig, wedos.NewDNSProviderConfig)
+	_ = assertConfigConstructors(westcn.NewDefaultConfig, westcn.NewDNSProviderConfig)
+	_ = assertConfigConstructors(yandex.NewDefaultConfig, yandex.NewDNSProviderConfig)
+	_ = assertConfigConstructors(yandex360.NewDefaultConfig, yandex360.NewDNSProviderConfig)
+	_ = assertConfigConstructors(yandexcloud.NewDefaultConfig, yandexcloud.NewDNSProviderConfig)
+	_ = assertConfigConstructors(zoneedit.NewDefaultConfig, zoneedit.NewDNSProviderConfig)
+	_ = assertConfigConstructors(zoneee.NewDefaultConfig, zoneee.NewDNSProviderConfig)
+	_ = assertConfigConstructors(zonomi.NewDefaultConfig, zonomi.NewDNSProviderConfig)
+)
+
+// assertConfigConstructors checks the signatures of NewDefaultConfig and NewDNSProviderConfig at compile time.
+func assertConfigConstructors[C any, P challenge.Provider, D func() *C | func() (*C, error)](_ D, _ func(*C) (P, error)) bool {
+	return true
+}
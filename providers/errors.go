@@ -0,0 +1,128 @@
+// Package providers contains the elements shared by the challenge providers.
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RequestIDHeaders the headers used by the APIs to return the ID of a request, in order of priority.
+var RequestIDHeaders = []string{
+	"X-Request-Id",
+	"X-Amz-Request-Id",
+	"X-Amzn-Requestid",
+	"X-Ms-Request-Id",
+	"X-Correlation-Id",
+	"X-Trace-Id",
+	"Request-Id",
+	"Cf-Ray",
+}
+
+// APIError an error returned by the API of a provider.
+// It contains the information to correlate the failure with the logs of the provider.
+type APIError struct {
+	// StatusCode the HTTP status code of the response.
+	StatusCode int
+
+	// RequestID the ID of the request, returned by the provider (see RequestIDHeaders).
+	RequestID string
+
+	// Endpoint the method and the URL of the request, without the query and the user information.
+	Endpoint string
+
+	Err error
+}
+
+// NewAPIError wraps the error of an API call.
+// Returns nil if err is nil, and err if it's already an APIError.
+func NewAPIError(req *http.Request, resp *http.Response, err error) error {
+	if req == nil {
+		return NewEndpointAPIError("", nil, resp, err)
+	}
+
+	return NewEndpointAPIError(req.Method, req.URL, resp, err)
+}
+
+// NewEndpointAPIError wraps the error of an API call made through an SDK that doesn't expose the HTTP request.
+// Returns nil if err is nil, and err if it's already an APIError.
+func NewEndpointAPIError(method string, endpoint *url.URL, resp *http.Response, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return err
+	}
+
+	apiErr = &APIError{Err: err}
+
+	if endpoint != nil {
+		apiErr.Endpoint = formatEndpoint(method, endpoint)
+	}
+
+	if resp != nil {
+		apiErr.StatusCode = resp.StatusCode
+		apiErr.RequestID = requestID(resp.Header)
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	msg := e.Err.Error()
+
+	if e.Endpoint != "" {
+		msg += fmt.Sprintf(" [endpoint: %s]", endpointPath(e.Endpoint))
+	}
+
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" [request ID: %s]", e.RequestID)
+	}
+
+	return msg
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// formatEndpoint returns the method and the URL, without the query and the user information.
+func formatEndpoint(method string, endpoint *url.URL) string {
+	u := url.URL{Scheme: endpoint.Scheme, Host: endpoint.Host, Path: endpoint.Path}
+
+	return strings.TrimSpace(method + " " + u.String())
+}
+
+// endpointPath returns the method and the path of the endpoint:
+// the host is known from the configuration of the provider, and it's not stable with the test servers.
+func endpointPath(endpoint string) string {
+	method, rawURL, found := strings.Cut(endpoint, " ")
+	if !found {
+		return endpoint
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return endpoint
+	}
+
+	if u.Path == "" {
+		return method + " /"
+	}
+
+	return method + " " + u.Path
+}
+
+func requestID(header http.Header) string {
+	for _, name := range RequestIDHeaders {
+		if value := header.Get(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
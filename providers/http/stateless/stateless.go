@@ -0,0 +1,118 @@
+// Package stateless implements an HTTP provider for solving the HTTP-01 challenge without provisioning the tokens:
+// the web server answers the challenges by itself with `<token>.<account thumbprint>`.
+package stateless
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/http01"
+	"github.com/go-acme/lego/v4/platform/wait"
+)
+
+const (
+	// DefaultTimeout the default duration of the check of the web server rule.
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultInterval the default interval between two checks of the web server rule.
+	DefaultInterval = 2 * time.Second
+)
+
+// maxBodySize the key authorization is short, a larger body is not an expected response.
+const maxBodySize = 1024
+
+// HTTPProvider implements ChallengeProvider for `http-01` challenge.
+// Nothing is provisioned, the provider only checks that the web server rule is live before the validation.
+//
+// Example of nginx rule:
+//
+//	location ~ ^/\.well-known/acme-challenge/([-_a-zA-Z0-9]+)$ {
+//	  default_type text/plain;
+//	  return 200 "$1.<account thumbprint>";
+//	}
+type HTTPProvider struct {
+	HTTPClient *http.Client
+	Timeout    time.Duration
+	Interval   time.Duration
+
+	// BaseURL overrides the URL of the web server (by default `http://<domain>`).
+	BaseURL string
+}
+
+// NewHTTPProvider returns a HTTPProvider instance.
+func NewHTTPProvider() *HTTPProvider {
+	return &HTTPProvider{
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+		Timeout:    DefaultTimeout,
+		Interval:   DefaultInterval,
+	}
+}
+
+// Present checks that the web server answers the challenge with the key authorization.
+func (p *HTTPProvider) Present(domain, token, keyAuth string) error {
+	endpoint := p.challengeURL(domain, token)
+
+	err := wait.For("stateless HTTP-01 rule", p.Timeout, p.Interval, func() (bool, error) {
+		errC := p.check(endpoint, keyAuth)
+		if errC != nil {
+			return false, errC
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("stateless: the web server rule is not live for %s: %w", domain, err)
+	}
+
+	return nil
+}
+
+// CleanUp does nothing: there is nothing to clean.
+func (p *HTTPProvider) CleanUp(domain, token, keyAuth string) error {
+	return nil
+}
+
+func (p *HTTPProvider) check(endpoint, keyAuth string) error {
+	resp, err := p.HTTPClient.Get(endpoint)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return err
+	}
+
+	// Like the ACME servers, ignore the trailing whitespaces.
+	// https://www.rfc-editor.org/rfc/rfc8555.html#section-8.3
+	value := strings.TrimRight(string(raw), " \t\r\n")
+
+	if value != keyAuth {
+		return fmt.Errorf("unexpected response: got %q, expected %q", value, keyAuth)
+	}
+
+	return nil
+}
+
+func (p *HTTPProvider) challengeURL(domain, token string) string {
+	if p.BaseURL != "" {
+		return strings.TrimSuffix(p.BaseURL, "/") + http01.ChallengePath(token)
+	}
+
+	host := domain
+	if ip := net.ParseIP(domain); ip != nil && ip.To4() == nil {
+		host = "[" + domain + "]"
+	}
+
+	return "http://" + host + http01.ChallengePath(token)
+}
@@ -0,0 +1,68 @@
+package stateless
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPProvider_Present(t *testing.T) {
+	var calls atomic.Int64
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/acme-challenge/{token}", func(rw http.ResponseWriter, req *http.Request) {
+		// The rule is not live for the first request.
+		if calls.Add(1) == 1 {
+			http.NotFound(rw, req)
+			return
+		}
+
+		_, _ = rw.Write([]byte(req.PathValue("token") + ".thumbprint\n"))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	provider := NewHTTPProvider()
+	provider.HTTPClient = server.Client()
+	provider.BaseURL = server.URL
+	provider.Interval = 10 * time.Millisecond
+
+	err := provider.Present("example.com", "token", "token.thumbprint")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), calls.Load())
+
+	err = provider.CleanUp("example.com", "token", "token.thumbprint")
+	require.NoError(t, err)
+}
+
+func TestHTTPProvider_Present_wrongValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte("token.other"))
+	}))
+	t.Cleanup(server.Close)
+
+	provider := NewHTTPProvider()
+	provider.HTTPClient = server.Client()
+	provider.BaseURL = server.URL
+	provider.Timeout = 50 * time.Millisecond
+	provider.Interval = 10 * time.Millisecond
+
+	err := provider.Present("example.com", "token", "token.thumbprint")
+	require.EqualError(t, err, `stateless: the web server rule is not live for example.com: `+
+		`stateless HTTP-01 rule: time limit exceeded: last error: unexpected response: got "token.other", expected "token.thumbprint"`)
+}
+
+func TestHTTPProvider_challengeURL(t *testing.T) {
+	provider := NewHTTPProvider()
+
+	assert.Equal(t, "http://example.com/.well-known/acme-challenge/abc", provider.challengeURL("example.com", "abc"))
+	assert.Equal(t, "http://192.0.2.1/.well-known/acme-challenge/abc", provider.challengeURL("192.0.2.1", "abc"))
+	assert.Equal(t, "http://[2001:db8::1]/.well-known/acme-challenge/abc", provider.challengeURL("2001:db8::1", "abc"))
+}
@@ -1,12 +1,12 @@
 package registration
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
-	"github.com/go-acme/lego/v4/log"
 )
 
 const mailTo = "mailto:"
@@ -27,6 +27,9 @@ type RegisterEABOptions struct {
 	TermsOfServiceAgreed bool
 	Kid                  string
 	HmacEncoded          string
+	// HmacEncoding the encoding of HmacEncoded (api.EABEncodingAuto to detect it).
+	// If empty, HmacEncoded is decoded as base64url.
+	HmacEncoding api.EABEncoding
 }
 
 type Registrar struct {
@@ -43,6 +46,11 @@ func NewRegistrar(core *api.Core, user User) *Registrar {
 
 // Register the current account to the ACME server.
 func (r *Registrar) Register(options RegisterOptions) (*Resource, error) {
+	return r.RegisterContext(context.Background(), options)
+}
+
+// RegisterContext is like [Registrar.Register] with a context.
+func (r *Registrar) RegisterContext(ctx context.Context, options RegisterOptions) (*Resource, error) {
 	if r == nil || r.user == nil {
 		return nil, errors.New("acme: cannot register a nil client or user")
 	}
@@ -53,11 +61,11 @@ func (r *Registrar) Register(options RegisterOptions) (*Resource, error) {
 	}
 
 	if r.user.GetEmail() != "" {
-		log.Infof("acme: Registering account for %s", r.user.GetEmail())
+		r.core.Log().Infof("acme: Registering account for %s", r.user.GetEmail())
 		accMsg.Contact = []string{mailTo + r.user.GetEmail()}
 	}
 
-	account, err := r.core.Accounts.New(accMsg)
+	account, err := r.core.Accounts.NewContext(ctx, accMsg)
 	if err != nil {
 		// seems impossible
 		errorDetails := &acme.ProblemDetails{}
@@ -71,17 +79,32 @@ func (r *Registrar) Register(options RegisterOptions) (*Resource, error) {
 
 // RegisterWithExternalAccountBinding Register the current account to the ACME server.
 func (r *Registrar) RegisterWithExternalAccountBinding(options RegisterEABOptions) (*Resource, error) {
+	return r.RegisterWithExternalAccountBindingContext(context.Background(), options)
+}
+
+// RegisterWithExternalAccountBindingContext is like [Registrar.RegisterWithExternalAccountBinding] with a context.
+func (r *Registrar) RegisterWithExternalAccountBindingContext(ctx context.Context, options RegisterEABOptions) (*Resource, error) {
 	accMsg := acme.Account{
 		TermsOfServiceAgreed: options.TermsOfServiceAgreed,
 		Contact:              []string{},
 	}
 
 	if r.user.GetEmail() != "" {
-		log.Infof("acme: Registering account for %s", r.user.GetEmail())
+		r.core.Log().Infof("acme: Registering account for %s", r.user.GetEmail())
 		accMsg.Contact = []string{mailTo + r.user.GetEmail()}
 	}
 
-	account, err := r.core.Accounts.NewEAB(accMsg, options.Kid, options.HmacEncoded)
+	var (
+		account acme.ExtendedAccount
+		err     error
+	)
+
+	if options.HmacEncoding == "" {
+		account, err = r.core.Accounts.NewEABContext(ctx, accMsg, options.Kid, options.HmacEncoded)
+	} else {
+		account, err = r.core.Accounts.NewEABWithEncoding(ctx, accMsg, options.Kid, options.HmacEncoded, options.HmacEncoding)
+	}
+
 	if err != nil {
 		// seems impossible
 		errorDetails := &acme.ProblemDetails{}
@@ -98,14 +121,19 @@ func (r *Registrar) RegisterWithExternalAccountBinding(options RegisterEABOption
 // This is similar to the Register function,
 // but acting on an existing registration link and resource.
 func (r *Registrar) QueryRegistration() (*Resource, error) {
+	return r.QueryRegistrationContext(context.Background())
+}
+
+// QueryRegistrationContext is like [Registrar.QueryRegistration] with a context.
+func (r *Registrar) QueryRegistrationContext(ctx context.Context) (*Resource, error) {
 	if r == nil || r.user == nil || r.user.GetRegistration() == nil {
 		return nil, errors.New("acme: cannot query the registration of a nil client or user")
 	}
 
 	// Log the URL here instead of the email as the email may not be set
-	log.Infof("acme: Querying account for %s", r.user.GetRegistration().URI)
+	r.core.Log().Infof("acme: Querying account for %s", r.user.GetRegistration().URI)
 
-	account, err := r.core.Accounts.Get(r.user.GetRegistration().URI)
+	account, err := r.core.Accounts.GetContext(ctx, r.user.GetRegistration().URI)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +147,11 @@ func (r *Registrar) QueryRegistration() (*Resource, error) {
 
 // UpdateRegistration update the user registration on the ACME server.
 func (r *Registrar) UpdateRegistration(options RegisterOptions) (*Resource, error) {
+	return r.UpdateRegistrationContext(context.Background(), options)
+}
+
+// UpdateRegistrationContext is like [Registrar.UpdateRegistration] with a context.
+func (r *Registrar) UpdateRegistrationContext(ctx context.Context, options RegisterOptions) (*Resource, error) {
 	if r == nil || r.user == nil {
 		return nil, errors.New("acme: cannot update a nil client or user")
 	}
@@ -129,13 +162,13 @@ func (r *Registrar) UpdateRegistration(options RegisterOptions) (*Resource, erro
 	}
 
 	if r.user.GetEmail() != "" {
-		log.Infof("acme: Registering account for %s", r.user.GetEmail())
+		r.core.Log().Infof("acme: Registering account for %s", r.user.GetEmail())
 		accMsg.Contact = []string{mailTo + r.user.GetEmail()}
 	}
 
 	accountURL := r.user.GetRegistration().URI
 
-	account, err := r.core.Accounts.Update(accountURL, accMsg)
+	account, err := r.core.Accounts.UpdateContext(ctx, accountURL, accMsg)
 	if err != nil {
 		return nil, err
 	}
@@ -145,23 +178,33 @@ func (r *Registrar) UpdateRegistration(options RegisterOptions) (*Resource, erro
 
 // DeleteRegistration deletes the client's user registration from the ACME server.
 func (r *Registrar) DeleteRegistration() error {
+	return r.DeleteRegistrationContext(context.Background())
+}
+
+// DeleteRegistrationContext is like [Registrar.DeleteRegistration] with a context.
+func (r *Registrar) DeleteRegistrationContext(ctx context.Context) error {
 	if r == nil || r.user == nil {
 		return errors.New("acme: cannot unregister a nil client or user")
 	}
 
-	log.Infof("acme: Deleting account for %s", r.user.GetEmail())
+	r.core.Log().Infof("acme: Deleting account for %s", r.user.GetEmail())
 
-	return r.core.Accounts.Deactivate(r.user.GetRegistration().URI)
+	return r.core.Accounts.DeactivateContext(ctx, r.user.GetRegistration().URI)
 }
 
 // ResolveAccountByKey will attempt to look up an account using the given account key
 // and return its registration resource.
 func (r *Registrar) ResolveAccountByKey() (*Resource, error) {
-	log.Infof("acme: Trying to resolve account by key")
+	return r.ResolveAccountByKeyContext(context.Background())
+}
+
+// ResolveAccountByKeyContext is like [Registrar.ResolveAccountByKey] with a context.
+func (r *Registrar) ResolveAccountByKeyContext(ctx context.Context) (*Resource, error) {
+	r.core.Log().Infof("acme: Trying to resolve account by key")
 
 	accMsg := acme.Account{OnlyReturnExisting: true}
 
-	account, err := r.core.Accounts.New(accMsg)
+	account, err := r.core.Accounts.NewContext(ctx, accMsg)
 	if err != nil {
 		return nil, err
 	}
@@ -45,3 +45,112 @@ func TestRegistrar_ResolveAccountByKey(t *testing.T) {
 
 	assert.Equal(t, "valid", res.Body.Status, "Unexpected account status")
 }
+
+func TestRegistrar_ResolveAccountByKeyContext(t *testing.T) {
+	server := tester.MockACMEServer().
+		Route("/account",
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Header().Set("Location",
+					fmt.Sprintf("http://%s/account", req.Context().Value(http.LocalAddrContextKey)))
+
+				servermock.JSONEncode(acme.Account{Status: "valid"}).ServeHTTP(rw, req)
+			})).
+		BuildHTTPS(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err, "Could not generate test key")
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     &Resource{},
+		privatekey: key,
+	}
+
+	core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+	require.NoError(t, err)
+
+	registrar := NewRegistrar(core, user)
+
+	res, err := registrar.ResolveAccountByKeyContext(t.Context())
+	require.NoError(t, err, "Unexpected error resolving account by key")
+
+	assert.Equal(t, "valid", res.Body.Status, "Unexpected account status")
+}
+
+func TestRegistrar_RegisterWithExternalAccountBinding(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		hmac       string
+		encoding   api.EABEncoding
+		requireErr require.ErrorAssertionFunc
+	}{
+		{
+			desc:       "base64url key without encoding",
+			hmac:       "yMnKy8zNzs_Q0dLT1NXW19jZ2tvc3d7f4OHi4-Tl5uc=",
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "base64 key without encoding",
+			hmac:       "yMnKy8zNzs/Q0dLT1NXW19jZ2tvc3d7f4OHi4+Tl5uc=",
+			requireErr: require.Error,
+		},
+		{
+			desc:       "base64 key with detection",
+			hmac:       "yMnKy8zNzs/Q0dLT1NXW19jZ2tvc3d7f4OHi4+Tl5uc=",
+			encoding:   api.EABEncodingAuto,
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "hexadecimal key with detection",
+			hmac:       "c8c9cacbcccdcecfd0d1d2d3d4d5d6d7d8d9dadbdcdddedfe0e1e2e3e4e5e6e7",
+			encoding:   api.EABEncodingAuto,
+			requireErr: require.NoError,
+		},
+		{
+			desc:       "short key with detection",
+			hmac:       "c2hvcnQta2V5",
+			encoding:   api.EABEncodingAuto,
+			requireErr: require.Error,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			server := tester.MockACMEServer().
+				Route("POST /account",
+					http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+						rw.Header().Set("Location",
+							fmt.Sprintf("https://%s/account", req.Context().Value(http.LocalAddrContextKey)))
+
+						servermock.JSONEncode(acme.Account{Status: "valid"}).ServeHTTP(rw, req)
+					})).
+				BuildHTTPS(t)
+
+			key, err := rsa.GenerateKey(rand.Reader, 1024)
+			require.NoError(t, err, "Could not generate test key")
+
+			user := mockUser{
+				email:      "test@test.com",
+				regres:     &Resource{},
+				privatekey: key,
+			}
+
+			core, err := api.New(server.Client(), "lego-test", server.URL+"/dir", "", key)
+			require.NoError(t, err)
+
+			registrar := NewRegistrar(core, user)
+
+			res, err := registrar.RegisterWithExternalAccountBinding(RegisterEABOptions{
+				TermsOfServiceAgreed: true,
+				Kid:                  "kid",
+				HmacEncoded:          test.hmac,
+				HmacEncoding:         test.encoding,
+			})
+			test.requireErr(t, err)
+
+			if err == nil {
+				assert.Equal(t, "valid", res.Body.Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Package simple obtains certificates with a single call.
+//
+// The account and the certificates are stored in a directory, with the same layout as the CLI (`--path`):
+// a certificate obtained with this package can be renewed by the CLI, and vice versa.
+// Another storage can be used with Options.Storage.
+package simple
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge/http01"
+	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
+	"github.com/go-acme/lego/v4/lego"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/providers/dns"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/go-acme/lego/v4/storage"
+)
+
+// Providers of the HTTP-01 and TLS-ALPN-01 challenges.
+const (
+	// ProviderHTTP solves the HTTP-01 challenge with a built-in server on the port 80.
+	ProviderHTTP = "http"
+	// ProviderTLS solves the TLS-ALPN-01 challenge with a built-in server on the port 443.
+	ProviderTLS = "tls"
+)
+
+// Default values of the options.
+const (
+	DefaultPath        = ".lego"
+	DefaultKeyType     = certcrypto.EC256
+	DefaultRenewBefore = 30 * 24 * time.Hour
+)
+
+// Options the options of Obtain.
+type Options struct {
+	// Domains the domains of the certificate (required).
+	Domains []string
+
+	// Email the email of the account.
+	Email string
+
+	// Provider the challenge provider (required):
+	// ProviderHTTP, ProviderTLS, or the name of a DNS provider (ex: "cloudflare").
+	// The DNS providers are configured with their environment variables.
+	Provider string
+
+	// AcceptTOS indicates that the terms of service of the CA are accepted.
+	// Required to create the account.
+	AcceptTOS bool
+
+	// Path the directory of the accounts and the certificates.
+	// Default: DefaultPath.
+	Path string
+
+	// Storage the storage of the accounts and the certificates.
+	// Default: the filesystem storage in Path.
+	Storage storage.Backend
+
+	// CADirURL the URL of the directory of the CA.
+	// Default: lego.LEDirectoryProduction.
+	CADirURL string
+
+	// KeyType the type of the private keys.
+	// Default: DefaultKeyType.
+	KeyType certcrypto.KeyType
+
+	// RenewBefore a certificate is renewed when it expires in less than RenewBefore.
+	// Default: DefaultRenewBefore.
+	RenewBefore time.Duration
+
+	// HTTPClient the HTTP client used to call the CA.
+	// Default: the client of lego.NewConfig.
+	HTTPClient *http.Client
+}
+
+// Obtain returns a certificate for the domains:
+//   - the stored certificate if it covers the domains and doesn't need to be renewed,
+//   - otherwise, a new certificate is obtained and stored.
+//
+// The account is created (and stored) if needed.
+func Obtain(ctx context.Context, opts Options) (*certificate.Resource, error) {
+	err := opts.setDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	certsStorage := newCertificatesStorage(opts.Storage)
+
+	res, err := certsStorage.readFresh(ctx, opts.Domains, opts.RenewBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	if res != nil {
+		log.Infof("[%s] simple: the certificate doesn't need to be renewed.", res.Domain)
+
+		return res, nil
+	}
+
+	err = ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newClient(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err = client.Certificate.ObtainContext(ctx, certificate.ObtainRequest{
+		Domains: opts.Domains,
+		Bundle:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = certsStorage.save(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (o *Options) setDefaults() error {
+	if len(o.Domains) == 0 {
+		return errors.New("simple: missing domains")
+	}
+
+	if o.Provider == "" {
+		return errors.New("simple: missing provider")
+	}
+
+	o.Domains = slices.Clone(o.Domains)
+
+	if o.Path == "" {
+		o.Path = DefaultPath
+	}
+
+	if o.Storage == nil {
+		o.Storage = storage.NewFileSystem(o.Path)
+	}
+
+	if o.CADirURL == "" {
+		o.CADirURL = lego.LEDirectoryProduction
+	}
+
+	if o.KeyType == "" {
+		o.KeyType = DefaultKeyType
+	}
+
+	if o.RenewBefore <= 0 {
+		o.RenewBefore = DefaultRenewBefore
+	}
+
+	return nil
+}
+
+// newClient creates a client with a registered account and the challenge provider.
+func newClient(ctx context.Context, opts Options) (*lego.Client, error) {
+	accountsStorage := newAccountsStorage(opts.Storage, opts.CADirURL, opts.Email)
+
+	account, err := accountsStorage.load(ctx, opts.KeyType)
+	if err != nil {
+		return nil, err
+	}
+
+	config := lego.NewConfig(account)
+	config.CADirURL = opts.CADirURL
+	config.Certificate.KeyType = opts.KeyType
+
+	if opts.HTTPClient != nil {
+		config.HTTPClient = opts.HTTPClient
+	}
+
+	client, err := lego.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("simple: create client: %w", err)
+	}
+
+	err = setProvider(client, opts.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Registration != nil {
+		return client, nil
+	}
+
+	if !opts.AcceptTOS {
+		return nil, errors.New("simple: the terms of service must be accepted (AcceptTOS) to create the account")
+	}
+
+	account.Registration, err = client.Registration.RegisterContext(ctx, registration.RegisterOptions{TermsOfServiceAgreed: true})
+	if err != nil {
+		return nil, fmt.Errorf("simple: register account: %w", err)
+	}
+
+	err = accountsStorage.save(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func setProvider(client *lego.Client, name string) error {
+	switch name {
+	case ProviderHTTP:
+		return client.Challenge.SetHTTP01Provider(http01.NewProviderServer("", ""))
+
+	case ProviderTLS:
+		return client.Challenge.SetTLSALPN01Provider(tlsalpn01.NewProviderServer("", ""))
+
+	default:
+		provider, err := dns.NewDNSChallengeProviderByName(name)
+		if err != nil {
+			return fmt.Errorf("simple: %w", err)
+		}
+
+		return client.Challenge.SetDNS01Provider(provider)
+	}
+}
@@ -0,0 +1,222 @@
+package simple
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/platform/tester/servermock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObtain(t *testing.T) {
+	var accounts, orders atomic.Int32
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	server := tester.MockACMEServer().
+		Route("POST /account", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			accounts.Add(1)
+
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			rw.Header().Set("Location", serverURL+"/account/1")
+
+			servermock.JSONEncode(acme.Account{Status: acme.StatusValid}).
+				WithStatusCode(http.StatusCreated).
+				ServeHTTP(rw, req)
+		})).
+		Route("POST /newOrder", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			orders.Add(1)
+
+			var order acme.Order
+
+			err := readJWSPayload(req, &order)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			var authorizations []string
+			for _, identifier := range order.Identifiers {
+				authorizations = append(authorizations, serverURL+"/authz/"+identifier.Value)
+			}
+
+			rw.Header().Set("Location", serverURL+"/order")
+
+			servermock.JSONEncode(acme.Order{
+				Status:         acme.StatusPending,
+				Identifiers:    order.Identifiers,
+				Authorizations: authorizations,
+				Finalize:       serverURL + "/finalize",
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /authz/{domain}", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			// Already valid: no challenge to solve.
+			servermock.JSONEncode(acme.Authorization{
+				Status:     acme.StatusValid,
+				Identifier: acme.Identifier{Type: "dns", Value: req.PathValue("domain")},
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /finalize", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			var msg acme.CSRMessage
+
+			err := readJWSPayload(req, &msg)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			certPEM, err := signCSR(msg.Csr, caKey)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			serverURL := fmt.Sprintf("https://%s", req.Context().Value(http.LocalAddrContextKey))
+
+			rw.Header().Set("Location", serverURL+"/order")
+
+			servermock.JSONEncode(acme.Order{
+				Status:      acme.StatusValid,
+				Certificate: serverURL + "/certificate/" + base64.RawURLEncoding.EncodeToString(certPEM),
+			}).ServeHTTP(rw, req)
+		})).
+		Route("POST /certificate/{cert}", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			certPEM, err := base64.RawURLEncoding.DecodeString(req.PathValue("cert"))
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			_, _ = rw.Write(certPEM)
+		})).
+		BuildHTTPS(t)
+
+	dir := t.TempDir()
+
+	opts := Options{
+		Domains:    []string{"example.com", "www.example.com"},
+		Email:      "test@example.com",
+		Provider:   ProviderHTTP,
+		Path:       dir,
+		CADirURL:   server.URL + "/dir",
+		HTTPClient: server.Client(),
+	}
+
+	_, err = Obtain(t.Context(), opts)
+	require.EqualError(t, err, "simple: the terms of service must be accepted (AcceptTOS) to create the account")
+
+	opts.AcceptTOS = true
+
+	res, err := Obtain(t.Context(), opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com", res.Domain)
+	assert.NotEmpty(t, res.PrivateKey)
+
+	assert.FileExists(t, filepath.Join(dir, "certificates", "example.com.crt"))
+	assert.FileExists(t, filepath.Join(dir, "certificates", "example.com.key"))
+	assert.FileExists(t, filepath.Join(dir, "certificates", "example.com.json"))
+	assert.FileExists(t, filepath.Join(dir, "accounts", strings.ReplaceAll(server.Listener.Addr().String(), ":", "_"), "test@example.com", "account.json"))
+
+	// The stored certificate is still valid.
+	stored, err := Obtain(t.Context(), opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, res.Certificate, stored.Certificate)
+	assert.Equal(t, res.PrivateKey, stored.PrivateKey)
+
+	// A new domain: a new certificate with the existing account.
+	opts.Domains = append(opts.Domains, "api.example.com")
+
+	_, err = Obtain(t.Context(), opts)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 1, accounts.Load())
+	assert.EqualValues(t, 2, orders.Load())
+}
+
+func TestObtain_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err := Obtain(ctx, Options{
+		Domains:  []string{"example.com"},
+		Provider: ProviderHTTP,
+		Path:     t.TempDir(),
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestObtain_missingOptions(t *testing.T) {
+	_, err := Obtain(t.Context(), Options{Provider: ProviderHTTP})
+	require.EqualError(t, err, "simple: missing domains")
+
+	_, err = Obtain(t.Context(), Options{Domains: []string{"example.com"}})
+	require.EqualError(t, err, "simple: missing provider")
+}
+
+func signCSR(rawCSR string, caKey *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := base64.RawURLEncoding.DecodeString(rawCSR)
+	if err != nil {
+		return nil, err
+	}
+
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		return nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+	}
+
+	cert, err := x509.CreateCertificate(rand.Reader, template, template, csr.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return certcrypto.PEMEncode(certcrypto.DERCertificateBytes(cert)), nil
+}
+
+func readJWSPayload(req *http.Request, v any) error {
+	var jws struct {
+		Payload string `json:"payload"`
+	}
+
+	err := json.NewDecoder(req.Body).Decode(&jws)
+	if err != nil {
+		return err
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(jws.Payload)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
@@ -0,0 +1,200 @@
+package simple
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/registration"
+	"github.com/go-acme/lego/v4/storage"
+)
+
+// Same as the CLI.
+const userIDPlaceholder = "noemail@example.com"
+
+// account implements registration.User.
+type account struct {
+	Email        string                 `json:"email"`
+	Registration *registration.Resource `json:"registration"`
+	key          crypto.PrivateKey
+}
+
+func (a *account) GetEmail() string {
+	return a.Email
+}
+
+func (a *account) GetRegistration() *registration.Resource {
+	return a.Registration
+}
+
+func (a *account) GetPrivateKey() crypto.PrivateKey {
+	return a.key
+}
+
+// accountsStorage stores the account.
+type accountsStorage struct {
+	backend storage.Backend
+	id      storage.AccountID
+	email   string
+}
+
+func newAccountsStorage(backend storage.Backend, caDirURL, email string) *accountsStorage {
+	userID := email
+	if userID == "" {
+		userID = userIDPlaceholder
+	}
+
+	return &accountsStorage{
+		backend: backend,
+		id:      storage.AccountID{Server: caDirURL, UserID: userID},
+		email:   email,
+	}
+}
+
+// load loads the account, the private key is created if needed.
+// The registration of a new account is nil.
+func (s *accountsStorage) load(ctx context.Context, keyType certcrypto.KeyType) (*account, error) {
+	key, err := s.loadKey(ctx, keyType)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := &account{Email: s.email}
+
+	data, err := s.backend.ReadAccount(ctx, s.id)
+	if errors.Is(err, storage.ErrNotFound) {
+		acc.key = key
+
+		return acc, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("simple: read account: %w", err)
+	}
+
+	err = json.Unmarshal(data, acc)
+	if err != nil {
+		return nil, fmt.Errorf("simple: parse account: %w", err)
+	}
+
+	acc.key = key
+
+	if acc.Registration != nil && acc.Registration.Body.Status == "" {
+		// Incomplete account file: the account is registered again (the CA returns the existing account).
+		acc.Registration = nil
+	}
+
+	return acc, nil
+}
+
+func (s *accountsStorage) loadKey(ctx context.Context, keyType certcrypto.KeyType) (crypto.PrivateKey, error) {
+	key, err := storage.LoadAccountKey(ctx, s.backend, s.id)
+	if err == nil {
+		return key, nil
+	}
+
+	if !errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("simple: read account key: %w", err)
+	}
+
+	key, err = certcrypto.GeneratePrivateKey(keyType)
+	if err != nil {
+		return nil, fmt.Errorf("simple: generate account key: %w", err)
+	}
+
+	err = storage.SaveAccountKey(ctx, s.backend, s.id, key)
+	if err != nil {
+		return nil, fmt.Errorf("simple: save account key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (s *accountsStorage) save(ctx context.Context, acc *account) error {
+	data, err := json.MarshalIndent(acc, "", "\t")
+	if err != nil {
+		return fmt.Errorf("simple: marshal account: %w", err)
+	}
+
+	err = s.backend.WriteAccount(ctx, s.id, data)
+	if err != nil {
+		return fmt.Errorf("simple: save account: %w", err)
+	}
+
+	return nil
+}
+
+// certificatesStorage stores the certificates.
+type certificatesStorage struct {
+	backend storage.Backend
+}
+
+func newCertificatesStorage(backend storage.Backend) *certificatesStorage {
+	return &certificatesStorage{backend: backend}
+}
+
+// readFresh returns the stored certificate if it covers the domains and doesn't need to be renewed.
+// Returns nil if there is no such certificate.
+func (s *certificatesStorage) readFresh(ctx context.Context, domains []string, renewBefore time.Duration) (*certificate.Resource, error) {
+	name, err := storage.SanitizedName(domains[0])
+	if err != nil {
+		return nil, fmt.Errorf("simple: %w", err)
+	}
+
+	certBytes, err := s.backend.ReadCertificateFile(ctx, name, storage.ExtCertificate)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("simple: read certificate: %w", err)
+	}
+
+	certs, err := certcrypto.ParsePEMBundle(certBytes)
+	if err != nil {
+		return nil, fmt.Errorf("simple: parse certificate: %w", err)
+	}
+
+	if time.Until(certs[0].NotAfter) < renewBefore {
+		return nil, nil
+	}
+
+	certDomains := certcrypto.ExtractDomains(certs[0])
+
+	for _, domain := range domains {
+		if !slices.Contains(certDomains, domain) {
+			return nil, nil
+		}
+	}
+
+	res, err := storage.LoadResource(ctx, s.backend, name)
+	if err != nil {
+		return nil, fmt.Errorf("simple: %w", err)
+	}
+
+	if res.PrivateKey == nil {
+		return nil, errors.New("simple: the private key of the certificate is missing")
+	}
+
+	return res, nil
+}
+
+func (s *certificatesStorage) save(ctx context.Context, res *certificate.Resource) error {
+	name, err := storage.SanitizedName(res.Domain)
+	if err != nil {
+		return fmt.Errorf("simple: %w", err)
+	}
+
+	err = storage.SaveResource(ctx, s.backend, name, res)
+	if err != nil {
+		return fmt.Errorf("simple: save certificate: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,368 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	filePerm os.FileMode = 0o600
+	dirPerm  os.FileMode = 0o700
+
+	insecureFilePerm os.FileMode = 0o640
+	insecureDirPerm  os.FileMode = 0o750
+)
+
+const (
+	accountsFolderName     = "accounts"
+	keysFolderName         = "keys"
+	certificatesFolderName = "certificates"
+	archivesFolderName     = "archives"
+	accountFileName        = "account.json"
+)
+
+var _ Backend = (*FileSystem)(nil)
+
+// FileSystem the filesystem storage (the default storage of the CLI).
+//
+//	./.lego/accounts/localhost_14000/foo@example.com/account.json
+//	./.lego/accounts/localhost_14000/foo@example.com/keys/foo@example.com.key
+//	./.lego/certificates/example.com.crt
+//	./.lego/archives/1700000000.example.com.crt
+//	     │      │                   └── files of the certificates
+//	     │      └── root directories
+//	     └── root path
+type FileSystem struct {
+	accountsPath     string
+	certificatesPath string
+	archivesPath     string
+
+	insecurePermissions bool
+}
+
+// NewFileSystem creates a filesystem storage in the root path (ex: "./.lego").
+func NewFileSystem(path string) *FileSystem {
+	return &FileSystem{
+		accountsPath:     filepath.Join(path, accountsFolderName),
+		certificatesPath: filepath.Join(path, certificatesFolderName),
+		archivesPath:     filepath.Join(path, archivesFolderName),
+	}
+}
+
+// SetInsecurePermissions makes the files and the directories readable by the group (0640 and 0750),
+// and keeps the access control lists inherited from the parent directory on Windows.
+// For the shared hosts, where another user of the group (ex: the user of a web server) reads the certificates and the private keys.
+func (f *FileSystem) SetInsecurePermissions(insecure bool) {
+	f.insecurePermissions = insecure
+}
+
+// InsecurePermissions returns true if the files are readable by the group (see SetInsecurePermissions).
+func (f *FileSystem) InsecurePermissions() bool {
+	return f.insecurePermissions
+}
+
+// MkdirAll creates a directory, and its parents, with the permissions of the storage.
+func (f *FileSystem) MkdirAll(path string) error {
+	if f.insecurePermissions {
+		return os.MkdirAll(path, insecureDirPerm)
+	}
+
+	return os.MkdirAll(path, dirPerm)
+}
+
+// AccountsPath returns the root directory of the accounts.
+func (f *FileSystem) AccountsPath() string {
+	return f.accountsPath
+}
+
+// CertificatesPath returns the directory of the certificates.
+func (f *FileSystem) CertificatesPath() string {
+	return f.certificatesPath
+}
+
+// ArchivesPath returns the directory of the archived certificates.
+func (f *FileSystem) ArchivesPath() string {
+	return f.archivesPath
+}
+
+// AccountPath returns the directory of an account.
+func (f *FileSystem) AccountPath(id AccountID) (string, error) {
+	serverURL, err := url.Parse(id.Server)
+	if err != nil {
+		return "", err
+	}
+
+	serverPath := strings.NewReplacer(":", "_", "/", string(os.PathSeparator)).Replace(serverURL.Host)
+
+	return filepath.Join(f.accountsPath, serverPath, id.UserID), nil
+}
+
+// AccountKeyPath returns the path of the private key of an account.
+func (f *FileSystem) AccountKeyPath(id AccountID) (string, error) {
+	accountPath, err := f.AccountPath(id)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(accountPath, keysFolderName, id.UserID+ExtKey), nil
+}
+
+// CertificateFilePath returns the path of a file of a certificate.
+func (f *FileSystem) CertificateFilePath(name, ext string) string {
+	return filepath.Join(f.certificatesPath, name+ext)
+}
+
+func (f *FileSystem) ReadAccount(_ context.Context, id AccountID) ([]byte, error) {
+	accountPath, err := f.AccountPath(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(filepath.Join(accountPath, accountFileName))
+}
+
+func (f *FileSystem) WriteAccount(_ context.Context, id AccountID, data []byte) error {
+	accountPath, err := f.AccountPath(id)
+	if err != nil {
+		return err
+	}
+
+	return f.writeFile(filepath.Join(accountPath, accountFileName), data)
+}
+
+func (f *FileSystem) ReadAccountKey(_ context.Context, id AccountID) ([]byte, error) {
+	keyPath, err := f.AccountKeyPath(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(keyPath)
+}
+
+func (f *FileSystem) WriteAccountKey(_ context.Context, id AccountID, data []byte) error {
+	keyPath, err := f.AccountKeyPath(id)
+	if err != nil {
+		return err
+	}
+
+	return f.writeFile(keyPath, data)
+}
+
+func (f *FileSystem) ListCertificates(_ context.Context) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(f.certificatesPath, "*"+ExtCertificate))
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, filename := range matches {
+		if strings.HasSuffix(filename, ExtIssuer) {
+			continue
+		}
+
+		names = append(names, strings.TrimSuffix(filepath.Base(filename), ExtCertificate))
+	}
+
+	return names, nil
+}
+
+func (f *FileSystem) ReadCertificateFile(_ context.Context, name, ext string) ([]byte, error) {
+	return os.ReadFile(f.CertificateFilePath(name, ext))
+}
+
+// OpenCertificateFile opens a file of a certificate, to read it without loading it in memory.
+func (f *FileSystem) OpenCertificateFile(_ context.Context, name, ext string) (io.ReadCloser, error) {
+	return os.Open(f.CertificateFilePath(name, ext))
+}
+
+func (f *FileSystem) WriteCertificateFile(ctx context.Context, name, ext string, data []byte) error {
+	return f.WriteCertificateFileParts(ctx, name, ext, data)
+}
+
+// WriteCertificateFileParts writes the parts of a file one after the other without concatenating them in memory.
+func (f *FileSystem) WriteCertificateFileParts(_ context.Context, name, ext string, parts ...[]byte) error {
+	return f.writeFile(f.CertificateFilePath(name, ext), parts...)
+}
+
+func (f *FileSystem) DeleteCertificateFile(_ context.Context, name, ext string) error {
+	err := os.Remove(f.CertificateFilePath(name, ext))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// ArchiveCertificate moves the files of a certificate to the archives directory.
+// The files are prefixed by the date of the archiving (Unix time), so the archive of a certificate can be found.
+func (f *FileSystem) ArchiveCertificate(_ context.Context, name string) error {
+	files, err := certificateFiles(filepath.Join(f.certificatesPath, name))
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	err = f.MkdirAll(f.archivesPath)
+	if err != nil {
+		return err
+	}
+
+	date := strconv.FormatInt(time.Now().Unix(), 10)
+
+	for _, file := range files {
+		err = os.Rename(file, filepath.Join(f.archivesPath, date+"."+filepath.Base(file)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *FileSystem) DeleteCertificate(_ context.Context, name string) error {
+	return deleteCertificateFiles(filepath.Join(f.certificatesPath, name))
+}
+
+// ListArchivedCertificates returns the names of the archives: the names of the archived files (ex: "1700000000.example.com").
+func (f *FileSystem) ListArchivedCertificates(_ context.Context) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(f.archivesPath, "*"+ExtCertificate))
+	if err != nil {
+		return nil, err
+	}
+
+	var archiveNames []string
+
+	for _, filename := range matches {
+		if strings.HasSuffix(filename, ExtIssuer) {
+			continue
+		}
+
+		archiveName := strings.TrimSuffix(filepath.Base(filename), ExtCertificate)
+
+		if _, _, ok := ParseArchiveName(archiveName); !ok {
+			// Not a file created by ArchiveCertificate.
+			continue
+		}
+
+		archiveNames = append(archiveNames, archiveName)
+	}
+
+	return archiveNames, nil
+}
+
+// ArchivedCertificateFilePath returns the path of a file of an archived certificate.
+func (f *FileSystem) ArchivedCertificateFilePath(archiveName, ext string) string {
+	return filepath.Join(f.archivesPath, archiveName+ext)
+}
+
+func (f *FileSystem) ReadArchivedCertificateFile(_ context.Context, archiveName, ext string) ([]byte, error) {
+	return os.ReadFile(f.ArchivedCertificateFilePath(archiveName, ext))
+}
+
+// DeleteArchivedCertificate deletes all the files of an archived certificate.
+// The name is the name of the archived files (ex: "1700000000.example.com").
+func (f *FileSystem) DeleteArchivedCertificate(_ context.Context, archiveName string) error {
+	return deleteCertificateFiles(filepath.Join(f.archivesPath, archiveName))
+}
+
+func deleteCertificateFiles(baseFilename string) error {
+	files, err := certificateFiles(baseFilename)
+	if err != nil {
+		return err
+	}
+
+	var errAll error
+
+	for _, file := range files {
+		err = os.Remove(file)
+		if err != nil {
+			errAll = errors.Join(errAll, err)
+		}
+	}
+
+	return errAll
+}
+
+// certificateFiles returns the files of a certificate (base path without extension).
+func certificateFiles(baseFilename string) ([]string, error) {
+	matches, err := filepath.Glob(baseFilename + ".*")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+
+	for _, file := range matches {
+		// Excludes the certificates with the same prefix (ex: "example.com.example.org.crt" for "example.com").
+		if strings.TrimSuffix(file, filepath.Ext(file)) == baseFilename || file == baseFilename+ExtIssuer {
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+func (f *FileSystem) writeFile(filename string, parts ...[]byte) error {
+	err := f.MkdirAll(filepath.Dir(filename))
+	if err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	var file *os.File
+
+	if f.insecurePermissions {
+		file, err = createFile(filename, insecureFilePerm)
+	} else {
+		file, err = createPrivateFile(filename)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		_, err = file.Write(part)
+		if err != nil {
+			_ = file.Close()
+
+			return err
+		}
+	}
+
+	return file.Close()
+}
+
+// createFile creates, or truncates, a file.
+// A new file is created with the permissions, whatever the umask;
+// the permissions of an existing file are kept (ex: changed by the administrator).
+func createFile(filename string, perm os.FileMode) (*os.File, error) {
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if errors.Is(err, os.ErrExist) {
+		return os.OpenFile(filename, os.O_WRONLY|os.O_TRUNC, perm)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = file.Chmod(perm)
+	if err != nil {
+		_ = file.Close()
+
+		return nil, err
+	}
+
+	return file, nil
+}
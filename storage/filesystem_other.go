@@ -0,0 +1,10 @@
+//go:build !windows
+
+package storage
+
+import "os"
+
+// createPrivateFile creates, or truncates, a file readable only by the current user.
+func createPrivateFile(filename string) (*os.File, error) {
+	return createFile(filename, filePerm)
+}
@@ -0,0 +1,221 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSystem_account(t *testing.T) {
+	root := t.TempDir()
+
+	fs := NewFileSystem(root)
+
+	id := AccountID{Server: "https://localhost:14000/dir", UserID: "foo@example.com"}
+
+	_, err := fs.ReadAccount(t.Context(), id)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = fs.WriteAccount(t.Context(), id, []byte(`{"email":"foo@example.com"}`))
+	require.NoError(t, err)
+
+	err = fs.WriteAccountKey(t.Context(), id, []byte("key"))
+	require.NoError(t, err)
+
+	// Same layout as the CLI.
+	assert.FileExists(t, filepath.Join(root, "accounts", "localhost_14000", "foo@example.com", "account.json"))
+	assert.FileExists(t, filepath.Join(root, "accounts", "localhost_14000", "foo@example.com", "keys", "foo@example.com.key"))
+
+	data, err := fs.ReadAccount(t.Context(), id)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"email":"foo@example.com"}`, string(data))
+
+	key, err := fs.ReadAccountKey(t.Context(), id)
+	require.NoError(t, err)
+
+	assert.Equal(t, "key", string(key))
+}
+
+func TestFileSystem_certificates(t *testing.T) {
+	root := t.TempDir()
+
+	fs := NewFileSystem(root)
+
+	names, err := fs.ListCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Empty(t, names)
+
+	for _, name := range []string{"example.com", "example.com.example.org"} {
+		for _, ext := range []string{ExtCertificate, ExtIssuer, ExtKey, ExtResource} {
+			err = fs.WriteCertificateFile(t.Context(), name, ext, []byte(name+ext))
+			require.NoError(t, err)
+		}
+	}
+
+	names, err = fs.ListCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"example.com", "example.com.example.org"}, names)
+
+	data, err := fs.ReadCertificateFile(t.Context(), "example.com", ExtIssuer)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com"+ExtIssuer, string(data))
+
+	file, err := fs.OpenCertificateFile(t.Context(), "example.com", ExtKey)
+	require.NoError(t, err)
+
+	data, err = io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	assert.Equal(t, "example.com"+ExtKey, string(data))
+
+	err = fs.DeleteCertificateFile(t.Context(), "example.com", ExtResource)
+	require.NoError(t, err)
+
+	// A missing file is not an error.
+	err = fs.DeleteCertificateFile(t.Context(), "example.com", ExtResource)
+	require.NoError(t, err)
+
+	_, err = fs.ReadCertificateFile(t.Context(), "example.com", ExtResource)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = fs.DeleteCertificate(t.Context(), "example.com")
+	require.NoError(t, err)
+
+	names, err = fs.ListCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com.example.org"}, names)
+}
+
+func TestFileSystem_ArchiveCertificate(t *testing.T) {
+	fs := NewFileSystem(t.TempDir())
+
+	for _, name := range []string{"example.com", "example.com.example.org"} {
+		for _, ext := range []string{ExtCertificate, ExtIssuer, ExtKey} {
+			err := fs.WriteCertificateFile(t.Context(), name, ext, []byte("test"))
+			require.NoError(t, err)
+		}
+	}
+
+	err := fs.ArchiveCertificate(t.Context(), "example.com")
+	require.NoError(t, err)
+
+	archives, err := os.ReadDir(fs.ArchivesPath())
+	require.NoError(t, err)
+
+	require.Len(t, archives, 3)
+
+	for _, archive := range archives {
+		assert.Regexp(t, `^\d+\.`+regexp.QuoteMeta("example.com."), archive.Name())
+		assert.NotContains(t, archive.Name(), "example.org")
+	}
+
+	names, err := fs.ListCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com.example.org"}, names)
+
+	archiveNames, err := fs.ListArchivedCertificates(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, archiveNames, 1)
+	assert.Equal(t, strings.TrimSuffix(archives[0].Name(), ExtCertificate), archiveNames[0])
+
+	name, _, ok := ParseArchiveName(archiveNames[0])
+	require.True(t, ok)
+
+	assert.Equal(t, "example.com", name)
+
+	data, err := fs.ReadArchivedCertificateFile(t.Context(), archiveNames[0], ExtKey)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("test"), data)
+
+	err = fs.DeleteArchivedCertificate(t.Context(), archiveNames[0])
+	require.NoError(t, err)
+
+	archives, err = os.ReadDir(fs.ArchivesPath())
+	require.NoError(t, err)
+
+	assert.Empty(t, archives)
+}
+
+func TestFileSystem_permissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the permission bits are not used on Windows")
+	}
+
+	testCases := []struct {
+		desc         string
+		insecure     bool
+		expectedFile os.FileMode
+		expectedDir  os.FileMode
+	}{
+		{
+			desc:         "default",
+			expectedFile: 0o600,
+			expectedDir:  0o700,
+		},
+		{
+			desc:         "insecure permissions",
+			insecure:     true,
+			expectedFile: 0o640,
+			expectedDir:  0o750,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			fs := NewFileSystem(t.TempDir())
+			fs.SetInsecurePermissions(test.insecure)
+
+			err := fs.WriteCertificateFile(t.Context(), "example.com", ExtKey, []byte("key"))
+			require.NoError(t, err)
+
+			filename := fs.CertificateFilePath("example.com", ExtKey)
+
+			info, err := os.Stat(filename)
+			require.NoError(t, err)
+
+			// The umask is ignored.
+			assert.Equal(t, test.expectedFile, info.Mode().Perm())
+
+			// The permissions of an existing file are kept.
+			require.NoError(t, os.Chmod(filename, 0o400))
+
+			err = fs.WriteCertificateFile(t.Context(), "example.com", ExtKey, []byte("new key"))
+			require.NoError(t, err)
+
+			info, err = os.Stat(filename)
+			require.NoError(t, err)
+
+			assert.Equal(t, os.FileMode(0o400), info.Mode().Perm())
+
+			data, err := os.ReadFile(filename)
+			require.NoError(t, err)
+
+			assert.Equal(t, "new key", string(data))
+
+			err = fs.ArchiveCertificate(t.Context(), "example.com")
+			require.NoError(t, err)
+
+			info, err = os.Stat(fs.ArchivesPath())
+			require.NoError(t, err)
+
+			// The umask can only remove permissions.
+			assert.Equal(t, os.FileMode(0), info.Mode().Perm()&^test.expectedDir)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+//go:build windows
+
+package storage
+
+import (
+	"fmt"
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// createPrivateFile creates, or truncates, a file accessible only by the current user and SYSTEM.
+// The permission bits are ignored by Windows: a new file is created with its own access control list (DACL),
+// without the entries inherited from the parent directory.
+// The access control list of an existing file is kept (ex: changed by the administrator).
+func createPrivateFile(filename string) (*os.File, error) {
+	sd, err := privateSecurityDescriptor()
+	if err != nil {
+		return nil, fmt.Errorf("security descriptor: %w", err)
+	}
+
+	path, err := windows.UTF16PtrFromString(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	sa := &windows.SecurityAttributes{
+		Length:             uint32(unsafe.Sizeof(windows.SecurityAttributes{})),
+		SecurityDescriptor: sd,
+	}
+
+	// The file is not shared until it is closed.
+	handle, err := windows.CreateFile(path, windows.GENERIC_WRITE, 0, sa,
+		windows.CREATE_ALWAYS, windows.FILE_ATTRIBUTE_NORMAL, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: filename, Err: err}
+	}
+
+	// The security attributes are only applied to a new file.
+	return os.NewFile(uintptr(handle), filename), nil
+}
+
+// privateSecurityDescriptor returns a security descriptor granting the full access only to the current user and SYSTEM.
+func privateSecurityDescriptor() (*windows.SECURITY_DESCRIPTOR, error) {
+	user, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return nil, err
+	}
+
+	// D:P the DACL is protected (the entries of the parent are not inherited),
+	// (A;;FA;;;SID) allows the full access (FA) to the SID, SY is the SID of SYSTEM.
+	return windows.SecurityDescriptorFromString(fmt.Sprintf("D:P(A;;FA;;;SY)(A;;FA;;;%s)", user.User.Sid.String()))
+}
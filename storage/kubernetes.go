@@ -0,0 +1,784 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// The in-cluster configuration (service account).
+const (
+	serviceAccountPath      = "/var/run/secrets/kubernetes.io/serviceaccount"
+	serviceAccountToken     = serviceAccountPath + "/token"
+	serviceAccountCA        = serviceAccountPath + "/ca.crt"
+	serviceAccountNamespace = serviceAccountPath + "/namespace"
+)
+
+// The labels and the annotations of the secrets.
+const (
+	labelManagedBy      = "app.kubernetes.io/managed-by"
+	labelType           = "lego.go-acme.github.io/type"
+	annotationName      = "lego.go-acme.github.io/name"
+	annotationServer    = "lego.go-acme.github.io/server"
+	annotationUserID    = "lego.go-acme.github.io/user-id"
+	annotationArchiveAt = "lego.go-acme.github.io/archived-at"
+)
+
+// The types of the secrets (label lego.go-acme.github.io/type).
+const (
+	secretTypeAccount     = "account"
+	secretTypeCertificate = "certificate"
+	secretTypeArchive     = "archive"
+)
+
+// The keys of the data of the secrets.
+const (
+	k8sKeyAccount     = "account.json"
+	k8sKeyAccountKey  = "account.key"
+	k8sKeyCertificate = "tls.crt"
+	k8sKeyPrivateKey  = "tls.key"
+	k8sKeyIssuer      = "ca.crt"
+)
+
+// The name of a secret (DNS subdomain, RFC 1123).
+var dnsSubdomainRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+
+var _ Backend = (*Kubernetes)(nil)
+
+// KubernetesConfig the configuration of the Kubernetes storage.
+type KubernetesConfig struct {
+	// Kubeconfig the path of a kubeconfig file.
+	// The in-cluster configuration (service account) is used if empty.
+	Kubeconfig string
+
+	// Context the context of the kubeconfig (the current context if empty).
+	Context string
+
+	// Namespace the namespace of the secrets (the namespace of the context, or of the service account, if empty).
+	Namespace string
+
+	// Prefix the prefix of the names of the secrets ("lego" if empty).
+	Prefix string
+}
+
+// Kubernetes the Kubernetes storage: the accounts and the certificates are stored as Secrets.
+//
+//	lego-account-<hash>                   Opaque             (account.json, account.key)
+//	lego-certificate-example.com          kubernetes.io/tls  (tls.crt, tls.key, ca.crt, json, ...)
+//	lego-archive-1700000000.example.com   kubernetes.io/tls  (tls.crt, tls.key, ca.crt, json, ...)
+//	  │       │          └── name of the certificate (a hash if the name is not valid for a secret)
+//	  │       └── type
+//	  └── prefix
+//
+// The certificate secrets can be used directly by the Ingresses.
+// The secrets have the label "app.kubernetes.io/managed-by=lego",
+// the names of the certificates and the accounts are in the annotations.
+type Kubernetes struct {
+	server     *url.URL
+	namespace  string
+	prefix     string
+	token      func() (string, error)
+	httpClient *http.Client
+}
+
+// NewKubernetes creates a Kubernetes storage.
+func NewKubernetes(config KubernetesConfig) (*Kubernetes, error) {
+	var (
+		k   *Kubernetes
+		err error
+	)
+
+	if config.Kubeconfig == "" {
+		k, err = newInClusterKubernetes()
+	} else {
+		k, err = newKubeconfigKubernetes(config.Kubeconfig, config.Context)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: %w", err)
+	}
+
+	if config.Namespace != "" {
+		k.namespace = config.Namespace
+	}
+
+	if k.namespace == "" {
+		k.namespace = "default"
+	}
+
+	k.prefix = config.Prefix
+	if k.prefix == "" {
+		k.prefix = "lego"
+	}
+
+	return k, nil
+}
+
+func newInClusterKubernetes() (*Kubernetes, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("not running in a cluster (KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are not defined), use a kubeconfig")
+	}
+
+	caPEM, err := os.ReadFile(serviceAccountCA)
+	if err != nil {
+		return nil, fmt.Errorf("read the CA of the cluster: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("invalid CA of the cluster: %s", serviceAccountCA)
+	}
+
+	namespace, _ := os.ReadFile(serviceAccountNamespace)
+
+	return &Kubernetes{
+		server:    &url.URL{Scheme: "https", Host: net.JoinHostPort(host, port), Path: "/"},
+		namespace: strings.TrimSpace(string(namespace)),
+		// The token is rotated by the kubelet: it is read before each request.
+		token:      readTokenFile(serviceAccountToken),
+		httpClient: newK8sHTTPClient(&tls.Config{RootCAs: pool}),
+	}, nil
+}
+
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+			TLSServerName            string `yaml:"tls-server-name"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster   string `yaml:"cluster"`
+			User      string `yaml:"user"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string `yaml:"token"`
+			TokenFile             string `yaml:"tokenFile"`
+			ClientCertificate     string `yaml:"client-certificate"`
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKey             string `yaml:"client-key"`
+			ClientKeyData         string `yaml:"client-key-data"`
+			Exec                  any    `yaml:"exec"`
+			AuthProvider          any    `yaml:"auth-provider"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+func newKubeconfigKubernetes(filename, contextName string) (*Kubernetes, error) {
+	// KUBECONFIG can be a list of files: only the first one is used.
+	filename, _, _ = strings.Cut(filename, string(os.PathListSeparator))
+
+	raw, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("read kubeconfig: %w", err)
+	}
+
+	var kc kubeconfig
+
+	err = yaml.Unmarshal(raw, &kc)
+	if err != nil {
+		return nil, fmt.Errorf("parse kubeconfig: %w", err)
+	}
+
+	if contextName == "" {
+		contextName = kc.CurrentContext
+	}
+
+	dir := filepath.Dir(filename)
+
+	for _, c := range kc.Contexts {
+		if c.Name != contextName {
+			continue
+		}
+
+		k := &Kubernetes{namespace: c.Context.Namespace}
+
+		tlsConfig := &tls.Config{}
+
+		err = k.setupCluster(kc, c.Context.Cluster, dir, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		err = k.setupUser(kc, c.Context.User, dir, tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		k.httpClient = newK8sHTTPClient(tlsConfig)
+
+		return k, nil
+	}
+
+	return nil, fmt.Errorf("context %q not found in the kubeconfig", contextName)
+}
+
+func (k *Kubernetes) setupCluster(kc kubeconfig, name, dir string, tlsConfig *tls.Config) error {
+	for _, c := range kc.Clusters {
+		if c.Name != name {
+			continue
+		}
+
+		server, err := url.Parse(c.Cluster.Server)
+		if err != nil {
+			return fmt.Errorf("cluster %q: server: %w", name, err)
+		}
+
+		if server.Path == "" {
+			server.Path = "/"
+		}
+
+		k.server = server
+
+		tlsConfig.InsecureSkipVerify = c.Cluster.InsecureSkipTLSVerify
+		tlsConfig.ServerName = c.Cluster.TLSServerName
+
+		caPEM, err := readKubeconfigData(c.Cluster.CertificateAuthorityData, c.Cluster.CertificateAuthority, dir)
+		if err != nil {
+			return fmt.Errorf("cluster %q: certificate authority: %w", name, err)
+		}
+
+		if len(caPEM) > 0 {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caPEM) {
+				return fmt.Errorf("cluster %q: invalid certificate authority", name)
+			}
+
+			tlsConfig.RootCAs = pool
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("cluster %q not found in the kubeconfig", name)
+}
+
+func (k *Kubernetes) setupUser(kc kubeconfig, name, dir string, tlsConfig *tls.Config) error {
+	for _, u := range kc.Users {
+		if u.Name != name {
+			continue
+		}
+
+		if u.User.Exec != nil || u.User.AuthProvider != nil {
+			return fmt.Errorf("user %q: the exec and auth-provider authentications are not supported, use a token or a client certificate", name)
+		}
+
+		switch {
+		case u.User.Token != "":
+			token := u.User.Token
+			k.token = func() (string, error) { return token, nil }
+
+		case u.User.TokenFile != "":
+			k.token = readTokenFile(resolvePath(u.User.TokenFile, dir))
+		}
+
+		certPEM, err := readKubeconfigData(u.User.ClientCertificateData, u.User.ClientCertificate, dir)
+		if err != nil {
+			return fmt.Errorf("user %q: client certificate: %w", name, err)
+		}
+
+		keyPEM, err := readKubeconfigData(u.User.ClientKeyData, u.User.ClientKey, dir)
+		if err != nil {
+			return fmt.Errorf("user %q: client key: %w", name, err)
+		}
+
+		if len(certPEM) > 0 || len(keyPEM) > 0 {
+			cert, err := tls.X509KeyPair(certPEM, keyPEM)
+			if err != nil {
+				return fmt.Errorf("user %q: client certificate: %w", name, err)
+			}
+
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("user %q not found in the kubeconfig", name)
+}
+
+func (k *Kubernetes) ReadAccount(ctx context.Context, id AccountID) ([]byte, error) {
+	return k.readData(ctx, k.accountSecretName(id), k8sKeyAccount)
+}
+
+func (k *Kubernetes) WriteAccount(ctx context.Context, id AccountID, data []byte) error {
+	return k.writeAccountData(ctx, id, k8sKeyAccount, data)
+}
+
+func (k *Kubernetes) ReadAccountKey(ctx context.Context, id AccountID) ([]byte, error) {
+	return k.readData(ctx, k.accountSecretName(id), k8sKeyAccountKey)
+}
+
+func (k *Kubernetes) WriteAccountKey(ctx context.Context, id AccountID, data []byte) error {
+	return k.writeAccountData(ctx, id, k8sKeyAccountKey, data)
+}
+
+func (k *Kubernetes) ListCertificates(ctx context.Context) ([]string, error) {
+	secrets, err := k.list(ctx, secretTypeCertificate)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, secret := range secrets {
+		names = append(names, secret.Metadata.Annotations[annotationName])
+	}
+
+	return names, nil
+}
+
+func (k *Kubernetes) ReadCertificateFile(ctx context.Context, name, ext string) ([]byte, error) {
+	return k.readData(ctx, k.secretName(secretTypeCertificate, name), certificateKey(ext))
+}
+
+func (k *Kubernetes) WriteCertificateFile(ctx context.Context, name, ext string, data []byte) error {
+	secretName := k.secretName(secretTypeCertificate, name)
+
+	secret, err := k.get(ctx, secretName)
+	if errors.Is(err, ErrNotFound) {
+		secret = k.newCertificateSecret(secretName, secretTypeCertificate, name)
+		secret.Data[certificateKey(ext)] = data
+
+		return k.create(ctx, secret)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	secret.Data[certificateKey(ext)] = data
+
+	return k.replace(ctx, secret)
+}
+
+func (k *Kubernetes) DeleteCertificateFile(ctx context.Context, name, ext string) error {
+	secretName := k.secretName(secretTypeCertificate, name)
+
+	secret, err := k.get(ctx, secretName)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	key := certificateKey(ext)
+
+	if _, ok := secret.Data[key]; !ok {
+		return nil
+	}
+
+	switch key {
+	case k8sKeyCertificate, k8sKeyPrivateKey:
+		// Required by the type kubernetes.io/tls.
+		secret.Data[key] = []byte{}
+	default:
+		delete(secret.Data, key)
+	}
+
+	for _, value := range secret.Data {
+		if len(value) > 0 {
+			return k.replace(ctx, secret)
+		}
+	}
+
+	return k.delete(ctx, secretName)
+}
+
+// ArchiveCertificate moves the secret of a certificate to an archive secret.
+// The name of the archive is prefixed by the date of the archiving (Unix time), as the filesystem storage.
+func (k *Kubernetes) ArchiveCertificate(ctx context.Context, name string) error {
+	secretName := k.secretName(secretTypeCertificate, name)
+
+	secret, err := k.get(ctx, secretName)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	date := strconv.FormatInt(time.Now().Unix(), 10)
+
+	archive := k.newCertificateSecret(k.secretName(secretTypeArchive, date+"."+name), secretTypeArchive, name)
+	archive.Metadata.Annotations[annotationArchiveAt] = date
+
+	for key, value := range secret.Data {
+		archive.Data[key] = value
+	}
+
+	err = k.create(ctx, archive)
+	if err != nil {
+		return err
+	}
+
+	return k.delete(ctx, secretName)
+}
+
+func (k *Kubernetes) DeleteCertificate(ctx context.Context, name string) error {
+	return k.delete(ctx, k.secretName(secretTypeCertificate, name))
+}
+
+// ListArchivedCertificates returns the names of the archives ("<Unix time of the archiving>.<name>").
+func (k *Kubernetes) ListArchivedCertificates(ctx context.Context) ([]string, error) {
+	secrets, err := k.list(ctx, secretTypeArchive)
+	if err != nil {
+		return nil, err
+	}
+
+	var archiveNames []string
+
+	for _, secret := range secrets {
+		archiveNames = append(archiveNames,
+			secret.Metadata.Annotations[annotationArchiveAt]+"."+secret.Metadata.Annotations[annotationName])
+	}
+
+	return archiveNames, nil
+}
+
+func (k *Kubernetes) ReadArchivedCertificateFile(ctx context.Context, archiveName, ext string) ([]byte, error) {
+	return k.readData(ctx, k.secretName(secretTypeArchive, archiveName), certificateKey(ext))
+}
+
+func (k *Kubernetes) DeleteArchivedCertificate(ctx context.Context, archiveName string) error {
+	return k.delete(ctx, k.secretName(secretTypeArchive, archiveName))
+}
+
+func (k *Kubernetes) writeAccountData(ctx context.Context, id AccountID, key string, data []byte) error {
+	secretName := k.accountSecretName(id)
+
+	secret, err := k.get(ctx, secretName)
+	if errors.Is(err, ErrNotFound) {
+		secret = &k8sSecret{
+			Metadata: k8sMetadata{
+				Name:   secretName,
+				Labels: map[string]string{labelManagedBy: "lego", labelType: secretTypeAccount},
+				Annotations: map[string]string{
+					annotationServer: id.Server,
+					annotationUserID: id.UserID,
+				},
+			},
+			Type: "Opaque",
+			Data: map[string][]byte{key: data},
+		}
+
+		return k.create(ctx, secret)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+
+	secret.Data[key] = data
+
+	return k.replace(ctx, secret)
+}
+
+func (k *Kubernetes) newCertificateSecret(secretName, secretType, name string) *k8sSecret {
+	return &k8sSecret{
+		Metadata: k8sMetadata{
+			Name:        secretName,
+			Labels:      map[string]string{labelManagedBy: "lego", labelType: secretType},
+			Annotations: map[string]string{annotationName: name},
+		},
+		Type: "kubernetes.io/tls",
+		// Required by the type kubernetes.io/tls.
+		Data: map[string][]byte{k8sKeyCertificate: {}, k8sKeyPrivateKey: {}},
+	}
+}
+
+// readData reads a key of a secret, a missing or empty key is not found.
+func (k *Kubernetes) readData(ctx context.Context, secretName, key string) ([]byte, error) {
+	secret, err := k.get(ctx, secretName)
+	if err != nil {
+		return nil, err
+	}
+
+	value := secret.Data[key]
+	if len(value) == 0 {
+		return nil, fmt.Errorf("kubernetes: secret %s: key %s: %w", secretName, key, ErrNotFound)
+	}
+
+	return value, nil
+}
+
+// secretName returns the name of a secret: "<prefix>-<type>-<name>".
+// A hash of the name is used if the name is not valid for a secret (ex: "_.example.com").
+func (k *Kubernetes) secretName(secretType, name string) string {
+	secretName := k.prefix + "-" + secretType + "-" + name
+
+	if len(secretName) <= 253 && dnsSubdomainRegexp.MatchString(secretName) {
+		return secretName
+	}
+
+	return k.prefix + "-" + secretType + "-" + shortHash(name)
+}
+
+func (k *Kubernetes) accountSecretName(id AccountID) string {
+	return k.prefix + "-" + secretTypeAccount + "-" + shortHash(id.Server+"\n"+id.UserID)
+}
+
+// list lists the secrets of a type managed by lego, with the prefix of the storage.
+func (k *Kubernetes) list(ctx context.Context, secretType string) ([]k8sSecret, error) {
+	selector := labelManagedBy + "=lego," + labelType + "=" + secretType
+
+	var list struct {
+		Items []k8sSecret `json:"items"`
+	}
+
+	err := k.do(ctx, http.MethodGet, k.secretsEndpoint("", url.Values{"labelSelector": {selector}}), nil, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets []k8sSecret
+
+	for _, item := range list.Items {
+		// Other prefixes in the same namespace.
+		if !strings.HasPrefix(item.Metadata.Name, k.prefix+"-") {
+			continue
+		}
+
+		secrets = append(secrets, item)
+	}
+
+	return secrets, nil
+}
+
+func (k *Kubernetes) get(ctx context.Context, secretName string) (*k8sSecret, error) {
+	secret := &k8sSecret{}
+
+	err := k.do(ctx, http.MethodGet, k.secretsEndpoint(secretName, nil), nil, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func (k *Kubernetes) create(ctx context.Context, secret *k8sSecret) error {
+	secret.APIVersion = "v1"
+	secret.Kind = "Secret"
+
+	return k.do(ctx, http.MethodPost, k.secretsEndpoint("", nil), secret, nil)
+}
+
+// replace replaces a secret.
+// The replacement fails if the secret has been modified in the meantime (resourceVersion).
+func (k *Kubernetes) replace(ctx context.Context, secret *k8sSecret) error {
+	return k.do(ctx, http.MethodPut, k.secretsEndpoint(secret.Metadata.Name, nil), secret, nil)
+}
+
+func (k *Kubernetes) delete(ctx context.Context, secretName string) error {
+	err := k.do(ctx, http.MethodDelete, k.secretsEndpoint(secretName, nil), nil, nil)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+func (k *Kubernetes) secretsEndpoint(secretName string, query url.Values) *url.URL {
+	elements := []string{"api", "v1", "namespaces", k.namespace, "secrets"}
+
+	if secretName != "" {
+		elements = append(elements, secretName)
+	}
+
+	endpoint := k.server.JoinPath(elements...)
+	endpoint.RawQuery = query.Encode()
+
+	return endpoint
+}
+
+func (k *Kubernetes) do(ctx context.Context, method string, endpoint *url.URL, payload, result any) error {
+	body := new(bytes.Buffer)
+
+	if payload != nil {
+		err := json.NewEncoder(body).Encode(payload)
+		if err != nil {
+			return fmt.Errorf("kubernetes: create request JSON body: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+	if err != nil {
+		return fmt.Errorf("kubernetes: unable to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if k.token != nil {
+		token, err := k.token()
+		if err != nil {
+			return fmt.Errorf("kubernetes: token: %w", err)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := k.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("kubernetes: %s %s: %w", method, endpoint.Path, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("kubernetes: %s %s: read response: %w", method, endpoint.Path, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("kubernetes: %s: %w", endpoint.Path, ErrNotFound)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		var status struct {
+			Message string `json:"message"`
+		}
+
+		_ = json.Unmarshal(raw, &status)
+
+		return fmt.Errorf("kubernetes: %s %s: %d: %s", method, endpoint.Path, resp.StatusCode, status.Message)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("kubernetes: %s %s: unmarshal response: %w", method, endpoint.Path, err)
+	}
+
+	return nil
+}
+
+type k8sSecret struct {
+	APIVersion string            `json:"apiVersion,omitempty"`
+	Kind       string            `json:"kind,omitempty"`
+	Metadata   k8sMetadata       `json:"metadata"`
+	Type       string            `json:"type,omitempty"`
+	Data       map[string][]byte `json:"data,omitempty"`
+}
+
+type k8sMetadata struct {
+	Name            string            `json:"name"`
+	ResourceVersion string            `json:"resourceVersion,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Annotations     map[string]string `json:"annotations,omitempty"`
+}
+
+// certificateKey returns the key of a file of a certificate in a secret:
+// the keys of the type kubernetes.io/tls, or the extension without the leading dot.
+func certificateKey(ext string) string {
+	switch ext {
+	case ExtCertificate:
+		return k8sKeyCertificate
+	case ExtKey:
+		return k8sKeyPrivateKey
+	case ExtIssuer:
+		return k8sKeyIssuer
+	default:
+		return strings.TrimPrefix(ext, ".")
+	}
+}
+
+func shortHash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// readKubeconfigData reads a value of a kubeconfig: the data (base64) or the file.
+func readKubeconfigData(data, filename, dir string) ([]byte, error) {
+	if data != "" {
+		return base64.StdEncoding.DecodeString(data)
+	}
+
+	if filename != "" {
+		return os.ReadFile(resolvePath(filename, dir))
+	}
+
+	return nil, nil
+}
+
+// resolvePath resolves a path relative to the directory of the kubeconfig.
+func resolvePath(filename, dir string) string {
+	if filepath.IsAbs(filename) {
+		return filename
+	}
+
+	return filepath.Join(dir, filename)
+}
+
+func readTokenFile(filename string) func() (string, error) {
+	return func() (string, error) {
+		token, err := os.ReadFile(filename)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(string(token)), nil
+	}
+}
+
+func newK8sHTTPClient(tlsConfig *tls.Config) *http.Client {
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	if tr, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport := tr.Clone()
+		transport.TLSClientConfig = tlsConfig
+
+		client.Transport = transport
+	}
+
+	return client
+}
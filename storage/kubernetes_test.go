@@ -0,0 +1,421 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKubernetes an in-memory API server of the secrets of the namespace "lego".
+type fakeKubernetes struct {
+	mu      sync.Mutex
+	secrets map[string]*k8sSecret
+	version int
+}
+
+func newFakeKubernetes(t *testing.T) (*fakeKubernetes, *Kubernetes) {
+	t.Helper()
+
+	fake := &fakeKubernetes{secrets: map[string]*k8sSecret{}}
+
+	server := httptest.NewTLSServer(fake)
+	t.Cleanup(server.Close)
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+current-context: test
+clusters:
+- name: test
+  cluster:
+    server: %s
+    certificate-authority-data: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+    namespace: lego
+users:
+- name: test
+  user:
+    token: secret-token
+`, server.URL, base64.StdEncoding.EncodeToString(caPEM))
+
+	filename := filepath.Join(t.TempDir(), "kubeconfig")
+
+	err := os.WriteFile(filename, []byte(kubeconfig), 0o600)
+	require.NoError(t, err)
+
+	backend, err := NewKubernetes(KubernetesConfig{Kubeconfig: filename})
+	require.NoError(t, err)
+
+	return fake, backend
+}
+
+func (f *fakeKubernetes) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if req.Header.Get("Authorization") != "Bearer secret-token" {
+		writeStatus(rw, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	name, ok := strings.CutPrefix(req.URL.Path, "/api/v1/namespaces/lego/secrets")
+	if !ok {
+		writeStatus(rw, http.StatusNotFound, "not found")
+		return
+	}
+
+	name = strings.TrimPrefix(name, "/")
+
+	switch {
+	case name == "" && req.Method == http.MethodGet:
+		selector := req.URL.Query().Get("labelSelector")
+
+		var items []*k8sSecret
+
+		for _, secret := range f.secrets {
+			if selector == labelManagedBy+"=lego,"+labelType+"="+secret.Metadata.Labels[labelType] {
+				items = append(items, secret)
+			}
+		}
+
+		_ = json.NewEncoder(rw).Encode(map[string]any{"items": items})
+
+	case name == "" && req.Method == http.MethodPost:
+		secret, err := decodeSecret(req)
+		if err != nil {
+			writeStatus(rw, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		if _, exists := f.secrets[secret.Metadata.Name]; exists {
+			writeStatus(rw, http.StatusConflict, "already exists")
+			return
+		}
+
+		f.store(secret)
+
+		rw.WriteHeader(http.StatusCreated)
+
+	case req.Method == http.MethodGet:
+		secret, exists := f.secrets[name]
+		if !exists {
+			writeStatus(rw, http.StatusNotFound, "not found")
+			return
+		}
+
+		_ = json.NewEncoder(rw).Encode(secret)
+
+	case req.Method == http.MethodPut:
+		secret, err := decodeSecret(req)
+		if err != nil {
+			writeStatus(rw, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		current, exists := f.secrets[name]
+		if !exists {
+			writeStatus(rw, http.StatusNotFound, "not found")
+			return
+		}
+
+		if current.Metadata.ResourceVersion != secret.Metadata.ResourceVersion {
+			writeStatus(rw, http.StatusConflict, "the object has been modified")
+			return
+		}
+
+		f.store(secret)
+
+	case req.Method == http.MethodDelete:
+		if _, exists := f.secrets[name]; !exists {
+			writeStatus(rw, http.StatusNotFound, "not found")
+			return
+		}
+
+		delete(f.secrets, name)
+
+	default:
+		writeStatus(rw, http.StatusMethodNotAllowed, "unsupported")
+	}
+}
+
+func (f *fakeKubernetes) store(secret *k8sSecret) {
+	f.version++
+	secret.Metadata.ResourceVersion = strconv.Itoa(f.version)
+	f.secrets[secret.Metadata.Name] = secret
+}
+
+func decodeSecret(req *http.Request) (*k8sSecret, error) {
+	secret := &k8sSecret{}
+
+	err := json.NewDecoder(req.Body).Decode(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dnsSubdomainRegexp.MatchString(secret.Metadata.Name) {
+		return nil, fmt.Errorf("invalid name: %s", secret.Metadata.Name)
+	}
+
+	if secret.Type == "kubernetes.io/tls" {
+		for _, key := range []string{k8sKeyCertificate, k8sKeyPrivateKey} {
+			if _, ok := secret.Data[key]; !ok {
+				return nil, fmt.Errorf("data[%s]: Required value", key)
+			}
+		}
+	}
+
+	return secret, nil
+}
+
+func writeStatus(rw http.ResponseWriter, code int, message string) {
+	rw.WriteHeader(code)
+	_ = json.NewEncoder(rw).Encode(map[string]any{"kind": "Status", "message": message, "code": code})
+}
+
+func TestNewKubernetes_errors(t *testing.T) {
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	dir := t.TempDir()
+
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+
+	err := os.WriteFile(kubeconfig, []byte(`current-context: test
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+users:
+- name: test
+  user:
+    exec:
+      command: aws
+`), 0o600)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		config   KubernetesConfig
+		expected string
+	}{
+		{
+			desc:     "not in a cluster",
+			config:   KubernetesConfig{},
+			expected: "kubernetes: not running in a cluster (KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are not defined), use a kubeconfig",
+		},
+		{
+			desc:     "unknown context",
+			config:   KubernetesConfig{Kubeconfig: kubeconfig, Context: "other"},
+			expected: `kubernetes: context "other" not found in the kubeconfig`,
+		},
+		{
+			desc:     "exec authentication",
+			config:   KubernetesConfig{Kubeconfig: kubeconfig},
+			expected: `kubernetes: user "test": the exec and auth-provider authentications are not supported, use a token or a client certificate`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := NewKubernetes(test.config)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestKubernetes_account(t *testing.T) {
+	fake, backend := newFakeKubernetes(t)
+
+	id := AccountID{Server: "https://localhost:14000/dir", UserID: "foo@example.com"}
+
+	_, err := backend.ReadAccount(t.Context(), id)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = backend.WriteAccountKey(t.Context(), id, []byte("key"))
+	require.NoError(t, err)
+
+	// The account key exists, not the account.
+	_, err = backend.ReadAccount(t.Context(), id)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = backend.WriteAccount(t.Context(), id, []byte(`{"email":"foo@example.com"}`))
+	require.NoError(t, err)
+
+	require.Len(t, fake.secrets, 1)
+
+	for name, secret := range fake.secrets {
+		assert.Regexp(t, `^lego-account-[0-9a-f]{16}$`, name)
+		assert.Equal(t, "Opaque", secret.Type)
+		assert.Equal(t, "foo@example.com", secret.Metadata.Annotations[annotationUserID])
+	}
+
+	data, err := backend.ReadAccount(t.Context(), id)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"email":"foo@example.com"}`, string(data))
+
+	key, err := backend.ReadAccountKey(t.Context(), id)
+	require.NoError(t, err)
+
+	assert.Equal(t, "key", string(key))
+}
+
+func TestKubernetes_certificates(t *testing.T) {
+	fake, backend := newFakeKubernetes(t)
+
+	names, err := backend.ListCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Empty(t, names)
+
+	for _, name := range []string{"example.com", "_.example.org"} {
+		for _, ext := range []string{ExtCertificate, ExtIssuer, ExtKey, ExtResource} {
+			err = backend.WriteCertificateFile(t.Context(), name, ext, []byte(name+ext))
+			require.NoError(t, err)
+		}
+	}
+
+	secret := fake.secrets["lego-certificate-example.com"]
+	require.NotNil(t, secret)
+
+	assert.Equal(t, "kubernetes.io/tls", secret.Type)
+
+	expected := map[string][]byte{
+		"tls.crt": []byte("example.com.crt"),
+		"tls.key": []byte("example.com.key"),
+		"ca.crt":  []byte("example.com.issuer.crt"),
+		"json":    []byte("example.com.json"),
+	}
+
+	assert.Equal(t, expected, secret.Data)
+
+	names, err = backend.ListCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"example.com", "_.example.org"}, names)
+
+	data, err := backend.ReadCertificateFile(t.Context(), "_.example.org", ExtIssuer)
+	require.NoError(t, err)
+
+	assert.Equal(t, "_.example.org"+ExtIssuer, string(data))
+
+	err = backend.DeleteCertificateFile(t.Context(), "example.com", ExtKey)
+	require.NoError(t, err)
+
+	// A missing file is not an error.
+	err = backend.DeleteCertificateFile(t.Context(), "example.com", ExtResource)
+	require.NoError(t, err)
+
+	err = backend.DeleteCertificateFile(t.Context(), "example.com", ExtResource)
+	require.NoError(t, err)
+
+	_, err = backend.ReadCertificateFile(t.Context(), "example.com", ExtKey)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = backend.ReadCertificateFile(t.Context(), "example.net", ExtCertificate)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = backend.DeleteCertificate(t.Context(), "_.example.org")
+	require.NoError(t, err)
+
+	names, err = backend.ListCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com"}, names)
+}
+
+func TestKubernetes_ArchiveCertificate(t *testing.T) {
+	fake, backend := newFakeKubernetes(t)
+
+	for _, ext := range []string{ExtCertificate, ExtIssuer, ExtKey} {
+		err := backend.WriteCertificateFile(t.Context(), "example.com", ext, []byte("test"))
+		require.NoError(t, err)
+	}
+
+	err := backend.ArchiveCertificate(t.Context(), "example.com")
+	require.NoError(t, err)
+
+	names, err := backend.ListCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Empty(t, names)
+
+	require.Len(t, fake.secrets, 1)
+
+	for name, secret := range fake.secrets {
+		assert.Regexp(t, `^lego-archive-\d+\.example\.com$`, name)
+		assert.Equal(t, secretTypeArchive, secret.Metadata.Labels[labelType])
+		assert.Equal(t, "example.com", secret.Metadata.Annotations[annotationName])
+		assert.Equal(t, map[string][]byte{"tls.crt": []byte("test"), "tls.key": []byte("test"), "ca.crt": []byte("test")}, secret.Data)
+	}
+
+	// Nothing to archive.
+	err = backend.ArchiveCertificate(t.Context(), "example.com")
+	require.NoError(t, err)
+}
+
+func TestKubernetes_archives(t *testing.T) {
+	_, backend := newFakeKubernetes(t)
+
+	archiveNames, err := backend.ListArchivedCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Empty(t, archiveNames)
+
+	for _, name := range []string{"example.com", "_.example.org"} {
+		err = backend.WriteCertificateFile(t.Context(), name, ExtCertificate, []byte("cert"))
+		require.NoError(t, err)
+
+		err = backend.ArchiveCertificate(t.Context(), name)
+		require.NoError(t, err)
+	}
+
+	archiveNames, err = backend.ListArchivedCertificates(t.Context())
+	require.NoError(t, err)
+
+	slices.Sort(archiveNames)
+
+	require.Len(t, archiveNames, 2)
+	assert.Regexp(t, `^\d+\._\.example\.org$`, archiveNames[0])
+	assert.Regexp(t, `^\d+\.example\.com$`, archiveNames[1])
+
+	for _, archiveName := range archiveNames {
+		data, errR := backend.ReadArchivedCertificateFile(t.Context(), archiveName, ExtCertificate)
+		require.NoError(t, errR)
+
+		assert.Equal(t, []byte("cert"), data)
+
+		_, errR = backend.ReadArchivedCertificateFile(t.Context(), archiveName, ExtIssuer)
+		require.ErrorIs(t, errR, ErrNotFound)
+
+		err = backend.DeleteArchivedCertificate(t.Context(), archiveName)
+		require.NoError(t, err)
+	}
+
+	archiveNames, err = backend.ListArchivedCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Empty(t, archiveNames)
+}
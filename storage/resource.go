@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"golang.org/x/net/idna"
+)
+
+// SaveResource writes the certificate, its issuer and its private key (if any), and the metadata of the resource.
+func SaveResource(ctx context.Context, backend Backend, name string, certRes *certificate.Resource) error {
+	err := backend.WriteCertificateFile(ctx, name, ExtCertificate, certRes.Certificate)
+	if err != nil {
+		return fmt.Errorf("write certificate: %w", err)
+	}
+
+	if certRes.IssuerCertificate != nil {
+		err = backend.WriteCertificateFile(ctx, name, ExtIssuer, certRes.IssuerCertificate)
+		if err != nil {
+			return fmt.Errorf("write issuer certificate: %w", err)
+		}
+	}
+
+	// With a CSR, the private key is unknown.
+	if certRes.PrivateKey != nil {
+		err = backend.WriteCertificateFile(ctx, name, ExtKey, certRes.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("write private key: %w", err)
+		}
+	}
+
+	data, err := json.MarshalIndent(certRes, "", "\t")
+	if err != nil {
+		return fmt.Errorf("marshal resource: %w", err)
+	}
+
+	err = backend.WriteCertificateFile(ctx, name, ExtResource, data)
+	if err != nil {
+		return fmt.Errorf("write resource: %w", err)
+	}
+
+	return nil
+}
+
+// LoadResource reads the metadata of a resource, the certificate, its issuer and its private key (if any).
+func LoadResource(ctx context.Context, backend Backend, name string) (*certificate.Resource, error) {
+	data, err := backend.ReadCertificateFile(ctx, name, ExtResource)
+	if err != nil {
+		return nil, fmt.Errorf("read resource: %w", err)
+	}
+
+	certRes := &certificate.Resource{}
+
+	err = json.Unmarshal(data, certRes)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal resource: %w", err)
+	}
+
+	certRes.Certificate, err = backend.ReadCertificateFile(ctx, name, ExtCertificate)
+	if err != nil {
+		return nil, fmt.Errorf("read certificate: %w", err)
+	}
+
+	certRes.IssuerCertificate, err = readOptional(ctx, backend, name, ExtIssuer)
+	if err != nil {
+		return nil, fmt.Errorf("read issuer certificate: %w", err)
+	}
+
+	certRes.PrivateKey, err = readOptional(ctx, backend, name, ExtKey)
+	if err != nil {
+		return nil, fmt.Errorf("read private key: %w", err)
+	}
+
+	return certRes, nil
+}
+
+// LoadAccountKey reads the private key of an account.
+func LoadAccountKey(ctx context.Context, backend Backend, id AccountID) (crypto.PrivateKey, error) {
+	data, err := backend.ReadAccountKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return certcrypto.ParsePEMPrivateKey(data)
+}
+
+// SaveAccountKey writes the private key of an account.
+func SaveAccountKey(ctx context.Context, backend Backend, id AccountID, privateKey crypto.PrivateKey) error {
+	return backend.WriteAccountKey(ctx, id, certcrypto.PEMEncode(privateKey))
+}
+
+func readOptional(ctx context.Context, backend Backend, name, ext string) ([]byte, error) {
+	data, err := backend.ReadCertificateFile(ctx, name, ext)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+
+	return data, err
+}
+
+// SanitizedName returns the name of the certificate of a domain: the wildcards and the ports are replaced, and the domain is converted to ASCII.
+func SanitizedName(domain string) (string, error) {
+	return idna.ToASCII(strings.NewReplacer(":", "-", "*", "_").Replace(domain))
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveResource(t *testing.T) {
+	fs := NewFileSystem(t.TempDir())
+
+	certRes := &certificate.Resource{
+		Domain:            "example.com",
+		CertURL:           "https://example.com/cert/1",
+		CertStableURL:     "https://example.com/cert/1",
+		Certificate:       []byte("certificate"),
+		IssuerCertificate: []byte("issuer"),
+		PrivateKey:        []byte("key"),
+	}
+
+	err := SaveResource(t.Context(), fs, "example.com", certRes)
+	require.NoError(t, err)
+
+	loaded, err := LoadResource(t.Context(), fs, "example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, certRes, loaded)
+}
+
+func TestSaveResource_withoutPrivateKey(t *testing.T) {
+	fs := NewFileSystem(t.TempDir())
+
+	certRes := &certificate.Resource{
+		Domain:      "example.com",
+		CertURL:     "https://example.com/cert/1",
+		Certificate: []byte("certificate"),
+	}
+
+	err := SaveResource(t.Context(), fs, "example.com", certRes)
+	require.NoError(t, err)
+
+	_, err = fs.ReadCertificateFile(t.Context(), "example.com", ExtKey)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	loaded, err := LoadResource(t.Context(), fs, "example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, certRes, loaded)
+}
+
+func TestLoadResource_notFound(t *testing.T) {
+	fs := NewFileSystem(t.TempDir())
+
+	_, err := LoadResource(t.Context(), fs, "example.com")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSaveAccountKey(t *testing.T) {
+	fs := NewFileSystem(t.TempDir())
+
+	id := AccountID{Server: "https://example.com/dir", UserID: "foo@example.com"}
+
+	_, err := LoadAccountKey(t.Context(), fs, id)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	privateKey, err := certcrypto.GeneratePrivateKey(certcrypto.EC256)
+	require.NoError(t, err)
+
+	err = SaveAccountKey(t.Context(), fs, id, privateKey)
+	require.NoError(t, err)
+
+	loaded, err := LoadAccountKey(t.Context(), fs, id)
+	require.NoError(t, err)
+
+	assert.Equal(t, privateKey, loaded)
+}
+
+func TestSanitizedName(t *testing.T) {
+	testCases := []struct {
+		domain   string
+		expected string
+	}{
+		{domain: "example.com", expected: "example.com"},
+		{domain: "*.example.com", expected: "_.example.com"},
+		{domain: "example.com:8443", expected: "example.com-8443"},
+		{domain: "bücher.example", expected: "xn--bcher-kva.example"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.domain, func(t *testing.T) {
+			t.Parallel()
+
+			name, err := SanitizedName(test.domain)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, name)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+// Package storage stores the accounts and the certificates.
+package storage
+
+import (
+	"context"
+	"io/fs"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The extensions of the files of a certificate.
+const (
+	ExtCertificate = ".crt"
+	ExtIssuer      = ".issuer.crt"
+	ExtKey         = ".key"
+	ExtResource    = ".json"
+)
+
+// ErrNotFound is returned (wrapped) by the backends when the data doesn't exist.
+// It matches the errors of the filesystem (fs.ErrNotExist).
+var ErrNotFound = fs.ErrNotExist
+
+// AccountID identifies an account.
+type AccountID struct {
+	// Server the URL of the directory of the CA.
+	Server string
+
+	// UserID the ID of the user (usually the email).
+	UserID string
+}
+
+// Backend a storage of the accounts (account data and private keys) and of the certificates.
+//
+// The certificates are identified by a name (usually the sanitized main domain),
+// and are stored as a set of files identified by their extension:
+// the certificate (ExtCertificate), its issuer (ExtIssuer), its private key (ExtKey),
+// the metadata of the certificate resource (ExtResource), and any other file (bundles, renewal state, etc.).
+type Backend interface {
+	// ReadAccount reads the account data (JSON).
+	ReadAccount(ctx context.Context, id AccountID) ([]byte, error)
+	// WriteAccount writes the account data (JSON).
+	WriteAccount(ctx context.Context, id AccountID, data []byte) error
+
+	// ReadAccountKey reads the private key (PEM) of the account.
+	ReadAccountKey(ctx context.Context, id AccountID) ([]byte, error)
+	// WriteAccountKey writes the private key (PEM) of the account.
+	WriteAccountKey(ctx context.Context, id AccountID, data []byte) error
+
+	// ListCertificates returns the names of the certificates.
+	ListCertificates(ctx context.Context) ([]string, error)
+
+	// ReadCertificateFile reads a file of a certificate.
+	ReadCertificateFile(ctx context.Context, name, ext string) ([]byte, error)
+	// WriteCertificateFile writes a file of a certificate.
+	WriteCertificateFile(ctx context.Context, name, ext string, data []byte) error
+	// DeleteCertificateFile deletes a file of a certificate, a missing file is not an error.
+	DeleteCertificateFile(ctx context.Context, name, ext string) error
+
+	// ArchiveCertificate moves all the files of a certificate out of the current certificates.
+	ArchiveCertificate(ctx context.Context, name string) error
+	// DeleteCertificate deletes all the files of a certificate.
+	DeleteCertificate(ctx context.Context, name string) error
+
+	// ListArchivedCertificates returns the names of the archives (see ParseArchiveName).
+	ListArchivedCertificates(ctx context.Context) ([]string, error)
+	// ReadArchivedCertificateFile reads a file of an archived certificate.
+	ReadArchivedCertificateFile(ctx context.Context, archiveName, ext string) ([]byte, error)
+	// DeleteArchivedCertificate deletes all the files of an archived certificate, a missing archive is not an error.
+	DeleteArchivedCertificate(ctx context.Context, archiveName string) error
+}
+
+// ParseArchiveName parses the name of an archive: "<Unix time of the archiving>.<name of the certificate>".
+// Returns false if the name is not the name of an archive created by ArchiveCertificate.
+func ParseArchiveName(archiveName string) (string, time.Time, bool) {
+	date, name, ok := strings.Cut(archiveName, ".")
+	if !ok || name == "" {
+		return "", time.Time{}, false
+	}
+
+	seconds, err := strconv.ParseInt(date, 10, 64)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+
+	return name, time.Unix(seconds, 0), true
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseArchiveName(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		archiveName  string
+		expectedName string
+		expectedDate time.Time
+		expectedOK   bool
+	}{
+		{
+			desc:         "archive",
+			archiveName:  "1700000000.example.com",
+			expectedName: "example.com",
+			expectedDate: time.Unix(1700000000, 0),
+			expectedOK:   true,
+		},
+		{
+			desc:         "wildcard",
+			archiveName:  "1700000000._.example.com",
+			expectedName: "_.example.com",
+			expectedDate: time.Unix(1700000000, 0),
+			expectedOK:   true,
+		},
+		{
+			desc:        "no date",
+			archiveName: "example.com",
+		},
+		{
+			desc:        "no name",
+			archiveName: "1700000000.",
+		},
+		{
+			desc:        "no dot",
+			archiveName: "1700000000",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			name, date, ok := ParseArchiveName(test.archiveName)
+
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expectedName, name)
+			assert.Equal(t, test.expectedDate, date)
+		})
+	}
+}
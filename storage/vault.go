@@ -0,0 +1,429 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// base64Suffix the suffix of the fields containing binary data (ex: PKCS#12), encoded in base64.
+const base64Suffix = ".b64"
+
+const (
+	vaultAccountField = "account"
+	vaultKeyField     = "key"
+)
+
+var _ Backend = (*Vault)(nil)
+
+// VaultConfig the configuration of the Vault storage.
+type VaultConfig struct {
+	// Address the address of the Vault server (ex: "https://vault.example.com:8200").
+	Address string
+
+	// Token the Vault token.
+	Token string
+
+	// Path the path of the storage in a KV version 2 secrets engine: "<mount>/<path>" (ex: "secret/lego").
+	Path string
+
+	// Namespace the Vault namespace (Vault Enterprise, optional).
+	Namespace string
+
+	HTTPClient *http.Client
+}
+
+// Vault the HashiCorp Vault storage (KV version 2 secrets engine).
+//
+//	secret/lego/accounts/localhost_14000/foo@example.com  (account, key)
+//	secret/lego/certificates/example.com                  (crt, issuer.crt, key, json, ...)
+//	secret/lego/archives/1700000000.example.com           (crt, issuer.crt, key, json, ...)
+//	  │     │      └── secrets
+//	  │     └── path
+//	  └── mount
+//
+// The files of a certificate are the fields of a secret, named by their extension without the leading dot.
+// The binary files are encoded in base64 (fields with the suffix ".b64").
+type Vault struct {
+	baseURL    *url.URL
+	token      string
+	namespace  string
+	mount      string
+	path       string
+	httpClient *http.Client
+}
+
+// NewVault creates a Vault storage.
+func NewVault(config VaultConfig) (*Vault, error) {
+	if config.Address == "" {
+		return nil, errors.New("vault: missing address")
+	}
+
+	if config.Token == "" {
+		return nil, errors.New("vault: missing token")
+	}
+
+	baseURL, err := url.Parse(config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("vault: address: %w", err)
+	}
+
+	if baseURL.Path == "" {
+		baseURL.Path = "/"
+	}
+
+	mount, path, _ := strings.Cut(strings.Trim(config.Path, "/"), "/")
+	if mount == "" {
+		return nil, errors.New("vault: missing path (<mount>/<path>)")
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &Vault{
+		baseURL:    baseURL,
+		token:      config.Token,
+		namespace:  config.Namespace,
+		mount:      mount,
+		path:       path,
+		httpClient: httpClient,
+	}, nil
+}
+
+func (v *Vault) ReadAccount(ctx context.Context, id AccountID) ([]byte, error) {
+	return v.readAccountField(ctx, id, vaultAccountField)
+}
+
+func (v *Vault) WriteAccount(ctx context.Context, id AccountID, data []byte) error {
+	return v.writeAccountField(ctx, id, vaultAccountField, data)
+}
+
+func (v *Vault) ReadAccountKey(ctx context.Context, id AccountID) ([]byte, error) {
+	return v.readAccountField(ctx, id, vaultKeyField)
+}
+
+func (v *Vault) WriteAccountKey(ctx context.Context, id AccountID, data []byte) error {
+	return v.writeAccountField(ctx, id, vaultKeyField, data)
+}
+
+func (v *Vault) ListCertificates(ctx context.Context) ([]string, error) {
+	return v.list(ctx, certificatesFolderName)
+}
+
+func (v *Vault) ReadCertificateFile(ctx context.Context, name, ext string) ([]byte, error) {
+	secret, _, err := v.read(ctx, certificatesFolderName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return getField(secret, fieldName(ext), name+ext)
+}
+
+func (v *Vault) WriteCertificateFile(ctx context.Context, name, ext string, data []byte) error {
+	return v.update(ctx, []string{certificatesFolderName, name}, func(secret map[string]string) {
+		setField(secret, fieldName(ext), data)
+	})
+}
+
+func (v *Vault) DeleteCertificateFile(ctx context.Context, name, ext string) error {
+	secret, version, err := v.read(ctx, certificatesFolderName, name)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	delete(secret, fieldName(ext))
+	delete(secret, fieldName(ext)+base64Suffix)
+
+	if len(secret) == 0 {
+		return v.delete(ctx, certificatesFolderName, name)
+	}
+
+	return v.write(ctx, secret, version, certificatesFolderName, name)
+}
+
+// ArchiveCertificate moves the secret of a certificate to the archives.
+// The name of the archive is prefixed by the date of the archiving (Unix time), as the filesystem storage.
+func (v *Vault) ArchiveCertificate(ctx context.Context, name string) error {
+	secret, _, err := v.read(ctx, certificatesFolderName, name)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	date := strconv.FormatInt(time.Now().Unix(), 10)
+
+	err = v.write(ctx, secret, 0, archivesFolderName, date+"."+name)
+	if err != nil {
+		return err
+	}
+
+	return v.delete(ctx, certificatesFolderName, name)
+}
+
+func (v *Vault) DeleteCertificate(ctx context.Context, name string) error {
+	return v.delete(ctx, certificatesFolderName, name)
+}
+
+// ListArchivedCertificates returns the names of the archives ("<Unix time of the archiving>.<name>").
+func (v *Vault) ListArchivedCertificates(ctx context.Context) ([]string, error) {
+	return v.list(ctx, archivesFolderName)
+}
+
+func (v *Vault) ReadArchivedCertificateFile(ctx context.Context, archiveName, ext string) ([]byte, error) {
+	secret, _, err := v.read(ctx, archivesFolderName, archiveName)
+	if err != nil {
+		return nil, err
+	}
+
+	return getField(secret, fieldName(ext), archiveName+ext)
+}
+
+func (v *Vault) DeleteArchivedCertificate(ctx context.Context, archiveName string) error {
+	return v.delete(ctx, archivesFolderName, archiveName)
+}
+
+func (v *Vault) readAccountField(ctx context.Context, id AccountID, field string) ([]byte, error) {
+	segments, err := accountSegments(id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, _, err := v.read(ctx, segments...)
+	if err != nil {
+		return nil, err
+	}
+
+	return getField(secret, field, strings.Join(segments, "/"))
+}
+
+func (v *Vault) writeAccountField(ctx context.Context, id AccountID, field string, data []byte) error {
+	segments, err := accountSegments(id)
+	if err != nil {
+		return err
+	}
+
+	return v.update(ctx, segments, func(secret map[string]string) {
+		setField(secret, field, data)
+	})
+}
+
+// update reads, modifies, and writes a secret.
+// The write fails if the secret has been modified in the meantime (check-and-set).
+func (v *Vault) update(ctx context.Context, segments []string, fn func(secret map[string]string)) error {
+	secret, version, err := v.read(ctx, segments...)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	if secret == nil {
+		secret = map[string]string{}
+	}
+
+	fn(secret)
+
+	return v.write(ctx, secret, version, segments...)
+}
+
+// read reads the current version of a secret.
+func (v *Vault) read(ctx context.Context, segments ...string) (map[string]string, int, error) {
+	var result struct {
+		Data struct {
+			Data     map[string]string `json:"data"`
+			Metadata struct {
+				Version int `json:"version"`
+			} `json:"metadata"`
+		} `json:"data"`
+	}
+
+	err := v.do(ctx, http.MethodGet, v.endpoint("data", segments...), nil, &result)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return result.Data.Data, result.Data.Metadata.Version, nil
+}
+
+// write writes a new version of a secret, only if the current version is the expected version (0 for a new secret).
+func (v *Vault) write(ctx context.Context, secret map[string]string, version int, segments ...string) error {
+	body := map[string]any{
+		"options": map[string]any{"cas": version},
+		"data":    secret,
+	}
+
+	return v.do(ctx, http.MethodPost, v.endpoint("data", segments...), body, nil)
+}
+
+// delete deletes all the versions of a secret.
+func (v *Vault) delete(ctx context.Context, segments ...string) error {
+	err := v.do(ctx, http.MethodDelete, v.endpoint("metadata", segments...), nil, nil)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// list lists the secrets of a folder.
+func (v *Vault) list(ctx context.Context, segments ...string) ([]string, error) {
+	var result struct {
+		Data struct {
+			Keys []string `json:"keys"`
+		} `json:"data"`
+	}
+
+	err := v.do(ctx, "LIST", v.endpoint("metadata", segments...), nil, &result)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+
+	for _, key := range result.Data.Keys {
+		// Skips the sub-folders.
+		if strings.HasSuffix(key, "/") {
+			continue
+		}
+
+		names = append(names, key)
+	}
+
+	return names, nil
+}
+
+func (v *Vault) endpoint(kind string, segments ...string) *url.URL {
+	elements := []string{"v1", v.mount, kind}
+
+	if v.path != "" {
+		elements = append(elements, strings.Split(v.path, "/")...)
+	}
+
+	elements = append(elements, segments...)
+
+	return v.baseURL.JoinPath(elements...)
+}
+
+func (v *Vault) do(ctx context.Context, method string, endpoint *url.URL, payload, result any) error {
+	body := new(bytes.Buffer)
+
+	if payload != nil {
+		err := json.NewEncoder(body).Encode(payload)
+		if err != nil {
+			return fmt.Errorf("vault: create request JSON body: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+	if err != nil {
+		return fmt.Errorf("vault: unable to create request: %w", err)
+	}
+
+	req.Header.Set("X-Vault-Token", v.token)
+
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault: %s %s: %w", method, endpoint.Path, err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("vault: %s %s: read response: %w", method, endpoint.Path, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("vault: %s: %w", endpoint.Path, ErrNotFound)
+	}
+
+	if resp.StatusCode/100 != 2 {
+		var errResp struct {
+			Errors []string `json:"errors"`
+		}
+
+		_ = json.Unmarshal(raw, &errResp)
+
+		return fmt.Errorf("vault: %s %s: %d: %s", method, endpoint.Path, resp.StatusCode, strings.Join(errResp.Errors, ", "))
+	}
+
+	if result == nil || len(raw) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("vault: %s %s: unmarshal response: %w", method, endpoint.Path, err)
+	}
+
+	return nil
+}
+
+// accountSegments returns the path of the secret of an account.
+func accountSegments(id AccountID) ([]string, error) {
+	serverURL, err := url.Parse(id.Server)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{accountsFolderName, strings.ReplaceAll(serverURL.Host, ":", "_"), id.UserID}, nil
+}
+
+// fieldName returns the name of the field of a file: the extension without the leading dot.
+func fieldName(ext string) string {
+	return strings.TrimPrefix(ext, ".")
+}
+
+func getField(secret map[string]string, field, desc string) ([]byte, error) {
+	if value, ok := secret[field]; ok {
+		return []byte(value), nil
+	}
+
+	if value, ok := secret[field+base64Suffix]; ok {
+		return base64.StdEncoding.DecodeString(value)
+	}
+
+	return nil, fmt.Errorf("vault: %s: %w", desc, ErrNotFound)
+}
+
+func setField(secret map[string]string, field string, data []byte) {
+	// The JSON strings only contain valid UTF-8.
+	if utf8.Valid(data) {
+		secret[field] = string(data)
+		delete(secret, field+base64Suffix)
+
+		return
+	}
+
+	secret[field+base64Suffix] = base64.StdEncoding.EncodeToString(data)
+	delete(secret, field)
+}
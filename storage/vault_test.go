@@ -0,0 +1,337 @@
+package storage
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault an in-memory KV version 2 secrets engine mounted on "secret".
+type fakeVault struct {
+	mu       sync.Mutex
+	secrets  map[string]map[string]string
+	versions map[string]int
+}
+
+func newFakeVault(t *testing.T) (*fakeVault, *Vault) {
+	t.Helper()
+
+	fake := &fakeVault{
+		secrets:  map[string]map[string]string{},
+		versions: map[string]int{},
+	}
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	backend, err := NewVault(VaultConfig{
+		Address:    server.URL,
+		Token:      "secret-token",
+		Path:       "secret/lego",
+		HTTPClient: server.Client(),
+	})
+	require.NoError(t, err)
+
+	return fake, backend
+}
+
+func (f *fakeVault) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if req.Header.Get("X-Vault-Token") != "secret-token" {
+		http.Error(rw, `{"errors":["permission denied"]}`, http.StatusForbidden)
+		return
+	}
+
+	kind, name, ok := strings.Cut(strings.TrimPrefix(req.URL.Path, "/v1/secret/"), "/")
+	if !ok {
+		http.Error(rw, `{"errors":[]}`, http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case kind == "data" && req.Method == http.MethodGet:
+		secret, exists := f.secrets[name]
+		if !exists {
+			http.Error(rw, `{"errors":[]}`, http.StatusNotFound)
+			return
+		}
+
+		_ = json.NewEncoder(rw).Encode(map[string]any{
+			"data": map[string]any{
+				"data":     secret,
+				"metadata": map[string]any{"version": f.versions[name]},
+			},
+		})
+
+	case kind == "data" && req.Method == http.MethodPost:
+		var body struct {
+			Options struct {
+				CAS int `json:"cas"`
+			} `json:"options"`
+			Data map[string]string `json:"data"`
+		}
+
+		err := json.NewDecoder(req.Body).Decode(&body)
+		if err != nil {
+			http.Error(rw, `{"errors":["invalid body"]}`, http.StatusBadRequest)
+			return
+		}
+
+		if body.Options.CAS != f.versions[name] {
+			http.Error(rw, `{"errors":["check-and-set parameter did not match the current version"]}`, http.StatusBadRequest)
+			return
+		}
+
+		f.secrets[name] = body.Data
+		f.versions[name]++
+
+		rw.WriteHeader(http.StatusOK)
+
+	case kind == "metadata" && req.Method == http.MethodDelete:
+		delete(f.secrets, name)
+		delete(f.versions, name)
+
+		rw.WriteHeader(http.StatusNoContent)
+
+	case kind == "metadata" && req.Method == "LIST":
+		var keys []string
+
+		for key := range f.secrets {
+			if path.Dir(key) == name {
+				keys = append(keys, path.Base(key))
+			}
+		}
+
+		if len(keys) == 0 {
+			http.Error(rw, `{"errors":[]}`, http.StatusNotFound)
+			return
+		}
+
+		slices.Sort(keys)
+
+		_ = json.NewEncoder(rw).Encode(map[string]any{"data": map[string]any{"keys": keys}})
+
+	default:
+		http.Error(rw, `{"errors":["unsupported"]}`, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestNewVault(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		config   VaultConfig
+		expected string
+	}{
+		{
+			desc:     "missing address",
+			config:   VaultConfig{Token: "token", Path: "secret/lego"},
+			expected: "vault: missing address",
+		},
+		{
+			desc:     "missing token",
+			config:   VaultConfig{Address: "https://vault.example.com", Path: "secret/lego"},
+			expected: "vault: missing token",
+		},
+		{
+			desc:     "missing path",
+			config:   VaultConfig{Address: "https://vault.example.com", Token: "token", Path: "/"},
+			expected: "vault: missing path (<mount>/<path>)",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, err := NewVault(test.config)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestVault_account(t *testing.T) {
+	fake, backend := newFakeVault(t)
+
+	id := AccountID{Server: "https://localhost:14000/dir", UserID: "foo@example.com"}
+
+	_, err := backend.ReadAccount(t.Context(), id)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = backend.WriteAccountKey(t.Context(), id, []byte("key"))
+	require.NoError(t, err)
+
+	// The account key exists, not the account.
+	_, err = backend.ReadAccount(t.Context(), id)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = backend.WriteAccount(t.Context(), id, []byte(`{"email":"foo@example.com"}`))
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		"account": `{"email":"foo@example.com"}`,
+		"key":     "key",
+	}
+
+	assert.Equal(t, expected, fake.secrets["lego/accounts/localhost_14000/foo@example.com"])
+
+	data, err := backend.ReadAccount(t.Context(), id)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"email":"foo@example.com"}`, string(data))
+
+	key, err := backend.ReadAccountKey(t.Context(), id)
+	require.NoError(t, err)
+
+	assert.Equal(t, "key", string(key))
+}
+
+func TestVault_certificates(t *testing.T) {
+	fake, backend := newFakeVault(t)
+
+	names, err := backend.ListCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Empty(t, names)
+
+	for _, name := range []string{"example.com", "example.org"} {
+		for _, ext := range []string{ExtCertificate, ExtIssuer, ExtKey, ExtResource} {
+			err = backend.WriteCertificateFile(t.Context(), name, ext, []byte(name+ext))
+			require.NoError(t, err)
+		}
+	}
+
+	// Binary data (PKCS#12).
+	err = backend.WriteCertificateFile(t.Context(), "example.com", ".pfx", []byte{0xff, 0xfe, 0x00})
+	require.NoError(t, err)
+
+	assert.Equal(t, "//4A", fake.secrets["lego/certificates/example.com"]["pfx.b64"])
+
+	names, err = backend.ListCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com", "example.org"}, names)
+
+	data, err := backend.ReadCertificateFile(t.Context(), "example.com", ExtIssuer)
+	require.NoError(t, err)
+
+	assert.Equal(t, "example.com"+ExtIssuer, string(data))
+
+	data, err = backend.ReadCertificateFile(t.Context(), "example.com", ".pfx")
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte{0xff, 0xfe, 0x00}, data)
+
+	err = backend.DeleteCertificateFile(t.Context(), "example.com", ExtResource)
+	require.NoError(t, err)
+
+	// A missing file is not an error.
+	err = backend.DeleteCertificateFile(t.Context(), "example.com", ExtResource)
+	require.NoError(t, err)
+
+	_, err = backend.ReadCertificateFile(t.Context(), "example.com", ExtResource)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	_, err = backend.ReadCertificateFile(t.Context(), "example.net", ExtCertificate)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = backend.DeleteCertificate(t.Context(), "example.com")
+	require.NoError(t, err)
+
+	names, err = backend.ListCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.org"}, names)
+}
+
+func TestVault_ArchiveCertificate(t *testing.T) {
+	fake, backend := newFakeVault(t)
+
+	for _, ext := range []string{ExtCertificate, ExtIssuer, ExtKey} {
+		err := backend.WriteCertificateFile(t.Context(), "example.com", ext, []byte("test"))
+		require.NoError(t, err)
+	}
+
+	err := backend.ArchiveCertificate(t.Context(), "example.com")
+	require.NoError(t, err)
+
+	names, err := backend.ListCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Empty(t, names)
+
+	var archives []string
+
+	for name, secret := range fake.secrets {
+		if strings.HasPrefix(name, "lego/archives/") {
+			archives = append(archives, name)
+
+			assert.Equal(t, map[string]string{"crt": "test", "issuer.crt": "test", "key": "test"}, secret)
+		}
+	}
+
+	require.Len(t, archives, 1)
+
+	assert.Regexp(t, `^lego/archives/\d+\.example\.com$`, archives[0])
+
+	// Nothing to archive.
+	err = backend.ArchiveCertificate(t.Context(), "example.com")
+	require.NoError(t, err)
+}
+
+func TestVault_archives(t *testing.T) {
+	_, backend := newFakeVault(t)
+
+	archiveNames, err := backend.ListArchivedCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Empty(t, archiveNames)
+
+	err = backend.WriteCertificateFile(t.Context(), "example.com", ExtCertificate, []byte("cert"))
+	require.NoError(t, err)
+
+	err = backend.ArchiveCertificate(t.Context(), "example.com")
+	require.NoError(t, err)
+
+	archiveNames, err = backend.ListArchivedCertificates(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, archiveNames, 1)
+	assert.Regexp(t, `^\d+\.example\.com$`, archiveNames[0])
+
+	data, err := backend.ReadArchivedCertificateFile(t.Context(), archiveNames[0], ExtCertificate)
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("cert"), data)
+
+	_, err = backend.ReadArchivedCertificateFile(t.Context(), archiveNames[0], ExtKey)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	err = backend.DeleteArchivedCertificate(t.Context(), archiveNames[0])
+	require.NoError(t, err)
+
+	archiveNames, err = backend.ListArchivedCertificates(t.Context())
+	require.NoError(t, err)
+
+	assert.Empty(t, archiveNames)
+
+	// Already deleted.
+	err = backend.DeleteArchivedCertificate(t.Context(), "1700000000.example.com")
+	require.NoError(t, err)
+}
+
+func TestVault_permissionDenied(t *testing.T) {
+	_, backend := newFakeVault(t)
+
+	backend.token = "invalid"
+
+	_, err := backend.ReadAccount(t.Context(), AccountID{Server: "https://localhost:14000/dir", UserID: "foo@example.com"})
+	require.EqualError(t, err, "vault: GET /v1/secret/data/lego/accounts/localhost_14000/foo@example.com: 403: permission denied")
+}
@@ -0,0 +1,102 @@
+// Package tracing traces the ACME operations (orders, challenges, DNS propagation, ACME requests) with OpenTelemetry.
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// ScopeName the instrumentation scope of the tracer.
+const ScopeName = "github.com/go-acme/lego/v4"
+
+// The names of the spans.
+const (
+	SpanObtain       = "lego.Obtain"
+	SpanObtainForCSR = "lego.ObtainForCSR"
+	SpanRenew        = "lego.Renew"
+	SpanSolve        = "lego.Solve"
+	SpanPresent      = "lego.challenge.Present"
+	SpanCleanUp      = "lego.challenge.CleanUp"
+	SpanPropagation  = "lego.dns.Propagation"
+	SpanFinalize     = "lego.Finalize"
+)
+
+// The attributes of the spans.
+const (
+	AttrDomain        = attribute.Key("lego.domain")
+	AttrDomains       = attribute.Key("lego.domains")
+	AttrChallengeType = attribute.Key("lego.challenge.type")
+	AttrDNSProvider   = attribute.Key("lego.dns.provider")
+	AttrOrder         = attribute.Key("lego.order")
+)
+
+// Tracer returns the tracer of the provider, or a tracer that does nothing if the provider is nil.
+func Tracer(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		return noop.NewTracerProvider().Tracer(ScopeName)
+	}
+
+	return provider.Tracer(ScopeName)
+}
+
+// WithChallenge returns the attributes of a span related to a challenge.
+func WithChallenge(domain, chlgType string) trace.SpanStartOption {
+	return trace.WithAttributes(AttrDomain.String(domain), AttrChallengeType.String(chlgType))
+}
+
+// End records the error, if any, and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// Transport creates a span for each HTTP request.
+type Transport struct {
+	tracer trace.Tracer
+	next   http.RoundTripper
+}
+
+// NewTransport creates a Transport.
+// If next is nil, http.DefaultTransport is used.
+func NewTransport(tracer trace.Tracer, next http.RoundTripper) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Transport{tracer: tracer, next: next}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", req.URL.String()),
+			attribute.String("server.address", req.URL.Hostname()),
+		),
+	)
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		End(span, err)
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	span.End()
+
+	return resp, err
+}
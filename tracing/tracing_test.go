@@ -0,0 +1,124 @@
+package tracing
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupTracer(t *testing.T) (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { _ = provider.Shutdown(t.Context()) })
+
+	return provider, recorder
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPost {
+			rw.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	provider, recorder := setupTracer(t)
+
+	tracer := Tracer(provider)
+
+	ctx, parent := tracer.Start(t.Context(), SpanObtain)
+
+	client := &http.Client{Transport: NewTransport(tracer, nil)}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, server.URL+"/nonce", http.NoBody)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/finalize", strings.NewReader("{}"))
+	require.NoError(t, err)
+
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close()
+
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	assert.Equal(t, http.MethodHead, spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("url.full", server.URL+"/nonce"))
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	assert.Equal(t, http.MethodPost, spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), attribute.Int("http.response.status_code", http.StatusBadRequest))
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+
+	assert.Equal(t, SpanObtain, spans[2].Name())
+}
+
+func TestTransport_error(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	provider, recorder := setupTracer(t)
+
+	client := &http.Client{Transport: NewTransport(Tracer(provider), nil)}
+
+	_, err := client.Get(server.URL)
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Len(t, spans[0].Events(), 1)
+}
+
+func TestEnd(t *testing.T) {
+	provider, recorder := setupTracer(t)
+
+	tracer := Tracer(provider)
+
+	_, span := tracer.Start(t.Context(), SpanPresent, WithChallenge("example.com", "http-01"))
+	End(span, nil)
+
+	_, span = tracer.Start(t.Context(), SpanCleanUp, WithChallenge("example.com", "http-01"))
+	End(span, errors.New("oops"))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	expected := []attribute.KeyValue{AttrDomain.String("example.com"), AttrChallengeType.String("http-01")}
+
+	assert.Equal(t, expected, spans[0].Attributes())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	assert.Equal(t, sdktrace.Status{Code: codes.Error, Description: "oops"}, spans[1].Status())
+}
+
+func TestTracer_nil(t *testing.T) {
+	_, span := Tracer(nil).Start(t.Context(), SpanObtain)
+	defer span.End()
+
+	assert.False(t, span.IsRecording())
+}